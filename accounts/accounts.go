@@ -155,7 +155,7 @@ func (aS *AccountS) accountDebit(acnt *utils.AccountProfile, usage *decimal.Big,
 	blcsWithWeight.Sort()
 	var blncOpers []balanceOperator
 	if blncOpers, err = newBalanceOperators(blcsWithWeight.Balances(), aS.fltrS, aS.connMgr,
-		aS.cfg.AccountSCfg().AttributeSConns, aS.cfg.AccountSCfg().RateSConns); err != nil {
+		aS.cfg.AccountSCfg().AttributeSConns, aS.cfg.AccountSCfg().RateSConns, aS.cfg, aS.dm); err != nil {
 		return
 	}
 
@@ -218,12 +218,13 @@ func (aS *AccountS) accountsDebit(acnts []*utils.AccountProfileWithWeight,
 			ec = utils.NewEventCharges()
 		}
 		if usage.Cmp(decimal.New(0, 0)) == 0 {
-			return // no more debits
+			break // no more debits
 		}
 		acntBkps[i] = acnt.AccountProfile.AccountBalancesBackup()
+		acntEv := volumeCountedEvent(cgrEv, acnt.AccountProfile.ID)
 		var ecDbt *utils.EventCharges
 		if ecDbt, err = aS.accountDebit(acnt.AccountProfile,
-			new(decimal.Big).Copy(usage), cgrEv, concretes); err != nil {
+			new(decimal.Big).Copy(usage), acntEv, concretes); err != nil {
 			if store {
 				restoreAccounts(aS.dm, acnts, acntBkps)
 			}
@@ -243,10 +244,44 @@ func (aS *AccountS) accountsDebit(acnts []*utils.AccountProfileWithWeight,
 		}
 		usage = utils.SubstractBig(usage, used)
 		ec.Merge(ecDbt)
+		if store && !concretes {
+			if errVol := updateRateVolumeCounter(aS.connMgr, aS.cfg.AccountSCfg().RateSConns,
+				acntEv, used); errVol != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> failed updating the volume counter of account <%s>, error: %s",
+						utils.AccountS, acnt.AccountProfile.TenantID(), errVol.Error()))
+			}
+		}
+	}
+	if ec == nil || ec.Concretes == nil ||
+		!aS.taxesEnabled(cgrEv) {
+		return
+	}
+	// apply the taxes on the charged concretes, including the ones paying the abstracts
+	if ec.Taxes, err = engine.TaxesForEvent(aS.cfg, aS.dm, aS.fltrS,
+		cgrEv, ec.Concretes); err != nil {
+		if err != utils.ErrNotFound {
+			if store {
+				restoreAccounts(aS.dm, acnts, acntBkps)
+			}
+			return
+		}
+		err = nil // no taxes for this event
 	}
 	return
 }
 
+// taxesEnabled checks if the taxes should be applied for the event
+// the *accountsTaxes option overwrites the taxes configuration
+func (aS *AccountS) taxesEnabled(cgrEv *utils.CGREvent) bool {
+	if val, has := cgrEv.Opts[utils.OptsAccountsTaxes]; has {
+		if taxes, err := utils.IfaceAsBool(val); err == nil {
+			return taxes
+		}
+	}
+	return aS.cfg.TaxSCfg().Enabled
+}
+
 // V1AccountProfilesForEvent returns the matching AccountProfiles for Event
 func (aS *AccountS) V1AccountProfilesForEvent(args *utils.ArgsAccountsForEvent, aps *[]*utils.AccountProfile) (err error) {
 	var acnts utils.AccountProfilesWithWeight
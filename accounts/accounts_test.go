@@ -664,6 +664,7 @@ func TestV1DebitAbstracts(t *testing.T) {
 
 	exEvCh := utils.ExtEventCharges{
 		Abstracts: utils.Float64Pointer(float64(27 * time.Second)),
+		Concretes: utils.Float64Pointer(27),
 	}
 	if err := accnts.V1DebitAbstracts(args, &reply); err != nil {
 		t.Error(err)
@@ -1185,3 +1186,79 @@ func TestV1ActionRemoveBalance(t *testing.T) {
 		t.Error("Unexpected status reply", reply)
 	}
 }
+
+func TestAccountsDebitTaxes(t *testing.T) {
+	engine.Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	fltr := engine.NewFilterS(cfg, nil, dm)
+	accnts := NewAccountS(cfg, fltr, nil, dm)
+
+	if err := dm.SetTaxProfile(&engine.TaxProfile{
+		Tenant:       "cgrates.org",
+		ID:           "TAX_VAT",
+		FilterIDs:    []string{"*string:~*req.Account:1004"},
+		Jurisdiction: "RO",
+		TaxType:      "VAT",
+		Rate:         utils.NewDecimal(19, 2),
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	accntsPrf := []*utils.AccountProfileWithWeight{
+		{
+			AccountProfile: &utils.AccountProfile{
+				Tenant:    "cgrates.org",
+				ID:        "TestAccountsDebitTaxes",
+				FilterIDs: []string{"*string:~*req.Account:1004"},
+				Balances: map[string]*utils.Balance{
+					"ConcreteBalance1": {
+						ID:    "ConcreteBalance1",
+						Type:  utils.MetaConcrete,
+						Units: utils.NewDecimal(int64(time.Minute), 0),
+						CostIncrements: []*utils.CostIncrement{
+							{
+								Increment:    utils.NewDecimal(int64(time.Second), 0),
+								FixedFee:     utils.NewDecimal(0, 0),
+								RecurrentFee: utils.NewDecimal(1, 0),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cgrEvent := &utils.CGREvent{
+		ID:     "TestAccountsDebitTaxes",
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.AccountField: "1004",
+			utils.Usage:        "10s",
+		},
+		Opts: map[string]interface{}{},
+	}
+	// taxes are disabled by default
+	if ec, err := accnts.accountsDebit(accntsPrf, cgrEvent, true, false); err != nil {
+		t.Fatal(err)
+	} else if len(ec.Taxes) != 0 {
+		t.Errorf("unexpected taxes: %s", utils.ToJSON(ec.Taxes))
+	}
+
+	cgrEvent.Opts[utils.OptsAccountsTaxes] = true
+	if ec, err := accnts.accountsDebit(accntsPrf, cgrEvent, true, false); err != nil {
+		t.Fatal(err)
+	} else if len(ec.Taxes) != 1 {
+		t.Errorf("expecting one tax line, received: %s", utils.ToJSON(ec.Taxes))
+	} else if expAmount := utils.MultiplyDecimal(ec.Concretes, utils.NewDecimal(19, 2)); ec.Taxes[0].Amount.Compare(expAmount) != 0 {
+		t.Errorf("expecting tax amount %s, received %s", expAmount, ec.Taxes[0].Amount)
+	}
+
+	// the concretes charged for the abstracts are taxed as well
+	if ec, err := accnts.accountsDebit(accntsPrf, cgrEvent, false, false); err != nil {
+		t.Fatal(err)
+	} else if len(ec.Taxes) != 1 {
+		t.Errorf("expecting one tax line, received: %s", utils.ToJSON(ec.Taxes))
+	} else if expAmount := utils.MultiplyDecimal(ec.Concretes, utils.NewDecimal(19, 2)); ec.Taxes[0].Amount.Compare(expAmount) != 0 {
+		t.Errorf("expecting tax amount %s, received %s", expAmount, ec.Taxes[0].Amount)
+	}
+}
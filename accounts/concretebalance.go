@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package accounts
 
 import (
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/ericlagergren/decimal"
@@ -46,8 +47,8 @@ func restoreUnitsFromClones(cBs []*concreteBalance, clnedUnts []*utils.Decimal)
 // newConcreteBalance constructs a concreteBalanceOperator
 func newConcreteBalanceOperator(blnCfg *utils.Balance,
 	fltrS *engine.FilterS, connMgr *engine.ConnManager,
-	attrSConns, rateSConns []string) balanceOperator {
-	return &concreteBalance{blnCfg, fltrS, connMgr, attrSConns, rateSConns}
+	attrSConns, rateSConns []string, cfg *config.CGRConfig, dm *engine.DataManager) balanceOperator {
+	return &concreteBalance{blnCfg, fltrS, connMgr, attrSConns, rateSConns, cfg, dm}
 }
 
 // concreteBalance is the operator for *concrete balance type
@@ -57,6 +58,17 @@ type concreteBalance struct {
 	connMgr *engine.ConnManager
 	attrSConns,
 	rateSConns []string
+	cfg *config.CGRConfig   // needed for exchange rates
+	dm  *engine.DataManager // needed for exchange rates
+}
+
+// currency returns the currency of the balance units, empty if not configured
+func (cB *concreteBalance) currency() string {
+	crncy, has := cB.blnCfg.Opts[utils.MetaBalanceCurrency]
+	if !has {
+		return utils.EmptyString
+	}
+	return utils.IfaceAsString(crncy)
 }
 
 // debitAbstracts implements the balanceOperator interface
@@ -21,6 +21,7 @@ package accounts
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 
@@ -33,7 +34,7 @@ import (
 // newAccountBalances constructs accountBalances
 func newBalanceOperators(blnCfgs []*utils.Balance,
 	fltrS *engine.FilterS, connMgr *engine.ConnManager,
-	attrSConns, rateSConns []string) (blncOpers []balanceOperator, err error) {
+	attrSConns, rateSConns []string, cfg *config.CGRConfig, dm *engine.DataManager) (blncOpers []balanceOperator, err error) {
 
 	blncOpers = make([]balanceOperator, len(blnCfgs))
 	var cncrtBlncs []*concreteBalance
@@ -42,7 +43,7 @@ func newBalanceOperators(blnCfgs []*utils.Balance,
 			continue
 		}
 		blncOpers[i] = newConcreteBalanceOperator(blnCfg,
-			fltrS, connMgr, attrSConns, rateSConns)
+			fltrS, connMgr, attrSConns, rateSConns, cfg, dm)
 		cncrtBlncs = append(cncrtBlncs, blncOpers[i].(*concreteBalance))
 	}
 
@@ -51,7 +52,7 @@ func newBalanceOperators(blnCfgs []*utils.Balance,
 			continue
 		}
 		if blncOpers[i], err = newBalanceOperator(blnCfg, cncrtBlncs, fltrS, connMgr,
-			attrSConns, rateSConns); err != nil {
+			attrSConns, rateSConns, cfg, dm); err != nil {
 			return
 		}
 	}
@@ -63,12 +64,12 @@ func newBalanceOperators(blnCfgs []*utils.Balance,
 // cncrtBlncs are needed for abstract balance debits
 func newBalanceOperator(blncCfg *utils.Balance, cncrtBlncs []*concreteBalance,
 	fltrS *engine.FilterS, connMgr *engine.ConnManager,
-	attrSConns, rateSConns []string) (bP balanceOperator, err error) {
+	attrSConns, rateSConns []string, cfg *config.CGRConfig, dm *engine.DataManager) (bP balanceOperator, err error) {
 	switch blncCfg.Type {
 	default:
 		return nil, fmt.Errorf("unsupported balance type: <%s>", blncCfg.Type)
 	case utils.MetaConcrete:
-		return newConcreteBalanceOperator(blncCfg, fltrS, connMgr, attrSConns, rateSConns), nil
+		return newConcreteBalanceOperator(blncCfg, fltrS, connMgr, attrSConns, rateSConns, cfg, dm), nil
 	case utils.MetaAbstract:
 		return newAbstractBalanceOperator(blncCfg, cncrtBlncs, fltrS, connMgr, attrSConns, rateSConns), nil
 	}
@@ -131,6 +132,39 @@ func rateSCostForEvent(connMgr *engine.ConnManager, cgrEv *utils.CGREvent,
 	return &tmpReply, nil
 }
 
+// volumeCountedEvent returns a copy of the event rated by RateS on the volume counter of the account
+// the counter is not updated while debiting since the same usage can be queried more than once
+func volumeCountedEvent(cgrEv *utils.CGREvent, acntID string) (acntEv *utils.CGREvent) {
+	acntEv = cgrEv.Clone()
+	if _, has := acntEv.Opts[utils.OptsRatesVolumeAccount]; !has {
+		acntEv.Opts[utils.OptsRatesVolumeAccount] = acntID
+	}
+	delete(acntEv.Opts, utils.OptsRatesVolumeUpdate)
+	return
+}
+
+// updateRateVolumeCounter adds the usage debited out of the account to its volume counter within RateS
+func updateRateVolumeCounter(connMgr *engine.ConnManager, rateSConns []string,
+	acntEv *utils.CGREvent, usage *decimal.Big) (err error) {
+	if len(rateSConns) == 0 || usage == nil ||
+		usage.Cmp(decimal.New(0, 0)) == 0 {
+		return
+	}
+	usgInt, ok := usage.Int64()
+	if !ok {
+		return fmt.Errorf("cannot convert usage <%s> to int64", usage)
+	}
+	ev := acntEv.Clone()
+	ev.Opts[utils.OptsRatesUsage] = time.Duration(usgInt)
+	var reply string
+	if err = connMgr.Call(rateSConns, nil, utils.RateSv1UpdateVolumeCounter,
+		&utils.ArgsCostForEvent{CGREvent: ev}, &reply); err != nil &&
+		err.Error() == utils.ErrNotFound.Error() { // no RateProfile matching the event
+		err = nil
+	}
+	return
+}
+
 // costIncrement computes the costIncrement for the event
 func costIncrement(cfgCostIncrmts []*utils.CostIncrement,
 	fltrS *engine.FilterS, tnt string, ev utils.DataProvider) (costIcrm *utils.CostIncrement, err error) {
@@ -189,11 +223,33 @@ func balanceLimit(optsCfg map[string]interface{}) (bL *utils.Decimal, err error)
 	return
 }
 
+// exchangeRate returns the rate converting the costs into the currency of the balance
+// the profiles configured in AccountS are queried in order, first one defining the rate wins
+func exchangeRate(cfg *config.CGRConfig, dm *engine.DataManager, tnt, fromCrncy, toCrncy string,
+	at time.Time) (rate *utils.Decimal, err error) {
+	for _, erpID := range cfg.AccountSCfg().ExchangeRateProfileIDs {
+		var erp *utils.ExchangeRateProfile
+		if erp, err = dm.GetExchangeRateProfile(tnt, erpID, true, true,
+			utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		if rate, err = erp.ExchangeRateAt(fromCrncy, toCrncy, at); err != utils.ErrNotFound {
+			return
+		}
+	}
+	return nil, fmt.Errorf("no exchange rate from <%s> to <%s>", fromCrncy, toCrncy)
+}
+
 // debitAbstractsFromConcretes attempts to debit the usage out of concrete balances
 // returns utils.ErrInsufficientCredit if complete usage cannot be debited
 func debitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decimal.Big,
 	costIcrm *utils.CostIncrement, cgrEv *utils.CGREvent,
 	connMgr *engine.ConnManager, rateSConns, rpIDs []string) (ec *utils.EventCharges, err error) {
+	var costCrncy string // known only for costs coming from RateS
 	if costIcrm.RecurrentFee.Cmp(decimal.New(-1, 0)) == 0 &&
 		costIcrm.FixedFee == nil {
 		var rplyCost *engine.RateProfileCost
@@ -203,6 +259,7 @@ func debitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decimal.B
 		}
 		costIcrm = costIcrm.Clone() // so we don't modify the original
 		costIcrm.FixedFee = utils.NewDecimalFromFloat64(rplyCost.Cost)
+		costCrncy = rplyCost.Currency
 	}
 	var tCost *decimal.Big
 	if costIcrm.FixedFee != nil {
@@ -221,16 +278,43 @@ func debitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decimal.B
 	}
 	clnedUnts := cloneUnitsFromConcretes(cncrtBlncs)
 	for i, cB := range cncrtBlncs {
+		dbtCost := tCost
+		var xRate *utils.Decimal
+		if blncCrncy := cB.currency(); costCrncy != utils.EmptyString &&
+			blncCrncy != utils.EmptyString && blncCrncy != costCrncy {
+			at := time.Now()
+			if cgrEv.Time != nil {
+				at = *cgrEv.Time
+			}
+			if xRate, err = exchangeRate(cB.cfg, cB.dm, cgrEv.Tenant, costCrncy, blncCrncy, at); err != nil {
+				restoreUnitsFromClones(cncrtBlncs, clnedUnts)
+				return nil, err
+			}
+			dbtCost = utils.MultiplyBig(tCost, xRate.Big)
+		}
 		var ecCncrt *utils.EventCharges
-		if ecCncrt, err = cB.debitConcretes(tCost, cgrEv); err != nil {
+		if ecCncrt, err = cB.debitConcretes(dbtCost, cgrEv); err != nil {
 			restoreUnitsFromClones(cncrtBlncs, clnedUnts)
 			return nil, err
 		}
 		if i == 0 {
 			ec = utils.NewEventCharges()
 		}
+		paid := ecCncrt.Concretes.Big
+		if xRate != nil { // convert back into the currency of the cost
+			paid = utils.DivideBig(paid, xRate.Big)
+			ecCncrt.ExchangeRates = append(ecCncrt.ExchangeRates, &utils.ChargedExchangeRate{
+				BalanceID:    cB.blnCfg.ID,
+				FromCurrency: costCrncy,
+				ToCurrency:   cB.currency(),
+				Rate:         xRate,
+				Cost:         &utils.Decimal{Big: paid},
+				Debited:      ecCncrt.Concretes,
+			})
+			ecCncrt.Concretes = &utils.Decimal{Big: paid} // merged in the currency of the cost
+		}
 		ec.Merge(ecCncrt)
-		tCost = utils.SubstractBig(tCost, ecCncrt.Concretes.Big)
+		tCost = utils.SubstractBig(tCost, paid)
 		if tCost.Cmp(decimal.New(0, 0)) <= 0 {
 			return // have debited all, total is smaller or equal to 0
 		}
@@ -264,6 +348,8 @@ func maxDebitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decima
 	origConcrtUnts := cloneUnitsFromConcretes(cncrtBlncs) // so we can revert on errors
 	paidConcrtUnts := origConcrtUnts                      // so we can revert when higher usages are not possible
 	var usagePaid, usageDenied *decimal.Big
+	var xRatesPaid []*utils.ChargedExchangeRate // exchange rates applied for the usage paid
+	var cncrtsPaid *utils.Decimal               // concretes charged for the usage paid
 	maxItr := config.CgrConfig().AccountSCfg().MaxIterations
 	for i := 0; i <= maxItr; i++ {
 		if i != 0 {
@@ -296,6 +382,8 @@ func maxDebitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decima
 		} else {
 			usagePaid = new(decimal.Big).Copy(usage)
 			paidConcrtUnts = cloneUnitsFromConcretes(cncrtBlncs)
+			xRatesPaid = ecDbt.ExchangeRates
+			cncrtsPaid = ecDbt.Concretes
 			ec.Merge(ecDbt)
 			if i == 0 { // no estimation done, covering full
 				break
@@ -320,7 +408,8 @@ func maxDebitAbstractsFromConcretes(cncrtBlncs []*concreteBalance, usage *decima
 		usagePaid = decimal.New(0, 0)
 	}
 	restoreUnitsFromClones(cncrtBlncs, paidConcrtUnts)
-	return &utils.EventCharges{Abstracts: &utils.Decimal{usagePaid}}, nil
+	return &utils.EventCharges{Abstracts: &utils.Decimal{usagePaid}, Concretes: cncrtsPaid,
+		ExchangeRates: xRatesPaid}, nil
 }
 
 // restoreAccounts will restore the accounts in DataDB out of their backups if present
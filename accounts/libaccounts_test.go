@@ -56,7 +56,7 @@ func TestNewAccountBalanceOperators(t *testing.T) {
 	}
 	filters := engine.NewFilterS(config.NewDefaultCGRConfig(), nil, nil)
 
-	concrete, err := newBalanceOperator(acntPrf.Balances["BL1"], nil, filters, nil, nil, nil)
+	concrete, err := newBalanceOperator(acntPrf.Balances["BL1"], nil, filters, nil, nil, nil, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
@@ -70,7 +70,7 @@ func TestNewAccountBalanceOperators(t *testing.T) {
 	}
 	blnCfgs := []*utils.Balance{acntPrf.Balances["BL0"], acntPrf.Balances["BL1"]}
 	if blcOp, err := newBalanceOperators(blnCfgs, filters, nil,
-		nil, nil); err != nil {
+		nil, nil, nil, nil); err != nil {
 		t.Error(err)
 	} else {
 		for _, bal := range blcOp {
@@ -85,7 +85,7 @@ func TestNewAccountBalanceOperators(t *testing.T) {
 	acntPrf.Balances["BL1"].Type = "INVALID_TYPE"
 	expectedErr := "unsupported balance type: <INVALID_TYPE>"
 	if _, err := newBalanceOperators(blnCfgs, filters, nil,
-		nil, nil); err == nil || err.Error() != expectedErr {
+		nil, nil, nil, nil); err == nil || err.Error() != expectedErr {
 		t.Errorf("Expected %+v, received %+v", expectedErr, err)
 	}
 }
@@ -309,6 +309,116 @@ func TestDebitUsageFromConcretesFromRateS(t *testing.T) {
 	}
 }
 
+func TestDebitUsageFromConcretesExchangeRates(t *testing.T) {
+	engine.Cache.Clear(nil)
+
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AccountSCfg().ExchangeRateProfileIDs = []string{"ERP_MISSING", "ERP_EUR"}
+	if err := dm.SetExchangeRateProfile(&utils.ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP_EUR",
+		Rates: []*utils.ExchangeRate{
+			{
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         utils.NewDecimal(2, 0),
+			},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.RateSv1CostForEvent: func(args interface{}, reply interface{}) error {
+				*reply.(*engine.RateProfileCost) = engine.RateProfileCost{
+					ID:       "RP_EUR",
+					Cost:     10,
+					Currency: "EUR",
+				}
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS): chanInternal,
+	})
+	filterS := engine.NewFilterS(cfg, nil, dm)
+	cb1 := &concreteBalance{
+		blnCfg: &utils.Balance{
+			ID:    "CB_USD",
+			Type:  utils.MetaConcrete,
+			Opts:  map[string]interface{}{utils.MetaBalanceCurrency: "USD"},
+			Units: utils.NewDecimal(10, 0),
+		},
+		fltrS:   filterS,
+		connMgr: connMgr,
+		cfg:     cfg,
+		dm:      dm,
+	}
+	cb2 := &concreteBalance{
+		blnCfg: &utils.Balance{
+			ID:    "CB_EUR",
+			Type:  utils.MetaConcrete,
+			Opts:  map[string]interface{}{utils.MetaBalanceCurrency: "EUR"},
+			Units: utils.NewDecimal(100, 0),
+		},
+		fltrS:   filterS,
+		connMgr: connMgr,
+		cfg:     cfg,
+		dm:      dm,
+	}
+
+	// 10 USD from the first balance cover 5 EUR, the rest is paid out of the EUR balance
+	evCh, err := debitAbstractsFromConcretes([]*concreteBalance{cb1, cb2}, decimal.New(1, 0),
+		&utils.CostIncrement{
+			Increment:    utils.NewDecimal(1, 0),
+			RecurrentFee: utils.NewDecimal(-1, 0),
+		}, &utils.CGREvent{Tenant: "cgrates.org"}, connMgr,
+		[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cb1.blnCfg.Units.Cmp(decimal.New(0, 0)) != 0 {
+		t.Errorf("balance remaining: %s", cb1.blnCfg.Units)
+	}
+	if cb2.blnCfg.Units.Cmp(decimal.New(95, 0)) != 0 {
+		t.Errorf("balance remaining: %s", cb2.blnCfg.Units)
+	}
+	if len(evCh.ExchangeRates) != 1 {
+		t.Fatalf("unexpected exchange rates: %s", utils.ToJSON(evCh.ExchangeRates))
+	}
+	if xRate := evCh.ExchangeRates[0]; xRate.BalanceID != "CB_USD" ||
+		xRate.FromCurrency != "EUR" || xRate.ToCurrency != "USD" ||
+		xRate.Rate.Cmp(decimal.New(2, 0)) != 0 ||
+		xRate.Cost.Cmp(decimal.New(5, 0)) != 0 ||
+		xRate.Debited.Cmp(decimal.New(10, 0)) != 0 {
+		t.Errorf("unexpected exchange rate: %s", utils.ToJSON(xRate))
+	}
+	// 5 EUR out of the USD balance and 5 EUR out of the EUR one
+	if evCh.Concretes.Cmp(decimal.New(10, 0)) != 0 {
+		t.Errorf("Expected the concretes in the currency of the cost, received %s", evCh.Concretes)
+	}
+
+	// no rate defined for the balance currency
+	cb1.blnCfg.Opts[utils.MetaBalanceCurrency] = "GBP"
+	cb1.blnCfg.Units = utils.NewDecimal(10, 0)
+	expErr := "no exchange rate from <EUR> to <GBP>"
+	if _, err := debitAbstractsFromConcretes([]*concreteBalance{cb1, cb2}, decimal.New(1, 0),
+		&utils.CostIncrement{
+			Increment:    utils.NewDecimal(1, 0),
+			RecurrentFee: utils.NewDecimal(-1, 0),
+		}, &utils.CGREvent{Tenant: "cgrates.org"}, connMgr,
+		[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)}, nil); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	} else if cb1.blnCfg.Units.Cmp(decimal.New(10, 0)) != 0 {
+		t.Errorf("balance remaining: %s", cb1.blnCfg.Units)
+	}
+}
+
 func TestDebitUsageFromConcretesRestore(t *testing.T) {
 	engine.Cache.Clear(nil)
 
@@ -486,3 +596,58 @@ func TestRestoreAccount3(t *testing.T) { //coverage purpose
 	})
 
 }
+
+func TestUpdateRateVolumeCounter(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	var rcvArgs *utils.ArgsCostForEvent
+	sTestMock := &testMockCall{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.RateSv1UpdateVolumeCounter: func(args interface{}, reply interface{}) error {
+				rcvArgs = args.(*utils.ArgsCostForEvent)
+				if rcvArgs.VolumeAccount() == "1002" {
+					return utils.ErrNotFound
+				}
+				*reply.(*string) = utils.OK
+				return nil
+			},
+		},
+	}
+	chanInternal := make(chan rpcclient.ClientConnector, 1)
+	chanInternal <- sTestMock
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS): chanInternal,
+	})
+	rateSConns := []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TEST_ID1",
+		Opts: map[string]interface{}{
+			utils.OptsRatesVolumeUpdate: true,
+		},
+	}
+	acntEv := volumeCountedEvent(cgrEv, "1001")
+	expOpts := map[string]interface{}{utils.OptsRatesVolumeAccount: "1001"}
+	if !reflect.DeepEqual(acntEv.Opts, expOpts) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expOpts), utils.ToJSON(acntEv.Opts))
+	}
+	if _, has := cgrEv.Opts[utils.OptsRatesVolumeAccount]; has {
+		t.Error("Expected the original event to not be modified")
+	}
+	if err := updateRateVolumeCounter(connMgr, rateSConns, acntEv, decimal.New(0, 0)); err != nil {
+		t.Error(err)
+	} else if rcvArgs != nil {
+		t.Error("Expected no call for zero usage")
+	}
+	if err := updateRateVolumeCounter(connMgr, rateSConns, acntEv,
+		decimal.New(int64(time.Minute), 0)); err != nil {
+		t.Error(err)
+	} else if usage, err := rcvArgs.Usage(); err != nil {
+		t.Error(err)
+	} else if usage != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, usage)
+	}
+	if err := updateRateVolumeCounter(connMgr, rateSConns, volumeCountedEvent(cgrEv, "1002"),
+		decimal.New(int64(time.Minute), 0)); err != nil { // no RateProfile matching
+		t.Error(err)
+	}
+}
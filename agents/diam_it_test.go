@@ -28,7 +28,6 @@ import (
 	"testing"
 	"time"
 
-	v1 "github.com/cgrates/cgrates/apier/v1"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
@@ -1454,7 +1453,7 @@ func testDiamItEmulateTerminate(t *testing.T) {
 	}
 	var result string
 	//add the second charger
-	chargerProfile := &v1.ChargerWithCache{
+	chargerProfile := &engine.ChargerProfileWithOpts{
 		ChargerProfile: &engine.ChargerProfile{
 			Tenant:       "cgrates.com",
 			ID:           "CustomCharger",
@@ -1470,7 +1469,7 @@ func testDiamItEmulateTerminate(t *testing.T) {
 		t.Error("Unexpected reply returned", result)
 	}
 	//add the second charger
-	chargerProfile2 := &v1.ChargerWithCache{
+	chargerProfile2 := &engine.ChargerProfileWithOpts{
 		ChargerProfile: &engine.ChargerProfile{
 			Tenant:       "cgrates.com",
 			ID:           "Default",
@@ -56,6 +56,11 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		raa:     make(map[string]chan *diam.Message),
 		dpa:     make(map[string]chan *diam.Message),
 		peers:   make(map[string]diam.Conn),
+		sySubs:  make(map[string]*sySubscription),
+	}
+	da.outPeers = make([]*diamPeer, len(cgrCfg.DiameterAgentCfg().Peers))
+	for i, peerCfg := range cgrCfg.DiameterAgentCfg().Peers {
+		da.outPeers[i] = newDiamPeer(peerCfg)
 	}
 	dictsPath := cgrCfg.DiameterAgentCfg().DictionariesPath
 	if len(dictsPath) != 0 {
@@ -80,6 +85,12 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 	return da, nil
 }
 
+// DiameterAgentAPI is the part of the DiameterAgent exported over RPC by DiameterAgentV1
+type DiameterAgentAPI interface {
+	V1GetPeers(args *utils.TenantWithOpts, reply *[]*DiameterPeerStatus) error
+	V1UpdatePolicyCounters(args *utils.UpdatePolicyCountersArgs, reply *string) error
+}
+
 // DiameterAgent describes the diameter server
 type DiameterAgent struct {
 	cgrCfg   *config.CGRConfig
@@ -92,8 +103,12 @@ type DiameterAgent struct {
 
 	peersLck sync.Mutex
 	peers    map[string]diam.Conn // peer index by OriginHost;OriginRealm
+	outPeers []*diamPeer          // configured peers we connect to
 	dpa      map[string]chan *diam.Message
 	dpaLck   sync.RWMutex
+
+	sySubs    map[string]*sySubscription // Sy subscriptions indexed by Session-Id
+	sySubsLck sync.RWMutex
 }
 
 // ListenAndServe is called when DiameterAgent is started, usually from within cmd/cgr-engine
@@ -105,6 +120,13 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 		Handler: da.handlers(),
 		Dict:    nil,
 	}
+	for _, p := range da.outPeers {
+		go da.connectPeer(p, stopChan)
+	}
+	if intvl := da.cgrCfg.DiameterAgentCfg().PolicyCntIntvl; intvl > 0 &&
+		len(da.cgrCfg.DiameterAgentCfg().PolicyCounters) != 0 {
+		go da.policyCountersLoop(intvl, stopChan)
+	}
 	// used to control the server state
 	var lsn net.Listener
 	if lsn, err = diam.MultistreamListen(utils.FirstNonEmpty(srv.Network, utils.TCP),
@@ -125,6 +147,15 @@ func (da *DiameterAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 
 // Creates the message handlers
 func (da *DiameterAgent) handlers() diam.Handler {
+	dSM := da.newStateMachine()
+	go da.handleConns(dSM.HandshakeNotify())
+	go da.logSMErrors(dSM, nil)
+	return dSM
+}
+
+// newStateMachine creates a diameter StateMachine with the CER/CEA settings of the agent
+// and the message handlers registered
+func (da *DiameterAgent) newStateMachine() (dSM *sm.StateMachine) {
 	settings := &sm.Settings{
 		OriginHost:       datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost),
 		OriginRealm:      datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm),
@@ -155,7 +186,7 @@ func (da *DiameterAgent) handlers() diam.Handler {
 		settings.HostIPAddresses[i] = datatype.Address(host)
 	}
 
-	dSM := sm.New(settings)
+	dSM = sm.New(settings)
 	if da.cgrCfg.DiameterAgentCfg().SyncedConnReqs {
 		dSM.HandleFunc(all, da.handleMessage)
 		dSM.HandleFunc(raa, da.handleRAA)
@@ -165,13 +196,19 @@ func (da *DiameterAgent) handlers() diam.Handler {
 		dSM.HandleFunc(raa, func(c diam.Conn, m *diam.Message) { go da.handleRAA(c, m) })
 		dSM.HandleFunc(dpa, func(c diam.Conn, m *diam.Message) { go da.handleDPA(c, m) })
 	}
-	go da.handleConns(dSM.HandshakeNotify())
-	go func() {
-		for err := range dSM.ErrorReports() {
+	return
+}
+
+// logSMErrors logs the errors reported by the StateMachine until stopChan is closed
+func (da *DiameterAgent) logSMErrors(dSM *sm.StateMachine, stopChan <-chan struct{}) {
+	for {
+		select {
+		case err := <-dSM.ErrorReports():
 			utils.Logger.Err(fmt.Sprintf("<%s> sm error: %v", utils.DiameterAgent, err))
+		case <-stopChan:
+			return
 		}
-	}()
-	return dSM
+	}
 }
 
 // handleMessageAsync will dispatch the message into it's own goroutine
@@ -181,6 +218,11 @@ func (da *DiameterAgent) handleMessageAsync(c diam.Conn, m *diam.Message) {
 
 // handleALL is the handler of all messages coming in via Diameter
 func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
+	if m.Header.ApplicationID == diam.DIAMETER_SY_APP_ID &&
+		len(da.cgrCfg.DiameterAgentCfg().PolicyCounters) != 0 {
+		da.handleSy(c, m)
+		return
+	}
 	dApp, err := m.Dictionary().App(m.Header.ApplicationID)
 	if err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> decoding app: %d, err: %s",
@@ -243,9 +285,10 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 	if da.cgrCfg.DiameterAgentCfg().ConcurrentReqs != -1 {
 		da.aReqsLck.Lock()
 		if da.aReqs == da.cgrCfg.DiameterAgentCfg().ConcurrentReqs {
-			utils.Logger.Err(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 				fmt.Sprintf("<%s> denying request due to maximum active requests reached: %d, message: %s",
-					utils.DiameterAgent, da.cgrCfg.DiameterAgentCfg().ConcurrentReqs, m))
+					utils.DiameterAgent, da.cgrCfg.DiameterAgentCfg().ConcurrentReqs, m),
+				diamLogFields(da.cgrCfg.GeneralCfg().DefaultTenant, m))
 			writeOnConn(c, diamErr)
 			da.aReqsLck.Unlock()
 			return
@@ -281,25 +324,28 @@ func (da *DiameterAgent) handleMessage(c diam.Conn, m *diam.Message) {
 		}
 	}
 	if err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> error: %s processing message: %s",
-				utils.DiameterAgent, err.Error(), m))
+				utils.DiameterAgent, err.Error(), m),
+			diamLogFields(da.cgrCfg.GeneralCfg().DefaultTenant, m))
 		writeOnConn(c, diamErr)
 		return
 	}
 	if !processed {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
-				utils.DiameterAgent, m, c.RemoteAddr()))
+				utils.DiameterAgent, m, c.RemoteAddr()),
+			diamLogFields(da.cgrCfg.GeneralCfg().DefaultTenant, m))
 		writeOnConn(c, diamErr)
 		return
 	}
 	a, err := diamAnswer(m, 0, false,
 		rply, da.cgrCfg.GeneralCfg().DefaultTimezone)
 	if err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> err: %s, replying to message: %+v",
-				utils.DiameterAgent, err.Error(), m))
+				utils.DiameterAgent, err.Error(), m),
+			diamLogFields(da.cgrCfg.GeneralCfg().DefaultTenant, m))
 		writeOnConn(c, diamErr)
 		return
 	}
@@ -330,25 +376,29 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 	var cgrArgs utils.Paginator
 	if reqType == utils.MetaAuthorize || reqType == utils.MetaMessage || reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
-				utils.DiameterAgent, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction failed because <%s>",
+					utils.DiameterAgent, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
 
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, processorID: %s, diameter message: %s",
-				utils.DiameterAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.DiameterAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, DiameterMessage: %s",
-				utils.DiameterAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.DiameterAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -480,14 +530,16 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 		return
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, Diameter reply: %s",
-				utils.DiameterAgent, agReq.Reply))
+				utils.DiameterAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	if reqType == utils.MetaDryRun {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, Diameter reply: %s",
-				utils.DiameterAgent, agReq.Reply))
+				utils.DiameterAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	return true, nil
 }
@@ -529,9 +581,10 @@ func (da *DiameterAgent) V1GetActiveSessionIDs(ignParam string,
 func (da *DiameterAgent) sendASR(originID string, reply *string) (err error) {
 	msg, has := engine.Cache.Get(utils.CacheDiameterMessages, originID)
 	if !has {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot retrieve message from cache with OriginID: <%s>",
-				utils.DiameterAgent, originID))
+				utils.DiameterAgent, originID),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrMandatoryIeMissing
 	}
 	dmd := msg.(*diamMsgData)
@@ -541,21 +594,31 @@ func (da *DiameterAgent) sendASR(originID string, reply *string) (err error) {
 		da.cgrCfg.GeneralCfg().DefaultTenant,
 		da.cgrCfg.GeneralCfg().DefaultTimezone, da.filterS, nil, nil)
 	if err = aReq.SetFields(da.cgrCfg.TemplatesCfg()[da.cgrCfg.DiameterAgentCfg().ASRTemplate]); err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot disconnect session with OriginID: <%s>, err: %s",
-				utils.DiameterAgent, originID, err.Error()))
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrServerError
 	}
 	m := diam.NewRequest(dmd.m.Header.CommandCode,
 		dmd.m.Header.ApplicationID, dmd.m.Dictionary())
 	if err = updateDiamMsgFromNavMap(m, aReq.diamreq,
 		da.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot disconnect session with OriginID: <%s>, err: %s",
-				utils.DiameterAgent, originID, err.Error()))
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrServerError
 	}
-	if err = writeOnConn(dmd.c, m); err != nil {
+	var c diam.Conn
+	if c, err = da.peerConn(dmd); err != nil {
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+			fmt.Sprintf("<%s> cannot disconnect session with OriginID: <%s>, err: %s",
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
+		return
+	}
+	if err = writeOnConn(c, m); err != nil {
 		return utils.ErrServerError
 	}
 	*reply = utils.OK
@@ -572,9 +635,10 @@ func (da *DiameterAgent) V1ReAuthorize(originID string, reply *string) (err erro
 	}
 	msg, has := engine.Cache.Get(utils.CacheDiameterMessages, originID)
 	if !has {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot retrieve message from cache with OriginID: <%s>",
-				utils.DiameterAgent, originID))
+				utils.DiameterAgent, originID),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrMandatoryIeMissing
 	}
 	dmd := msg.(*diamMsgData)
@@ -584,18 +648,20 @@ func (da *DiameterAgent) V1ReAuthorize(originID string, reply *string) (err erro
 		da.cgrCfg.GeneralCfg().DefaultTenant,
 		da.cgrCfg.GeneralCfg().DefaultTimezone, da.filterS, nil, nil)
 	if err = aReq.SetFields(da.cgrCfg.TemplatesCfg()[da.cgrCfg.DiameterAgentCfg().RARTemplate]); err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot send RAR with OriginID: <%s>, err: %s",
-				utils.DiameterAgent, originID, err.Error()))
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrServerError
 	}
 	m := diam.NewRequest(diam.ReAuth,
 		dmd.m.Header.ApplicationID, dmd.m.Dictionary())
 	if err = updateDiamMsgFromNavMap(m, aReq.diamreq,
 		da.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> cannot send RAR with OriginID: <%s>, err: %s",
-				utils.DiameterAgent, originID, err.Error()))
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
 		return utils.ErrServerError
 	}
 	raaCh := make(chan *diam.Message, 1)
@@ -607,7 +673,15 @@ func (da *DiameterAgent) V1ReAuthorize(originID string, reply *string) (err erro
 		delete(da.raa, originID)
 		da.raaLck.Unlock()
 	}()
-	if err = writeOnConn(dmd.c, m); err != nil {
+	var c diam.Conn
+	if c, err = da.peerConn(dmd); err != nil {
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+			fmt.Sprintf("<%s> cannot send RAR with OriginID: <%s>, err: %s",
+				utils.DiameterAgent, originID, err.Error()),
+			utils.LogFields{utils.LogFieldOriginID: originID})
+		return
+	}
+	if err = writeOnConn(c, m); err != nil {
 		return utils.ErrServerError
 	}
 	select {
@@ -0,0 +1,377 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smparser"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smpeer"
+)
+
+// peer states as defined by the watchdog algorithm in RFC 3539
+const (
+	diamPeerOkay    = "OKAY"
+	diamPeerSuspect = "SUSPECT"
+	diamPeerDown    = "DOWN"
+	dwa             = "DWA"
+)
+
+// DiameterPeerStatus is the status of a peer as returned by DiameterAgentV1.GetPeers
+type DiameterPeerStatus struct {
+	ID           string // configured ID, empty for the incoming connections
+	Address      string
+	OriginHost   string
+	OriginRealm  string
+	Outgoing     bool // the connection was initiated by us
+	State        string
+	LastWatchdog time.Time // last DWA received
+	Reconnects   int
+	LastError    string
+}
+
+func newDiamPeer(cfg *config.DiameterPeerCfg) *diamPeer {
+	return &diamPeer{
+		cfg:   cfg,
+		state: diamPeerDown,
+		dwac:  make(chan struct{}, 1),
+	}
+}
+
+// diamPeer holds the connection state of one configured outgoing peer
+type diamPeer struct {
+	sync.RWMutex
+	cfg          *config.DiameterPeerCfg
+	conn         diam.Conn
+	key          string // OriginHost;OriginRealm received in CEA
+	state        string
+	lastWatchdog time.Time
+	reconnects   int
+	lastErr      error
+	dwac         chan struct{} // signals the DWA received
+}
+
+// setState updates the state and the last error of the peer
+func (p *diamPeer) setState(state string, err error) {
+	p.Lock()
+	p.state = state
+	if err != nil {
+		p.lastErr = err
+	}
+	p.Unlock()
+}
+
+// getState returns the current state of the peer
+func (p *diamPeer) getState() (state string) {
+	p.RLock()
+	state = p.state
+	p.RUnlock()
+	return
+}
+
+// status returns the peer status as exported over the API
+func (p *diamPeer) status() (ps *DiameterPeerStatus) {
+	p.RLock()
+	ps = &DiameterPeerStatus{
+		ID:           p.cfg.ID,
+		Address:      p.cfg.Address,
+		Outgoing:     true,
+		State:        p.state,
+		LastWatchdog: p.lastWatchdog,
+		Reconnects:   p.reconnects,
+	}
+	if p.key != utils.EmptyString {
+		hostRealm := strings.SplitN(p.key, utils.ConcatenatedKeySep, 2)
+		ps.OriginHost, ps.OriginRealm = hostRealm[0], hostRealm[1]
+	}
+	if p.lastErr != nil {
+		ps.LastError = p.lastErr.Error()
+	}
+	p.RUnlock()
+	return
+}
+
+// handleDWA signals the watchdog about the answers received from peer
+func (p *diamPeer) handleDWA(c diam.Conn, m *diam.Message) {
+	dwa := new(smparser.DWA)
+	if err := dwa.Parse(m); err != nil ||
+		dwa.ResultCode != diam.Success {
+		return
+	}
+	select {
+	case p.dwac <- struct{}{}:
+	default:
+	}
+}
+
+// nextReconnectInterval doubles the reconnect interval without passing over maxIntvl
+func nextReconnectInterval(intvl, maxIntvl time.Duration) time.Duration {
+	if intvl *= 2; maxIntvl > 0 && intvl > maxIntvl {
+		return maxIntvl
+	}
+	return intvl
+}
+
+// connectPeer keeps the connection towards the peer open until stopChan is closed,
+// reconnecting with exponential backoff
+func (da *DiameterAgent) connectPeer(p *diamPeer, stopChan <-chan struct{}) {
+	dSM := da.newStateMachine()
+	dSM.HandleFunc(dwa, p.handleDWA)
+	go da.logSMErrors(dSM, stopChan)
+	intvl := da.cgrCfg.DiameterAgentCfg().ReconnectInterval
+	for {
+		c, err := da.dialPeer(dSM, p)
+		if err != nil {
+			p.setState(diamPeerDown, err)
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot connect to peer <%s> at <%s>, err: %s, retrying in %s",
+					utils.DiameterAgent, p.cfg.ID, p.cfg.Address, err.Error(), intvl))
+			select {
+			case <-stopChan:
+				return
+			case <-time.After(intvl):
+			}
+			intvl = nextReconnectInterval(intvl, da.cgrCfg.DiameterAgentCfg().MaxReconnectIntvl)
+			continue
+		}
+		intvl = da.cgrCfg.DiameterAgentCfg().ReconnectInterval
+		key := da.registerPeer(p, c)
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> connected to peer <%s> with identity <%s>",
+				utils.DiameterAgent, p.cfg.ID, key))
+		da.watchPeer(p, c, stopChan)
+		da.unregisterPeer(p, c)
+		select {
+		case <-stopChan:
+			return
+		default:
+		}
+		p.Lock()
+		p.reconnects++
+		p.Unlock()
+	}
+}
+
+// dialPeer opens the connection and performs the CER/CEA handshake
+func (da *DiameterAgent) dialPeer(dSM *sm.StateMachine, p *diamPeer) (diam.Conn, error) {
+	cli := &sm.Client{
+		Dict:    dict.Default,
+		Handler: dSM,
+	}
+	for _, appID := range p.cfg.AuthApplicationIDs {
+		cli.AuthApplicationID = append(cli.AuthApplicationID,
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID)))
+	}
+	return cli.DialNetwork(utils.FirstNonEmpty(p.cfg.Network, utils.TCP), p.cfg.Address)
+}
+
+// registerPeer stores the connection in the peers table so it can be used to send server initiated requests
+func (da *DiameterAgent) registerPeer(p *diamPeer, c diam.Conn) (key string) {
+	meta, _ := smpeer.FromContext(c.Context())
+	key = string(meta.OriginHost + utils.ConcatenatedKeySep + meta.OriginRealm)
+	p.Lock()
+	p.conn = c
+	p.key = key
+	p.state = diamPeerOkay
+	p.lastErr = nil
+	p.Unlock()
+	da.peersLck.Lock()
+	da.peers[key] = c
+	da.peersLck.Unlock()
+	return
+}
+
+// unregisterPeer removes the connection from the peers table if not already replaced
+func (da *DiameterAgent) unregisterPeer(p *diamPeer, c diam.Conn) {
+	p.Lock()
+	p.conn = nil
+	key := p.key
+	p.Unlock()
+	da.peersLck.Lock()
+	if da.peers[key] == c {
+		delete(da.peers, key)
+	}
+	da.peersLck.Unlock()
+}
+
+// watchPeer implements the watchdog algorithm from RFC 3539 over the connection,
+// returning when the connection is closed
+func (da *DiameterAgent) watchPeer(p *diamPeer, c diam.Conn, stopChan <-chan struct{}) {
+	disconnect := c.(diam.CloseNotifier).CloseNotify()
+	tw := da.cgrCfg.DiameterAgentCfg().WatchdogInterval
+	var watchdog <-chan time.Time
+	var pending bool // a DWR was sent without an answer
+	for {
+		if tw > 0 {
+			watchdog = time.After(tw)
+		}
+		select {
+		case <-stopChan:
+			c.Close()
+			return
+		case <-disconnect:
+			p.setState(diamPeerDown, fmt.Errorf("connection closed"))
+			return
+		case <-p.dwac:
+			pending = false
+			p.Lock()
+			p.state = diamPeerOkay
+			p.lastWatchdog = time.Now()
+			p.Unlock()
+		case <-watchdog:
+			switch {
+			case !pending:
+				pending = true
+				if err := writeOnConn(c, da.newDWR()); err != nil {
+					p.setState(diamPeerDown, err)
+					c.Close()
+					return
+				}
+			case p.getState() == diamPeerOkay:
+				p.setState(diamPeerSuspect, utils.ErrTimedOut)
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> no watchdog answer from peer <%s>, marking it as %s",
+						utils.DiameterAgent, p.cfg.ID, diamPeerSuspect))
+			default:
+				p.setState(diamPeerDown, utils.ErrTimedOut)
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> no watchdog answer from peer <%s>, closing the connection",
+						utils.DiameterAgent, p.cfg.ID))
+				c.Close()
+				return
+			}
+		}
+	}
+}
+
+// newDWR creates a Device-Watchdog-Request with the identity of the agent
+func (da *DiameterAgent) newDWR() (m *diam.Message) {
+	m = diam.NewRequest(diam.DeviceWatchdog, 0, dict.Default)
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm))
+	return
+}
+
+// peerAvailable returns false if the connection under key belongs to a peer failing the watchdog
+func (da *DiameterAgent) peerAvailable(key string) bool {
+	for _, p := range da.outPeers {
+		p.RLock()
+		pKey, state := p.key, p.state
+		p.RUnlock()
+		if pKey == key {
+			return state == diamPeerOkay
+		}
+	}
+	return true
+}
+
+// peerConn returns the connection used to send the server initiated requests for the session.
+// The connection the request came in on is preferred, falling back on the peer with the same identity
+// and at last on any available peer within the same realm
+func (da *DiameterAgent) peerConn(dmd *diamMsgData) (diam.Conn, error) {
+	if !diamConnClosed(dmd.c) {
+		return dmd.c, nil
+	}
+	var originHost, originRealm string
+	if a, err := dmd.m.FindAVP(avp.OriginHost, dict.UndefinedVendorID); err == nil {
+		originHost, _ = diamAVPAsString(a)
+	}
+	if a, err := dmd.m.FindAVP(avp.OriginRealm, dict.UndefinedVendorID); err == nil {
+		originRealm, _ = diamAVPAsString(a)
+	}
+	key := originHost + utils.ConcatenatedKeySep + originRealm
+	da.peersLck.Lock()
+	defer da.peersLck.Unlock()
+	if c, has := da.peers[key]; has && da.peerAvailable(key) {
+		return c, nil
+	}
+	keys := make([]string, 0, len(da.peers))
+	for pKey := range da.peers {
+		keys = append(keys, pKey)
+	}
+	sort.Strings(keys) // predictable choice between the peers of the realm
+	for _, pKey := range keys {
+		if strings.HasSuffix(pKey, utils.ConcatenatedKeySep+originRealm) &&
+			da.peerAvailable(pKey) {
+			return da.peers[pKey], nil
+		}
+	}
+	return nil, utils.ErrNotConnected
+}
+
+// diamConnClosed checks if the connection was closed
+func diamConnClosed(c diam.Conn) bool {
+	cn, canNotify := c.(diam.CloseNotifier)
+	if !canNotify {
+		return false
+	}
+	select {
+	case <-cn.CloseNotify():
+		return true
+	default:
+		return false
+	}
+}
+
+// V1GetPeers returns the status of the configured peers followed by the one of the connected clients
+func (da *DiameterAgent) V1GetPeers(ign *utils.TenantWithOpts, reply *[]*DiameterPeerStatus) (err error) {
+	peers := make([]*DiameterPeerStatus, 0, len(da.outPeers))
+	outKeys := make(utils.StringSet)
+	for _, p := range da.outPeers {
+		ps := p.status()
+		if ps.State != diamPeerDown {
+			outKeys.Add(ps.OriginHost + utils.ConcatenatedKeySep + ps.OriginRealm)
+		}
+		peers = append(peers, ps)
+	}
+	da.peersLck.Lock()
+	inPeers := make([]*DiameterPeerStatus, 0, len(da.peers))
+	for key, c := range da.peers {
+		if outKeys.Has(key) {
+			continue
+		}
+		hostRealm := strings.SplitN(key, utils.ConcatenatedKeySep, 2)
+		ps := &DiameterPeerStatus{
+			OriginHost:  hostRealm[0],
+			OriginRealm: hostRealm[1],
+			State:       diamPeerOkay,
+		}
+		if c.RemoteAddr() != nil {
+			ps.Address = c.RemoteAddr().String()
+		}
+		inPeers = append(inPeers, ps)
+	}
+	da.peersLck.Unlock()
+	sort.Slice(inPeers, func(i, j int) bool {
+		return inPeers[i].OriginHost+inPeers[i].OriginRealm < inPeers[j].OriginHost+inPeers[j].OriginRealm
+	})
+	*reply = append(peers, inPeers...)
+	return
+}
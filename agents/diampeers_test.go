@@ -0,0 +1,252 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+type testMockDiamConn struct {
+	closed chan struct{}
+	raddr  net.Addr
+}
+
+func newTestMockDiamConn(raddr string) *testMockDiamConn {
+	addr, _ := net.ResolveTCPAddr(utils.TCP, raddr)
+	return &testMockDiamConn{closed: make(chan struct{}), raddr: addr}
+}
+
+func (c *testMockDiamConn) Write(b []byte) (int, error)                    { return len(b), nil }
+func (c *testMockDiamConn) WriteStream(b []byte, stream uint) (int, error) { return len(b), nil }
+func (c *testMockDiamConn) Close()                                         { close(c.closed) }
+func (c *testMockDiamConn) LocalAddr() net.Addr                            { return nil }
+func (c *testMockDiamConn) RemoteAddr() net.Addr                           { return c.raddr }
+func (c *testMockDiamConn) TLS() *tls.ConnectionState                      { return nil }
+func (c *testMockDiamConn) Dictionary() *dict.Parser                       { return dict.Default }
+func (c *testMockDiamConn) Context() context.Context                       { return context.Background() }
+func (c *testMockDiamConn) SetContext(ctx context.Context)                 {}
+func (c *testMockDiamConn) Connection() net.Conn                           { return nil }
+func (c *testMockDiamConn) CloseNotify() <-chan struct{}                   { return c.closed }
+
+func TestDiamNextReconnectInterval(t *testing.T) {
+	if rcv := nextReconnectInterval(5*time.Second, time.Minute); rcv != 10*time.Second {
+		t.Errorf("Expected %v, received %v", 10*time.Second, rcv)
+	}
+	if rcv := nextReconnectInterval(40*time.Second, time.Minute); rcv != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, rcv)
+	}
+	if rcv := nextReconnectInterval(40*time.Second, 0); rcv != 80*time.Second {
+		t.Errorf("Expected %v, received %v", 80*time.Second, rcv)
+	}
+}
+
+func TestDiamPeerConn(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 4, dict.Default)
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("pgw1"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("epc.org"))
+	orig := newTestMockDiamConn("127.0.0.1:3868")
+	pgw1 := newTestMockDiamConn("127.0.0.1:3869")
+	pgw2 := newTestMockDiamConn("127.0.0.1:3870")
+	pcef := newTestMockDiamConn("127.0.0.1:3871")
+	p := newDiamPeer(&config.DiameterPeerCfg{ID: "PGW1"})
+	p.key = "pgw1:epc.org"
+	p.state = diamPeerOkay
+	da := &DiameterAgent{
+		peers: map[string]diam.Conn{
+			"pgw1:epc.org":      pgw1,
+			"pgw2:epc.org":      pgw2,
+			"pcef1:cgrates.org": pcef,
+		},
+		outPeers: []*diamPeer{p},
+	}
+	dmd := &diamMsgData{c: orig, m: m}
+	if c, err := da.peerConn(dmd); err != nil {
+		t.Error(err)
+	} else if c != orig {
+		t.Errorf("Expected the original connection, received: %+v", c)
+	}
+	orig.Close()
+	if c, err := da.peerConn(dmd); err != nil {
+		t.Error(err)
+	} else if c != pgw1 {
+		t.Errorf("Expected the connection of pgw1, received: %+v", c)
+	}
+	p.setState(diamPeerSuspect, utils.ErrTimedOut)
+	if c, err := da.peerConn(dmd); err != nil {
+		t.Error(err)
+	} else if c != pgw2 {
+		t.Errorf("Expected the connection of pgw2, received: %+v", c)
+	}
+	delete(da.peers, "pgw2:epc.org")
+	if _, err := da.peerConn(dmd); err != utils.ErrNotConnected {
+		t.Errorf("Expected %v, received %v", utils.ErrNotConnected, err)
+	}
+}
+
+func TestDiamAgentV1GetPeers(t *testing.T) {
+	p1 := newDiamPeer(&config.DiameterPeerCfg{ID: "PGW1", Address: "127.0.0.1:3869"})
+	p1.key = "pgw1:epc.org"
+	p1.state = diamPeerOkay
+	p1.reconnects = 2
+	p2 := newDiamPeer(&config.DiameterPeerCfg{ID: "PGW2", Address: "127.0.0.1:3870"})
+	p2.setState(diamPeerDown, utils.ErrTimedOut)
+	da := &DiameterAgent{
+		peers: map[string]diam.Conn{
+			"pgw1:epc.org":      newTestMockDiamConn("127.0.0.1:3869"),
+			"pcef1:cgrates.org": newTestMockDiamConn("127.0.0.1:3871"),
+		},
+		outPeers: []*diamPeer{p1, p2},
+	}
+	var rply []*DiameterPeerStatus
+	if err := da.V1GetPeers(nil, &rply); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*DiameterPeerStatus{
+		{
+			ID:          "PGW1",
+			Address:     "127.0.0.1:3869",
+			OriginHost:  "pgw1",
+			OriginRealm: "epc.org",
+			Outgoing:    true,
+			State:       diamPeerOkay,
+			Reconnects:  2,
+		},
+		{
+			ID:        "PGW2",
+			Address:   "127.0.0.1:3870",
+			Outgoing:  true,
+			State:     diamPeerDown,
+			LastError: utils.ErrTimedOut.Error(),
+		},
+		{
+			Address:     "127.0.0.1:3871",
+			OriginHost:  "pcef1",
+			OriginRealm: "cgrates.org",
+			State:       diamPeerOkay,
+		},
+	}
+	if utils.ToJSON(rply) != utils.ToJSON(exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+}
+
+func TestDiamAgentConnectPeer(t *testing.T) {
+	lsn, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lsn.Addr().String()
+	lsn.Close()
+
+	srvCfg := config.NewDefaultCGRConfig()
+	srvCfg.DiameterAgentCfg().Listen = addr
+	srvCfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	srvCfg.DiameterAgentCfg().OriginHost = "pgw1"
+	srvCfg.DiameterAgentCfg().OriginRealm = "epc.org"
+	srvDA, err := NewDiameterAgent(srvCfg, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srvStop := make(chan struct{})
+	defer close(srvStop)
+	go srvDA.ListenAndServe(srvStop)
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().DictionariesPath = utils.EmptyString
+	cfg.DiameterAgentCfg().WatchdogInterval = 20 * time.Millisecond
+	cfg.DiameterAgentCfg().ReconnectInterval = 10 * time.Millisecond
+	cfg.DiameterAgentCfg().MaxReconnectIntvl = 50 * time.Millisecond
+	cfg.DiameterAgentCfg().Peers = []*config.DiameterPeerCfg{
+		{ID: "PGW1", Address: addr, Network: utils.TCP, AuthApplicationIDs: []int{4}},
+	}
+	da, err := NewDiameterAgent(cfg, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stop := make(chan struct{})
+	defer close(stop)
+	go da.connectPeer(da.outPeers[0], stop)
+
+	waitPeer := func(reconnects int) (ps *DiameterPeerStatus) {
+		for i := 0; i < 100; i++ {
+			if ps = da.outPeers[0].status(); ps.State == diamPeerOkay &&
+				ps.Reconnects == reconnects && !ps.LastWatchdog.IsZero() {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("peer not connected, status: %s", utils.ToJSON(ps))
+		return
+	}
+	if ps := waitPeer(0); ps.OriginHost != "pgw1" || ps.OriginRealm != "epc.org" {
+		t.Errorf("Unexpected peer status: %s", utils.ToJSON(ps))
+	}
+	da.peersLck.Lock()
+	_, has := da.peers["pgw1:epc.org"]
+	da.peersLck.Unlock()
+	if !has {
+		t.Error("Expected the peer in the peers table")
+	}
+	// break the connection from the server side and expect the agent to reconnect
+	srvDA.peersLck.Lock()
+	c, has := srvDA.peers["CGR-DA:cgrates.org"]
+	srvDA.peersLck.Unlock()
+	if !has {
+		t.Fatal("Expected the agent connected to server")
+	}
+	c.Close()
+	waitPeer(1)
+}
+
+func TestDiamAgentWatchPeerFailure(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().WatchdogInterval = 10 * time.Millisecond
+	p := newDiamPeer(&config.DiameterPeerCfg{ID: "PGW1"})
+	p.state = diamPeerOkay
+	da := &DiameterAgent{cgrCfg: cfg}
+	c := newTestMockDiamConn("127.0.0.1:3869")
+	done := make(chan struct{})
+	go func() {
+		da.watchPeer(p, c, nil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watchdog did not close the connection")
+	}
+	if !diamConnClosed(c) {
+		t.Error("Expected the connection closed")
+	}
+	if ps := p.status(); ps.State != diamPeerDown ||
+		ps.LastError != utils.ErrTimedOut.Error() {
+		t.Errorf("Unexpected peer status: %s", utils.ToJSON(ps))
+	}
+}
@@ -0,0 +1,322 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// Sy reference point between PCRF and OCS as defined in 3GPP TS 29.219
+const (
+	sy3GPPVendorID = 10415
+
+	syCmdSpendingStatusNotification = 8388636
+
+	avpPolicyCounterIdentifier   = 2901
+	avpPolicyCounterStatus       = 2902
+	avpPolicyCounterStatusReport = 2903
+	avpSLRequestType             = 2904
+
+	slRequestInitial      = 0
+	slRequestIntermediate = 1
+
+	diamUnknownPolicyCounter = 5570 // DIAMETER_ERROR_UNKNOWN_POLICY_COUNTERS
+)
+
+// sySubscription is the spending limit session opened by the PCRF
+type sySubscription struct {
+	c          diam.Conn
+	m          *diam.Message     // the initial SLR, used as event when evaluating the counters
+	counterIDs []string          // subscribed policy counters
+	statuses   map[string]string // last status reported for each counter
+}
+
+// handleSy processes the Sy requests when policy counters are configured
+func (da *DiameterAgent) handleSy(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag != diam.RequestFlag {
+		return // SNA, nothing to do with it
+	}
+	var a *diam.Message
+	switch m.Header.CommandCode {
+	case diam.SpendingLimit:
+		a = da.processSLR(c, m)
+	case diam.SessionTermination:
+		a = da.processSySTR(m)
+	default:
+		a = diamBareErr(m, diam.CommandUnsupported)
+	}
+	writeOnConn(c, a)
+}
+
+// processSLR subscribes the PCRF to the requested policy counters and answers with their current status
+func (da *DiameterAgent) processSLR(c diam.Conn, m *diam.Message) (a *diam.Message) {
+	sessionID := diamSessionID(m)
+	if sessionID == utils.EmptyString {
+		return diamBareErr(m, diam.MissingAVP)
+	}
+	reqType := slRequestInitial
+	if rt, err := m.FindAVP(avpSLRequestType, sy3GPPVendorID); err == nil {
+		if enm, canCast := rt.Data.(datatype.Enumerated); canCast {
+			reqType = int(enm)
+		}
+	}
+	cntIDs, unknown := da.slrCounterIDs(m)
+	if len(unknown) != 0 {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> unknown policy counters: %v within SLR for session: <%s>",
+				utils.DiameterAgent, unknown, sessionID))
+		return da.syAnswer(m, sessionID, diamUnknownPolicyCounter)
+	}
+	da.sySubsLck.RLock()
+	crntSub, has := da.sySubs[sessionID]
+	da.sySubsLck.RUnlock()
+	if !has && reqType == slRequestIntermediate {
+		return da.syAnswer(m, sessionID, diam.UnknownSessionID)
+	}
+	sub := &sySubscription{c: c, m: m, counterIDs: cntIDs}
+	if has {
+		sub.m = crntSub.m // an intermediate request replaces only the subscribed counters
+	}
+	sub.statuses = da.policyCounterStatuses(sub)
+	a = da.syAnswer(m, sessionID, diam.Success)
+	for _, cntID := range cntIDs {
+		a.AddAVP(newPolicyCounterStatusReport(cntID, sub.statuses[cntID]))
+	}
+	da.sySubsLck.Lock()
+	da.sySubs[sessionID] = sub
+	da.sySubsLck.Unlock()
+	return
+}
+
+// slrCounterIDs returns the policy counters requested, all of the configured ones if none is listed
+func (da *DiameterAgent) slrCounterIDs(m *diam.Message) (cntIDs, unknown []string) {
+	avps, _ := m.FindAVPs(avpPolicyCounterIdentifier, sy3GPPVendorID)
+	if len(avps) == 0 {
+		for _, pc := range da.cgrCfg.DiameterAgentCfg().PolicyCounters {
+			cntIDs = append(cntIDs, pc.ID)
+		}
+		return
+	}
+	for _, a := range avps {
+		cntID, err := diamAVPAsString(a)
+		if err != nil {
+			continue
+		}
+		if da.policyCounter(cntID) == nil {
+			unknown = append(unknown, cntID)
+			continue
+		}
+		cntIDs = append(cntIDs, cntID)
+	}
+	return
+}
+
+// processSySTR removes the spending limit subscription
+func (da *DiameterAgent) processSySTR(m *diam.Message) *diam.Message {
+	sessionID := diamSessionID(m)
+	da.sySubsLck.Lock()
+	_, has := da.sySubs[sessionID]
+	delete(da.sySubs, sessionID)
+	da.sySubsLck.Unlock()
+	if !has {
+		return da.syAnswer(m, sessionID, diam.UnknownSessionID)
+	}
+	return da.syAnswer(m, sessionID, diam.Success)
+}
+
+// policyCounter returns the configuration of the policy counter with the given ID
+func (da *DiameterAgent) policyCounter(cntID string) *config.DiameterPolicyCounterCfg {
+	for _, pc := range da.cgrCfg.DiameterAgentCfg().PolicyCounters {
+		if pc.ID == cntID {
+			return pc
+		}
+	}
+	return nil
+}
+
+// policyCounterStatuses evaluates the subscribed counters against the initial SLR
+func (da *DiameterAgent) policyCounterStatuses(sub *sySubscription) (statuses map[string]string) {
+	statuses = make(map[string]string, len(sub.counterIDs))
+	ev := utils.MapStorage{utils.MetaReq: newDADataProvider(sub.c, sub.m)}
+	tnt := da.cgrCfg.GeneralCfg().DefaultTenant
+	for _, cntID := range sub.counterIDs {
+		pc := da.policyCounter(cntID)
+		if pc == nil { // removed out of config on reload
+			continue
+		}
+		statuses[cntID] = pc.DefaultStatus
+		for _, st := range pc.Statuses {
+			pass, err := da.filterS.Pass(tnt, st.FilterIDs, ev)
+			if err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: %s evaluating status: <%s> of policy counter: <%s>",
+						utils.DiameterAgent, err.Error(), st.Status, cntID))
+				continue
+			}
+			if pass {
+				statuses[cntID] = st.Status
+				break
+			}
+		}
+	}
+	return
+}
+
+// updatePolicyCounters re-evaluates the subscriptions and sends SNR for the counters changing status.
+// Empty sessionIDs will check all of the subscriptions
+func (da *DiameterAgent) updatePolicyCounters(sessionIDs []string) (err error) {
+	da.sySubsLck.RLock()
+	if len(sessionIDs) == 0 {
+		sessionIDs = make([]string, 0, len(da.sySubs))
+		for sessionID := range da.sySubs {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+	subs := make(map[string]*sySubscription, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		if sub, has := da.sySubs[sessionID]; has {
+			subs[sessionID] = &sySubscription{c: sub.c, m: sub.m, counterIDs: sub.counterIDs}
+		}
+	}
+	da.sySubsLck.RUnlock()
+	for sessionID, sub := range subs {
+		statuses := da.policyCounterStatuses(sub) // outside lock since the filters can query other subsystems
+		var changed []string
+		da.sySubsLck.Lock()
+		if crntSub, has := da.sySubs[sessionID]; has {
+			for _, cntID := range crntSub.counterIDs {
+				if status, has := statuses[cntID]; has && status != crntSub.statuses[cntID] {
+					crntSub.statuses[cntID] = status
+					changed = append(changed, cntID)
+				}
+			}
+		}
+		da.sySubsLck.Unlock()
+		if len(changed) == 0 {
+			continue
+		}
+		if errSNR := da.sendSNR(sessionID, sub, changed, statuses); errSNR != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot send SNR for session: <%s>, err: %s",
+					utils.DiameterAgent, sessionID, errSNR.Error()))
+			err = utils.ErrPartiallyExecuted
+		}
+	}
+	return
+}
+
+// sendSNR notifies the PCRF about the counters changing status
+func (da *DiameterAgent) sendSNR(sessionID string, sub *sySubscription,
+	cntIDs []string, statuses map[string]string) (err error) {
+	var c diam.Conn
+	if c, err = da.peerConn(&diamMsgData{c: sub.c, m: sub.m}); err != nil {
+		return
+	}
+	m := diam.NewRequest(syCmdSpendingStatusNotification, diam.DIAMETER_SY_APP_ID, sub.m.Dictionary())
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm))
+	if a, errAVP := sub.m.FindAVP(avp.OriginRealm, dict.UndefinedVendorID); errAVP == nil {
+		m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, a.Data)
+	}
+	if a, errAVP := sub.m.FindAVP(avp.OriginHost, dict.UndefinedVendorID); errAVP == nil {
+		m.NewAVP(avp.DestinationHost, avp.Mbit, 0, a.Data)
+	}
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.DIAMETER_SY_APP_ID))
+	for _, cntID := range cntIDs {
+		m.AddAVP(newPolicyCounterStatusReport(cntID, statuses[cntID]))
+	}
+	return writeOnConn(c, m)
+}
+
+// policyCountersLoop re-evaluates periodically all of the subscriptions
+func (da *DiameterAgent) policyCountersLoop(intvl time.Duration, stopChan <-chan struct{}) {
+	tm := time.NewTicker(intvl)
+	defer tm.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-tm.C:
+			da.updatePolicyCounters(nil)
+		}
+	}
+}
+
+// syAnswer builds the answer for the Sy requests, the 5xxx codes specific to Sy are sent as Experimental-Result
+func (da *DiameterAgent) syAnswer(m *diam.Message, sessionID string, resCode uint32) (a *diam.Message) {
+	if resCode != diamUnknownPolicyCounter {
+		a = m.Answer(resCode)
+	} else {
+		a = m.Answer(0)
+		a.NewAVP(avp.ExperimentalResult, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(sy3GPPVendorID)),
+				diam.NewAVP(avp.ExperimentalResultCode, avp.Mbit, 0, datatype.Unsigned32(resCode)),
+			}})
+	}
+	a.InsertAVP(diam.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID)))
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost))
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm))
+	return
+}
+
+func newPolicyCounterStatusReport(cntID, status string) *diam.AVP {
+	return diam.NewAVP(avpPolicyCounterStatusReport, avp.Mbit|avp.Vbit, sy3GPPVendorID, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avpPolicyCounterIdentifier, avp.Mbit|avp.Vbit, sy3GPPVendorID, datatype.UTF8String(cntID)),
+			diam.NewAVP(avpPolicyCounterStatus, avp.Mbit|avp.Vbit, sy3GPPVendorID, datatype.UTF8String(status)),
+		}})
+}
+
+// diamSessionID returns the Session-Id of the message
+func diamSessionID(m *diam.Message) (sessionID string) {
+	if a, err := m.FindAVP(avp.SessionID, dict.UndefinedVendorID); err == nil {
+		sessionID, _ = diamAVPAsString(a)
+	}
+	return
+}
+
+// diamLogFields returns the fields identifying the diameter message within the structured logs
+func diamLogFields(tnt string, m *diam.Message) utils.LogFields {
+	return utils.LogFields{
+		utils.LogFieldTenant:   tnt,
+		utils.LogFieldOriginID: diamSessionID(m),
+	}
+}
+
+// V1UpdatePolicyCounters re-evaluates the policy counters, sending SNR to the PCRF for the changed ones
+func (da *DiameterAgent) V1UpdatePolicyCounters(args *utils.UpdatePolicyCountersArgs, reply *string) (err error) {
+	if len(da.cgrCfg.DiameterAgentCfg().PolicyCounters) == 0 {
+		return utils.ErrNotImplemented
+	}
+	if err = da.updatePolicyCounters(args.SessionIDs); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,274 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// testSyConn decodes the messages written on it
+type testSyConn struct {
+	*testMockDiamConn
+	msgs chan *diam.Message
+}
+
+func newTestSyConn() *testSyConn {
+	return &testSyConn{
+		testMockDiamConn: newTestMockDiamConn("127.0.0.1:3868"),
+		msgs:             make(chan *diam.Message, 10),
+	}
+}
+
+func (c *testSyConn) Write(b []byte) (int, error) {
+	if m, err := diam.ReadMessage(bytes.NewReader(b), dict.Default); err == nil {
+		c.msgs <- m
+	}
+	return len(b), nil
+}
+
+func (c *testSyConn) WriteStream(b []byte, stream uint) (int, error) { return c.Write(b) }
+
+func (c *testSyConn) lastMsg(t *testing.T) (m *diam.Message) {
+	select {
+	case m = <-c.msgs:
+	default:
+		t.Fatal("no message written")
+	}
+	return
+}
+
+var testSyDictOnce sync.Once
+
+func testSyAgent(t *testing.T) *DiameterAgent {
+	var err error
+	testSyDictOnce.Do(func() { // commands cannot be loaded twice
+		err = dict.Default.LoadFile("../data/diameter/dict/3gpp/sy.xml")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DiameterAgentCfg().OriginHost = "ocs.cgrates.org"
+	cfg.DiameterAgentCfg().OriginRealm = "cgrates.org"
+	cfg.DiameterAgentCfg().PolicyCounters = []*config.DiameterPolicyCounterCfg{
+		{
+			ID:            "DATA_LIMIT",
+			DefaultStatus: "valid",
+			Statuses: []*config.DiameterCounterStatusCfg{{
+				Status:    "exceeded",
+				FilterIDs: []string{"*string:~*req.Subscription-Id.Subscription-Id-Data:1002"},
+			}},
+		},
+		{
+			ID:            "VOICE_LIMIT",
+			DefaultStatus: "valid",
+		},
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	return &DiameterAgent{
+		cgrCfg:  cfg,
+		filterS: engine.NewFilterS(cfg, nil, dm),
+		peers:   make(map[string]diam.Conn),
+		sySubs:  make(map[string]*sySubscription),
+	}
+}
+
+func newTestSLR(sessionID string, reqType int, cntIDs ...string) (m *diam.Message) {
+	m = diam.NewRequest(diam.SpendingLimit, diam.DIAMETER_SY_APP_ID, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("pcrf.cgrates.org"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+	m.NewAVP(avpSLRequestType, avp.Mbit|avp.Vbit, sy3GPPVendorID, datatype.Enumerated(reqType))
+	m.NewAVP(avp.SubscriptionID, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.SubscriptionIDType, avp.Mbit, 0, datatype.Enumerated(0)),
+			diam.NewAVP(avp.SubscriptionIDData, avp.Mbit, 0, datatype.UTF8String("1001")),
+		}})
+	for _, cntID := range cntIDs {
+		m.NewAVP(avpPolicyCounterIdentifier, avp.Mbit|avp.Vbit, sy3GPPVendorID, datatype.UTF8String(cntID))
+	}
+	return
+}
+
+// testSyReports returns the Policy-Counter-Status-Report AVPs as counterID: status
+func testSyReports(t *testing.T, m *diam.Message) (reports map[string]string) {
+	reports = make(map[string]string)
+	avps, err := m.FindAVPs(avpPolicyCounterStatusReport, sy3GPPVendorID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range avps {
+		var cntID, status string
+		for _, gAVP := range a.Data.(*diam.GroupedAVP).AVP {
+			switch gAVP.Code {
+			case avpPolicyCounterIdentifier:
+				cntID, _ = diamAVPAsString(gAVP)
+			case avpPolicyCounterStatus:
+				status, _ = diamAVPAsString(gAVP)
+			}
+		}
+		reports[cntID] = status
+	}
+	return
+}
+
+func testSyResultCode(t *testing.T, m *diam.Message) (resCode uint32) {
+	a, err := m.FindAVP(avp.ResultCode, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uint32(a.Data.(datatype.Unsigned32))
+}
+
+func TestDiamSySLR(t *testing.T) {
+	da := testSyAgent(t)
+	c := newTestSyConn()
+	da.handleSy(c, newTestSLR("sess1", slRequestInitial))
+	a := c.lastMsg(t)
+	if resCode := testSyResultCode(t, a); resCode != diam.Success {
+		t.Errorf("Expected %d, received %d", diam.Success, resCode)
+	}
+	if sessionID := diamSessionID(a); sessionID != "sess1" {
+		t.Errorf("Expected sess1, received %q", sessionID)
+	}
+	exp := map[string]string{"DATA_LIMIT": "valid", "VOICE_LIMIT": "valid"}
+	if rcv := testSyReports(t, a); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	// intermediate request replaces the counters
+	da.handleSy(c, newTestSLR("sess1", slRequestIntermediate, "VOICE_LIMIT"))
+	exp = map[string]string{"VOICE_LIMIT": "valid"}
+	if rcv := testSyReports(t, c.lastMsg(t)); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	if rcv := da.sySubs["sess1"].counterIDs; !reflect.DeepEqual([]string{"VOICE_LIMIT"}, rcv) {
+		t.Errorf("Expected [VOICE_LIMIT], received %+v", rcv)
+	}
+	// intermediate request for a session we do not know
+	da.handleSy(c, newTestSLR("sess2", slRequestIntermediate))
+	if resCode := testSyResultCode(t, c.lastMsg(t)); resCode != diam.UnknownSessionID {
+		t.Errorf("Expected %d, received %d", diam.UnknownSessionID, resCode)
+	}
+}
+
+func TestDiamSySLRUnknownCounter(t *testing.T) {
+	da := testSyAgent(t)
+	c := newTestSyConn()
+	da.handleSy(c, newTestSLR("sess1", slRequestInitial, "DATA_LIMIT", "SMS_LIMIT"))
+	a := c.lastMsg(t)
+	avps, err := a.FindAVPsWithPath([]interface{}{avp.ExperimentalResult, avp.ExperimentalResultCode}, 0)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(avps) != 1 {
+		t.Fatalf("Expected one Experimental-Result-Code, received: %s", a)
+	}
+	if resCode := uint32(avps[0].Data.(datatype.Unsigned32)); resCode != diamUnknownPolicyCounter {
+		t.Errorf("Expected %d, received %d", diamUnknownPolicyCounter, resCode)
+	}
+	if len(da.sySubs) != 0 {
+		t.Errorf("Expected no subscription, received: %+v", da.sySubs)
+	}
+}
+
+func TestDiamSySTR(t *testing.T) {
+	da := testSyAgent(t)
+	c := newTestSyConn()
+	da.handleSy(c, newTestSLR("sess1", slRequestInitial))
+	c.lastMsg(t)
+	str := diam.NewRequest(diam.SessionTermination, diam.DIAMETER_SY_APP_ID, dict.Default)
+	str.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("sess1"))
+	da.handleSy(c, str)
+	if resCode := testSyResultCode(t, c.lastMsg(t)); resCode != diam.Success {
+		t.Errorf("Expected %d, received %d", diam.Success, resCode)
+	}
+	if len(da.sySubs) != 0 {
+		t.Errorf("Expected no subscription, received: %+v", da.sySubs)
+	}
+	da.handleSy(c, str)
+	if resCode := testSyResultCode(t, c.lastMsg(t)); resCode != diam.UnknownSessionID {
+		t.Errorf("Expected %d, received %d", diam.UnknownSessionID, resCode)
+	}
+}
+
+func TestDiamSyUpdatePolicyCounters(t *testing.T) {
+	da := testSyAgent(t)
+	c := newTestSyConn()
+	da.handleSy(c, newTestSLR("sess1", slRequestInitial))
+	c.lastMsg(t)
+	var reply string
+	if err := da.V1UpdatePolicyCounters(&utils.UpdatePolicyCountersArgs{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case m := <-c.msgs:
+		t.Fatalf("no status changed, SNR sent: %s", m)
+	default:
+	}
+	da.cgrCfg.DiameterAgentCfg().PolicyCounters[0].Statuses[0].FilterIDs = []string{
+		"*string:~*req.Subscription-Id.Subscription-Id-Data:1001"}
+	if err := da.V1UpdatePolicyCounters(&utils.UpdatePolicyCountersArgs{SessionIDs: []string{"sess1"}}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected OK, received %q", reply)
+	}
+	snr := c.lastMsg(t)
+	if snr.Header.CommandCode != syCmdSpendingStatusNotification ||
+		snr.Header.CommandFlags&diam.RequestFlag != diam.RequestFlag {
+		t.Errorf("Expected SNR, received: %s", snr)
+	}
+	if sessionID := diamSessionID(snr); sessionID != "sess1" {
+		t.Errorf("Expected sess1, received %q", sessionID)
+	}
+	if a, err := snr.FindAVP(avp.DestinationHost, 0); err != nil {
+		t.Error(err)
+	} else if dst, _ := diamAVPAsString(a); dst != "pcrf.cgrates.org" {
+		t.Errorf("Expected pcrf.cgrates.org, received %q", dst)
+	}
+	exp := map[string]string{"DATA_LIMIT": "exceeded"} // only the changed counters
+	if rcv := testSyReports(t, snr); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	// SNA is ignored
+	da.handleSy(c, snr.Answer(diam.Success))
+	select {
+	case m := <-c.msgs:
+		t.Fatalf("unexpected answer to SNA: %s", m)
+	default:
+	}
+}
+
+func TestDiamSyUpdatePolicyCountersDisabled(t *testing.T) {
+	da := testSyAgent(t)
+	da.cgrCfg.DiameterAgentCfg().PolicyCounters = nil
+	var reply string
+	if err := da.V1UpdatePolicyCounters(&utils.UpdatePolicyCountersArgs{}, &reply); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+}
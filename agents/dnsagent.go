@@ -91,12 +91,13 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 	dnsDP := newDNSDataProvider(req, w)
 	reqVars := make(utils.NavigableMap2)
 	reqVars[QueryType] = utils.NewNMData(dns.TypeToString[req.Question[0].Qtype])
+	reqVars[QueryName] = utils.NewNMData(req.Question[0].Name)
 	rply := new(dns.Msg)
 	rply.SetReply(req)
+	setDNSReplyEDNS0(req, rply)
 	// message preprocesing
 	switch req.Question[0].Qtype {
 	case dns.TypeNAPTR:
-		reqVars[QueryName] = utils.NewNMData(req.Question[0].Name)
 		e164, err := e164FromNAPTR(req.Question[0].Name)
 		if err != nil {
 			utils.Logger.Warning(
@@ -160,6 +161,7 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 	if err = dnsWriteMsg(w, rply); err != nil { // failed sending, most probably content issue
 		rply = new(dns.Msg)
 		rply.SetReply(req)
+		setDNSReplyEDNS0(req, rply)
 		rply.Rcode = dns.RcodeServerFailure
 		dnsWriteMsg(w, rply)
 	}
@@ -192,24 +194,28 @@ func (da *DNSAgent) processRequest(reqProcessor *config.RequestProcessor,
 		reqType == utils.MetaMessage ||
 		reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
-				utils.DNSAgent, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction failed because <%s>",
+					utils.DNSAgent, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, processorID: <%s>, message: %s",
-				utils.DNSAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.DNSAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, CGREvent: %s",
-				utils.DNSAgent, reqProcessor.ID, utils.ToJSON(cgrEv)))
+				utils.DNSAgent, reqProcessor.ID, utils.ToJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -346,14 +352,16 @@ func (da *DNSAgent) processRequest(reqProcessor *config.RequestProcessor,
 		return false, err
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, reply: %s",
-				utils.DNSAgent, agReq.Reply))
+				utils.DNSAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	if reqType == utils.MetaDryRun {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, reply: %s",
-				utils.DNSAgent, agReq.Reply))
+				utils.DNSAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	return true, nil
 }
@@ -149,9 +149,10 @@ func (fsa *FSsessions) onChannelPark(fsev FSEvent, connIdx int) {
 	authArgs.CGREvent.Event[FsConnID] = connIdx // Attach the connection ID
 	var authReply sessions.V1AuthorizeReply
 	if err := fsa.connMgr.Call(fsa.cfg.SessionSConns, fsa, utils.SessionSv1AuthorizeEvent, authArgs, &authReply); err != nil {
-		utils.Logger.Err(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 			fmt.Sprintf("<%s> Could not authorize event %s, error: %s",
-				utils.FreeSWITCHAgent, fsev.GetUUID(), err.Error()))
+				utils.FreeSWITCHAgent, fsev.GetUUID(), err.Error()),
+			engine.MapEvent(authArgs.CGREvent.Event).LogFields(authArgs.CGREvent.Tenant))
 		fsa.unparkCall(fsev.GetUUID(), connIdx,
 			fsev.GetCallDestNr(utils.MetaDefault), err.Error())
 		return
@@ -240,9 +241,10 @@ func (fsa *FSsessions) onChannelAnswer(fsev FSEvent, connIdx int) {
 	var initReply sessions.V1InitSessionReply
 	if err := fsa.connMgr.Call(fsa.cfg.SessionSConns, fsa, utils.SessionSv1InitiateSession,
 		initSessionArgs, &initReply); err != nil {
-		utils.Logger.Err(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 			fmt.Sprintf("<%s> could not process answer for event %s, error: %s",
-				utils.FreeSWITCHAgent, chanUUID, err.Error()))
+				utils.FreeSWITCHAgent, chanUUID, err.Error()),
+			engine.MapEvent(initSessionArgs.CGREvent.Event).LogFields(initSessionArgs.CGREvent.Tenant))
 		fsa.disconnectSession(connIdx, chanUUID, "", err.Error())
 		return
 	}
@@ -264,9 +266,10 @@ func (fsa *FSsessions) onChannelHangupComplete(fsev FSEvent, connIdx int) {
 		terminateSessionArgs.CGREvent.Event[FsConnID] = connIdx // Attach the connection ID in case we need to create a session and disconnect it
 		if err := fsa.connMgr.Call(fsa.cfg.SessionSConns, fsa, utils.SessionSv1TerminateSession,
 			terminateSessionArgs, &reply); err != nil {
-			utils.Logger.Err(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 				fmt.Sprintf("<%s> Could not terminate session with event %s, error: %s",
-					utils.FreeSWITCHAgent, fsev.GetUUID(), err.Error()))
+					utils.FreeSWITCHAgent, fsev.GetUUID(), err.Error()),
+				engine.MapEvent(terminateSessionArgs.CGREvent.Event).LogFields(terminateSessionArgs.CGREvent.Tenant))
 		}
 	}
 	if fsa.cfg.CreateCdr {
@@ -276,8 +279,10 @@ func (fsa *FSsessions) onChannelHangupComplete(fsev FSEvent, connIdx int) {
 		}
 		if err := fsa.connMgr.Call(fsa.cfg.SessionSConns, fsa, utils.SessionSv1ProcessCDR,
 			cgrEv, &reply); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> Failed processing CGREvent: %s,  error: <%s>",
-				utils.FreeSWITCHAgent, utils.ToJSON(cgrEv), err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> Failed processing CGREvent: %s,  error: <%s>",
+					utils.FreeSWITCHAgent, utils.ToJSON(cgrEv), err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 		}
 	}
 }
@@ -129,24 +129,28 @@ func (ha *HTTPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		reqType == utils.MetaMessage ||
 		reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
-				utils.HTTPAgent, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction failed because <%s>",
+					utils.HTTPAgent, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, processorID: %s, http message: %s",
-				utils.HTTPAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.HTTPAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, CGREvent: %s",
-				utils.HTTPAgent, reqProcessor.ID, utils.ToJSON(cgrEv)))
+				utils.HTTPAgent, reqProcessor.ID, utils.ToJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -276,14 +280,16 @@ func (ha *HTTPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		return false, err
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, HTTP reply: %s",
-				utils.HTTPAgent, agReq.Reply))
+				utils.HTTPAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	if reqType == utils.MetaDryRun {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, HTTP reply: %s",
-				utils.HTTPAgent, agReq.Reply))
+				utils.HTTPAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	return true, nil
 }
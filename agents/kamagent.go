@@ -128,18 +128,24 @@ func (ka *KamailioAgent) onCgrAuth(evData []byte, connIdx int) {
 	}
 	if kev.MissingParameter() {
 		if kRply, err := kev.AsKamAuthReply(nil, nil, utils.ErrMandatoryIeMissing); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed building auth reply for event: %s, error: %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed building auth reply for event: %s, error: %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		} else if err = ka.conns[connIdx].Send(kRply.String()); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed sending auth reply for event: %s, error %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed sending auth reply for event: %s, error %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		}
 		return
 	}
 	authArgs := kev.V1AuthorizeArgs()
 	if authArgs == nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate auth session arguments",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> event: %s cannot generate auth session arguments",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	authArgs.CGREvent.Event[EvapiConnID] = connIdx // Attach the connection ID
@@ -148,11 +154,15 @@ func (ka *KamailioAgent) onCgrAuth(evData []byte, connIdx int) {
 	// and send it as parameter to AsKamAuthReply
 	err = ka.connMgr.Call(ka.cfg.SessionSConns, ka, utils.SessionSv1AuthorizeEvent, authArgs, &authReply)
 	if kar, err := kev.AsKamAuthReply(authArgs, &authReply, err); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed building auth reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed building auth reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	} else if err = ka.conns[connIdx].Send(kar.String()); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	}
 }
 
@@ -179,8 +189,10 @@ func (ka *KamailioAgent) onCallStart(evData []byte, connIdx int) {
 	}
 	initSessionArgs := kev.V1InitSessionArgs()
 	if initSessionArgs == nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate init session arguments",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> event: %s cannot generate init session arguments",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	initSessionArgs.CGREvent.Event[EvapiConnID] = connIdx // Attach the connection ID so we can properly disconnect later
@@ -188,9 +200,10 @@ func (ka *KamailioAgent) onCallStart(evData []byte, connIdx int) {
 	var initReply sessions.V1InitSessionReply
 	if err := ka.connMgr.Call(ka.cfg.SessionSConns, ka, utils.SessionSv1InitiateSession,
 		initSessionArgs, &initReply); err != nil {
-		utils.Logger.Err(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 			fmt.Sprintf("<%s> could not process answer for event %s, error: %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 		ka.disconnectSession(connIdx,
 			NewKamSessionDisconnect(kev[KamHashEntry], kev[KamHashID],
 				utils.ErrServerError.Error()))
@@ -214,30 +227,37 @@ func (ka *KamailioAgent) onCallEnd(evData []byte, connIdx int) {
 		return
 	}
 	if kev.MissingParameter() {
-		utils.Logger.Err(fmt.Sprintf("<%s> mandatory IE missing out from event: %s",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> mandatory IE missing out from event: %s",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	tsArgs := kev.V1TerminateSessionArgs()
 	if tsArgs == nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate terminate session arguments",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> event: %s cannot generate terminate session arguments",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	var reply string
 	tsArgs.CGREvent.Event[EvapiConnID] = connIdx // Attach the connection ID in case we need to create a session and disconnect it
 	if err := ka.connMgr.Call(ka.cfg.SessionSConns, ka, utils.SessionSv1TerminateSession,
 		tsArgs, &reply); err != nil {
-		utils.Logger.Err(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
 			fmt.Sprintf("<%s> could not terminate session with event %s, error: %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 		// no return here since we want CDR anyhow
 	}
 	if ka.cfg.CreateCdr || strings.Index(kev[utils.CGRFlags], utils.MetaCDRs) != -1 {
 		if err := ka.connMgr.Call(ka.cfg.SessionSConns, ka, utils.SessionSv1ProcessCDR,
 			tsArgs.CGREvent, &reply); err != nil {
-			utils.Logger.Err(fmt.Sprintf("%s> failed processing CGREvent: %s, error: %s",
-				utils.KamailioAgent, utils.ToJSON(tsArgs.CGREvent), err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("%s> failed processing CGREvent: %s, error: %s",
+					utils.KamailioAgent, utils.ToJSON(tsArgs.CGREvent), err.Error()),
+				kev.logFields())
 		}
 	}
 }
@@ -275,11 +295,15 @@ func (ka *KamailioAgent) onCgrProcessMessage(evData []byte, connIdx int) {
 
 	if kev.MissingParameter() {
 		if kRply, err := kev.AsKamProcessMessageReply(nil, nil, utils.ErrMandatoryIeMissing); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		} else if err = ka.conns[connIdx].Send(kRply.String()); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed sending process session event reply for event: %s, error %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed sending process session event reply for event: %s, error %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		}
 		return
 	}
@@ -288,15 +312,19 @@ func (ka *KamailioAgent) onCgrProcessMessage(evData []byte, connIdx int) {
 	//we consider this as ping-pong event
 	if _, has := kev[utils.CGRFlags]; !has {
 		if err = ka.conns[connIdx].Send(kev.AsKamProcessMessageEmptyReply().String()); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed sending empty process message reply for event: %s, error %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed sending empty process message reply for event: %s, error %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		}
 	}
 
 	procEvArgs := kev.V1ProcessMessageArgs()
 	if procEvArgs == nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate process message session arguments",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> event: %s cannot generate process message session arguments",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	procEvArgs.CGREvent.Event[EvapiConnID] = connIdx // Attach the connection ID
@@ -306,11 +334,15 @@ func (ka *KamailioAgent) onCgrProcessMessage(evData []byte, connIdx int) {
 	// take the error after calling SessionSv1.ProcessMessage
 	// and send it as parameter to AsKamProcessMessageReply
 	if kar, err := kev.AsKamProcessMessageReply(procEvArgs, &processReply, err); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	} else if err = ka.conns[connIdx].Send(kar.String()); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	}
 }
 
@@ -329,19 +361,25 @@ func (ka *KamailioAgent) onCgrProcessCDR(evData []byte, connIdx int) {
 
 	if kev.MissingParameter() {
 		if kRply, err := kev.AsKamProcessCDRReply(nil, nil, utils.ErrMandatoryIeMissing); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		} else if err = ka.conns[connIdx].Send(kRply.String()); err != nil {
-			utils.Logger.Err(fmt.Sprintf("<%s> failed sending process session event reply for event: %s, error %s",
-				utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+				fmt.Sprintf("<%s> failed sending process session event reply for event: %s, error %s",
+					utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+				kev.logFields())
 		}
 		return
 	}
 
 	procCDRArgs := kev.V1ProcessCDRArgs()
 	if procCDRArgs == nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> event: %s cannot generate process cdr session arguments",
-			utils.KamailioAgent, kev[utils.OriginID]))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> event: %s cannot generate process cdr session arguments",
+				utils.KamailioAgent, kev[utils.OriginID]),
+			kev.logFields())
 		return
 	}
 	procCDRArgs.Event[EvapiConnID] = connIdx // Attach the connection ID
@@ -351,11 +389,15 @@ func (ka *KamailioAgent) onCgrProcessCDR(evData []byte, connIdx int) {
 	// take the error after calling SessionSv1.ProcessCDR
 	// and send it as parameter to AsKamProcessCDRReply
 	if kar, err := kev.AsKamProcessCDRReply(procCDRArgs, &processReply, err); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed building process session event reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	} else if err = ka.conns[connIdx].Send(kar.String()); err != nil {
-		utils.Logger.Err(fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
-			utils.KamailioAgent, kev[utils.OriginID], err.Error()))
+		utils.Logger.LogWithFields(utils.LOGLEVEL_ERROR,
+			fmt.Sprintf("<%s> failed sending auth reply for event: %s, error: %s",
+				utils.KamailioAgent, kev[utils.OriginID], err.Error()),
+			kev.logFields())
 	}
 }
 
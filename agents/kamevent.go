@@ -199,6 +199,15 @@ func (kev KamEvent) String() string {
 	return utils.ToJSON(kev)
 }
 
+// logFields returns the fields identifying the event within the structured logs
+func (kev KamEvent) logFields() utils.LogFields {
+	return utils.LogFields{
+		utils.LogFieldTenant: utils.FirstNonEmpty(kev[utils.Tenant],
+			config.CgrConfig().GeneralCfg().DefaultTenant),
+		utils.LogFieldOriginID: kev[utils.OriginID],
+	}
+}
+
 // V1AuthorizeArgs returns the arguments used in SessionSv1.AuthorizeEvent
 func (kev KamEvent) V1AuthorizeArgs() (args *sessions.V1AuthorizeArgs) {
 	cgrEv, err := kev.AsCGREvent(config.CgrConfig().GeneralCfg().DefaultTimezone)
@@ -105,14 +105,59 @@ func (dP *dnsDP) FieldAsInterface(fldPath []string) (data interface{}, err error
 		return // data was found in cache
 	}
 	data = ""
-	// Return Question[0] by default
-	if len(dP.req.Question) != 0 {
-		data = dP.req.Question[0]
+	switch fldPath[0] {
+	case utils.ClientSubnet:
+		var subnet *dns.EDNS0_SUBNET
+		if subnet = dnsClientSubnet(dP.req); subnet == nil {
+			return nil, utils.ErrNotFound
+		}
+		data = (&net.IPNet{
+			IP:   subnet.Address,
+			Mask: net.CIDRMask(int(subnet.SourceNetmask), len(subnet.Address)*8),
+		}).String()
+	default: // Return Question[0] by default
+		if len(dP.req.Question) != 0 {
+			data = dP.req.Question[0]
+		}
 	}
 	dP.cache.Set(fldPath, data)
 	return
 }
 
+// dnsClientSubnet returns the EDNS0 client subnet option of the message or nil if not present
+func dnsClientSubnet(msg *dns.Msg) *dns.EDNS0_SUBNET {
+	opt := msg.IsEdns0()
+	if opt == nil {
+		return nil
+	}
+	for _, o := range opt.Option {
+		if subnet, has := o.(*dns.EDNS0_SUBNET); has {
+			return subnet
+		}
+	}
+	return nil
+}
+
+// setDNSReplyEDNS0 will add the EDNS0 OPT record to the reply if the request contains one
+// the client subnet is echoed back with the scope of the source netmask since the answer is computed for it
+func setDNSReplyEDNS0(req, rply *dns.Msg) {
+	opt := req.IsEdns0()
+	if opt == nil {
+		return
+	}
+	rply.SetEdns0(opt.UDPSize(), opt.Do())
+	if subnet := dnsClientSubnet(req); subnet != nil {
+		rplyOpt := rply.IsEdns0()
+		rplyOpt.Option = append(rplyOpt.Option, &dns.EDNS0_SUBNET{
+			Code:          dns.EDNS0SUBNET,
+			Family:        subnet.Family,
+			SourceNetmask: subnet.SourceNetmask,
+			SourceScope:   subnet.SourceNetmask,
+			Address:       subnet.Address,
+		})
+	}
+}
+
 // dnsWriteErr writes the error with code back to the client
 func dnsWriteMsg(w dns.ResponseWriter, msg *dns.Msg) (err error) {
 	if err = w.WriteMsg(msg); err != nil {
@@ -125,34 +170,36 @@ func dnsWriteMsg(w dns.ResponseWriter, msg *dns.Msg) (err error) {
 
 // appendDNSAnswer will append the right answer payload to the message
 func appendDNSAnswer(msg *dns.Msg) (err error) {
+	hdr := dns.RR_Header{
+		Name:   msg.Question[0].Name,
+		Rrtype: msg.Question[0].Qtype,
+		Class:  dns.ClassINET,
+		Ttl:    60}
+	var rr dns.RR
 	switch msg.Question[0].Qtype {
 	case dns.TypeA:
-		msg.Answer = append(msg.Answer,
-			&dns.A{
-				Hdr: dns.RR_Header{
-					Name:   msg.Question[0].Name,
-					Rrtype: dns.TypeA,
-					Class:  dns.ClassINET,
-					Ttl:    60},
-			},
-		)
+		rr = &dns.A{Hdr: hdr}
+	case dns.TypeAAAA:
+		rr = &dns.AAAA{Hdr: hdr}
 	case dns.TypeNAPTR:
-		msg.Answer = append(msg.Answer,
-			&dns.NAPTR{
-				Hdr: dns.RR_Header{
-					Name:   msg.Question[0].Name,
-					Rrtype: msg.Question[0].Qtype,
-					Class:  dns.ClassINET,
-					Ttl:    60},
-			},
-		)
+		rr = &dns.NAPTR{Hdr: hdr}
+	case dns.TypeSRV:
+		rr = &dns.SRV{Hdr: hdr}
+	case dns.TypeTXT:
+		rr = &dns.TXT{Hdr: hdr}
+	case dns.TypeCNAME:
+		rr = &dns.CNAME{Hdr: hdr}
+	case dns.TypePTR:
+		rr = &dns.PTR{Hdr: hdr}
 	default:
 		return fmt.Errorf("unsupported DNS type: <%v>", msg.Question[0].Qtype)
 	}
+	msg.Answer = append(msg.Answer, rr)
 	return
 }
 
 // updateDNSMsgFromNM will update DNS message with values from NavigableMap
+// a new answer is appended each time a field already populated is received again
 func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap) (err error) {
 	msgFields := make(utils.StringSet) // work around to NMap issue
 	for el := nm.GetFirstElement(); el != nil; el = el.Next() {
@@ -178,56 +225,117 @@ func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap) (err error)
 			}
 			msgFields = make(utils.StringSet) // reset the fields inside since we have a new message
 		}
-		itmData := cfgItm.Data
-		switch cfgItm.Path[0] {
-		case utils.Rcode:
-			var itm int64
-			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
-				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
-			}
-			msg.Rcode = int(itm)
-		case utils.Order:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Order)
-			}
-			var itm int64
-			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
-				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
-			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Order = uint16(itm)
-		case utils.Preference:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Preference)
-			}
-			var itm int64
-			if itm, err = utils.IfaceAsInt64(itmData); err != nil {
-				return fmt.Errorf("item: <%s>, err: %s", cfgItm.Path[0], err.Error())
-			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Preference = uint16(itm)
-		case utils.Flags:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Flags)
-			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Flags = utils.IfaceAsString(itmData)
-		case utils.Service:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Service)
-			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Service = utils.IfaceAsString(itmData)
-		case utils.Regexp:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Regexp)
+		if err = updateDNSAnswer(msg, cfgItm.Path[0], cfgItm.Data); err != nil {
+			return
+		}
+		msgFields.Add(cfgItm.Path[0]) // detect new branch
+
+	}
+	return
+}
+
+// updateDNSAnswer will populate the field of the last answer in the message
+func updateDNSAnswer(msg *dns.Msg, fldName string, itmData interface{}) (err error) {
+	ans := msg.Answer[len(msg.Answer)-1]
+	switch fldName {
+	case utils.Rcode:
+		var itm int64
+		if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+			return fmt.Errorf("item: <%s>, err: %s", fldName, err.Error())
+		}
+		msg.Rcode = int(itm)
+	case utils.TTL:
+		var itm int64
+		if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+			return fmt.Errorf("item: <%s>, err: %s", fldName, err.Error())
+		}
+		ans.Header().Ttl = uint32(itm)
+	case utils.Order, utils.Preference, utils.Flags,
+		utils.Service, utils.Regexp, utils.Replacement:
+		naptr, isNAPTR := ans.(*dns.NAPTR)
+		if !isNAPTR {
+			return fmt.Errorf("field <%s> only works with NAPTR", fldName)
+		}
+		return updateDNSNAPTRAnswer(naptr, fldName, itmData)
+	case utils.Priority, utils.Weight, utils.Port:
+		srv, isSRV := ans.(*dns.SRV)
+		if !isSRV {
+			return fmt.Errorf("field <%s> only works with SRV", fldName)
+		}
+		var itm int64
+		if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+			return fmt.Errorf("item: <%s>, err: %s", fldName, err.Error())
+		}
+		switch fldName {
+		case utils.Priority:
+			srv.Priority = uint16(itm)
+		case utils.Weight:
+			srv.Weight = uint16(itm)
+		case utils.Port:
+			srv.Port = uint16(itm)
+		}
+	case utils.Target:
+		switch rr := ans.(type) {
+		case *dns.SRV:
+			rr.Target = dns.Fqdn(utils.IfaceAsString(itmData))
+		case *dns.CNAME:
+			rr.Target = dns.Fqdn(utils.IfaceAsString(itmData))
+		default:
+			return fmt.Errorf("field <%s> only works with SRV or CNAME", fldName)
+		}
+	case utils.Address:
+		ip := net.ParseIP(utils.IfaceAsString(itmData))
+		switch rr := ans.(type) {
+		case *dns.A:
+			if ip = ip.To4(); ip == nil {
+				return fmt.Errorf("item: <%s>, err: invalid IPv4 address: <%s>", fldName, utils.IfaceAsString(itmData))
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Regexp = utils.IfaceAsString(itmData)
-		case utils.Replacement:
-			if msg.Question[0].Qtype != dns.TypeNAPTR {
-				return fmt.Errorf("field <%s> only works with NAPTR", utils.Replacement)
+			rr.A = ip
+		case *dns.AAAA:
+			if ip == nil {
+				return fmt.Errorf("item: <%s>, err: invalid IPv6 address: <%s>", fldName, utils.IfaceAsString(itmData))
 			}
-			msg.Answer[len(msg.Answer)-1].(*dns.NAPTR).Replacement = utils.IfaceAsString(itmData)
+			rr.AAAA = ip
+		default:
+			return fmt.Errorf("field <%s> only works with A or AAAA", fldName)
 		}
+	case utils.Txt:
+		txt, isTXT := ans.(*dns.TXT)
+		if !isTXT {
+			return fmt.Errorf("field <%s> only works with TXT", fldName)
+		}
+		txt.Txt = append(txt.Txt, utils.IfaceAsString(itmData))
+	case utils.Ptr:
+		ptr, isPTR := ans.(*dns.PTR)
+		if !isPTR {
+			return fmt.Errorf("field <%s> only works with PTR", fldName)
+		}
+		ptr.Ptr = dns.Fqdn(utils.IfaceAsString(itmData))
+	}
+	return
+}
 
-		msgFields.Add(cfgItm.Path[0]) // detect new branch
-
+// updateDNSNAPTRAnswer will populate the NAPTR specific fields
+func updateDNSNAPTRAnswer(naptr *dns.NAPTR, fldName string, itmData interface{}) (err error) {
+	switch fldName {
+	case utils.Order, utils.Preference:
+		var itm int64
+		if itm, err = utils.IfaceAsInt64(itmData); err != nil {
+			return fmt.Errorf("item: <%s>, err: %s", fldName, err.Error())
+		}
+		if fldName == utils.Order {
+			naptr.Order = uint16(itm)
+		} else {
+			naptr.Preference = uint16(itm)
+		}
+	case utils.Flags:
+		naptr.Flags = utils.IfaceAsString(itmData)
+	case utils.Service:
+		naptr.Service = utils.IfaceAsString(itmData)
+	case utils.Regexp:
+		naptr.Regexp = utils.IfaceAsString(itmData)
+	case utils.Replacement:
+		naptr.Replacement = utils.IfaceAsString(itmData)
 	}
 	return
 }
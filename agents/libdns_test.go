@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"net"
 	"reflect"
 	"strings"
 	"testing"
@@ -339,3 +340,210 @@ func TestUpdateDNSMsgFromNM(t *testing.T) {
 	}
 
 }
+
+func TestAppendDNSAnswerExtendedTypes(t *testing.T) {
+	for qType, expType := range map[uint16]dns.RR{
+		dns.TypeAAAA:  new(dns.AAAA),
+		dns.TypeSRV:   new(dns.SRV),
+		dns.TypeTXT:   new(dns.TXT),
+		dns.TypeCNAME: new(dns.CNAME),
+		dns.TypePTR:   new(dns.PTR),
+	} {
+		m := new(dns.Msg)
+		m.SetQuestion("cgrates.org.", qType)
+		if err := appendDNSAnswer(m); err != nil {
+			t.Fatal(err)
+		}
+		if len(m.Answer) != 1 {
+			t.Fatalf("Unexpected number of Answers : %+v", len(m.Answer))
+		} else if reflect.TypeOf(m.Answer[0]) != reflect.TypeOf(expType) {
+			t.Errorf("expecting: <%T>, received: <%T>", expType, m.Answer[0])
+		} else if m.Answer[0].Header().Rrtype != qType {
+			t.Errorf("expecting: <%+v>, received: <%+v>", qType, m.Answer[0].Header().Rrtype)
+		} else if m.Answer[0].Header().Ttl != 60 {
+			t.Errorf("expecting: <60>, received: <%+v>", m.Answer[0].Header().Ttl)
+		}
+	}
+}
+
+// newDNSTestNM builds the reply NavigableMap out of the given path and value pairs
+func newDNSTestNM(itms ...*config.NMItem) (nM *utils.OrderedNavigableMap) {
+	nM = utils.NewOrderedNavigableMap()
+	for _, itm := range itms {
+		nM.Set(&utils.FullPath{
+			Path:      strings.Join(itm.Path, utils.NestingSep),
+			PathItems: utils.NewPathItems(itm.Path),
+		}, &utils.NMSlice{itm})
+	}
+	return
+}
+
+func TestUpdateDNSMsgFromNMSRV(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("_sip._udp.cgrates.org.", dns.TypeSRV)
+	nM := utils.NewOrderedNavigableMap()
+	for i, route := range []string{"sip1.cgrates.org", "sip2.cgrates.org"} { // same paths generate new answers
+		for _, itm := range []*config.NMItem{
+			{Path: []string{utils.Priority}, Data: i + 1},
+			{Path: []string{utils.Weight}, Data: "10"},
+			{Path: []string{utils.Port}, Data: 5060},
+			{Path: []string{utils.Target}, Data: route},
+			{Path: []string{utils.TTL}, Data: 300},
+		} {
+			if err := utils.AppendNavMapVal(nM, &utils.FullPath{
+				Path:      strings.Join(itm.Path, utils.NestingSep),
+				PathItems: utils.NewPathItems(itm.Path),
+			}, itm); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := updateDNSMsgFromNM(m, nM); err != nil {
+		t.Fatal(err)
+	}
+	exp := []dns.RR{
+		&dns.SRV{
+			Hdr:      dns.RR_Header{Name: "_sip._udp.cgrates.org.", Rrtype: dns.TypeSRV, Class: dns.ClassINET, Ttl: 300},
+			Priority: 1, Weight: 10, Port: 5060, Target: "sip1.cgrates.org.",
+		},
+		&dns.SRV{
+			Hdr:      dns.RR_Header{Name: "_sip._udp.cgrates.org.", Rrtype: dns.TypeSRV, Class: dns.ClassINET, Ttl: 300},
+			Priority: 2, Weight: 10, Port: 5060, Target: "sip2.cgrates.org.",
+		},
+	}
+	if !reflect.DeepEqual(exp, m.Answer) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(m.Answer))
+	}
+
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Port}, Data: "RandomValue"})); err == nil ||
+		err.Error() != `item: <Port>, err: strconv.ParseInt: parsing "RandomValue": invalid syntax` {
+		t.Error(err)
+	}
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Txt}, Data: "text"})); err == nil ||
+		err.Error() != `field <Txt> only works with TXT` {
+		t.Error(err)
+	}
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Order}, Data: 10})); err == nil ||
+		err.Error() != `field <Order> only works with NAPTR` {
+		t.Error(err)
+	}
+}
+
+func TestUpdateDNSMsgFromNMExtendedTypes(t *testing.T) {
+	for qType, tc := range map[uint16]struct {
+		itms []*config.NMItem
+		exp  dns.RR
+	}{
+		dns.TypeA: {
+			itms: []*config.NMItem{{Path: []string{utils.Address}, Data: "192.168.56.203"}},
+			exp:  &dns.A{A: net.ParseIP("192.168.56.203").To4()},
+		},
+		dns.TypeAAAA: {
+			itms: []*config.NMItem{{Path: []string{utils.Address}, Data: "2001:db8::1"}},
+			exp:  &dns.AAAA{AAAA: net.ParseIP("2001:db8::1")},
+		},
+		dns.TypeTXT: {
+			itms: []*config.NMItem{
+				{Path: []string{utils.Txt}, Data: "v=spf1 -all"},
+			},
+			exp: &dns.TXT{Txt: []string{"v=spf1 -all"}},
+		},
+		dns.TypeCNAME: {
+			itms: []*config.NMItem{{Path: []string{utils.Target}, Data: "sip.cgrates.org"}},
+			exp:  &dns.CNAME{Target: "sip.cgrates.org."},
+		},
+		dns.TypePTR: {
+			itms: []*config.NMItem{{Path: []string{utils.Ptr}, Data: "sip.cgrates.org."}},
+			exp:  &dns.PTR{Ptr: "sip.cgrates.org."},
+		},
+	} {
+		m := new(dns.Msg)
+		m.SetQuestion("cgrates.org.", qType)
+		if err := updateDNSMsgFromNM(m, newDNSTestNM(tc.itms...)); err != nil {
+			t.Fatal(err)
+		}
+		*tc.exp.Header() = dns.RR_Header{Name: "cgrates.org.", Rrtype: qType, Class: dns.ClassINET, Ttl: 60}
+		if len(m.Answer) != 1 {
+			t.Fatalf("Unexpected number of Answers : %+v", len(m.Answer))
+		} else if !reflect.DeepEqual(tc.exp, m.Answer[0]) {
+			t.Errorf("expecting: %s, received: %s", utils.ToJSON(tc.exp), utils.ToJSON(m.Answer[0]))
+		}
+	}
+
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeA)
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Address}, Data: "2001:db8::1"})); err == nil ||
+		err.Error() != `item: <Address>, err: invalid IPv4 address: <2001:db8::1>` {
+		t.Error(err)
+	}
+	m = new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeTXT)
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Address}, Data: "192.168.56.203"})); err == nil ||
+		err.Error() != `field <Address> only works with A or AAAA` {
+		t.Error(err)
+	}
+	m = new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypePTR)
+	if err := updateDNSMsgFromNM(m, newDNSTestNM(&config.NMItem{Path: []string{utils.Target}, Data: "sip.cgrates.org"})); err == nil ||
+		err.Error() != `field <Target> only works with SRV or CNAME` {
+		t.Error(err)
+	}
+}
+
+func TestDNSDPFieldAsInterfaceClientSubnet(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("cgrates.org.", dns.TypeA)
+	dp := newDNSDataProvider(m, nil)
+	if _, err := dp.FieldAsInterface([]string{utils.ClientSubnet}); err != utils.ErrNotFound {
+		t.Errorf("expecting: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+	m.SetEdns0(4096, false)
+	m.IsEdns0().Option = append(m.IsEdns0().Option, &dns.EDNS0_SUBNET{
+		Code:          dns.EDNS0SUBNET,
+		Family:        1,
+		SourceNetmask: 24,
+		Address:       net.ParseIP("192.168.56.0").To4(),
+	})
+	dp = newDNSDataProvider(m, nil)
+	if data, err := dp.FieldAsString([]string{utils.ClientSubnet}); err != nil {
+		t.Error(err)
+	} else if data != "192.168.56.0/24" {
+		t.Errorf("expecting: <192.168.56.0/24>, received: <%+v>", data)
+	}
+}
+
+func TestSetDNSReplyEDNS0(t *testing.T) {
+	req := new(dns.Msg)
+	req.SetQuestion("cgrates.org.", dns.TypeA)
+	rply := new(dns.Msg)
+	rply.SetReply(req)
+	if setDNSReplyEDNS0(req, rply); rply.IsEdns0() != nil {
+		t.Errorf("expecting no OPT record, received: %s", utils.ToJSON(rply.Extra))
+	}
+	req.SetEdns0(4096, true)
+	req.IsEdns0().Option = append(req.IsEdns0().Option, &dns.EDNS0_SUBNET{
+		Code:          dns.EDNS0SUBNET,
+		Family:        2,
+		SourceNetmask: 56,
+		Address:       net.ParseIP("2001:db8::"),
+	})
+	rply = new(dns.Msg)
+	rply.SetReply(req)
+	setDNSReplyEDNS0(req, rply)
+	opt := rply.IsEdns0()
+	if opt == nil {
+		t.Fatal("expecting OPT record in reply")
+	} else if opt.UDPSize() != 4096 || !opt.Do() {
+		t.Errorf("expecting UDPSize: 4096 and DO bit, received: %+v", utils.ToJSON(opt))
+	}
+	exp := []dns.EDNS0{&dns.EDNS0_SUBNET{
+		Code:          dns.EDNS0SUBNET,
+		Family:        2,
+		SourceNetmask: 56,
+		SourceScope:   56,
+		Address:       net.ParseIP("2001:db8::"),
+	}}
+	if !reflect.DeepEqual(exp, opt.Option) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(opt.Option))
+	}
+}
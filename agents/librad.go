@@ -19,8 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
@@ -188,3 +192,88 @@ func radauthReq(flags utils.FlagsWithParams, req *radigo.Packet, aReq *AgentRequ
 
 	return true, nil
 }
+
+// Dynamic Authorization packet codes as defined in RFC 5176
+const (
+	radDisconnectRequest radigo.PacketCode = 40
+	radDisconnectACK     radigo.PacketCode = 41
+	radDisconnectNAK     radigo.PacketCode = 42
+	radCoARequest        radigo.PacketCode = 43
+	radCoAACK            radigo.PacketCode = 44
+	radCoANAK            radigo.PacketCode = 45
+
+	radDAPort = "3799" // default port of the Dynamic Authorization Server
+)
+
+// radDACodeName returns the name of the Dynamic Authorization packet code
+func radDACodeName(code radigo.PacketCode) string {
+	switch code {
+	case radDisconnectRequest:
+		return "Disconnect-Request"
+	case radDisconnectACK:
+		return "Disconnect-ACK"
+	case radDisconnectNAK:
+		return "Disconnect-NAK"
+	case radCoARequest:
+		return "CoA-Request"
+	case radCoAACK:
+		return "CoA-ACK"
+	case radCoANAK:
+		return "CoA-NAK"
+	}
+	return code.String()
+}
+
+// radDAAuthenticator computes the authenticator of the Dynamic Authorization packet
+// reqAuth is empty for requests and the authenticator of the request for replies
+func radDAAuthenticator(raw []byte, reqAuth [16]byte, secret string) (auth [16]byte) {
+	hash := md5.New()
+	hash.Write(raw[:4])
+	hash.Write(reqAuth[:])
+	hash.Write(raw[20:])
+	hash.Write([]byte(secret))
+	copy(auth[:], hash.Sum(nil))
+	return
+}
+
+// sendRadDARequest sends the Dynamic Authorization request over UDP and waits for its reply
+func sendRadDARequest(addr, secret string, req *radigo.Packet,
+	dict *radigo.Dictionary, timeout time.Duration) (rpl *radigo.Packet, err error) {
+	var b [radigo.MaxPacketLen]byte
+	var n int
+	if n, err = req.Encode(b[:]); err != nil {
+		return
+	}
+	req.Authenticator = radDAAuthenticator(b[:n], [16]byte{}, secret)
+	copy(b[4:20], req.Authenticator[:])
+	var conn net.Conn
+	if conn, err = net.DialTimeout(utils.UDP, addr, timeout); err != nil {
+		return
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return
+	}
+	if _, err = conn.Write(b[:n]); err != nil {
+		return
+	}
+	for {
+		if n, err = conn.Read(b[:]); err != nil {
+			if nErr, canCast := err.(net.Error); canCast && nErr.Timeout() {
+				err = utils.ErrTimedOut
+			}
+			return
+		}
+		if n < 20 || b[1] != req.Identifier ||
+			int(binary.BigEndian.Uint16(b[2:4])) != n {
+			continue // not the reply for our request
+		}
+		if auth := radDAAuthenticator(b[:n], req.Authenticator, secret); !bytes.Equal(auth[:], b[4:20]) {
+			continue // ignore the replies that are not authentic
+		}
+		break
+	}
+	rpl = radigo.NewPacket(radigo.PacketCode(b[0]), b[1], dict, radigo.NewCoder(), secret)
+	err = rpl.Decode(b[:n])
+	return
+}
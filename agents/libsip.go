@@ -20,6 +20,8 @@ package agents
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/cgrates/cgrates/config"
@@ -75,3 +77,48 @@ func bareSipErr(m sipingo.Message, err string) sipingo.Message {
 	m.PrepareReply()
 	return m
 }
+
+// sipRedirectReply will build the redirect answer out of the authorization result
+// the routes are converted in a Contact list with the q-values decreasing in the order of the routes
+// in case of authorization error a 403 is returned and in case of no routes a 503, both with a Reason header
+func sipRedirectReply(reqURI string, sRoutes *engine.SortedRoutes, authErr error) (rply map[string]string) {
+	if authErr != nil && !strings.HasPrefix(authErr.Error(), utils.RoutesErrorPrfx) {
+		return map[string]string{
+			requestHeader: sipForbidden,
+			reasonHeader:  sipReason(403, authErr.Error()),
+		}
+	}
+	if sRoutes == nil || len(sRoutes.SortedRoutes) == 0 {
+		reason := utils.ErrNotFound.Error()
+		if authErr != nil {
+			reason = authErr.Error()
+		}
+		return map[string]string{
+			requestHeader: sipServiceUnavailable,
+			reasonHeader:  sipReason(503, reason),
+		}
+	}
+	user := sipingo.UserFrom(reqURI)
+	contacts := make([]string, len(sRoutes.SortedRoutes))
+	for i, route := range sRoutes.SortedRoutes {
+		target := utils.FirstNonEmpty(route.RouteParameters, route.RouteID)
+		if !strings.HasPrefix(target, "sip:") &&
+			!strings.HasPrefix(target, "sips:") {
+			if user != utils.EmptyString {
+				target = user + "@" + target
+			}
+			target = "sip:" + target
+		}
+		q := math.Floor(float64(len(sRoutes.SortedRoutes)-i)/float64(len(sRoutes.SortedRoutes))*1000) / 1000
+		contacts[i] = fmt.Sprintf("<%s>;q=%s", target, strconv.FormatFloat(q, 'f', -1, 64))
+	}
+	return map[string]string{
+		requestHeader: sipMovedTemporarily,
+		contactHeader: strings.Join(contacts, ","),
+	}
+}
+
+// sipReason builds the value of the Reason header
+func sipReason(cause int, text string) string {
+	return fmt.Sprintf("SIP;cause=%d;text=%q", cause, text)
+}
@@ -19,11 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/sipingo"
 )
@@ -73,3 +75,56 @@ func TestUpdateSIPMsgFromNavMap2(t *testing.T) {
 		t.Errorf("Expected error %s,received:%v", expectedErr, err)
 	}
 }
+
+func TestSIPRedirectReply(t *testing.T) {
+	sRoutes := &engine.SortedRoutes{
+		ProfileID: "ROUTE_LCR",
+		Sorting:   utils.MetaLC,
+		Count:     3,
+		SortedRoutes: []*engine.SortedRoute{
+			{RouteID: "route1", RouteParameters: "192.168.56.203:5060"},
+			{RouteID: "route2", RouteParameters: "sip:1002@192.168.56.204"},
+			{RouteID: "sbc3.cgrates.org"},
+		},
+	}
+	exp := map[string]string{
+		requestHeader: sipMovedTemporarily,
+		contactHeader: "<sip:1002@192.168.56.203:5060>;q=1,<sip:1002@192.168.56.204>;q=0.666,<sip:1002@sbc3.cgrates.org>;q=0.333",
+	}
+	if rply := sipRedirectReply("INVITE sip:1002@192.168.58.203 SIP/2.0", sRoutes, nil); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	exp = map[string]string{
+		requestHeader: sipForbidden,
+		reasonHeader:  `SIP;cause=403;text="RALS_ERROR:INSUFFICIENT_CREDIT"`,
+	}
+	if rply := sipRedirectReply("INVITE sip:1002@192.168.58.203 SIP/2.0", sRoutes,
+		utils.NewErrRALs(utils.ErrInsufficientCredit)); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	exp = map[string]string{
+		requestHeader: sipServiceUnavailable,
+		reasonHeader:  `SIP;cause=503;text="ROUTES_ERROR:NOT_FOUND"`,
+	}
+	if rply := sipRedirectReply("INVITE sip:1002@192.168.58.203 SIP/2.0", nil,
+		utils.NewErrRouteS(utils.ErrNotFound)); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	exp = map[string]string{
+		requestHeader: sipServiceUnavailable,
+		reasonHeader:  `SIP;cause=503;text="NOT_FOUND"`,
+	}
+	if rply := sipRedirectReply("INVITE sip:1002@192.168.58.203 SIP/2.0",
+		&engine.SortedRoutes{}, nil); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+}
+
+func TestSIPReason(t *testing.T) {
+	if rcv := sipReason(403, errors.New(`unauthorized "1001"`).Error()); rcv != `SIP;cause=403;text="unauthorized \"1001\""` {
+		t.Errorf("Received: %s", rcv)
+	}
+}
@@ -20,12 +20,17 @@ package agents
 
 import (
 	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
 
+	"github.com/cenkalti/rpc2"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/sessions"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/radigo"
+	"github.com/cgrates/rpcclient"
 )
 
 const (
@@ -52,8 +57,9 @@ func NewRadiusAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 		}
 	}
 	dicts := radigo.NewDictionaries(dts)
-	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr}
 	secrets := radigo.NewSecrets(cgrCfg.RadiusAgentCfg().ClientSecrets)
+	ra = &RadiusAgent{cgrCfg: cgrCfg, filterS: filterS, connMgr: connMgr,
+		dicts: dicts, secrets: secrets}
 	ra.rsAuth = radigo.NewServer(cgrCfg.RadiusAgentCfg().ListenNet,
 		cgrCfg.RadiusAgentCfg().ListenAuth, secrets, dicts,
 		map[radigo.PacketCode]func(*radigo.Packet) (*radigo.Packet, error){
@@ -71,6 +77,9 @@ type RadiusAgent struct {
 	filterS *engine.FilterS
 	rsAuth  *radigo.Server
 	rsAcct  *radigo.Server
+	dicts   *radigo.Dictionaries
+	secrets *radigo.Secrets
+	daID    uint32 // identifier of the last Dynamic Authorization request sent
 }
 
 // handleAuth handles RADIUS Authorization request
@@ -170,6 +179,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	if err = agReq.SetFields(reqProcessor.RequestFields); err != nil {
 		return
 	}
+	ra.cacheRadiusPacket(req, agReq)
 	cgrEv := config.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
 	var reqType string
 	for _, typ := range []string{
@@ -187,24 +197,28 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 		reqType == utils.MetaMessage ||
 		reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
-				utils.RadiusAgent, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction failed because <%s>",
+					utils.RadiusAgent, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, processorID: %s, radius message: %s",
-				utils.RadiusAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.RadiusAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, CGREvent: %s",
-				utils.RadiusAgent, reqProcessor.ID, utils.ToJSON(cgrEv)))
+				utils.RadiusAgent, reqProcessor.ID, utils.ToJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -222,7 +236,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1AuthorizeReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -240,7 +254,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1InitSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1InitiateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1InitiateSession,
 			initArgs, rply)
 		rply.SetMaxUsageNeeded(initArgs.InitSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -253,7 +267,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.Has(utils.MetaAccounts),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		rply := new(sessions.V1UpdateSessionReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1UpdateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1UpdateSession,
 			updateArgs, rply)
 		rply.SetMaxUsageNeeded(updateArgs.UpdateSession)
 		if err = agReq.setCGRReply(rply, err); err != nil {
@@ -269,7 +283,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamsSlice(utils.MetaStats, utils.MetaIDs),
 			cgrEv, reqProcessor.Flags.Has(utils.MetaFD))
 		var rply string
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1TerminateSession,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1TerminateSession,
 			terminateArgs, &rply)
 		if err = agReq.setCGRReply(nil, err); err != nil {
 			return
@@ -291,7 +305,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			reqProcessor.Flags.ParamValue(utils.MetaRoutesMaxCost),
 		)
 		rply := new(sessions.V1ProcessMessageReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessMessage, evArgs, rply)
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessMessage, evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if evArgs.Debit {
@@ -308,7 +322,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 			Paginator: cgrArgs,
 		}
 		rply := new(sessions.V1ProcessEventReply)
-		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
+		err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
@@ -329,7 +343,7 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.GetBool(utils.MetaCDRs) {
 		var rplyCDRs string
-		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessCDR,
+		if err = ra.connMgr.Call(ra.cgrCfg.RadiusAgentCfg().SessionSConns, ra, utils.SessionSv1ProcessCDR,
 			cgrEv, &rplyCDRs); err != nil {
 			agReq.CGRReply.Set(utils.PathItems{{Field: utils.Error}}, utils.NewNMData(err.Error()))
 		}
@@ -340,14 +354,16 @@ func (ra *RadiusAgent) processRequest(req *radigo.Packet, reqProcessor *config.R
 	}
 
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, Radius reply: %s",
-				utils.RadiusAgent, utils.ToIJSON(agReq.Reply)))
+				utils.RadiusAgent, utils.ToIJSON(agReq.Reply)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	if reqType == utils.MetaDryRun {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, Radius reply: %s",
-				utils.RadiusAgent, utils.ToJSON(agReq.Reply)))
+				utils.RadiusAgent, utils.ToJSON(agReq.Reply)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	return true, nil
 }
@@ -369,3 +385,194 @@ func (ra *RadiusAgent) ListenAndServe(stopChan <-chan struct{}) (err error) {
 	err = <-errListen
 	return
 }
+
+// radPacketData is cached for building the Dynamic Authorization requests
+type radPacketData struct {
+	req  *radigo.Packet
+	vars utils.NavigableMap2
+}
+
+// cacheRadiusPacket caches the request under its OriginID so it can be used to build the Dynamic Authorization requests
+func (ra *RadiusAgent) cacheRadiusPacket(req *radigo.Packet, agReq *AgentRequest) {
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate == utils.EmptyString &&
+		ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return
+	}
+	originID, err := agReq.FieldAsString([]string{utils.MetaCgreq, utils.OriginID})
+	if err != nil { // no session to disconnect later
+		return
+	}
+	if err = engine.Cache.Set(utils.CacheRadiusPackets, originID, &radPacketData{req: req, vars: agReq.Vars},
+		nil, true, utils.NonTransactional); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed caching the packet with OriginID: <%s>, err: %s",
+			utils.RadiusAgent, originID, err.Error()))
+	}
+}
+
+// daAddress returns the address of the Dynamic Authorization Server for the client with the given IP
+func (ra *RadiusAgent) daAddress(clientIP string) string {
+	if addr, has := ra.cgrCfg.RadiusAgentCfg().ClientDAAddresses[clientIP]; has {
+		return addr
+	}
+	return net.JoinHostPort(clientIP, radDAPort)
+}
+
+// sendDARequest builds the Dynamic Authorization request out of the template and sends it to the client of the session
+func (ra *RadiusAgent) sendDARequest(code radigo.PacketCode, tplID, originID string) (err error) {
+	msg, has := engine.Cache.Get(utils.CacheRadiusPackets, originID)
+	if !has {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot retrieve packet from cache with OriginID: <%s>",
+				utils.RadiusAgent, originID))
+		return utils.ErrMandatoryIeMissing
+	}
+	rpd := msg.(*radPacketData)
+	var remoteHost, clientIP string
+	if remoteHost, err = rpd.vars.FieldAsString([]string{utils.RemoteHost}); err != nil {
+		return
+	}
+	if clientIP, _, err = net.SplitHostPort(remoteHost); err != nil {
+		return
+	}
+	aReq := NewAgentRequest(
+		newRADataProvider(rpd.req),
+		rpd.vars, nil, nil, nil, nil,
+		ra.cgrCfg.GeneralCfg().DefaultTenant,
+		ra.cgrCfg.GeneralCfg().DefaultTimezone, ra.filterS, nil, nil)
+	if err = aReq.SetFields(ra.cgrCfg.TemplatesCfg()[tplID]); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDACodeName(code), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	dict := ra.dicts.GetInstance(clientIP)
+	secret := ra.secrets.GetSecret(clientIP)
+	daReq := radigo.NewPacket(code, uint8(atomic.AddUint32(&ra.daID, 1)),
+		dict, radigo.NewCoder(), secret)
+	if err = radReplyAppendAttributes(daReq, aReq.radDAReq); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot send %s with OriginID: <%s>, err: %s",
+				utils.RadiusAgent, radDACodeName(code), originID, err.Error()))
+		return utils.ErrServerError
+	}
+	var rpl *radigo.Packet
+	if rpl, err = sendRadDARequest(ra.daAddress(clientIP), secret, daReq, dict, time.Second); err != nil {
+		return
+	}
+	if rpl.Code != code+1 { // the ACK code follows the request one
+		return fmt.Errorf("received %s for OriginID: <%s>", radDACodeName(rpl.Code), originID)
+	}
+	return
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (ra *RadiusAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(ra, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is part of the sessions.BiRPClient
+// sends a Disconnect-Request to the client of the session
+func (ra *RadiusAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	ssID, has := args.EventStart[utils.OriginID]
+	if !has {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.RadiusAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if ra.cgrCfg.RadiusAgentCfg().DMRTemplate != utils.EmptyString {
+		if err = ra.sendDARequest(radDisconnectRequest, ra.cgrCfg.RadiusAgentCfg().DMRTemplate,
+			utils.IfaceAsString(ssID)); err != nil {
+			return
+		}
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs is part of the sessions.BiRPClient
+func (*RadiusAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return utils.ErrNotImplemented
+}
+
+// V1ReAuthorize sends a CoA-Request to the client of the session
+func (ra *RadiusAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot send CoA-Request, missing session ID",
+				utils.RadiusAgent))
+		return utils.ErrMandatoryIeMissing
+	}
+	if ra.cgrCfg.RadiusAgentCfg().CoATemplate == utils.EmptyString {
+		return utils.ErrNotImplemented
+	}
+	if err = ra.sendDARequest(radCoARequest, ra.cgrCfg.RadiusAgentCfg().CoATemplate,
+		originID); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (*RadiusAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (ra *RadiusAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(ra, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return ra.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return ra.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return ra.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return ra.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (ra *RadiusAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return ra.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (ra *RadiusAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return ra.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return ra.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return ra.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return ra.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return ra.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
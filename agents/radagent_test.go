@@ -0,0 +1,220 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/radigo"
+)
+
+func TestRAsSessionSClientIface(t *testing.T) {
+	_ = sessions.BiRPClient(new(RadiusAgent))
+}
+
+// startRadDAStub starts a NAS accepting Dynamic Authorization requests on a random port
+// the authentic requests are published on the returned channel and replied with rplCode, no reply is sent if rplCode is 0
+func startRadDAStub(t *testing.T, secret string, rplCode radigo.PacketCode) (addr string, reqs chan *radigo.Packet, stop func()) {
+	conn, err := net.ListenPacket(utils.UDP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reqs = make(chan *radigo.Packet, 10)
+	go func() {
+		var b [radigo.MaxPacketLen]byte
+		for {
+			n, rAddr, err := conn.ReadFrom(b[:])
+			if err != nil {
+				return
+			}
+			if auth := radDAAuthenticator(b[:n], [16]byte{}, secret); !bytes.Equal(auth[:], b[4:20]) {
+				continue
+			}
+			req := radigo.NewPacket(radigo.PacketCode(b[0]), b[1], dictRad, coder, secret)
+			if err := req.Decode(b[:n]); err != nil {
+				continue
+			}
+			req.SetAVPValues()
+			reqs <- req
+			if rplCode == 0 {
+				continue
+			}
+			rpl := []byte{byte(rplCode), b[1], 0, 20,
+				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+			auth := radDAAuthenticator(rpl, req.Authenticator, secret)
+			copy(rpl[4:20], auth[:])
+			conn.WriteTo(rpl, rAddr)
+		}
+	}()
+	return conn.LocalAddr().String(), reqs, func() { conn.Close() }
+}
+
+// receiveRadDARequest returns the request received by the stub NAS
+func receiveRadDARequest(t *testing.T, reqs chan *radigo.Packet) (req *radigo.Packet) {
+	select {
+	case req = <-reqs:
+	case <-time.After(time.Second):
+		t.Fatal("no request received by the NAS")
+	}
+	return
+}
+
+func newTestRadiusAgent(t *testing.T, daAddr string) (ra *RadiusAgent) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RadiusAgentCfg().DMRTemplate = utils.MetaDMR
+	cfg.RadiusAgentCfg().CoATemplate = utils.MetaCoA
+	cfg.RadiusAgentCfg().ClientDAAddresses = map[string]string{"127.0.0.1": daAddr}
+	ra = &RadiusAgent{
+		cgrCfg:  cfg,
+		filterS: engine.NewFilterS(cfg, nil, nil),
+		dicts:   radigo.NewDictionaries(map[string]*radigo.Dictionary{utils.MetaDefault: dictRad}),
+		secrets: radigo.NewSecrets(map[string]string{utils.MetaDefault: "CGRateS.org"}),
+	}
+	pkt := radigo.NewPacket(radigo.AccountingRequest, 1, dictRad, coder, "CGRateS.org")
+	for attr, val := range map[string]string{
+		"User-Name":       "1001",
+		"NAS-IP-Address":  "127.0.0.1",
+		"Acct-Session-Id": "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0",
+	} {
+		if err := pkt.AddAVPWithName(attr, val, utils.EmptyString); err != nil {
+			t.Fatal(err)
+		}
+	}
+	agReq := NewAgentRequest(newRADataProvider(pkt),
+		utils.NavigableMap2{utils.RemoteHost: utils.NewNMData("127.0.0.1:41625")},
+		nil, nil, nil, nil, "cgrates.org", utils.EmptyString, ra.filterS, nil, nil)
+	reqFlds := []*config.FCTemplate{{Tag: utils.OriginID, Path: utils.MetaCgreq + utils.NestingSep + utils.OriginID,
+		Type: utils.MetaVariable, Value: config.NewRSRParsersMustCompile("~*req.Acct-Session-Id", utils.InfieldSep)}}
+	for _, fld := range reqFlds {
+		fld.ComputePath()
+	}
+	if err := agReq.SetFields(reqFlds); err != nil {
+		t.Fatal(err)
+	}
+	ra.cacheRadiusPacket(pkt, agReq)
+	return
+}
+
+func TestRadiusAgentV1DisconnectSession(t *testing.T) {
+	addr, reqs, stop := startRadDAStub(t, "CGRateS.org", radDisconnectACK)
+	defer stop()
+	ra := newTestRadiusAgent(t, addr)
+	var reply string
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	req := receiveRadDARequest(t, reqs)
+	if req.Code != radDisconnectRequest {
+		t.Errorf("Expected %s, received %s", radDACodeName(radDisconnectRequest), radDACodeName(req.Code))
+	}
+	for attr, val := range map[string]string{
+		"User-Name":       "1001",
+		"NAS-IP-Address":  "127.0.0.1",
+		"Acct-Session-Id": "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0",
+	} {
+		if avps := req.AttributesWithName(attr, utils.EmptyString); len(avps) != 1 {
+			t.Errorf("Expected one %s, received %d", attr, len(avps))
+		} else if avps[0].GetStringValue() != val {
+			t.Errorf("Expected %s: %q, received %q", attr, val, avps[0].GetStringValue())
+		}
+	}
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "unknown"},
+	}, &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected %v, received %v", utils.ErrMandatoryIeMissing, err)
+	}
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{},
+	}, &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected %v, received %v", utils.ErrMandatoryIeMissing, err)
+	}
+}
+
+func TestRadiusAgentV1ReAuthorize(t *testing.T) {
+	addr, reqs, stop := startRadDAStub(t, "CGRateS.org", radCoANAK)
+	defer stop()
+	ra := newTestRadiusAgent(t, addr)
+	var reply string
+	if err := ra.V1ReAuthorize("e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0", &reply); err == nil ||
+		err.Error() != "received CoA-NAK for OriginID: <e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0>" {
+		t.Errorf("Expected CoA-NAK error, received %v", err)
+	}
+	if req := receiveRadDARequest(t, reqs); req.Code != radCoARequest {
+		t.Errorf("Expected %s, received %s", radDACodeName(radCoARequest), radDACodeName(req.Code))
+	}
+
+	addr, reqs, stop = startRadDAStub(t, "CGRateS.org", radCoAACK)
+	defer stop()
+	ra.cgrCfg.RadiusAgentCfg().ClientDAAddresses["127.0.0.1"] = addr
+	if err := ra.V1ReAuthorize("e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0", &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	if req := receiveRadDARequest(t, reqs); req.Code != radCoARequest {
+		t.Errorf("Expected %s, received %s", radDACodeName(radCoARequest), radDACodeName(req.Code))
+	}
+
+	ra.cgrCfg.RadiusAgentCfg().CoATemplate = utils.EmptyString
+	if err := ra.V1ReAuthorize("e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0", &reply); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+}
+
+func TestRadiusAgentDARequestWrongSecret(t *testing.T) {
+	addr, reqs, stop := startRadDAStub(t, "wrongSecret", radDisconnectACK)
+	defer stop()
+	ra := newTestRadiusAgent(t, addr)
+	var reply string
+	if err := ra.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "e4921177ab0e3586c37f6a185864b71a@0:0:0:0:0:0:0:0"},
+	}, &reply); err != utils.ErrTimedOut {
+		t.Errorf("Expected %v, received %v", utils.ErrTimedOut, err)
+	}
+	select {
+	case req := <-reqs:
+		t.Errorf("Expected the request to be ignored, received %s", utils.ToJSON(req))
+	default:
+	}
+}
+
+func TestRadiusAgentDAAddress(t *testing.T) {
+	ra := &RadiusAgent{cgrCfg: config.NewDefaultCGRConfig()}
+	if addr := ra.daAddress("192.168.56.203"); addr != "192.168.56.203:3799" {
+		t.Errorf("Expected %q, received %q", "192.168.56.203:3799", addr)
+	}
+	if addr := ra.daAddress("::1"); addr != "[::1]:3799" {
+		t.Errorf("Expected %q, received %q", "[::1]:3799", addr)
+	}
+	ra.cgrCfg.RadiusAgentCfg().ClientDAAddresses = map[string]string{"192.168.56.203": "192.168.56.204:1700"}
+	if addr := ra.daAddress("192.168.56.203"); addr != "192.168.56.204:1700" {
+		t.Errorf("Expected %q, received %q", "192.168.56.204:1700", addr)
+	}
+}
@@ -33,15 +33,22 @@ import (
 )
 
 const (
-	bufferSize      = 5000
-	ackMethod       = "ACK"
-	inviteMethod    = "INVITE"
-	requestHeader   = "Request"
-	callIDHeader    = "Call-ID"
-	fromHeader      = "From"
-	sipServerErr    = "SIP/2.0 500 Internal Server Error"
-	userAgentHeader = "User-Agent"
-	method          = "Method"
+	bufferSize            = 5000
+	ackMethod             = "ACK"
+	inviteMethod          = "INVITE"
+	requestHeader         = "Request"
+	callIDHeader          = "Call-ID"
+	fromHeader            = "From"
+	cSeqHeader            = "CSeq"
+	contactHeader         = "Contact"
+	reasonHeader          = "Reason"
+	sipServerErr          = "SIP/2.0 500 Internal Server Error"
+	sipMovedTemporarily   = "SIP/2.0 302 Moved Temporarily"
+	sipForbidden          = "SIP/2.0 403 Forbidden"
+	sipServiceUnavailable = "SIP/2.0 503 Service Unavailable"
+	userAgentHeader       = "User-Agent"
+	method                = "Method"
+	sipTransactionTTL     = 32 * time.Second // 64*T1, after this the INVITE transaction is considered terminated
 )
 
 var (
@@ -56,6 +63,7 @@ func NewSIPAgent(connMgr *engine.ConnManager, cfg *config.CGRConfig,
 		filterS:  filterS,
 		cfg:      cfg,
 		ackMap:   make(map[string]chan struct{}),
+		trans:    make(map[string][]byte),
 		stopChan: make(chan struct{}),
 	}
 	msgTemplates := sa.cfg.TemplatesCfg()
@@ -83,6 +91,8 @@ type SIPAgent struct {
 	stopChan chan struct{}
 	ackMap   map[string]chan struct{}
 	ackLocks sync.RWMutex
+	trans    map[string][]byte // INVITE transactions with the answer sent, used to reply to retransmissions
+	transLk  sync.Mutex
 }
 
 // Shutdown will stop the SIPAgent server
@@ -252,11 +262,33 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 		}
 		sa.ackLocks.Unlock() // log the message if we did not find it in the map
 	}
+	var tKey string
+	if method == inviteMethod {
+		tKey = utils.ConcatenatedKey(key, sipMessage[cSeqHeader])
+		if ans, isRetransmission := sa.beginTransaction(tKey); isRetransmission {
+			if len(ans) == 0 { // still processing or nothing to reply
+				return
+			}
+			if err = write(ans); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: %s sending message: %s",
+						utils.SIPAgent, err.Error(), ans))
+			}
+			return
+		}
+	}
 	var sipAnswer sipingo.Message
 	if sipAnswer = sa.handleMessage(sipMessage, addr); len(sipAnswer) == 0 {
 		return // do not write the message if we do not have anything to reply
 	}
 	ans := []byte(sipAnswer.String())
+	if tKey != utils.EmptyString {
+		sa.transLk.Lock()
+		if _, has := sa.trans[tKey]; has { // not expired yet
+			sa.trans[tKey] = ans
+		}
+		sa.transLk.Unlock()
+	}
 	if err = write(ans); err != nil {
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> error: %s sending message: %s",
@@ -293,6 +325,23 @@ func (sa *SIPAgent) answerMessage(messageStr, addr string, write func(ans []byte
 	return
 }
 
+// beginTransaction registers the INVITE transaction with the given key
+// returns the answer already sent and true if the transaction was already started
+func (sa *SIPAgent) beginTransaction(tKey string) (ans []byte, has bool) {
+	sa.transLk.Lock()
+	defer sa.transLk.Unlock()
+	if ans, has = sa.trans[tKey]; has {
+		return
+	}
+	sa.trans[tKey] = nil
+	time.AfterFunc(sipTransactionTTL, func() {
+		sa.transLk.Lock()
+		delete(sa.trans, tKey)
+		sa.transLk.Unlock()
+	})
+	return
+}
+
 func (sa *SIPAgent) handleMessage(sipMessage sipingo.Message, remoteHost string) (sipAnswer sipingo.Message) {
 	if sipMessage[userAgentHeader] != "" {
 		sipMessage[userAgentHeader] = fmt.Sprintf("%s@%s", utils.CGRateS, utils.Version)
@@ -393,28 +442,34 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		}
 	}
 	var cgrArgs utils.Paginator
+	var authErr error
+	var sRoutes *engine.SortedRoutes
 	if reqType == utils.MetaAuthorize ||
 		reqType == utils.MetaMessage ||
 		reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction failed because <%s>",
-				utils.SIPAgent, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction failed because <%s>",
+					utils.SIPAgent, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, processorID: %s, SIP message: %s",
-				utils.SIPAgent, reqProcessor.ID, agReq.Request.String()))
+				utils.SIPAgent, reqProcessor.ID, agReq.Request.String()),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, CGREvent: %s",
-				utils.SIPAgent, reqProcessor.ID, utils.ToJSON(cgrEv)))
+				utils.SIPAgent, reqProcessor.ID, utils.ToJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			reqProcessor.Flags.GetBool(utils.MetaAttributes),
@@ -435,6 +490,7 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		err = sa.connMgr.Call(sa.cfg.SIPAgentCfg().SessionSConns, nil, utils.SessionSv1AuthorizeEvent,
 			authArgs, rply)
 		rply.SetMaxUsageNeeded(authArgs.GetMaxUsage)
+		authErr, sRoutes = err, rply.Routes
 		if err = agReq.setCGRReply(rply, err); err != nil {
 			return
 		}
@@ -448,6 +504,7 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 		rply := new(sessions.V1ProcessEventReply)
 		err = sa.connMgr.Call(sa.cfg.SIPAgentCfg().SessionSConns, nil, utils.SessionSv1ProcessEvent,
 			evArgs, rply)
+		authErr, sRoutes = err, rply.Routes[utils.MetaRaw]
 		if utils.ErrHasPrefix(err, utils.RalsErrorPrfx) {
 			cgrEv.Event[utils.Usage] = 0 // avoid further debits
 		} else if needsMaxUsage(reqProcessor.Flags[utils.MetaRALs]) {
@@ -457,18 +514,34 @@ func (sa *SIPAgent) processRequest(reqProcessor *config.RequestProcessor,
 			return
 		}
 	}
+	if reqProcessor.Flags.Has(utils.MetaRedirect) { // answer with the routes, the reply fields can overwrite the headers
+		var reqURI string
+		if reqURI, err = agReq.Request.FieldAsString([]string{requestHeader}); err != nil {
+			return
+		}
+		for hdr, val := range sipRedirectReply(reqURI, sRoutes, authErr) {
+			if _, err = agReq.Reply.Set(&utils.FullPath{
+				Path:      hdr,
+				PathItems: utils.PathItems{{Field: hdr}},
+			}, &utils.NMSlice{&config.NMItem{Data: val, Path: []string{hdr}}}); err != nil {
+				return
+			}
+		}
+	}
 	if err := agReq.SetFields(reqProcessor.ReplyFields); err != nil {
 		return false, err
 	}
 	if reqProcessor.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, SIP reply: %s",
-				utils.SIPAgent, agReq.Reply))
+				utils.SIPAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	if reqType == utils.MetaDryRun {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRY_RUN, SIP reply: %s",
-				utils.SIPAgent, agReq.Reply))
+				utils.SIPAgent, agReq.Reply),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	return true, nil
 }
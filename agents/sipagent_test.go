@@ -0,0 +1,142 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+	"github.com/cgrates/sipingo"
+)
+
+func TestSIPAgentRedirect(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SIPAgentCfg().RetransmissionTimer = 0
+	cfg.TemplatesCfg()[utils.MetaErr] = []*config.FCTemplate{
+		{Tag: "Request", Path: utils.MetaRep + utils.NestingSep + requestHeader, Type: utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile(sipServerErr, utils.InfieldSep)},
+	}
+	cfg.TemplatesCfg()[utils.MetaErr][0].ComputePath()
+	cfg.SIPAgentCfg().RequestProcessors = []*config.RequestProcessor{{
+		ID:      "RoutesRedirect",
+		Filters: []string{"*string:~*vars.Method:INVITE"},
+		Flags:   utils.FlagsWithParamsFromSlice([]string{utils.MetaAuthorize, utils.MetaRoutes, utils.MetaRedirect}),
+		RequestFields: []*config.FCTemplate{
+			{Tag: utils.AccountField, Path: utils.MetaCgreq + utils.NestingSep + utils.AccountField,
+				Type: utils.MetaVariable, Value: config.NewRSRParsersMustCompile("~*req.From{*sipuri_user}", utils.InfieldSep)},
+		},
+		ReplyFields: []*config.FCTemplate{},
+	}}
+	for _, fld := range cfg.SIPAgentCfg().RequestProcessors[0].RequestFields {
+		fld.ComputePath()
+	}
+	var calls int
+	var authErr error
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1AuthorizeEvent: func(arg interface{}, rply interface{}) error {
+			calls++
+			if authErr != nil {
+				return authErr
+			}
+			*rply.(*sessions.V1AuthorizeReply) = sessions.V1AuthorizeReply{
+				Routes: &engine.SortedRoutes{
+					ProfileID: "ROUTE_LCR",
+					Count:     2,
+					SortedRoutes: []*engine.SortedRoute{
+						{RouteID: "route1", RouteParameters: "192.168.56.203"},
+						{RouteID: "route2", RouteParameters: "192.168.56.204"},
+					},
+				},
+			}
+			return nil
+		},
+	}}
+	internalSessionSChan := make(chan rpcclient.ClientConnector, 1)
+	internalSessionSChan <- sS
+	sa, err := NewSIPAgent(engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS): internalSessionSChan,
+	}), cfg, engine.NewFilterS(cfg, nil, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	invite := "INVITE sip:1002@192.168.58.203 SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP 192.168.58.201:5060;branch=z9hG4bK4f5b.1\r\n" +
+		"From: <sip:1001@192.168.58.201>;tag=1234\r\n" +
+		"To: <sip:1002@192.168.58.203>\r\n" +
+		"Call-ID: 5e2e7b8e-c9a7-4e06\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	var answers []string
+	write := func(ans []byte) error {
+		answers = append(answers, string(ans))
+		return nil
+	}
+	if err := sa.answerMessage(invite, "192.168.58.201:5060", write); err != nil {
+		t.Fatal(err)
+	}
+	if err := sa.answerMessage(invite, "192.168.58.201:5060", write); err != nil { // retransmission
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected one authorization, received: %d", calls)
+	}
+	if len(answers) != 2 {
+		t.Fatalf("Expected two answers, received: %d", len(answers))
+	} else if answers[0] != answers[1] {
+		t.Errorf("Expected the same answer for the retransmission, received: %q and %q", answers[0], answers[1])
+	}
+	if ans, err := sipingo.NewMessage(answers[0]); err != nil {
+		t.Fatal(err)
+	} else if ans[requestHeader] != sipMovedTemporarily {
+		t.Errorf("Expected %q, received %q", sipMovedTemporarily, ans[requestHeader])
+	} else if exp := "<sip:1002@192.168.56.203>;q=1,<sip:1002@192.168.56.204>;q=0.5"; ans[contactHeader] != exp {
+		t.Errorf("Expected %q, received %q", exp, ans[contactHeader])
+	}
+
+	authErr = utils.NewErrRALs(utils.ErrInsufficientCredit)
+	answers = nil
+	if err := sa.answerMessage(invite, "192.168.58.201:5060", write); err != nil {
+		t.Fatal(err)
+	} else if calls != 1 {
+		t.Errorf("Expected the transaction to be answered from memory, received %d authorizations", calls)
+	}
+	invite = "INVITE sip:1002@192.168.58.203 SIP/2.0\r\n" +
+		"From: <sip:1001@192.168.58.201>;tag=1234\r\n" +
+		"Call-ID: 5e2e7b8e-c9a7-4e06\r\n" +
+		"CSeq: 2 INVITE\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	if err := sa.answerMessage(invite, "192.168.58.201:5060", write); err != nil {
+		t.Fatal(err)
+	} else if calls != 2 {
+		t.Errorf("Expected a new transaction, received %d authorizations", calls)
+	}
+	if len(answers) != 2 {
+		t.Fatalf("Expected two answers, received: %d", len(answers))
+	} else if ans, err := sipingo.NewMessage(answers[1]); err != nil {
+		t.Fatal(err)
+	} else if ans[requestHeader] != sipForbidden {
+		t.Errorf("Expected %q, received %q", sipForbidden, ans[requestHeader])
+	} else if exp := `SIP;cause=403;text="RALS_ERROR:INSUFFICIENT_CREDIT"`; ans[reasonHeader] != exp {
+		t.Errorf("Expected %q, received %q", exp, ans[reasonHeader])
+	}
+}
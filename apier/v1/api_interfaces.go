@@ -21,6 +21,7 @@ package v1
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/agents"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/dispatchers"
 	"github.com/cgrates/cgrates/engine"
@@ -65,6 +66,7 @@ type RouteSv1Interface interface {
 type AttributeSv1Interface interface {
 	GetAttributeForEvent(args *engine.AttrArgsProcessEvent, reply *engine.AttributeProfile) (err error)
 	ProcessEvent(args *engine.AttrArgsProcessEvent, reply *engine.AttrSProcessEventReply) error
+	TraceEvent(args *engine.AttrArgsProcessEvent, reply *engine.AttrSTraceReply) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -152,6 +154,9 @@ type CDRsV1Interface interface {
 	StoreSessionCost(attr *engine.AttrCDRSStoreSMCost, reply *string) error
 	GetCDRsCount(args *utils.RPCCDRsFilterWithOpts, reply *int64) error
 	GetCDRs(args *utils.RPCCDRsFilterWithOpts, reply *[]*engine.CDR) error
+	BillingRun(args *engine.ArgsBillingRun, reply *[]*engine.Invoice) error
+	GetInvoices(args *utils.InvoicesFilterWithOpts, reply *[]*engine.Invoice) error
+	RemoveInvoices(args *utils.InvoicesFilterWithOpts, reply *string) error
 	Ping(ign *utils.CGREvent, reply *string) error
 }
 
@@ -173,6 +178,9 @@ type ConfigSv1Interface interface {
 	SetConfig(args *config.SetConfigArgs, reply *string) (err error)
 	SetConfigFromJSON(args *config.SetConfigFromJSONArgs, reply *string) (err error)
 	GetConfigAsJSON(args *config.SectionWithOpts, reply *string) (err error)
+	GetConfigHistory(args *config.SectionWithOpts, reply *[]*config.ConfigVersion) (err error)
+	RollbackConfig(args *config.RollbackConfigArgs, reply *string) (err error)
+	SetDBConfig(args *config.SetDBConfigArgs, reply *string) (err error)
 }
 
 type CoreSv1Interface interface {
@@ -184,6 +192,10 @@ type CoreSv1Interface interface {
 type RateSv1Interface interface {
 	Ping(ign *utils.CGREvent, reply *string) error
 	CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.RateProfileCost) error
+	CostForEvents(args *utils.ArgsCostForEvents, evCosts *[]*engine.EventRateProfileCost) error
+	CompareRateProfiles(args *utils.ArgsCostForEvents, rpCmps *[]*engine.RateProfileComparison) error
+	GetRateForPrefix(args *utils.ArgsGetRateForPrefix, rdr *engine.RateDeckRate) error
+	GetRateProfileVersions(args *utils.TenantIDWithOpts, rpvs *[]*engine.RateProfileVersion) error
 }
 
 type RateProfileSv1Interface interface {
@@ -296,3 +308,9 @@ type AccountSv1Interface interface {
 	DebitConcretes(args *utils.ArgsAccountsForEvent, eEc *utils.ExtEventCharges) (err error)
 	ActionRemoveBalance(args *utils.ArgsActRemoveBalances, eEc *string) (err error)
 }
+
+type DiameterAgentV1Interface interface {
+	Ping(ign *utils.CGREvent, reply *string) error
+	GetPeers(args *utils.TenantWithOpts, reply *[]*agents.DiameterPeerStatus) error
+	UpdatePolicyCounters(args *utils.UpdatePolicyCountersArgs, reply *string) error
+}
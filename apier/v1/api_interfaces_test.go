@@ -128,3 +128,8 @@ func TestActionSv1Interface(t *testing.T) {
 	_ = ActionSv1Interface(NewDispatcherActionSv1(nil))
 	_ = ActionSv1Interface(NewActionSv1(nil))
 }
+
+func TestDiameterAgentV1Interface(t *testing.T) {
+	_ = DiameterAgentV1Interface(NewDispatcherDiameterAgentV1(nil))
+	_ = DiameterAgentV1Interface(NewDiameterAgentV1(nil))
+}
@@ -1532,13 +1532,14 @@ func (apierSv1 *APIerSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	return nil
 }
 
-//ExportToFolder export specific items (or all items if items is empty) from DataDB back to CSV
+// ExportToFolder export specific items (or all items if items is empty) from DataDB back to CSV
 func (apierSv1 *APIerSv1) ExportToFolder(arg *utils.ArgExportToFolder, reply *string) error {
 	// if items is empty we need to export all items
 	if len(arg.Items) == 0 {
 		arg.Items = []string{utils.MetaAttributes, utils.MetaChargers, utils.MetaDispatchers,
 			utils.MetaDispatcherHosts, utils.MetaFilters, utils.MetaResources, utils.MetaStats,
-			utils.MetaRoutes, utils.MetaThresholds, utils.MetaRateProfiles, utils.MetaActionProfiles, utils.MetaAccountProfiles}
+			utils.MetaRoutes, utils.MetaThresholds, utils.MetaRateProfiles, utils.MetaActionProfiles, utils.MetaAccountProfiles,
+			utils.MetaExchangeRateProfiles, utils.MetaRateDecks, utils.MetaTaxProfiles, utils.MetaLookupTables}
 	}
 	if _, err := os.Stat(arg.Path); os.IsNotExist(err) {
 		os.Mkdir(arg.Path, os.ModeDir)
@@ -1996,6 +1997,154 @@ func (apierSv1 *APIerSv1) ExportToFolder(arg *utils.ArgExportToFolder, reply *st
 				}
 			}
 			csvWriter.Flush()
+		case utils.MetaExchangeRateProfiles:
+			prfx := utils.ExchangeRateProfilePrefix
+			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			f, err := os.Create(path.Join(arg.Path, utils.ExchangeRateProfilesCsv))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			csvWriter := csv.NewWriter(f)
+			csvWriter.Comma = utils.CSVSep
+			//write the header of the file
+			if err := csvWriter.Write(engine.ExchangeRateProfileMdls{}.CSVHeader()); err != nil {
+				return err
+			}
+			for _, key := range keys {
+				tntID := strings.SplitN(key[len(prfx):], utils.InInFieldSep, 2)
+				erPrf, err := apierSv1.DataManager.GetExchangeRateProfile(tntID[0], tntID[1],
+					true, false, utils.NonTransactional)
+				if err != nil {
+					return err
+				}
+				for _, model := range engine.APItoModelTPExchangeRateProfile(engine.ExchangeRateProfileToAPI(erPrf)) {
+					if record, err := engine.CsvDump(model); err != nil {
+						return err
+					} else if err := csvWriter.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+			csvWriter.Flush()
+		case utils.MetaRateDecks:
+			prfx := utils.RateDeckPrefix
+			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			f, err := os.Create(path.Join(arg.Path, utils.RateDecksCsv))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			csvWriter := csv.NewWriter(f)
+			csvWriter.Comma = utils.CSVSep
+			//write the header of the file
+			if err := csvWriter.Write(engine.RateDeckMdls{}.CSVHeader()); err != nil {
+				return err
+			}
+			for _, key := range keys {
+				tntID := strings.SplitN(key[len(prfx):], utils.InInFieldSep, 2)
+				rd, err := apierSv1.DataManager.GetRateDeck(tntID[0], tntID[1],
+					true, false, utils.NonTransactional)
+				if err != nil {
+					return err
+				}
+				for _, model := range engine.APItoModelTPRateDeck(engine.RateDeckToAPI(rd)) {
+					if record, err := engine.CsvDump(model); err != nil {
+						return err
+					} else if err := csvWriter.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+			csvWriter.Flush()
+		case utils.MetaTaxProfiles:
+			prfx := utils.TaxProfilePrefix
+			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			f, err := os.Create(path.Join(arg.Path, utils.TaxProfilesCsv))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			csvWriter := csv.NewWriter(f)
+			csvWriter.Comma = utils.CSVSep
+			//write the header of the file
+			if err := csvWriter.Write(engine.TaxProfileMdls{}.CSVHeader()); err != nil {
+				return err
+			}
+			for _, key := range keys {
+				tntID := strings.SplitN(key[len(prfx):], utils.InInFieldSep, 2)
+				txp, err := apierSv1.DataManager.GetTaxProfile(tntID[0], tntID[1],
+					true, false, utils.NonTransactional)
+				if err != nil {
+					return err
+				}
+				for _, model := range engine.APItoModelTPTaxProfile(engine.TaxProfileToAPI(txp)) {
+					if record, err := engine.CsvDump(model); err != nil {
+						return err
+					} else if err := csvWriter.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+			csvWriter.Flush()
+		case utils.MetaLookupTables:
+			prfx := utils.LookupTablePrefix
+			keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+			if err != nil {
+				return err
+			}
+			if len(keys) == 0 {
+				continue
+			}
+			f, err := os.Create(path.Join(arg.Path, utils.LookupTablesCsv))
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			csvWriter := csv.NewWriter(f)
+			csvWriter.Comma = utils.CSVSep
+			//write the header of the file
+			if err := csvWriter.Write(engine.LookupTableMdls{}.CSVHeader()); err != nil {
+				return err
+			}
+			for _, key := range keys {
+				tntID := strings.SplitN(key[len(prfx):], utils.InInFieldSep, 2)
+				lt, err := apierSv1.DataManager.GetLookupTable(tntID[0], tntID[1],
+					true, false, utils.NonTransactional)
+				if err != nil {
+					return err
+				}
+				for _, model := range engine.APItoModelTPLookupTable(engine.LookupTableToAPI(lt)) {
+					if record, err := engine.CsvDump(model); err != nil {
+						return err
+					} else if err := csvWriter.Write(record); err != nil {
+						return err
+					}
+				}
+			}
+			csvWriter.Flush()
 		}
 	}
 	*reply = utils.OK
@@ -183,6 +183,12 @@ func (alSv1 *AttributeSv1) ProcessEvent(args *engine.AttrArgsProcessEvent,
 	return alSv1.attrS.V1ProcessEvent(args, reply)
 }
 
+// TraceEvent processes the event returning the decisions taken by each process run
+func (alSv1 *AttributeSv1) TraceEvent(args *engine.AttrArgsProcessEvent,
+	reply *engine.AttrSTraceReply) error {
+	return alSv1.attrS.V1TraceEvent(args, reply)
+}
+
 // Ping return pong if the service is active
 func (alSv1 *AttributeSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
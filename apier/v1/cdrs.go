@@ -122,6 +122,21 @@ func (cdrSv1 *CDRsV1) GetCDRs(args *utils.RPCCDRsFilterWithOpts, reply *[]*engin
 	return cdrSv1.CDRs.V1GetCDRs(*args, reply)
 }
 
+// BillingRun generates the invoices for a billing period
+func (cdrSv1 *CDRsV1) BillingRun(args *engine.ArgsBillingRun, reply *[]*engine.Invoice) error {
+	return cdrSv1.CDRs.V1BillingRun(args, reply)
+}
+
+// GetInvoices returns the stored invoices
+func (cdrSv1 *CDRsV1) GetInvoices(args *utils.InvoicesFilterWithOpts, reply *[]*engine.Invoice) error {
+	return cdrSv1.CDRs.V1GetInvoices(args, reply)
+}
+
+// RemoveInvoices removes the stored invoices
+func (cdrSv1 *CDRsV1) RemoveInvoices(args *utils.InvoicesFilterWithOpts, reply *string) error {
+	return cdrSv1.CDRs.V1RemoveInvoices(args, reply)
+}
+
 func (cdrSv1 *CDRsV1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
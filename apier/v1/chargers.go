@@ -78,6 +78,11 @@ func (apierSv1 *APIerSv1) SetChargerProfile(arg *ChargerWithCache, reply *string
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
+	for _, aggr := range arg.Aggregations {
+		if err := aggr.Validate(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
 	if err := apierSv1.DataManager.SetChargerProfile(arg.ChargerProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
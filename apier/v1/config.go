@@ -58,6 +58,21 @@ func (cSv1 *ConfigSv1) GetConfigAsJSON(args *config.SectionWithOpts, reply *stri
 	return cSv1.cfg.V1GetConfigAsJSON(args, reply)
 }
 
+// GetConfigHistory returns the recorded config versions
+func (cSv1 *ConfigSv1) GetConfigHistory(args *config.SectionWithOpts, reply *[]*config.ConfigVersion) (err error) {
+	return cSv1.cfg.V1GetConfigHistory(args, reply)
+}
+
+// RollbackConfig restores the config to a previous version
+func (cSv1 *ConfigSv1) RollbackConfig(args *config.RollbackConfigArgs, reply *string) (err error) {
+	return cSv1.cfg.V1RollbackConfig(args, reply)
+}
+
+// SetDBConfig stores the config sections in DataDB
+func (cSv1 *ConfigSv1) SetDBConfig(args *config.SetDBConfigArgs, reply *string) (err error) {
+	return cSv1.cfg.V1SetDBConfig(args, reply)
+}
+
 // Call implements rpcclient.ClientConnector interface for internal RPC
 func (cSv1 *ConfigSv1) Call(serviceMethod string,
 	args interface{}, reply interface{}) error {
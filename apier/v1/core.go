@@ -44,6 +44,21 @@ func (cS *CoreSv1) Status(arg *utils.TenantWithOpts, reply *map[string]interface
 	return cS.cS.Status(arg, reply)
 }
 
+// SetLogLevel changes the log level of the engine or of one subsystem
+func (cS *CoreSv1) SetLogLevel(arg *utils.ArgsSetLogLevel, reply *string) error {
+	return cS.cS.SetLogLevel(arg, reply)
+}
+
+// GetLogLevels returns the log levels in use
+func (cS *CoreSv1) GetLogLevels(arg *utils.TenantWithOpts, reply *utils.LogLevels) error {
+	return cS.cS.GetLogLevels(arg, reply)
+}
+
+// Snapshot writes the persistent internal databases to disk
+func (cS *CoreSv1) Snapshot(arg *utils.TenantWithOpts, reply *string) error {
+	return cS.cS.Snapshot(arg, reply)
+}
+
 // Ping used to determinate if component is active
 func (cS *CoreSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
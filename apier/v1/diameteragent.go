@@ -0,0 +1,84 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewDiameterAgentV1 initializes DiameterAgentV1
+func NewDiameterAgentV1(da agents.DiameterAgentAPI) *DiameterAgentV1 {
+	return &DiameterAgentV1{da: da}
+}
+
+// DiameterAgentV1 exports RPC from DiameterAgent
+type DiameterAgentV1 struct {
+	sync.RWMutex
+	da agents.DiameterAgentAPI
+}
+
+// SetDiameterAgent replaces the agent served, used when the agent is restarted on reload
+func (daV1 *DiameterAgentV1) SetDiameterAgent(da agents.DiameterAgentAPI) {
+	daV1.Lock()
+	daV1.da = da
+	daV1.Unlock()
+}
+
+func (daV1 *DiameterAgentV1) getDiameterAgent() (da agents.DiameterAgentAPI, err error) {
+	daV1.RLock()
+	da = daV1.da
+	daV1.RUnlock()
+	if da == nil {
+		err = utils.NewErrServiceNotOperational(utils.DiameterAgent)
+	}
+	return
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (daV1 *DiameterAgentV1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(daV1, serviceMethod, args, reply)
+}
+
+// Ping return pong if the service is active
+func (daV1 *DiameterAgentV1) Ping(ign *utils.CGREvent, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// GetPeers returns the status of the diameter peers
+func (daV1 *DiameterAgentV1) GetPeers(args *utils.TenantWithOpts, reply *[]*agents.DiameterPeerStatus) (err error) {
+	var da agents.DiameterAgentAPI
+	if da, err = daV1.getDiameterAgent(); err != nil {
+		return
+	}
+	return da.V1GetPeers(args, reply)
+}
+
+// UpdatePolicyCounters re-evaluates the Sy policy counters, notifying the PCRF about the changed ones
+func (daV1 *DiameterAgentV1) UpdatePolicyCounters(args *utils.UpdatePolicyCountersArgs, reply *string) (err error) {
+	var da agents.DiameterAgentAPI
+	if da, err = daV1.getDiameterAgent(); err != nil {
+		return
+	}
+	return da.V1UpdatePolicyCounters(args, reply)
+}
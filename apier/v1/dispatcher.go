@@ -21,6 +21,7 @@ package v1
 import (
 	"time"
 
+	"github.com/cgrates/cgrates/agents"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/dispatchers"
 	"github.com/cgrates/cgrates/engine"
@@ -390,6 +391,12 @@ func (dA *DispatcherAttributeSv1) ProcessEvent(args *engine.AttrArgsProcessEvent
 	return dA.dA.AttributeSv1ProcessEvent(args, reply)
 }
 
+// TraceEvent implements AttributeSv1TraceEvent
+func (dA *DispatcherAttributeSv1) TraceEvent(args *engine.AttrArgsProcessEvent,
+	reply *engine.AttrSTraceReply) error {
+	return dA.dA.AttributeSv1TraceEvent(args, reply)
+}
+
 func NewDispatcherChargerSv1(dps *dispatchers.DispatcherService) *DispatcherChargerSv1 {
 	return &DispatcherChargerSv1{dC: dps}
 }
@@ -792,6 +799,18 @@ func (dS *DispatcherSCDRsV1) RateCDRs(args *engine.ArgRateCDRs, reply *string) e
 	return dS.dS.CDRsV1RateCDRs(args, reply)
 }
 
+func (dS *DispatcherSCDRsV1) BillingRun(args *engine.ArgsBillingRun, reply *[]*engine.Invoice) error {
+	return dS.dS.CDRsV1BillingRun(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) GetInvoices(args *utils.InvoicesFilterWithOpts, reply *[]*engine.Invoice) error {
+	return dS.dS.CDRsV1GetInvoices(args, reply)
+}
+
+func (dS *DispatcherSCDRsV1) RemoveInvoices(args *utils.InvoicesFilterWithOpts, reply *string) error {
+	return dS.dS.CDRsV1RemoveInvoices(args, reply)
+}
+
 func (dS *DispatcherSCDRsV1) ProcessExternalCDR(args *engine.ExternalCDRWithOpts, reply *string) error {
 	return dS.dS.CDRsV1ProcessExternalCDR(args, reply)
 }
@@ -855,6 +874,18 @@ func (dS *DispatcherConfigSv1) GetConfigAsJSON(args *config.SectionWithOpts, rep
 	return dS.dS.ConfigSv1GetConfigAsJSON(args, reply)
 }
 
+func (dS *DispatcherConfigSv1) GetConfigHistory(args *config.SectionWithOpts, reply *[]*config.ConfigVersion) (err error) {
+	return dS.dS.ConfigSv1GetConfigHistory(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) RollbackConfig(args *config.RollbackConfigArgs, reply *string) (err error) {
+	return dS.dS.ConfigSv1RollbackConfig(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) SetDBConfig(args *config.SetDBConfigArgs, reply *string) (err error) {
+	return dS.dS.ConfigSv1SetDBConfig(args, reply)
+}
+
 func NewDispatcherCoreSv1(dps *dispatchers.DispatcherService) *DispatcherCoreSv1 {
 	return &DispatcherCoreSv1{dS: dps}
 }
@@ -877,6 +908,18 @@ func (dS *DispatcherCoreSv1) Sleep(arg *utils.DurationArgs, reply *string) error
 	return dS.dS.CoreSv1Sleep(arg, reply)
 }
 
+func (dS *DispatcherCoreSv1) SetLogLevel(args *utils.ArgsSetLogLevel, reply *string) error {
+	return dS.dS.CoreSv1SetLogLevel(args, reply)
+}
+
+func (dS *DispatcherCoreSv1) GetLogLevels(args *utils.TenantWithOpts, reply *utils.LogLevels) error {
+	return dS.dS.CoreSv1GetLogLevels(args, reply)
+}
+
+func (dS *DispatcherCoreSv1) Snapshot(args *utils.TenantWithOpts, reply *string) error {
+	return dS.dS.CoreSv1Snapshot(args, reply)
+}
+
 func NewDispatcherRALsV1(dps *dispatchers.DispatcherService) *DispatcherRALsV1 {
 	return &DispatcherRALsV1{dS: dps}
 }
@@ -1343,6 +1386,34 @@ func (dR *DispatcherRateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *
 	return dR.dR.RateSv1CostForEvent(args, rpCost)
 }
 
+func (dR *DispatcherRateSv1) CostForEvents(args *utils.ArgsCostForEvents, evCosts *[]*engine.EventRateProfileCost) error {
+	return dR.dR.RateSv1CostForEvents(args, evCosts)
+}
+
+func (dR *DispatcherRateSv1) CompareRateProfiles(args *utils.ArgsCostForEvents, rpCmps *[]*engine.RateProfileComparison) error {
+	return dR.dR.RateSv1CompareRateProfiles(args, rpCmps)
+}
+
+func (dR *DispatcherRateSv1) GetRateForPrefix(args *utils.ArgsGetRateForPrefix, rdr *engine.RateDeckRate) error {
+	return dR.dR.RateSv1GetRateForPrefix(args, rdr)
+}
+
+func (dR *DispatcherRateSv1) GetRateProfileVersions(args *utils.TenantIDWithOpts, rpvs *[]*engine.RateProfileVersion) error {
+	return dR.dR.RateSv1GetRateProfileVersions(args, rpvs)
+}
+
+func (dR *DispatcherRateSv1) UpdateVolumeCounter(args *utils.ArgsCostForEvent, reply *string) error {
+	return dR.dR.RateSv1UpdateVolumeCounter(args, reply)
+}
+
+func (dR *DispatcherRateSv1) GetVolumeCounter(args *utils.ArgsRateVolumeCounter, rvc *engine.RateVolumeCounter) error {
+	return dR.dR.RateSv1GetVolumeCounter(args, rvc)
+}
+
+func (dR *DispatcherRateSv1) ResetVolumeCounter(args *utils.ArgsRateVolumeCounter, reply *string) error {
+	return dR.dR.RateSv1ResetVolumeCounter(args, reply)
+}
+
 func NewDispatcherActionSv1(dps *dispatchers.DispatcherService) *DispatcherActionSv1 {
 	return &DispatcherActionSv1{dR: dps}
 }
@@ -1405,3 +1476,25 @@ func (dR *DispatcherAccountSv1) ActionSetBalance(args *utils.ArgsActSetBalance,
 func (dR *DispatcherAccountSv1) ActionRemoveBalance(args *utils.ArgsActRemoveBalances, eEc *string) (err error) {
 	return dR.dR.AccountSv1ActionRemoveBalance(args, eEc)
 }
+
+func NewDispatcherDiameterAgentV1(dps *dispatchers.DispatcherService) *DispatcherDiameterAgentV1 {
+	return &DispatcherDiameterAgentV1{dS: dps}
+}
+
+// Exports RPC from DiameterAgent
+type DispatcherDiameterAgentV1 struct {
+	dS *dispatchers.DispatcherService
+}
+
+// Ping implements DiameterAgentV1Ping
+func (dS *DispatcherDiameterAgentV1) Ping(args *utils.CGREvent, reply *string) error {
+	return dS.dS.DiameterAgentV1Ping(args, reply)
+}
+
+func (dS *DispatcherDiameterAgentV1) GetPeers(args *utils.TenantWithOpts, reply *[]*agents.DiameterPeerStatus) error {
+	return dS.dS.DiameterAgentV1GetPeers(args, reply)
+}
+
+func (dS *DispatcherDiameterAgentV1) UpdatePolicyCounters(args *utils.UpdatePolicyCountersArgs, reply *string) error {
+	return dS.dS.DiameterAgentV1UpdatePolicyCounters(args, reply)
+}
@@ -0,0 +1,127 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetExchangeRateProfile returns an Exchange Rate Profile
+func (apierSv1 *APIerSv1) GetExchangeRateProfile(arg *utils.TenantIDWithOpts, reply *utils.ExchangeRateProfile) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	erp, err := apierSv1.DataManager.GetExchangeRateProfile(tnt, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *erp
+	return nil
+}
+
+// GetExchangeRateProfileIDs returns list of Exchange Rate Profile IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetExchangeRateProfileIDs(args *utils.PaginatorWithTenant, erPrfIDs *[]string) error {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.ExchangeRateProfilePrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*erPrfIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+type ExchangeRateProfileWithCache struct {
+	*utils.ExchangeRateProfile
+	Cache *string
+	Opts  map[string]interface{}
+}
+
+// SetExchangeRateProfile add/update a new Exchange Rate Profile
+func (apierSv1 *APIerSv1) SetExchangeRateProfile(args *ExchangeRateProfileWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(args.ExchangeRateProfile, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	for _, rt := range args.Rates {
+		if rt.FromCurrency == utils.EmptyString ||
+			rt.ToCurrency == utils.EmptyString ||
+			rt.Rate == nil {
+			return utils.NewErrMandatoryIeMissing(utils.FromCurrency, utils.ToCurrency, "Rate")
+		}
+	}
+	if err := apierSv1.DataManager.SetExchangeRateProfile(args.ExchangeRateProfile); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheExchangeRateProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheExchangeRateProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for ExchangeRateProfile
+	if err := apierSv1.CallCache(args.Cache, args.Tenant, utils.CacheExchangeRateProfiles,
+		args.TenantID(), nil, nil, args.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveExchangeRateProfile remove a specific Exchange Rate Profile
+func (apierSv1 *APIerSv1) RemoveExchangeRateProfile(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.RemoveExchangeRateProfile(tnt,
+		arg.ID, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheExchangeRateProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheExchangeRateProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for ExchangeRateProfile
+	if err := apierSv1.CallCache(arg.Cache, tnt, utils.CacheExchangeRateProfiles,
+		utils.ConcatenatedKey(tnt, arg.ID), nil, nil, arg.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
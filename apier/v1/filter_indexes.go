@@ -63,6 +63,8 @@ func (apierSv1 *APIerSv1) RemoveFilterIndexes(arg *AttrRemFilterIndexes, reply *
 		arg.ItemType = utils.CacheChargerFilterIndexes
 	case utils.MetaAccountProfiles:
 		arg.ItemType = utils.CacheAccountProfilesFilterIndexes
+	case utils.MetaTaxProfiles:
+		arg.ItemType = utils.CacheTaxProfilesFilterIndexes
 	case utils.MetaActionProfiles:
 		arg.ItemType = utils.CacheActionProfilesFilterIndexes
 	case utils.MetaRateProfiles:
@@ -118,6 +120,8 @@ func (apierSv1 *APIerSv1) GetFilterIndexes(arg *AttrGetFilterIndexes, reply *[]s
 		arg.ItemType = utils.CacheChargerFilterIndexes
 	case utils.MetaAccountProfiles:
 		arg.ItemType = utils.CacheAccountProfilesFilterIndexes
+	case utils.MetaTaxProfiles:
+		arg.ItemType = utils.CacheTaxProfilesFilterIndexes
 	case utils.MetaActionProfiles:
 		arg.ItemType = utils.CacheActionProfilesFilterIndexes
 	case utils.MetaRateProfiles:
@@ -343,6 +347,23 @@ func (apierSv1 *APIerSv1) ComputeFilterIndexes(args *utils.ArgsComputeFilterInde
 			return utils.APIErrorHandler(err)
 		}
 	}
+	//TaxProfile Indexes
+	if args.TaxS {
+		if args.TaxS, err = engine.ComputeIndexes(apierSv1.DataManager, tnt, args.Context, utils.CacheTaxProfilesFilterIndexes,
+			nil, transactionID, func(tnt, id, ctx string) (*[]string, error) {
+				txp, e := apierSv1.DataManager.GetTaxProfile(tnt, id, true, false, utils.NonTransactional)
+				if e != nil {
+					return nil, e
+				}
+				fltrIDs := make([]string, len(txp.FilterIDs))
+				for i, fltrID := range txp.FilterIDs {
+					fltrIDs[i] = fltrID
+				}
+				return &fltrIDs, nil
+			}); err != nil && err != utils.ErrNotFound {
+			return utils.APIErrorHandler(err)
+		}
+	}
 	//ActionProfile Indexes
 	if args.ActionS {
 		if args.ActionS, err = engine.ComputeIndexes(apierSv1.DataManager, tnt, args.Context, utils.CacheActionProfilesFilterIndexes,
@@ -369,9 +390,9 @@ func (apierSv1 *APIerSv1) ComputeFilterIndexes(args *utils.ArgsComputeFilterInde
 				if e != nil {
 					return nil, e
 				}
-				fltrIDs := make([]string, len(rpr.FilterIDs))
-				for i, fltrID := range rpr.FilterIDs {
-					fltrIDs[i] = fltrID
+				fltrIDs, e := apierSv1.DataManager.RateProfileFilterIDs(rpr)
+				if e != nil {
+					return nil, e
 				}
 
 				ids := make([]string, 0, len(rpr.Rates))
@@ -465,6 +486,12 @@ func (apierSv1 *APIerSv1) ComputeFilterIndexes(args *utils.ArgsComputeFilterInde
 			return
 		}
 	}
+	//TaxProfile Indexes
+	if args.TaxS {
+		if err = apierSv1.DataManager.SetIndexes(utils.CacheTaxProfilesFilterIndexes, tnt, nil, true, transactionID); err != nil {
+			return
+		}
+	}
 	//ActionProfile Indexes
 	if args.ActionS {
 		if err = apierSv1.DataManager.SetIndexes(utils.CacheActionProfilesFilterIndexes, tnt, nil, true, transactionID); err != nil {
@@ -581,6 +608,21 @@ func (apierSv1 *APIerSv1) ComputeFilterIndexIDs(args *utils.ArgsComputeFilterInd
 		}); err != nil && err != utils.ErrNotFound {
 		return utils.APIErrorHandler(err)
 	}
+	//TaxProfile Indexes
+	if _, err = engine.ComputeIndexes(apierSv1.DataManager, tnt, args.Context, utils.CacheTaxProfilesFilterIndexes,
+		&args.TaxProfileIDs, transactionID, func(tnt, id, ctx string) (*[]string, error) {
+			txp, e := apierSv1.DataManager.GetTaxProfile(tnt, id, true, false, utils.NonTransactional)
+			if e != nil {
+				return nil, e
+			}
+			fltrIDs := make([]string, len(txp.FilterIDs))
+			for i, fltrID := range txp.FilterIDs {
+				fltrIDs[i] = fltrID
+			}
+			return &fltrIDs, nil
+		}); err != nil && err != utils.ErrNotFound {
+		return utils.APIErrorHandler(err)
+	}
 	//ActionProfile Indexes
 	if _, err = engine.ComputeIndexes(apierSv1.DataManager, tnt, args.Context, utils.CacheActionProfilesFilterIndexes,
 		&args.ActionProfileIDs, transactionID, func(tnt, id, ctx string) (*[]string, error) {
@@ -603,9 +645,9 @@ func (apierSv1 *APIerSv1) ComputeFilterIndexIDs(args *utils.ArgsComputeFilterInd
 			if e != nil {
 				return nil, e
 			}
-			fltrIDs := make([]string, len(rp.FilterIDs))
-			for i, fltrID := range rp.FilterIDs {
-				fltrIDs[i] = fltrID
+			fltrIDs, e := apierSv1.DataManager.RateProfileFilterIDs(rp)
+			if e != nil {
+				return nil, e
 			}
 			return &fltrIDs, nil
 		}); err != nil && err != utils.ErrNotFound {
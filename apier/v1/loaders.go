@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package v1
 
 import (
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/loaders"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -48,6 +49,24 @@ func (ldrSv1 *LoaderSv1) Remove(args *loaders.ArgsProcessFolder,
 	return ldrSv1.ldrS.V1Remove(args, rply)
 }
 
+// DryRun validates the loader folder without writing into DataDB
+func (ldrSv1 *LoaderSv1) DryRun(args *loaders.ArgsProcessFolder,
+	rply *engine.DryRunReport) error {
+	return ldrSv1.ldrS.V1DryRun(args, rply)
+}
+
+// Rollback restores the profiles as they were before an atomic load
+func (ldrSv1 *LoaderSv1) Rollback(args *loaders.ArgsRollback,
+	rply *string) error {
+	return ldrSv1.ldrS.V1Rollback(args, rply)
+}
+
+// GetLoadVersions returns the atomic loads that can be rolled back
+func (ldrSv1 *LoaderSv1) GetLoadVersions(args *loaders.ArgsGetLoadVersions,
+	rply *[]*loaders.LoadVersion) error {
+	return ldrSv1.ldrS.V1GetLoadVersions(args, rply)
+}
+
 func (rsv1 *LoaderSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
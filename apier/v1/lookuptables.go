@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetLookupTable returns a Lookup Table
+func (apierSv1 *APIerSv1) GetLookupTable(arg *utils.TenantIDWithOpts, reply *engine.LookupTable) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	lt, err := apierSv1.DataManager.GetLookupTable(tnt, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *lt
+	return nil
+}
+
+// GetLookupTableIDs returns list of Lookup Table IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetLookupTableIDs(args *utils.PaginatorWithTenant, ltIDs *[]string) error {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.LookupTablePrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*ltIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+type LookupTableWithCache struct {
+	*engine.LookupTable
+	Cache *string
+	Opts  map[string]interface{}
+}
+
+// SetLookupTable add/update a new Lookup Table
+func (apierSv1 *APIerSv1) SetLookupTable(args *LookupTableWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(args.LookupTable, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.SetLookupTable(args.LookupTable); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheLookupTables and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheLookupTables: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for LookupTable
+	if err := apierSv1.CallCache(args.Cache, args.Tenant, utils.CacheLookupTables,
+		args.TenantID(), nil, nil, args.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveLookupTable remove a specific Lookup Table
+func (apierSv1 *APIerSv1) RemoveLookupTable(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.RemoveLookupTable(tnt,
+		arg.ID, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheLookupTables and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheLookupTables: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for LookupTable
+	if err := apierSv1.CallCache(arg.Cache, tnt, utils.CacheLookupTables,
+		utils.ConcatenatedKey(tnt, arg.ID), nil, nil, arg.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"sort"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetProfileHits returns the number of events matched by a profile and the time of the last match
+func (apierSv1 *APIerSv1) GetProfileHits(args *utils.ArgsGetProfileHits, reply *engine.ProfileHits) error {
+	if missing := utils.MissingStructFields(args, []string{utils.Type, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if !engine.ProfileHitsTypes.Has(args.Type) {
+		return utils.ErrPrefix(utils.ErrNotImplemented, args.Type)
+	}
+	if apierSv1.Config.GeneralCfg().HitsStoreInterval == 0 {
+		return utils.NewErrServiceNotOperational(utils.HitsStoreIntervalCfg)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	engine.StoreProfileHits() // make sure the pending hits are counted
+	ph, err := apierSv1.DataManager.GetProfileHits(tnt, engine.ProfileHitsID(args.Type, args.ID))
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *ph
+	return nil
+}
+
+// GetStaleProfiles returns the IDs of the profiles not matched by any event since the given time
+func (apierSv1 *APIerSv1) GetStaleProfiles(args *utils.ArgsGetStaleProfiles, prfIDs *[]string) error {
+	missing := utils.MissingStructFields(args, []string{utils.Type})
+	if args.Since.IsZero() {
+		missing = append(missing, utils.Since)
+	}
+	if len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if !engine.ProfileHitsTypes.Has(args.Type) {
+		return utils.ErrPrefix(utils.ErrNotImplemented, args.Type)
+	}
+	if apierSv1.Config.GeneralCfg().HitsStoreInterval == 0 { // without counting every profile would look stale
+		return utils.NewErrServiceNotOperational(utils.HitsStoreIntervalCfg)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	engine.StoreProfileHits() // make sure the pending hits are counted
+	prfx := utils.CacheInstanceToPrefix[args.Type] + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	var staleIDs []string
+	for _, key := range keys {
+		prfID := key[len(prfx):]
+		ph, err := apierSv1.DataManager.GetProfileHits(tnt, engine.ProfileHitsID(args.Type, prfID))
+		if err != nil && err != utils.ErrNotFound {
+			return utils.NewErrServerError(err)
+		}
+		if ph == nil || ph.LastHit.Before(args.Since) {
+			staleIDs = append(staleIDs, prfID)
+		}
+	}
+	if len(staleIDs) == 0 {
+		return utils.ErrNotFound
+	}
+	sort.Strings(staleIDs)
+	*prfIDs = args.PaginateStringSlice(staleIDs)
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetRateDeck returns an Rate Deck
+func (apierSv1 *APIerSv1) GetRateDeck(arg *utils.TenantIDWithOpts, reply *engine.RateDeck) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	rd, err := apierSv1.DataManager.GetRateDeck(tnt, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *rd
+	return nil
+}
+
+// GetRateDeckIDs returns list of Rate Deck IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetRateDeckIDs(args *utils.PaginatorWithTenant, rdIDs *[]string) error {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.RateDeckPrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*rdIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+type RateDeckWithCache struct {
+	*engine.RateDeck
+	Cache *string
+	Opts  map[string]interface{}
+}
+
+// SetRateDeck add/update a new Rate Deck
+func (apierSv1 *APIerSv1) SetRateDeck(args *RateDeckWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(args.RateDeck, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	for _, rt := range args.Rates {
+		if rt.Prefix == utils.EmptyString ||
+			rt.Rate == nil {
+			return utils.NewErrMandatoryIeMissing(utils.Prefix, utils.RateString)
+		}
+	}
+	if err := apierSv1.DataManager.SetRateDeck(args.RateDeck); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheRateDecks and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheRateDecks: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for RateDeck
+	if err := apierSv1.CallCache(args.Cache, args.Tenant, utils.CacheRateDecks,
+		args.TenantID(), nil, nil, args.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveRateDeck remove a specific Rate Deck
+func (apierSv1 *APIerSv1) RemoveRateDeck(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.RemoveRateDeck(tnt,
+		arg.ID, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheRateDecks and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheRateDecks: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for RateDeck
+	if err := apierSv1.CallCache(arg.Cache, tnt, utils.CacheRateDecks,
+		utils.ConcatenatedKey(tnt, arg.ID), nil, nil, arg.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -222,6 +222,41 @@ func (rSv1 *RateSv1) CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.R
 	return rSv1.rS.V1CostForEvent(args, rpCost)
 }
 
+// CostForEvents returns the costs for a batch of events, with the errors reported per event
+func (rSv1 *RateSv1) CostForEvents(args *utils.ArgsCostForEvents, evCosts *[]*engine.EventRateProfileCost) (err error) {
+	return rSv1.rS.V1CostForEvents(args, evCosts)
+}
+
+// CompareRateProfiles rates the same batch of events with each of the RateProfiles
+func (rSv1 *RateSv1) CompareRateProfiles(args *utils.ArgsCostForEvents, rpCmps *[]*engine.RateProfileComparison) (err error) {
+	return rSv1.rS.V1CompareRateProfiles(args, rpCmps)
+}
+
+// GetRateForPrefix returns the rate of the longest prefix within a RateDeck matching the destination
+func (rSv1 *RateSv1) GetRateForPrefix(args *utils.ArgsGetRateForPrefix, rdr *engine.RateDeckRate) (err error) {
+	return rSv1.rS.V1GetRateForPrefix(args, rdr)
+}
+
+// GetRateProfileVersions returns the history of a RateProfile
+func (rSv1 *RateSv1) GetRateProfileVersions(args *utils.TenantIDWithOpts, rpvs *[]*engine.RateProfileVersion) (err error) {
+	return rSv1.rS.V1GetRateProfileVersions(args, rpvs)
+}
+
+// UpdateVolumeCounter adds the usage of the event to the volume counter of the account
+func (rSv1 *RateSv1) UpdateVolumeCounter(args *utils.ArgsCostForEvent, reply *string) (err error) {
+	return rSv1.rS.V1UpdateVolumeCounter(args, reply)
+}
+
+// GetVolumeCounter returns the volume counter of an account on a RateProfile
+func (rSv1 *RateSv1) GetVolumeCounter(args *utils.ArgsRateVolumeCounter, rvc *engine.RateVolumeCounter) (err error) {
+	return rSv1.rS.V1GetVolumeCounter(args, rvc)
+}
+
+// ResetVolumeCounter removes the volume counter of an account on a RateProfile
+func (rSv1 *RateSv1) ResetVolumeCounter(args *utils.ArgsRateVolumeCounter, reply *string) (err error) {
+	return rSv1.rS.V1ResetVolumeCounter(args, reply)
+}
+
 func (rSv1 *RateSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
 	return nil
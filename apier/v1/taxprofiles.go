@@ -0,0 +1,152 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetTaxProfile returns a Tax Profile
+func (apierSv1 *APIerSv1) GetTaxProfile(arg *utils.TenantIDWithOpts, reply *engine.TaxProfile) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	tp, err := apierSv1.DataManager.GetTaxProfile(tnt, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *tp
+	return nil
+}
+
+// GetTaxProfileIDs returns list of Tax Profile IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetTaxProfileIDs(args *utils.PaginatorWithTenant, tpIDs *[]string) error {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prfx := utils.TaxProfilePrefix + tnt + utils.ConcatenatedKeySep
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*tpIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+type TaxProfileWithCache struct {
+	*engine.TaxProfile
+	Cache *string
+	Opts  map[string]interface{}
+}
+
+// SetTaxProfile add/update a new Tax Profile
+func (apierSv1 *APIerSv1) SetTaxProfile(args *TaxProfileWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(args.TaxProfile, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if args.Rate == nil {
+		return utils.NewErrMandatoryIeMissing(utils.RateString)
+	}
+	if err := apierSv1.DataManager.SetTaxProfile(args.TaxProfile, true); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheTaxProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheTaxProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for TaxProfile
+	if err := apierSv1.CallCache(args.Cache, args.Tenant, utils.CacheTaxProfiles,
+		args.TenantID(), &args.FilterIDs, nil, args.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveTaxProfile remove a specific Tax Profile
+func (apierSv1 *APIerSv1) RemoveTaxProfile(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if err := apierSv1.DataManager.RemoveTaxProfile(tnt,
+		arg.ID, utils.NonTransactional, true); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheTaxProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheTaxProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for TaxProfile
+	if err := apierSv1.CallCache(arg.Cache, tnt, utils.CacheTaxProfiles,
+		utils.ConcatenatedKey(tnt, arg.ID), nil, nil, arg.Opts); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// GetTaxesForEvent returns the tax lines matching the event for the amount given in Cost field
+func (apierSv1 *APIerSv1) GetTaxesForEvent(args *utils.CGREvent, reply *[]*utils.ExtTaxCharge) (err error) {
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	if _, has := args.Event[utils.Cost]; !has {
+		return utils.NewErrMandatoryIeMissing(utils.Cost)
+	}
+	var cost float64
+	if cost, err = args.FieldAsFloat64(utils.Cost); err != nil {
+		return
+	}
+	amount := utils.NewDecimalFromFloat64(cost)
+	var tcs []*utils.TaxCharge
+	if tcs, err = engine.TaxesForEvent(apierSv1.Config, apierSv1.DataManager,
+		apierSv1.FilterS, args, amount); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	extTcs := make([]*utils.ExtTaxCharge, len(tcs))
+	for i, tc := range tcs {
+		if extTcs[i], err = tc.AsExtTaxCharge(); err != nil {
+			return
+		}
+	}
+	*reply = extTcs
+	return
+}
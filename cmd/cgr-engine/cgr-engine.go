@@ -60,7 +60,7 @@ var (
 	memProfNrFiles    = cgrEngineFlags.Int(utils.MemProfNrFilesCgr, 1, "Number of memory profile to write")
 	scheduledShutdown = cgrEngineFlags.String(utils.ScheduledShutdownCgr, utils.EmptyString, "shutdown the engine after this duration")
 	singlecpu         = cgrEngineFlags.Bool(utils.SingleCpuCgr, false, "Run on single CPU core")
-	syslogger         = cgrEngineFlags.String(utils.LoggerCfg, utils.EmptyString, "logger <*syslog|*stdout>")
+	syslogger         = cgrEngineFlags.String(utils.LoggerCfg, utils.EmptyString, "logger <*syslog|*stdout|*json>")
 	nodeID            = cgrEngineFlags.String(utils.NodeIDCfg, utils.EmptyString, "The node ID of the engine")
 	logLevel          = cgrEngineFlags.Int(utils.LogLevelCfg, -1, "Log level (0-emergency to 7-debug)")
 	preload           = cgrEngineFlags.String(utils.PreloadCgr, utils.EmptyString, "LoaderIDs used to load the data before the engine starts")
@@ -81,6 +81,11 @@ func initCacheS(internalCacheSChan chan rpcclient.ClientConnector,
 	anz *services.AnalyzerService,
 	cpS *engine.CapsStats) (chS *engine.CacheS) {
 	chS = engine.NewCacheS(cfg, dm, cpS)
+	engine.Cache = chS // the internal databases are restored in the new cache
+	if err := engine.RestoreInternalDBs(); err != nil {
+		utils.Logger.Crit(fmt.Sprintf("<%s> could not restore the internal databases, error: %s", utils.CacheS, err.Error()))
+		shdChan.CloseOnce()
+	}
 	go func() {
 		if err := chS.Precache(); err != nil {
 			utils.Logger.Crit(fmt.Sprintf("<%s> could not init, error: %s", utils.CacheS, err.Error()))
@@ -375,6 +380,20 @@ func runPreload(loader *services.LoaderService, internalLoaderSChan chan rpcclie
 	}
 }
 
+// loadConfigFromDataDB loads the config sections stored in DataDB using a temporary connection
+func loadConfigFromDataDB(cfg *config.CGRConfig) (err error) {
+	var d engine.DataDB
+	if d, err = engine.NewDataDBConn(cfg.DataDbCfg().Type,
+		cfg.DataDbCfg().Host, cfg.DataDbCfg().Port,
+		cfg.DataDbCfg().Name, cfg.DataDbCfg().User,
+		cfg.DataDbCfg().Password, cfg.GeneralCfg().DBDataEncoding,
+		cfg.DataDbCfg().Opts); err != nil {
+		return
+	}
+	defer d.Close()
+	return cfg.LoadFromDB(d)
+}
+
 func main() {
 	if err := cgrEngineFlags.Parse(os.Args[1:]); err != nil {
 		return
@@ -449,11 +468,24 @@ func main() {
 		cfg.GeneralCfg().NodeID = *nodeID
 	}
 
+	if cfg.ConfigSCfg().LoadFromDataDB &&
+		cfg.DataDbCfg().Type != utils.INTERNAL { // load the sections from DataDB before the logger, listen and cores are initialized
+		if err = loadConfigFromDataDB(cfg); err != nil {
+			log.Fatalf("Could not load the config from DataDB: <%s>", err.Error())
+			return
+		}
+	}
+
 	config.SetCgrConfig(cfg) // Share the config object
 
 	// init syslog
-	if utils.Logger, err = utils.Newlogger(utils.FirstNonEmpty(*syslogger,
-		cfg.GeneralCfg().Logger), cfg.GeneralCfg().NodeID); err != nil {
+	if lgrType := utils.FirstNonEmpty(*syslogger, cfg.GeneralCfg().Logger); lgrType == utils.MetaJSON &&
+		cfg.GeneralCfg().LogFile != utils.EmptyString {
+		utils.Logger, err = utils.NewJSONFileLogger(cfg.GeneralCfg().LogFile, cfg.GeneralCfg().NodeID)
+	} else {
+		utils.Logger, err = utils.Newlogger(lgrType, cfg.GeneralCfg().NodeID)
+	}
+	if err != nil {
 		log.Fatalf("Could not initialize syslog connection, err: <%s>", err.Error())
 		return
 	}
@@ -462,6 +494,21 @@ func main() {
 		lgLevel = *logLevel
 	}
 	utils.Logger.SetLogLevel(lgLevel)
+	for subsys, lvl := range cfg.GeneralCfg().LogLevels {
+		utils.Logger.SetSubsystemLogLevel(subsys, lvl)
+	}
+	// init the tracing
+	var tracer *utils.Tracer
+	if cfg.GeneralCfg().TracesExporter != utils.EmptyString {
+		var spanExp utils.SpanExporter
+		if spanExp, err = utils.NewSpanExporter(cfg.GeneralCfg().TracesExporter,
+			cfg.GeneralCfg().TracesEndpoint, cfg.GeneralCfg().ReplyTimeout); err != nil {
+			log.Fatalf("Could not initialize the traces exporter, err: <%s>", err.Error())
+			return
+		}
+		tracer = utils.NewTracer(spanExp, cfg.GeneralCfg().NodeID, time.Second)
+		utils.SetTracer(tracer)
+	}
 	// init the concurrentRequests
 	cncReqsLimit := cfg.CoreSCfg().Caps
 	if utils.ConcurrentReqsLimit != 0 { // used as shared variable
@@ -624,7 +671,6 @@ func main() {
 
 	// init CacheS
 	cacheS := initCacheS(internalCacheSChan, server, dmService.GetDM(), shdChan, anz, coreS.GetCoreS().CapsStats)
-	engine.Cache = cacheS
 
 	// init GuardianSv1
 	initGuardianSv1(internalGuardianSChan, server, anz)
@@ -670,10 +716,10 @@ func main() {
 		services.NewDNSAgent(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewFreeswitchAgent(cfg, shdChan, connManager, srvDep),
 		services.NewKamailioAgent(cfg, shdChan, connManager, srvDep),
-		services.NewAsteriskAgent(cfg, shdChan, connManager, srvDep),              // partial reload
-		services.NewRadiusAgent(cfg, filterSChan, shdChan, connManager, srvDep),   // partial reload
-		services.NewDiameterAgent(cfg, filterSChan, shdChan, connManager, srvDep), // partial reload
-		services.NewHTTPAgent(cfg, filterSChan, server, connManager, srvDep),      // no reload
+		services.NewAsteriskAgent(cfg, shdChan, connManager, srvDep),                      // partial reload
+		services.NewRadiusAgent(cfg, filterSChan, shdChan, connManager, srvDep),           // partial reload
+		services.NewDiameterAgent(cfg, filterSChan, server, shdChan, connManager, srvDep), // partial reload
+		services.NewHTTPAgent(cfg, filterSChan, server, connManager, srvDep),              // no reload
 		ldrs, anz, dspS, dspH, dmService, storDBService,
 		services.NewEventExporterService(cfg, filterSChan,
 			connManager, server, internalEEsChan, anz, srvDep),
@@ -747,6 +793,13 @@ func main() {
 			utils.ServiceManager))
 	}
 
+	if tracer != nil { // export the remaining spans
+		utils.SetTracer(nil)
+		if err := tracer.Close(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error closing the traces exporter: %s", utils.CoreS, err.Error()))
+		}
+	}
+
 	if *memProfDir != utils.EmptyString { // write last memory profiling
 		memProfFile(path.Join(*memProfDir, utils.MemProfFileCgr))
 	}
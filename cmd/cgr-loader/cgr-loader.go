@@ -366,14 +366,22 @@ func main() {
 		ldrCfg.LoaderCgrCfg().SchedulerConns, false); err != nil {
 		log.Fatal(err)
 	}
-	if err = tpReader.LoadAll(); err != nil {
-		log.Fatal(err)
-	}
-
 	if *dryRun { // We were just asked to parse the data, not saving it
+		var rpt *engine.DryRunReport
+		if rpt, err = tpReader.DryRun(*remove); err != nil {
+			log.Fatal("Could not validate the data: ", err)
+		}
+		fmt.Println(utils.ToIJSON(rpt))
+		if rpt.HasErrors() {
+			os.Exit(1)
+		}
 		return
 	}
 
+	if err = tpReader.LoadAll(); err != nil {
+		log.Fatal(err)
+	}
+
 	if *remove {
 		if err = tpReader.RemoveFromDatabase(*verbose, *disableReverse); err != nil {
 			log.Fatal("Could not delete from database: ", err)
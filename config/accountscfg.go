@@ -33,6 +33,8 @@ type AccountSCfg struct {
 	NestedFields        bool
 	MaxIterations       int
 	MaxUsage            *utils.Decimal
+	// ExchangeRateProfileIDs are queried in order when converting between currencies
+	ExchangeRateProfileIDs []string
 }
 
 func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
@@ -107,6 +109,12 @@ func (acS *AccountSCfg) loadFromJSONCfg(jsnCfg *AccountSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Exchange_rate_profile_ids != nil {
+		acS.ExchangeRateProfileIDs = make([]string, len(*jsnCfg.Exchange_rate_profile_ids))
+		for i, erpID := range *jsnCfg.Exchange_rate_profile_ids {
+			acS.ExchangeRateProfileIDs[i] = erpID
+		}
+	}
 	return
 }
 
@@ -172,6 +180,13 @@ func (acS *AccountSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	if acS.MaxUsage != nil {
 		initialMP[utils.MaxUsage] = acS.MaxUsage
 	}
+	if acS.ExchangeRateProfileIDs != nil {
+		erpIDs := make([]string, len(acS.ExchangeRateProfileIDs))
+		for i, item := range acS.ExchangeRateProfileIDs {
+			erpIDs[i] = item
+		}
+		initialMP[utils.ExchangeRateProfileIDsCfg] = erpIDs
+	}
 	return
 }
 
@@ -190,6 +205,12 @@ func (acS AccountSCfg) Clone() (cln *AccountSCfg) {
 			cln.AttributeSConns[i] = con
 		}
 	}
+	if acS.ExchangeRateProfileIDs != nil {
+		cln.ExchangeRateProfileIDs = make([]string, len(acS.ExchangeRateProfileIDs))
+		for i, erpID := range acS.ExchangeRateProfileIDs {
+			cln.ExchangeRateProfileIDs[i] = erpID
+		}
+	}
 	if acS.RateSConns != nil {
 		cln.RateSConns = make([]string, len(acS.RateSConns))
 		for i, con := range acS.RateSConns {
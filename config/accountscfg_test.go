@@ -27,34 +27,36 @@ import (
 
 func TestAccountSCfgLoadFromJSONCfg(t *testing.T) {
 	jsonCfg := &AccountSJsonCfg{
-		Enabled:               utils.BoolPointer(true),
-		Attributes_conns:      &[]string{utils.MetaInternal},
-		Rates_conns:           &[]string{utils.MetaInternal},
-		Thresholds_conns:      &[]string{utils.MetaInternal},
-		Indexed_selects:       utils.BoolPointer(false),
-		String_indexed_fields: &[]string{"*req.index1"},
-		Prefix_indexed_fields: &[]string{"*req.index1"},
-		Suffix_indexed_fields: &[]string{"*req.index1"},
-		Nested_fields:         utils.BoolPointer(true),
-		Max_iterations:        utils.IntPointer(1000),
-		Max_usage:             utils.StringPointer("200h"),
+		Enabled:                   utils.BoolPointer(true),
+		Attributes_conns:          &[]string{utils.MetaInternal},
+		Rates_conns:               &[]string{utils.MetaInternal},
+		Thresholds_conns:          &[]string{utils.MetaInternal},
+		Indexed_selects:           utils.BoolPointer(false),
+		String_indexed_fields:     &[]string{"*req.index1"},
+		Prefix_indexed_fields:     &[]string{"*req.index1"},
+		Suffix_indexed_fields:     &[]string{"*req.index1"},
+		Nested_fields:             utils.BoolPointer(true),
+		Max_iterations:            utils.IntPointer(1000),
+		Max_usage:                 utils.StringPointer("200h"),
+		Exchange_rate_profile_ids: &[]string{"ERP_EUR", "ERP_USD"},
 	}
 	usage, err := utils.NewDecimalFromUsage("200h")
 	if err != nil {
 		t.Error(err)
 	}
 	expected := &AccountSCfg{
-		Enabled:             true,
-		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
-		RateSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)},
-		ThresholdSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
-		IndexedSelects:      false,
-		StringIndexedFields: &[]string{"*req.index1"},
-		PrefixIndexedFields: &[]string{"*req.index1"},
-		SuffixIndexedFields: &[]string{"*req.index1"},
-		NestedFields:        true,
-		MaxIterations:       1000,
-		MaxUsage:            usage,
+		Enabled:                true,
+		AttributeSConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)},
+		RateSConns:             []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)},
+		ThresholdSConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)},
+		IndexedSelects:         false,
+		StringIndexedFields:    &[]string{"*req.index1"},
+		PrefixIndexedFields:    &[]string{"*req.index1"},
+		SuffixIndexedFields:    &[]string{"*req.index1"},
+		NestedFields:           true,
+		MaxIterations:          1000,
+		MaxUsage:               usage,
+		ExchangeRateProfileIDs: []string{"ERP_EUR", "ERP_USD"},
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.accountSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -89,20 +91,22 @@ func TestAccountSCfgAsMapInterface(t *testing.T) {
 	"nested_fields": true,			
     "max_iterations": 100,
     "max_usage": "72h",
+	"exchange_rate_profile_ids": ["ERP_EUR"],
 },	
 }`
 
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:             true,
-		utils.IndexedSelectsCfg:      false,
-		utils.AttributeSConnsCfg:     []string{utils.MetaInternal},
-		utils.RateSConnsCfg:          []string{utils.MetaInternal},
-		utils.ThresholdSConnsCfg:     []string{utils.MetaInternal},
-		utils.StringIndexedFieldsCfg: []string{"*req.index1"},
-		utils.PrefixIndexedFieldsCfg: []string{"*req.index1"},
-		utils.SuffixIndexedFieldsCfg: []string{"*req.index1"},
-		utils.NestedFieldsCfg:        true,
-		utils.MaxIterations:          100,
+		utils.EnabledCfg:                true,
+		utils.IndexedSelectsCfg:         false,
+		utils.AttributeSConnsCfg:        []string{utils.MetaInternal},
+		utils.RateSConnsCfg:             []string{utils.MetaInternal},
+		utils.ThresholdSConnsCfg:        []string{utils.MetaInternal},
+		utils.StringIndexedFieldsCfg:    []string{"*req.index1"},
+		utils.PrefixIndexedFieldsCfg:    []string{"*req.index1"},
+		utils.SuffixIndexedFieldsCfg:    []string{"*req.index1"},
+		utils.NestedFieldsCfg:           true,
+		utils.MaxIterations:             100,
+		utils.ExchangeRateProfileIDsCfg: []string{"ERP_EUR"},
 	}
 	usage, err := utils.NewDecimalFromUsage("72h")
 	if err != nil {
@@ -123,17 +127,18 @@ func TestAccountSCfgClone(t *testing.T) {
 		t.Error(err)
 	}
 	ban := &AccountSCfg{
-		Enabled:             true,
-		IndexedSelects:      false,
-		AttributeSConns:     []string{"*req.index1"},
-		RateSConns:          []string{"*req.index1"},
-		ThresholdSConns:     []string{"*req.index1"},
-		StringIndexedFields: &[]string{"*req.index1"},
-		PrefixIndexedFields: &[]string{"*req.index1", "*req.index2"},
-		SuffixIndexedFields: &[]string{"*req.index1"},
-		NestedFields:        true,
-		MaxIterations:       1000,
-		MaxUsage:            usage,
+		Enabled:                true,
+		IndexedSelects:         false,
+		AttributeSConns:        []string{"*req.index1"},
+		RateSConns:             []string{"*req.index1"},
+		ThresholdSConns:        []string{"*req.index1"},
+		StringIndexedFields:    &[]string{"*req.index1"},
+		PrefixIndexedFields:    &[]string{"*req.index1", "*req.index2"},
+		SuffixIndexedFields:    &[]string{"*req.index1"},
+		NestedFields:           true,
+		MaxIterations:          1000,
+		MaxUsage:               usage,
+		ExchangeRateProfileIDs: []string{"ERP_EUR"},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -157,4 +162,7 @@ func TestAccountSCfgClone(t *testing.T) {
 	if (*rcv.SuffixIndexedFields)[0] = utils.EmptyString; (*ban.SuffixIndexedFields)[0] != "*req.index1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ExchangeRateProfileIDs[0] = utils.EmptyString; ban.ExchangeRateProfileIDs[0] != "ERP_EUR" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -204,8 +204,11 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.apiBanCfg = new(APIBanCfg)
 	cfg.coreSCfg = new(CoreSCfg)
 	cfg.accountSCfg = new(AccountSCfg)
+	cfg.taxSCfg = new(TaxSCfg)
 
 	cfg.cacheDP = make(map[string]utils.MapStorage)
+	cfg.history = newConfigHistory()
+	cfg.dbLayers = new(configDBLayers)
 
 	var cgrJSONCfg *CgrJsonCfg
 	if cgrJSONCfg, err = NewCgrJsonCfgFromBytes(config); err != nil {
@@ -340,15 +343,21 @@ type CGRConfig struct {
 	apiBanCfg        *APIBanCfg        // APIBan config
 	coreSCfg         *CoreSCfg         // CoreS config
 	accountSCfg      *AccountSCfg      // AccountS config
+	taxSCfg          *TaxSCfg          // taxes config
 
 	cacheDP    map[string]utils.MapStorage
 	cacheDPMux sync.RWMutex
+
+	history  *configHistory  // versions recorded on runtime changes
+	dbLayers *configDBLayers // sections loaded out of DataDB
 }
 
 var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
 	utils.MetaResources, utils.MetaFilters, utils.MetaStats,
 	utils.MetaRoutes, utils.MetaThresholds, utils.MetaChargers,
-	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaRateProfiles})
+	utils.MetaDispatchers, utils.MetaDispatcherHosts, utils.MetaRateProfiles,
+	utils.MetaExchangeRateProfiles, utils.MetaRateDecks, utils.MetaTaxProfiles,
+	utils.MetaLookupTables})
 
 var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 	utils.MetaKafkajsonMap, utils.MetaFileXML, utils.MetaSQL, utils.MetaFileFWV,
@@ -356,7 +365,8 @@ var possibleReaderTypes = utils.NewStringSet([]string{utils.MetaFileCSV,
 
 var possibleExporterTypes = utils.NewStringSet([]string{utils.MetaFileCSV, utils.MetaNone, utils.MetaFileFWV,
 	utils.MetaHTTPPost, utils.MetaHTTPjsonMap, utils.MetaAMQPjsonMap, utils.MetaAMQPV1jsonMap, utils.MetaSQSjsonMap,
-	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL})
+	utils.MetaKafkajsonMap, utils.MetaS3jsonMap, utils.MetaElastic, utils.MetaVirt, utils.MetaSQL,
+	utils.MetaFileJSON, utils.MetaFileHTML})
 
 // LazySanityCheck used after check config sanity to display warnings related to the config
 func (cfg *CGRConfig) LazySanityCheck() {
@@ -411,7 +421,7 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
 		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadRegistrarCCfg,
 		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg, cfg.loadActionSCfg,
-		cfg.loadAccountSCfg} {
+		cfg.loadAccountSCfg, cfg.loadTaxSCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -863,6 +873,15 @@ func (cfg *CGRConfig) loadAccountSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.accountSCfg.loadFromJSONCfg(jsnActionCfg)
 }
 
+// loadTaxSCfg loads the taxes section of the configuration
+func (cfg *CGRConfig) loadTaxSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnTaxCfg *TaxSJsonCfg
+	if jsnTaxCfg, err = jsnCfg.TaxSCfgJson(); err != nil {
+		return
+	}
+	return cfg.taxSCfg.loadFromJSONCfg(jsnTaxCfg)
+}
+
 // SureTaxCfg use locking to retrieve the configuration, possibility later for runtime reload
 func (cfg *CGRConfig) SureTaxCfg() *SureTaxCfg {
 	cfg.lks[SURETAX_JSON].Lock()
@@ -1141,6 +1160,13 @@ func (cfg *CGRConfig) AccountSCfg() *AccountSCfg {
 	return cfg.accountSCfg
 }
 
+// TaxSCfg reads the taxes configuration
+func (cfg *CGRConfig) TaxSCfg() *TaxSCfg {
+	cfg.lks[TaxSJson].RLock()
+	defer cfg.lks[TaxSJson].RUnlock()
+	return cfg.taxSCfg
+}
+
 // SIPAgentCfg reads the Apier configuration
 func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	cfg.lks[SIPAgentJson].Lock()
@@ -1297,6 +1323,7 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		CoreSCfgJson:       cfg.loadCoreSCfg,
 		ActionSJson:        cfg.loadActionSCfg,
 		AccountSCfgJson:    cfg.loadAccountSCfg,
+		TaxSJson:           cfg.loadTaxSCfg,
 	}
 }
 
@@ -1607,6 +1634,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		CoreSCfgJson:       cfg.coreSCfg.AsMapInterface(),
 		ActionSJson:        cfg.actionSCfg.AsMapInterface(),
 		AccountSCfgJson:    cfg.accountSCfg.AsMapInterface(),
+		TaxSJson:           cfg.taxSCfg.AsMapInterface(),
 	}
 }
 
@@ -1625,8 +1653,9 @@ func (cfg *CGRConfig) V1ReloadConfig(args *ReloadArgs, reply *string) (err error
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
 	cfgV := cfg
+	oldCfg := cfg.Clone()
 	if args.DryRun {
-		cfgV = cfg.Clone()
+		cfgV = oldCfg
 	}
 	cfgV.reloadDPCache(args.Section)
 	if err = cfgV.loadCfgWithLocks(args.Path, args.Section); err != nil {
@@ -1643,11 +1672,12 @@ func (cfg *CGRConfig) V1ReloadConfig(args *ReloadArgs, reply *string) (err error
 		return
 	}
 	if !args.DryRun {
+		sections := []string{args.Section}
 		if args.Section == utils.EmptyString || args.Section == utils.MetaAll {
-			cfgV.reloadSections(sortedCfgSections...)
-		} else {
-			cfgV.reloadSections(args.Section)
+			sections = sortedCfgSections
 		}
+		cfgV.reloadSections(sections...)
+		cfgV.recordConfigVersionWithLog(utils.ConfigSv1ReloadConfig, args.Opts, oldCfg, sections)
 	}
 	*reply = utils.OK
 	return
@@ -1773,6 +1803,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithOpts, reply *map[string]inter
 		mp = cfg.ActionSCfg().AsMapInterface()
 	case AccountSCfgJson:
 		mp = cfg.AccountSCfg().AsMapInterface()
+	case TaxSJson:
+		mp = cfg.TaxSCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -1803,8 +1835,9 @@ func (cfg *CGRConfig) V1SetConfig(args *SetConfigArgs, reply *string) (err error
 		return
 	}
 	cfgV := cfg
+	oldCfg := cfg.Clone()
 	if args.DryRun {
-		cfgV = cfg.Clone()
+		cfgV = oldCfg
 	}
 
 	cfgV.reloadDPCache(sections...)
@@ -1823,12 +1856,13 @@ func (cfg *CGRConfig) V1SetConfig(args *SetConfigArgs, reply *string) (err error
 	}
 	if !args.DryRun {
 		cfgV.reloadSections(sections...)
+		cfgV.recordConfigVersionWithLog(utils.ConfigSv1SetConfig, args.Opts, oldCfg, sections)
 	}
 	*reply = utils.OK
 	return
 }
 
-//V1GetConfigAsJSON will retrieve from CGRConfig a section as a string
+// V1GetConfigAsJSON will retrieve from CGRConfig a section as a string
 func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithOpts, reply *string) (err error) {
 	args.Section = utils.FirstNonEmpty(args.Section, utils.MetaAll)
 	cfg.cacheDPMux.RLock()
@@ -1941,6 +1975,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithOpts, reply *string) (e
 		mp = cfg.CoreSCfg().AsMapInterface()
 	case AccountSCfgJson:
 		mp = cfg.AccountSCfg().AsMapInterface()
+	case TaxSJson:
+		mp = cfg.TaxSCfg().AsMapInterface()
 	default:
 		return errors.New("Invalid section")
 	}
@@ -1964,8 +2000,9 @@ func (cfg *CGRConfig) V1SetConfigFromJSON(args *SetConfigFromJSONArgs, reply *st
 		return
 	}
 	cfgV := cfg
+	oldCfg := cfg.Clone()
 	if args.DryRun {
-		cfgV = cfg.Clone()
+		cfgV = oldCfg
 	}
 
 	cfgV.reloadDPCache(sortedCfgSections...)
@@ -1982,6 +2019,7 @@ func (cfg *CGRConfig) V1SetConfigFromJSON(args *SetConfigFromJSONArgs, reply *st
 	}
 	if !args.DryRun {
 		cfgV.reloadSections(sortedCfgSections...)
+		cfgV.recordConfigVersionWithLog(utils.ConfigSv1SetConfigFromJSON, args.Opts, oldCfg, sortedCfgSections)
 	}
 	*reply = utils.OK
 	return
@@ -2040,8 +2078,11 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		coreSCfg:         cfg.coreSCfg.Clone(),
 		actionSCfg:       cfg.actionSCfg.Clone(),
 		accountSCfg:      cfg.accountSCfg.Clone(),
+		taxSCfg:          cfg.taxSCfg.Clone(),
 
-		cacheDP: make(map[string]utils.MapStorage),
+		cacheDP:  make(map[string]utils.MapStorage),
+		history:  newConfigHistory(),
+		dbLayers: new(configDBLayers),
 	}
 	cln.initChanels()
 	return
@@ -29,8 +29,12 @@ const CGRATES_CFG_JSON = `
 
 "general": {
 	"node_id": "",											// identifier of this instance in the cluster, if empty it will be autogenerated
-	"logger":"*syslog",										// controls the destination of logs <*syslog|*stdout>
+	"logger":"*syslog",										// controls the destination of logs <*syslog|*stdout|*json>
 	"log_level": 6,											// control the level of messages logged (0-emerg to 7-debug)
+	"log_levels": {},										// log level overwrites per subsystem, ie: {"SessionS": 7}
+	"log_file": "",											// file where the *json logger writes, empty for stdout
+	"traces_exporter": "",									// exporter for the trace spans <""|*file|*http>, empty disables the tracing
+	"traces_endpoint": "",									// file path for *file or OTLP/HTTP collector URL for *http, ie: http://127.0.0.1:4318/v1/traces
 	"rounding_decimals": 5,									// system level precision for floats
 	"dbdata_encoding": "*msgpack",							// encoding used to store object data in strings: <*msgpack|*json>
 	"tpexport_dir": "/var/spool/cgrates/tpe",				// path towards export folder for offline TariffPlans
@@ -49,6 +53,7 @@ const CGRATES_CFG_JSON = `
 	"connect_timeout": "1s",								// consider connection unsuccessful on timeout, 0 to disable the feature
 	"reply_timeout": "2s",									// consider connection down for replies taking longer than this value
 	"locking_timeout": "0",									// timeout internal locks to avoid deadlocks
+	"hits_store_interval": "0",								// interval to store the profile hit counters in DataDB: <""|0|-1|$dur>, 0 disables the counting, -1 stores on each hit
 	"digest_separator": ",",								// separator to use in replies containing data digests
 	"digest_equal": ":",									// equal symbol used in case of digests
 	"rsr_separator": ";",									// separator used within RSR fields
@@ -123,6 +128,9 @@ const CGRATES_CFG_JSON = `
 		"redis_client_certificate":"",			// path to client certificate
 		"redis_client_key":"",					// path to client key
 		"redis_ca_certificate":"",				// path to CA certificate (populate for self-signed certificate otherwise let it empty)
+		"internal_db_path": "",				// directory for the snapshot and append-only log of *internal; empty keeps the data only in memory
+		"internal_db_snapshot_interval": "0",	// interval between the snapshots of *internal, 0 to snapshot only on shutdown
+		"internal_db_fsync": "*none",			// sync the append-only log to disk: <*always|*none|$interval>
 	}
 },
 
@@ -143,10 +151,14 @@ const CGRATES_CFG_JSON = `
 		"query_timeout":"10s",
 		"sslmode":"disable",				// sslmode in case of *postgres
 		"mysql_location": "Local",			// the location the time from mysql is retrived
+		"internal_db_path": "",				// directory for the snapshot and append-only log of *internal; empty keeps the data only in memory
+		"internal_db_snapshot_interval": "0",	// interval between the snapshots of *internal, 0 to snapshot only on shutdown
+		"internal_db_fsync": "*none",			// sync the append-only log to disk: <*always|*none|$interval>
 	},
 	"items":{
 		"*session_costs": {"remote":false, "replicate":false}, 
 		"*cdrs": {"remote":false, "replicate":false}, 		
+		"*invoices": {"remote":false, "replicate":false},
 		"*tp_timings":{"remote":false, "replicate":false}, 					
 		"*tp_destinations": {"remote":false, "replicate":false},
 		"*tp_rates": {"remote":false, "replicate":false}, 
@@ -230,6 +242,7 @@ const CGRATES_CFG_JSON = `
 	"cdrs_conns": [],				// connections to CDRs for *cdrlog actions <""|*internal|$rpc_conns_id>
 	"thresholds_conns": [],			// connections to ThresholdS for *reset_threshold action <""|*internal|$rpc_conns_id>
 	"stats_conns": [],				// connections to StatS for *reset_stat_queue action: <""|*internal|$rpc_conns_id>
+	"diameter_agent_conns": [],		// connections to DiameterAgent for *diameter_policy_counters action: <""|$rpc_conns_id>
 	"filters": [],					// only execute actions matching these filters
 },
 
@@ -262,6 +275,15 @@ const CGRATES_CFG_JSON = `
 		"*rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// control rate profile caching
 		"*action_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control action profile caching
 		"*account_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},		// control account profile caching
+		"*exchange_rate_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},	// control exchange rate profile caching
+		"*rate_volume_counters": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// volume counters of the rate profiles, used only by internal DataDB
+		"*rate_decks": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control rate deck caching
+		"*rate_profile_versions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// versions of the rate profiles, used only by internal DataDB
+		"*tax_profiles": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},				// control tax profile caching
+		"*lookup_tables": {"limit": -1, "ttl": "", "static_ttl": false, "precache": false, "replicate": false},			// control lookup table caching
+		"*profile_hits": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},								// hit counters of the profiles, used only by internal DataDB
+		"*load_versions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},								// atomic loads of the loaders, used only by internal DataDB
+		"*active_load_versions": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},						// load versions switched in by the loaders, used only by internal DataDB
 		"*resource_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control resource filter indexes caching
 		"*stat_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 					// control stat filter indexes caching
 		"*threshold_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control threshold filter indexes caching
@@ -273,11 +295,13 @@ const CGRATES_CFG_JSON = `
 		"*rate_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 					// control rate filter indexes caching
 		"*action_profile_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 			// control action profile filter indexes caching
 		"*account_profile_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 			// control coount profile filter indexes caching
+		"*tax_profile_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 				// control tax profile filter indexes caching
 		"*reverse_filter_indexes" : {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 					// control reverse filter indexes caching used only for set and remove filters 
 		"*dispatcher_routes": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 						// control dispatcher routes caching
 		"*dispatcher_loads": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},							// control dispatcher load( in case of *ratio ConnParams is present)
 		"*dispatchers": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 								// control dispatcher interface
 		"*diameter_messages": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// diameter messages caching
+		"*radius_packets": {"limit": -1, "ttl": "3h", "static_ttl": false, "replicate": false},						// radius packets caching
 		"*rpc_responses": {"limit": 0, "ttl": "2s", "static_ttl": false, "replicate": false},							// RPC responses caching
 		"*closed_sessions": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},						// closed sessions cached for CDRs
 		"*event_charges": {"limit": -1, "ttl": "10s", "static_ttl": false, "replicate": false},							// events proccessed by ChargerS
@@ -296,6 +320,7 @@ const CGRATES_CFG_JSON = `
 		// internal storDB tabels
 		"*session_costs": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 
 		"*cdrs": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 		
+		"*invoices": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},
 		"*tp_timings":{"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 					
 		"*tp_destinations": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false},
 		"*tp_rates": {"limit": -1, "ttl": "", "static_ttl": false, "replicate": false}, 
@@ -531,6 +556,27 @@ const CGRATES_CFG_JSON = `
 	"asr_template": "",											// enable AbortSession message being sent to client on DisconnectSession
 	"rar_template": "",											// template used to build the Re-Auth-Request
 	"forced_disconnect": "*none",								// the request to send to diameter on DisconnectSession <*none|*asr|*rar>
+	"watchdog_interval": "30s",									// Tw, interval between the DWRs sent to the outgoing peers
+	"reconnect_interval": "5s",									// initial interval to reconnect to outgoing peers, doubled at each failure
+	"max_reconnect_interval": "1m",								// maximum interval to reconnect to outgoing peers
+	"peers": [													// outgoing peers the agent connects to
+		// {
+		// 	"id": "PCEF1",										// peer identifier
+		// 	"address": "127.0.0.1:3869",						// address of the peer <x.y.z.y:1234>
+		// 	"network": "tcp",									// transport type for diameter <tcp|sctp>
+		// 	"auth_application_ids": [4],						// Auth-Application-Id AVPs advertised in the CER
+		// },
+	],
+	"policy_counters_interval": "0",							// re-evaluate the subscribed Sy policy counters, sending SNR on status change <0 to disable>
+	"policy_counters": [										// Sy policy counters reported to the PCRF
+		// {
+		// 	"id": "DATA_LIMIT",									// Policy-Counter-Identifier
+		// 	"default_status": "valid",							// status reported when no other status matches
+		// 	"statuses": [										// first status with all filters passing is reported
+		// 		{"status": "exceeded", "filters": ["*gte:~*stats.<SQ_;~*req.Subscription-Id.Subscription-Id-Data>.*sum#~*req.Usage:1073741824"]},
+		// 	],
+		// },
+	],
 	"request_processors": [				// list of processors to be applied to diameter messages
 	],
 },
@@ -547,7 +593,10 @@ const CGRATES_CFG_JSON = `
 	"client_dictionaries": {									// per client path towards directory holding additional dictionaries to load (extra to RFC)
 		"*default": "/usr/share/cgrates/radius/dict/",			// key represents the client IP or catch-all <*default|$client_ip>
 	},
-	"sessions_conns": ["*internal"],
+	"client_da_addresses": {},									// address where to send the CoA/Disconnect requests, keyed by client IP <$client_ip: $host:$port>, defaults to port 3799 of the client
+	"sessions_conns": ["*internal"],							// use *birpc_internal to receive the CoA/Disconnect requests from SessionS
+	"dmr_template": "",											// template used to build the Disconnect-Request sent on DisconnectSession, empty to disable, requires *birpc_internal sessions_conns
+	"coa_template": "",											// template used to build the CoA-Request sent on ReAuthorize, empty to disable, requires *birpc_internal sessions_conns
 	"request_processors": [										// request processors to be applied to Radius messages
 	],
 },
@@ -640,6 +689,7 @@ const CGRATES_CFG_JSON = `
 	"resources_conns": [],					// connections to ResourceS for *res sorting, empty to disable functionality: <""|*internal|$rpc_conns_id>
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"rates_conns": [],						// connections to RateS for the customer price used by *margin sorting: <""|*internal|$rpc_conns_id>
 	"default_ratio":1						// default ratio used in case of *load strategy
 },
 
@@ -652,10 +702,13 @@ const CGRATES_CFG_JSON = `
 		"dry_run": false,									// do not send the CDRs to CDRS, just parse them
 		"run_delay": "0",									// sleep interval in seconds between consecutive runs, -1 to use automation via inotify or 0 to disable running all together
 		"lock_filename": ".cgr.lck",						// Filename containing concurrency lock in case of delayed processing
+		"atomic": false,									// stage the whole load and switch it in at once, keeping the previous versions for rollback
+		"versions_limit": 3,								// number of atomic load versions kept in DataDB for rollback
 		"caches_conns": ["*internal"],
 		"field_separator": ",",								// separator used in case of csv files
-		"tp_in_dir": "/var/spool/cgrates/loader/in",		// absolute path towards the directory where the TPs are stored
+		"tp_in_dir": "/var/spool/cgrates/loader/in",		// absolute path towards the directory where the TPs are stored or <s3://bucket/prefix|sftp://user@host/path|http(s)://host/path>
 		"tp_out_dir": "/var/spool/cgrates/loader/out",		// absolute path towards the directory where processed TPs will be moved
+		"opts": {},											// source options, eg: awsRegion, awsKey, awsSecret, awsToken, s3Endpoint, sftpKeyFile, sftpKnownHostsFile, sftpInsecureHostKey
 		"data":[											// data profiles to load
 			{
 				"type": "*attributes",						// data source type
@@ -759,6 +812,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RouteBlocker", "path": "RouteBlocker", "type": "*variable", "value": "~*req.13"},
 					{"tag": "RouteParameters", "path": "RouteParameters", "type": "*variable", "value": "~*req.14"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.15"},
+					{"tag": "RouteRateProfileIDs", "path": "RouteRateProfileIDs", "type": "*variable", "value": "~*req.16"},
 				],
 			},
 			{
@@ -772,6 +826,11 @@ const CGRATES_CFG_JSON = `
 					{"tag": "RunID", "path": "RunID", "type": "*variable", "value": "~*req.4"},
 					{"tag": "AttributeIDs", "path": "AttributeIDs", "type": "*variable", "value": "~*req.5"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.6"},
+					{"tag": "AggregationPath", "path": "AggregationPath", "type": "*variable", "value": "~*req.7"},
+					{"tag": "AggregationFilterIDs", "path": "AggregationFilterIDs", "type": "*variable", "value": "~*req.8"},
+					{"tag": "AggregationType", "path": "AggregationType", "type": "*variable", "value": "~*req.9"},
+					{"tag": "AggregationRunIDs", "path": "AggregationRunIDs", "type": "*variable", "value": "~*req.10"},
+					{"tag": "AggregationThresholdIDs", "path": "AggregationThresholdIDs", "type": "*variable", "value": "~*req.11"},
 				],
 			},
 			{
@@ -826,6 +885,8 @@ const CGRATES_CFG_JSON = `
                     {"tag": "RateRecurrentFee", "path": "RateRecurrentFee", "type": "*variable", "value": "~*req.15"},
 					{"tag": "RateUnit", "path": "RateUnit", "type": "*variable", "value": "~*req.16"},
 					{"tag": "RateIncrement", "path": "RateIncrement", "type": "*variable", "value": "~*req.17"},
+					{"tag": "Currency", "path": "Currency", "type": "*variable", "value": "~*req.18"},
+					{"tag": "VolumePeriod", "path": "VolumePeriod", "type": "*variable", "value": "~*req.19"},
 				],
 			},
 			{
@@ -873,6 +934,61 @@ const CGRATES_CFG_JSON = `
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.16"},
 				],
 			},
+			{
+				"type": "*exchange_rate_profiles",					// data source type
+				"file_name": "ExchangeRateProfiles.csv",			// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~*req.0", "mandatory": true},
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~*req.1", "mandatory": true},
+					{"tag": "FromCurrency", "path": "FromCurrency", "type": "*variable", "value": "~*req.2"},
+					{"tag": "ToCurrency", "path": "ToCurrency", "type": "*variable", "value": "~*req.3"},
+					{"tag": "ActivationTime", "path": "ActivationTime", "type": "*variable", "value": "~*req.4"},
+					{"tag": "Rate", "path": "Rate", "type": "*variable", "value": "~*req.5"},
+				],
+			},
+			{
+				"type": "*rate_decks",								// data source type
+				"file_name": "RateDecks.csv",						// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~*req.0", "mandatory": true},
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~*req.1", "mandatory": true},
+					{"tag": "IncreaseNotice", "path": "IncreaseNotice", "type": "*variable", "value": "~*req.2"},
+					{"tag": "DecreaseNotice", "path": "DecreaseNotice", "type": "*variable", "value": "~*req.3"},
+					{"tag": "Prefix", "path": "Prefix", "type": "*variable", "value": "~*req.4"},
+					{"tag": "Description", "path": "Description", "type": "*variable", "value": "~*req.5"},
+					{"tag": "Rate", "path": "Rate", "type": "*variable", "value": "~*req.6"},
+					{"tag": "EffectiveDate", "path": "EffectiveDate", "type": "*variable", "value": "~*req.7"},
+					{"tag": "ExpiryDate", "path": "ExpiryDate", "type": "*variable", "value": "~*req.8"},
+				],
+			},
+			{
+				"type": "*tax_profiles",							// data source type
+				"file_name": "TaxProfiles.csv",						// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~*req.0", "mandatory": true},
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~*req.1", "mandatory": true},
+					{"tag": "FilterIDs", "path": "FilterIDs", "type": "*variable", "value": "~*req.2"},
+					{"tag": "ActivationInterval", "path": "ActivationInterval", "type": "*variable", "value": "~*req.3"},
+					{"tag": "Weights", "path": "Weights", "type": "*variable", "value": "~*req.4"},
+					{"tag": "Jurisdiction", "path": "Jurisdiction", "type": "*variable", "value": "~*req.5"},
+					{"tag": "TaxType", "path": "TaxType", "type": "*variable", "value": "~*req.6"},
+					{"tag": "Rate", "path": "Rate", "type": "*variable", "value": "~*req.7"},
+					{"tag": "Compound", "path": "Compound", "type": "*variable", "value": "~*req.8"},
+					{"tag": "ExemptFilterIDs", "path": "ExemptFilterIDs", "type": "*variable", "value": "~*req.9"},
+				],
+			},
+			{
+				"type": "*lookup_tables",							// data source type
+				"file_name": "LookupTables.csv",						// file name in the tp_in_dir
+				"fields": [
+					{"tag": "Tenant", "path": "Tenant", "type": "*variable", "value": "~*req.0", "mandatory": true},
+					{"tag": "ID", "path": "ID", "type": "*variable", "value": "~*req.1", "mandatory": true},
+					{"tag": "Mode", "path": "Mode", "type": "*variable", "value": "~*req.2"},
+					{"tag": "Default", "path": "Default", "type": "*variable", "value": "~*req.3"},
+					{"tag": "Key", "path": "Key", "type": "*variable", "value": "~*req.4"},
+					{"tag": "Value", "path": "Value", "type": "*variable", "value": "~*req.5"},
+				],
+			},
 		],
 	},
 ],
@@ -1015,6 +1131,7 @@ const CGRATES_CFG_JSON = `
 	"rate_suffix_indexed_fields": [],		// query indexes based on these fields for faster processing
 	"rate_nested_fields": false,			// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "verbosity": 1000,                      // number of increment iterations allowed
+	"profile_versions_limit": 10,			// number of effective versions kept in the history of a rate profile, the scheduled ones are always kept <-1 for unlimited>
 },
 
 
@@ -1085,6 +1202,22 @@ const CGRATES_CFG_JSON = `
 		{"tag": "ReAuthRequestType", "path": "*diamreq.Re-Auth-Request-Type", "type": "*constant",
 			"value": "0"},
 	],
+	"*dmr": [
+		{"tag": "UserName", "path": "*radDAReq.User-Name", "type": "*variable",
+			"value": "~*req.User-Name"},
+		{"tag": "NASIPAddress", "path": "*radDAReq.NAS-IP-Address", "type": "*variable",
+			"value": "~*req.NAS-IP-Address"},
+		{"tag": "AcctSessionId", "path": "*radDAReq.Acct-Session-Id", "type": "*variable",
+			"value": "~*req.Acct-Session-Id", "mandatory": true},
+	],
+	"*coa": [
+		{"tag": "UserName", "path": "*radDAReq.User-Name", "type": "*variable",
+			"value": "~*req.User-Name"},
+		{"tag": "NASIPAddress", "path": "*radDAReq.NAS-IP-Address", "type": "*variable",
+			"value": "~*req.NAS-IP-Address"},
+		{"tag": "AcctSessionId", "path": "*radDAReq.Acct-Session-Id", "type": "*variable",
+			"value": "~*req.Acct-Session-Id", "mandatory": true},
+	],
 	"*errSip": [
 			{"tag": "Request", "path": "*rep.Request", "type": "*constant",
 				"value": "SIP/2.0 500 Internal Server Error", "mandatory": true},
@@ -1124,6 +1257,10 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,
 	"url": "/configs/",										// configs url 
 	"root_dir": "/var/spool/cgrates/configs",				// root directory in case of calling /configs request
+	"history_dir": "",										// directory where the config versions are persisted, empty to keep them only in memory
+	"history_limit": 10,									// maximum number of config versions kept, <0 for unlimited, 0 to disable the history
+	"load_from_datadb": false,								// load the config sections out of DataDB, the shared *default ones overwritten by the node_id ones, data_db and configs are never loaded, general, listen, tls and cores only on engine start
+	"datadb_sync_interval": "0",							// interval to check DataDB for config changes, <""|0s> to disable
 },
 
 
@@ -1161,6 +1298,17 @@ const CGRATES_CFG_JSON = `
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
     "max_iterations": 1000,                 // maximum number of iterations
     "max_usage": "72h",                     // maximum time of usage
+	"exchange_rate_profile_ids": [],		// exchange rate profiles queried in order when the cost and balance currencies differ
+},
+
+
+"taxes": {									// tax calculation config
+	"enabled": false,						// apply the taxes by default after rating in CDRs and AccountS: <true|false>
+	"indexed_selects": true,				// enable profile matching exclusively on indexes
+	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
+	"prefix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
+	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 },
 
 
@@ -68,6 +68,7 @@ const (
 	APIBanCfgJson      = "apiban"
 	CoreSCfgJson       = "cores"
 	AccountSCfgJson    = "accounts"
+	TaxSJson           = "taxes"
 )
 
 var (
@@ -76,7 +77,7 @@ var (
 		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
 		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson,
-		ActionSJson, AccountSCfgJson}
+		ActionSJson, AccountSCfgJson, TaxSJson}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -610,3 +611,15 @@ func (self CgrJsonCfg) AccountSCfgJson() (*AccountSJsonCfg, error) {
 	}
 	return cfg, nil
 }
+
+func (self CgrJsonCfg) TaxSCfgJson() (*TaxSJsonCfg, error) {
+	rawCfg, hasKey := self[TaxSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(TaxSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -32,6 +32,10 @@ func TestDfGeneralJsonCfg(t *testing.T) {
 		Node_id:              utils.StringPointer(""),
 		Logger:               utils.StringPointer(utils.MetaSysLog),
 		Log_level:            utils.IntPointer(utils.LOGLEVEL_INFO),
+		Log_levels:           map[string]int{},
+		Log_file:             utils.StringPointer(""),
+		Traces_exporter:      utils.StringPointer(""),
+		Traces_endpoint:      utils.StringPointer(""),
 		Rounding_decimals:    utils.IntPointer(5),
 		Dbdata_encoding:      utils.StringPointer("*msgpack"),
 		Tpexport_dir:         utils.StringPointer("/var/spool/cgrates/tpe"),
@@ -48,6 +52,7 @@ func TestDfGeneralJsonCfg(t *testing.T) {
 		Connect_timeout:      utils.StringPointer("1s"),
 		Reply_timeout:        utils.StringPointer("2s"),
 		Locking_timeout:      utils.StringPointer("0"),
+		Hits_store_interval:  utils.StringPointer("0"),
 		Digest_separator:     utils.StringPointer(","),
 		Digest_equal:         utils.StringPointer(":"),
 		Rsr_separator:        utils.StringPointer(";"),
@@ -160,6 +165,33 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheAccountProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheExchangeRateProfiles: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheRateVolumeCounters: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheRateDecks: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheRateProfileVersions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxProfiles: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheLookupTables: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheProfileHits: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheLoadVersions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheActiveLoadVersions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheDispatcherHosts: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -196,6 +228,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheAccountProfilesFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxProfilesFilterIndexes: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheReverseFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -211,6 +246,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheDiameterMessages: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheRadiusPackets: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer("3h"), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheRPCResponses: {Limit: utils.IntPointer(0),
 				Ttl: utils.StringPointer("2s"), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -298,6 +336,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheCDRsTBL: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheInvoicesTBL: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheTBLTPRoutes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -377,15 +418,18 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 		Remote_conn_id:       utils.StringPointer(""),
 		Replication_cache:    utils.StringPointer(""),
 		Opts: map[string]interface{}{
-			utils.RedisSentinelNameCfg:       "",
-			utils.QueryTimeoutCfg:            "10s",
-			utils.RedisClusterCfg:            false,
-			utils.RedisClusterOnDownDelayCfg: "0",
-			utils.RedisClusterSyncCfg:        "5s",
-			utils.RedisTLS:                   false,
-			utils.RedisClientCertificate:     "",
-			utils.RedisClientKey:             "",
-			utils.RedisCACertificate:         "",
+			utils.RedisSentinelNameCfg:          "",
+			utils.QueryTimeoutCfg:               "10s",
+			utils.RedisClusterCfg:               false,
+			utils.RedisClusterOnDownDelayCfg:    "0",
+			utils.RedisClusterSyncCfg:           "5s",
+			utils.RedisTLS:                      false,
+			utils.RedisClientCertificate:        "",
+			utils.RedisClientKey:                "",
+			utils.RedisCACertificate:            "",
+			utils.InternalDBPathCfg:             "",
+			utils.InternalDBSnapshotIntervalCfg: "0",
+			utils.InternalDBFsyncCfg:            utils.MetaNone,
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
@@ -524,12 +568,15 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 		String_indexed_fields: &[]string{},
 		Prefix_indexed_fields: &[]string{},
 		Opts: map[string]interface{}{
-			utils.QueryTimeoutCfg:    "10s",
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.SSLModeCfg:         utils.PostgressSSLModeDisable,
-			utils.MysqlLocation:      "Local",
+			utils.QueryTimeoutCfg:               "10s",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.SSLModeCfg:                    utils.PostgressSSLModeDisable,
+			utils.MysqlLocation:                 "Local",
+			utils.InternalDBPathCfg:             "",
+			utils.InternalDBSnapshotIntervalCfg: "0",
+			utils.InternalDBFsyncCfg:            utils.MetaNone,
 		},
 		Items: &map[string]*ItemOptJson{
 			utils.CacheTBLTPTimings: {
@@ -592,6 +639,10 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
 			},
+			utils.CacheInvoicesTBL: {
+				Replicate: utils.BoolPointer(false),
+				Remote:    utils.BoolPointer(false),
+			},
 			utils.CacheTBLTPActionPlans: {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
@@ -684,11 +735,12 @@ func TestDfRalsJsonCfg(t *testing.T) {
 
 func TestDfSchedulerJsonCfg(t *testing.T) {
 	eCfg := &SchedulerJsonCfg{
-		Enabled:          utils.BoolPointer(false),
-		Cdrs_conns:       &[]string{},
-		Thresholds_conns: &[]string{},
-		Stats_conns:      &[]string{},
-		Filters:          &[]string{},
+		Enabled:              utils.BoolPointer(false),
+		Cdrs_conns:           &[]string{},
+		Thresholds_conns:     &[]string{},
+		Stats_conns:          &[]string{},
+		Diameter_agent_conns: &[]string{},
+		Filters:              &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -858,21 +910,27 @@ func TestAsteriskAgentJsonCfg(t *testing.T) {
 
 func TestDiameterAgentJsonCfg(t *testing.T) {
 	eCfg := &DiameterAgentJsonCfg{
-		Enabled:              utils.BoolPointer(false),
-		Listen:               utils.StringPointer("127.0.0.1:3868"),
-		Listen_net:           utils.StringPointer(utils.TCP),
-		Dictionaries_path:    utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
-		Sessions_conns:       &[]string{rpcclient.BiRPCInternal},
-		Origin_host:          utils.StringPointer("CGR-DA"),
-		Origin_realm:         utils.StringPointer("cgrates.org"),
-		Vendor_id:            utils.IntPointer(0),
-		Product_name:         utils.StringPointer("CGRateS"),
-		Concurrent_requests:  utils.IntPointer(-1),
-		Synced_conn_requests: utils.BoolPointer(false),
-		Asr_template:         utils.StringPointer(""),
-		Rar_template:         utils.StringPointer(""),
-		Forced_disconnect:    utils.StringPointer(utils.MetaNone),
-		Request_processors:   &[]*ReqProcessorJsnCfg{},
+		Enabled:                  utils.BoolPointer(false),
+		Listen:                   utils.StringPointer("127.0.0.1:3868"),
+		Listen_net:               utils.StringPointer(utils.TCP),
+		Dictionaries_path:        utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
+		Sessions_conns:           &[]string{rpcclient.BiRPCInternal},
+		Origin_host:              utils.StringPointer("CGR-DA"),
+		Origin_realm:             utils.StringPointer("cgrates.org"),
+		Vendor_id:                utils.IntPointer(0),
+		Product_name:             utils.StringPointer("CGRateS"),
+		Concurrent_requests:      utils.IntPointer(-1),
+		Synced_conn_requests:     utils.BoolPointer(false),
+		Asr_template:             utils.StringPointer(""),
+		Rar_template:             utils.StringPointer(""),
+		Forced_disconnect:        utils.StringPointer(utils.MetaNone),
+		Watchdog_interval:        utils.StringPointer("30s"),
+		Reconnect_interval:       utils.StringPointer("5s"),
+		Max_reconnect_interval:   utils.StringPointer("1m"),
+		Peers:                    &[]*DiameterPeerJsonCfg{},
+		Policy_counters_interval: utils.StringPointer("0"),
+		Policy_counters:          &[]*DiameterPolicyCounterJsonCfg{},
+		Request_processors:       &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -897,8 +955,11 @@ func TestRadiusAgentJsonCfg(t *testing.T) {
 		Client_dictionaries: utils.MapStringStringPointer(map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		}),
-		Sessions_conns:     &[]string{utils.MetaInternal},
-		Request_processors: &[]*ReqProcessorJsnCfg{},
+		Client_da_addresses: utils.MapStringStringPointer(map[string]string{}),
+		Sessions_conns:      &[]string{utils.MetaInternal},
+		Dmr_template:        utils.StringPointer(utils.EmptyString),
+		Coa_template:        utils.StringPointer(utils.EmptyString),
+		Request_processors:  &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1084,6 +1145,7 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Resources_conns:       &[]string{},
 		Stats_conns:           &[]string{},
 		Rals_conns:            &[]string{},
+		Rates_conns:           &[]string{},
 		Default_ratio:         utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
 	}
@@ -1107,10 +1169,13 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Dry_run:         utils.BoolPointer(false),
 			Run_delay:       utils.StringPointer("0"),
 			Lock_filename:   utils.StringPointer(".cgr.lck"),
+			Atomic:          utils.BoolPointer(false),
+			Versions_limit:  utils.IntPointer(3),
 			Caches_conns:    &[]string{utils.MetaInternal},
 			Field_separator: utils.StringPointer(","),
 			Tp_in_dir:       utils.StringPointer("/var/spool/cgrates/loader/in"),
 			Tp_out_dir:      utils.StringPointer("/var/spool/cgrates/loader/out"),
+			Opts:            map[string]interface{}{},
 			Data: &[]*LoaderJsonDataType{
 				{
 					Type:      utils.StringPointer(utils.MetaAttributes),
@@ -1431,6 +1496,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.15")},
+						{Tag: utils.StringPointer("RouteRateProfileIDs"),
+							Path:  utils.StringPointer("RouteRateProfileIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.16")},
 					},
 				},
 				{
@@ -1467,6 +1536,26 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Weight"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.6")},
+						{Tag: utils.StringPointer(utils.AggregationPath),
+							Path:  utils.StringPointer(utils.AggregationPath),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.7")},
+						{Tag: utils.StringPointer(utils.AggregationFilterIDs),
+							Path:  utils.StringPointer(utils.AggregationFilterIDs),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.8")},
+						{Tag: utils.StringPointer(utils.AggregationType),
+							Path:  utils.StringPointer(utils.AggregationType),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.9")},
+						{Tag: utils.StringPointer(utils.AggregationRunIDs),
+							Path:  utils.StringPointer(utils.AggregationRunIDs),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer(utils.AggregationThresholdIDs),
+							Path:  utils.StringPointer(utils.AggregationThresholdIDs),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
 					},
 				},
 				{
@@ -1635,6 +1724,14 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("RateIncrement"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.17")},
+						{Tag: utils.StringPointer(utils.Currency),
+							Path:  utils.StringPointer(utils.Currency),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.18")},
+						{Tag: utils.StringPointer(utils.VolumePeriod),
+							Path:  utils.StringPointer(utils.VolumePeriod),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.19")},
 					},
 				},
 				{
@@ -1785,6 +1882,162 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Value: utils.StringPointer("~*req.16")},
 					},
 				},
+				{
+					Type:      utils.StringPointer(utils.MetaExchangeRateProfiles),
+					File_name: utils.StringPointer(utils.ExchangeRateProfilesCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("FromCurrency"),
+							Path:  utils.StringPointer("FromCurrency"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.2")},
+						{Tag: utils.StringPointer("ToCurrency"),
+							Path:  utils.StringPointer("ToCurrency"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.3")},
+						{Tag: utils.StringPointer("ActivationTime"),
+							Path:  utils.StringPointer("ActivationTime"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.4")},
+						{Tag: utils.StringPointer("Rate"),
+							Path:  utils.StringPointer("Rate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.5")},
+					},
+				},
+				{
+					Type:      utils.StringPointer(utils.MetaRateDecks),
+					File_name: utils.StringPointer(utils.RateDecksCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("IncreaseNotice"),
+							Path:  utils.StringPointer("IncreaseNotice"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.2")},
+						{Tag: utils.StringPointer("DecreaseNotice"),
+							Path:  utils.StringPointer("DecreaseNotice"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.3")},
+						{Tag: utils.StringPointer("Prefix"),
+							Path:  utils.StringPointer("Prefix"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.4")},
+						{Tag: utils.StringPointer("Description"),
+							Path:  utils.StringPointer("Description"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.5")},
+						{Tag: utils.StringPointer("Rate"),
+							Path:  utils.StringPointer("Rate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.6")},
+						{Tag: utils.StringPointer("EffectiveDate"),
+							Path:  utils.StringPointer("EffectiveDate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.7")},
+						{Tag: utils.StringPointer("ExpiryDate"),
+							Path:  utils.StringPointer("ExpiryDate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.8")},
+					},
+				},
+				{
+					Type:      utils.StringPointer(utils.MetaTaxProfiles),
+					File_name: utils.StringPointer(utils.TaxProfilesCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("FilterIDs"),
+							Path:  utils.StringPointer("FilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.2")},
+						{Tag: utils.StringPointer("ActivationInterval"),
+							Path:  utils.StringPointer("ActivationInterval"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.3")},
+						{Tag: utils.StringPointer("Weights"),
+							Path:  utils.StringPointer("Weights"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.4")},
+						{Tag: utils.StringPointer("Jurisdiction"),
+							Path:  utils.StringPointer("Jurisdiction"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.5")},
+						{Tag: utils.StringPointer("TaxType"),
+							Path:  utils.StringPointer("TaxType"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.6")},
+						{Tag: utils.StringPointer("Rate"),
+							Path:  utils.StringPointer("Rate"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.7")},
+						{Tag: utils.StringPointer("Compound"),
+							Path:  utils.StringPointer("Compound"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.8")},
+						{Tag: utils.StringPointer("ExemptFilterIDs"),
+							Path:  utils.StringPointer("ExemptFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.9")},
+					},
+				},
+				{
+					Type:      utils.StringPointer(utils.MetaLookupTables),
+					File_name: utils.StringPointer(utils.LookupTablesCsv),
+					Fields: &[]*FcTemplateJsonCfg{
+						{Tag: utils.StringPointer(utils.Tenant),
+							Path:      utils.StringPointer(utils.Tenant),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.0"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer(utils.ID),
+							Path:      utils.StringPointer(utils.ID),
+							Type:      utils.StringPointer(utils.MetaVariable),
+							Value:     utils.StringPointer("~*req.1"),
+							Mandatory: utils.BoolPointer(true)},
+						{Tag: utils.StringPointer("Mode"),
+							Path:  utils.StringPointer("Mode"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.2")},
+						{Tag: utils.StringPointer("Default"),
+							Path:  utils.StringPointer("Default"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.3")},
+						{Tag: utils.StringPointer("Key"),
+							Path:  utils.StringPointer("Key"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.4")},
+						{Tag: utils.StringPointer("Value"),
+							Path:  utils.StringPointer("Value"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.5")},
+					},
+				},
 			},
 		},
 	}
@@ -2155,6 +2408,7 @@ func TestDfRateSJsonCfg(t *testing.T) {
 		Rate_suffix_indexed_fields: &[]string{},
 		Rate_nested_fields:         utils.BoolPointer(false),
 		Verbosity:                  utils.IntPointer(1000),
+		Profile_versions_limit:     utils.IntPointer(10),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -2322,6 +2576,42 @@ func TestDfTemplateSJsonCfg(t *testing.T) {
 				Value: utils.StringPointer("0"),
 			},
 		},
+		utils.MetaDMR: {
+			{
+				Tag:   utils.StringPointer("UserName"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.User-Name", utils.MetaRadDAReq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.User-Name")},
+			{
+				Tag:   utils.StringPointer("NASIPAddress"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.NAS-IP-Address", utils.MetaRadDAReq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.NAS-IP-Address")},
+			{
+				Tag:       utils.StringPointer("AcctSessionId"),
+				Path:      utils.StringPointer(fmt.Sprintf("%s.Acct-Session-Id", utils.MetaRadDAReq)),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*req.Acct-Session-Id"),
+				Mandatory: utils.BoolPointer(true)},
+		},
+		utils.MetaCoA: {
+			{
+				Tag:   utils.StringPointer("UserName"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.User-Name", utils.MetaRadDAReq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.User-Name")},
+			{
+				Tag:   utils.StringPointer("NASIPAddress"),
+				Path:  utils.StringPointer(fmt.Sprintf("%s.NAS-IP-Address", utils.MetaRadDAReq)),
+				Type:  utils.StringPointer(utils.MetaVariable),
+				Value: utils.StringPointer("~*req.NAS-IP-Address")},
+			{
+				Tag:       utils.StringPointer("AcctSessionId"),
+				Path:      utils.StringPointer(fmt.Sprintf("%s.Acct-Session-Id", utils.MetaRadDAReq)),
+				Type:      utils.StringPointer(utils.MetaVariable),
+				Value:     utils.StringPointer("~*req.Acct-Session-Id"),
+				Mandatory: utils.BoolPointer(true)},
+		},
 		utils.MetaCdrLog: {
 			{
 				Tag:       utils.StringPointer("ToR"),
@@ -2452,3 +2742,23 @@ func TestDfActionSJsonCfg(t *testing.T) {
 		t.Errorf("\n Expected <%+v>,\nReceived:<%+v>", utils.ToJSON(eCfg), utils.ToJSON(cfg))
 	}
 }
+
+func TestDfTaxSJsonCfg(t *testing.T) {
+	eCfg := &TaxSJsonCfg{
+		Enabled:               utils.BoolPointer(false),
+		Indexed_selects:       utils.BoolPointer(true),
+		String_indexed_fields: nil,
+		Prefix_indexed_fields: &[]string{},
+		Suffix_indexed_fields: &[]string{},
+		Nested_fields:         utils.BoolPointer(false),
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.TaxSCfgJson(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("\n Expected <%+v>,\nReceived:<%+v>", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ConfigDB is the storage used to share the config between the engines
+// the config sections are stored as JSON for each node ID and *default is shared by all nodes
+type ConfigDB interface {
+	GetConfigSectionsDrv(nodeID string, sectionIDs []string) (map[string][]byte, error)
+	SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) error
+}
+
+var (
+	// dbConnSections are used to reach DataDB so they are never loaded out of it
+	dbConnSections = utils.NewStringSet([]string{DATADB_JSN, ConfigSJson})
+	// dbStartSections are applied only on engine start so they are never set or reloaded out of DataDB
+	dbStartSections = utils.NewStringSet([]string{GENERAL_JSN, LISTEN_JSN, TlsCfgJson, CoreSCfgJson})
+)
+
+// configDBLayers keeps the sections loaded out of ConfigDB
+type configDBLayers struct {
+	sync.Mutex
+	db   ConfigDB
+	dflt map[string][]byte // sections shared by all nodes
+	node map[string][]byte // sections for this node only, loaded over the *default ones
+}
+
+// getDBLayers returns the *default and the node sections stored in db
+func (cfg *CGRConfig) getDBLayers(db ConfigDB) (dflt, node map[string][]byte, err error) {
+	if dflt, err = db.GetConfigSectionsDrv(utils.MetaDefault, sortedCfgSections); err != nil {
+		return
+	}
+	nodeID := cfg.GeneralCfg().NodeID
+	if nodeID == utils.EmptyString || nodeID == utils.MetaDefault {
+		node = make(map[string][]byte)
+		return
+	}
+	node, err = db.GetConfigSectionsDrv(nodeID, sortedCfgSections)
+	return
+}
+
+// loadDBLayers loads the given sections, first the *default ones and after the node ones
+func (cfg *CGRConfig) loadDBLayers(dflt, node map[string][]byte, sections []string) (err error) {
+	for _, layer := range []map[string][]byte{dflt, node} {
+		jsnCfg := make(map[string]json.RawMessage)
+		for _, section := range sections {
+			if dbConnSections.Has(section) {
+				continue
+			}
+			if data, has := layer[section]; has {
+				jsnCfg[section] = data
+			}
+		}
+		if len(jsnCfg) == 0 {
+			continue
+		}
+		var b []byte
+		if b, err = json.Marshal(jsnCfg); err != nil {
+			return
+		}
+		if err = cfg.loadCfgFromJSONWithLocks(bytes.NewBuffer(b), sections); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// LoadFromDB loads the config sections stored in db over the ones already loaded
+// used on engine start so no reload is triggered
+func (cfg *CGRConfig) LoadFromDB(db ConfigDB) (err error) {
+	var dflt, node map[string][]byte
+	if dflt, node, err = cfg.getDBLayers(db); err != nil {
+		return
+	}
+	cfg.dbLayers.Lock()
+	defer cfg.dbLayers.Unlock()
+	if err = cfg.loadDBLayers(dflt, node, sortedCfgSections); err != nil {
+		return
+	}
+	cfg.rLockSections()
+	err = cfg.checkConfigSanity()
+	cfg.rUnlockSections()
+	if err != nil {
+		return
+	}
+	cfg.reloadDPCache(sortedCfgSections...)
+	cfg.dbLayers.db = db
+	cfg.dbLayers.dflt = dflt
+	cfg.dbLayers.node = node
+	return
+}
+
+// SetConfigDB sets the db used to reload the config sections already loaded by LoadFromDB
+func (cfg *CGRConfig) SetConfigDB(db ConfigDB) {
+	cfg.dbLayers.Lock()
+	cfg.dbLayers.db = db
+	cfg.dbLayers.Unlock()
+}
+
+// changedDBSections returns the sections that differ from the ones loaded before
+func (cfg *CGRConfig) changedDBSections(dflt, node map[string][]byte) (sections []string) {
+	for _, section := range sortedCfgSections {
+		if dbStartSections.Has(section) { // only loaded on engine start
+			continue
+		}
+		if !bytes.Equal(cfg.dbLayers.dflt[section], dflt[section]) ||
+			!bytes.Equal(cfg.dbLayers.node[section], node[section]) {
+			sections = append(sections, section)
+		}
+	}
+	return
+}
+
+// ReloadFromDB loads and reloads the sections changed in DataDB since the last load
+// the fields removed from DataDB keep their last value
+func (cfg *CGRConfig) ReloadFromDB() (err error) {
+	cfg.dbLayers.Lock()
+	defer cfg.dbLayers.Unlock()
+	if cfg.dbLayers.db == nil {
+		return
+	}
+	var dflt, node map[string][]byte
+	if dflt, node, err = cfg.getDBLayers(cfg.dbLayers.db); err != nil {
+		return
+	}
+	sections := cfg.changedDBSections(dflt, node)
+	if len(sections) == 0 {
+		return
+	}
+	oldCfg := cfg.Clone()
+	cfgV := oldCfg.Clone() // check the sanity on a copy so a wrong config leaves the current one untouched
+	if err = cfgV.loadDBLayers(dflt, node, sections); err != nil {
+		return
+	}
+	if err = cfgV.checkConfigSanity(); err != nil {
+		return
+	}
+	cfg.reloadDPCache(sections...)
+	if err = cfg.loadDBLayers(dflt, node, sections); err != nil {
+		return
+	}
+	cfg.dbLayers.dflt = dflt
+	cfg.dbLayers.node = node
+	cfg.reloadSections(sections...)
+	cfg.recordConfigVersionWithLog(utils.MetaDataDB, nil, oldCfg, sections)
+	return
+}
+
+// SyncFromDB checks periodically DataDB for config changes until stopChan is closed
+func (cfg *CGRConfig) SyncFromDB(interval time.Duration, stopChan chan struct{}) {
+	tkr := time.NewTicker(interval)
+	defer tkr.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-tkr.C:
+			if err := cfg.ReloadFromDB(); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed to reload the config from DataDB because: %s",
+					utils.ConfigSv1, err))
+			}
+		}
+	}
+}
+
+// SetDBConfigArgs the API params for V1SetDBConfig
+type SetDBConfigArgs struct {
+	Opts   map[string]interface{}
+	Tenant string
+	NodeID string // the node the sections are stored for, *default if empty
+	Config map[string]interface{}
+	DryRun bool
+}
+
+// V1SetDBConfig stores the config sections in DataDB
+// the fields are merged with the ones already stored and the sections are reloaded if they apply to this node
+func (cfg *CGRConfig) V1SetDBConfig(args *SetDBConfigArgs, reply *string) (err error) {
+	cfg.dbLayers.Lock()
+	db := cfg.dbLayers.db
+	cfg.dbLayers.Unlock()
+	if db == nil {
+		return errors.New("config is not loaded from DataDB")
+	}
+	if len(args.Config) == 0 {
+		*reply = utils.OK
+		return
+	}
+	nodeID := utils.FirstNonEmpty(args.NodeID, utils.MetaDefault)
+	sections := make([]string, 0, len(args.Config))
+	for section := range args.Config {
+		if dbConnSections.Has(section) || dbStartSections.Has(section) {
+			return fmt.Errorf("section <%s> can not be loaded from DataDB", section)
+		}
+		sections = append(sections, section)
+	}
+	var stored map[string][]byte
+	if stored, err = db.GetConfigSectionsDrv(nodeID, sections); err != nil {
+		return
+	}
+	sectionsData := make(map[string][]byte)
+	for section, val := range args.Config {
+		newFlds, isMap := val.(map[string]interface{})
+		if oldData, has := stored[section]; isMap && has {
+			var oldFlds map[string]interface{}
+			if err = json.Unmarshal(oldData, &oldFlds); err == nil {
+				for fld, fldVal := range newFlds {
+					oldFlds[fld] = fldVal
+				}
+				val = oldFlds
+			}
+			err = nil // the lists(eg: loaders) are overwritten
+		}
+		if sectionsData[section], err = json.Marshal(val); err != nil {
+			return
+		}
+	}
+	cfgV := cfg.Clone() // validate the sections by loading them on a copy
+	jsnCfg := make(map[string]json.RawMessage)
+	for section, data := range sectionsData {
+		jsnCfg[section] = data
+	}
+	var b []byte
+	if b, err = json.Marshal(jsnCfg); err != nil {
+		return
+	}
+	if err = cfgV.loadCfgFromJSONWithLocks(bytes.NewBuffer(b), sections); err != nil {
+		return
+	}
+	if err = cfgV.checkConfigSanity(); err != nil {
+		return
+	}
+	if args.DryRun {
+		*reply = utils.OK
+		return
+	}
+	if err = db.SetConfigSectionsDrv(nodeID, sectionsData); err != nil {
+		return
+	}
+	if nodeID == utils.MetaDefault || nodeID == cfg.GeneralCfg().NodeID {
+		if err = cfg.ReloadFromDB(); err != nil {
+			return
+		}
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,276 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+type testConfigDB map[string]map[string][]byte
+
+func (tDB testConfigDB) GetConfigSectionsDrv(nodeID string, sectionIDs []string) (sectionsData map[string][]byte, err error) {
+	sectionsData = make(map[string][]byte)
+	for _, sectionID := range sectionIDs {
+		if data, has := tDB[nodeID][sectionID]; has {
+			sectionsData[sectionID] = data
+		}
+	}
+	return
+}
+
+func (tDB testConfigDB) SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) (err error) {
+	if _, has := tDB[nodeID]; !has {
+		tDB[nodeID] = make(map[string][]byte)
+	}
+	for sectionID, data := range sectionsData {
+		tDB[nodeID][sectionID] = data
+	}
+	return
+}
+
+func TestConfigLoadFromDB(t *testing.T) {
+	db := testConfigDB{
+		utils.MetaDefault: {
+			GENERAL_JSN:  []byte(`{"default_tenant":"cgrates.net","rounding_decimals":3}`),
+			CoreSCfgJson: []byte(`{"caps":10}`),
+		},
+		"node1": {
+			GENERAL_JSN: []byte(`{"rounding_decimals":4}`),
+		},
+	}
+	cfg := NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "node1"
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Expected %q, received %q", "cgrates.net", cfg.GeneralCfg().DefaultTenant)
+	}
+	if cfg.GeneralCfg().RoundingDecimals != 4 {
+		t.Errorf("Expected %v, received %v", 4, cfg.GeneralCfg().RoundingDecimals)
+	}
+	if cfg.CoreSCfg().Caps != 10 {
+		t.Errorf("Expected %v, received %v", 10, cfg.CoreSCfg().Caps)
+	}
+}
+
+func TestConfigLoadFromDBSanity(t *testing.T) {
+	db := testConfigDB{
+		utils.MetaDefault: {
+			RALS_JSN: []byte(`{"enabled":true,"stats_conns":["*internal"]}`),
+		},
+	}
+	cfg := NewDefaultCGRConfig()
+	expErr := "<StatS> not enabled but requested by <RALs> component"
+	if err := cfg.LoadFromDB(db); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+}
+
+func TestConfigReloadFromDB(t *testing.T) {
+	db := testConfigDB{
+		utils.MetaDefault: {
+			GENERAL_JSN: []byte(`{"default_tenant":"cgrates.net"}`),
+		},
+	}
+	cfg := NewDefaultCGRConfig()
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.ReloadFromDB(); err != nil { // nothing changed
+		t.Fatal(err)
+	}
+	if _, err := cfg.history.getVersion(0); err != utils.ErrNotFound {
+		t.Errorf("Expected error %s, received %v", utils.ErrNotFound, err)
+	}
+	db[utils.MetaDefault][GENERAL_JSN] = []byte(`{"default_tenant":"cgrates.com"}`) // only loaded on engine start
+	db[utils.MetaDefault][MAILER_JSN] = []byte(`{"server":"mail5:25"}`)
+	if err := cfg.ReloadFromDB(); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.MailerCfg().MailerServer != "mail5:25" {
+		t.Errorf("Expected %q, received %q", "mail5:25", cfg.MailerCfg().MailerServer)
+	}
+	if cfg.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Expected %q, received %q", "cgrates.net", cfg.GeneralCfg().DefaultTenant)
+	}
+	var versions []*ConfigVersion
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{Section: MAILER_JSN}, &versions); err != nil {
+		t.Fatal(err)
+	} else if len(versions) != 1 {
+		t.Fatalf("Expected 1 version, received %s", utils.ToJSON(versions))
+	} else if versions[0].APIMethod != utils.MetaDataDB {
+		t.Errorf("Expected %q, received %q", utils.MetaDataDB, versions[0].APIMethod)
+	} else if !reflect.DeepEqual(versions[0].Sections, []string{MAILER_JSN}) {
+		t.Errorf("Expected %+v, received %+v", []string{MAILER_JSN}, versions[0].Sections)
+	}
+
+	db[utils.MetaDefault][RALS_JSN] = []byte(`{"enabled":true,"stats_conns":["*internal"]}`)
+	expErr := "<StatS> not enabled but requested by <RALs> component"
+	if err := cfg.ReloadFromDB(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+	if cfg.RalsCfg().Enabled {
+		t.Error("Expected the RALs config to not be changed")
+	}
+}
+
+func TestConfigSyncFromDB(t *testing.T) {
+	db := testConfigDB{utils.MetaDefault: {}}
+	cfg := NewDefaultCGRConfig()
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	cfg.dbLayers.Lock()
+	db[utils.MetaDefault][MAILER_JSN] = []byte(`{"server":"mail7:25"}`)
+	cfg.dbLayers.Unlock()
+	stopChan := make(chan struct{})
+	go cfg.SyncFromDB(5*time.Millisecond, stopChan)
+	time.Sleep(50 * time.Millisecond)
+	close(stopChan)
+	cfg.lks[MAILER_JSN].RLock()
+	server := cfg.mailerCfg.MailerServer
+	cfg.lks[MAILER_JSN].RUnlock()
+	if server != "mail7:25" {
+		t.Errorf("Expected %q, received %q", "mail7:25", server)
+	}
+}
+
+func TestConfigV1SetDBConfig(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	var reply string
+	expErr := "config is not loaded from DataDB"
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+	db := testConfigDB{
+		utils.MetaDefault: {
+			MAILER_JSN: []byte(`{"auth_user":"cgrates"}`),
+		},
+	}
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+		Config: map[string]interface{}{
+			"wrong_section": map[string]interface{}{},
+		},
+	}, &reply); err == nil || err.Error() != "Invalid section: <wrong_section>" {
+		t.Errorf("Expected error Invalid section: <wrong_section>, received %v", err)
+	}
+	expErr = "<StatS> not enabled but requested by <RALs> component"
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+		Config: map[string]interface{}{
+			RALS_JSN: map[string]interface{}{utils.EnabledCfg: true, utils.StatSConnsCfg: []string{utils.MetaInternal}},
+		},
+	}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+	if _, has := db[utils.MetaDefault][RALS_JSN]; has {
+		t.Error("Expected the invalid config to not be stored")
+	}
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+		Config: map[string]interface{}{
+			MAILER_JSN: map[string]interface{}{utils.MailerServerCfg: "mail3:25"},
+		},
+		DryRun: true,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	if rcv := string(db[utils.MetaDefault][MAILER_JSN]); rcv != `{"auth_user":"cgrates"}` {
+		t.Errorf("Expected the config to not be stored on dry run, received %s", rcv)
+	}
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+		Config: map[string]interface{}{
+			MAILER_JSN: map[string]interface{}{utils.MailerServerCfg: "mail3:25"},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"auth_user":"cgrates","server":"mail3:25"}`
+	if rcv := string(db[utils.MetaDefault][MAILER_JSN]); rcv != exp {
+		t.Errorf("Expected %s, received %s", exp, rcv)
+	}
+	if cfg.MailerCfg().MailerServer != "mail3:25" {
+		t.Errorf("Expected %q, received %q", "mail3:25", cfg.MailerCfg().MailerServer)
+	}
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{ // other node so no reload
+		NodeID: "node2",
+		Config: map[string]interface{}{
+			MAILER_JSN: map[string]interface{}{utils.MailerServerCfg: "mail6:25"},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := db["node2"][MAILER_JSN]; !has {
+		t.Error("Expected the config to be stored for node2")
+	}
+	if cfg.MailerCfg().MailerServer != "mail3:25" {
+		t.Errorf("Expected %q, received %q", "mail3:25", cfg.MailerCfg().MailerServer)
+	}
+}
+
+func TestConfigV1SetDBConfigStartSections(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	db := testConfigDB{utils.MetaDefault: {}}
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	var reply string
+	expErr := "section <data_db> can not be loaded from DataDB"
+	if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+		Config: map[string]interface{}{
+			DATADB_JSN: map[string]interface{}{utils.DataDbNameCfg: "11"},
+		},
+	}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+	for _, dbType := range []string{utils.Redis, utils.INTERNAL} {
+		cfg.DataDbCfg().Type = dbType
+		expErr = "section <listen> can not be loaded from DataDB"
+		if err := cfg.V1SetDBConfig(&SetDBConfigArgs{
+			Config: map[string]interface{}{
+				LISTEN_JSN: map[string]interface{}{utils.RPCJSONListenCfg: ":3012"},
+			},
+		}, &reply); err == nil || err.Error() != expErr {
+			t.Errorf("Expected error %s, received %v", expErr, err)
+		}
+	}
+}
+
+func TestConfigLoadFromDBConnSections(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	db := testConfigDB{
+		utils.MetaDefault: {
+			DATADB_JSN: []byte(`{"db_name":"11"}`),
+		},
+	}
+	if err := cfg.LoadFromDB(db); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DataDbCfg().Name != "10" {
+		t.Errorf("Expected %q, received %q", "10", cfg.DataDbCfg().Name)
+	}
+}
@@ -0,0 +1,492 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ConfigFieldDiff holds the old and the new value of a changed config field
+type ConfigFieldDiff struct {
+	Old interface{}
+	New interface{}
+}
+
+// ConfigVersion is the snapshot recorded for each runtime config change
+type ConfigVersion struct {
+	Version   int
+	Time      time.Time
+	Author    string
+	APIMethod string
+	Sections  []string
+	Diff      map[string]map[string]*ConfigFieldDiff // map[section]map[field]*ConfigFieldDiff
+
+	cfg *CGRConfig // config as it was after the change, used on rollback
+}
+
+// configVersionFile is the format of the versions persisted in the history_dir
+type configVersionFile struct {
+	*ConfigVersion
+	Config map[string]interface{} // snapshot of the config as returned by configSnapshot, used to rebuild it for rollback
+}
+
+// newConfigHistory returns an empty history
+func newConfigHistory() *configHistory {
+	return new(configHistory)
+}
+
+// configHistory keeps the config versions ordered ascending
+type configHistory struct {
+	sync.RWMutex
+	loaded   bool // the versions were read from the history_dir
+	versions []*ConfigVersion
+}
+
+// loadFromDir reads the versions persisted by a previous run, only once
+func (cH *configHistory) loadFromDir(dir string) (err error) {
+	if cH.loaded || dir == utils.EmptyString {
+		return
+	}
+	cH.loaded = true
+	var fis []os.FileInfo
+	if fis, err = ioutil.ReadDir(dir); err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	for _, fi := range fis {
+		if fi.IsDir() || !strings.HasSuffix(fi.Name(), utils.JSNSuffix) {
+			continue
+		}
+		var b []byte
+		if b, err = ioutil.ReadFile(path.Join(dir, fi.Name())); err != nil {
+			return
+		}
+		cVFile := &configVersionFile{ConfigVersion: new(ConfigVersion)}
+		if err = json.Unmarshal(b, cVFile); err != nil {
+			return fmt.Errorf("file <%s>:%s", fi.Name(), err.Error())
+		}
+		if cVFile.Config != nil {
+			var rbErr error
+			if cVFile.cfg, rbErr = configFromSnapshot(cVFile.Config); rbErr != nil {
+				cVFile.cfg = nil // not available for rollback
+				utils.Logger.Warning(fmt.Sprintf("<%s> cannot rebuild the config of version <%d> from file <%s> because: %s",
+					utils.ConfigSv1, cVFile.Version, fi.Name(), rbErr))
+			}
+		}
+		cH.versions = append(cH.versions, cVFile.ConfigVersion)
+	}
+	sort.Slice(cH.versions, func(i, j int) bool {
+		return cH.versions[i].Version < cH.versions[j].Version
+	})
+	return
+}
+
+// add appends the version and removes the oldest ones over limit
+func (cH *configHistory) add(cV *ConfigVersion, dir string, limit int) (err error) {
+	if len(cH.versions) != 0 {
+		cV.Version = cH.versions[len(cH.versions)-1].Version + 1
+	}
+	cH.versions = append(cH.versions, cV)
+	if dir != utils.EmptyString { // the snapshots include the DB and connection credentials so only the owner can read them
+		if err = os.MkdirAll(dir, 0700); err != nil {
+			return
+		}
+		cVFile := &configVersionFile{ConfigVersion: cV}
+		if cV.cfg != nil {
+			cVFile.Config = configSnapshot(cV.cfg)
+		}
+		if err = ioutil.WriteFile(path.Join(dir, strconv.Itoa(cV.Version)+utils.JSNSuffix),
+			[]byte(utils.ToJSON(cVFile)), 0600); err != nil {
+			return
+		}
+	}
+	if limit < 0 || len(cH.versions) <= limit {
+		return
+	}
+	for _, rmV := range cH.versions[:len(cH.versions)-limit] {
+		if dir == utils.EmptyString {
+			continue
+		}
+		if err = os.Remove(path.Join(dir, strconv.Itoa(rmV.Version)+utils.JSNSuffix)); err != nil &&
+			!os.IsNotExist(err) {
+			return
+		}
+		err = nil
+	}
+	cH.versions = cH.versions[len(cH.versions)-limit:]
+	return
+}
+
+// getVersion returns the version with the given number
+func (cH *configHistory) getVersion(version int) (cV *ConfigVersion, err error) {
+	for _, cV = range cH.versions {
+		if cV.Version == version {
+			return
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+// sectionAsInterface returns the section normalized through JSON so it can be compared and persisted
+func sectionAsInterface(mp map[string]interface{}, section string) (iface interface{}) {
+	json.Unmarshal([]byte(utils.ToJSON(mp[section])), &iface)
+	return
+}
+
+// configSnapshot returns the config sections in the format accepted when loading the config
+// so the config of a version can be rebuilt after it was read from the history_dir
+func configSnapshot(cfg *CGRConfig) (snapshot map[string]interface{}) {
+	rsrSep := cfg.GeneralCfg().RSRSep
+	mp := cfg.AsMapInterface(rsrSep)
+	snapshot = make(map[string]interface{})
+	for _, section := range sortedCfgSections {
+		sec := sectionAsInterface(mp, section)
+		if sec == nil {
+			continue
+		}
+		switch section { // the fields that are not exported in the same format as they are loaded
+		case ERsJson:
+			if rdrs, has := sec.(map[string]interface{})[utils.ReadersCfg].([]interface{}); has {
+				for _, rdr := range rdrs {
+					rdrMp := rdr.(map[string]interface{})
+					if rootPath, has := rdrMp[utils.XMLRootPathCfg].([]interface{}); has {
+						hp := make(utils.HierarchyPath, len(rootPath))
+						for i, itm := range rootPath {
+							hp[i] = utils.IfaceAsString(itm)
+						}
+						rdrMp[utils.XMLRootPathCfg] = hp.AsString(utils.Slash, false)
+					}
+				}
+			}
+		case FreeSWITCHAgentJSN:
+			secMp := sec.(map[string]interface{})
+			if extraFlds, has := secMp[utils.ExtraFieldsCfg].(string); has {
+				secMp[utils.ExtraFieldsCfg] = []string{}
+				if extraFlds != utils.EmptyString {
+					secMp[utils.ExtraFieldsCfg] = strings.Split(extraFlds, rsrSep)
+				}
+			}
+		case SIPAgentJson:
+			secMp := sec.(map[string]interface{})
+			if rtTimer, has := secMp[utils.RetransmissionTimerCfg].(float64); has {
+				secMp[utils.RetransmissionTimerCfg] = time.Duration(rtTimer).String()
+			}
+		case AccountSCfgJson:
+			secMp := sec.(map[string]interface{})
+			if maxUsage, has := secMp[utils.MaxUsage].(float64); has {
+				secMp[utils.MaxUsage] = strconv.FormatInt(int64(maxUsage), 10)
+			}
+		}
+		snapshot[section] = sec
+	}
+	return
+}
+
+// configFromSnapshot rebuilds the config out of the sections returned by configSnapshot
+func configFromSnapshot(snapshot map[string]interface{}) (cfg *CGRConfig, err error) {
+	cfg = NewDefaultCGRConfig()
+	if _, has := snapshot[LoaderJson]; has {
+		cfg.loaderCfg = nil // the loaders are appended on load so we drop the default ones
+	}
+	jsnCfg := new(CgrJsonCfg)
+	if err = NewRjReaderFromBytes([]byte(utils.ToJSON(snapshot))).Decode(jsnCfg); err != nil {
+		return
+	}
+	err = cfg.loadFromJSONCfg(jsnCfg)
+	return
+}
+
+// diffConfigSections returns the fields that differ between the two configs for the given sections
+func diffConfigSections(oldCfg, newCfg *CGRConfig, sections []string) (diff map[string]map[string]*ConfigFieldDiff) {
+	oldMp := oldCfg.AsMapInterface(oldCfg.GeneralCfg().RSRSep)
+	newMp := newCfg.AsMapInterface(newCfg.GeneralCfg().RSRSep)
+	diff = make(map[string]map[string]*ConfigFieldDiff)
+	for _, section := range sections {
+		oldSec := sectionAsInterface(oldMp, section)
+		newSec := sectionAsInterface(newMp, section)
+		if reflect.DeepEqual(oldSec, newSec) {
+			continue
+		}
+		oldFlds, oldIsMap := oldSec.(map[string]interface{})
+		newFlds, newIsMap := newSec.(map[string]interface{})
+		if !oldIsMap || !newIsMap { // lists (eg: loaders) are compared as a whole
+			diff[section] = map[string]*ConfigFieldDiff{utils.MetaAll: {Old: oldSec, New: newSec}}
+			continue
+		}
+		diff[section] = make(map[string]*ConfigFieldDiff)
+		for fld, oldVal := range oldFlds {
+			if newVal := newFlds[fld]; !reflect.DeepEqual(oldVal, newVal) {
+				diff[section][fld] = &ConfigFieldDiff{Old: oldVal, New: newVal}
+			}
+		}
+		for fld, newVal := range newFlds {
+			if _, has := oldFlds[fld]; !has {
+				diff[section][fld] = &ConfigFieldDiff{New: newVal}
+			}
+		}
+	}
+	return
+}
+
+// recordConfigVersion adds a new version to the config history
+// oldCfg is a clone of the config taken before the change was applied
+func (cfg *CGRConfig) recordConfigVersion(apiMethod string, opts map[string]interface{},
+	oldCfg *CGRConfig, sections []string) (err error) {
+	cfgSCfg := cfg.ConfigSCfg()
+	if cfgSCfg.HistoryLimit == 0 {
+		return
+	}
+	cfg.history.Lock()
+	defer cfg.history.Unlock()
+	if err = cfg.history.loadFromDir(cfgSCfg.HistoryDir); err != nil {
+		return
+	}
+	if len(cfg.history.versions) == 0 ||
+		cfg.history.versions[len(cfg.history.versions)-1].cfg == nil { // no version to rollback to before this change
+		if err = cfg.history.add(&ConfigVersion{
+			Time:      time.Now(),
+			Author:    utils.CGRateS,
+			APIMethod: utils.MetaInit,
+			Sections:  []string{},
+			Diff:      make(map[string]map[string]*ConfigFieldDiff),
+			cfg:       oldCfg,
+		}, cfgSCfg.HistoryDir, cfgSCfg.HistoryLimit); err != nil {
+			return
+		}
+	}
+	newCfg := cfg.Clone()
+	diff := diffConfigSections(oldCfg, newCfg, sections)
+	changed := make([]string, 0, len(diff)) // only the sections that changed so the history can be filtered on them
+	for _, section := range sections {
+		if _, has := diff[section]; has {
+			changed = append(changed, section)
+		}
+	}
+	return cfg.history.add(&ConfigVersion{
+		Time:      time.Now(),
+		Author:    utils.IfaceAsString(opts[utils.OptsConfigAuthor]),
+		APIMethod: apiMethod,
+		Sections:  changed,
+		Diff:      diff,
+		cfg:       newCfg,
+	}, cfgSCfg.HistoryDir, cfgSCfg.HistoryLimit)
+}
+
+// recordConfigVersionWithLog records the config version, logging the errors since the change was already applied
+func (cfg *CGRConfig) recordConfigVersionWithLog(apiMethod string, opts map[string]interface{},
+	oldCfg *CGRConfig, sections []string) {
+	if err := cfg.recordConfigVersion(apiMethod, opts, oldCfg, sections); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed recording the config version for <%s> because: %s",
+			utils.ConfigSv1, apiMethod, err))
+	}
+}
+
+// restoreSection replaces the section with a copy of the same section out of src
+// the caller needs to lock the section
+func (cfg *CGRConfig) restoreSection(section string, src *CGRConfig) {
+	switch section {
+	case GENERAL_JSN:
+		cfg.generalCfg = src.generalCfg.Clone()
+	case RPCConnsJsonName:
+		cfg.rpcConns = src.rpcConns.Clone()
+	case DATADB_JSN:
+		cfg.dataDbCfg = src.dataDbCfg.Clone()
+	case STORDB_JSN:
+		cfg.storDbCfg = src.storDbCfg.Clone()
+	case LISTEN_JSN:
+		cfg.listenCfg = src.listenCfg.Clone()
+	case TlsCfgJson:
+		cfg.tlsCfg = src.tlsCfg.Clone()
+	case HTTP_JSN:
+		cfg.httpCfg = src.httpCfg.Clone()
+	case SCHEDULER_JSN:
+		cfg.schedulerCfg = src.schedulerCfg.Clone()
+	case CACHE_JSN:
+		cfg.cacheCfg = src.cacheCfg.Clone()
+	case FilterSjsn:
+		cfg.filterSCfg = src.filterSCfg.Clone()
+	case RALS_JSN:
+		cfg.ralsCfg = src.ralsCfg.Clone()
+	case CDRS_JSN:
+		cfg.cdrsCfg = src.cdrsCfg.Clone()
+	case ERsJson:
+		cfg.ersCfg = src.ersCfg.Clone()
+	case SessionSJson:
+		cfg.sessionSCfg = src.sessionSCfg.Clone()
+	case AsteriskAgentJSN:
+		cfg.asteriskAgentCfg = src.asteriskAgentCfg.Clone()
+	case FreeSWITCHAgentJSN:
+		cfg.fsAgentCfg = src.fsAgentCfg.Clone()
+	case KamailioAgentJSN:
+		cfg.kamAgentCfg = src.kamAgentCfg.Clone()
+	case DA_JSN:
+		cfg.diameterAgentCfg = src.diameterAgentCfg.Clone()
+	case RA_JSN:
+		cfg.radiusAgentCfg = src.radiusAgentCfg.Clone()
+	case HttpAgentJson:
+		cfg.httpAgentCfg = src.httpAgentCfg.Clone()
+	case DNSAgentJson:
+		cfg.dnsAgentCfg = src.dnsAgentCfg.Clone()
+	case ATTRIBUTE_JSN:
+		cfg.attributeSCfg = src.attributeSCfg.Clone()
+	case ChargerSCfgJson:
+		cfg.chargerSCfg = src.chargerSCfg.Clone()
+	case RESOURCES_JSON:
+		cfg.resourceSCfg = src.resourceSCfg.Clone()
+	case STATS_JSON:
+		cfg.statsCfg = src.statsCfg.Clone()
+	case THRESHOLDS_JSON:
+		cfg.thresholdSCfg = src.thresholdSCfg.Clone()
+	case RouteSJson:
+		cfg.routeSCfg = src.routeSCfg.Clone()
+	case LoaderJson:
+		cfg.loaderCfg = src.loaderCfg.Clone()
+	case MAILER_JSN:
+		cfg.mailerCfg = src.mailerCfg.Clone()
+	case SURETAX_JSON:
+		cfg.sureTaxCfg = src.sureTaxCfg.Clone()
+	case CgrLoaderCfgJson:
+		cfg.loaderCgrCfg = src.loaderCgrCfg.Clone()
+	case CgrMigratorCfgJson:
+		cfg.migratorCgrCfg = src.migratorCgrCfg.Clone()
+	case DispatcherSJson:
+		cfg.dispatcherSCfg = src.dispatcherSCfg.Clone()
+	case AnalyzerCfgJson:
+		cfg.analyzerSCfg = src.analyzerSCfg.Clone()
+	case ApierS:
+		cfg.apier = src.apier.Clone()
+	case EEsJson:
+		cfg.eesCfg = src.eesCfg.Clone()
+	case RateSJson:
+		cfg.rateSCfg = src.rateSCfg.Clone()
+	case SIPAgentJson:
+		cfg.sipAgentCfg = src.sipAgentCfg.Clone()
+	case RegistrarCJson:
+		cfg.registrarCCfg = src.registrarCCfg.Clone()
+	case TemplatesJson:
+		cfg.templates = src.templates.Clone()
+	case ConfigSJson:
+		cfg.configSCfg = src.configSCfg.Clone()
+	case APIBanCfgJson:
+		cfg.apiBanCfg = src.apiBanCfg.Clone()
+	case CoreSCfgJson:
+		cfg.coreSCfg = src.coreSCfg.Clone()
+	case ActionSJson:
+		cfg.actionSCfg = src.actionSCfg.Clone()
+	case AccountSCfgJson:
+		cfg.accountSCfg = src.accountSCfg.Clone()
+	case TaxSJson:
+		cfg.taxSCfg = src.taxSCfg.Clone()
+	}
+}
+
+// V1GetConfigHistory returns the recorded config versions, optionally only the ones changing a section
+func (cfg *CGRConfig) V1GetConfigHistory(args *SectionWithOpts, reply *[]*ConfigVersion) (err error) {
+	cfg.history.Lock()
+	defer cfg.history.Unlock()
+	if err = cfg.history.loadFromDir(cfg.ConfigSCfg().HistoryDir); err != nil {
+		return
+	}
+	versions := make([]*ConfigVersion, 0, len(cfg.history.versions))
+	for _, cV := range cfg.history.versions {
+		if args.Section != utils.EmptyString && args.Section != utils.MetaAll &&
+			!utils.IsSliceMember(cV.Sections, args.Section) {
+			continue
+		}
+		versions = append(versions, cV)
+	}
+	if len(versions) == 0 {
+		return utils.ErrNotFound
+	}
+	*reply = versions
+	return
+}
+
+// RollbackConfigArgs the API params for V1RollbackConfig
+type RollbackConfigArgs struct {
+	Opts    map[string]interface{}
+	Tenant  string
+	Version int
+	DryRun  bool
+}
+
+// V1RollbackConfig restores the config to the given version
+// only the sections that differ from the version are loaded and reloaded
+func (cfg *CGRConfig) V1RollbackConfig(args *RollbackConfigArgs, reply *string) (err error) {
+	var cV *ConfigVersion
+	cfg.history.Lock()
+	if err = cfg.history.loadFromDir(cfg.ConfigSCfg().HistoryDir); err == nil {
+		cV, err = cfg.history.getVersion(args.Version)
+	}
+	cfg.history.Unlock()
+	if err != nil {
+		return
+	}
+	if cV.cfg == nil {
+		return fmt.Errorf("version <%d> is not available for rollback", args.Version)
+	}
+	oldCfg := cfg.Clone()
+	oldMp := oldCfg.AsMapInterface(oldCfg.GeneralCfg().RSRSep)
+	rbMp := cV.cfg.AsMapInterface(cV.cfg.GeneralCfg().RSRSep)
+	var sections []string
+	for _, section := range sortedCfgSections {
+		if !reflect.DeepEqual(sectionAsInterface(oldMp, section), sectionAsInterface(rbMp, section)) {
+			sections = append(sections, section)
+		}
+	}
+	if len(sections) == 0 {
+		*reply = utils.OK
+		return
+	}
+	cfgV := oldCfg.Clone() // check the sanity on a copy so a failed rollback leaves the config untouched
+	for _, section := range sections {
+		cfgV.restoreSection(section, cV.cfg)
+	}
+	if err = cfgV.checkConfigSanity(); err != nil {
+		return
+	}
+	if args.DryRun {
+		*reply = utils.OK
+		return
+	}
+	cfg.reloadDPCache(sections...)
+	cfg.LockSections(sections...)
+	for _, section := range sections {
+		cfg.restoreSection(section, cV.cfg)
+	}
+	cfg.UnlockSections(sections...)
+	cfg.reloadSections(sections...)
+	cfg.recordConfigVersionWithLog(utils.ConfigSv1RollbackConfig, args.Opts, oldCfg, sections)
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,259 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestConfigHistorySetConfig(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	var reply string
+	if err := cfg.V1SetConfig(&SetConfigArgs{
+		Opts: map[string]interface{}{utils.OptsConfigAuthor: "admin"},
+		Config: map[string]interface{}{
+			GENERAL_JSN: map[string]interface{}{utils.DefaultTenantCfg: "cgrates.net"},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	var rcv []*ConfigVersion
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 2 {
+		t.Fatalf("Expected 2 versions, received: %s", utils.ToJSON(rcv))
+	}
+	if rcv[0].Version != 0 || rcv[0].APIMethod != utils.MetaInit {
+		t.Errorf("Unexpected initial version: %s", utils.ToJSON(rcv[0]))
+	}
+	if rcv[1].Version != 1 || rcv[1].Author != "admin" ||
+		rcv[1].APIMethod != utils.ConfigSv1SetConfig ||
+		!reflect.DeepEqual(rcv[1].Sections, []string{GENERAL_JSN}) {
+		t.Errorf("Unexpected version: %s", utils.ToJSON(rcv[1]))
+	}
+	eDiff := map[string]map[string]*ConfigFieldDiff{
+		GENERAL_JSN: {
+			utils.DefaultTenantCfg: {Old: "cgrates.org", New: "cgrates.net"},
+		},
+	}
+	if !reflect.DeepEqual(eDiff, rcv[1].Diff) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(eDiff), utils.ToJSON(rcv[1].Diff))
+	}
+
+	// dry run should not be recorded
+	if err := cfg.V1SetConfig(&SetConfigArgs{
+		Config: map[string]interface{}{
+			GENERAL_JSN: map[string]interface{}{utils.DefaultTenantCfg: "cgrates.com"},
+		},
+		DryRun: true,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{Section: GENERAL_JSN}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 1 {
+		t.Errorf("Expected 1 version, received: %s", utils.ToJSON(rcv))
+	}
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{Section: CoreSCfgJson}, &rcv); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestConfigHistorySetConfigFromJSONSections(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	for _, section := range sortedCfgSections {
+		cfg.rldChans[section] = make(chan struct{}, 1)
+	}
+	var reply string
+	if err := cfg.V1SetConfigFromJSON(&SetConfigFromJSONArgs{
+		Config: `{"general":{"default_tenant":"cgrates.net"}}`,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	var rcv []*ConfigVersion
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 2 || !reflect.DeepEqual(rcv[1].Sections, []string{GENERAL_JSN}) {
+		t.Errorf("Expected only the changed section, received: %s", utils.ToJSON(rcv))
+	}
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{Section: CoreSCfgJson}, &rcv); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+}
+
+func TestConfigHistoryRollback(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	var reply string
+	if err := cfg.V1SetConfig(&SetConfigArgs{
+		Config: map[string]interface{}{
+			GENERAL_JSN:  map[string]interface{}{utils.DefaultTenantCfg: "cgrates.net"},
+			CoreSCfgJson: map[string]interface{}{utils.CapsCfg: 10},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.V1SetConfig(&SetConfigArgs{
+		Config: map[string]interface{}{
+			CoreSCfgJson: map[string]interface{}{utils.CapsCfg: 20},
+		},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 5}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 0, DryRun: true}, &reply); err != nil {
+		t.Fatal(err)
+	} else if cfg.CoreSCfg().Caps != 20 {
+		t.Errorf("Expected the dry run to not change the config, received caps: %d", cfg.CoreSCfg().Caps)
+	}
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 1}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CoreSCfg().Caps != 10 || cfg.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Unexpected config after rollback: %s %s",
+			utils.ToJSON(cfg.CoreSCfg()), utils.ToJSON(cfg.GeneralCfg()))
+	}
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 0}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if dflt := NewDefaultCGRConfig(); cfg.CoreSCfg().Caps != dflt.CoreSCfg().Caps ||
+		cfg.GeneralCfg().DefaultTenant != dflt.GeneralCfg().DefaultTenant {
+		t.Errorf("Unexpected config after rollback: %s %s",
+			utils.ToJSON(cfg.CoreSCfg()), utils.ToJSON(cfg.GeneralCfg()))
+	}
+	var rcv []*ConfigVersion
+	if err := cfg.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 5 {
+		t.Fatalf("Expected 5 versions, received: %s", utils.ToJSON(rcv))
+	} else if rcv[4].APIMethod != utils.ConfigSv1RollbackConfig ||
+		!reflect.DeepEqual(rcv[4].Sections, []string{GENERAL_JSN, CoreSCfgJson}) {
+		t.Errorf("Unexpected version: %s", utils.ToJSON(rcv[4]))
+	}
+}
+
+func TestConfigHistoryRollbackSanity(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.history.versions = []*ConfigVersion{{Version: 0, cfg: cfg.Clone()}, {Version: 1}}
+	cfg.history.versions[0].cfg.ralsCfg.Enabled = true
+	cfg.history.versions[0].cfg.ralsCfg.StatSConns = []string{utils.MetaInternal}
+	var reply string
+	expErr := "version <1> is not available for rollback"
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 1}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	expErr = "<StatS> not enabled but requested by <RALs> component"
+	if err := cfg.V1RollbackConfig(&RollbackConfigArgs{Version: 0}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	} else if cfg.RalsCfg().Enabled {
+		t.Error("Expected the config to not be changed")
+	}
+}
+
+func TestConfigHistoryPersistence(t *testing.T) {
+	dir, err := ioutil.TempDir(utils.EmptyString, "cfg_history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cfg := NewDefaultCGRConfig()
+	cfg.ConfigSCfg().HistoryDir = dir
+	cfg.ConfigSCfg().HistoryLimit = 2
+	var reply string
+	for _, tnt := range []string{"cgrates.net", "cgrates.com"} {
+		if err := cfg.V1SetConfig(&SetConfigArgs{
+			Config: map[string]interface{}{
+				GENERAL_JSN: map[string]interface{}{utils.DefaultTenantCfg: tnt},
+			},
+		}, &reply); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var files []string
+	for _, fi := range fis {
+		files = append(files, fi.Name())
+		if fi.Mode().Perm() != 0600 {
+			t.Errorf("Expected file %s to be readable only by the owner, received mode: %s", fi.Name(), fi.Mode())
+		}
+	}
+	if exp := []string{"1.json", "2.json"}; !reflect.DeepEqual(exp, files) {
+		t.Errorf("Expected %+v, received %+v", exp, files)
+	}
+
+	cfg2 := NewDefaultCGRConfig()
+	cfg2.ConfigSCfg().HistoryDir = dir
+	var rcv []*ConfigVersion
+	if err := cfg2.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 2 || rcv[1].Version != 2 ||
+		rcv[1].Diff[GENERAL_JSN][utils.DefaultTenantCfg].New != "cgrates.com" {
+		t.Errorf("Unexpected versions: %s", utils.ToJSON(rcv))
+	}
+	if err := cfg2.V1RollbackConfig(&RollbackConfigArgs{Version: 1}, &reply); err != nil {
+		t.Fatal(err)
+	} else if tnt := cfg2.GeneralCfg().DefaultTenant; tnt != "cgrates.net" {
+		t.Errorf("Expected the tenant restored out of the history_dir, received %q", tnt)
+	}
+	if err := cfg2.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 2 || rcv[1].Version != 3 || rcv[1].APIMethod != utils.ConfigSv1RollbackConfig {
+		t.Errorf("Unexpected versions: %s", utils.ToJSON(rcv))
+	}
+
+	if err := ioutil.WriteFile(path.Join(dir, "3.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg3 := NewDefaultCGRConfig()
+	cfg3.ConfigSCfg().HistoryDir = dir
+	if err := cfg3.V1GetConfigHistory(&SectionWithOpts{}, &rcv); err == nil {
+		t.Error("Expected error for invalid history file")
+	}
+}
+
+func TestConfigHistorySnapshot(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	cfg.GeneralCfg().DefaultTenant = "cgrates.net"
+	cfg.SIPAgentCfg().RetransmissionTimer = 2 * time.Second
+	cfg.FsAgentCfg().ExtraFields = NewRSRParsersMustCompile("~*req.Field1;~*req.Field2", utils.InfieldSep)
+	cfg.AccountSCfg().MaxUsage = utils.NewDecimal(int64(time.Hour), 0)
+	cfg.ERsCfg().Readers[0].XMLRootPath = utils.HierarchyPath{"root", "child"}
+	rbCfg, err := configFromSnapshot(configSnapshot(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mp := cfg.AsMapInterface(cfg.GeneralCfg().RSRSep)
+	rbMp := rbCfg.AsMapInterface(rbCfg.GeneralCfg().RSRSep)
+	for _, section := range sortedCfgSections {
+		if exp, rcv := sectionAsInterface(mp, section), sectionAsInterface(rbMp, section); !reflect.DeepEqual(exp, rcv) {
+			t.Errorf("Section %s, expected %s, received %s", section, utils.ToJSON(exp), utils.ToJSON(rcv))
+		}
+	}
+}
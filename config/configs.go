@@ -22,15 +22,21 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
 
 // ConfigSCfg config for listening over http
 type ConfigSCfg struct {
-	Enabled bool
-	URL     string
-	RootDir string
+	Enabled      bool
+	URL          string
+	RootDir      string
+	HistoryDir   string // directory where the config versions are persisted
+	HistoryLimit int    // maximum number of config versions kept, -1 for unlimited
+
+	LoadFromDataDB     bool          // load the config sections out of DataDB
+	DataDBSyncInterval time.Duration // interval to check DataDB for config changes
 }
 
 // loadFromJSONCfg loads Database config from JsonCfg
@@ -47,6 +53,20 @@ func (cScfg *ConfigSCfg) loadFromJSONCfg(jsnCfg *ConfigSCfgJson) (err error) {
 	if jsnCfg.Root_dir != nil {
 		cScfg.RootDir = *jsnCfg.Root_dir
 	}
+	if jsnCfg.History_dir != nil {
+		cScfg.HistoryDir = *jsnCfg.History_dir
+	}
+	if jsnCfg.History_limit != nil {
+		cScfg.HistoryLimit = *jsnCfg.History_limit
+	}
+	if jsnCfg.Load_from_datadb != nil {
+		cScfg.LoadFromDataDB = *jsnCfg.Load_from_datadb
+	}
+	if jsnCfg.Datadb_sync_interval != nil {
+		if cScfg.DataDBSyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Datadb_sync_interval); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -111,9 +131,16 @@ func handleConfigSFile(path string, w http.ResponseWriter) {
 // AsMapInterface returns the config as a map[string]interface{}
 func (cScfg *ConfigSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg: cScfg.Enabled,
-		utils.URLCfg:     cScfg.URL,
-		utils.RootDirCfg: cScfg.RootDir,
+		utils.EnabledCfg:            cScfg.Enabled,
+		utils.URLCfg:                cScfg.URL,
+		utils.RootDirCfg:            cScfg.RootDir,
+		utils.HistoryDirCfg:         cScfg.HistoryDir,
+		utils.HistoryLimitCfg:       cScfg.HistoryLimit,
+		utils.LoadFromDataDBCfg:     cScfg.LoadFromDataDB,
+		utils.DataDBSyncIntervalCfg: "0",
+	}
+	if cScfg.DataDBSyncInterval != 0 {
+		initialMP[utils.DataDBSyncIntervalCfg] = cScfg.DataDBSyncInterval.String()
 	}
 	return
 }
@@ -121,8 +148,13 @@ func (cScfg *ConfigSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of ConfigSCfg
 func (cScfg *ConfigSCfg) Clone() *ConfigSCfg {
 	return &ConfigSCfg{
-		Enabled: cScfg.Enabled,
-		URL:     cScfg.URL,
-		RootDir: cScfg.RootDir,
+		Enabled:      cScfg.Enabled,
+		URL:          cScfg.URL,
+		RootDir:      cScfg.RootDir,
+		HistoryDir:   cScfg.HistoryDir,
+		HistoryLimit: cScfg.HistoryLimit,
+
+		LoadFromDataDB:     cScfg.LoadFromDataDB,
+		DataDBSyncInterval: cScfg.DataDBSyncInterval,
 	}
 }
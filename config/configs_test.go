@@ -22,20 +22,29 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
 
 func TestConfigsloadFromJsonCfg(t *testing.T) {
 	jsonCfgs := &ConfigSCfgJson{
-		Enabled:  utils.BoolPointer(true),
-		Url:      utils.StringPointer("/randomURL/"),
-		Root_dir: utils.StringPointer("/randomPath/"),
+		Enabled:              utils.BoolPointer(true),
+		Url:                  utils.StringPointer("/randomURL/"),
+		Root_dir:             utils.StringPointer("/randomPath/"),
+		History_dir:          utils.StringPointer("/randomHistoryPath/"),
+		History_limit:        utils.IntPointer(-1),
+		Load_from_datadb:     utils.BoolPointer(true),
+		Datadb_sync_interval: utils.StringPointer("10s"),
 	}
 	expectedCfg := &ConfigSCfg{
-		Enabled: true,
-		URL:     "/randomURL/",
-		RootDir: "/randomPath/",
+		Enabled:            true,
+		URL:                "/randomURL/",
+		RootDir:            "/randomPath/",
+		HistoryDir:         "/randomHistoryPath/",
+		HistoryLimit:       -1,
+		LoadFromDataDB:     true,
+		DataDBSyncInterval: 10 * time.Second,
 	}
 	cgrCfg := NewDefaultCGRConfig()
 	if err := cgrCfg.configSCfg.loadFromJSONCfg(jsonCfgs); err != nil {
@@ -50,13 +59,21 @@ func TestConfigsAsMapInterface(t *testing.T) {
       "configs": {
           "enabled": true,
           "url": "",
-          "root_dir": "/var/spool/cgrates/configs"
+          "root_dir": "/var/spool/cgrates/configs",
+          "history_dir": "/var/spool/cgrates/configs_history",
+          "history_limit": 5,
+          "load_from_datadb": true,
+          "datadb_sync_interval": "1m"
       },
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg: true,
-		utils.URLCfg:     "",
-		utils.RootDirCfg: "/var/spool/cgrates/configs",
+		utils.EnabledCfg:            true,
+		utils.URLCfg:                "",
+		utils.RootDirCfg:            "/var/spool/cgrates/configs",
+		utils.HistoryDirCfg:         "/var/spool/cgrates/configs_history",
+		utils.HistoryLimitCfg:       5,
+		utils.LoadFromDataDBCfg:     true,
+		utils.DataDBSyncIntervalCfg: "1m0s",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgsJSONStr); err != nil {
 		t.Error(err)
@@ -70,9 +87,13 @@ func TestConfigsAsMapInterface2(t *testing.T) {
       "configs":{}
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg: false,
-		utils.URLCfg:     "/configs/",
-		utils.RootDirCfg: "/var/spool/cgrates/configs",
+		utils.EnabledCfg:            false,
+		utils.URLCfg:                "/configs/",
+		utils.RootDirCfg:            "/var/spool/cgrates/configs",
+		utils.HistoryDirCfg:         "",
+		utils.HistoryLimitCfg:       10,
+		utils.LoadFromDataDBCfg:     false,
+		utils.DataDBSyncIntervalCfg: "0",
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgsJSONStr); err != nil {
 		t.Error(err)
@@ -100,9 +121,14 @@ func TestNewCGRConfigFromPathWithoutEnv(t *testing.T) {
 
 func TestConfigSCfgClone(t *testing.T) {
 	cS := &ConfigSCfg{
-		Enabled: true,
-		URL:     "/randomURL/",
-		RootDir: "/randomPath/",
+		Enabled:      true,
+		URL:          "/randomURL/",
+		RootDir:      "/randomPath/",
+		HistoryDir:   "/randomHistoryPath/",
+		HistoryLimit: 10,
+
+		LoadFromDataDB:     true,
+		DataDBSyncInterval: time.Minute,
 	}
 	rcv := cS.Clone()
 	if !reflect.DeepEqual(cS, rcv) {
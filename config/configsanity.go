@@ -120,14 +120,34 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if !ldrSCfg.Enabled {
 			continue
 		}
-		if _, err := os.Stat(ldrSCfg.TpInDir); err != nil && os.IsNotExist(err) { // if loader is enabled tpInDir must exist
-			return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpInDir)
+		if loaderURLScheme(ldrSCfg.TpInDir) == utils.EmptyString {
+			if _, err := os.Stat(ldrSCfg.TpInDir); err != nil && os.IsNotExist(err) { // if loader is enabled tpInDir must exist
+				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpInDir)
+			}
+		} else if ldrSCfg.RunDelay == -1 { // only the local folders can be watched
+			return fmt.Errorf("<%s> cannot watch for changes the path: %s", utils.LoaderS, ldrSCfg.TpInDir)
 		}
 		if ldrSCfg.TpOutDir != utils.EmptyString { // tpOutDir support empty string for no moving files after process
-			if _, err := os.Stat(ldrSCfg.TpOutDir); err != nil && os.IsNotExist(err) {
-				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpOutDir)
+			switch loaderURLScheme(ldrSCfg.TpOutDir) {
+			case utils.EmptyString:
+				if _, err := os.Stat(ldrSCfg.TpOutDir); err != nil && os.IsNotExist(err) {
+					return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, ldrSCfg.TpOutDir)
+				}
+			case utils.HTTPScheme, utils.HTTPSScheme:
+				return fmt.Errorf("<%s> cannot move the files to the read only path: %s", utils.LoaderS, ldrSCfg.TpOutDir)
+			}
+		}
+		if loaderURLScheme(ldrSCfg.TpInDir) == utils.SFTPScheme ||
+			loaderURLScheme(ldrSCfg.TpOutDir) == utils.SFTPScheme {
+			if _, has := ldrSCfg.Opts[utils.SFTPKnownHostsFile]; !has {
+				if insecure, _ := utils.IfaceAsBool(ldrSCfg.Opts[utils.SFTPInsecureHostKey]); !insecure {
+					return fmt.Errorf("<%s> %s for the sftp path", utils.LoaderS, utils.NewErrMandatoryIeMissing(utils.SFTPKnownHostsFile))
+				}
 			}
 		}
+		if ldrSCfg.Atomic && ldrSCfg.VersionsLimit < 1 { // the active version is kept for rollback
+			return fmt.Errorf("<%s> the %s should be at least 1 for the atomic loads", utils.LoaderS, utils.VersionsLimitCfg)
+		}
 		for _, data := range ldrSCfg.Data {
 			if !posibleLoaderTypes.Has(data.Type) {
 				return fmt.Errorf("<%s> unsupported data type %s", utils.LoaderS, data.Type)
@@ -314,6 +334,16 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				}
 			}
 		}
+		for _, peer := range cfg.diameterAgentCfg.Peers {
+			if peer.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for peer %s", utils.DiameterAgent, utils.NewErrMandatoryIeMissing(utils.Address), peer.ID)
+			}
+		}
+		for i, pc := range cfg.diameterAgentCfg.PolicyCounters {
+			if pc.ID == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for policy counter %d", utils.DiameterAgent, utils.NewErrMandatoryIeMissing(utils.ID), i)
+			}
+		}
 	}
 	//Radius Agent
 	if cfg.radiusAgentCfg.Enabled {
@@ -322,13 +352,19 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				utils.RadiusAgent, utils.SessionS)
 		}
 		for _, connID := range cfg.radiusAgentCfg.SessionSConns {
-			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.sessionSCfg.Enabled {
+			isInternal := strings.HasPrefix(connID, utils.MetaInternal) || strings.HasPrefix(connID, rpcclient.BiRPCInternal)
+			if isInternal && !cfg.sessionSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.RadiusAgent)
 			}
-			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+			if _, has := cfg.rpcConns[connID]; !has && !isInternal {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RadiusAgent, connID)
 			}
 		}
+		for _, tplID := range []string{cfg.radiusAgentCfg.DMRTemplate, cfg.radiusAgentCfg.CoATemplate} {
+			if _, has := cfg.templates[tplID]; tplID != utils.EmptyString && !has {
+				return fmt.Errorf("<%s> template with id: <%s> not defined", utils.RadiusAgent, tplID)
+			}
+		}
 		for _, req := range cfg.radiusAgentCfg.RequestProcessors {
 			for _, field := range req.RequestFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -500,6 +536,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		for _, connID := range cfg.routeSCfg.RateSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.rateSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.RateS, utils.RouteS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
+			}
+		}
 	}
 	// Scheduler check connection with CDR Server
 	if cfg.schedulerCfg.Enabled {
@@ -528,6 +572,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// the *diameter_policy_counters action can be executed by ThresholdS also
+	for _, connID := range cfg.schedulerCfg.DiameterAgentConns {
+		if strings.HasPrefix(connID, utils.MetaInternal) {
+			return fmt.Errorf("<%s> internal connection IDs are not supported for <%s>", utils.SchedulerS, utils.DiameterAgentConnsCfg)
+		}
+		if _, has := cfg.rpcConns[connID]; !has {
+			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SchedulerS, connID)
+		}
+	}
 	// EventReader sanity checks
 	if cfg.ersCfg.Enabled {
 		for _, connID := range cfg.ersCfg.SessionSConns {
@@ -601,12 +654,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				if exp.FieldSep == utils.EmptyString {
 					return fmt.Errorf("<%s> empty FieldSep for exporter with ID: %s", utils.EEs, exp.ID)
 				}
-			case utils.MetaFileFWV:
+			case utils.MetaFileFWV, utils.MetaFileJSON, utils.MetaFileHTML:
 				for _, dir := range []string{exp.ExportPath} {
 					if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
 						return fmt.Errorf("<%s> nonexistent folder: %s for exporter with ID: %s", utils.EEs, dir, exp.ID)
 					}
 				}
+				if tmplPath, has := exp.Opts[utils.HTMLTemplate]; exp.Type == utils.MetaFileHTML && has {
+					if _, err := os.Stat(utils.IfaceAsString(tmplPath)); err != nil {
+						return fmt.Errorf("<%s> nonexistent %s: %s for exporter with ID: %s", utils.EEs, utils.HTMLTemplate, tmplPath, exp.ID)
+					}
+				}
 			case utils.MetaSQL:
 				if len(exp.ContentFields()) == 0 {
 					return fmt.Errorf("<%s> empty content fields for exporter with ID: %s", utils.EEs, exp.ID)
@@ -808,6 +866,21 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	for subsys, lvl := range cfg.generalCfg.LogLevels {
+		if lvl < utils.LOGLEVEL_EMERGENCY || lvl > utils.LOGLEVEL_DEBUG {
+			return fmt.Errorf("<%s> invalid log level %d for subsystem <%s>", GENERAL_JSN, lvl, subsys)
+		}
+	}
+	if cfg.generalCfg.TracesExporter != utils.EmptyString {
+		if cfg.generalCfg.TracesExporter != utils.MetaFile &&
+			cfg.generalCfg.TracesExporter != utils.MetaHTTP {
+			return fmt.Errorf("<%s> unsupported traces exporter: <%s>", GENERAL_JSN, cfg.generalCfg.TracesExporter)
+		}
+		if cfg.generalCfg.TracesEndpoint == utils.EmptyString {
+			return fmt.Errorf("<%s> empty traces endpoint for exporter <%s>", GENERAL_JSN, cfg.generalCfg.TracesExporter)
+		}
+	}
+
 	if cfg.analyzerSCfg.Enabled {
 		if _, err := os.Stat(cfg.analyzerSCfg.DBPath); err != nil && os.IsNotExist(err) {
 			return fmt.Errorf("<%s> nonexistent DB folder: %q", utils.AnalyzerS, cfg.analyzerSCfg.DBPath)
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestConfigSanityRater(t *testing.T) {
@@ -169,6 +170,59 @@ func TestConfigSanityLoaders(t *testing.T) {
 		t.Errorf("Expecting: %+q received: %+q", expected, err)
 	}
 
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "sftp://cgrates@127.0.0.1/tariffs",
+			RunDelay: -1,
+		},
+	}
+	expected = "<LoaderS> cannot watch for changes the path: sftp://cgrates@127.0.0.1/tariffs"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q received: %+q", expected, err)
+	}
+
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "sftp://cgrates@127.0.0.1/tariffs",
+			TpOutDir: utils.EmptyString,
+		},
+	}
+	expected = "<LoaderS> MANDATORY_IE_MISSING: [sftpKnownHostsFile] for the sftp path"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q received: %+q", expected, err)
+	}
+	cfg.loaderCfg[0].Opts = map[string]interface{}{utils.SFTPInsecureHostKey: true}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "s3://cgrates/tariffs",
+			TpOutDir: "https://cgrates.org/tariffs",
+		},
+	}
+	expected = "<LoaderS> cannot move the files to the read only path: https://cgrates.org/tariffs"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q received: %+q", expected, err)
+	}
+
+	cfg.loaderCfg = LoaderSCfgs{
+		&LoaderSCfg{
+			Enabled:  true,
+			TpInDir:  "/",
+			TpOutDir: "/",
+			Atomic:   true,
+		},
+	}
+	expected = "<LoaderS> the versions_limit should be at least 1 for the atomic loads"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.loaderCfg = LoaderSCfgs{
 		&LoaderSCfg{
 			Enabled:  true,
@@ -489,6 +543,18 @@ func TestConfigSanityDAgent(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.diameterAgentCfg.RequestProcessors[0].ReplyFields[0].Type = utils.MetaNone
+	cfg.diameterAgentCfg.Peers = []*DiameterPeerCfg{{ID: "PCEF1"}}
+	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [Address] for peer PCEF1"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers[0].Address = "127.0.0.1:3868"
+	cfg.diameterAgentCfg.PolicyCounters = []*DiameterPolicyCounterCfg{{DefaultStatus: "valid"}}
+	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [ID] for policy counter 0"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityRadiusAgent(t *testing.T) {
@@ -520,6 +586,10 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.radiusAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS)}
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 	cfg.radiusAgentCfg.SessionSConns = []string{"test"}
 	expected = "<RadiusAgent> connection with id: <test> not defined"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
@@ -527,6 +597,13 @@ func TestConfigSanityRadiusAgent(t *testing.T) {
 	}
 
 	cfg.rpcConns["test"] = nil
+	cfg.radiusAgentCfg.DMRTemplate = "*dmr_missing"
+	expected = "<RadiusAgent> template with id: <*dmr_missing> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.radiusAgentCfg.DMRTemplate = utils.MetaDMR
+	cfg.radiusAgentCfg.CoATemplate = utils.MetaCoA
 	expected = "<RadiusAgent> MANDATORY_IE_MISSING: [Path] for cgrates at SessionId"
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
@@ -811,6 +888,18 @@ func TestConfigSanityRouteS(t *testing.T) {
 	}
 	cfg.routeSCfg.RALsConns = []string{}
 
+	cfg.routeSCfg.RateSConns = []string{utils.MetaInternal}
+	expected = "<RateS> not enabled but requested by <RouteS> component"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.RateSConns = []string{"test"}
+	expected = "<RouteS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.routeSCfg.RateSConns = []string{}
+
 }
 
 func TestConfigSanityScheduler(t *testing.T) {
@@ -852,6 +941,18 @@ func TestConfigSanityScheduler(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 	cfg.schedulerCfg.StatSConns = []string{}
+
+	cfg.schedulerCfg.DiameterAgentConns = []string{utils.MetaInternal}
+	expected = "<SchedulerS> internal connection IDs are not supported for <diameter_agent_conns>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.schedulerCfg.DiameterAgentConns = []string{"test"}
+	expected = "<SchedulerS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.schedulerCfg.DiameterAgentConns = []string{}
 }
 
 func TestConfigSanityEventReader(t *testing.T) {
@@ -1019,6 +1120,19 @@ func TestConfigSanityEventExporter(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.eesCfg.Exporters[0].Type = utils.MetaFileHTML
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].ExportPath = "/"
+	cfg.eesCfg.Exporters[0].Opts = map[string]interface{}{utils.HTMLTemplate: "randomTemplate"}
+	expected = "<EEs> nonexistent htmlTemplate: randomTemplate for exporter with ID: "
+	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.eesCfg.Exporters[0].ExportPath = "randomPath"
+	cfg.eesCfg.Exporters[0].Opts = nil
+
 	cfg.eesCfg.Exporters[0].Type = utils.MetaSQL
 	expected = "<EEs> empty content fields for exporter with ID: "
 	if err := cfg.CheckConfigSanity(); err == nil || err.Error() != expected {
@@ -1199,6 +1313,33 @@ func TestConfigSanityStorDB(t *testing.T) {
 	}
 }
 
+func TestConfigSanityGeneral(t *testing.T) {
+	cfg = NewDefaultCGRConfig()
+	cfg.generalCfg.LogLevels = map[string]int{utils.SessionS: 8}
+	expected := "<general> invalid log level 8 for subsystem <SessionS>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.LogLevels[utils.SessionS] = utils.LOGLEVEL_DEBUG
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+	cfg.generalCfg.TracesExporter = "*grpc"
+	expected = "<general> unsupported traces exporter: <*grpc>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.TracesExporter = utils.MetaHTTP
+	expected = "<general> empty traces endpoint for exporter <*http>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.generalCfg.TracesEndpoint = "http://127.0.0.1:4318/v1/traces"
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestConfigSanityAnalyzer(t *testing.T) {
 	cfg := NewDefaultCGRConfig()
 
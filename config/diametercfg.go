@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
 )
@@ -39,6 +41,12 @@ type DiameterAgentCfg struct {
 	ASRTemplate       string
 	RARTemplate       string
 	ForcedDisconnect  string
+	WatchdogInterval  time.Duration // Tw, the interval between the DWRs sent to the outgoing peers
+	ReconnectInterval time.Duration // initial wait before reconnecting to a peer, doubled at each failure
+	MaxReconnectIntvl time.Duration // maximum wait before reconnecting to a peer
+	Peers             []*DiameterPeerCfg
+	PolicyCntIntvl    time.Duration // re-evaluate the subscribed policy counters, 0 to disable
+	PolicyCounters    []*DiameterPolicyCounterCfg
 	RequestProcessors []*RequestProcessor
 }
 
@@ -96,6 +104,40 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separa
 	if jsnCfg.Forced_disconnect != nil {
 		da.ForcedDisconnect = *jsnCfg.Forced_disconnect
 	}
+	if jsnCfg.Watchdog_interval != nil {
+		if da.WatchdogInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Watchdog_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reconnect_interval != nil {
+		if da.ReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconnect_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Max_reconnect_interval != nil {
+		if da.MaxReconnectIntvl, err = utils.ParseDurationWithNanosecs(*jsnCfg.Max_reconnect_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Peers != nil {
+		da.Peers = make([]*DiameterPeerCfg, len(*jsnCfg.Peers))
+		for i, peerJsn := range *jsnCfg.Peers {
+			da.Peers[i] = new(DiameterPeerCfg)
+			da.Peers[i].loadFromJSONCfg(peerJsn)
+		}
+	}
+	if jsnCfg.Policy_counters_interval != nil {
+		if da.PolicyCntIntvl, err = utils.ParseDurationWithNanosecs(*jsnCfg.Policy_counters_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Policy_counters != nil {
+		da.PolicyCounters = make([]*DiameterPolicyCounterCfg, len(*jsnCfg.Policy_counters))
+		for i, pcJsn := range *jsnCfg.Policy_counters {
+			da.PolicyCounters[i] = new(DiameterPolicyCounterCfg)
+			da.PolicyCounters[i].loadFromJSONCfg(pcJsn)
+		}
+	}
 	if jsnCfg.Request_processors != nil {
 		for _, reqProcJsn := range *jsnCfg.Request_processors {
 			rp := new(RequestProcessor)
@@ -121,20 +163,46 @@ func (da *DiameterAgentCfg) loadFromJSONCfg(jsnCfg *DiameterAgentJsonCfg, separa
 // AsMapInterface returns the config as a map[string]interface{}
 func (da *DiameterAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:            da.Enabled,
-		utils.ListenNetCfg:          da.ListenNet,
-		utils.ListenCfg:             da.Listen,
-		utils.DictionariesPathCfg:   da.DictionariesPath,
-		utils.OriginHostCfg:         da.OriginHost,
-		utils.OriginRealmCfg:        da.OriginRealm,
-		utils.VendorIDCfg:           da.VendorID,
-		utils.ProductNameCfg:        da.ProductName,
-		utils.ConcurrentRequestsCfg: da.ConcurrentReqs,
-		utils.SyncedConnReqsCfg:     da.SyncedConnReqs,
-		utils.ASRTemplateCfg:        da.ASRTemplate,
-		utils.RARTemplateCfg:        da.RARTemplate,
-		utils.ForcedDisconnectCfg:   da.ForcedDisconnect,
+		utils.EnabledCfg:                da.Enabled,
+		utils.ListenNetCfg:              da.ListenNet,
+		utils.ListenCfg:                 da.Listen,
+		utils.DictionariesPathCfg:       da.DictionariesPath,
+		utils.OriginHostCfg:             da.OriginHost,
+		utils.OriginRealmCfg:            da.OriginRealm,
+		utils.VendorIDCfg:               da.VendorID,
+		utils.ProductNameCfg:            da.ProductName,
+		utils.ConcurrentRequestsCfg:     da.ConcurrentReqs,
+		utils.SyncedConnReqsCfg:         da.SyncedConnReqs,
+		utils.ASRTemplateCfg:            da.ASRTemplate,
+		utils.RARTemplateCfg:            da.RARTemplate,
+		utils.ForcedDisconnectCfg:       da.ForcedDisconnect,
+		utils.WatchdogIntervalCfg:       "0",
+		utils.ReconnectIntervalCfg:      "0",
+		utils.MaxReconnectIntervalCfg:   "0",
+		utils.PolicyCountersIntervalCfg: "0",
+	}
+	if da.WatchdogInterval != 0 {
+		initialMP[utils.WatchdogIntervalCfg] = da.WatchdogInterval.String()
+	}
+	if da.ReconnectInterval != 0 {
+		initialMP[utils.ReconnectIntervalCfg] = da.ReconnectInterval.String()
 	}
+	if da.MaxReconnectIntvl != 0 {
+		initialMP[utils.MaxReconnectIntervalCfg] = da.MaxReconnectIntvl.String()
+	}
+	peers := make([]map[string]interface{}, len(da.Peers))
+	for i, peer := range da.Peers {
+		peers[i] = peer.AsMapInterface()
+	}
+	initialMP[utils.PeersCfg] = peers
+	if da.PolicyCntIntvl != 0 {
+		initialMP[utils.PolicyCountersIntervalCfg] = da.PolicyCntIntvl.String()
+	}
+	policyCounters := make([]map[string]interface{}, len(da.PolicyCounters))
+	for i, pc := range da.PolicyCounters {
+		policyCounters[i] = pc.AsMapInterface()
+	}
+	initialMP[utils.PolicyCountersCfg] = policyCounters
 
 	requestProcessors := make([]map[string]interface{}, len(da.RequestProcessors))
 	for i, item := range da.RequestProcessors {
@@ -160,19 +228,35 @@ func (da *DiameterAgentCfg) AsMapInterface(separator string) (initialMP map[stri
 // Clone returns a deep copy of DiameterAgentCfg
 func (da DiameterAgentCfg) Clone() (cln *DiameterAgentCfg) {
 	cln = &DiameterAgentCfg{
-		Enabled:          da.Enabled,
-		ListenNet:        da.ListenNet,
-		Listen:           da.Listen,
-		DictionariesPath: da.DictionariesPath,
-		OriginHost:       da.OriginHost,
-		OriginRealm:      da.OriginRealm,
-		VendorID:         da.VendorID,
-		ProductName:      da.ProductName,
-		ConcurrentReqs:   da.ConcurrentReqs,
-		SyncedConnReqs:   da.SyncedConnReqs,
-		ASRTemplate:      da.ASRTemplate,
-		RARTemplate:      da.RARTemplate,
-		ForcedDisconnect: da.ForcedDisconnect,
+		Enabled:           da.Enabled,
+		ListenNet:         da.ListenNet,
+		Listen:            da.Listen,
+		DictionariesPath:  da.DictionariesPath,
+		OriginHost:        da.OriginHost,
+		OriginRealm:       da.OriginRealm,
+		VendorID:          da.VendorID,
+		ProductName:       da.ProductName,
+		ConcurrentReqs:    da.ConcurrentReqs,
+		SyncedConnReqs:    da.SyncedConnReqs,
+		ASRTemplate:       da.ASRTemplate,
+		RARTemplate:       da.RARTemplate,
+		ForcedDisconnect:  da.ForcedDisconnect,
+		WatchdogInterval:  da.WatchdogInterval,
+		ReconnectInterval: da.ReconnectInterval,
+		MaxReconnectIntvl: da.MaxReconnectIntvl,
+		PolicyCntIntvl:    da.PolicyCntIntvl,
+	}
+	if da.PolicyCounters != nil {
+		cln.PolicyCounters = make([]*DiameterPolicyCounterCfg, len(da.PolicyCounters))
+		for i, pc := range da.PolicyCounters {
+			cln.PolicyCounters[i] = pc.Clone()
+		}
+	}
+	if da.Peers != nil {
+		cln.Peers = make([]*DiameterPeerCfg, len(da.Peers))
+		for i, peer := range da.Peers {
+			cln.Peers[i] = peer.Clone()
+		}
 	}
 	if da.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(da.SessionSConns))
@@ -188,3 +272,149 @@ func (da DiameterAgentCfg) Clone() (cln *DiameterAgentCfg) {
 	}
 	return
 }
+
+// DiameterPeerCfg is an outgoing peer the DiameterAgent connects to
+type DiameterPeerCfg struct {
+	ID                 string
+	Address            string
+	Network            string // sctp or tcp
+	AuthApplicationIDs []int  // advertised in the CER
+}
+
+func (dp *DiameterPeerCfg) loadFromJSONCfg(jsnCfg *DiameterPeerJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		dp.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Address != nil {
+		dp.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Network != nil {
+		dp.Network = *jsnCfg.Network
+	}
+	if jsnCfg.Auth_application_ids != nil {
+		dp.AuthApplicationIDs = make([]int, len(*jsnCfg.Auth_application_ids))
+		copy(dp.AuthApplicationIDs, *jsnCfg.Auth_application_ids)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dp *DiameterPeerCfg) AsMapInterface() map[string]interface{} {
+	authAppIDs := make([]int, len(dp.AuthApplicationIDs))
+	copy(authAppIDs, dp.AuthApplicationIDs)
+	return map[string]interface{}{
+		utils.IDCfg:                 dp.ID,
+		utils.AddressCfg:            dp.Address,
+		utils.NetworkCfg:            dp.Network,
+		utils.AuthApplicationIDsCfg: authAppIDs,
+	}
+}
+
+// Clone returns a deep copy of DiameterPeerCfg
+func (dp DiameterPeerCfg) Clone() (cln *DiameterPeerCfg) {
+	cln = &DiameterPeerCfg{
+		ID:      dp.ID,
+		Address: dp.Address,
+		Network: dp.Network,
+	}
+	if dp.AuthApplicationIDs != nil {
+		cln.AuthApplicationIDs = make([]int, len(dp.AuthApplicationIDs))
+		copy(cln.AuthApplicationIDs, dp.AuthApplicationIDs)
+	}
+	return
+}
+
+// DiameterPolicyCounterCfg is a Sy policy counter with the statuses reported to the PCRF
+type DiameterPolicyCounterCfg struct {
+	ID            string // Policy-Counter-Identifier
+	DefaultStatus string // reported when none of the Statuses matches
+	Statuses      []*DiameterCounterStatusCfg
+}
+
+func (pc *DiameterPolicyCounterCfg) loadFromJSONCfg(jsnCfg *DiameterPolicyCounterJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		pc.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Default_status != nil {
+		pc.DefaultStatus = *jsnCfg.Default_status
+	}
+	if jsnCfg.Statuses != nil {
+		pc.Statuses = make([]*DiameterCounterStatusCfg, len(*jsnCfg.Statuses))
+		for i, stJsn := range *jsnCfg.Statuses {
+			pc.Statuses[i] = new(DiameterCounterStatusCfg)
+			pc.Statuses[i].loadFromJSONCfg(stJsn)
+		}
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (pc *DiameterPolicyCounterCfg) AsMapInterface() map[string]interface{} {
+	statuses := make([]map[string]interface{}, len(pc.Statuses))
+	for i, st := range pc.Statuses {
+		statuses[i] = st.AsMapInterface()
+	}
+	return map[string]interface{}{
+		utils.IDCfg:            pc.ID,
+		utils.DefaultStatusCfg: pc.DefaultStatus,
+		utils.StatusesCfg:      statuses,
+	}
+}
+
+// Clone returns a deep copy of DiameterPolicyCounterCfg
+func (pc DiameterPolicyCounterCfg) Clone() (cln *DiameterPolicyCounterCfg) {
+	cln = &DiameterPolicyCounterCfg{
+		ID:            pc.ID,
+		DefaultStatus: pc.DefaultStatus,
+	}
+	if pc.Statuses != nil {
+		cln.Statuses = make([]*DiameterCounterStatusCfg, len(pc.Statuses))
+		for i, st := range pc.Statuses {
+			cln.Statuses[i] = st.Clone()
+		}
+	}
+	return
+}
+
+// DiameterCounterStatusCfg is the status of a policy counter when the filters are passing
+type DiameterCounterStatusCfg struct {
+	Status    string
+	FilterIDs []string
+}
+
+func (cs *DiameterCounterStatusCfg) loadFromJSONCfg(jsnCfg *DiameterCounterStatusJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Status != nil {
+		cs.Status = *jsnCfg.Status
+	}
+	if jsnCfg.Filters != nil {
+		cs.FilterIDs = make([]string, len(*jsnCfg.Filters))
+		copy(cs.FilterIDs, *jsnCfg.Filters)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (cs *DiameterCounterStatusCfg) AsMapInterface() map[string]interface{} {
+	filterIDs := make([]string, len(cs.FilterIDs))
+	copy(filterIDs, cs.FilterIDs)
+	return map[string]interface{}{
+		utils.StatusCfg:  cs.Status,
+		utils.FiltersCfg: filterIDs,
+	}
+}
+
+// Clone returns a deep copy of DiameterCounterStatusCfg
+func (cs DiameterCounterStatusCfg) Clone() (cln *DiameterCounterStatusCfg) {
+	cln = &DiameterCounterStatusCfg{Status: cs.Status}
+	if cs.FilterIDs != nil {
+		cln.FilterIDs = make([]string, len(cs.FilterIDs))
+		copy(cln.FilterIDs, cs.FilterIDs)
+	}
+	return
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -42,6 +43,28 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		Asr_template:         utils.StringPointer("randomTemplate"),
 		Rar_template:         utils.StringPointer("randomTemplate"),
 		Forced_disconnect:    utils.StringPointer("forced"),
+		Watchdog_interval:    utils.StringPointer("10s"),
+		Peers: &[]*DiameterPeerJsonCfg{
+			{
+				Id:                   utils.StringPointer("PCEF1"),
+				Address:              utils.StringPointer("127.0.0.1:3869"),
+				Network:              utils.StringPointer(utils.TCP),
+				Auth_application_ids: &[]int{4},
+			},
+		},
+		Policy_counters_interval: utils.StringPointer("1m"),
+		Policy_counters: &[]*DiameterPolicyCounterJsonCfg{
+			{
+				Id:             utils.StringPointer("DATA_LIMIT"),
+				Default_status: utils.StringPointer("valid"),
+				Statuses: &[]*DiameterCounterStatusJsonCfg{
+					{
+						Status:  utils.StringPointer("exceeded"),
+						Filters: &[]string{"*gte:~*req.Usage:100"},
+					},
+				},
+			},
+		},
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:       utils.StringPointer(utils.CGRateSLwr),
@@ -50,20 +73,44 @@ func TestDiameterAgentCfgloadFromJsonCfg(t *testing.T) {
 		},
 	}
 	expected := &DiameterAgentCfg{
-		Enabled:          true,
-		ListenNet:        "tcp",
-		Listen:           "127.0.0.1:3868",
-		DictionariesPath: "/usr/share/cgrates/diameter/dict/",
-		SessionSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
-		OriginHost:       "CGR-DA",
-		OriginRealm:      "cgrates.org",
-		VendorID:         0,
-		ProductName:      "randomName",
-		ConcurrentReqs:   10,
-		SyncedConnReqs:   true,
-		ASRTemplate:      "randomTemplate",
-		RARTemplate:      "randomTemplate",
-		ForcedDisconnect: "forced",
+		Enabled:           true,
+		ListenNet:         "tcp",
+		Listen:            "127.0.0.1:3868",
+		DictionariesPath:  "/usr/share/cgrates/diameter/dict/",
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		OriginHost:        "CGR-DA",
+		OriginRealm:       "cgrates.org",
+		VendorID:          0,
+		ProductName:       "randomName",
+		ConcurrentReqs:    10,
+		SyncedConnReqs:    true,
+		ASRTemplate:       "randomTemplate",
+		RARTemplate:       "randomTemplate",
+		ForcedDisconnect:  "forced",
+		WatchdogInterval:  10 * time.Second,
+		ReconnectInterval: 5 * time.Second,
+		MaxReconnectIntvl: time.Minute,
+		Peers: []*DiameterPeerCfg{
+			{
+				ID:                 "PCEF1",
+				Address:            "127.0.0.1:3869",
+				Network:            utils.TCP,
+				AuthApplicationIDs: []int{4},
+			},
+		},
+		PolicyCntIntvl: time.Minute,
+		PolicyCounters: []*DiameterPolicyCounterCfg{
+			{
+				ID:            "DATA_LIMIT",
+				DefaultStatus: "valid",
+				Statuses: []*DiameterCounterStatusCfg{
+					{
+						Status:    "exceeded",
+						FilterIDs: []string{"*gte:~*req.Usage:100"},
+					},
+				},
+			},
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -147,20 +194,26 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.ASRTemplateCfg:        "",
-		utils.ConcurrentRequestsCfg: -1,
-		utils.DictionariesPathCfg:   "/usr/share/cgrates/diameter/dict/",
-		utils.EnabledCfg:            false,
-		utils.ForcedDisconnectCfg:   "*none",
-		utils.ListenCfg:             "127.0.0.1:3868",
-		utils.ListenNetCfg:          "tcp",
-		utils.OriginHostCfg:         "CGR-DA",
-		utils.OriginRealmCfg:        "cgrates.org",
-		utils.ProductNameCfg:        "CGRateS",
-		utils.RARTemplateCfg:        "",
-		utils.SessionSConnsCfg:      []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
-		utils.SyncedConnReqsCfg:     true,
-		utils.VendorIDCfg:           0,
+		utils.ASRTemplateCfg:            "",
+		utils.ConcurrentRequestsCfg:     -1,
+		utils.DictionariesPathCfg:       "/usr/share/cgrates/diameter/dict/",
+		utils.EnabledCfg:                false,
+		utils.ForcedDisconnectCfg:       "*none",
+		utils.WatchdogIntervalCfg:       "30s",
+		utils.ReconnectIntervalCfg:      "5s",
+		utils.MaxReconnectIntervalCfg:   "1m0s",
+		utils.PeersCfg:                  []map[string]interface{}{},
+		utils.PolicyCountersIntervalCfg: "0",
+		utils.PolicyCountersCfg:         []map[string]interface{}{},
+		utils.ListenCfg:                 "127.0.0.1:3868",
+		utils.ListenNetCfg:              "tcp",
+		utils.OriginHostCfg:             "CGR-DA",
+		utils.OriginRealmCfg:            "cgrates.org",
+		utils.ProductNameCfg:            "CGRateS",
+		utils.RARTemplateCfg:            "",
+		utils.SessionSConnsCfg:          []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
+		utils.SyncedConnReqsCfg:         true,
+		utils.VendorIDCfg:               0,
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:       utils.CGRateSLwr,
@@ -210,21 +263,27 @@ func TestDiameterAgentCfgAsMapInterface1(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.ASRTemplateCfg:        "",
-		utils.ConcurrentRequestsCfg: -1,
-		utils.DictionariesPathCfg:   "/usr/share/cgrates/diameter",
-		utils.EnabledCfg:            true,
-		utils.ForcedDisconnectCfg:   "*none",
-		utils.ListenCfg:             "127.0.0.1:3868",
-		utils.ListenNetCfg:          "tcp",
-		utils.OriginHostCfg:         "CGR-DA",
-		utils.OriginRealmCfg:        "cgrates.org",
-		utils.ProductNameCfg:        "CGRateS",
-		utils.RARTemplateCfg:        "",
-		utils.SessionSConnsCfg:      []string{rpcclient.BiRPCInternal},
-		utils.SyncedConnReqsCfg:     false,
-		utils.VendorIDCfg:           0,
-		utils.RequestProcessorsCfg:  []map[string]interface{}{},
+		utils.ASRTemplateCfg:            "",
+		utils.ConcurrentRequestsCfg:     -1,
+		utils.DictionariesPathCfg:       "/usr/share/cgrates/diameter",
+		utils.EnabledCfg:                true,
+		utils.ForcedDisconnectCfg:       "*none",
+		utils.WatchdogIntervalCfg:       "30s",
+		utils.ReconnectIntervalCfg:      "5s",
+		utils.MaxReconnectIntervalCfg:   "1m0s",
+		utils.PeersCfg:                  []map[string]interface{}{},
+		utils.PolicyCountersIntervalCfg: "0",
+		utils.PolicyCountersCfg:         []map[string]interface{}{},
+		utils.ListenCfg:                 "127.0.0.1:3868",
+		utils.ListenNetCfg:              "tcp",
+		utils.OriginHostCfg:             "CGR-DA",
+		utils.OriginRealmCfg:            "cgrates.org",
+		utils.ProductNameCfg:            "CGRateS",
+		utils.RARTemplateCfg:            "",
+		utils.SessionSConnsCfg:          []string{rpcclient.BiRPCInternal},
+		utils.SyncedConnReqsCfg:         false,
+		utils.VendorIDCfg:               0,
+		utils.RequestProcessorsCfg:      []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -249,6 +308,15 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 		ASRTemplate:      "randomTemplate",
 		RARTemplate:      "randomTemplate",
 		ForcedDisconnect: "forced",
+		WatchdogInterval: 30 * time.Second,
+		Peers: []*DiameterPeerCfg{
+			{
+				ID:                 "PCEF1",
+				Address:            "127.0.0.1:3869",
+				Network:            utils.TCP,
+				AuthApplicationIDs: []int{4},
+			},
+		},
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:       "cgrates",
@@ -266,4 +334,7 @@ func TestDiameterAgentCfgClone(t *testing.T) {
 	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "cgrates" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.Peers[0].AuthApplicationIDs[0] = 0; ban.Peers[0].AuthApplicationIDs[0] != 4 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
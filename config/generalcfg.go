@@ -27,29 +27,34 @@ import (
 
 // GeneralCfg is the general config section
 type GeneralCfg struct {
-	NodeID           string        // Identifier for this engine instance
-	Logger           string        // dictates the way logs are displayed/stored
-	LogLevel         int           // system wide log level, nothing higher than this will be logged
-	RoundingDecimals int           // Number of decimals to round end prices at
-	DBDataEncoding   string        // The encoding used to store object data in strings: <msgpack|json>
-	TpExportPath     string        // Path towards export folder for offline Tariff Plans
-	PosterAttempts   int           // Time to wait before writing the failed posts in a single file
-	FailedPostsDir   string        // Directory path where we store failed http requests
-	FailedPostsTTL   time.Duration // Directory path where we store failed http requests
-	DefaultReqType   string        // Use this request type if not defined on top
-	DefaultCategory  string        // set default type of record
-	DefaultTenant    string        // set default tenant
-	DefaultTimezone  string        // default timezone for timestamps where not specified <""|UTC|Local|$IANA_TZ_DB>
-	DefaultCaching   string
-	ConnectAttempts  int           // number of initial connection attempts before giving up
-	Reconnects       int           // number of recconect attempts in case of connection lost <-1 for infinite | nb>
-	ConnectTimeout   time.Duration // timeout for RPC connection attempts
-	ReplyTimeout     time.Duration // timeout replies if not reaching back
-	LockingTimeout   time.Duration // locking mechanism timeout to avoid deadlocks
-	DigestSeparator  string        //
-	DigestEqual      string        //
-	RSRSep           string        // separator used to split RSRParser (by default is used ";")
-	MaxParallelConns int           // the maximum number of connection used by the *parallel strategy
+	NodeID            string         // Identifier for this engine instance
+	Logger            string         // dictates the way logs are displayed/stored
+	LogLevel          int            // system wide log level, nothing higher than this will be logged
+	LogLevels         map[string]int // log level overwrites per subsystem
+	LogFile           string         // file used by the *json logger, empty for stdout
+	TracesExporter    string         // exporter for the trace spans <""|*file|*http>, empty disables the tracing
+	TracesEndpoint    string         // file path or collector URL where the spans are exported
+	RoundingDecimals  int            // Number of decimals to round end prices at
+	DBDataEncoding    string         // The encoding used to store object data in strings: <msgpack|json>
+	TpExportPath      string         // Path towards export folder for offline Tariff Plans
+	PosterAttempts    int            // Time to wait before writing the failed posts in a single file
+	FailedPostsDir    string         // Directory path where we store failed http requests
+	FailedPostsTTL    time.Duration  // Directory path where we store failed http requests
+	DefaultReqType    string         // Use this request type if not defined on top
+	DefaultCategory   string         // set default type of record
+	DefaultTenant     string         // set default tenant
+	DefaultTimezone   string         // default timezone for timestamps where not specified <""|UTC|Local|$IANA_TZ_DB>
+	DefaultCaching    string
+	ConnectAttempts   int           // number of initial connection attempts before giving up
+	Reconnects        int           // number of recconect attempts in case of connection lost <-1 for infinite | nb>
+	ConnectTimeout    time.Duration // timeout for RPC connection attempts
+	ReplyTimeout      time.Duration // timeout replies if not reaching back
+	LockingTimeout    time.Duration // locking mechanism timeout to avoid deadlocks
+	HitsStoreInterval time.Duration // interval to store the profile hit counters, 0 disables the counting and -1 stores on each hit
+	DigestSeparator   string        //
+	DigestEqual       string        //
+	RSRSep            string        // separator used to split RSRParser (by default is used ";")
+	MaxParallelConns  int           // the maximum number of connection used by the *parallel strategy
 }
 
 // loadFromJSONCfg loads General config from JsonCfg
@@ -66,6 +71,21 @@ func (gencfg *GeneralCfg) loadFromJSONCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 	if jsnGeneralCfg.Log_level != nil {
 		gencfg.LogLevel = *jsnGeneralCfg.Log_level
 	}
+	if jsnGeneralCfg.Log_levels != nil {
+		gencfg.LogLevels = make(map[string]int)
+		for subsys, lvl := range jsnGeneralCfg.Log_levels {
+			gencfg.LogLevels[subsys] = lvl
+		}
+	}
+	if jsnGeneralCfg.Log_file != nil {
+		gencfg.LogFile = *jsnGeneralCfg.Log_file
+	}
+	if jsnGeneralCfg.Traces_exporter != nil {
+		gencfg.TracesExporter = *jsnGeneralCfg.Traces_exporter
+	}
+	if jsnGeneralCfg.Traces_endpoint != nil {
+		gencfg.TracesEndpoint = *jsnGeneralCfg.Traces_endpoint
+	}
 
 	if jsnGeneralCfg.Dbdata_encoding != nil {
 		gencfg.DBDataEncoding = strings.TrimPrefix(*jsnGeneralCfg.Dbdata_encoding, "*")
@@ -123,6 +143,11 @@ func (gencfg *GeneralCfg) loadFromJSONCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 			return err
 		}
 	}
+	if jsnGeneralCfg.Hits_store_interval != nil {
+		if gencfg.HitsStoreInterval, err = utils.ParseDurationWithNanosecs(*jsnGeneralCfg.Hits_store_interval); err != nil {
+			return err
+		}
+	}
 	if jsnGeneralCfg.Digest_separator != nil {
 		gencfg.DigestSeparator = *jsnGeneralCfg.Digest_separator
 	}
@@ -142,35 +167,49 @@ func (gencfg *GeneralCfg) loadFromJSONCfg(jsnGeneralCfg *GeneralJsonCfg) (err er
 // AsMapInterface returns the config as a map[string]interface{}
 func (gencfg *GeneralCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.NodeIDCfg:           gencfg.NodeID,
-		utils.LoggerCfg:           gencfg.Logger,
-		utils.LogLevelCfg:         gencfg.LogLevel,
-		utils.RoundingDecimalsCfg: gencfg.RoundingDecimals,
-		utils.DBDataEncodingCfg:   utils.Meta + gencfg.DBDataEncoding,
-		utils.TpExportPathCfg:     gencfg.TpExportPath,
-		utils.PosterAttemptsCfg:   gencfg.PosterAttempts,
-		utils.FailedPostsDirCfg:   gencfg.FailedPostsDir,
-		utils.DefaultReqTypeCfg:   gencfg.DefaultReqType,
-		utils.DefaultCategoryCfg:  gencfg.DefaultCategory,
-		utils.DefaultTenantCfg:    gencfg.DefaultTenant,
-		utils.DefaultTimezoneCfg:  gencfg.DefaultTimezone,
-		utils.DefaultCachingCfg:   gencfg.DefaultCaching,
-		utils.ConnectAttemptsCfg:  gencfg.ConnectAttempts,
-		utils.ReconnectsCfg:       gencfg.Reconnects,
-		utils.DigestSeparatorCfg:  gencfg.DigestSeparator,
-		utils.DigestEqualCfg:      gencfg.DigestEqual,
-		utils.RSRSepCfg:           gencfg.RSRSep,
-		utils.MaxParallelConnsCfg: gencfg.MaxParallelConns,
-		utils.LockingTimeoutCfg:   "0",
-		utils.FailedPostsTTLCfg:   "0",
-		utils.ConnectTimeoutCfg:   "0",
-		utils.ReplyTimeoutCfg:     "0",
+		utils.NodeIDCfg:            gencfg.NodeID,
+		utils.LoggerCfg:            gencfg.Logger,
+		utils.LogLevelCfg:          gencfg.LogLevel,
+		utils.LogFileCfg:           gencfg.LogFile,
+		utils.TracesExporterCfg:    gencfg.TracesExporter,
+		utils.TracesEndpointCfg:    gencfg.TracesEndpoint,
+		utils.RoundingDecimalsCfg:  gencfg.RoundingDecimals,
+		utils.DBDataEncodingCfg:    utils.Meta + gencfg.DBDataEncoding,
+		utils.TpExportPathCfg:      gencfg.TpExportPath,
+		utils.PosterAttemptsCfg:    gencfg.PosterAttempts,
+		utils.FailedPostsDirCfg:    gencfg.FailedPostsDir,
+		utils.DefaultReqTypeCfg:    gencfg.DefaultReqType,
+		utils.DefaultCategoryCfg:   gencfg.DefaultCategory,
+		utils.DefaultTenantCfg:     gencfg.DefaultTenant,
+		utils.DefaultTimezoneCfg:   gencfg.DefaultTimezone,
+		utils.DefaultCachingCfg:    gencfg.DefaultCaching,
+		utils.ConnectAttemptsCfg:   gencfg.ConnectAttempts,
+		utils.ReconnectsCfg:        gencfg.Reconnects,
+		utils.DigestSeparatorCfg:   gencfg.DigestSeparator,
+		utils.DigestEqualCfg:       gencfg.DigestEqual,
+		utils.RSRSepCfg:            gencfg.RSRSep,
+		utils.MaxParallelConnsCfg:  gencfg.MaxParallelConns,
+		utils.LockingTimeoutCfg:    "0",
+		utils.HitsStoreIntervalCfg: "0",
+		utils.FailedPostsTTLCfg:    "0",
+		utils.ConnectTimeoutCfg:    "0",
+		utils.ReplyTimeoutCfg:      "0",
 	}
 
+	logLevels := make(map[string]interface{})
+	for subsys, lvl := range gencfg.LogLevels {
+		logLevels[subsys] = lvl
+	}
+	initialMP[utils.LogLevelsCfg] = logLevels
+
 	if gencfg.LockingTimeout != 0 {
 		initialMP[utils.LockingTimeoutCfg] = gencfg.LockingTimeout.String()
 	}
 
+	if gencfg.HitsStoreInterval != 0 {
+		initialMP[utils.HitsStoreIntervalCfg] = gencfg.HitsStoreInterval.String()
+	}
+
 	if gencfg.FailedPostsTTL != 0 {
 		initialMP[utils.FailedPostsTTLCfg] = gencfg.FailedPostsTTL.String()
 	}
@@ -187,30 +226,41 @@ func (gencfg *GeneralCfg) AsMapInterface() (initialMP map[string]interface{}) {
 }
 
 // Clone returns a deep copy of GeneralCfg
-func (gencfg GeneralCfg) Clone() *GeneralCfg {
-	return &GeneralCfg{
-		NodeID:           gencfg.NodeID,
-		Logger:           gencfg.Logger,
-		LogLevel:         gencfg.LogLevel,
-		RoundingDecimals: gencfg.RoundingDecimals,
-		DBDataEncoding:   gencfg.DBDataEncoding,
-		TpExportPath:     gencfg.TpExportPath,
-		PosterAttempts:   gencfg.PosterAttempts,
-		FailedPostsDir:   gencfg.FailedPostsDir,
-		FailedPostsTTL:   gencfg.FailedPostsTTL,
-		DefaultReqType:   gencfg.DefaultReqType,
-		DefaultCategory:  gencfg.DefaultCategory,
-		DefaultTenant:    gencfg.DefaultTenant,
-		DefaultTimezone:  gencfg.DefaultTimezone,
-		DefaultCaching:   gencfg.DefaultCaching,
-		ConnectAttempts:  gencfg.ConnectAttempts,
-		Reconnects:       gencfg.Reconnects,
-		ConnectTimeout:   gencfg.ConnectTimeout,
-		ReplyTimeout:     gencfg.ReplyTimeout,
-		LockingTimeout:   gencfg.LockingTimeout,
-		DigestSeparator:  gencfg.DigestSeparator,
-		DigestEqual:      gencfg.DigestEqual,
-		RSRSep:           gencfg.RSRSep,
-		MaxParallelConns: gencfg.MaxParallelConns,
+func (gencfg GeneralCfg) Clone() (cln *GeneralCfg) {
+	cln = &GeneralCfg{
+		NodeID:            gencfg.NodeID,
+		Logger:            gencfg.Logger,
+		LogLevel:          gencfg.LogLevel,
+		LogFile:           gencfg.LogFile,
+		TracesExporter:    gencfg.TracesExporter,
+		TracesEndpoint:    gencfg.TracesEndpoint,
+		RoundingDecimals:  gencfg.RoundingDecimals,
+		DBDataEncoding:    gencfg.DBDataEncoding,
+		TpExportPath:      gencfg.TpExportPath,
+		PosterAttempts:    gencfg.PosterAttempts,
+		FailedPostsDir:    gencfg.FailedPostsDir,
+		FailedPostsTTL:    gencfg.FailedPostsTTL,
+		DefaultReqType:    gencfg.DefaultReqType,
+		DefaultCategory:   gencfg.DefaultCategory,
+		DefaultTenant:     gencfg.DefaultTenant,
+		DefaultTimezone:   gencfg.DefaultTimezone,
+		DefaultCaching:    gencfg.DefaultCaching,
+		ConnectAttempts:   gencfg.ConnectAttempts,
+		Reconnects:        gencfg.Reconnects,
+		ConnectTimeout:    gencfg.ConnectTimeout,
+		ReplyTimeout:      gencfg.ReplyTimeout,
+		LockingTimeout:    gencfg.LockingTimeout,
+		HitsStoreInterval: gencfg.HitsStoreInterval,
+		DigestSeparator:   gencfg.DigestSeparator,
+		DigestEqual:       gencfg.DigestEqual,
+		RSRSep:            gencfg.RSRSep,
+		MaxParallelConns:  gencfg.MaxParallelConns,
 	}
+	if gencfg.LogLevels != nil {
+		cln.LogLevels = make(map[string]int)
+		for subsys, lvl := range gencfg.LogLevels {
+			cln.LogLevels[subsys] = lvl
+		}
+	}
+	return
 }
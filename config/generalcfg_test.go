@@ -30,6 +30,10 @@ func TestGeneralCfgloadFromJsonCfg(t *testing.T) {
 		Node_id:              utils.StringPointer("randomID"),
 		Logger:               utils.StringPointer(utils.MetaSysLog),
 		Log_level:            utils.IntPointer(6),
+		Log_levels:           map[string]int{utils.SessionS: 7},
+		Log_file:             utils.StringPointer("/var/log/cgrates/cgrates.log"),
+		Traces_exporter:      utils.StringPointer(utils.MetaFile),
+		Traces_endpoint:      utils.StringPointer("/var/log/cgrates/traces.json"),
 		Rounding_decimals:    utils.IntPointer(5),
 		Dbdata_encoding:      utils.StringPointer("msgpack"),
 		Tpexport_dir:         utils.StringPointer("/var/spool/cgrates/tpe"),
@@ -44,31 +48,37 @@ func TestGeneralCfgloadFromJsonCfg(t *testing.T) {
 		Digest_separator:     utils.StringPointer(","),
 		Digest_equal:         utils.StringPointer(":"),
 		Failed_posts_ttl:     utils.StringPointer("2"),
+		Hits_store_interval:  utils.StringPointer("1m"),
 	}
 
 	expected := &GeneralCfg{
-		NodeID:           "randomID",
-		Logger:           utils.MetaSysLog,
-		LogLevel:         6,
-		RoundingDecimals: 5,
-		DBDataEncoding:   "msgpack",
-		TpExportPath:     "/var/spool/cgrates/tpe",
-		PosterAttempts:   3,
-		FailedPostsDir:   "/var/spool/cgrates/failed_posts",
-		DefaultReqType:   utils.MetaRated,
-		DefaultCategory:  utils.Call,
-		DefaultTenant:    "cgrates.org",
-		DefaultTimezone:  "Local",
-		ConnectAttempts:  3,
-		Reconnects:       -1,
-		ConnectTimeout:   time.Second,
-		ReplyTimeout:     2 * time.Second,
-		DigestSeparator:  ",",
-		DigestEqual:      ":",
-		MaxParallelConns: 100,
-		RSRSep:           ";",
-		DefaultCaching:   utils.MetaReload,
-		FailedPostsTTL:   2,
+		NodeID:            "randomID",
+		Logger:            utils.MetaSysLog,
+		LogLevel:          6,
+		LogLevels:         map[string]int{utils.SessionS: 7},
+		LogFile:           "/var/log/cgrates/cgrates.log",
+		TracesExporter:    utils.MetaFile,
+		TracesEndpoint:    "/var/log/cgrates/traces.json",
+		RoundingDecimals:  5,
+		DBDataEncoding:    "msgpack",
+		TpExportPath:      "/var/spool/cgrates/tpe",
+		PosterAttempts:    3,
+		FailedPostsDir:    "/var/spool/cgrates/failed_posts",
+		DefaultReqType:    utils.MetaRated,
+		DefaultCategory:   utils.Call,
+		DefaultTenant:     "cgrates.org",
+		DefaultTimezone:   "Local",
+		ConnectAttempts:   3,
+		Reconnects:        -1,
+		ConnectTimeout:    time.Second,
+		ReplyTimeout:      2 * time.Second,
+		DigestSeparator:   ",",
+		DigestEqual:       ":",
+		MaxParallelConns:  100,
+		RSRSep:            ";",
+		DefaultCaching:    utils.MetaReload,
+		FailedPostsTTL:    2,
+		HitsStoreInterval: time.Minute,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.generalCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -112,6 +122,14 @@ func TestGeneralParseDurationCfgloadFromJsonCfg(t *testing.T) {
 		t.Errorf("Expected %+v, received %v", expected, err)
 	}
 
+	cfgJSON4 := &GeneralJsonCfg{
+		Hits_store_interval: utils.StringPointer("1ss"),
+	}
+	jsonCfg = NewDefaultCGRConfig()
+	if err = jsonCfg.generalCfg.loadFromJSONCfg(cfgJSON4); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %v", expected, err)
+	}
+
 }
 
 func TestGeneralCfgAsMapInterface(t *testing.T) {
@@ -120,6 +138,7 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 			"node_id": "cgrates",											
 			"logger":"*syslog",										
 			"log_level": 6,											
+			"log_levels": {"SessionS": 7},
 			"rounding_decimals": 5,									
 			"dbdata_encoding": "*msgpack",							
 			"tpexport_dir": "/var/spool/cgrates/tpe",				
@@ -136,6 +155,7 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 			"connect_timeout": "1s",								
 			"reply_timeout": "2s",									
 			"locking_timeout": "1s",									
+			"hits_store_interval": "-1",
 			"digest_separator": ",",								
 			"digest_equal": ":",									
 			"rsr_separator": ";",									
@@ -143,29 +163,34 @@ func TestGeneralCfgAsMapInterface(t *testing.T) {
 		},
 	}`
 	eMap := map[string]interface{}{
-		utils.NodeIDCfg:           "cgrates",
-		utils.LoggerCfg:           "*syslog",
-		utils.LogLevelCfg:         6,
-		utils.RoundingDecimalsCfg: 5,
-		utils.DBDataEncodingCfg:   "*msgpack",
-		utils.TpExportPathCfg:     "/var/spool/cgrates/tpe",
-		utils.PosterAttemptsCfg:   3,
-		utils.FailedPostsDirCfg:   "/var/spool/cgrates/failed_posts",
-		utils.FailedPostsTTLCfg:   "5s",
-		utils.DefaultReqTypeCfg:   "*rated",
-		utils.DefaultCategoryCfg:  "call",
-		utils.DefaultTenantCfg:    "cgrates.org",
-		utils.DefaultTimezoneCfg:  "Local",
-		utils.DefaultCachingCfg:   "*reload",
-		utils.ConnectAttemptsCfg:  5,
-		utils.ReconnectsCfg:       -1,
-		utils.ConnectTimeoutCfg:   "1s",
-		utils.ReplyTimeoutCfg:     "2s",
-		utils.LockingTimeoutCfg:   "1s",
-		utils.DigestSeparatorCfg:  ",",
-		utils.DigestEqualCfg:      ":",
-		utils.RSRSepCfg:           ";",
-		utils.MaxParallelConnsCfg: 100,
+		utils.NodeIDCfg:            "cgrates",
+		utils.LoggerCfg:            "*syslog",
+		utils.LogLevelCfg:          6,
+		utils.LogLevelsCfg:         map[string]interface{}{utils.SessionS: 7},
+		utils.LogFileCfg:           "",
+		utils.TracesExporterCfg:    "",
+		utils.TracesEndpointCfg:    "",
+		utils.RoundingDecimalsCfg:  5,
+		utils.DBDataEncodingCfg:    "*msgpack",
+		utils.TpExportPathCfg:      "/var/spool/cgrates/tpe",
+		utils.PosterAttemptsCfg:    3,
+		utils.FailedPostsDirCfg:    "/var/spool/cgrates/failed_posts",
+		utils.FailedPostsTTLCfg:    "5s",
+		utils.DefaultReqTypeCfg:    "*rated",
+		utils.DefaultCategoryCfg:   "call",
+		utils.DefaultTenantCfg:     "cgrates.org",
+		utils.DefaultTimezoneCfg:   "Local",
+		utils.DefaultCachingCfg:    "*reload",
+		utils.ConnectAttemptsCfg:   5,
+		utils.ReconnectsCfg:        -1,
+		utils.ConnectTimeoutCfg:    "1s",
+		utils.ReplyTimeoutCfg:      "2s",
+		utils.LockingTimeoutCfg:    "1s",
+		utils.HitsStoreIntervalCfg: "-1ns",
+		utils.DigestSeparatorCfg:   ",",
+		utils.DigestEqualCfg:       ":",
+		utils.RSRSepCfg:            ";",
+		utils.MaxParallelConnsCfg:  100,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -186,29 +211,34 @@ func TestGeneralCfgAsMapInterface1(t *testing.T) {
         }
 }`
 	eMap := map[string]interface{}{
-		utils.NodeIDCfg:           "ENGINE1",
-		utils.LoggerCfg:           "*syslog",
-		utils.LogLevelCfg:         6,
-		utils.RoundingDecimalsCfg: 5,
-		utils.DBDataEncodingCfg:   "*msgpack",
-		utils.TpExportPathCfg:     "/var/spool/cgrates/tpe",
-		utils.PosterAttemptsCfg:   3,
-		utils.FailedPostsDirCfg:   "/var/spool/cgrates/failed_posts",
-		utils.FailedPostsTTLCfg:   "0",
-		utils.DefaultReqTypeCfg:   "*rated",
-		utils.DefaultCategoryCfg:  "call",
-		utils.DefaultTenantCfg:    "cgrates.org",
-		utils.DefaultTimezoneCfg:  "Local",
-		utils.DefaultCachingCfg:   "*reload",
-		utils.ConnectAttemptsCfg:  5,
-		utils.ReconnectsCfg:       -1,
-		utils.ConnectTimeoutCfg:   "0",
-		utils.ReplyTimeoutCfg:     "0",
-		utils.LockingTimeoutCfg:   "0",
-		utils.DigestSeparatorCfg:  ",",
-		utils.DigestEqualCfg:      ":",
-		utils.RSRSepCfg:           ";",
-		utils.MaxParallelConnsCfg: 100,
+		utils.NodeIDCfg:            "ENGINE1",
+		utils.LoggerCfg:            "*syslog",
+		utils.LogLevelCfg:          6,
+		utils.LogLevelsCfg:         map[string]interface{}{},
+		utils.LogFileCfg:           "",
+		utils.TracesExporterCfg:    "",
+		utils.TracesEndpointCfg:    "",
+		utils.RoundingDecimalsCfg:  5,
+		utils.DBDataEncodingCfg:    "*msgpack",
+		utils.TpExportPathCfg:      "/var/spool/cgrates/tpe",
+		utils.PosterAttemptsCfg:    3,
+		utils.FailedPostsDirCfg:    "/var/spool/cgrates/failed_posts",
+		utils.FailedPostsTTLCfg:    "0",
+		utils.DefaultReqTypeCfg:    "*rated",
+		utils.DefaultCategoryCfg:   "call",
+		utils.DefaultTenantCfg:     "cgrates.org",
+		utils.DefaultTimezoneCfg:   "Local",
+		utils.DefaultCachingCfg:    "*reload",
+		utils.ConnectAttemptsCfg:   5,
+		utils.ReconnectsCfg:        -1,
+		utils.ConnectTimeoutCfg:    "0",
+		utils.ReplyTimeoutCfg:      "0",
+		utils.LockingTimeoutCfg:    "0",
+		utils.HitsStoreIntervalCfg: "0",
+		utils.DigestSeparatorCfg:   ",",
+		utils.DigestEqualCfg:       ":",
+		utils.RSRSepCfg:            ";",
+		utils.MaxParallelConnsCfg:  100,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -219,28 +249,30 @@ func TestGeneralCfgAsMapInterface1(t *testing.T) {
 
 func TestGeneralCfgClone(t *testing.T) {
 	ban := &GeneralCfg{
-		NodeID:           "randomID",
-		Logger:           utils.MetaSysLog,
-		LogLevel:         6,
-		RoundingDecimals: 5,
-		DBDataEncoding:   "msgpack",
-		TpExportPath:     "/var/spool/cgrates/tpe",
-		PosterAttempts:   3,
-		FailedPostsDir:   "/var/spool/cgrates/failed_posts",
-		DefaultReqType:   utils.MetaRated,
-		DefaultCategory:  utils.Call,
-		DefaultTenant:    "cgrates.org",
-		DefaultTimezone:  "Local",
-		ConnectAttempts:  3,
-		Reconnects:       -1,
-		ConnectTimeout:   time.Second,
-		ReplyTimeout:     2 * time.Second,
-		DigestSeparator:  ",",
-		DigestEqual:      ":",
-		MaxParallelConns: 100,
-		RSRSep:           ";",
-		DefaultCaching:   utils.MetaReload,
-		FailedPostsTTL:   2,
+		NodeID:            "randomID",
+		Logger:            utils.MetaSysLog,
+		LogLevel:          6,
+		LogLevels:         map[string]int{utils.SessionS: 7},
+		RoundingDecimals:  5,
+		DBDataEncoding:    "msgpack",
+		TpExportPath:      "/var/spool/cgrates/tpe",
+		PosterAttempts:    3,
+		FailedPostsDir:    "/var/spool/cgrates/failed_posts",
+		DefaultReqType:    utils.MetaRated,
+		DefaultCategory:   utils.Call,
+		DefaultTenant:     "cgrates.org",
+		DefaultTimezone:   "Local",
+		ConnectAttempts:   3,
+		Reconnects:        -1,
+		ConnectTimeout:    time.Second,
+		ReplyTimeout:      2 * time.Second,
+		DigestSeparator:   ",",
+		DigestEqual:       ":",
+		MaxParallelConns:  100,
+		RSRSep:            ";",
+		DefaultCaching:    utils.MetaReload,
+		FailedPostsTTL:    2,
+		HitsStoreInterval: time.Minute,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -249,4 +281,7 @@ func TestGeneralCfgClone(t *testing.T) {
 	if rcv.NodeID = ""; ban.NodeID != "randomID" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.LogLevels[utils.SessionS] = 6; ban.LogLevels[utils.SessionS] != 7 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
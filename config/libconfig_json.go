@@ -27,6 +27,10 @@ type GeneralJsonCfg struct {
 	Node_id              *string
 	Logger               *string
 	Log_level            *int
+	Log_levels           map[string]int
+	Log_file             *string
+	Traces_exporter      *string
+	Traces_endpoint      *string
 	Rounding_decimals    *int
 	Dbdata_encoding      *string
 	Tpexport_dir         *string
@@ -43,6 +47,7 @@ type GeneralJsonCfg struct {
 	Connect_timeout      *string
 	Reply_timeout        *string
 	Locking_timeout      *string
+	Hits_store_interval  *string
 	Digest_separator     *string
 	Digest_equal         *string
 	Rsr_separator        *string
@@ -131,11 +136,12 @@ type RalsJsonCfg struct {
 
 // Scheduler config section
 type SchedulerJsonCfg struct {
-	Enabled          *bool
-	Cdrs_conns       *[]string
-	Thresholds_conns *[]string
-	Stats_conns      *[]string
-	Filters          *[]string
+	Enabled              *bool
+	Cdrs_conns           *[]string
+	Thresholds_conns     *[]string
+	Stats_conns          *[]string
+	Diameter_agent_conns *[]string
+	Filters              *[]string
 }
 
 // Cdrs config section
@@ -333,21 +339,48 @@ type OsipsConnJsonCfg struct {
 
 // DiameterAgent configuration
 type DiameterAgentJsonCfg struct {
-	Enabled              *bool
-	Listen               *string
-	Listen_net           *string
-	Dictionaries_path    *string
-	Sessions_conns       *[]string
-	Origin_host          *string
-	Origin_realm         *string
-	Vendor_id            *int
-	Product_name         *string
-	Concurrent_requests  *int
-	Synced_conn_requests *bool
-	Asr_template         *string
-	Rar_template         *string
-	Forced_disconnect    *string
-	Request_processors   *[]*ReqProcessorJsnCfg
+	Enabled                  *bool
+	Listen                   *string
+	Listen_net               *string
+	Dictionaries_path        *string
+	Sessions_conns           *[]string
+	Origin_host              *string
+	Origin_realm             *string
+	Vendor_id                *int
+	Product_name             *string
+	Concurrent_requests      *int
+	Synced_conn_requests     *bool
+	Asr_template             *string
+	Rar_template             *string
+	Forced_disconnect        *string
+	Watchdog_interval        *string
+	Reconnect_interval       *string
+	Max_reconnect_interval   *string
+	Peers                    *[]*DiameterPeerJsonCfg
+	Policy_counters_interval *string
+	Policy_counters          *[]*DiameterPolicyCounterJsonCfg
+	Request_processors       *[]*ReqProcessorJsnCfg
+}
+
+// DiameterPeerJsonCfg is an outgoing peer of the Diameter Agent
+type DiameterPeerJsonCfg struct {
+	Id                   *string
+	Address              *string
+	Network              *string
+	Auth_application_ids *[]int
+}
+
+// DiameterPolicyCounterJsonCfg is a Sy policy counter reported by the Diameter Agent
+type DiameterPolicyCounterJsonCfg struct {
+	Id             *string
+	Default_status *string
+	Statuses       *[]*DiameterCounterStatusJsonCfg
+}
+
+// DiameterCounterStatusJsonCfg is one of the statuses of a policy counter
+type DiameterCounterStatusJsonCfg struct {
+	Status  *string
+	Filters *[]string
 }
 
 // Radius Agent configuration section
@@ -358,7 +391,10 @@ type RadiusAgentJsonCfg struct {
 	Listen_acct         *string
 	Client_secrets      *map[string]string
 	Client_dictionaries *map[string]string
+	Client_da_addresses *map[string]string
 	Sessions_conns      *[]string
+	Dmr_template        *string
+	Coa_template        *string
 	Timezone            *string
 	Request_processors  *[]*ReqProcessorJsnCfg
 }
@@ -466,6 +502,7 @@ type RouteSJsonCfg struct {
 	Resources_conns       *[]string
 	Stats_conns           *[]string
 	Rals_conns            *[]string
+	Rates_conns           *[]string
 	Default_ratio         *int
 }
 
@@ -483,10 +520,13 @@ type LoaderJsonCfg struct {
 	Dry_run         *bool
 	Run_delay       *string
 	Lock_filename   *string
+	Atomic          *bool
+	Versions_limit  *int
 	Caches_conns    *[]string
 	Field_separator *string
 	Tp_in_dir       *string
 	Tp_out_dir      *string
+	Opts            map[string]interface{}
 	Data            *[]*LoaderJsonDataType
 }
 
@@ -639,6 +679,7 @@ type RateSJsonCfg struct {
 	Rate_suffix_indexed_fields *[]string
 	Rate_nested_fields         *bool // applies when indexed fields is not defined
 	Verbosity                  *int
+	Profile_versions_limit     *int
 }
 
 // SIPAgentJsonCfg
@@ -653,9 +694,13 @@ type SIPAgentJsonCfg struct {
 }
 
 type ConfigSCfgJson struct {
-	Enabled  *bool
-	Url      *string
-	Root_dir *string
+	Enabled              *bool
+	Url                  *string
+	Root_dir             *string
+	History_dir          *string
+	History_limit        *int
+	Load_from_datadb     *bool
+	Datadb_sync_interval *string
 }
 
 type APIBanJsonCfg struct {
@@ -688,15 +733,26 @@ type ActionSJsonCfg struct {
 
 // Account service config section
 type AccountSJsonCfg struct {
+	Enabled                   *bool
+	Indexed_selects           *bool
+	Attributes_conns          *[]string
+	Rates_conns               *[]string
+	Thresholds_conns          *[]string
+	String_indexed_fields     *[]string
+	Prefix_indexed_fields     *[]string
+	Suffix_indexed_fields     *[]string
+	Nested_fields             *bool // applies when indexed fields is not defined
+	Max_iterations            *int
+	Max_usage                 *string
+	Exchange_rate_profile_ids *[]string
+}
+
+// Tax calculation config section
+type TaxSJsonCfg struct {
 	Enabled               *bool
 	Indexed_selects       *bool
-	Attributes_conns      *[]string
-	Rates_conns           *[]string
-	Thresholds_conns      *[]string
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
-	Max_iterations        *int
-	Max_usage             *string
 }
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -72,13 +73,30 @@ type LoaderSCfg struct {
 	DryRun         bool
 	RunDelay       time.Duration
 	LockFileName   string
+	Atomic         bool
+	VersionsLimit  int
 	CacheSConns    []string
 	FieldSeparator string
 	TpInDir        string
 	TpOutDir       string
+	Opts           map[string]interface{}
 	Data           []*LoaderDataType
 }
 
+// loaderURLScheme returns the scheme if the path points to a S3, SFTP or HTTP source
+// or empty string for a local folder
+func loaderURLScheme(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return utils.EmptyString
+	}
+	switch u.Scheme {
+	case utils.S3Scheme, utils.SFTPScheme, utils.HTTPScheme, utils.HTTPSScheme:
+		return u.Scheme
+	}
+	return utils.EmptyString
+}
+
 // LoaderDataType the template for profile loading
 type LoaderDataType struct {
 	Type     string
@@ -139,6 +157,12 @@ func (l *LoaderSCfg) loadFromJSONCfg(jsnCfg *LoaderJsonCfg, msgTemplates map[str
 	if jsnCfg.Lock_filename != nil {
 		l.LockFileName = *jsnCfg.Lock_filename
 	}
+	if jsnCfg.Atomic != nil {
+		l.Atomic = *jsnCfg.Atomic
+	}
+	if jsnCfg.Versions_limit != nil {
+		l.VersionsLimit = *jsnCfg.Versions_limit
+	}
 	if jsnCfg.Caches_conns != nil {
 		l.CacheSConns = make([]string, len(*jsnCfg.Caches_conns))
 		for idx, connID := range *jsnCfg.Caches_conns {
@@ -159,6 +183,14 @@ func (l *LoaderSCfg) loadFromJSONCfg(jsnCfg *LoaderJsonCfg, msgTemplates map[str
 	if jsnCfg.Tp_out_dir != nil {
 		l.TpOutDir = *jsnCfg.Tp_out_dir
 	}
+	if jsnCfg.Opts != nil {
+		if l.Opts == nil {
+			l.Opts = make(map[string]interface{})
+		}
+		for k, v := range jsnCfg.Opts {
+			l.Opts[k] = v
+		}
+	}
 	if jsnCfg.Data != nil {
 		data := make([]*LoaderDataType, len(*jsnCfg.Data))
 		for idx, jsnLoCfg := range *jsnCfg.Data {
@@ -196,12 +228,20 @@ func (l LoaderSCfg) Clone() (cln *LoaderSCfg) {
 		DryRun:         l.DryRun,
 		RunDelay:       l.RunDelay,
 		LockFileName:   l.LockFileName,
+		Atomic:         l.Atomic,
+		VersionsLimit:  l.VersionsLimit,
 		CacheSConns:    make([]string, len(l.CacheSConns)),
 		FieldSeparator: l.FieldSeparator,
 		TpInDir:        l.TpInDir,
 		TpOutDir:       l.TpOutDir,
 		Data:           make([]*LoaderDataType, len(l.Data)),
 	}
+	if l.Opts != nil {
+		cln.Opts = make(map[string]interface{})
+		for k, v := range l.Opts {
+			cln.Opts[k] = v
+		}
+	}
 	for idx, connID := range l.CacheSConns {
 		cln.CacheSConns[idx] = connID
 	}
@@ -230,15 +270,17 @@ func (lData *LoaderDataType) AsMapInterface(separator string) (initialMP map[str
 // AsMapInterface returns the config as a map[string]interface{}
 func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.IDCfg:           l.ID,
-		utils.TenantCfg:       l.Tenant.GetRule(separator),
-		utils.EnabledCfg:      l.Enabled,
-		utils.DryRunCfg:       l.DryRun,
-		utils.LockFileNameCfg: l.LockFileName,
-		utils.FieldSepCfg:     l.FieldSeparator,
-		utils.TpInDirCfg:      l.TpInDir,
-		utils.TpOutDirCfg:     l.TpOutDir,
-		utils.RunDelayCfg:     "0",
+		utils.IDCfg:            l.ID,
+		utils.TenantCfg:        l.Tenant.GetRule(separator),
+		utils.EnabledCfg:       l.Enabled,
+		utils.DryRunCfg:        l.DryRun,
+		utils.LockFileNameCfg:  l.LockFileName,
+		utils.AtomicCfg:        l.Atomic,
+		utils.VersionsLimitCfg: l.VersionsLimit,
+		utils.FieldSepCfg:      l.FieldSeparator,
+		utils.TpInDirCfg:       l.TpInDir,
+		utils.TpOutDirCfg:      l.TpOutDir,
+		utils.RunDelayCfg:      "0",
 	}
 	if l.Data != nil {
 		data := make([]map[string]interface{}, len(l.Data))
@@ -250,6 +292,11 @@ func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]inte
 	if l.RunDelay != 0 {
 		initialMP[utils.RunDelayCfg] = l.RunDelay.String()
 	}
+	opts := make(map[string]interface{})
+	for k, v := range l.Opts {
+		opts[k] = v
+	}
+	initialMP[utils.OptsCfg] = opts
 	if l.CacheSConns != nil {
 		cacheSConns := make([]string, len(l.CacheSConns))
 		for i, item := range l.CacheSConns {
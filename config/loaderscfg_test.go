@@ -64,10 +64,12 @@ func TestLoaderSCfgloadFromJsonCfgCase1(t *testing.T) {
 			ID:             utils.MetaDefault,
 			Tenant:         ten,
 			LockFileName:   ".cgr.lck",
+			VersionsLimit:  3,
 			CacheSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches), "*conn1"},
 			FieldSeparator: ",",
 			TpInDir:        "/var/spool/cgrates/loader/in",
 			TpOutDir:       "/var/spool/cgrates/loader/out",
+			Opts:           map[string]interface{}{},
 			Data: []*LoaderDataType{
 				{
 					Type:     "*attributes",
@@ -34,7 +34,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		Listen_acct:         utils.StringPointer("127.0.0.1:1813"),
 		Client_secrets:      &map[string]string{utils.MetaDefault: "CGRateS.org"},
 		Client_dictionaries: &map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		Sessions_conns:      &[]string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		Client_da_addresses: &map[string]string{"192.168.56.203": "192.168.56.203:1700"},
+		Sessions_conns:      &[]string{rpcclient.BiRPCInternal},
+		Dmr_template:        utils.StringPointer(utils.MetaDMR),
+		Coa_template:        utils.StringPointer(utils.MetaCoA),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -63,7 +66,10 @@ func TestRadiusAgentCfgloadFromJsonCfgCase1(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
-		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		ClientDAAddresses:  map[string]string{"192.168.56.203": "192.168.56.203:1700"},
+		SessionSConns:      []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS)},
+		DMRTemplate:        utils.MetaDMR,
+		CoATemplate:        utils.MetaCoA,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -155,7 +161,12 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 	     "client_dictionaries": {									
 	    	"*default": "/usr/share/cgrates/",			
 	     },
+	     "client_da_addresses": {
+	    	"192.168.56.203": "192.168.56.203:1700",
+	     },
 	     "sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
+	     "dmr_template": "*dmr",
+	     "coa_template": "*coa",
          "request_processors": [
 			{
 				"id": "OutboundAUTHDryRun",
@@ -181,7 +192,12 @@ func TestRadiusAgentCfgAsMapInterface(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/",
 		},
+		utils.ClientDAAddressesCfg: map[string]string{
+			"192.168.56.203": "192.168.56.203:1700",
+		},
 		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.DMRTemplateCfg:   utils.MetaDMR,
+		utils.CoATemplateCfg:   utils.MetaCoA,
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -218,7 +234,10 @@ func TestRadiusAgentCfgAsMapInterface1(t *testing.T) {
 		utils.ClientDictionariesCfg: map[string]string{
 			utils.MetaDefault: "/usr/share/cgrates/radius/dict/",
 		},
-		utils.SessionSConnsCfg:     []string{"*internal"},
+		utils.ClientDAAddressesCfg: map[string]string{},
+		utils.SessionSConnsCfg:     []string{utils.MetaInternal},
+		utils.DMRTemplateCfg:       utils.EmptyString,
+		utils.CoATemplateCfg:       utils.EmptyString,
 		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
@@ -236,7 +255,10 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 		ListenAcct:         "127.0.0.1:1813",
 		ClientSecrets:      map[string]string{utils.MetaDefault: "CGRateS.org"},
 		ClientDictionaries: map[string]string{utils.MetaDefault: "/usr/share/cgrates/radius/dict/"},
+		ClientDAAddresses:  map[string]string{"192.168.56.203": "192.168.56.203:1700"},
 		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		DMRTemplate:        utils.MetaDMR,
+		CoATemplate:        utils.MetaCoA,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -274,4 +296,7 @@ func TestRadiusAgentCfgClone(t *testing.T) {
 	if rcv.ClientDictionaries[utils.MetaDefault] = ""; ban.ClientDictionaries[utils.MetaDefault] != "/usr/share/cgrates/radius/dict/" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.ClientDAAddresses["192.168.56.203"] = ""; ban.ClientDAAddresses["192.168.56.203"] != "192.168.56.203:1700" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
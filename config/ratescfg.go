@@ -36,6 +36,7 @@ type RateSCfg struct {
 	RateSuffixIndexedFields *[]string
 	RateNestedFields        bool
 	Verbosity               int
+	ProfileVersionsLimit    int // number of effective versions kept in the history of a rate profile, -1 for unlimited
 }
 
 func (rCfg *RateSCfg) loadFromJSONCfg(jsnCfg *RateSJsonCfg) (err error) {
@@ -103,18 +104,22 @@ func (rCfg *RateSCfg) loadFromJSONCfg(jsnCfg *RateSJsonCfg) (err error) {
 	if jsnCfg.Verbosity != nil {
 		rCfg.Verbosity = *jsnCfg.Verbosity
 	}
+	if jsnCfg.Profile_versions_limit != nil {
+		rCfg.ProfileVersionsLimit = *jsnCfg.Profile_versions_limit
+	}
 	return
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (rCfg *RateSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:            rCfg.Enabled,
-		utils.IndexedSelectsCfg:     rCfg.IndexedSelects,
-		utils.NestedFieldsCfg:       rCfg.NestedFields,
-		utils.RateIndexedSelectsCfg: rCfg.RateIndexedSelects,
-		utils.RateNestedFieldsCfg:   rCfg.RateNestedFields,
-		utils.Verbosity:             rCfg.Verbosity,
+		utils.EnabledCfg:              rCfg.Enabled,
+		utils.IndexedSelectsCfg:       rCfg.IndexedSelects,
+		utils.NestedFieldsCfg:         rCfg.NestedFields,
+		utils.RateIndexedSelectsCfg:   rCfg.RateIndexedSelects,
+		utils.RateNestedFieldsCfg:     rCfg.RateNestedFields,
+		utils.Verbosity:               rCfg.Verbosity,
+		utils.ProfileVersionsLimitCfg: rCfg.ProfileVersionsLimit,
 	}
 	if rCfg.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*rCfg.StringIndexedFields))
@@ -164,12 +169,13 @@ func (rCfg *RateSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 // Clone returns a deep copy of RateSCfg
 func (rCfg RateSCfg) Clone() (cln *RateSCfg) {
 	cln = &RateSCfg{
-		Enabled:            rCfg.Enabled,
-		IndexedSelects:     rCfg.IndexedSelects,
-		NestedFields:       rCfg.NestedFields,
-		RateIndexedSelects: rCfg.RateIndexedSelects,
-		RateNestedFields:   rCfg.RateNestedFields,
-		Verbosity:          rCfg.Verbosity,
+		Enabled:              rCfg.Enabled,
+		IndexedSelects:       rCfg.IndexedSelects,
+		NestedFields:         rCfg.NestedFields,
+		RateIndexedSelects:   rCfg.RateIndexedSelects,
+		RateNestedFields:     rCfg.RateNestedFields,
+		Verbosity:            rCfg.Verbosity,
+		ProfileVersionsLimit: rCfg.ProfileVersionsLimit,
 	}
 	if rCfg.StringIndexedFields != nil {
 		idx := make([]string, len(*rCfg.StringIndexedFields))
@@ -39,6 +39,7 @@ func TestRateSConfigloadFromJsonCfg(t *testing.T) {
 		Rate_suffix_indexed_fields: &[]string{"*req.index1"},
 		Rate_nested_fields:         utils.BoolPointer(true),
 		Verbosity:                  utils.IntPointer(20),
+		Profile_versions_limit:     utils.IntPointer(5),
 	}
 	expected := &RateSCfg{
 		Enabled:                 true,
@@ -53,6 +54,7 @@ func TestRateSConfigloadFromJsonCfg(t *testing.T) {
 		RateSuffixIndexedFields: &[]string{"*req.index1"},
 		RateNestedFields:        true,
 		Verbosity:               20,
+		ProfileVersionsLimit:    5,
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.rateSCfg.loadFromJSONCfg(cfgJSON); err != nil {
@@ -77,6 +79,7 @@ func TestRatesCfgAsMapInterface(t *testing.T) {
 		utils.RateSuffixIndexedFieldsCfg: []string{},
 		utils.RateNestedFieldsCfg:        false,
 		utils.Verbosity:                  1000,
+		utils.ProfileVersionsLimitCfg:    10,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -114,6 +117,7 @@ func TestRatesCfgAsMapInterface1(t *testing.T) {
 		utils.RateSuffixIndexedFieldsCfg: []string{"*req.index1", "*req.index2", "*req.index3"},
 		utils.RateNestedFieldsCfg:        true,
 		utils.Verbosity:                  1000,
+		utils.ProfileVersionsLimitCfg:    10,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -136,6 +140,7 @@ func TestRateSCfgClone(t *testing.T) {
 		RateSuffixIndexedFields: &[]string{"*req.index1"},
 		RateNestedFields:        true,
 		Verbosity:               20,
+		ProfileVersionsLimit:    5,
 	}
 	rcv := sa.Clone()
 	if !reflect.DeepEqual(sa, rcv) {
@@ -33,6 +33,7 @@ type RouteSCfg struct {
 	ResourceSConns      []string
 	StatSConns          []string
 	RALsConns           []string
+	RateSConns          []string
 	DefaultRatio        int
 	NestedFields        bool
 }
@@ -108,6 +109,16 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Rates_conns != nil {
+		rts.RateSConns = make([]string, len(*jsnCfg.Rates_conns))
+		for idx, conn := range *jsnCfg.Rates_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			rts.RateSConns[idx] = conn
+			if conn == utils.MetaInternal {
+				rts.RateSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)
+			}
+		}
+	}
 	if jsnCfg.Default_ratio != nil {
 		rts.DefaultRatio = *jsnCfg.Default_ratio
 	}
@@ -166,6 +177,16 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.RALsConnsCfg] = ralSConns
 	}
+	if rts.RateSConns != nil {
+		rateSConns := make([]string, len(rts.RateSConns))
+		for i, item := range rts.RateSConns {
+			rateSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS) {
+				rateSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.RateSConnsCfg] = rateSConns
+	}
 	if rts.ResourceSConns != nil {
 		resourceSConns := make([]string, len(rts.ResourceSConns))
 		for i, item := range rts.ResourceSConns {
@@ -221,6 +242,12 @@ func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 			cln.RALsConns[i] = con
 		}
 	}
+	if rts.RateSConns != nil {
+		cln.RateSConns = make([]string, len(rts.RateSConns))
+		for i, con := range rts.RateSConns {
+			cln.RateSConns[i] = con
+		}
+	}
 	if rts.StringIndexedFields != nil {
 		idx := make([]string, len(*rts.StringIndexedFields))
 		for i, dx := range *rts.StringIndexedFields {
@@ -24,11 +24,12 @@ import (
 
 // SchedulerCfg the condig section for scheduler
 type SchedulerCfg struct {
-	Enabled      bool
-	CDRsConns    []string
-	ThreshSConns []string
-	StatSConns   []string
-	Filters      []string
+	Enabled            bool
+	CDRsConns          []string
+	ThreshSConns       []string
+	StatSConns         []string
+	DiameterAgentConns []string
+	Filters            []string
 }
 
 func (schdcfg *SchedulerCfg) loadFromJSONCfg(jsnCfg *SchedulerJsonCfg) error {
@@ -74,6 +75,12 @@ func (schdcfg *SchedulerCfg) loadFromJSONCfg(jsnCfg *SchedulerJsonCfg) error {
 			}
 		}
 	}
+	if jsnCfg.Diameter_agent_conns != nil {
+		schdcfg.DiameterAgentConns = make([]string, len(*jsnCfg.Diameter_agent_conns))
+		for idx, connID := range *jsnCfg.Diameter_agent_conns {
+			schdcfg.DiameterAgentConns[idx] = connID
+		}
+	}
 	return nil
 }
 
@@ -113,6 +120,13 @@ func (schdcfg *SchedulerCfg) AsMapInterface() (initialMP map[string]interface{})
 		}
 		initialMP[utils.StatSConnsCfg] = stsConns
 	}
+	if schdcfg.DiameterAgentConns != nil {
+		daConns := make([]string, len(schdcfg.DiameterAgentConns))
+		for i, item := range schdcfg.DiameterAgentConns {
+			daConns[i] = item
+		}
+		initialMP[utils.DiameterAgentConnsCfg] = daConns
+	}
 	return
 }
 
@@ -139,6 +153,12 @@ func (schdcfg SchedulerCfg) Clone() (cln *SchedulerCfg) {
 			cln.StatSConns[i] = con
 		}
 	}
+	if schdcfg.DiameterAgentConns != nil {
+		cln.DiameterAgentConns = make([]string, len(schdcfg.DiameterAgentConns))
+		for i, con := range schdcfg.DiameterAgentConns {
+			cln.DiameterAgentConns[i] = con
+		}
+	}
 	if schdcfg.Filters != nil {
 		cln.Filters = make([]string, len(schdcfg.Filters))
 		for i, con := range schdcfg.Filters {
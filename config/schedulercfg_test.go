@@ -26,18 +26,20 @@ import (
 
 func TestSchedulerCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSONS := &SchedulerJsonCfg{
-		Enabled:          utils.BoolPointer(true),
-		Cdrs_conns:       &[]string{utils.MetaInternal, "*conn1"},
-		Thresholds_conns: &[]string{utils.MetaInternal, "*conn1"},
-		Stats_conns:      &[]string{utils.MetaInternal, "*conn1"},
-		Filters:          &[]string{"randomFilter"},
+		Enabled:              utils.BoolPointer(true),
+		Cdrs_conns:           &[]string{utils.MetaInternal, "*conn1"},
+		Thresholds_conns:     &[]string{utils.MetaInternal, "*conn1"},
+		Stats_conns:          &[]string{utils.MetaInternal, "*conn1"},
+		Diameter_agent_conns: &[]string{"*conn1"},
+		Filters:              &[]string{"randomFilter"},
 	}
 	expected := &SchedulerCfg{
-		Enabled:      true,
-		CDRsConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
-		ThreshSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
-		StatSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
-		Filters:      []string{"randomFilter"},
+		Enabled:            true,
+		CDRsConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
+		ThreshSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
+		StatSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
+		DiameterAgentConns: []string{"*conn1"},
+		Filters:            []string{"randomFilter"},
 	}
 	jsonCfg := NewDefaultCGRConfig()
 	if err = jsonCfg.schedulerCfg.loadFromJSONCfg(cfgJSONS); err != nil {
@@ -52,11 +54,12 @@ func TestSchedulerCfgAsMapInterface(t *testing.T) {
 	"schedulers": {},
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:      false,
-		utils.CDRsConnsCfg:    []string{},
-		utils.ThreshSConnsCfg: []string{},
-		utils.StatSConnsCfg:   []string{},
-		utils.FiltersCfg:      []string{},
+		utils.EnabledCfg:            false,
+		utils.CDRsConnsCfg:          []string{},
+		utils.ThreshSConnsCfg:       []string{},
+		utils.StatSConnsCfg:         []string{},
+		utils.DiameterAgentConnsCfg: []string{},
+		utils.FiltersCfg:            []string{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -73,15 +76,17 @@ func TestSchedulerCfgAsMapInterface1(t *testing.T) {
 	   "cdrs_conns": ["*internal", "*conn1"],
 	   "thresholds_conns": ["*internal", "*conn1"],
 	   "stats_conns": ["*internal", "*conn1"],
+	   "diameter_agent_conns": ["*conn1"],
        "filters": ["randomFilter"],
     },
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:      true,
-		utils.CDRsConnsCfg:    []string{utils.MetaInternal, "*conn1"},
-		utils.ThreshSConnsCfg: []string{utils.MetaInternal, "*conn1"},
-		utils.StatSConnsCfg:   []string{utils.MetaInternal, "*conn1"},
-		utils.FiltersCfg:      []string{"randomFilter"},
+		utils.EnabledCfg:            true,
+		utils.CDRsConnsCfg:          []string{utils.MetaInternal, "*conn1"},
+		utils.ThreshSConnsCfg:       []string{utils.MetaInternal, "*conn1"},
+		utils.StatSConnsCfg:         []string{utils.MetaInternal, "*conn1"},
+		utils.DiameterAgentConnsCfg: []string{"*conn1"},
+		utils.FiltersCfg:            []string{"randomFilter"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -93,11 +98,12 @@ func TestSchedulerCfgAsMapInterface1(t *testing.T) {
 
 func TestSchedulerCfgClone(t *testing.T) {
 	ban := &SchedulerCfg{
-		Enabled:      true,
-		CDRsConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
-		ThreshSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
-		StatSConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
-		Filters:      []string{"randomFilter"},
+		Enabled:            true,
+		CDRsConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
+		ThreshSConns:       []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
+		StatSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
+		DiameterAgentConns: []string{"*conn1"},
+		Filters:            []string{"randomFilter"},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -112,6 +118,9 @@ func TestSchedulerCfgClone(t *testing.T) {
 	if rcv.StatSConns[1] = ""; ban.StatSConns[1] != "*conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.DiameterAgentConns[0] = ""; ban.DiameterAgentConns[0] != "*conn1" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if rcv.Filters[0] = ""; ban.Filters[0] != "randomFilter" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
@@ -75,12 +75,15 @@ func TestStoreDbCfgloadFromJsonCfgCase1(t *testing.T) {
 			},
 		},
 		Opts: map[string]interface{}{
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.QueryTimeoutCfg:    "10s",
-			utils.SSLModeCfg:         "disable",
-			utils.MysqlLocation:      "UTC",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.SSLModeCfg:                    "disable",
+			utils.MysqlLocation:                 "UTC",
+			utils.InternalDBPathCfg:             "",
+			utils.InternalDBSnapshotIntervalCfg: "0",
+			utils.InternalDBFsyncCfg:            utils.MetaNone,
 		},
 	}
 	jsonCfg := NewDefaultCGRConfig()
@@ -231,12 +234,15 @@ func TestStorDbCfgAsMapInterface(t *testing.T) {
 		utils.RemoteConnsCfg:         []string{"*conn1"},
 		utils.ReplicationConnsCfg:    []string{"*conn1"},
 		utils.OptsCfg: map[string]interface{}{
-			utils.MaxOpenConnsCfg:    100.,
-			utils.MaxIdleConnsCfg:    10.,
-			utils.ConnMaxLifetimeCfg: 0.,
-			utils.QueryTimeoutCfg:    "10s",
-			utils.SSLModeCfg:         "disable",
-			utils.MysqlLocation:      "UTC",
+			utils.MaxOpenConnsCfg:               100.,
+			utils.MaxIdleConnsCfg:               10.,
+			utils.ConnMaxLifetimeCfg:            0.,
+			utils.QueryTimeoutCfg:               "10s",
+			utils.SSLModeCfg:                    "disable",
+			utils.MysqlLocation:                 "UTC",
+			utils.InternalDBPathCfg:             "",
+			utils.InternalDBSnapshotIntervalCfg: "0",
+			utils.InternalDBFsyncCfg:            utils.MetaNone,
 		},
 		utils.ItemsCfg: map[string]interface{}{
 			utils.SessionCostsTBL: map[string]interface{}{utils.RemoteCfg: false, utils.ReplicateCfg: false},
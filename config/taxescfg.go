@@ -0,0 +1,130 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import "github.com/cgrates/cgrates/utils"
+
+// TaxSCfg is the configuration of the tax calculation
+type TaxSCfg struct {
+	Enabled             bool // apply the taxes by default after rating in CDRs and AccountS
+	IndexedSelects      bool
+	StringIndexedFields *[]string
+	PrefixIndexedFields *[]string
+	SuffixIndexedFields *[]string
+	NestedFields        bool
+}
+
+func (txS *TaxSCfg) loadFromJSONCfg(jsnCfg *TaxSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		txS.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Indexed_selects != nil {
+		txS.IndexedSelects = *jsnCfg.Indexed_selects
+	}
+	if jsnCfg.String_indexed_fields != nil {
+		sif := make([]string, len(*jsnCfg.String_indexed_fields))
+		for i, fID := range *jsnCfg.String_indexed_fields {
+			sif[i] = fID
+		}
+		txS.StringIndexedFields = &sif
+	}
+	if jsnCfg.Prefix_indexed_fields != nil {
+		pif := make([]string, len(*jsnCfg.Prefix_indexed_fields))
+		for i, fID := range *jsnCfg.Prefix_indexed_fields {
+			pif[i] = fID
+		}
+		txS.PrefixIndexedFields = &pif
+	}
+	if jsnCfg.Suffix_indexed_fields != nil {
+		sif := make([]string, len(*jsnCfg.Suffix_indexed_fields))
+		for i, fID := range *jsnCfg.Suffix_indexed_fields {
+			sif[i] = fID
+		}
+		txS.SuffixIndexedFields = &sif
+	}
+	if jsnCfg.Nested_fields != nil {
+		txS.NestedFields = *jsnCfg.Nested_fields
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (txS *TaxSCfg) AsMapInterface() (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:        txS.Enabled,
+		utils.IndexedSelectsCfg: txS.IndexedSelects,
+		utils.NestedFieldsCfg:   txS.NestedFields,
+	}
+	if txS.StringIndexedFields != nil {
+		stringIndexedFields := make([]string, len(*txS.StringIndexedFields))
+		for i, item := range *txS.StringIndexedFields {
+			stringIndexedFields[i] = item
+		}
+		initialMP[utils.StringIndexedFieldsCfg] = stringIndexedFields
+	}
+	if txS.PrefixIndexedFields != nil {
+		prefixIndexedFields := make([]string, len(*txS.PrefixIndexedFields))
+		for i, item := range *txS.PrefixIndexedFields {
+			prefixIndexedFields[i] = item
+		}
+		initialMP[utils.PrefixIndexedFieldsCfg] = prefixIndexedFields
+	}
+	if txS.SuffixIndexedFields != nil {
+		suffixIndexedFields := make([]string, len(*txS.SuffixIndexedFields))
+		for i, item := range *txS.SuffixIndexedFields {
+			suffixIndexedFields[i] = item
+		}
+		initialMP[utils.SuffixIndexedFieldsCfg] = suffixIndexedFields
+	}
+	return
+}
+
+// Clone returns a deep copy of TaxSCfg
+func (txS TaxSCfg) Clone() (cln *TaxSCfg) {
+	cln = &TaxSCfg{
+		Enabled:        txS.Enabled,
+		IndexedSelects: txS.IndexedSelects,
+		NestedFields:   txS.NestedFields,
+	}
+	if txS.StringIndexedFields != nil {
+		idx := make([]string, len(*txS.StringIndexedFields))
+		for i, dx := range *txS.StringIndexedFields {
+			idx[i] = dx
+		}
+		cln.StringIndexedFields = &idx
+	}
+	if txS.PrefixIndexedFields != nil {
+		idx := make([]string, len(*txS.PrefixIndexedFields))
+		for i, dx := range *txS.PrefixIndexedFields {
+			idx[i] = dx
+		}
+		cln.PrefixIndexedFields = &idx
+	}
+	if txS.SuffixIndexedFields != nil {
+		idx := make([]string, len(*txS.SuffixIndexedFields))
+		for i, dx := range *txS.SuffixIndexedFields {
+			idx[i] = dx
+		}
+		cln.SuffixIndexedFields = &idx
+	}
+	return
+}
@@ -0,0 +1,78 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdAttributesTraceEvent{
+		name:      "attributes_trace_event",
+		rpcMethod: utils.AttributeSv1TraceEvent,
+		rpcParams: &engine.AttrArgsProcessEvent{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdAttributesTraceEvent struct {
+	name      string
+	rpcMethod string
+	rpcParams *engine.AttrArgsProcessEvent
+	*CommandExecuter
+}
+
+func (self *CmdAttributesTraceEvent) Name() string {
+	return self.name
+}
+
+func (self *CmdAttributesTraceEvent) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdAttributesTraceEvent) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &engine.AttrArgsProcessEvent{
+			CGREvent: new(utils.CGREvent),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdAttributesTraceEvent) PostprocessRpcParams() error {
+	if self.rpcParams != nil && self.rpcParams.Time == nil {
+		self.rpcParams.Time = utils.TimePointer(time.Now())
+	}
+	return nil
+}
+
+func (self *CmdAttributesTraceEvent) RpcResult() interface{} {
+	var atr engine.AttrSTraceReply
+	return &atr
+}
+
+func (self *CmdAttributesTraceEvent) GetFormatedResult(result interface{}) string {
+	return GetFormatedResult(result, utils.StringSet{
+		utils.Usage: {},
+	})
+}
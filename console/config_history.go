@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetConfigHistory{
+		name:      "config_history",
+		rpcMethod: utils.ConfigSv1GetConfigHistory,
+		rpcParams: &config.SectionWithOpts{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// Commander implementation
+type CmdGetConfigHistory struct {
+	name      string
+	rpcMethod string
+	rpcParams *config.SectionWithOpts
+	*CommandExecuter
+}
+
+func (self *CmdGetConfigHistory) Name() string {
+	return self.name
+}
+
+func (self *CmdGetConfigHistory) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetConfigHistory) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &config.SectionWithOpts{Opts: make(map[string]interface{})}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetConfigHistory) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetConfigHistory) RpcResult() interface{} {
+	var s []*config.ConfigVersion
+	return &s
+}
@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import "github.com/cgrates/cgrates/utils"
+
+func init() {
+	c := &CmdLogLevels{
+		name:      "log_levels",
+		rpcMethod: utils.CoreSv1GetLogLevels,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdLogLevels struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantWithOpts
+	*CommandExecuter
+}
+
+func (self *CmdLogLevels) Name() string {
+	return self.name
+}
+
+func (self *CmdLogLevels) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdLogLevels) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &utils.TenantWithOpts{
+			Opts: make(map[string]interface{}),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdLogLevels) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdLogLevels) RpcResult() interface{} {
+	var s utils.LogLevels
+	return &s
+}
+
+func (self *CmdLogLevels) ClientArgs() (args []string) {
+	return
+}
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdSetLookupTable{
+		name:      "lookup_table_set",
+		rpcMethod: utils.APIerSv1SetLookupTable,
+		rpcParams: &v1.LookupTableWithCache{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdSetLookupTable struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.LookupTableWithCache
+	*CommandExecuter
+}
+
+func (self *CmdSetLookupTable) Name() string {
+	return self.name
+}
+
+func (self *CmdSetLookupTable) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdSetLookupTable) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &v1.LookupTableWithCache{LookupTable: new(engine.LookupTable)}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdSetLookupTable) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdSetLookupTable) RpcResult() interface{} {
+	var s string
+	return &s
+}
@@ -113,11 +113,11 @@ func TestNewCapsGOBCodec(t *testing.T) {
 	conn := new(mockConn)
 	cr := engine.NewCaps(0, utils.MetaBusy)
 	anz := &analyzers.AnalyzerService{}
-	exp := newGobServerCodec(conn)
+	exp := newLogServerCodec(newGobServerCodec(conn))
 	if r := newCapsGOBCodec(conn, cr, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
-	exp = analyzers.NewAnalyzerServerCodec(newGobServerCodec(conn), anz, utils.MetaGOB, utils.Local, utils.Local)
+	exp = analyzers.NewAnalyzerServerCodec(newLogServerCodec(newGobServerCodec(conn)), anz, utils.MetaGOB, utils.Local, utils.Local)
 	if r := newCapsGOBCodec(conn, cr, anz); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
@@ -127,11 +127,11 @@ func TestNewCapsJSONCodec(t *testing.T) {
 	conn := new(mockConn)
 	cr := engine.NewCaps(0, utils.MetaBusy)
 	anz := &analyzers.AnalyzerService{}
-	exp := jsonrpc.NewServerCodec(conn)
+	exp := newLogServerCodec(jsonrpc.NewServerCodec(conn))
 	if r := newCapsJSONCodec(conn, cr, nil); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
-	exp = analyzers.NewAnalyzerServerCodec(jsonrpc.NewServerCodec(conn), anz, utils.MetaJSON, utils.Local, utils.Local)
+	exp = analyzers.NewAnalyzerServerCodec(newLogServerCodec(jsonrpc.NewServerCodec(conn)), anz, utils.MetaJSON, utils.Local, utils.Local)
 	if r := newCapsJSONCodec(conn, cr, anz); !reflect.DeepEqual(r, exp) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
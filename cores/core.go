@@ -67,3 +67,33 @@ func (cS *CoreService) Status(arg *utils.TenantWithOpts, reply *map[string]inter
 	*reply = response
 	return
 }
+
+// SetLogLevel changes the log level of the engine or of one subsystem
+func (cS *CoreService) SetLogLevel(arg *utils.ArgsSetLogLevel, reply *string) (err error) {
+	if arg.Level > utils.LOGLEVEL_DEBUG ||
+		(arg.Subsystem == utils.EmptyString && arg.Level < utils.LOGLEVEL_EMERGENCY) {
+		return utils.NewErrServerError(fmt.Errorf("invalid log level: %d", arg.Level))
+	}
+	if arg.Subsystem == utils.EmptyString {
+		utils.Logger.SetLogLevel(arg.Level)
+	} else {
+		utils.Logger.SetSubsystemLogLevel(arg.Subsystem, arg.Level)
+	}
+	*reply = utils.OK
+	return
+}
+
+// GetLogLevels returns the log levels in use
+func (cS *CoreService) GetLogLevels(arg *utils.TenantWithOpts, reply *utils.LogLevels) (err error) {
+	*reply = *utils.Logger.GetLogLevels()
+	return
+}
+
+// Snapshot writes the persistent internal databases to disk
+func (cS *CoreService) Snapshot(arg *utils.TenantWithOpts, reply *string) (err error) {
+	if err = engine.SnapshotInternalDBs(); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package cores
 
 import (
+	"io/ioutil"
 	"reflect"
 	"runtime"
 	"testing"
@@ -93,3 +94,45 @@ func TestCoreServiceStatus(t *testing.T) {
 
 	utils.GitLastLog = ""
 }
+
+func TestCoreServiceLogLevels(t *testing.T) {
+	tmpLogger := utils.Logger
+	defer func() {
+		utils.Logger = tmpLogger
+	}()
+	utils.Logger = utils.NewJSONLogger(ioutil.Discard, "node1")
+	cores := NewCoreService(config.NewDefaultCGRConfig(), engine.NewCaps(1, utils.MetaBusy), nil)
+	var reply string
+	if err := cores.SetLogLevel(&utils.ArgsSetLogLevel{Level: utils.LOGLEVEL_NOTICE}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected OK, received %q", reply)
+	}
+	if err := cores.SetLogLevel(&utils.ArgsSetLogLevel{Subsystem: utils.SessionS, Level: utils.LOGLEVEL_DEBUG}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	expErr := "SERVER_ERROR: invalid log level: 8"
+	if err := cores.SetLogLevel(&utils.ArgsSetLogLevel{Level: 8}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+	exp := utils.LogLevels{
+		LogLevel:   utils.LOGLEVEL_NOTICE,
+		Subsystems: map[string]int{utils.SessionS: utils.LOGLEVEL_DEBUG},
+	}
+	var rply utils.LogLevels
+	if err := cores.GetLogLevels(new(utils.TenantWithOpts), &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %+v, received %+v", exp, rply)
+	}
+	// negative level removes the subsystem overwrite
+	if err := cores.SetLogLevel(&utils.ArgsSetLogLevel{Subsystem: utils.SessionS, Level: -1}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp.Subsystems = map[string]int{}
+	if err := cores.GetLogLevels(new(utils.TenantWithOpts), &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %+v, received %+v", exp, rply)
+	}
+}
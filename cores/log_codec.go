@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"fmt"
+	"net/rpc"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newLogServerCodec logs every served request if the CoreS log level is debug
+// the level is checked for each request so it can be changed at runtime
+func newLogServerCodec(sc rpc.ServerCodec) rpc.ServerCodec {
+	return &logServerCodec{
+		sc:   sc,
+		reqs: make(map[uint64]*logRequest),
+	}
+}
+
+type logRequest struct {
+	method    string
+	tenant    string
+	startTime time.Time
+}
+
+type logServerCodec struct {
+	sc rpc.ServerCodec
+
+	reqs   map[uint64]*logRequest
+	reqIdx uint64 // the body is read after the header
+	reqsLk sync.Mutex
+}
+
+func (c *logServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err != nil {
+		return
+	}
+	logReq := utils.Logger.GetLogLevels().SubsystemLogLevel(utils.CoreS) >= utils.LOGLEVEL_DEBUG
+	c.reqsLk.Lock()
+	c.reqIdx = r.Seq
+	if logReq {
+		c.reqs[c.reqIdx] = &logRequest{
+			method:    r.ServiceMethod,
+			startTime: time.Now(),
+		}
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+func (c *logServerCodec) ReadRequestBody(x interface{}) (err error) {
+	err = c.sc.ReadRequestBody(x)
+	c.reqsLk.Lock()
+	if req, has := c.reqs[c.reqIdx]; has {
+		req.tenant = argsTenant(x)
+	}
+	c.reqsLk.Unlock()
+	return
+}
+
+func (c *logServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.reqsLk.Lock()
+	req, has := c.reqs[r.Seq]
+	delete(c.reqs, r.Seq)
+	c.reqsLk.Unlock()
+	if has {
+		flds := utils.LogFields{
+			utils.LogFieldRPCMethod: req.method,
+			utils.LogFieldDuration:  time.Since(req.startTime).String(),
+		}
+		if req.tenant != utils.EmptyString {
+			flds[utils.LogFieldTenant] = req.tenant
+		}
+		if r.Error != utils.EmptyString {
+			flds[utils.LogFieldError] = r.Error
+		}
+		utils.Logger.LogWithFields(utils.LOGLEVEL_DEBUG,
+			fmt.Sprintf("<%s> served API call", utils.CoreS), flds)
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *logServerCodec) Close() error { return c.sc.Close() }
+
+// argsTenant returns the Tenant field of the API arguments if present
+func argsTenant(args interface{}) (tnt string) {
+	v := reflect.ValueOf(args)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	if fld := v.FieldByName(utils.Tenant); fld.IsValid() && fld.Kind() == reflect.String {
+		tnt = fld.String()
+	}
+	return
+}
@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/rpc"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestNewLogServerCodec(t *testing.T) {
+	tmpLogger := utils.Logger
+	defer func() {
+		utils.Logger = tmpLogger
+	}()
+	output := new(bytes.Buffer)
+	utils.Logger = utils.NewJSONLogger(output, "node1")
+	utils.Logger.SetLogLevel(utils.LOGLEVEL_INFO)
+	mk := new(mockServerCodec)
+	codec := newLogServerCodec(mk)
+	if _, canCast := codec.(*logServerCodec); !canCast {
+		t.Fatalf("Expected *logServerCodec, received %T", codec)
+	}
+	r := &rpc.Request{Seq: 1}
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody(&utils.CGREvent{Tenant: "cgrates.org"}); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: 1, ServiceMethod: utils.CoreSv1Ping}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if output.Len() != 0 {
+		t.Errorf("Expected no log for info level, received %q", output.String())
+	}
+	utils.Logger.SetSubsystemLogLevel(utils.CoreS, utils.LOGLEVEL_DEBUG) // applied on the existing connection
+	r = new(rpc.Request)
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.ReadRequestBody(&utils.CGREvent{Tenant: "cgrates.org"}); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: 0, ServiceMethod: utils.CoreSv1Ping, Error: "NOT_FOUND"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	rec := make(map[string]interface{})
+	if err := json.Unmarshal(output.Bytes(), &rec); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := rec[utils.LogFieldDuration]; !has {
+		t.Errorf("Expected duration in %+v", rec)
+	}
+	delete(rec, utils.LogFieldDuration)
+	delete(rec, utils.LogFieldTime)
+	exp := map[string]interface{}{
+		utils.LogFieldLevel:     "DEBUG",
+		utils.LogFieldNodeID:    "node1",
+		utils.LogFieldSubsystem: utils.CoreS,
+		utils.LogFieldMessage:   "served API call",
+		utils.LogFieldRPCMethod: utils.CoreSv1Ping,
+		utils.LogFieldTenant:    "cgrates.org",
+		utils.LogFieldError:     "NOT_FOUND",
+	}
+	if !reflect.DeepEqual(exp, rec) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rec))
+	}
+	if err := codec.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestArgsTenant(t *testing.T) {
+	if tnt := argsTenant(&utils.TenantWithOpts{Tenant: "cgrates.org"}); tnt != "cgrates.org" {
+		t.Errorf("Expected cgrates.org, received %q", tnt)
+	}
+	var ev *utils.CGREvent
+	if tnt := argsTenant(ev); tnt != utils.EmptyString {
+		t.Errorf("Expected empty tenant, received %q", tnt)
+	}
+	if tnt := argsTenant("args"); tnt != utils.EmptyString {
+		t.Errorf("Expected empty tenant, received %q", tnt)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"errors"
+	"net/rpc"
+	"sync"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// newTraceServerCodec starts a server span for every served request if the tracing is enabled
+// the span continues the trace received in the options of the request
+// and is passed further to the API as the new traceparent
+func newTraceServerCodec(sc rpc.ServerCodec) rpc.ServerCodec {
+	if !utils.TracingEnabled() {
+		return sc
+	}
+	return &traceServerCodec{
+		sc:    sc,
+		spans: make(map[uint64]*utils.Span),
+	}
+}
+
+type traceServerCodec struct {
+	sc rpc.ServerCodec
+
+	spans   map[uint64]*utils.Span
+	reqIdx  uint64 // the body is read after the header
+	method  string
+	spansLk sync.Mutex
+}
+
+func (c *traceServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.sc.ReadRequestHeader(r); err != nil {
+		return
+	}
+	c.reqIdx = r.Seq
+	c.method = r.ServiceMethod
+	return
+}
+
+func (c *traceServerCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.sc.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	span, _ := utils.StartRPCSpan(x, c.method, utils.SpanKindServer)
+	if tnt := argsTenant(x); tnt != utils.EmptyString {
+		span.SetAttribute(utils.TraceTenant, tnt)
+	}
+	utils.SetArgsOpt(x, utils.OptsTraceParent, span.TraceParent())
+	c.spansLk.Lock()
+	c.spans[c.reqIdx] = span
+	c.spansLk.Unlock()
+	return
+}
+
+func (c *traceServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.spansLk.Lock()
+	span, has := c.spans[r.Seq]
+	delete(c.spans, r.Seq)
+	c.spansLk.Unlock()
+	if has {
+		var err error
+		if r.Error != utils.EmptyString {
+			err = errors.New(r.Error)
+		}
+		span.End(err)
+	}
+	return c.sc.WriteResponse(r, x)
+}
+
+func (c *traceServerCodec) Close() error { return c.sc.Close() }
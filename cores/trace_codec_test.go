@@ -0,0 +1,118 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net/rpc"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+type mockSpanExporter struct {
+	spans []*utils.Span
+}
+
+func (me *mockSpanExporter) ExportSpans(_ string, spans []*utils.Span) error {
+	me.spans = append(me.spans, spans...)
+	return nil
+}
+
+func (me *mockSpanExporter) Close() error { return nil }
+
+// mockBodyServerCodec decodes the request into a CGREvent with the given traceparent
+type mockBodyServerCodec struct {
+	mockServerCodec
+	traceParent string
+}
+
+func (c *mockBodyServerCodec) ReadRequestBody(x interface{}) (err error) {
+	*x.(*utils.CGREvent) = utils.CGREvent{
+		Tenant: "cgrates.org",
+		Opts:   map[string]interface{}{utils.OptsTraceParent: c.traceParent},
+	}
+	return
+}
+
+func TestNewTraceServerCodec(t *testing.T) {
+	mk := new(mockServerCodec)
+	if r := newTraceServerCodec(mk); !reflect.DeepEqual(mk, r) {
+		t.Errorf("Expected: %v ,received:%v", mk, r)
+	}
+	exp := new(mockSpanExporter)
+	tracer := utils.NewTracer(exp, "node1", time.Hour)
+	utils.SetTracer(tracer)
+	defer utils.SetTracer(nil)
+
+	parent := utils.StartSpan(utils.EmptyString, "parent", utils.SpanKindClient)
+	mkBody := &mockBodyServerCodec{traceParent: parent.TraceParent()}
+	codec := newTraceServerCodec(mkBody)
+	if _, canCast := codec.(*traceServerCodec); !canCast {
+		t.Fatalf("Expected *traceServerCodec, received %T", codec)
+	}
+	r := new(rpc.Request)
+	if err := codec.ReadRequestHeader(r); err != nil {
+		t.Fatal(err)
+	}
+	args := new(utils.CGREvent)
+	if err := codec.ReadRequestBody(args); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.WriteResponse(&rpc.Response{Seq: 0, ServiceMethod: utils.CoreSv1Ping, Error: "NOT_FOUND"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := codec.Close(); err != nil {
+		t.Error(err)
+	}
+	if err := tracer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(exp.spans) != 1 {
+		t.Fatalf("Expected one span, received %s", utils.ToJSON(exp.spans))
+	}
+	span := exp.spans[0]
+	if span.TraceID != parent.TraceID || span.ParentSpanID != parent.SpanID {
+		t.Errorf("Expected child of %s, received %s", utils.ToJSON(parent), utils.ToJSON(span))
+	}
+	if span.Name != utils.CoreSv1Ping || span.Kind != utils.SpanKindServer || span.Error != "NOT_FOUND" {
+		t.Errorf("Unexpected span: %s", utils.ToJSON(span))
+	}
+	if span.Attributes[utils.TraceTenant] != "cgrates.org" {
+		t.Errorf("Expected tenant in %+v", span.Attributes)
+	}
+	if tp := args.Opts[utils.OptsTraceParent]; tp != span.TraceParent() {
+		t.Errorf("Expected traceparent %q passed to the API, received %q", span.TraceParent(), tp)
+	}
+}
+
+func TestTraceServerCodecReadError(t *testing.T) {
+	tracer := utils.NewTracer(new(mockSpanExporter), "node1", time.Hour)
+	defer tracer.Close()
+	utils.SetTracer(tracer)
+	defer utils.SetTracer(nil)
+	codec := newTraceServerCodec(new(mockServerCodec))
+	if err := codec.ReadRequestBody(new(utils.CGREvent)); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	if spans := codec.(*traceServerCodec).spans; len(spans) != 0 {
+		t.Errorf("Expected no spans, received %+v", spans)
+	}
+}
@@ -0,0 +1,17 @@
+--
+-- Adds the aggregation columns to the `tp_chargers` table (TpChargers version 2)
+--
+
+USE `cgrates`;
+
+ALTER TABLE `tp_chargers`
+  ADD COLUMN `aggregation_path` varchar(64) NOT NULL DEFAULT '' AFTER `weight`,
+  ADD COLUMN `aggregation_filter_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_path`,
+  ADD COLUMN `aggregation_type` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_filter_ids`,
+  ADD COLUMN `aggregation_run_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_type`,
+  ADD COLUMN `aggregation_threshold_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_run_ids`,
+  DROP INDEX `unique_tp_chargers`,
+  ADD UNIQUE KEY `unique_tp_chargers` (`tpid`,`tenant`,
+    `id`,`filter_ids`,`run_id`,`attribute_ids`,`aggregation_path`);
+
+UPDATE versions SET version=2 WHERE item='TpChargers';
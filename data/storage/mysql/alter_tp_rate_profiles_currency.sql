@@ -0,0 +1,11 @@
+--
+-- Adds the currency and volume period columns to the `tp_rate_profiles` table (TpRateProfiles version 2)
+--
+
+USE `cgrates`;
+
+ALTER TABLE `tp_rate_profiles`
+  ADD COLUMN `currency` varchar(16) NOT NULL DEFAULT '' AFTER `rate_increment`,
+  ADD COLUMN `volume_period` varchar(16) NOT NULL DEFAULT '' AFTER `currency`;
+
+UPDATE versions SET version=2 WHERE item='TpRateProfiles';
@@ -50,3 +50,22 @@ CREATE TABLE session_costs (
   KEY run_origin_idx (run_id, origin_id),
   KEY deleted_at_idx (deleted_at)
 );
+
+DROP TABLE IF EXISTS invoices;
+CREATE TABLE invoices (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  tenant varchar(64) NOT NULL,
+  invoice_id varchar(64) NOT NULL,
+  account varchar(128) NOT NULL,
+  period_start TIMESTAMP NULL,
+  period_end TIMESTAMP NULL,
+  `lines` MEDIUMTEXT,
+  taxes TEXT,
+  subtotal DECIMAL(20,4) NOT NULL,
+  tax_total DECIMAL(20,4) NOT NULL,
+  total DECIMAL(20,4) NOT NULL,
+  created_at TIMESTAMP NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY invoiceid (tenant, invoice_id),
+  KEY account_idx (tenant, account)
+);
@@ -414,11 +414,16 @@ CREATE TABLE tp_chargers (
   `run_id` varchar(64) NOT NULL,
   `attribute_ids` varchar(64) NOT NULL,
   `weight` decimal(8,2) NOT NULL,
+  `aggregation_path` varchar(64) NOT NULL,
+  `aggregation_filter_ids` varchar(64) NOT NULL,
+  `aggregation_type` varchar(64) NOT NULL,
+  `aggregation_run_ids` varchar(64) NOT NULL,
+  `aggregation_threshold_ids` varchar(64) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
   UNIQUE KEY `unique_tp_chargers` (`tpid`,`tenant`,
-    `id`,`filter_ids`,`run_id`,`attribute_ids`)
+    `id`,`filter_ids`,`run_id`,`attribute_ids`,`aggregation_path`)
 );
 
 --
@@ -496,6 +501,8 @@ CREATE TABLE tp_rate_profiles (
   `rate_recurrent_fee` decimal(8,4) NOT NULL,
   `rate_unit` varchar(64) NOT NULL,
   `rate_increment` varchar(64) NOT NULL,
+  `currency` varchar(16) NOT NULL,
+  `volume_period` varchar(16) NOT NULL,
   `created_at` TIMESTAMP,
   PRIMARY KEY (`pk`),
   KEY `tpid` (`tpid`),
@@ -0,0 +1,15 @@
+--
+-- Adds the aggregation columns to the tp_chargers table (TpChargers version 2)
+--
+
+ALTER TABLE tp_chargers
+  ADD COLUMN "aggregation_path" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN "aggregation_filter_ids" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN "aggregation_type" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN "aggregation_run_ids" varchar(64) NOT NULL DEFAULT '',
+  ADD COLUMN "aggregation_threshold_ids" varchar(64) NOT NULL DEFAULT '';
+DROP INDEX IF EXISTS tp_chargers_unique;
+CREATE INDEX tp_chargers_unique ON tp_chargers  ("tpid",  "tenant", "id",
+  "filter_ids","run_id","attribute_ids","aggregation_path");
+
+UPDATE versions SET version=2 WHERE item='TpChargers';
@@ -0,0 +1,9 @@
+--
+-- Adds the currency and volume period columns to the tp_rate_profiles table (TpRateProfiles version 2)
+--
+
+ALTER TABLE tp_rate_profiles
+  ADD COLUMN "currency" VARCHAR(16) NOT NULL DEFAULT '',
+  ADD COLUMN "volume_period" VARCHAR(16) NOT NULL DEFAULT '';
+
+UPDATE versions SET version=2 WHERE item='TpRateProfiles';
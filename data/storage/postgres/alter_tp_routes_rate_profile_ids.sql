@@ -0,0 +1,8 @@
+--
+-- Adds the route rate profile ids column to the tp_routes table (TpRoutes version 2)
+--
+
+ALTER TABLE tp_routes
+  ADD COLUMN IF NOT EXISTS "route_rate_profile_ids" VARCHAR(64) NOT NULL DEFAULT '';
+
+UPDATE versions SET version=2 WHERE item='TpRoutes';
@@ -57,3 +57,22 @@ DROP INDEX IF EXISTS run_origin_sessionscost_idx;
 CREATE INDEX run_origin_sessionscost_idx ON session_costs (run_id, origin_id);
 DROP INDEX IF EXISTS deleted_at_sessionscost_idx;
 CREATE INDEX deleted_at_sessionscost_idx ON session_costs (deleted_at);
+
+DROP TABLE IF EXISTS invoices;
+CREATE TABLE invoices (
+  id SERIAL PRIMARY KEY,
+  tenant VARCHAR(64) NOT NULL,
+  invoice_id VARCHAR(64) NOT NULL,
+  account VARCHAR(128) NOT NULL,
+  period_start TIMESTAMP WITH TIME ZONE,
+  period_end TIMESTAMP WITH TIME ZONE,
+  lines jsonb,
+  taxes jsonb,
+  subtotal NUMERIC(20,4) NOT NULL,
+  tax_total NUMERIC(20,4) NOT NULL,
+  total NUMERIC(20,4) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (tenant, invoice_id)
+);
+DROP INDEX IF EXISTS account_invoices_idx;
+CREATE INDEX account_invoices_idx ON invoices (tenant, account);
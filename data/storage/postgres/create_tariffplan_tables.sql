@@ -350,6 +350,7 @@ CREATE TABLE tp_routes (
   "route_filter_ids" varchar(64) NOT NULL,
   "route_account_ids" varchar(64) NOT NULL,
   "route_ratingplan_ids" varchar(64) NOT NULL,
+  "route_rate_profile_ids" varchar(64) NOT NULL,
   "route_resource_ids" varchar(64) NOT NULL,
   "route_stat_ids" varchar(64) NOT NULL,
   "route_weight" decimal(8,2) NOT NULL,
@@ -403,11 +404,16 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
     "run_id" varchar(64) NOT NULL,
     "attribute_ids" varchar(64) NOT NULL,
     "weight" decimal(8,2) NOT NULL,
+    "aggregation_path" varchar(64) NOT NULL,
+    "aggregation_filter_ids" varchar(64) NOT NULL,
+    "aggregation_type" varchar(64) NOT NULL,
+    "aggregation_run_ids" varchar(64) NOT NULL,
+    "aggregation_threshold_ids" varchar(64) NOT NULL,
     "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_chargers_ids ON tp_chargers (tpid);
   CREATE INDEX tp_chargers_unique ON tp_chargers  ("tpid",  "tenant", "id",
-    "filter_ids","run_id","attribute_ids");
+    "filter_ids","run_id","attribute_ids","aggregation_path");
 
   --
   -- Table structure for table `tp_dispatchers`
@@ -481,6 +487,8 @@ CREATE INDEX tp_routes_unique ON tp_routes  ("tpid",  "tenant", "id",
   "rate_recurrent_fee" decimal(8,4) NOT NULL,
   "rate_unit" VARCHAR(64) NOT NULL,
   "rate_increment" VARCHAR(64) NOT NULL,
+  "currency" VARCHAR(16) NOT NULL,
+  "volume_period" VARCHAR(16) NOT NULL,
   "created_at" TIMESTAMP WITH TIME ZONE
   );
   CREATE INDEX tp_rate_profiles_ids ON tp_rate_profiles (tpid);
@@ -74,3 +74,20 @@ func (dS *DispatcherService) AttributeSv1ProcessEvent(args *engine.AttrArgsProce
 	}
 	return dS.Dispatch(args.CGREvent, utils.MetaAttributes, utils.AttributeSv1ProcessEvent, args, reply)
 }
+
+// AttributeSv1TraceEvent .
+func (dS *DispatcherService) AttributeSv1TraceEvent(args *engine.AttrArgsProcessEvent,
+	reply *engine.AttrSTraceReply) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.CGREvent != nil && args.CGREvent.Tenant != utils.EmptyString {
+		tnt = args.CGREvent.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.AttributeSv1TraceEvent, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+
+	}
+	return dS.Dispatch(args.CGREvent, utils.MetaAttributes, utils.AttributeSv1TraceEvent, args, reply)
+}
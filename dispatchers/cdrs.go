@@ -195,3 +195,54 @@ func (dS *DispatcherService) CDRsV2StoreSessionCost(args *engine.ArgsV2CDRSStore
 		Opts:   args.Opts,
 	}, utils.MetaCDRs, utils.CDRsV2StoreSessionCost, args, reply)
 }
+
+func (dS *DispatcherService) CDRsV1BillingRun(args *engine.ArgsBillingRun, reply *[]*engine.Invoice) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.CDRsV1BillingRun, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaCDRs, utils.CDRsV1BillingRun, args, reply)
+}
+
+func (dS *DispatcherService) CDRsV1GetInvoices(args *utils.InvoicesFilterWithOpts, reply *[]*engine.Invoice) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.InvoicesFilter != nil && args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.CDRsV1GetInvoices, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaCDRs, utils.CDRsV1GetInvoices, args, reply)
+}
+
+func (dS *DispatcherService) CDRsV1RemoveInvoices(args *utils.InvoicesFilterWithOpts, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.InvoicesFilter != nil && args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.CDRsV1RemoveInvoices, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaCDRs, utils.CDRsV1RemoveInvoices, args, reply)
+}
@@ -109,3 +109,54 @@ func (dS *DispatcherService) ConfigSv1GetConfigAsJSON(args *config.SectionWithOp
 		Opts:   args.Opts,
 	}, utils.MetaConfig, utils.ConfigSv1GetConfigAsJSON, args, reply)
 }
+
+func (dS *DispatcherService) ConfigSv1GetConfigHistory(args *config.SectionWithOpts, reply *[]*config.ConfigVersion) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ConfigSv1GetConfigHistory, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaConfig, utils.ConfigSv1GetConfigHistory, args, reply)
+}
+
+func (dS *DispatcherService) ConfigSv1RollbackConfig(args *config.RollbackConfigArgs, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ConfigSv1RollbackConfig, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaConfig, utils.ConfigSv1RollbackConfig, args, reply)
+}
+
+func (dS *DispatcherService) ConfigSv1SetDBConfig(args *config.SetDBConfigArgs, reply *string) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ConfigSv1SetDBConfig, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.MetaConfig, utils.ConfigSv1SetDBConfig, args, reply)
+}
@@ -168,6 +168,7 @@ func (dS *DispatcherService) dispatcherProfileForEvent(tnt string, ev *utils.CGR
 	if dPrlf == nil {
 		return nil, utils.ErrNotFound
 	}
+	engine.RecordProfileHit(utils.CacheDispatcherProfiles, tnt, dPrlf.ID, dPrlf.FilterIDs...)
 	return
 }
 
@@ -178,6 +179,15 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if tnt == utils.EmptyString {
 		tnt = dS.cfg.GeneralCfg().DefaultTenant
 	}
+	routeID := utils.IfaceAsString(ev.Opts[utils.OptsRouteID])
+	if utils.TracingEnabled() {
+		var span *utils.Span
+		span, args = utils.StartRPCSpan(args, utils.DispatcherSv1+utils.NestingSep+serviceMethod, utils.SpanKindInternal)
+		span.SetAttribute(utils.TraceSubsystem, subsys)
+		span.SetAttribute(utils.TraceTenant, tnt)
+		span.SetAttribute(utils.TraceDispatcherRoute, routeID)
+		defer func() { span.End(err) }()
+	}
 	dPrfl, errDsp := dS.dispatcherProfileForEvent(tnt, ev, subsys)
 	if errDsp != nil {
 		return utils.NewErrDispatcherS(errDsp)
@@ -194,7 +204,7 @@ func (dS *DispatcherService) Dispatch(ev *utils.CGREvent, subsys string,
 	if errCh := engine.Cache.Set(utils.CacheDispatchers, tntID, d, nil, true, utils.EmptyString); errCh != nil {
 		return utils.NewErrDispatcherS(errCh)
 	}
-	return d.Dispatch(utils.IfaceAsString(ev.Opts[utils.OptsRouteID]), subsys, serviceMethod, args, reply)
+	return d.Dispatch(routeID, subsys, serviceMethod, args, reply)
 }
 
 func (dS *DispatcherService) V1GetProfileForEvent(ev *utils.CGREvent,
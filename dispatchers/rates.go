@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package dispatchers
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
@@ -50,3 +52,117 @@ func (dS *DispatcherService) RateSv1CostForEvent(args *utils.ArgsCostForEvent, r
 	}
 	return dS.Dispatch(args.CGREvent, utils.RateS, utils.RateSv1CostForEvent, args, rpCost)
 }
+
+func (dS *DispatcherService) RateSv1CostForEvents(args *utils.ArgsCostForEvents, evCosts *[]*engine.EventRateProfileCost) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1CostForEvents, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1CostForEvents, args, evCosts)
+}
+
+func (dS *DispatcherService) RateSv1CompareRateProfiles(args *utils.ArgsCostForEvents, rpCmps *[]*engine.RateProfileComparison) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1CompareRateProfiles, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1CompareRateProfiles, args, rpCmps)
+}
+
+func (dS *DispatcherService) RateSv1GetRateForPrefix(args *utils.ArgsGetRateForPrefix, rdr *engine.RateDeckRate) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1GetRateForPrefix, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		Time:   args.Time,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1GetRateForPrefix, args, rdr)
+}
+
+func (dS *DispatcherService) RateSv1GetRateProfileVersions(args *utils.TenantIDWithOpts, rpvs *[]*engine.RateProfileVersion) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.TenantID != nil && args.TenantID.Tenant != utils.EmptyString {
+		tnt = args.TenantID.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1GetRateProfileVersions, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.ID,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1GetRateProfileVersions, args, rpvs)
+}
+
+func (dS *DispatcherService) RateSv1UpdateVolumeCounter(args *utils.ArgsCostForEvent, reply *string) (err error) {
+	if args == nil {
+		args = new(utils.ArgsCostForEvent)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1UpdateVolumeCounter, args.CGREvent.Tenant,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), args.CGREvent.Time); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(args.CGREvent, utils.RateS, utils.RateSv1UpdateVolumeCounter, args, reply)
+}
+
+func (dS *DispatcherService) RateSv1GetVolumeCounter(args *utils.ArgsRateVolumeCounter, rvc *engine.RateVolumeCounter) (err error) {
+	tnt := utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1GetVolumeCounter, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.RateProfileID,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1GetVolumeCounter, args, rvc)
+}
+
+func (dS *DispatcherService) RateSv1ResetVolumeCounter(args *utils.ArgsRateVolumeCounter, reply *string) (err error) {
+	tnt := utils.FirstNonEmpty(args.Tenant, dS.cfg.GeneralCfg().DefaultTenant)
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.RateSv1ResetVolumeCounter, tnt,
+			utils.IfaceAsString(args.Opts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant: tnt,
+		ID:     args.RateProfileID,
+		Opts:   args.Opts,
+	}, utils.RateS, utils.RateSv1ResetVolumeCounter, args, reply)
+}
@@ -46,6 +46,10 @@ func NewEventExporter(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.Filt
 		return NewFileCSVee(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaFileFWV:
 		return NewFileFWVee(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFileJSON:
+		return NewFileJSONee(cgrCfg, cfgIdx, filterS, dc)
+	case utils.MetaFileHTML:
+		return NewFileHTMLee(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaHTTPPost:
 		return NewHTTPPostEe(cgrCfg, cfgIdx, filterS, dc)
 	case utils.MetaHTTPjsonMap:
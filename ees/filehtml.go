@@ -0,0 +1,157 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// defaultHTMLTemplate is used when no htmlTemplate is configured for the exporter
+// it renders one table per exported event
+const defaultHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.ID}}</title>
+</head>
+<body>
+{{range .Records}}<table>
+{{range $key, $val := .Fields}}<tr><th>{{$key}}</th><td>{{$val}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`
+
+func NewFileHTMLee(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (fHTML *FileHTMLee, err error) {
+	fHTML = &FileHTMLee{id: cgrCfg.EEsCfg().Exporters[cfgIdx].ID,
+		cgrCfg: cgrCfg, cfgIdx: cfgIdx, filterS: filterS, dc: dc}
+	err = fHTML.init()
+	return
+}
+
+// FileHTMLee implements EventExporter interface for .html files
+// the events are rendered with the html template once the exporter is evicted
+type FileHTMLee struct {
+	id       string
+	cgrCfg   *config.CGRConfig
+	cfgIdx   int // index of config instance within EEsCfg.Exporters
+	filterS  *engine.FilterS
+	filePath string
+	tmpl     *template.Template
+	records  []*HTMLRecord
+	sync.RWMutex
+	dc utils.MapStorage
+}
+
+// HTMLRecord is one exported event as seen by the template
+type HTMLRecord struct {
+	Fields map[string]interface{} // the exported fields
+	Event  map[string]interface{} // the original event, used by templates to range over complex fields(ie: invoice lines)
+}
+
+// init will parse the template and compute the file path
+func (fHTML *FileHTMLee) init() (err error) {
+	if tmplPath, has := fHTML.cgrCfg.EEsCfg().Exporters[fHTML.cfgIdx].Opts[utils.HTMLTemplate]; has {
+		if fHTML.tmpl, err = template.ParseFiles(utils.IfaceAsString(tmplPath)); err != nil {
+			return
+		}
+	} else if fHTML.tmpl, err = template.New(fHTML.id).Parse(defaultHTMLTemplate); err != nil {
+		return
+	}
+	fHTML.filePath = path.Join(fHTML.cgrCfg.EEsCfg().Exporters[fHTML.cfgIdx].ExportPath,
+		fHTML.id+utils.Underline+utils.UUIDSha1Prefix()+utils.HTMLSuffix)
+	fHTML.Lock()
+	fHTML.dc[utils.ExportPath] = fHTML.filePath
+	fHTML.Unlock()
+	return
+}
+
+// ID returns the identificator of this exporter
+func (fHTML *FileHTMLee) ID() string {
+	return fHTML.id
+}
+
+// OnEvicted implements EventExporter, rendering the collected events into the file
+func (fHTML *FileHTMLee) OnEvicted(_ string, _ interface{}) {
+	fHTML.Lock()
+	defer fHTML.Unlock()
+	if err := fHTML.render(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when rendering the file",
+			utils.EventExporterS, fHTML.id, err.Error()))
+	}
+}
+
+// render writes the collected records into the file
+func (fHTML *FileHTMLee) render() (err error) {
+	var file *os.File
+	if file, err = os.Create(fHTML.filePath); err != nil {
+		return
+	}
+	if err = fHTML.tmpl.Execute(file, map[string]interface{}{
+		utils.ID:      fHTML.id,
+		utils.Records: fHTML.records,
+	}); err != nil {
+		file.Close()
+		return
+	}
+	return file.Close()
+}
+
+// ExportEvent implements EventExporter
+func (fHTML *FileHTMLee) ExportEvent(cgrEv *utils.CGREvent) (err error) {
+	fHTML.Lock()
+	defer func() {
+		if err != nil {
+			fHTML.dc[utils.NegativeExports].(utils.StringSet).Add(cgrEv.ID)
+		} else {
+			fHTML.dc[utils.PositiveExports].(utils.StringSet).Add(cgrEv.ID)
+		}
+		fHTML.Unlock()
+	}()
+	fHTML.dc[utils.NumberOfEvents] = fHTML.dc[utils.NumberOfEvents].(int64) + 1
+
+	var valMp map[string]interface{}
+	if valMp, err = composeExportMap(fHTML.cgrCfg, fHTML.cfgIdx, fHTML.filterS, fHTML.dc, cgrEv); err != nil {
+		return
+	}
+	flds := make(map[string]interface{}, len(valMp))
+	for k, v := range valMp {
+		flds[k] = utils.IfaceAsString(v)
+	}
+	fHTML.records = append(fHTML.records, &HTMLRecord{
+		Fields: flds,
+		Event:  cgrEv.Event,
+	})
+	updateEEMetrics(fHTML.dc, cgrEv.Event, utils.FirstNonEmpty(fHTML.cgrCfg.EEsCfg().Exporters[fHTML.cfgIdx].Timezone,
+		fHTML.cgrCfg.GeneralCfg().DefaultTimezone))
+	return
+}
+
+func (fHTML *FileHTMLee) GetMetrics() utils.MapStorage {
+	return fHTML.dc.Clone()
+}
@@ -0,0 +1,117 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"io/ioutil"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFileHTMLeeDefaultTemplate(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].ID = "html_exporter"
+	cgrCfg.EEsCfg().Exporters[0].Type = utils.MetaFileHTML
+	cgrCfg.EEsCfg().Exporters[0].ExportPath = t.TempDir()
+	newDM := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cgrCfg.CacheCfg(), nil)
+	filterS := engine.NewFilterS(cgrCfg, nil, newDM)
+	ee, err := NewEventExporter(cgrCfg, 0, filterS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fHTML, canCast := ee.(*FileHTMLee)
+	if !canCast {
+		t.Fatalf("unexpected exporter: %T", ee)
+	}
+	if fHTML.ID() != "html_exporter" {
+		t.Errorf("unexpected ID: %s", fHTML.ID())
+	}
+	if err := fHTML.ExportEvent(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.AccountField: "<1001>",
+			utils.Total:        10.5,
+		},
+	}); err != nil {
+		t.Error(err)
+	}
+	fHTML.OnEvicted(utils.EmptyString, nil)
+	body, err := ioutil.ReadFile(utils.IfaceAsString(fHTML.GetMetrics()[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, exp := range []string{
+		"<title>html_exporter</title>",
+		"<tr><th>Account</th><td>&lt;1001&gt;</td></tr>",
+		"<tr><th>Total</th><td>10.5</td></tr>",
+	} {
+		if !strings.Contains(string(body), exp) {
+			t.Errorf("expected %q in %q", exp, body)
+		}
+	}
+}
+
+func TestFileHTMLeeCustomTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	tmplPath := path.Join(tmpDir, "invoice.html")
+	if err := ioutil.WriteFile(tmplPath, []byte(`{{range .Records}}{{.Fields.Account}}:{{range .Event.Lines}}[{{.Category}} {{.Amount}}]{{end}}{{end}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].ExportPath = tmpDir
+	cgrCfg.EEsCfg().Exporters[0].Opts[utils.HTMLTemplate] = tmplPath
+	dc, err := newEEMetrics(utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fHTML, err := NewFileHTMLee(cgrCfg, 0, nil, dc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fHTML.ExportEvent(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "INV1",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Lines: []*engine.InvoiceLine{
+				{Type: utils.MetaRecurrent, Category: "monthly", Quantity: 1, Amount: 5},
+			},
+		},
+	}); err != nil {
+		t.Error(err)
+	}
+	fHTML.OnEvicted(utils.EmptyString, nil)
+	body, err := ioutil.ReadFile(utils.IfaceAsString(dc[utils.ExportPath]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := "1001:[monthly 5]"; string(body) != exp {
+		t.Errorf("Expected %q, received %q", exp, body)
+	}
+	cgrCfg.EEsCfg().Exporters[0].Opts[utils.HTMLTemplate] = path.Join(tmpDir, "missing.html")
+	if _, err := NewFileHTMLee(cgrCfg, 0, nil, dc); err == nil {
+		t.Error("expected error for missing template")
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewFileJSONee(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage) (fJSON *FileJSONee, err error) {
+	fJSON = &FileJSONee{id: cgrCfg.EEsCfg().Exporters[cfgIdx].ID,
+		cgrCfg: cgrCfg, cfgIdx: cfgIdx, filterS: filterS, dc: dc}
+	err = fJSON.init()
+	return
+}
+
+// FileJSONee implements EventExporter interface for .json files
+// the events are written as an array of JSON objects
+type FileJSONee struct {
+	id      string
+	cgrCfg  *config.CGRConfig
+	cfgIdx  int // index of config instance within EEsCfg.Exporters
+	filterS *engine.FilterS
+	file    io.WriteCloser
+	written bool // at least one event was written
+	sync.RWMutex
+	dc utils.MapStorage
+}
+
+// init will create all the necessary dependencies, including opening the file
+func (fJSON *FileJSONee) init() (err error) {
+	filePath := path.Join(fJSON.cgrCfg.EEsCfg().Exporters[fJSON.cfgIdx].ExportPath,
+		fJSON.id+utils.Underline+utils.UUIDSha1Prefix()+utils.JSNSuffix)
+	fJSON.Lock()
+	fJSON.dc[utils.ExportPath] = filePath
+	fJSON.Unlock()
+	if fJSON.file, err = os.Create(filePath); err != nil {
+		return
+	}
+	_, err = fJSON.file.Write([]byte(utils.IdxStart))
+	return
+}
+
+// ID returns the identificator of this exporter
+func (fJSON *FileJSONee) ID() string {
+	return fJSON.id
+}
+
+// OnEvicted implements EventExporter, doing the cleanup before exit
+func (fJSON *FileJSONee) OnEvicted(_ string, _ interface{}) {
+	fJSON.Lock()
+	defer fJSON.Unlock()
+	if _, err := fJSON.file.Write([]byte(utils.IdxEnd)); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the array",
+			utils.EventExporterS, fJSON.id, err.Error()))
+	}
+	if err := fJSON.file.Close(); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> Exporter with id: <%s> received error: <%s> when closing the file",
+			utils.EventExporterS, fJSON.id, err.Error()))
+	}
+}
+
+// ExportEvent implements EventExporter
+func (fJSON *FileJSONee) ExportEvent(cgrEv *utils.CGREvent) (err error) {
+	fJSON.Lock()
+	defer func() {
+		if err != nil {
+			fJSON.dc[utils.NegativeExports].(utils.StringSet).Add(cgrEv.ID)
+		} else {
+			fJSON.dc[utils.PositiveExports].(utils.StringSet).Add(cgrEv.ID)
+		}
+		fJSON.Unlock()
+	}()
+	fJSON.dc[utils.NumberOfEvents] = fJSON.dc[utils.NumberOfEvents].(int64) + 1
+
+	var valMp map[string]interface{}
+	if valMp, err = composeExportMap(fJSON.cgrCfg, fJSON.cfgIdx, fJSON.filterS, fJSON.dc, cgrEv); err != nil {
+		return
+	}
+	var body []byte
+	if body, err = json.Marshal(valMp); err != nil {
+		return
+	}
+	if fJSON.written {
+		body = append([]byte(utils.FieldsSep), body...)
+	}
+	if _, err = fJSON.file.Write(body); err != nil {
+		return
+	}
+	fJSON.written = true
+	updateEEMetrics(fJSON.dc, cgrEv.Event, utils.FirstNonEmpty(fJSON.cgrCfg.EEsCfg().Exporters[fJSON.cfgIdx].Timezone,
+		fJSON.cgrCfg.GeneralCfg().DefaultTimezone))
+	return
+}
+
+func (fJSON *FileJSONee) GetMetrics() utils.MapStorage {
+	return fJSON.dc.Clone()
+}
+
+// composeExportMap returns the event as it should be exported, based on the content fields of the exporter
+// without content fields the event is exported as it is
+func composeExportMap(cgrCfg *config.CGRConfig, cfgIdx int, filterS *engine.FilterS,
+	dc utils.MapStorage, cgrEv *utils.CGREvent) (valMp map[string]interface{}, err error) {
+	if len(cgrCfg.EEsCfg().Exporters[cfgIdx].ContentFields()) == 0 {
+		return cgrEv.Event, nil
+	}
+	oNm := map[string]*utils.OrderedNavigableMap{
+		utils.MetaExp: utils.NewOrderedNavigableMap(),
+	}
+	eeReq := engine.NewEventRequest(utils.MapStorage(cgrEv.Event), dc, cgrEv.Opts,
+		cgrCfg.EEsCfg().Exporters[cfgIdx].Tenant,
+		cgrCfg.GeneralCfg().DefaultTenant,
+		utils.FirstNonEmpty(cgrCfg.EEsCfg().Exporters[cfgIdx].Timezone,
+			cgrCfg.GeneralCfg().DefaultTimezone), filterS, oNm)
+	if err = eeReq.SetFields(cgrCfg.EEsCfg().Exporters[cfgIdx].ContentFields()); err != nil {
+		return
+	}
+	valMp = make(map[string]interface{})
+	for el := eeReq.OrdNavMP[utils.MetaExp].GetFirstElement(); el != nil; el = el.Next() {
+		var nmIt utils.NMInterface
+		if nmIt, err = eeReq.OrdNavMP[utils.MetaExp].Field(el.Value); err != nil {
+			return
+		}
+		itm, isNMItem := nmIt.(*config.NMItem)
+		if !isNMItem {
+			return nil, fmt.Errorf("cannot encode reply value: %s, err: not NMItems", utils.ToJSON(el.Value))
+		}
+		if itm == nil {
+			continue
+		}
+		valMp[strings.Join(itm.Path, utils.NestingSep)] = itm.Data
+	}
+	return
+}
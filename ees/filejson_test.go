@@ -0,0 +1,113 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package ees
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFileJSONeeExportEvent(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].ID = "json_exporter"
+	cgrCfg.EEsCfg().Exporters[0].Type = utils.MetaFileJSON
+	cgrCfg.EEsCfg().Exporters[0].ExportPath = t.TempDir()
+	newDM := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cgrCfg.CacheCfg(), nil)
+	filterS := engine.NewFilterS(cgrCfg, nil, newDM)
+	ee, err := NewEventExporter(cgrCfg, 0, filterS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fJSON, canCast := ee.(*FileJSONee)
+	if !canCast {
+		t.Fatalf("unexpected exporter: %T", ee)
+	}
+	if fJSON.ID() != "json_exporter" {
+		t.Errorf("unexpected ID: %s", fJSON.ID())
+	}
+	for _, ev := range []*utils.CGREvent{
+		{Tenant: "cgrates.org", ID: "ev1", Event: map[string]interface{}{utils.AccountField: "1001"}},
+		{Tenant: "cgrates.org", ID: "ev2", Event: map[string]interface{}{utils.AccountField: "1002"}},
+	} {
+		if err := fJSON.ExportEvent(ev); err != nil {
+			t.Error(err)
+		}
+	}
+	filePath := utils.IfaceAsString(ee.GetMetrics()[utils.ExportPath])
+	fJSON.OnEvicted(utils.EmptyString, nil)
+	body, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rcv []map[string]interface{}
+	if err := json.Unmarshal(body, &rcv); err != nil {
+		t.Fatalf("%s: %q", err, body)
+	}
+	exp := []map[string]interface{}{
+		{utils.AccountField: "1001"},
+		{utils.AccountField: "1002"},
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestFileJSONeeContentFields(t *testing.T) {
+	cgrCfg := config.NewDefaultCGRConfig()
+	cgrCfg.EEsCfg().Exporters[0].ExportPath = t.TempDir()
+	cgrCfg.EEsCfg().Exporters[0].Fields = []*config.FCTemplate{
+		{
+			Path: "*exp.Account", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Account", utils.InfieldSep),
+		},
+		{
+			Path: "*exp.Total", Type: utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.Total", utils.InfieldSep),
+		},
+	}
+	for _, field := range cgrCfg.EEsCfg().Exporters[0].Fields {
+		field.ComputePath()
+	}
+	cgrCfg.EEsCfg().Exporters[0].ComputeFields()
+	newDM := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), cgrCfg.CacheCfg(), nil)
+	filterS := engine.NewFilterS(cgrCfg, nil, newDM)
+	exp := map[string]interface{}{
+		utils.AccountField: "1001",
+		utils.Total:        "10.5",
+	}
+	if rcv, err := composeExportMap(cgrCfg, 0, filterS, utils.MapStorage{}, &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev1",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Total:        10.5,
+			utils.Subtotal:     10.5,
+		},
+	}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -107,6 +107,7 @@ func getActionFunc(typ string) (actionTypeFunc, bool) {
 		utils.MetaResetThreshold:          resetThreshold,
 		utils.MetaResetStatQueue:          resetStatQueue,
 		utils.MetaRemoteSetAccount:        remoteSetAccount,
+		utils.MetaDiameterPolicyCounters:  diameterPolicyCounters,
 	}
 	f, exists := actionFuncMap[typ]
 	return f, exists
@@ -1068,6 +1069,20 @@ func resetStatQueue(ub *Account, a *Action, acs Actions, extraData interface{})
 		utils.StatSv1ResetStatQueue, args, &rply)
 }
 
+// diameterPolicyCounters asks the DiameterAgent to re-evaluate the Sy policy counters,
+// limited to the Session-Ids in ExtraParameters or all the subscriptions if empty
+func diameterPolicyCounters(ub *Account, a *Action, acs Actions, extraData interface{}) (err error) {
+	args := &utils.UpdatePolicyCountersArgs{
+		Tenant: config.CgrConfig().GeneralCfg().DefaultTenant,
+	}
+	if a.ExtraParameters != utils.EmptyString {
+		args.SessionIDs = strings.Split(a.ExtraParameters, utils.InfieldSep)
+	}
+	var rply string
+	return connMgr.Call(config.CgrConfig().SchedulerCfg().DiameterAgentConns, nil,
+		utils.DiameterAgentV1UpdatePolicyCounters, args, &rply)
+}
+
 func remoteSetAccount(ub *Account, a *Action, acs Actions, extraData interface{}) (err error) {
 	client := &http.Client{Transport: httpPstrTransport}
 	var resp *http.Response
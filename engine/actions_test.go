@@ -2770,6 +2770,50 @@ func TestRemoteSetAccountAction(t *testing.T) {
 	ts.Close()
 }
 
+type diamPolicyCountersMock struct {
+	args *utils.UpdatePolicyCountersArgs
+}
+
+func (d *diamPolicyCountersMock) Call(method string, args interface{}, rply interface{}) error {
+	if method != utils.DiameterAgentV1UpdatePolicyCounters {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	d.args = args.(*utils.UpdatePolicyCountersArgs)
+	*rply.(*string) = utils.OK
+	return nil
+}
+
+func TestDiameterPolicyCountersAction(t *testing.T) {
+	mock := new(diamPolicyCountersMock)
+	dfltCfg := config.NewDefaultCGRConfig()
+	dfltCfg.SchedulerCfg().DiameterAgentConns = []string{"diamConn"}
+	config.SetCgrConfig(dfltCfg)
+	defer config.SetCgrConfig(config.NewDefaultCGRConfig())
+	internalChan := make(chan rpcclient.ClientConnector, 1)
+	internalChan <- mock
+	NewConnManager(dfltCfg, map[string]chan rpcclient.ClientConnector{
+		"diamConn": internalChan,
+	})
+	Cache.Clear([]string{utils.CacheRPCConnections})
+
+	if err := diameterPolicyCounters(nil, &Action{ActionType: utils.MetaDiameterPolicyCounters}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	exp := &utils.UpdatePolicyCountersArgs{Tenant: "cgrates.org"}
+	if !reflect.DeepEqual(exp, mock.args) {
+		t.Errorf("Expected: %s,received: %s", utils.ToJSON(exp), utils.ToJSON(mock.args))
+	}
+
+	if err := diameterPolicyCounters(nil, &Action{ActionType: utils.MetaDiameterPolicyCounters,
+		ExtraParameters: "sess1;sess2"}, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	exp.SessionIDs = []string{"sess1", "sess2"}
+	if !reflect.DeepEqual(exp, mock.args) {
+		t.Errorf("Expected: %s,received: %s", utils.ToJSON(exp), utils.ToJSON(mock.args))
+	}
+}
+
 /**************** Benchmarks ********************************/
 
 func BenchmarkUUID(b *testing.B) {
@@ -53,7 +53,9 @@ func (alS *AttributeService) Shutdown() {
 }
 
 // attributeProfileForEvent returns the matching attribute
-func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, attrsIDs []string, actTime *time.Time, evNm utils.MapStorage, lastID string) (matchAttrPrfl *AttributeProfile, err error) {
+// the candidate profiles are traced into prfTrcs if not nil
+func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, attrsIDs []string, actTime *time.Time, evNm utils.MapStorage, lastID string,
+	prfTrcs *ProfileTraces) (matchAttrPrfl *AttributeProfile, err error) {
 	var attrIDs []string
 	contextVal := utils.MetaDefault
 	if ctx != nil && *ctx != "" {
@@ -96,22 +98,29 @@ func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, a
 			}
 			return nil, err
 		}
+		pt := prfTrcs.newTrace(aPrfl.ID, aPrfl.Weight)
 		if !(len(aPrfl.Contexts) == 1 && aPrfl.Contexts[0] == utils.MetaAny) &&
 			!utils.IsSliceMember(aPrfl.Contexts, contextVal) {
+			pt.skip(utils.MetaContext)
 			continue
 		}
 		if aPrfl.ActivationInterval != nil && actTime != nil &&
 			!aPrfl.ActivationInterval.IsActiveAtTime(*actTime) { // not active
+			pt.skip(utils.MetaActivationInterval)
 			continue
 		}
-		if pass, err := alS.filterS.Pass(tnt, aPrfl.FilterIDs,
-			evNm); err != nil {
+		if pass, err := alS.filterS.passWithTrace(tnt, aPrfl.FilterIDs,
+			evNm, pt.filterTraces()); err != nil {
 			return nil, err
 		} else if !pass {
+			pt.skip(utils.MetaFilters)
 			continue
 		}
-		if (matchAttrPrfl == nil || matchAttrPrfl.Weight < aPrfl.Weight) &&
-			apID != lastID {
+		if apID == lastID {
+			pt.skip(utils.MetaLastMatched)
+			continue
+		}
+		if matchAttrPrfl == nil || matchAttrPrfl.Weight < aPrfl.Weight {
 			matchAttrPrfl = aPrfl
 		}
 	}
@@ -119,6 +128,10 @@ func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, a
 	if matchAttrPrfl == nil {
 		return nil, utils.ErrNotFound
 	}
+	if prfTrcs == nil {
+		hitCounters.Hit(utils.CacheAttributeProfiles, tnt, matchAttrPrfl.ID, matchAttrPrfl.FilterIDs...)
+	}
+	prfTrcs.selectIDs(matchAttrPrfl.ID)
 	return
 }
 
@@ -147,6 +160,32 @@ func (attrReply *AttrSProcessEventReply) Digest() (rplyDigest string) {
 	return
 }
 
+// AttrSubstitutionTrace is the trace of one attribute of the matched profile
+type AttrSubstitutionTrace struct {
+	Path    string
+	Type    string
+	Filters []*FilterTrace
+	Applied bool        // false if the attribute filters did not pass
+	Before  interface{} // the value of the field before the substitution
+	After   interface{} // the value of the field after the substitution
+}
+
+// AttrSRunTrace is the trace of one of the process runs
+type AttrSRunTrace struct {
+	Run            int
+	Profiles       ProfileTraces
+	MatchedProfile string
+	Substitutions  []*AttrSubstitutionTrace
+	Blocker        bool // the matched profile stopped the next runs
+}
+
+// AttrSTraceReply is the reply of V1TraceEvent
+type AttrSTraceReply struct {
+	Runs            []*AttrSRunTrace
+	Error           string // the error which stopped the processing
+	*utils.CGREvent        // the event as resulted from processing
+}
+
 // AttrArgsProcessEvent arguments used for proccess event
 type AttrArgsProcessEvent struct {
 	AttributeIDs []string
@@ -196,12 +235,21 @@ func (attr *AttrArgsProcessEvent) Clone() *AttrArgsProcessEvent {
 }
 
 // processEvent will match event with attribute profile and do the necessary replacements
-func (alS *AttributeService) processEvent(tnt string, args *AttrArgsProcessEvent, evNm utils.MapStorage, dynDP utils.DataProvider, lastID string) (
-	rply *AttrSProcessEventReply, err error) {
+// the decisions are traced into runTrc if not nil
+func (alS *AttributeService) processEvent(tnt string, args *AttrArgsProcessEvent, evNm utils.MapStorage, dynDP utils.DataProvider, lastID string,
+	runTrc *AttrSRunTrace) (rply *AttrSProcessEventReply, err error) {
+	var prfTrcs *ProfileTraces
+	if runTrc != nil {
+		prfTrcs = &runTrc.Profiles
+	}
 	var attrPrf *AttributeProfile
-	if attrPrf, err = alS.attributeProfileForEvent(tnt, args.Context, args.AttributeIDs, args.Time, evNm, lastID); err != nil {
+	if attrPrf, err = alS.attributeProfileForEvent(tnt, args.Context, args.AttributeIDs, args.Time, evNm, lastID, prfTrcs); err != nil {
 		return
 	}
+	if runTrc != nil {
+		runTrc.MatchedProfile = attrPrf.ID
+		runTrc.Blocker = attrPrf.Blocker
+	}
 	rply = &AttrSProcessEventReply{
 		MatchedProfiles: []string{attrPrf.ID},
 		CGREvent:        args.CGREvent,
@@ -209,11 +257,26 @@ func (alS *AttributeService) processEvent(tnt string, args *AttrArgsProcessEvent
 	}
 	rply.Tenant = tnt
 	for _, attribute := range attrPrf.Attributes {
+		var subTrc *AttrSubstitutionTrace
+		var fTrcs *[]*FilterTrace
+		if runTrc != nil {
+			subTrc = &AttrSubstitutionTrace{
+				Path: attribute.Path,
+				Type: attribute.Type,
+			}
+			if attribute.Path == utils.MetaTenant {
+				subTrc.Before = rply.CGREvent.Tenant
+			} else {
+				subTrc.Before, _ = evNm.FieldAsInterface(strings.Split(attribute.Path, utils.NestingSep))
+			}
+			fTrcs = &subTrc.Filters
+			runTrc.Substitutions = append(runTrc.Substitutions, subTrc)
+		}
 		//in case that we have filter for attribute send them to FilterS to be processed
 		if len(attribute.FilterIDs) != 0 {
 			var pass bool
-			if pass, err = alS.filterS.Pass(tnt, attribute.FilterIDs,
-				evNm); err != nil {
+			if pass, err = alS.filterS.passWithTrace(tnt, attribute.FilterIDs,
+				evNm, fTrcs); err != nil {
 				return
 			} else if !pass {
 				continue
@@ -391,10 +454,18 @@ func (alS *AttributeService) processEvent(tnt string, args *AttrArgsProcessEvent
 			} else {
 				rply.CGREvent.Tenant = substitute
 			}
+			if subTrc != nil {
+				subTrc.Applied = true
+				subTrc.After = rply.CGREvent.Tenant
+			}
 			continue
 		}
 		if substitute == utils.MetaRemove {
 			evNm.Remove(strings.Split(attribute.Path, utils.NestingSep))
+			if subTrc != nil {
+				subTrc.Applied = true
+				subTrc.After = utils.MetaRemove
+			}
 			continue
 		}
 		if attribute.Type == utils.MetaComposed {
@@ -409,6 +480,10 @@ func (alS *AttributeService) processEvent(tnt string, args *AttrArgsProcessEvent
 			rply = nil
 			return
 		}
+		if subTrc != nil {
+			subTrc.Applied = true
+			subTrc.After = substitute
+		}
 	}
 	return
 }
@@ -429,7 +504,7 @@ func (alS *AttributeService) V1GetAttributeForEvent(args *AttrArgsProcessEvent,
 		utils.MetaVars: utils.MapStorage{
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
-	}, utils.EmptyString)
+	}, utils.EmptyString, nil)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
@@ -453,12 +528,63 @@ func (alS *AttributeService) V1ProcessEvent(args *AttrArgsProcessEvent,
 	if tnt == utils.EmptyString {
 		tnt = alS.cgrcfg.GeneralCfg().DefaultTenant
 	}
+	args.CGREvent = args.CGREvent.Clone()
+	var matchedIDs []string
+	var alteredFields utils.StringSet
+	matchedIDs, alteredFields, err = alS.processRuns(tnt, args, nil)
+	if err == nil || err == utils.ErrNotFound {
+		// Make sure the requested fields were populated
+		for field, val := range args.CGREvent.Event {
+			if val == utils.MetaAttributes {
+				// mandatory IE missing
+				err = utils.NewErrMandatoryIeMissing(field)
+				return
+			}
+		}
+	} else {
+		return
+	}
+
+	*reply = AttrSProcessEventReply{
+		MatchedProfiles: matchedIDs,
+		AlteredFields:   alteredFields.AsSlice(),
+		CGREvent:        args.CGREvent,
+	}
+	return
+}
+
+// V1TraceEvent processes the event the same way as V1ProcessEvent
+// returning the decisions taken on each run instead of failing on errors
+func (alS *AttributeService) V1TraceEvent(args *AttrArgsProcessEvent,
+	reply *AttrSTraceReply) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = alS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	args.CGREvent = args.CGREvent.Clone()
+	trc := &AttrSTraceReply{Runs: make([]*AttrSRunTrace, 0)}
+	if _, _, err = alS.processRuns(tnt, args, trc); err != nil {
+		trc.Error = err.Error()
+	}
+	trc.CGREvent = args.CGREvent
+	*reply = *trc
+	return nil
+}
 
+// processRuns passes the event through the matching profiles for the configured number of runs
+// the runs are traced into trc if not nil
+func (alS *AttributeService) processRuns(tnt string, args *AttrArgsProcessEvent,
+	trc *AttrSTraceReply) (matchedIDs []string, alteredFields utils.StringSet, err error) {
 	processRuns := alS.cgrcfg.AttributeSCfg().ProcessRuns
 	if args.ProcessRuns != nil && *args.ProcessRuns != 0 {
 		processRuns = *args.ProcessRuns
 	}
-	args.CGREvent = args.CGREvent.Clone()
 	eNV := utils.MapStorage{
 		utils.MetaReq:  args.CGREvent.Event,
 		utils.MetaOpts: args.Opts,
@@ -467,14 +593,19 @@ func (alS *AttributeService) V1ProcessEvent(args *AttrArgsProcessEvent,
 		},
 	}
 	var lastID string
-	matchedIDs := make([]string, 0, processRuns)
-	alteredFields := make(utils.StringSet)
+	matchedIDs = make([]string, 0, processRuns)
+	alteredFields = make(utils.StringSet)
 	dynDP := newDynamicDP(alS.cgrcfg.AttributeSCfg().ResourceSConns,
 		alS.cgrcfg.AttributeSCfg().StatSConns, alS.cgrcfg.AttributeSCfg().ApierSConns, args.Tenant, eNV)
 	for i := 0; i < processRuns; i++ {
 		(eNV[utils.MetaVars].(utils.MapStorage))[utils.ProcessRuns] = utils.NewNMData(i + 1)
+		var runTrc *AttrSRunTrace
+		if trc != nil {
+			runTrc = &AttrSRunTrace{Run: i + 1}
+			trc.Runs = append(trc.Runs, runTrc)
+		}
 		var evRply *AttrSProcessEventReply
-		evRply, err = alS.processEvent(tnt, args, eNV, dynDP, lastID)
+		evRply, err = alS.processEvent(tnt, args, eNV, dynDP, lastID, runTrc)
 		if err != nil {
 			if err != utils.ErrNotFound {
 				err = utils.NewErrServerError(err)
@@ -493,23 +624,5 @@ func (alS *AttributeService) V1ProcessEvent(args *AttrArgsProcessEvent,
 			break
 		}
 	}
-	if err == nil || err == utils.ErrNotFound {
-		// Make sure the requested fields were populated
-		for field, val := range args.CGREvent.Event {
-			if val == utils.MetaAttributes {
-				// mandatory IE missing
-				err = utils.NewErrMandatoryIeMissing(field)
-				return
-			}
-		}
-	} else {
-		return
-	}
-
-	*reply = AttrSProcessEventReply{
-		MatchedProfiles: matchedIDs,
-		AlteredFields:   alteredFields.AsSlice(),
-		CGREvent:        args.CGREvent,
-	}
 	return
 }
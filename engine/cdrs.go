@@ -347,8 +347,9 @@ func (cdrS *CDRServer) refundEventCost(ec *EventCost, reqType, tor string) (rfnd
 	return true, nil
 }
 
-// chrgrSProcessEvent forks CGREventWithOpts into multiples based on matching ChargerS profiles
-func (cdrS *CDRServer) chrgrSProcessEvent(cgrEv *utils.CGREvent) (cgrEvs []*utils.CGREvent, err error) {
+// chrgrSProcessEvent forks the event with ChargerS, returning also the aggregations indexed on RunID
+func (cdrS *CDRServer) chrgrSProcessEvent(cgrEv *utils.CGREvent) (cgrEvs []*utils.CGREvent,
+	aggrs map[string][]*ChargerAggregation, err error) {
 	var chrgrs []*ChrgSProcessEventReply
 	if err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().ChargerSConns, nil,
 		utils.ChargerSv1ProcessEvent,
@@ -361,6 +362,77 @@ func (cdrS *CDRServer) chrgrSProcessEvent(cgrEv *utils.CGREvent) (cgrEvs []*util
 	cgrEvs = make([]*utils.CGREvent, len(chrgrs))
 	for i, cgrPrfl := range chrgrs {
 		cgrEvs[i] = cgrPrfl.CGREvent
+		if len(cgrPrfl.Aggregations) == 0 {
+			continue
+		}
+		if aggrs == nil {
+			aggrs = make(map[string][]*ChargerAggregation)
+		}
+		runID := utils.IfaceAsString(cgrPrfl.CGREvent.Event[utils.RunID])
+		aggrs[runID] = append(aggrs[runID], cgrPrfl.Aggregations...)
+	}
+	return
+}
+
+// aggregateRuns populates the fields aggregated out of the costs of the runs forked by ChargerS,
+// returning the events with negative results which need to be processed by ThresholdS
+// the runs are known only when forked here so the CDRs of the runs forked by SessionS are not aggregated
+func (cdrS *CDRServer) aggregateRuns(cdrs []*CDR, cgrEvs []*utils.CGREvent,
+	aggrs map[string][]*ChargerAggregation) (thArgs []*ThresholdsArgsProcessEvent) {
+	runCosts := make(map[string]float64)
+	for _, cdr := range cdrs {
+		if cdr == nil || cdr.Cost == -1 { // not rated
+			continue
+		}
+		runCosts[cdr.RunID] = cdr.Cost
+	}
+	for i, cdr := range cdrs {
+		if cdr == nil || len(aggrs[cdr.RunID]) == 0 {
+			continue
+		}
+		evNm := utils.MapStorage{
+			utils.MetaReq:  cgrEvs[i].Event,
+			utils.MetaOpts: cgrEvs[i].Opts,
+		}
+		var thIDs []string
+		var aggregated bool
+		for _, aggr := range aggrs[cdr.RunID] {
+			if pass, err := cdrS.filterS.Pass(cdr.Tenant, aggr.FilterIDs, evNm); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> checking filters of aggregation <%s> on CDR %+v",
+						utils.CDRs, err.Error(), aggr.Path, utils.ToJSON(cdr)))
+				continue
+			} else if !pass {
+				continue
+			}
+			val, err := aggr.Aggregate(runCosts)
+			if err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> computing aggregation <%s> on CDR %+v",
+						utils.CDRs, err.Error(), aggr.Path, utils.ToJSON(cdr)))
+				continue
+			}
+			if cdr.ExtraFields == nil {
+				cdr.ExtraFields = make(map[string]string)
+			}
+			cdr.ExtraFields[aggr.Path] = val.String()
+			aggregated = true
+			if val.Sign() < 0 {
+				thIDs = append(thIDs, aggr.ThresholdIDs...)
+			}
+		}
+		if !aggregated {
+			continue
+		}
+		cgrEv := cdr.AsCGREvent()
+		cgrEv.Opts = cgrEvs[i].Opts
+		cgrEvs[i] = cgrEv
+		if len(thIDs) != 0 {
+			thArgs = append(thArgs, &ThresholdsArgsProcessEvent{
+				ThresholdIDs: thIDs,
+				CGREvent:     cgrEv,
+			})
+		}
 	}
 	return
 }
@@ -396,12 +468,13 @@ func (cdrS *CDRServer) attrSProcessEvent(cgrEv *utils.CGREvent) (err error) {
 	return
 }
 
-// thdSProcessEvent will send the event to ThresholdS
-func (cdrS *CDRServer) thdSProcessEvent(cgrEv *utils.CGREvent) (err error) {
+// thdSProcessEvent will send the event to ThresholdS, limiting the processing to thIDs if not empty
+func (cdrS *CDRServer) thdSProcessEvent(cgrEv *utils.CGREvent, thIDs []string) (err error) {
 	var tIDs []string
 	// we clone the CGREvent so we can add EventType without being propagated
 	thArgs := &ThresholdsArgsProcessEvent{
-		CGREvent: cgrEv.Clone(),
+		ThresholdIDs: thIDs,
+		CGREvent:     cgrEv.Clone(),
 	}
 	if thArgs.Opts == nil {
 		thArgs.Opts = make(map[string]interface{})
@@ -443,10 +516,38 @@ func (cdrS *CDRServer) eeSProcessEvent(cgrEv *utils.CGREventWithEeIDs) (err erro
 	return
 }
 
+// taxSProcessCDR applies the matching TaxProfiles on the CDR cost
+// the tax lines are kept in the ExtraFields so they can be exported
+func (cdrS *CDRServer) taxSProcessCDR(cdr *CDR) (applied bool, err error) {
+	if cdr.Cost < 0 { // not rated
+		return
+	}
+	var tcs []*utils.TaxCharge
+	if tcs, err = TaxesForEvent(cdrS.cgrCfg, cdrS.dm, cdrS.filterS,
+		cdr.AsCGREvent(), utils.NewDecimalFromFloat64(cdr.Cost)); err != nil {
+		if err == utils.ErrNotFound { // no taxes for this CDR
+			err = nil
+		}
+		return
+	}
+	extTcs := make([]*utils.ExtTaxCharge, len(tcs))
+	for i, tc := range tcs {
+		if extTcs[i], err = tc.AsExtTaxCharge(); err != nil {
+			return
+		}
+	}
+	if cdr.ExtraFields == nil {
+		cdr.ExtraFields = make(map[string]string)
+	}
+	cdr.ExtraFields[utils.TaxAmount] = utils.TaxesTotal(tcs).String()
+	cdr.ExtraFields[utils.Taxes] = utils.ToJSON(extTcs)
+	return true, nil
+}
+
 // processEvent processes a CGREvent based on arguments
 // in case of partially executed, both error and evs will be returned
 func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
-	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
+	chrgS, attrS, refund, ralS, taxS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
 	if attrS {
 		if err = cdrS.attrSProcessEvent(ev); err != nil {
 			utils.Logger.Warning(
@@ -457,8 +558,9 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 		}
 	}
 	var cgrEvs []*utils.CGREvent
+	var aggrs map[string][]*ChargerAggregation
 	if chrgS {
-		if cgrEvs, err = cdrS.chrgrSProcessEvent(ev); err != nil {
+		if cgrEvs, aggrs, err = cdrS.chrgrSProcessEvent(ev); err != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
 					utils.CDRs, err.Error(), utils.ToJSON(ev), utils.ChargerS))
@@ -483,9 +585,14 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 				me.GetStringIgnoreErrors(utils.RunID),
 			)
 			if Cache.HasItem(utils.CacheCDRIDs, uID) && !reRate {
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
-						utils.CDRs, utils.ErrExists, utils.ToJSON(cgrEv), utils.CacheS))
+						utils.CDRs, utils.ErrExists, utils.ToJSON(cgrEv), utils.CacheS),
+					utils.LogFields{
+						utils.LogFieldTenant:   cgrEv.Tenant,
+						utils.LogFieldCGRID:    me.GetStringIgnoreErrors(utils.CGRID),
+						utils.LogFieldOriginID: me.GetStringIgnoreErrors(utils.OriginID),
+					})
 				return nil, utils.ErrExists
 			}
 			if errCh := Cache.Set(utils.CacheCDRIDs, uID, true, nil,
@@ -496,7 +603,7 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 	}
 	// Populate CDR list out of events
 	cdrs := make([]*CDR, len(cgrEvs))
-	if refund || ralS || store || reRate || export {
+	if refund || ralS || taxS || store || reRate || export {
 		for i, cgrEv := range cgrEvs {
 			if cdrs[i], err = NewMapEvent(cgrEv.Event).AsCDR(cdrS.cgrCfg,
 				cgrEv.Tenant, cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
@@ -516,9 +623,14 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 		for i, cdr := range cdrs {
 			if rfnd, errRfd := cdrS.refundEventCost(cdr.CostDetails,
 				cdr.RequestType, cdr.ToR); errRfd != nil {
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> error: <%s> refunding CDR %+v",
-						utils.CDRs, errRfd.Error(), utils.ToJSON(cdr)))
+						utils.CDRs, errRfd.Error(), utils.ToJSON(cdr)),
+					utils.LogFields{
+						utils.LogFieldTenant:   cdr.Tenant,
+						utils.LogFieldCGRID:    cdr.CGRID,
+						utils.LogFieldOriginID: cdr.OriginID,
+					})
 			} else if rfnd {
 				procFlgs[i].Add(utils.MetaRefund)
 			}
@@ -543,6 +655,30 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 			}
 		}
 	}
+	if taxS {
+		for i, cdr := range cdrs {
+			var applied bool
+			if applied, err = cdrS.taxSProcessCDR(cdr); err != nil { // the CDR is already charged so it is stored without taxes
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> applying taxes on CDR %+v",
+						utils.CDRs, err.Error(), utils.ToJSON(cdr)))
+				cdr.ExtraInfo = err.Error()
+				err = nil
+			} else if !applied {
+				continue
+			}
+			cgrEv := cdr.AsCGREvent()
+			cgrEv.Opts = cgrEvs[i].Opts
+			cgrEvs[i] = cgrEv
+			if applied {
+				procFlgs[i].Add(utils.MetaTaxes)
+			}
+		}
+	}
+	var aggrThArgs []*ThresholdsArgsProcessEvent // negative aggregations processed by ThresholdS
+	if len(aggrs) != 0 {
+		aggrThArgs = cdrS.aggregateRuns(cdrs, cgrEvs, aggrs)
+	}
 	if store {
 		refundCDRCosts := func() { // will be used to refund all CDRs on errors
 			for _, cdr := range cdrs { // refund what we have charged since duplicates are not allowed
@@ -607,7 +743,7 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 	}
 	if thdS {
 		for _, cgrEv := range cgrEvs {
-			if err = cdrS.thdSProcessEvent(cgrEv); err != nil {
+			if err = cdrS.thdSProcessEvent(cgrEv, nil); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
 						utils.CDRs, err.Error(), utils.ToJSON(cgrEv), utils.ThresholdS))
@@ -615,6 +751,16 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREvent,
 			}
 		}
 	}
+	if len(aggrThArgs) != 0 && len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0 {
+		for _, thArgs := range aggrThArgs {
+			if err = cdrS.thdSProcessEvent(thArgs.CGREvent, thArgs.ThresholdIDs); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> processing negative aggregation of event %+v with %s",
+						utils.CDRs, err.Error(), utils.ToJSON(thArgs.CGREvent), utils.ThresholdS))
+				partiallyExecuted = true
+			}
+		}
+	}
 	if stS {
 		for _, cgrEv := range cgrEvs {
 			if err = cdrS.statSProcessEvent(cgrEv); err != nil {
@@ -712,6 +858,7 @@ func (cdrS *CDRServer) V1ProcessCDR(cdr *CDRWithOpts, reply *string) (err error)
 		len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0,
 		false,
 		!cdr.PreRated, // rate the CDR if is not PreRated
+		cdrS.cgrCfg.TaxSCfg().Enabled,
 		cdrS.cgrCfg.CdrsCfg().StoreCdrs,
 		false, // no rerate
 		len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0,
@@ -826,10 +973,14 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 	if flgs.Has(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	taxS := cdrS.cgrCfg.TaxSCfg().Enabled // apply the taxes after rating
+	if flgs.Has(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 	// end of processing options
 
 	if _, err = cdrS.processEvent(&arg.CGREvent, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, taxS, store, reRate, export, thdS, stS); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -902,11 +1053,15 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 	if flgs.Has(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	taxS := cdrS.cgrCfg.TaxSCfg().Enabled // apply the taxes after rating
+	if flgs.Has(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 	// end of processing options
 
 	var procEvs []*utils.EventWithFlags
 	if procEvs, err = cdrS.processEvent(&arg.CGREvent, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, taxS, store, reRate, export, thdS, stS); err != nil {
 		return
 	}
 	*evs = procEvs
@@ -1052,6 +1207,10 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 	if flgs.Has(utils.MetaAttributes) {
 		attrS = flgs.GetBool(utils.MetaAttributes)
 	}
+	taxS := cdrS.cgrCfg.TaxSCfg().Enabled
+	if flgs.Has(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 
 	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
 		return utils.NewErrNotConnected(utils.ChargerS)
@@ -1061,7 +1220,7 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 		cgrEv := cdr.AsCGREvent()
 		cgrEv.Opts = arg.Opts
 		if _, err = cdrS.processEvent(cgrEv, chrgS, attrS, false,
-			true, store, true, export, thdS, statS); err != nil {
+			true, taxS, store, true, export, thdS, statS); err != nil {
 			return utils.NewErrServerError(err)
 		}
 	}
@@ -1116,3 +1275,175 @@ func (cdrS *CDRServer) V1CountCDRs(args *utils.RPCCDRsFilterWithOpts, cnt *int64
 	*cnt = qryCnt
 	return nil
 }
+
+// billingRunCDRsLimit is the number of CDRs read at once out of StorDB by a billing run
+var billingRunCDRsLimit = 1000
+
+// processCDRsPaginated reads the CDRs matching the filter page by page, ordered by OrderID,
+// passing them one by one to the process function
+func (cdrS *CDRServer) processCDRsPaginated(fltr *utils.CDRsFilter, process func(*CDR) error) (err error) {
+	fltr.OrderBy = utils.OrderID
+	for offset := 0; ; {
+		pgFltr := *fltr // the internal StorDB consumes the filter slices on each query
+		pgFltr.Paginator = utils.Paginator{
+			Limit:  utils.IntPointer(billingRunCDRsLimit),
+			Offset: utils.IntPointer(offset),
+		}
+		var cdrs []*CDR
+		if cdrs, _, err = cdrS.cdrDb.GetCDRs(&pgFltr, false); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+			}
+			return
+		}
+		for _, cdr := range cdrs {
+			if err = process(cdr); err != nil {
+				return
+			}
+		}
+		if len(cdrs) < billingRunCDRsLimit {
+			return
+		}
+		offset += len(cdrs)
+	}
+}
+
+// V1BillingRun generates the invoices for the accounts out of the rated CDRs within the billing period
+// and the recurrent fees charged by the scheduled actions, logged as CDRs with *cdrlog
+// without Accounts in args all the accounts with CDRs in the period are billed
+func (cdrS *CDRServer) V1BillingRun(args *ArgsBillingRun, invoices *[]*Invoice) (err error) {
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	if args.PeriodStart == utils.EmptyString || args.PeriodEnd == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.PeriodStart, utils.PeriodEnd)
+	}
+	var pStart, pEnd time.Time
+	if pStart, err = utils.ParseTimeDetectLayout(args.PeriodStart,
+		cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if pEnd, err = utils.ParseTimeDetectLayout(args.PeriodEnd,
+		cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	runIDs := args.RunIDs
+	if len(runIDs) == 0 {
+		runIDs = []string{utils.MetaDefault}
+	}
+	feeRunIDs := args.FeeRunIDs
+	if len(feeRunIDs) == 0 {
+		feeRunIDs = []string{utils.MetaDebit, utils.MetaDebitReset}
+	}
+	cdrLogSources := []string{utils.CDRLog, utils.MetaCdrLog}
+	invs := make(map[string]*Invoice)
+	for _, acnt := range args.Accounts {
+		invs[acnt] = NewInvoice(tnt, acnt, pStart, pEnd)
+	}
+	invoice := func(acnt string) (inv *Invoice) {
+		var has bool
+		if inv, has = invs[acnt]; !has {
+			inv = NewInvoice(tnt, acnt, pStart, pEnd)
+			invs[acnt] = inv
+		}
+		return
+	}
+	if err = cdrS.processCDRsPaginated(&utils.CDRsFilter{
+		Tenants:         []string{tnt},
+		Accounts:        args.Accounts,
+		RunIDs:          runIDs,
+		NotSources:      cdrLogSources,
+		AnswerTimeStart: &pStart,
+		AnswerTimeEnd:   &pEnd,
+		MinCost:         utils.Float64Pointer(0),
+	}, func(cdr *CDR) error {
+		return invoice(cdr.Account).AddCDR(cdr)
+	}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if err = cdrS.processCDRsPaginated(&utils.CDRsFilter{
+		Tenants:         []string{tnt},
+		Accounts:        args.Accounts,
+		RunIDs:          feeRunIDs,
+		Sources:         cdrLogSources,
+		AnswerTimeStart: &pStart,
+		AnswerTimeEnd:   &pEnd,
+		MinCost:         utils.Float64Pointer(0),
+	}, func(cdr *CDR) error {
+		return invoice(cdr.Account).AddRecurrentFee(cdr)
+	}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	store := args.Store == nil || *args.Store
+	rply := make([]*Invoice, 0, len(invs))
+	for _, inv := range invs {
+		if len(inv.Lines) == 0 { // nothing to bill
+			continue
+		}
+		inv.Compute(cdrS.cgrCfg.GeneralCfg().RoundingDecimals)
+		inv.CreatedAt = time.Now()
+		if store {
+			if err = cdrS.cdrDb.SetInvoice(inv); err != nil {
+				return utils.NewErrServerError(err)
+			}
+		}
+		rply = append(rply, inv)
+	}
+	if len(rply) == 0 {
+		return utils.ErrNotFound
+	}
+	SortInvoices(rply)
+	if args.Export {
+		for _, inv := range rply {
+			cgrEv := inv.AsCGREvent()
+			for k, v := range args.Opts {
+				cgrEv.Opts[k] = v
+			}
+			if err = cdrS.eeSProcessEvent(&utils.CGREventWithEeIDs{
+				CGREvent: cgrEv,
+				EeIDs:    args.EeIDs,
+			}); err != nil {
+				return utils.NewErrServerError(err)
+			}
+		}
+	}
+	*invoices = rply
+	return
+}
+
+// V1GetInvoices returns the invoices stored in StorDB
+func (cdrS *CDRServer) V1GetInvoices(args *utils.InvoicesFilterWithOpts, invoices *[]*Invoice) (err error) {
+	fltr := args.InvoicesFilter
+	if fltr == nil {
+		fltr = new(utils.InvoicesFilter)
+	}
+	if fltr.Tenant == utils.EmptyString {
+		fltr.Tenant = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	var invs []*Invoice
+	if invs, err = cdrS.cdrDb.GetInvoices(fltr); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*invoices = invs
+	return
+}
+
+// V1RemoveInvoices removes the invoices from StorDB
+func (cdrS *CDRServer) V1RemoveInvoices(args *utils.InvoicesFilterWithOpts, reply *string) (err error) {
+	fltr := args.InvoicesFilter
+	if fltr == nil {
+		fltr = new(utils.InvoicesFilter)
+	}
+	if fltr.Tenant == utils.EmptyString {
+		fltr.Tenant = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	if err = cdrS.cdrDb.RemoveInvoices(fltr); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return
+}
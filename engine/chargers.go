@@ -46,7 +46,9 @@ func (cS *ChargerService) Shutdown() {
 }
 
 // matchingChargingProfilesForEvent returns ordered list of matching chargers which are active by the time of the function call
-func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *utils.CGREvent) (cPs ChargerProfiles, err error) {
+// the candidate profiles are traced into prfTrcs if not nil
+func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *utils.CGREvent,
+	prfTrcs *ProfileTraces) (cPs ChargerProfiles, err error) {
 	evNm := utils.MapStorage{
 		utils.MetaReq:  cgrEv.Event,
 		utils.MetaOpts: cgrEv.Opts,
@@ -71,14 +73,17 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *uti
 			}
 			return nil, err
 		}
+		pt := prfTrcs.newTrace(cP.ID, cP.Weight)
 		if cP.ActivationInterval != nil && cgrEv.Time != nil &&
 			!cP.ActivationInterval.IsActiveAtTime(*cgrEv.Time) { // not active
+			pt.skip(utils.MetaActivationInterval)
 			continue
 		}
-		if pass, err := cS.filterS.Pass(tnt, cP.FilterIDs,
-			evNm); err != nil {
+		if pass, err := cS.filterS.passWithTrace(tnt, cP.FilterIDs,
+			evNm, pt.filterTraces()); err != nil {
 			return nil, err
 		} else if !pass {
+			pt.skip(utils.MetaFilters)
 			continue
 		}
 		matchingCPs[cpID] = cP
@@ -93,6 +98,17 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *uti
 		i++
 	}
 	cPs.Sort()
+	if prfTrcs != nil {
+		ids := make([]string, len(cPs))
+		for i, cP := range cPs {
+			ids[i] = cP.ID
+		}
+		prfTrcs.selectIDs(ids...)
+	} else {
+		for _, cP := range cPs {
+			hitCounters.Hit(utils.CacheChargerProfiles, tnt, cP.ID, cP.FilterIDs...)
+		}
+	}
 	return
 }
 
@@ -102,6 +118,7 @@ type ChrgSProcessEventReply struct {
 	AttributeSProfiles []string
 	AlteredFields      []string
 	CGREvent           *utils.CGREvent
+	Aggregations       []*ChargerAggregation // aggregations computed by CDRs on this run
 }
 
 func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply []*ChrgSProcessEventReply, err error) {
@@ -112,7 +129,7 @@ func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply
 			processRuns = utils.IntPointer(int(v))
 		}
 	}
-	if cPs, err = cS.matchingChargerProfilesForEvent(tnt, cgrEv); err != nil {
+	if cPs, err = cS.matchingChargerProfilesForEvent(tnt, cgrEv, nil); err != nil {
 		return nil, err
 	}
 	rply = make([]*ChrgSProcessEventReply, len(cPs))
@@ -125,6 +142,7 @@ func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply
 			ChargerSProfile: cP.ID,
 			CGREvent:        clonedEv,
 			AlteredFields:   []string{utils.MetaReqRunID},
+			Aggregations:    cP.Aggregations,
 		}
 		if len(cP.AttributeIDs) == 1 && cP.AttributeIDs[0] == utils.MetaNone {
 			continue // AttributeS disabled
@@ -184,7 +202,7 @@ func (cS *ChargerService) V1GetChargersForEvent(args *utils.CGREvent,
 	if tnt == utils.EmptyString {
 		tnt = cS.cfg.GeneralCfg().DefaultTenant
 	}
-	cPs, err := cS.matchingChargerProfilesForEvent(tnt, args)
+	cPs, err := cS.matchingChargerProfilesForEvent(tnt, args, nil)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
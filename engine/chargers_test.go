@@ -287,18 +287,18 @@ func TestChargerMatchingChargerProfilesForEvent(t *testing.T) {
 		}
 	}
 
-	if _, err = chargerSrv.matchingChargerProfilesForEvent(chargerEvents[2].Tenant, chargerEvents[2]); err == nil ||
+	if _, err = chargerSrv.matchingChargerProfilesForEvent(chargerEvents[2].Tenant, chargerEvents[2], nil); err == nil ||
 		err.Error() != utils.ErrNotFound.Error() {
 		t.Errorf("Error: %+v", err)
 	}
 
-	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[0].Tenant, chargerEvents[0]); err != nil {
+	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[0].Tenant, chargerEvents[0], nil); err != nil {
 		t.Errorf("Error: %+v", err)
 	} else if !reflect.DeepEqual(cPPs[0], rcv[0]) {
 		t.Errorf("Expecting: %+v, received: %+v ", cPPs[0], rcv[0])
 	}
 
-	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[1].Tenant, chargerEvents[1]); err != nil {
+	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[1].Tenant, chargerEvents[1], nil); err != nil {
 		t.Errorf("Error: %+v", err)
 	} else if !reflect.DeepEqual(cPPs[1], rcv[0]) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(cPPs[1]), utils.ToJSON(rcv))
@@ -454,18 +454,18 @@ func TestChargerProcessEvent(t *testing.T) {
 		}
 	}
 
-	if _, err = chargerSrv.matchingChargerProfilesForEvent(chargerEvents[2].Tenant, chargerEvents[2]); err == nil ||
+	if _, err = chargerSrv.matchingChargerProfilesForEvent(chargerEvents[2].Tenant, chargerEvents[2], nil); err == nil ||
 		err.Error() != utils.ErrNotFound.Error() {
 		t.Errorf("Error: %+v", err)
 	}
 
-	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[0].Tenant, chargerEvents[0]); err != nil {
+	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[0].Tenant, chargerEvents[0], nil); err != nil {
 		t.Errorf("Error: %+v", err)
 	} else if !reflect.DeepEqual(cPPs[0], rcv[0]) {
 		t.Errorf("Expecting: %+v, received: %+v ", cPPs[0], rcv[0])
 	}
 
-	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[1].Tenant, chargerEvents[1]); err != nil {
+	if rcv, err := chargerSrv.matchingChargerProfilesForEvent(chargerEvents[1].Tenant, chargerEvents[1], nil); err != nil {
 		t.Errorf("Error: %+v", err)
 	} else if !reflect.DeepEqual(cPPs[1], rcv[0]) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(cPPs[1]), utils.ToJSON(rcv))
@@ -486,3 +486,128 @@ func TestChargerProcessEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v ", utils.ToJSON(rpl[0]), utils.ToJSON(rcv[0]))
 	}
 }
+
+func TestChargerAggregationAggregate(t *testing.T) {
+	runCosts := map[string]float64{
+		utils.MetaDefault: 1.2,
+		"supplier":        0.7,
+		"reseller":        0.2,
+	}
+	aggr := &ChargerAggregation{
+		Path:   "Margin",
+		Type:   utils.MetaDifference,
+		RunIDs: []string{utils.MetaDefault, "supplier", "reseller"},
+	}
+	if val, err := aggr.Aggregate(runCosts); err != nil {
+		t.Error(err)
+	} else if val.String() != "0.3" {
+		t.Errorf("Expected 0.3, received %s", val)
+	}
+	aggr.Type = utils.MetaSum
+	if val, err := aggr.Aggregate(runCosts); err != nil {
+		t.Error(err)
+	} else if val.String() != "2.1" {
+		t.Errorf("Expected 2.1, received %s", val)
+	}
+	aggr.RunIDs = []string{utils.MetaDefault, "carrier"}
+	if _, err := aggr.Aggregate(runCosts); err == nil || err.Error() != "NOT_FOUND:carrier" {
+		t.Errorf("Expected NOT_FOUND:carrier, received %v", err)
+	}
+	aggr.RunIDs = nil
+	if _, err := aggr.Aggregate(runCosts); err == nil || err.Error() != "MANDATORY_IE_MISSING: [RunIDs]" {
+		t.Errorf("Expected MANDATORY_IE_MISSING: [RunIDs], received %v", err)
+	}
+	aggr.Type = "*average"
+	expErr := "unsupported aggregation type: <*average>"
+	if _, err := aggr.Aggregate(runCosts); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+}
+
+func TestChargerAggregationValidate(t *testing.T) {
+	aggr := &ChargerAggregation{
+		Path:   "Margin",
+		Type:   utils.MetaDifference,
+		RunIDs: []string{utils.MetaDefault, "supplier"},
+	}
+	if err := aggr.Validate(); err != nil {
+		t.Error(err)
+	}
+	aggr.Type = "*difference;*sum"
+	expErr := "unsupported aggregation type: <*difference;*sum>"
+	if err := aggr.Validate(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+	aggr.Type = utils.MetaSum
+	aggr.RunIDs = nil
+	if err := aggr.Validate(); err == nil || err.Error() != "MANDATORY_IE_MISSING: [RunIDs]" {
+		t.Errorf("Expected MANDATORY_IE_MISSING: [RunIDs], received %v", err)
+	}
+}
+
+func TestCDRsAggregateRuns(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmAggr := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		dm:      dmAggr,
+		filterS: NewFilterS(cfg, nil, dmAggr),
+	}
+	cdrs := []*CDR{
+		{CGRID: "cgrid1", RunID: utils.MetaDefault, Tenant: "aggr.org",
+			Account: "1001", Destination: "1002", Cost: 0.5},
+		{CGRID: "cgrid1", RunID: "supplier", Tenant: "aggr.org",
+			Account: "1001", Destination: "1002", Cost: 0.8},
+		{CGRID: "cgrid1", RunID: "reseller", Tenant: "aggr.org",
+			Account: "1001", Destination: "1002", Cost: -1}, // not rated
+	}
+	cgrEvs := make([]*utils.CGREvent, len(cdrs))
+	for i, cdr := range cdrs {
+		cgrEvs[i] = cdr.AsCGREvent()
+	}
+	aggrs := map[string][]*ChargerAggregation{
+		utils.MetaDefault: {
+			{
+				Path:         "Margin",
+				Type:         utils.MetaDifference,
+				RunIDs:       []string{utils.MetaDefault, "supplier"},
+				ThresholdIDs: []string{"THD_NEGATIVE_MARGIN"},
+			},
+			{
+				Path:      "ResellerMargin",
+				Type:      utils.MetaDifference,
+				RunIDs:    []string{utils.MetaDefault, "reseller"},
+				FilterIDs: []string{"*string:~*req.Account:1001"},
+			},
+			{
+				Path:      "PremiumMargin",
+				Type:      utils.MetaDifference,
+				RunIDs:    []string{utils.MetaDefault, "supplier"},
+				FilterIDs: []string{"*string:~*req.Category:premium"},
+			},
+		},
+	}
+	thArgs := cdrS.aggregateRuns(cdrs, cgrEvs, aggrs)
+	if exp := map[string]string{"Margin": "-0.3"}; !reflect.DeepEqual(exp, cdrs[0].ExtraFields) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(cdrs[0].ExtraFields))
+	}
+	if cdrs[1].ExtraFields != nil {
+		t.Errorf("Unexpected aggregation on supplier run: %s", utils.ToJSON(cdrs[1].ExtraFields))
+	}
+	if cgrEvs[0].Event["Margin"] != "-0.3" {
+		t.Errorf("Expected the event to be updated, received %s", utils.ToJSON(cgrEvs[0]))
+	}
+	if len(thArgs) != 1 {
+		t.Fatalf("Expected one event for ThresholdS, received %s", utils.ToJSON(thArgs))
+	} else if !reflect.DeepEqual(thArgs[0].ThresholdIDs, []string{"THD_NEGATIVE_MARGIN"}) ||
+		thArgs[0].CGREvent != cgrEvs[0] {
+		t.Errorf("Unexpected threshold args: %s", utils.ToJSON(thArgs[0]))
+	}
+	cdrs[1].Cost = 0.4
+	cgrEvs[0] = cdrs[0].AsCGREvent()
+	if thArgs = cdrS.aggregateRuns(cdrs, cgrEvs, aggrs); len(thArgs) != 0 {
+		t.Errorf("Unexpected threshold args: %s", utils.ToJSON(thArgs))
+	} else if cdrs[0].ExtraFields["Margin"] != "0.1" {
+		t.Errorf("Expected 0.1, received %s", utils.ToJSON(cdrs[0].ExtraFields))
+	}
+}
@@ -145,6 +145,12 @@ func (cM *ConnManager) Call(connIDs []string, biRPCClient rpcclient.BiRPCConecto
 	if len(connIDs) == 0 {
 		return utils.NewErrMandatoryIeMissing("connIDs")
 	}
+	if utils.TracingEnabled() {
+		var span *utils.Span
+		span, arg = utils.StartRPCSpan(arg, method, utils.SpanKindClient)
+		span.SetAttribute(utils.TraceConnIDs, connIDs)
+		defer func() { span.End(err) }()
+	}
 	var conn rpcclient.ClientConnector
 	for _, connID := range connIDs {
 		if conn, err = cM.getConn(connID, biRPCClient); err != nil {
@@ -166,6 +172,12 @@ func (cM *ConnManager) CallWithConnIDs(connIDs []string, subsHostIDs utils.Strin
 	if subsHostIDs.Size() == 0 {
 		return
 	}
+	if utils.TracingEnabled() {
+		var span *utils.Span
+		span, arg = utils.StartRPCSpan(arg, method, utils.SpanKindClient)
+		span.SetAttribute(utils.TraceConnIDs, connIDs)
+		defer func() { span.End(err) }()
+	}
 	var conn rpcclient.ClientConnector
 	for _, connID := range connIDs {
 		// recreate the config with only conns that are needed
@@ -24,7 +24,7 @@ import (
 
 type DataDBMock struct{}
 
-//Storage methods
+// Storage methods
 func (dbM *DataDBMock) Close() {}
 
 func (dbM *DataDBMock) Flush(string) error {
@@ -59,7 +59,7 @@ func (dbM *DataDBMock) IsDBEmpty() (resp bool, err error) {
 	return false, utils.ErrNotImplemented
 }
 
-//DataDB methods
+// DataDB methods
 func (dbM *DataDBMock) HasDataDrv(string, string, string) (bool, error) {
 	return false, utils.ErrNotImplemented
 }
@@ -418,6 +418,110 @@ func (dbM *DataDBMock) RemoveAccountProfileDrv(string, string) error {
 	return utils.ErrNotImplemented
 }
 
+func (dbM *DataDBMock) GetExchangeRateProfileDrv(string, string) (*utils.ExchangeRateProfile, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetExchangeRateProfileDrv(*utils.ExchangeRateProfile) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveExchangeRateProfileDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetRateVolumeCounterDrv(string, string) (*RateVolumeCounter, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRateVolumeCounterDrv(*RateVolumeCounter) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRateVolumeCounterDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetRateDeckDrv(string, string) (*RateDeck, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRateDeckDrv(*RateDeck) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRateDeckDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetTaxProfileDrv(string, string) (*TaxProfile, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetTaxProfileDrv(*TaxProfile) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveTaxProfileDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetLookupTableDrv(string, string) (*LookupTable, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetLookupTableDrv(*LookupTable) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveLookupTableDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetProfileHitsDrv(string, string) (*ProfileHits, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetProfileHitsDrv(*ProfileHits) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveProfileHitsDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetRateProfileVersionsDrv(string, string) (*RateProfileVersions, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetRateProfileVersionsDrv(*RateProfileVersions) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveRateProfileVersionsDrv(string, string) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetLoadVersionDrv(string, int) (*LoadVersion, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetLoadVersionDrv(*LoadVersion) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveLoadVersionDrv(string, int) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) GetActiveLoadVersionDrv(string) (int, error) {
+	return 0, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetActiveLoadVersionDrv(string, int) error {
+	return utils.ErrNotImplemented
+}
+
 func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 	return utils.ErrNotImplemented
 }
@@ -425,3 +529,15 @@ func (dbM *DataDBMock) SetVersions(vrs Versions, overwrite bool) (err error) {
 func (dbM *DataDBMock) RemoveRatingProfileDrv(string) error {
 	return utils.ErrNotImplemented
 }
+
+func (dbM *DataDBMock) GetConfigSectionsDrv(string, []string) (map[string][]byte, error) {
+	return nil, utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) SetConfigSectionsDrv(string, map[string][]byte) error {
+	return utils.ErrNotImplemented
+}
+
+func (dbM *DataDBMock) RemoveConfigSectionsDrv(string, []string) error {
+	return utils.ErrNotImplemented
+}
@@ -16,6 +16,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -41,6 +42,7 @@ var (
 		utils.ActionPlanIndexes:             {},
 		utils.FilterIndexPrfx:               {},
 		utils.AccountProfileFilterIndexPrfx: {},
+		utils.TaxProfilesFilterIndexPrfx:    {},
 	}
 	cachePrefixMap = utils.StringSet{
 		utils.DestinationPrefix:             {},
@@ -67,6 +69,10 @@ var (
 		utils.DispatcherHostPrefix:          {},
 		utils.RateProfilePrefix:             {},
 		utils.ActionProfilePrefix:           {},
+		utils.ExchangeRateProfilePrefix:     {},
+		utils.RateDeckPrefix:                {},
+		utils.TaxProfilePrefix:              {},
+		utils.LookupTablePrefix:             {},
 		utils.AttributeFilterIndexes:        {},
 		utils.ResourceFilterIndexes:         {},
 		utils.StatFilterIndexes:             {},
@@ -77,6 +83,7 @@ var (
 		utils.RateProfilesFilterIndexPrfx:   {},
 		utils.ActionProfilesFilterIndexPrfx: {},
 		utils.RateFilterIndexPrfx:           {},
+		utils.TaxProfilesFilterIndexPrfx:    {},
 		utils.FilterIndexPrfx:               {},
 		utils.MetaAPIBan:                    {}, // not realy a prefix as this is not stored in DB
 	}
@@ -229,6 +236,18 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.ActionProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetActionProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.ExchangeRateProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetExchangeRateProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.RateDeckPrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetRateDeck(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.TaxProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetTaxProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.LookupTablePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetLookupTable(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 		case utils.AttributeFilterIndexes:
 			var tntCtx, idxKey string
 			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
@@ -295,6 +314,12 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 				return
 			}
 			_, err = dm.GetIndexes(utils.CacheAccountProfilesFilterIndexes, tntCtx, idxKey, false, true)
+		case utils.TaxProfilesFilterIndexPrfx:
+			var tntCtx, idxKey string
+			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
+				return
+			}
+			_, err = dm.GetIndexes(utils.CacheTaxProfilesFilterIndexes, tntCtx, idxKey, false, true)
 		case utils.FilterIndexPrfx:
 			idx := strings.LastIndexByte(dataID, utils.InInFieldSep[0])
 			if idx < 0 {
@@ -827,6 +852,13 @@ func (dm *DataManager) RemoveStatQueue(tenant, id string, transactionID string)
 func (dm *DataManager) GetFilter(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (fltr *Filter, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaFilters, utils.CacheFilters,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*Filter), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheFilters, tntID); ok {
 			if x == nil {
@@ -1062,6 +1094,13 @@ func (dm *DataManager) RemoveThreshold(tenant, id, transactionID string) (err er
 func (dm *DataManager) GetThresholdProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (th *ThresholdProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaThresholds, utils.CacheThresholdProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*ThresholdProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheThresholdProfiles, tntID); ok {
 			if x == nil {
@@ -1190,6 +1229,13 @@ func (dm *DataManager) RemoveThresholdProfile(tenant, id,
 func (dm *DataManager) GetStatQueueProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (sqp *StatQueueProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaStats, utils.CacheStatQueueProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*StatQueueProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheStatQueueProfiles, tntID); ok {
 			if x == nil {
@@ -1525,6 +1571,13 @@ func (dm *DataManager) RemoveResource(tenant, id, transactionID string) (err err
 func (dm *DataManager) GetResourceProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (rp *ResourceProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaResources, utils.CacheResourceProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*ResourceProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheResourceProfiles, tntID); ok {
 			if x == nil {
@@ -1720,7 +1773,7 @@ func (dm *DataManager) RemoveActionTriggers(id, transactionID string) (err error
 	return
 }
 
-//SetActionTriggersArgWithOpts is used to send the key and the ActionTriggers to Replicator
+// SetActionTriggersArgWithOpts is used to send the key and the ActionTriggers to Replicator
 type SetActionTriggersArgWithOpts struct {
 	Key    string
 	Attrs  ActionTriggers
@@ -1899,7 +1952,7 @@ func (dm *DataManager) GetActions(key string, skipCache bool, transactionID stri
 	return
 }
 
-//SetActionsArgsWithOpts is used to send the key and the Actions to replicator
+// SetActionsArgsWithOpts is used to send the key and the Actions to replicator
 type SetActionsArgsWithOpts struct {
 	Key    string
 	Acs    Actions
@@ -2079,7 +2132,7 @@ func (dm *DataManager) GetAccountActionPlans(acntID string,
 	return
 }
 
-//SetAccountActionPlansArgWithOpts is used to send the key and the Actions to replicator
+// SetAccountActionPlansArgWithOpts is used to send the key and the Actions to replicator
 type SetAccountActionPlansArgWithOpts struct {
 	AcntID    string
 	AplIDs    []string
@@ -2342,6 +2395,13 @@ func (dm *DataManager) HasData(category, subject, tenant string) (has bool, err
 func (dm *DataManager) GetRouteProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (rpp *RouteProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaRoutes, utils.CacheRouteProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*RouteProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheRouteProfiles, tntID); ok {
 			if x == nil {
@@ -2473,6 +2533,13 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id, transactionID string, with
 func (dm *DataManager) GetAttributeProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (attrPrfl *AttributeProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaAttributes, utils.CacheAttributeProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*AttributeProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheAttributeProfiles, tntID); ok {
 			if x == nil {
@@ -2610,6 +2677,13 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, transactionID s
 func (dm *DataManager) GetChargerProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (cpp *ChargerProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaChargers, utils.CacheChargerProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*ChargerProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheChargerProfiles, tntID); ok {
 			if x == nil {
@@ -2738,6 +2812,13 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string,
 func (dm *DataManager) GetDispatcherProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (dpp *DispatcherProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaDispatchers, utils.CacheDispatcherProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*DispatcherProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheDispatcherProfiles, tntID); ok {
 			if x == nil {
@@ -2870,6 +2951,13 @@ func (dm *DataManager) RemoveDispatcherProfile(tenant, id string,
 func (dm *DataManager) GetDispatcherHost(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (dH *DispatcherHost, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaDispatcherHosts, utils.CacheDispatcherHosts,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*DispatcherHost), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheDispatcherHosts, tntID); ok {
 			if x == nil {
@@ -2965,6 +3053,463 @@ func (dm *DataManager) RemoveDispatcherHost(tenant, id string,
 	return
 }
 
+func (dm *DataManager) GetExchangeRateProfile(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (erp *utils.ExchangeRateProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaExchangeRateProfiles, utils.CacheExchangeRateProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*utils.ExchangeRateProfile), nil
+	}
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheExchangeRateProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*utils.ExchangeRateProfile), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if erp, err = dm.dataDB.GetExchangeRateProfileDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheExchangeRateProfiles, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if err = Cache.Set(utils.CacheExchangeRateProfiles, tntID, erp, nil,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (dm *DataManager) SetExchangeRateProfile(erp *utils.ExchangeRateProfile) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	erp.Sort()
+	return dm.DataDB().SetExchangeRateProfileDrv(erp)
+}
+
+func (dm *DataManager) RemoveExchangeRateProfile(tenant, id string,
+	transactionID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldErp, err := dm.GetExchangeRateProfile(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveExchangeRateProfileDrv(tenant, id); err != nil {
+		return
+	}
+	if oldErp == nil {
+		return utils.ErrNotFound
+	}
+	return
+}
+
+// GetRateVolumeCounter returns the volume counter of an account, not cached since it changes with each rated event
+func (dm *DataManager) GetRateVolumeCounter(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetRateVolumeCounterDrv(tenant, id)
+}
+
+func (dm *DataManager) SetRateVolumeCounter(rvc *RateVolumeCounter) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().SetRateVolumeCounterDrv(rvc)
+}
+
+func (dm *DataManager) RemoveRateVolumeCounter(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().RemoveRateVolumeCounterDrv(tenant, id)
+}
+
+// GetProfileHits returns the stored hits of a profile, not cached since they change with each match
+func (dm *DataManager) GetProfileHits(tenant, id string) (ph *ProfileHits, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetProfileHitsDrv(tenant, id)
+}
+
+func (dm *DataManager) SetProfileHits(ph *ProfileHits) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().SetProfileHitsDrv(ph)
+}
+
+func (dm *DataManager) RemoveProfileHits(tenant, id string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().RemoveProfileHitsDrv(tenant, id)
+}
+
+func (dm *DataManager) GetLoadVersion(ldrID string, version int) (lv *LoadVersion, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.dataDB.GetLoadVersionDrv(ldrID, version)
+}
+
+func (dm *DataManager) SetLoadVersion(lv *LoadVersion) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().SetLoadVersionDrv(lv)
+}
+
+func (dm *DataManager) RemoveLoadVersion(ldrID string, version int) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().RemoveLoadVersionDrv(ldrID, version)
+}
+
+// GetActiveLoadVersion returns the last load version switched in by the loader, 0 if none
+func (dm *DataManager) GetActiveLoadVersion(ldrID string) (version int, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if version, err = dm.dataDB.GetActiveLoadVersionDrv(ldrID); err == utils.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+// SetActiveLoadVersion switches the loader to the given load version
+func (dm *DataManager) SetActiveLoadVersion(ldrID string, version int) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	return dm.DataDB().SetActiveLoadVersionDrv(ldrID, version)
+}
+
+// stagedLoadProfile returns the profile as it was before the atomic loads which are not switched in
+// the atomic loads write their profiles after staging the load version and switch to it
+// with the single write of the active version, so the readers caching the profiles resolve
+// the items of the versions above the active one to their previous profile
+func (dm *DataManager) stagedLoadProfile(ldrType, cacheID, tntID string,
+	cacheRead, cacheWrite bool) (prf interface{}, staged bool, err error) {
+	if dm == nil || !cacheWrite { // the profiles are read for update
+		return
+	}
+	if _, isInternal := dm.dataDB.(*InternalDB); !isInternal && cacheRead {
+		if _, has := Cache.Get(cacheID, tntID); has { // the caches are reloaded once the load is switched in
+			return
+		}
+	}
+	for _, ldrCfg := range config.CgrConfig().LoaderCfg() {
+		if !ldrCfg.Atomic {
+			continue
+		}
+		var itm *LoadVersionItem
+		if itm, err = dm.stagedLoadItem(ldrCfg.ID, ldrType, tntID); err != nil || itm != nil {
+			if err != nil {
+				return nil, true, err
+			}
+			if itm.Previous == nil {
+				return nil, true, utils.ErrNotFound
+			}
+			if prf, err = NewLoaderProfile(ldrType); err != nil {
+				return nil, true, err
+			}
+			if err = json.Unmarshal(itm.Previous, prf); err != nil {
+				return nil, true, err
+			}
+			if cPrf, canCompile := prf.(interface{ Compile() error }); canCompile {
+				err = cPrf.Compile()
+			}
+			return prf, true, err
+		}
+	}
+	return
+}
+
+// stagedLoadItem returns the item of the first load version above the active one of the loader, nil if not staged
+func (dm *DataManager) stagedLoadItem(ldrID, ldrType, tntID string) (itm *LoadVersionItem, err error) {
+	var active int
+	if active, err = dm.GetActiveLoadVersion(ldrID); err != nil {
+		return
+	}
+	for version := active + 1; ; version++ {
+		var lv *LoadVersion
+		if lv, err = dm.dataDB.GetLoadVersionDrv(ldrID, version); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+			}
+			return
+		}
+		for _, lvItm := range lv.Items {
+			if lvItm.LoaderType == ldrType && lvItm.TenantID == tntID {
+				return lvItm, nil
+			}
+		}
+	}
+}
+
+func (dm *DataManager) GetRateDeck(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (rd *RateDeck, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaRateDecks, utils.CacheRateDecks,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*RateDeck), nil
+	}
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheRateDecks, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*RateDeck), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if rd, err = dm.dataDB.GetRateDeckDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheRateDecks, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if err = rd.Compile(); err != nil {
+		return nil, err
+	}
+	if cacheWrite {
+		if err = Cache.Set(utils.CacheRateDecks, tntID, rd, nil,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// SetRateDeck stores the RateDeck, deferring the changed rates based on the notice periods
+func (dm *DataManager) SetRateDeck(rd *RateDeck) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldRd, err := dm.GetRateDeck(rd.Tenant, rd.ID, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	rd.ApplyNotices(oldRd, time.Now())
+	if err = rd.Compile(); err != nil {
+		return
+	}
+	return dm.DataDB().SetRateDeckDrv(rd)
+}
+
+func (dm *DataManager) RemoveRateDeck(tenant, id string,
+	transactionID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldRd, err := dm.GetRateDeck(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveRateDeckDrv(tenant, id); err != nil {
+		return
+	}
+	if oldRd == nil {
+		return utils.ErrNotFound
+	}
+	return
+}
+
+func (dm *DataManager) GetTaxProfile(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (tp *TaxProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaTaxProfiles, utils.CacheTaxProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*TaxProfile), nil
+	}
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheTaxProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*TaxProfile), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if tp, err = dm.dataDB.GetTaxProfileDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheTaxProfiles, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if err = Cache.Set(utils.CacheTaxProfiles, tntID, tp, nil,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (dm *DataManager) SetTaxProfile(tp *TaxProfile, withIndex bool) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if withIndex {
+		if brokenReference := dm.checkFilters(tp.Tenant, tp.FilterIDs); len(brokenReference) != 0 {
+			// if we get a broken filter do not set the profile
+			return fmt.Errorf("broken reference to filter: %+v for item with ID: %+v",
+				brokenReference, tp.TenantID())
+		}
+	}
+	oldTp, err := dm.GetTaxProfile(tp.Tenant, tp.ID, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().SetTaxProfileDrv(tp); err != nil {
+		return err
+	}
+	if withIndex {
+		var oldFiltersIDs *[]string
+		if oldTp != nil {
+			oldFiltersIDs = &oldTp.FilterIDs
+		}
+		if err := updatedIndexes(dm, utils.CacheTaxProfilesFilterIndexes, tp.Tenant,
+			utils.EmptyString, tp.ID, oldFiltersIDs, tp.FilterIDs, false); err != nil {
+			return err
+		}
+	}
+	return
+}
+
+func (dm *DataManager) RemoveTaxProfile(tenant, id string,
+	transactionID string, withIndex bool) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldTp, err := dm.GetTaxProfile(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveTaxProfileDrv(tenant, id); err != nil {
+		return
+	}
+	if oldTp == nil {
+		return utils.ErrNotFound
+	}
+	if withIndex {
+		if err = removeItemFromFilterIndex(dm, utils.CacheTaxProfilesFilterIndexes,
+			tenant, utils.EmptyString, id, oldTp.FilterIDs); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (dm *DataManager) GetLookupTable(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (lt *LookupTable, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaLookupTables, utils.CacheLookupTables,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*LookupTable), nil
+	}
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheLookupTables, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*LookupTable), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if lt, err = dm.dataDB.GetLookupTableDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheLookupTables, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if err = Cache.Set(utils.CacheLookupTables, tntID, lt, nil,
+			cacheCommit(transactionID), transactionID); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (dm *DataManager) SetLookupTable(lt *LookupTable) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	if err = lt.Compile(); err != nil {
+		return
+	}
+	return dm.DataDB().SetLookupTableDrv(lt)
+}
+
+func (dm *DataManager) RemoveLookupTable(tenant, id string,
+	transactionID string) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	oldLt, err := dm.GetLookupTable(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveLookupTableDrv(tenant, id); err != nil {
+		return
+	}
+	if oldLt == nil {
+		return utils.ErrNotFound
+	}
+	return
+}
+
 func (dm *DataManager) GetItemLoadIDs(itemIDPrefix string, cacheWrite bool) (loadIDs map[string]int64, err error) {
 	if dm == nil {
 		err = utils.ErrNoDatabaseConn
@@ -3039,6 +3584,13 @@ func (dm *DataManager) SetLoadIDs(loadIDs map[string]int64) (err error) {
 func (dm *DataManager) GetRateProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (rpp *RateProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaRateProfiles, utils.CacheRateProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*RateProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheRateProfiles, tntID); ok {
 			if x == nil {
@@ -3082,6 +3634,11 @@ func (dm *DataManager) GetRateProfile(tenant, id string, cacheRead, cacheWrite b
 		return nil, err
 	}
 	if cacheWrite {
+		// refresh the cached history together with the profile
+		if _, errVrs := dm.GetRateProfileVersions(tenant, id, false, true, transactionID); errVrs != nil &&
+			errVrs != utils.ErrNotFound {
+			return nil, errVrs
+		}
 		if errCh := Cache.Set(utils.CacheRateProfiles, tntID, rpp, nil,
 			cacheCommit(transactionID), transactionID); errCh != nil {
 			return nil, errCh
@@ -3105,17 +3662,28 @@ func (dm *DataManager) SetRateProfile(rpp *RateProfile, withIndex bool) (err err
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	var oldFiltersIDs *[]string
+	if withIndex && oldRpp != nil {
+		var oldFltrIDs []string
+		if oldFltrIDs, err = dm.RateProfileFilterIDs(oldRpp); err != nil {
+			return err
+		}
+		oldFiltersIDs = &oldFltrIDs
+	}
 	rpp.Sort()
+	if err = dm.setRateProfileVersion(rpp, oldRpp); err != nil {
+		return err
+	}
 	if err = dm.DataDB().SetRateProfileDrv(rpp); err != nil {
 		return err
 	}
 	if withIndex {
-		var oldFiltersIDs *[]string
-		if oldRpp != nil {
-			oldFiltersIDs = &oldRpp.FilterIDs
+		var fltrIDs []string
+		if fltrIDs, err = dm.RateProfileFilterIDs(rpp); err != nil {
+			return err
 		}
 		if err := updatedIndexes(dm, utils.CacheRateProfilesFilterIndexes, rpp.Tenant,
-			utils.EmptyString, rpp.ID, oldFiltersIDs, rpp.FilterIDs, false); err != nil {
+			utils.EmptyString, rpp.ID, oldFiltersIDs, fltrIDs, false); err != nil {
 			return err
 		}
 		// remove indexes for old rates
@@ -3168,9 +3736,20 @@ func (dm *DataManager) RemoveRateProfile(tenant, id string,
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	var oldFltrIDs []string
+	if withIndex && oldRpp != nil {
+		if oldFltrIDs, err = dm.RateProfileFilterIDs(oldRpp); err != nil {
+			return
+		}
+	}
 	if err = dm.DataDB().RemoveRateProfileDrv(tenant, id); err != nil {
 		return
 	}
+	if err = dm.DataDB().RemoveRateProfileVersionsDrv(tenant, id); err != nil &&
+		err != utils.ErrNotFound {
+		return
+	}
+	err = nil
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
@@ -3182,7 +3761,7 @@ func (dm *DataManager) RemoveRateProfile(tenant, id string,
 			}
 		}
 		if err = removeItemFromFilterIndex(dm, utils.CacheRateProfilesFilterIndexes,
-			tenant, utils.EmptyString, id, oldRpp.FilterIDs); err != nil {
+			tenant, utils.EmptyString, id, oldFltrIDs); err != nil {
 			return
 		}
 	}
@@ -3207,6 +3786,7 @@ func (dm *DataManager) RemoveRateProfileRates(tenant, id string, rateIDs []strin
 	if err != nil {
 		return err
 	}
+	prvRpp := oldRpp.Clone()
 	if len(rateIDs) == 0 {
 		if withIndex {
 			for key, rate := range oldRpp.Rates {
@@ -3232,6 +3812,9 @@ func (dm *DataManager) RemoveRateProfileRates(tenant, id string, rateIDs []strin
 			delete(oldRpp.Rates, rateID)
 		}
 	}
+	if err = dm.setRateProfileVersion(oldRpp, prvRpp); err != nil {
+		return err
+	}
 	if err = dm.DataDB().SetRateProfileDrv(oldRpp); err != nil {
 		return err
 	}
@@ -3266,6 +3849,7 @@ func (dm *DataManager) SetRateProfileRates(rpp *RateProfile, withIndex bool) (er
 	if err != nil {
 		return err
 	}
+	prvRpp := oldRpp.Clone()
 	// create index for each rate
 	for key, rate := range rpp.Rates {
 		if withIndex {
@@ -3281,7 +3865,9 @@ func (dm *DataManager) SetRateProfileRates(rpp *RateProfile, withIndex bool) (er
 		}
 		oldRpp.Rates[key] = rate
 	}
-
+	if err = dm.setRateProfileVersion(oldRpp, prvRpp); err != nil {
+		return err
+	}
 	if err = dm.DataDB().SetRateProfileDrv(oldRpp); err != nil {
 		return err
 	}
@@ -3299,9 +3885,92 @@ func (dm *DataManager) SetRateProfileRates(rpp *RateProfile, withIndex bool) (er
 	return
 }
 
+// GetRateProfileVersions returns the history of a RateProfile
+// the cached history is refreshed each time the RateProfile is written in cache
+func (dm *DataManager) GetRateProfileVersions(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (rpvs *RateProfileVersions, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheRateProfileVersions, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*RateProfileVersions), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if rpvs, err = dm.dataDB.GetRateProfileVersionsDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Remove(utils.CacheRateProfileVersions, tntID,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if err = rpvs.Compile(); err != nil {
+		return nil, err
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheRateProfileVersions, tntID, rpvs, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// RateProfileFilterIDs returns the FilterIDs the RateProfile is indexed on,
+// the ones of its older versions included so these are still matched when rating in the past
+func (dm *DataManager) RateProfileFilterIDs(rp *RateProfile) (fltrIDs []string, err error) {
+	var rpvs *RateProfileVersions
+	if rpvs, err = dm.GetRateProfileVersions(rp.Tenant, rp.ID,
+		false, false, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+	}
+	return rpvs.FilterIDs(rp), nil
+}
+
+// setRateProfileVersion records the RateProfile within its history
+// prvRpp is the profile stored before, kept as first version for the profiles without history
+func (dm *DataManager) setRateProfileVersion(rpp, prvRpp *RateProfile) (err error) {
+	var rpvs *RateProfileVersions
+	if rpvs, err = dm.GetRateProfileVersions(rpp.Tenant, rpp.ID,
+		false, false, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		rpvs = &RateProfileVersions{Tenant: rpp.Tenant, ID: rpp.ID}
+		if prvRpp != nil {
+			rpvs.Versions = []*RateProfileVersion{{RateProfile: prvRpp.Clone()}}
+		}
+	}
+	prvStored := rpvs.Stored
+	tNow := time.Now()
+	added := rpvs.AddVersion(rpp.Clone(), tNow)
+	if !rpvs.Trim(config.CgrConfig().RateSCfg().ProfileVersionsLimit, tNow) &&
+		!added && rpvs.Stored.Equal(prvStored) {
+		return
+	}
+	return dm.DataDB().SetRateProfileVersionsDrv(rpvs)
+}
+
 func (dm *DataManager) GetActionProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (ap *ActionProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
+	if stgPrf, staged, errStg := dm.stagedLoadProfile(utils.MetaActionProfiles, utils.CacheActionProfiles,
+		tntID, cacheRead, cacheWrite); staged {
+		if errStg != nil {
+			return nil, errStg
+		}
+		return stgPrf.(*ActionProfile), nil
+	}
 	if cacheRead {
 		if x, ok := Cache.Get(utils.CacheActionProfiles, tntID); ok {
 			if x == nil {
@@ -165,6 +165,12 @@ func (dH *DispatcherHost) Call(serviceMethod string, args interface{}, reply int
 		}
 
 	}
+	if utils.TracingEnabled() {
+		var span *utils.Span
+		span, args = utils.StartRPCSpan(args, serviceMethod, utils.SpanKindClient)
+		span.SetAttribute(utils.TraceDispatcherHost, dH.TenantID())
+		defer func() { span.End(err) }()
+	}
 	return dH.rpcConn.Call(serviceMethod, args, reply)
 }
 
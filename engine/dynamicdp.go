@@ -18,6 +18,7 @@ package engine
 import (
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/nyaruka/phonenumbers"
 
@@ -131,6 +132,20 @@ func (dDP *dynamicDP) fieldAsInterface(fldPath []string) (val interface{}, err e
 		}
 		dDP.cache.Set(fldPath[:2], dp)
 		return dp.FieldAsInterface(fldPath[2:])
+	case utils.MetaLookup:
+		// sample of fieldName ~*lookup.PORTED_NUMBERS.<~*req.Destination>
+		if len(fldPath) < 3 {
+			return nil, fmt.Errorf("invalid fieldname <%s>", fldPath)
+		}
+		var lt *LookupTable
+		if lt, err = dm.GetLookupTable(dDP.tenant, fldPath[1], true, true, utils.NonTransactional); err != nil {
+			return
+		}
+		if val, err = lt.Lookup(strings.Join(fldPath[2:], utils.NestingSep)); err != nil {
+			return
+		}
+		dDP.cache.Set(fldPath, val)
+		return
 	case utils.MetaAsm:
 		// sample of fieldName ~*asm.BalanceSummaries.HolidayBalance.Value
 		stringReq, err := dDP.initialDP.FieldAsString([]string{utils.MetaReq})
@@ -49,6 +49,12 @@ type FilterS struct {
 // receives the event as DataProvider so we can accept undecoded data (ie: HttpRequest)
 func (fS *FilterS) Pass(tenant string, filterIDs []string,
 	ev utils.DataProvider) (pass bool, err error) {
+	return fS.passWithTrace(tenant, filterIDs, ev, nil)
+}
+
+// passWithTrace is the Pass version which records the result of each filter into fTrcs, if not nil
+func (fS *FilterS) passWithTrace(tenant string, filterIDs []string,
+	ev utils.DataProvider, fTrcs *[]*FilterTrace) (pass bool, err error) {
 	if len(filterIDs) == 0 {
 		return true, nil
 	}
@@ -63,15 +69,29 @@ func (fS *FilterS) Pass(tenant string, filterIDs []string,
 			}
 			return false, err
 		}
+		var fTrc *FilterTrace
+		if fTrcs != nil {
+			fTrc = &FilterTrace{FilterID: fltrID}
+			*fTrcs = append(*fTrcs, fTrc)
+		}
 		if f.ActivationInterval != nil &&
 			!f.ActivationInterval.IsActiveAtTime(time.Now()) { // not active
+			if fTrc != nil {
+				fTrc.Inactive = true
+			}
 			continue
 		}
 		for _, fltr := range f.Rules {
 			if pass, err = fltr.Pass(dDP); err != nil || !pass {
+				if fTrc != nil {
+					fTrc.FailedRule = filterRuleString(fltr)
+				}
 				return pass, err
 			}
 		}
+		if fTrc != nil {
+			fTrc.Pass = true
+		}
 		pass = true
 	}
 	return
@@ -32,6 +32,7 @@ var (
 	dm                *DataManager
 	cdrStorage        CdrStorage
 	connMgr           *ConnManager
+	hitCounters       *HitCounters
 )
 
 func init() {
@@ -49,6 +50,21 @@ func SetConnManager(conMgr *ConnManager) {
 	connMgr = conMgr
 }
 
+// SetHitCounters sets the counters used to record the profile matches
+func SetHitCounters(hc *HitCounters) {
+	hitCounters = hc
+}
+
+// RecordProfileHit counts one match of the profile and of its filters, used by the subsystems outside engine
+func RecordProfileHit(prfType, tnt, prfID string, fltrIDs ...string) {
+	hitCounters.Hit(prfType, tnt, prfID, fltrIDs...)
+}
+
+// StoreProfileHits stores the pending profile hits so they can be queried from DataDB
+func StoreProfileHits() {
+	hitCounters.StoreHits()
+}
+
 // SetCdrStorage sets the database for CDR storing, used by *cdrlog in first place
 func SetCdrStorage(cStorage CdrStorage) {
 	cdrStorage = cStorage
@@ -0,0 +1,243 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ArgsBillingRun are the arguments used to generate the invoices for a billing period
+type ArgsBillingRun struct {
+	Tenant      string
+	Accounts    []string // bill only these accounts, all the accounts with CDRs in the period or with AccountProfiles if empty
+	RunIDs      []string // the runs of the billed CDRs, defaults to *default so the CDRs of the other ChargerS runs are not billed again
+	FeeRunIDs   []string // the action types of the *cdrlog CDRs billed as recurrent fees, defaults to *debit and *debit_reset
+	PeriodStart string
+	PeriodEnd   string
+	Store       *bool    // store the invoices in StorDB, defaults to true
+	Export      bool     // export the invoices via EEs
+	EeIDs       []string // selective exporters
+	Opts        map[string]interface{}
+}
+
+// Invoice aggregates the charges of one account over a billing period
+type Invoice struct {
+	Tenant      string
+	ID          string
+	Account     string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Lines       []*InvoiceLine
+	Taxes       []*InvoiceTax
+	Subtotal    float64 // sum of the lines, without taxes
+	TaxTotal    float64
+	Total       float64
+	CreatedAt   time.Time
+}
+
+// InvoiceLine groups the charges with the same category and destination
+type InvoiceLine struct {
+	Type        string // *cdrs or *recurrent
+	Category    string // the name of the fee for *recurrent lines
+	Destination string
+	Quantity    int64
+	Usage       time.Duration
+	Amount      float64
+}
+
+// InvoiceTax aggregates the taxes with the same jurisdiction and type
+type InvoiceTax struct {
+	Jurisdiction string
+	TaxType      string
+	Amount       float64
+}
+
+// NewInvoice returns an empty invoice for the account and period
+// the ID is computed out of the account and period so a new billing run will overwrite the invoice
+func NewInvoice(tnt, acnt string, pStart, pEnd time.Time) *Invoice {
+	return &Invoice{
+		Tenant:      tnt,
+		ID:          utils.Sha1(tnt, acnt, pStart.UTC().String(), pEnd.UTC().String()),
+		Account:     acnt,
+		PeriodStart: pStart,
+		PeriodEnd:   pEnd,
+	}
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (inv *Invoice) TenantID() string {
+	return utils.ConcatenatedKey(inv.Tenant, inv.ID)
+}
+
+// addLine adds the charge to the line matching type, category and destination
+func (inv *Invoice) addLine(lnType, category, dst string, usage time.Duration, amount float64) {
+	for _, ln := range inv.Lines {
+		if ln.Type == lnType &&
+			ln.Category == category &&
+			ln.Destination == dst {
+			ln.Quantity++
+			ln.Usage += usage
+			ln.Amount += amount
+			return
+		}
+	}
+	inv.Lines = append(inv.Lines, &InvoiceLine{
+		Type:        lnType,
+		Category:    category,
+		Destination: dst,
+		Quantity:    1,
+		Usage:       usage,
+		Amount:      amount,
+	})
+}
+
+// addTax adds the amount to the tax matching jurisdiction and type
+func (inv *Invoice) addTax(jurisdiction, taxType string, amount float64) {
+	for _, tx := range inv.Taxes {
+		if tx.Jurisdiction == jurisdiction &&
+			tx.TaxType == taxType {
+			tx.Amount += amount
+			return
+		}
+	}
+	inv.Taxes = append(inv.Taxes, &InvoiceTax{
+		Jurisdiction: jurisdiction,
+		TaxType:      taxType,
+		Amount:       amount,
+	})
+}
+
+// AddCDR adds the cost of a rated CDR together with the taxes applied on it by CDRs
+func (inv *Invoice) AddCDR(cdr *CDR) (err error) {
+	inv.addLine(utils.MetaCDRs, cdr.Category, cdr.Destination, cdr.Usage, cdr.Cost)
+	return inv.addCDRTaxes(cdr)
+}
+
+// AddRecurrentFee adds the fee charged by a scheduled action and logged as CDR with *cdrlog,
+// named after the CDR category or the action type if the category is missing
+func (inv *Invoice) AddRecurrentFee(cdr *CDR) (err error) {
+	inv.addLine(utils.MetaRecurrent, utils.FirstNonEmpty(cdr.Category, cdr.RunID),
+		utils.EmptyString, 0, cdr.Cost)
+	return inv.addCDRTaxes(cdr)
+}
+
+// addCDRTaxes adds the taxes applied by CDRs on the CDR
+func (inv *Invoice) addCDRTaxes(cdr *CDR) (err error) {
+	txs, has := cdr.ExtraFields[utils.Taxes]
+	if !has || txs == utils.EmptyString {
+		return
+	}
+	var extTcs []*utils.ExtTaxCharge
+	if err = json.Unmarshal([]byte(txs), &extTcs); err != nil {
+		return
+	}
+	for _, tc := range extTcs {
+		if tc.Amount == nil {
+			continue
+		}
+		inv.addTax(tc.Jurisdiction, tc.TaxType, *tc.Amount)
+	}
+	return
+}
+
+// Compute sorts the lines and computes the totals, rounding them to the given decimals
+func (inv *Invoice) Compute(roundingDecimals int) {
+	sort.SliceStable(inv.Lines, func(i, j int) bool {
+		if inv.Lines[i].Type != inv.Lines[j].Type {
+			return inv.Lines[i].Type < inv.Lines[j].Type
+		}
+		if inv.Lines[i].Category != inv.Lines[j].Category {
+			return inv.Lines[i].Category < inv.Lines[j].Category
+		}
+		return inv.Lines[i].Destination < inv.Lines[j].Destination
+	})
+	inv.Subtotal, inv.TaxTotal = 0, 0
+	for _, ln := range inv.Lines {
+		ln.Amount = utils.Round(ln.Amount, roundingDecimals, utils.MetaRoundingMiddle)
+		inv.Subtotal += ln.Amount
+	}
+	for _, tx := range inv.Taxes {
+		tx.Amount = utils.Round(tx.Amount, roundingDecimals, utils.MetaRoundingMiddle)
+		inv.TaxTotal += tx.Amount
+	}
+	inv.Subtotal = utils.Round(inv.Subtotal, roundingDecimals, utils.MetaRoundingMiddle)
+	inv.TaxTotal = utils.Round(inv.TaxTotal, roundingDecimals, utils.MetaRoundingMiddle)
+	inv.Total = utils.Round(inv.Subtotal+inv.TaxTotal, roundingDecimals, utils.MetaRoundingMiddle)
+}
+
+// AsCGREvent converts the Invoice into an event which can be exported by EEs
+func (inv *Invoice) AsCGREvent() *utils.CGREvent {
+	return &utils.CGREvent{
+		Tenant: inv.Tenant,
+		ID:     inv.ID,
+		Time:   utils.TimePointer(inv.PeriodEnd),
+		Event: map[string]interface{}{
+			utils.EventType:    utils.MetaInvoice,
+			utils.Tenant:       inv.Tenant,
+			utils.InvoiceID:    inv.ID,
+			utils.AccountField: inv.Account,
+			utils.PeriodStart:  inv.PeriodStart,
+			utils.PeriodEnd:    inv.PeriodEnd,
+			utils.Lines:        inv.Lines,
+			utils.Taxes:        inv.Taxes,
+			utils.Subtotal:     inv.Subtotal,
+			utils.TaxAmount:    inv.TaxTotal,
+			utils.Total:        inv.Total,
+			utils.CreatedAt:    inv.CreatedAt,
+		},
+		Opts: make(map[string]interface{}),
+	}
+}
+
+// PassInvoicesFilter checks if the invoice is selected by the filter
+func (inv *Invoice) PassInvoicesFilter(fltr *utils.InvoicesFilter) bool {
+	if fltr == nil {
+		return true
+	}
+	if fltr.Tenant != utils.EmptyString && fltr.Tenant != inv.Tenant {
+		return false
+	}
+	if len(fltr.IDs) != 0 && !utils.IsSliceMember(fltr.IDs, inv.ID) {
+		return false
+	}
+	if len(fltr.Accounts) != 0 && !utils.IsSliceMember(fltr.Accounts, inv.Account) {
+		return false
+	}
+	if fltr.PeriodStart != nil && inv.PeriodStart.Before(*fltr.PeriodStart) {
+		return false
+	}
+	if fltr.PeriodEnd != nil && inv.PeriodEnd.After(*fltr.PeriodEnd) {
+		return false
+	}
+	return true
+}
+
+// SortInvoices orders the invoices by account and period
+func SortInvoices(invs []*Invoice) {
+	sort.SliceStable(invs, func(i, j int) bool {
+		if invs[i].Account != invs[j].Account {
+			return invs[i].Account < invs[j].Account
+		}
+		return invs[i].PeriodStart.Before(invs[j].PeriodStart)
+	})
+}
@@ -0,0 +1,372 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestInvoiceAddCDRCompute(t *testing.T) {
+	pStart := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pEnd := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	inv := NewInvoice("cgrates.org", "1001", pStart, pEnd)
+	if inv.ID != NewInvoice("cgrates.org", "1001", pStart, pEnd).ID {
+		t.Errorf("expected the same ID for the same account and period")
+	}
+	for _, cdr := range []*CDR{
+		{Category: "call", Destination: "1002", Usage: time.Minute, Cost: 0.1234,
+			ExtraFields: map[string]string{
+				utils.Taxes: `[{"Jurisdiction":"CA","TaxType":"GST","Amount":0.0062}]`,
+			}},
+		{Category: "call", Destination: "1002", Usage: 2 * time.Minute, Cost: 0.2468,
+			ExtraFields: map[string]string{
+				utils.Taxes: `[{"Jurisdiction":"CA","TaxType":"GST","Amount":0.0123}]`,
+			}},
+		{Category: "call", Destination: "1003", Usage: time.Minute, Cost: 0.5},
+	} {
+		if err := inv.AddCDR(cdr); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := inv.AddRecurrentFee(&CDR{Category: "monthly", RunID: utils.MetaDebit, Cost: 10,
+		ExtraFields: map[string]string{
+			utils.Taxes: `[{"Jurisdiction":"CA","TaxType":"GST","Amount":0.5}]`,
+		}}); err != nil {
+		t.Fatal(err)
+	}
+	inv.Compute(2)
+	expLines := []*InvoiceLine{
+		{Type: utils.MetaCDRs, Category: "call", Destination: "1002",
+			Quantity: 2, Usage: 3 * time.Minute, Amount: 0.37},
+		{Type: utils.MetaCDRs, Category: "call", Destination: "1003",
+			Quantity: 1, Usage: time.Minute, Amount: 0.5},
+		{Type: utils.MetaRecurrent, Category: "monthly",
+			Quantity: 1, Amount: 10},
+	}
+	if !reflect.DeepEqual(expLines, inv.Lines) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expLines), utils.ToJSON(inv.Lines))
+	}
+	expTaxes := []*InvoiceTax{{Jurisdiction: "CA", TaxType: "GST", Amount: 0.52}}
+	if !reflect.DeepEqual(expTaxes, inv.Taxes) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expTaxes), utils.ToJSON(inv.Taxes))
+	}
+	if inv.Subtotal != 10.87 || inv.TaxTotal != 0.52 || inv.Total != 11.39 {
+		t.Errorf("unexpected totals: %s", utils.ToJSON(inv))
+	}
+	if err := inv.AddCDR(&CDR{ExtraFields: map[string]string{utils.Taxes: "invalid"}}); err == nil {
+		t.Error("expected error for invalid taxes")
+	}
+}
+
+func TestInvoicePassInvoicesFilter(t *testing.T) {
+	pStart := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pEnd := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	inv := NewInvoice("cgrates.org", "1001", pStart, pEnd)
+	if !inv.PassInvoicesFilter(nil) {
+		t.Error("expected to pass nil filter")
+	}
+	if !inv.PassInvoicesFilter(&utils.InvoicesFilter{
+		Tenant:      "cgrates.org",
+		IDs:         []string{inv.ID},
+		Accounts:    []string{"1001", "1002"},
+		PeriodStart: &pStart,
+		PeriodEnd:   &pEnd,
+	}) {
+		t.Error("expected to pass the filter")
+	}
+	for _, fltr := range []*utils.InvoicesFilter{
+		{Tenant: "itsyscom.com"},
+		{IDs: []string{"INV2"}},
+		{Accounts: []string{"1002"}},
+		{PeriodStart: utils.TimePointer(pStart.Add(time.Hour))},
+		{PeriodEnd: utils.TimePointer(pEnd.Add(-time.Hour))},
+	} {
+		if inv.PassInvoicesFilter(fltr) {
+			t.Errorf("expected to not pass filter: %s", utils.ToJSON(fltr))
+		}
+	}
+}
+
+func TestInvoiceAsCGREvent(t *testing.T) {
+	pStart := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pEnd := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	inv := NewInvoice("cgrates.org", "1001", pStart, pEnd)
+	if err := inv.AddRecurrentFee(&CDR{RunID: utils.MetaDebit, Cost: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if inv.Lines[0].Category != utils.MetaDebit {
+		t.Errorf("expected the fee named after the action type: %s", utils.ToJSON(inv.Lines))
+	}
+	inv.Compute(2)
+	ev := inv.AsCGREvent()
+	if ev.Tenant != "cgrates.org" || ev.ID != inv.ID {
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+	}
+	if ev.Event[utils.EventType] != utils.MetaInvoice ||
+		ev.Event[utils.AccountField] != "1001" ||
+		ev.Event[utils.Total] != 10. {
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+	}
+}
+
+func TestInternalDBInvoices(t *testing.T) {
+	storDB := NewInternalDB(nil, nil, false)
+	pStart := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	pEnd := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	inv1 := NewInvoice("invoices.org", "1002", pStart, pEnd)
+	inv2 := NewInvoice("invoices.org", "1001", pStart, pEnd)
+	inv3 := NewInvoice("invoices.org", "1001", pEnd, pEnd.AddDate(0, 1, 0))
+	for _, inv := range []*Invoice{inv1, inv2, inv3} {
+		if err := storDB.SetInvoice(inv); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if rcv, err := storDB.GetInvoices(&utils.InvoicesFilter{Tenant: "invoices.org"}); err != nil {
+		t.Error(err)
+	} else if exp := []*Invoice{inv2, inv3, inv1}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv, err := storDB.GetInvoices(&utils.InvoicesFilter{
+		Tenant:    "invoices.org",
+		PeriodEnd: &pEnd,
+	}); err != nil {
+		t.Error(err)
+	} else if exp := []*Invoice{inv2, inv1}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := storDB.RemoveInvoices(&utils.InvoicesFilter{
+		Tenant:   "invoices.org",
+		Accounts: []string{"1001"},
+	}); err != nil {
+		t.Error(err)
+	}
+	if rcv, err := storDB.GetInvoices(&utils.InvoicesFilter{Tenant: "invoices.org"}); err != nil {
+		t.Error(err)
+	} else if exp := []*Invoice{inv1}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := storDB.RemoveInvoices(&utils.InvoicesFilter{Tenant: "invoices.org"}); err != nil {
+		t.Error(err)
+	}
+	if _, err := storDB.GetInvoices(&utils.InvoicesFilter{Tenant: "invoices.org"}); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestCDRsV1BillingRun(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	storDB := NewInternalDB(nil, nil, false)
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		cdrDb:   storDB,
+		dm:      dm,
+		filterS: NewFilterS(cfg, nil, dm),
+	}
+	// read the CDRs one by one so the paging is covered
+	defer func(limit int) { billingRunCDRsLimit = limit }(billingRunCDRsLimit)
+	billingRunCDRsLimit = 1
+	aTime := time.Date(2021, 1, 10, 10, 0, 0, 0, time.UTC)
+	for i, cdr := range []*CDR{
+		{Tenant: "billing.org", Account: "1001", Category: "monthly", Source: utils.CDRLog,
+			RunID: utils.MetaDebit, AnswerTime: aTime, Usage: 1, Cost: 5,
+			ExtraFields: map[string]string{
+				utils.Taxes: `[{"Jurisdiction":"EU","TaxType":"VAT","Amount":1}]`,
+			}}, // recurrent fee debited by the scheduler
+		{Tenant: "billing.org", Account: "1001", Category: "monthly", Source: utils.CDRLog,
+			RunID: utils.MetaTopUp, AnswerTime: aTime, Usage: 1, Cost: 10}, // not a charge
+		{Tenant: "billing.org", Account: "1001", Category: "call", Destination: "1002",
+			AnswerTime: aTime, Usage: time.Minute, Cost: 1.5},
+		{Tenant: "billing.org", Account: "1001", Category: "call", Destination: "1002",
+			AnswerTime: aTime.Add(time.Hour), Usage: time.Minute, Cost: 0.5},
+		{Tenant: "billing.org", Account: "1002", Category: "call", Destination: "1001",
+			AnswerTime: aTime, Usage: time.Minute, Cost: 2},
+		{Tenant: "billing.org", Account: "1002", Category: "call", Destination: "1001",
+			AnswerTime: aTime.AddDate(0, 1, 0), Usage: time.Minute, Cost: 3}, // out of the period
+		{Tenant: "billing.org", Account: "1002", Category: "call", Destination: "1001",
+			AnswerTime: aTime, Usage: time.Minute, Cost: -1}, // not rated
+	} {
+		cdr.CGRID = utils.Sha1(utils.IfaceAsString(i))
+		if cdr.RunID == utils.EmptyString {
+			cdr.RunID = utils.MetaDefault
+		}
+		cdr.OriginID = utils.IfaceAsString(i)
+		if err := storDB.SetCDR(cdr, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var invs []*Invoice
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{Tenant: "billing.org"}, &invs); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.PeriodStart, utils.PeriodEnd).Error() {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{
+		Tenant:      "billing.org",
+		PeriodStart: "2021-01-01T00:00:00Z",
+		PeriodEnd:   "2021-02-01T00:00:00Z",
+	}, &invs); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 2 {
+		t.Fatalf("unexpected invoices: %s", utils.ToJSON(invs))
+	}
+	expLines := []*InvoiceLine{
+		{Type: utils.MetaCDRs, Category: "call", Destination: "1002",
+			Quantity: 2, Usage: 2 * time.Minute, Amount: 2},
+		{Type: utils.MetaRecurrent, Category: "monthly", Quantity: 1, Amount: 5},
+	}
+	if invs[0].Account != "1001" || !reflect.DeepEqual(expLines, invs[0].Lines) {
+		t.Errorf("unexpected invoice: %s", utils.ToJSON(invs[0]))
+	}
+	if invs[0].Subtotal != 7 || invs[0].TaxTotal != 1 || invs[0].Total != 8 {
+		t.Errorf("unexpected totals: %s", utils.ToJSON(invs[0]))
+	}
+	if invs[1].Account != "1002" || invs[1].Total != 2 {
+		t.Errorf("unexpected invoice: %s", utils.ToJSON(invs[1]))
+	}
+	var rcv []*Invoice
+	if err := cdrS.V1GetInvoices(&utils.InvoicesFilterWithOpts{
+		InvoicesFilter: &utils.InvoicesFilter{
+			Tenant:   "billing.org",
+			Accounts: []string{"1002"},
+		},
+	}, &rcv); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(invs[1:], rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(invs[1:]), utils.ToJSON(rcv))
+	}
+	var reply string
+	if err := cdrS.V1RemoveInvoices(&utils.InvoicesFilterWithOpts{
+		InvoicesFilter: &utils.InvoicesFilter{Tenant: "billing.org"},
+	}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	if err := cdrS.V1GetInvoices(&utils.InvoicesFilterWithOpts{
+		InvoicesFilter: &utils.InvoicesFilter{Tenant: "billing.org"},
+	}, &rcv); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{
+		Tenant:      "billing.org",
+		PeriodStart: "2020-01-01T00:00:00Z",
+		PeriodEnd:   "2020-02-01T00:00:00Z",
+		Accounts:    []string{"1002"},
+		Store:       utils.BoolPointer(false),
+	}, &invs); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestCDRsV1BillingRunRunIDs(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	storDB := NewInternalDB(nil, nil, false)
+	cdrS := &CDRServer{
+		cgrCfg:  cfg,
+		cdrDb:   storDB,
+		dm:      dm,
+		filterS: NewFilterS(cfg, nil, dm),
+	}
+	aTime := time.Date(2021, 1, 10, 10, 0, 0, 0, time.UTC)
+	for i, runID := range []string{utils.MetaDebit, utils.MetaAddBalance} {
+		if err := storDB.SetCDR(&CDR{
+			CGRID:      utils.Sha1("billingrunsfee"),
+			RunID:      runID,
+			OriginID:   "billingrunsfee",
+			Source:     utils.MetaCdrLog,
+			Tenant:     "billingruns.org",
+			Account:    "1003",
+			AnswerTime: aTime,
+			Usage:      1,
+			Cost:       float64(5 * (i + 1)),
+		}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, runID := range []string{utils.MetaDefault, "supplier", "reseller"} {
+		if err := storDB.SetCDR(&CDR{
+			CGRID:       utils.Sha1("billingruns"),
+			RunID:       runID,
+			OriginID:    "billingruns",
+			Tenant:      "billingruns.org",
+			Account:     "1001",
+			Category:    "call",
+			Destination: "1002",
+			AnswerTime:  aTime,
+			Usage:       time.Minute,
+			Cost:        float64(i + 1),
+		}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var invs []*Invoice
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{
+		Tenant:      "billingruns.org",
+		PeriodStart: "2021-01-01T00:00:00Z",
+		PeriodEnd:   "2021-02-01T00:00:00Z",
+		Store:       utils.BoolPointer(false),
+	}, &invs); err != nil {
+		t.Fatal(err)
+	}
+	// only the *default run is billed and the account with only recurrent fees is included
+	if len(invs) != 2 {
+		t.Fatalf("unexpected invoices: %s", utils.ToJSON(invs))
+	}
+	if invs[0].Account != "1001" || invs[0].Total != 1 {
+		t.Errorf("unexpected invoice: %s", utils.ToJSON(invs[0]))
+	}
+	if invs[1].Account != "1003" || invs[1].Total != 5 {
+		t.Errorf("unexpected invoice: %s", utils.ToJSON(invs[1]))
+	}
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{
+		Tenant:      "billingruns.org",
+		Accounts:    []string{"1001"},
+		RunIDs:      []string{"reseller"},
+		PeriodStart: "2021-01-01T00:00:00Z",
+		PeriodEnd:   "2021-02-01T00:00:00Z",
+		Store:       utils.BoolPointer(false),
+	}, &invs); err != nil {
+		t.Fatal(err)
+	}
+	if len(invs) != 1 || invs[0].Total != 3 {
+		t.Errorf("unexpected invoices: %s", utils.ToJSON(invs))
+	}
+	// the fees logged by the other action types are billed only when requested
+	if err := cdrS.V1BillingRun(&ArgsBillingRun{
+		Tenant:      "billingruns.org",
+		Accounts:    []string{"1003"},
+		FeeRunIDs:   []string{utils.MetaAddBalance},
+		PeriodStart: "2021-01-01T00:00:00Z",
+		PeriodEnd:   "2021-02-01T00:00:00Z",
+		Store:       utils.BoolPointer(false),
+	}, &invs); err != nil {
+		t.Fatal(err)
+	}
+	expLines := []*InvoiceLine{{Type: utils.MetaRecurrent, Category: utils.MetaAddBalance, Quantity: 1, Amount: 10}}
+	if len(invs) != 1 || !reflect.DeepEqual(expLines, invs[0].Lines) {
+		t.Errorf("unexpected invoices: %s", utils.ToJSON(invs))
+	}
+}
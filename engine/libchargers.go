@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/cgrates/cgrates/utils"
@@ -33,6 +34,48 @@ type ChargerProfile struct {
 	RunID              string
 	AttributeIDs       []string // perform data aliasing based on these Attributes
 	Weight             float64
+	Aggregations       []*ChargerAggregation // relate the costs of the runs into fields of this run
+}
+
+// ChargerAggregation computes a field of the run out of the costs of the runs forked by ChargerS,
+// ie: Margin as the *default run cost minus the supplier run cost
+type ChargerAggregation struct {
+	Path         string   // field populated on the CDR of the run
+	FilterIDs    []string // conditions on the CDR for the aggregation to apply
+	Type         string   // <*sum|*difference>, *difference substracts the other runs from the first one
+	RunIDs       []string // runs with the costs aggregated
+	ThresholdIDs []string // thresholds processed when the result is negative, none if empty
+}
+
+// Validate checks the aggregation so it can be rejected when stored instead of failing on each CDR
+func (ca *ChargerAggregation) Validate() error {
+	if ca.Type != utils.MetaSum && ca.Type != utils.MetaDifference {
+		return fmt.Errorf("unsupported aggregation type: <%s>", ca.Type)
+	}
+	if len(ca.RunIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.RunIDs)
+	}
+	return nil
+}
+
+// Aggregate returns the result out of the costs of the runs
+func (ca *ChargerAggregation) Aggregate(runCosts map[string]float64) (val *utils.Decimal, err error) {
+	if err = ca.Validate(); err != nil {
+		return
+	}
+	val = utils.NewDecimal(0, 0)
+	for i, runID := range ca.RunIDs {
+		cost, has := runCosts[runID]
+		if !has {
+			return nil, utils.ErrPrefixNotFound(runID)
+		}
+		if i != 0 && ca.Type == utils.MetaDifference {
+			val = utils.SubstractDecimal(val, utils.NewDecimalFromFloat64(cost))
+			continue
+		}
+		val = &utils.Decimal{Big: utils.SumBig(val.Big, utils.NewDecimalFromFloat64(cost).Big)}
+	}
+	return
 }
 
 // ChargerProfileWithOpts is used in replicatorV1 for dispatcher
@@ -0,0 +1,374 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// DryRunError is one error found while validating a load
+type DryRunError struct {
+	LoaderType string
+	ID         string // TenantID of the profile, empty if the error is not related to a profile
+	Line       int    // line in the file, 0 if the error is not related to a line
+	Error      string
+}
+
+// DryRunReport is the result of a load validated without writing into DataDB
+type DryRunReport struct {
+	Created   map[string][]string // map[loaderType][]TenantID
+	Updated   map[string][]string
+	Unchanged map[string][]string
+	Removed   map[string][]string
+	Errors    []*DryRunError
+
+	loaded map[string]utils.StringSet // map[loaderType]TenantIDs, used to resolve the references between the loaded profiles
+	refs   []*dryRunRef
+	lines  map[string]int // map[loaderType:TenantID]line, the line where the profile starts in the file
+}
+
+// dryRunRef is a reference from one profile to another one
+type dryRunRef struct {
+	ldrType string
+	tntID   string
+	refType string
+	refTnt  string
+	refID   string
+}
+
+// NewDryRunReport returns an empty report
+func NewDryRunReport() *DryRunReport {
+	return &DryRunReport{
+		Created:   make(map[string][]string),
+		Updated:   make(map[string][]string),
+		Unchanged: make(map[string][]string),
+		Removed:   make(map[string][]string),
+		Errors:    make([]*DryRunError, 0),
+		loaded:    make(map[string]utils.StringSet),
+		lines:     make(map[string]int),
+	}
+}
+
+// HasErrors returns true if the load would fail
+func (rpt *DryRunReport) HasErrors() bool {
+	return len(rpt.Errors) != 0
+}
+
+// SetLine records the line where the profile starts so its errors can be traced back to the file
+func (rpt *DryRunReport) SetLine(ldrType, tntID string, line int) {
+	rpt.lines[utils.ConcatenatedKey(ldrType, tntID)] = line
+}
+
+// AddError records an error for the loader type
+// the line is taken from the profile if not specified
+func (rpt *DryRunReport) AddError(ldrType, tntID string, line int, err error) {
+	if line == 0 && tntID != utils.EmptyString {
+		line = rpt.lines[utils.ConcatenatedKey(ldrType, tntID)]
+	}
+	rpt.Errors = append(rpt.Errors, &DryRunError{
+		LoaderType: ldrType,
+		ID:         tntID,
+		Line:       line,
+		Error:      err.Error(),
+	})
+}
+
+// AddLoaded marks the IDs as available for references without comparing them with DataDB
+func (rpt *DryRunReport) AddLoaded(ldrType string, tntIDs ...string) {
+	if _, has := rpt.loaded[ldrType]; !has {
+		rpt.loaded[ldrType] = make(utils.StringSet)
+	}
+	rpt.loaded[ldrType].AddSlice(tntIDs)
+}
+
+// AddProfile compares the profile with the one from DataDB and collects its references
+func (rpt *DryRunReport) AddProfile(dm *DataManager, ldrType string,
+	prf interface{ TenantID() string }) (err error) {
+	tntID := prf.TenantID()
+	var dbPrf interface{}
+	if dbPrf, err = GetLoaderProfile(dm, ldrType, utils.NewTenantID(tntID)); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+		rpt.Created[ldrType] = append(rpt.Created[ldrType], tntID)
+	} else if utils.ToJSON(dbPrf) != utils.ToJSON(prf) {
+		rpt.Updated[ldrType] = append(rpt.Updated[ldrType], tntID)
+	} else {
+		rpt.Unchanged[ldrType] = append(rpt.Unchanged[ldrType], tntID)
+	}
+	rpt.AddLoaded(ldrType, tntID)
+	rpt.addProfileRefs(ldrType, prf)
+	return
+}
+
+// AddRemoved checks that the profile to be removed exists in DataDB
+func (rpt *DryRunReport) AddRemoved(dm *DataManager, ldrType, tntID string) (err error) {
+	if _, err = GetLoaderProfile(dm, ldrType, utils.NewTenantID(tntID)); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+		rpt.AddError(ldrType, tntID, 0, utils.ErrNotFound)
+		return
+	}
+	rpt.Removed[ldrType] = append(rpt.Removed[ldrType], tntID)
+	return
+}
+
+// CheckReferences verifies that every referenced profile is either loaded or already in DataDB
+func (rpt *DryRunReport) CheckReferences(dm *DataManager) (err error) {
+	checked := make(utils.StringSet)
+	missing := make(utils.StringSet)
+	for _, ref := range rpt.refs {
+		refTntID := ref.refID
+		if ref.refTnt != utils.EmptyString {
+			refTntID = utils.ConcatenatedKey(ref.refTnt, ref.refID)
+		}
+		refKey := utils.ConcatenatedKey(ref.refType, refTntID)
+		if !checked.Has(refKey) {
+			checked.Add(refKey)
+			if !rpt.loaded[ref.refType].Has(refTntID) {
+				if _, err = GetLoaderProfile(dm, ref.refType,
+					&utils.TenantID{Tenant: ref.refTnt, ID: ref.refID}); err != nil {
+					if err != utils.ErrNotFound {
+						return
+					}
+					err = nil
+					missing.Add(refKey)
+				}
+			}
+		}
+		if missing.Has(refKey) {
+			rpt.AddError(ref.ldrType, ref.tntID, 0,
+				fmt.Errorf("broken reference to %s: <%s>", ref.refType, refTntID))
+		}
+	}
+	rpt.refs = nil
+	return
+}
+
+// Sort orders the IDs in the report so it can be compared
+func (rpt *DryRunReport) Sort() {
+	for _, ids := range []map[string][]string{rpt.Created, rpt.Updated, rpt.Unchanged, rpt.Removed} {
+		for _, tntIDs := range ids {
+			sort.Strings(tntIDs)
+		}
+	}
+	sort.SliceStable(rpt.Errors, func(i, j int) bool {
+		if rpt.Errors[i].LoaderType != rpt.Errors[j].LoaderType {
+			return rpt.Errors[i].LoaderType < rpt.Errors[j].LoaderType
+		}
+		if rpt.Errors[i].ID != rpt.Errors[j].ID {
+			return rpt.Errors[i].ID < rpt.Errors[j].ID
+		}
+		return rpt.Errors[i].Line < rpt.Errors[j].Line
+	})
+}
+
+// addRefs records the references of the same type and validates the inline filters
+func (rpt *DryRunReport) addRefs(ldrType, tntID, refType, refTnt string, refIDs []string) {
+	for _, refID := range refIDs {
+		if refID == utils.EmptyString || refID == utils.MetaNone {
+			continue
+		}
+		if refType == utils.MetaFilters && strings.HasPrefix(refID, utils.Meta) {
+			if err := checkInlineFilter(refTnt, refID); err != nil {
+				rpt.AddError(ldrType, tntID, 0, err)
+			}
+			continue
+		}
+		if strings.HasPrefix(refID, utils.Meta) { // not a profile (ie: *internal host or *constant attribute)
+			continue
+		}
+		rpt.refs = append(rpt.refs, &dryRunRef{
+			ldrType: ldrType,
+			tntID:   tntID,
+			refType: refType,
+			refTnt:  refTnt,
+			refID:   refID,
+		})
+	}
+}
+
+// addProfileRefs collects the references out of the profile
+func (rpt *DryRunReport) addProfileRefs(ldrType string, prf interface{ TenantID() string }) {
+	tntID := prf.TenantID()
+	switch p := prf.(type) {
+	case *Filter:
+		for _, rule := range p.Rules {
+			if _, err := NewFilterRule(rule.Type, rule.Element, rule.Values); err != nil {
+				rpt.AddError(ldrType, tntID, 0, err)
+			}
+		}
+	case *AttributeProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		for _, attr := range p.Attributes {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, attr.FilterIDs)
+		}
+	case *ResourceProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		rpt.addRefs(ldrType, tntID, utils.MetaThresholds, p.Tenant, p.ThresholdIDs)
+	case *StatQueueProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		rpt.addRefs(ldrType, tntID, utils.MetaThresholds, p.Tenant, p.ThresholdIDs)
+		for _, metric := range p.Metrics {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, metric.FilterIDs)
+		}
+	case *ThresholdProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		rpt.addRefs(ldrType, tntID, utils.MetaActions, utils.EmptyString, p.ActionIDs)
+	case *RouteProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		for _, route := range p.Routes {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, route.FilterIDs)
+			rpt.addRefs(ldrType, tntID, utils.MetaResources, p.Tenant, route.ResourceIDs)
+			statIDs := make([]string, len(route.StatIDs))
+			for i, statID := range route.StatIDs { // the metric can be specified after the StatID
+				statIDs[i] = strings.SplitN(statID, utils.InInFieldSep, 2)[0]
+			}
+			rpt.addRefs(ldrType, tntID, utils.MetaStats, p.Tenant, statIDs)
+		}
+	case *ChargerProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		rpt.addRefs(ldrType, tntID, utils.MetaAttributes, p.Tenant, p.AttributeIDs)
+	case *DispatcherProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		for _, host := range p.Hosts {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, host.FilterIDs)
+			rpt.addRefs(ldrType, tntID, utils.MetaDispatcherHosts, p.Tenant, []string{host.ID})
+		}
+	case *RateProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		for _, rt := range p.Rates {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, rt.FilterIDs)
+		}
+	case *ActionProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		for _, act := range p.Actions {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, act.FilterIDs)
+		}
+	case *utils.AccountProfile:
+		rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, p.FilterIDs)
+		rpt.addRefs(ldrType, tntID, utils.MetaThresholds, p.Tenant, p.ThresholdIDs)
+		for _, blnc := range p.Balances {
+			rpt.addRefs(ldrType, tntID, utils.MetaFilters, p.Tenant, blnc.FilterIDs)
+			rpt.addRefs(ldrType, tntID, utils.MetaAttributes, p.Tenant, blnc.AttributeIDs)
+			rpt.addRefs(ldrType, tntID, utils.MetaRateProfiles, p.Tenant, blnc.RateProfileIDs)
+		}
+	}
+}
+
+// checkInlineFilter validates the syntax of an inline filter
+func checkInlineFilter(tnt, fltrID string) (err error) {
+	var fltr *Filter
+	if fltr, err = NewFilterFromInline(tnt, fltrID); err != nil {
+		return
+	}
+	for _, rule := range fltr.Rules {
+		if _, err = NewFilterRule(rule.Type, rule.Element, rule.Values); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// GetLoaderProfile returns the profile of the given loader type from DataDB without caching it
+func GetLoaderProfile(dm *DataManager, ldrType string, tntID *utils.TenantID) (prf interface{}, err error) {
+	switch ldrType {
+	case utils.MetaAttributes:
+		return dm.GetAttributeProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaResources:
+		return dm.GetResourceProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaFilters:
+		return dm.GetFilter(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaStats:
+		return dm.GetStatQueueProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaThresholds:
+		return dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaRoutes:
+		return dm.GetRouteProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaChargers:
+		return dm.GetChargerProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaDispatchers:
+		return dm.GetDispatcherProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaDispatcherHosts:
+		return dm.GetDispatcherHost(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaRateProfiles:
+		return dm.GetRateProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaActionProfiles:
+		return dm.GetActionProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaAccountProfiles:
+		return dm.GetAccountProfile(tntID.Tenant, tntID.ID)
+	case utils.MetaExchangeRateProfiles:
+		return dm.GetExchangeRateProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaRateDecks:
+		return dm.GetRateDeck(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaTaxProfiles:
+		return dm.GetTaxProfile(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaLookupTables:
+		return dm.GetLookupTable(tntID.Tenant, tntID.ID, false, false, utils.NonTransactional)
+	case utils.MetaActions:
+		return dm.GetActions(tntID.ID, true, utils.NonTransactional)
+	}
+	return nil, fmt.Errorf("unsupported loader type: <%s>", ldrType)
+}
+
+// NewLoaderProfile returns an empty profile of the loader type to decode the stored profiles into
+func NewLoaderProfile(ldrType string) (interface{}, error) {
+	switch ldrType {
+	case utils.MetaAttributes:
+		return new(AttributeProfile), nil
+	case utils.MetaResources:
+		return new(ResourceProfile), nil
+	case utils.MetaFilters:
+		return new(Filter), nil
+	case utils.MetaStats:
+		return new(StatQueueProfile), nil
+	case utils.MetaThresholds:
+		return new(ThresholdProfile), nil
+	case utils.MetaRoutes:
+		return new(RouteProfile), nil
+	case utils.MetaChargers:
+		return new(ChargerProfile), nil
+	case utils.MetaDispatchers:
+		return new(DispatcherProfile), nil
+	case utils.MetaDispatcherHosts:
+		return new(DispatcherHost), nil
+	case utils.MetaRateProfiles:
+		return new(RateProfile), nil
+	case utils.MetaActionProfiles:
+		return new(ActionProfile), nil
+	case utils.MetaAccountProfiles:
+		return new(utils.AccountProfile), nil
+	case utils.MetaExchangeRateProfiles:
+		return new(utils.ExchangeRateProfile), nil
+	case utils.MetaRateDecks:
+		return new(RateDeck), nil
+	case utils.MetaTaxProfiles:
+		return new(TaxProfile), nil
+	case utils.MetaLookupTables:
+		return new(LookupTable), nil
+	}
+	return nil, fmt.Errorf("unsupported loader type: <%s>", ldrType)
+}
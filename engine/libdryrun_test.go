@@ -0,0 +1,124 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDryRunReportAddProfile(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	fltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1001"},
+		}},
+	}
+	if err := dm.SetFilter(fltr, false); err != nil {
+		t.Fatal(err)
+	}
+	rpt := NewDryRunReport()
+	rpt.SetLine(utils.MetaThresholds, "cgrates.org:TH_1", 4)
+	if err := rpt.AddProfile(dm, utils.MetaFilters, fltr); err != nil {
+		t.Fatal(err)
+	}
+	if err := rpt.AddProfile(dm, utils.MetaFilters, &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_2",
+		Rules:  []*FilterRule{{Type: "*wrong", Element: "~*req.Account"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rpt.AddProfile(dm, utils.MetaThresholds, &ThresholdProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TH_1",
+		FilterIDs: []string{"FLTR_1", "FLTR_2", "FLTR_3", "*string:~*req.Account"},
+		ActionIDs: []string{"ACT_1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rpt.AddLoaded(utils.MetaActions, "ACT_1")
+	if err := rpt.CheckReferences(dm); err != nil {
+		t.Fatal(err)
+	}
+	rpt.Sort()
+	exp := &DryRunReport{
+		Created: map[string][]string{
+			utils.MetaFilters:    {"cgrates.org:FLTR_2"},
+			utils.MetaThresholds: {"cgrates.org:TH_1"},
+		},
+		Updated:   map[string][]string{},
+		Unchanged: map[string][]string{utils.MetaFilters: {"cgrates.org:FLTR_1"}},
+		Removed:   map[string][]string{},
+		Errors: []*DryRunError{
+			{LoaderType: utils.MetaFilters, ID: "cgrates.org:FLTR_2",
+				Error: "Unsupported filter Type: *wrong"},
+			{LoaderType: utils.MetaThresholds, ID: "cgrates.org:TH_1", Line: 4,
+				Error: "inline parse error for string: <*string:~*req.Account>"},
+			{LoaderType: utils.MetaThresholds, ID: "cgrates.org:TH_1", Line: 4,
+				Error: "broken reference to *filters: <cgrates.org:FLTR_3>"},
+		},
+		lines: map[string]int{"*thresholds:cgrates.org:TH_1": 4},
+	}
+	rpt.loaded = nil
+	if !reflect.DeepEqual(rpt, exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rpt))
+	}
+	if !rpt.HasErrors() {
+		t.Error("Expected errors")
+	}
+}
+
+func TestDryRunReportAddRemoved(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	if err := dm.SetChargerProfile(&ChargerProfile{
+		Tenant: "cgrates.org",
+		ID:     "CHRG_1",
+		RunID:  utils.MetaDefault,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	rpt := NewDryRunReport()
+	if err := rpt.AddRemoved(dm, utils.MetaChargers, "cgrates.org:CHRG_1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rpt.AddRemoved(dm, utils.MetaChargers, "cgrates.org:CHRG_2"); err != nil {
+		t.Fatal(err)
+	}
+	expRemoved := map[string][]string{utils.MetaChargers: {"cgrates.org:CHRG_1"}}
+	if !reflect.DeepEqual(rpt.Removed, expRemoved) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expRemoved), utils.ToJSON(rpt.Removed))
+	}
+	expErrs := []*DryRunError{{LoaderType: utils.MetaChargers,
+		ID: "cgrates.org:CHRG_2", Error: utils.ErrNotFound.Error()}}
+	if !reflect.DeepEqual(rpt.Errors, expErrs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expErrs), utils.ToJSON(rpt.Errors))
+	}
+	expErr := "unsupported loader type: <*wrong>"
+	if err := rpt.AddRemoved(dm, "*wrong", "cgrates.org:CHRG_1"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+}
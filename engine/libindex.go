@@ -713,6 +713,26 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 				}); err != nil && err != utils.ErrNotFound {
 				return utils.APIErrorHandler(err)
 			}
+		case utils.CacheTaxProfilesFilterIndexes:
+			if err = removeFilterIndexesForFilter(dm, idxItmType, newFlt.Tenant, //remove the indexes for the filter
+				removeIndexKeys, indx); err != nil {
+				return
+			}
+			idxSlice := indx.AsSlice()
+			if _, err = ComputeIndexes(dm, newFlt.Tenant, utils.EmptyString, idxItmType, // compute all the indexes for afected items
+				&idxSlice, utils.NonTransactional, func(tnt, id, ctx string) (*[]string, error) {
+					tp, e := dm.GetTaxProfile(tnt, id, true, false, utils.NonTransactional)
+					if e != nil {
+						return nil, e
+					}
+					fltrIDs := make([]string, len(tp.FilterIDs))
+					for i, fltrID := range tp.FilterIDs {
+						fltrIDs[i] = fltrID
+					}
+					return &fltrIDs, nil
+				}); err != nil && err != utils.ErrNotFound {
+				return utils.APIErrorHandler(err)
+			}
 		case utils.CacheActionProfilesFilterIndexes:
 			if err = removeFilterIndexesForFilter(dm, idxItmType, newFlt.Tenant, //remove the indexes for the filter
 				removeIndexKeys, indx); err != nil {
@@ -745,9 +765,9 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 					if e != nil {
 						return nil, e
 					}
-					fltrIDs := make([]string, len(rp.FilterIDs))
-					for i, fltrID := range rp.FilterIDs {
-						fltrIDs[i] = fltrID
+					fltrIDs, e := dm.RateProfileFilterIDs(rp)
+					if e != nil {
+						return nil, e
 					}
 					return &fltrIDs, nil
 				}); err != nil && err != utils.ErrNotFound {
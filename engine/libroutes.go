@@ -176,6 +176,34 @@ func (sRoutes *SortedRoutes) SortLoadDistribution() {
 	})
 }
 
+// SortMargin is part of sort interface,
+// sort descendent based on Margin with fallback on Weight
+func (sRoutes *SortedRoutes) SortMargin() {
+	sort.Slice(sRoutes.SortedRoutes, func(i, j int) bool {
+		if sRoutes.SortedRoutes[i].SortingData[utils.Margin].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Margin].(float64) {
+			if sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64) {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) > sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64)
+		}
+		return sRoutes.SortedRoutes[i].SortingData[utils.Margin].(float64) > sRoutes.SortedRoutes[j].SortingData[utils.Margin].(float64)
+	})
+}
+
+// SortScore is part of sort interface,
+// sort descendent based on Score with fallback on Weight
+func (sRoutes *SortedRoutes) SortScore() {
+	sort.Slice(sRoutes.SortedRoutes, func(i, j int) bool {
+		if sRoutes.SortedRoutes[i].SortingData[utils.Score].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Score].(float64) {
+			if sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) == sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64) {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.SortedRoutes[i].SortingData[utils.Weight].(float64) > sRoutes.SortedRoutes[j].SortingData[utils.Weight].(float64)
+		}
+		return sRoutes.SortedRoutes[i].SortingData[utils.Score].(float64) > sRoutes.SortedRoutes[j].SortingData[utils.Score].(float64)
+	})
+}
+
 // Digest returns list of routeIDs + parameters for easier outside access
 // format route1:route1params,route2:route2params
 func (sRoutes *SortedRoutes) Digest() string {
@@ -224,6 +252,8 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher) {
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaMargin] = NewMarginSorter(lcrS)
+	rsd[utils.MetaScore] = NewScoreSorter(lcrS)
 	return
 }
 
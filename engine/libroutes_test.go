@@ -1081,3 +1081,104 @@ func TestSortedRoutesAsNavigableMap(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expNavMap), utils.ToJSON(rcv))
 	}
 }
+
+func TestLibRoutesSortMargin(t *testing.T) {
+	sSpls := &SortedRoutes{
+		SortedRoutes: []*SortedRoute{
+			{
+				RouteID: "route1",
+				SortingData: map[string]interface{}{
+					utils.Margin: 0.1,
+					utils.Weight: 15.0,
+				},
+			},
+			{
+				RouteID: "route2",
+				SortingData: map[string]interface{}{
+					utils.Margin: 0.3,
+					utils.Weight: 10.0,
+				},
+			},
+			{
+				RouteID: "route3",
+				SortingData: map[string]interface{}{
+					utils.Margin: 0.1,
+					utils.Weight: 20.0,
+				},
+			},
+		},
+	}
+	sSpls.SortMargin()
+	if rcv := sSpls.RouteIDs(); !reflect.DeepEqual([]string{"route2", "route3", "route1"}, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", []string{"route2", "route3", "route1"}, rcv)
+	}
+}
+
+func TestLibRoutesSortScore(t *testing.T) {
+	sSpls := &SortedRoutes{
+		SortedRoutes: []*SortedRoute{
+			{
+				RouteID: "route1",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 80.0,
+					utils.MetaPDD: 4.0,
+					utils.Cost:    0.2,
+					utils.Weight:  10.0,
+				},
+			},
+			{
+				RouteID: "route2",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: 40.0,
+					utils.MetaPDD: 2.0,
+					utils.Cost:    0.1,
+					utils.Weight:  20.0,
+				},
+			},
+			{
+				RouteID: "route3",
+				SortingData: map[string]interface{}{
+					utils.MetaASR: -1.0, // no data in StatS
+					utils.MetaPDD: 3.0,
+					utils.Cost:    0.3,
+					utils.Weight:  30.0,
+				},
+			},
+		},
+	}
+	sSpls.computeScores(map[string]float64{
+		utils.MetaASR:  2,
+		utils.MetaPDD:  1,
+		utils.MetaCost: 1,
+	})
+	sSpls.SortScore()
+	if rcv := sSpls.RouteIDs(); !reflect.DeepEqual([]string{"route1", "route2", "route3"}, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", []string{"route1", "route2", "route3"}, rcv)
+	}
+	// route1: (2*1 + 1*0 + 1*0.5)/4, route2: (2*0 + 1*1 + 1*1)/4, route3: (2*0 + 1*0.5 + 1*0)/4
+	for i, eScore := range []float64{0.625, 0.5, 0.125} {
+		if score := sSpls.SortedRoutes[i].SortingData[utils.Score]; score != eScore {
+			t.Errorf("Expecting score %v for %s, received: %v", eScore, sSpls.SortedRoutes[i].RouteID, score)
+		}
+	}
+}
+
+func TestScoreCoefficients(t *testing.T) {
+	eCoefs := map[string]float64{
+		utils.MetaASR:  2,
+		utils.MetaACD:  1.5,
+		utils.MetaCost: 0,
+	}
+	if coefs, err := scoreCoefficients([]string{"*asr:2", "*acd:1.5", "*cost:0"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCoefs, coefs) {
+		t.Errorf("Expecting: %+v, received: %+v", eCoefs, coefs)
+	}
+	if _, err := scoreCoefficients(nil); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.SortingParameters).Error() {
+		t.Errorf("Expecting: %+v, received: %+v", utils.NewErrMandatoryIeMissing(utils.SortingParameters), err)
+	}
+	if _, err := scoreCoefficients([]string{"*asr:a"}); err == nil {
+		t.Error("Expecting error for invalid coefficient")
+	}
+}
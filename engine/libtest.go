@@ -269,8 +269,10 @@ cgrates.org,ALS1,con1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*string:~*
 cgrates.org,ALS1,con2;con3,,,,*req.Field2,*variable,Sub2,true,20
 `
 	ChargersCSVContent = `
-#Tenant,ID,FilterIDs,ActivationInterval,RunID,AttributeIDs,Weight
-cgrates.org,Charger1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*rated,ATTR_1001_SIMPLEAUTH,20
+#Tenant,ID,FilterIDs,ActivationInterval,RunID,AttributeIDs,Weight,AggregationPath,AggregationFilterIDs,AggregationType,AggregationRunIDs,AggregationThresholdIDs
+cgrates.org,Charger1,*string:~*req.Account:1001,2014-07-29T15:00:00Z,*rated,ATTR_1001_SIMPLEAUTH,20,Margin,,*difference,*rated;SupplierCharges,THD_NEGATIVE_MARGIN
+cgrates.org,Charger1,,,,,,TotalCosts,*string:~*req.Category:call,*sum,*rated,
+cgrates.org,Charger1,,,,,,TotalCosts,,,SupplierCharges,
 `
 	DispatcherCSVContent = `
 #Tenant,ID,FilterIDs,ActivationInterval,Strategy,Hosts,Weight
@@ -282,11 +284,11 @@ cgrates.org,D1,,,,*first,,C2,*lt:~*req.Usage:10,10,false,192.168.56.204,
 cgrates.org,ALL1,127.0.0.1:2012,*json,true
 `
 	RateProfileCSVContent = `
-#Tenant,ID,FilterIDs,ActivationInterval,Weights,MinCost,MaxCost,MaxCostStrategy,RateID,RateFilterIDs,RateActivationStart,RateWeights,RateBlocker,RateIntervalStart,RateFixedFee,RateRecurrentFee,RateUnit,RateIncrement
-cgrates.org,RP1,*string:~*req.Subject:1001,,;0,0.1,0.6,*free,RT_WEEK,,"* * * * 1-5",;0,false,0s,0,0.12,1m,1m
-cgrates.org,RP1,,,,,,,RT_WEEK,,,,,1m,1.234,0.06,1m,1s
-cgrates.org,RP1,,,,,,,RT_WEEKEND,,"* * * * 0,6",;10,false,0s,0.089,0.06,1m,1s
-cgrates.org,RP1,,,,,,,RT_CHRISTMAS,,* * 24 12 *,;30,false,0s,0.0564,0.06,1m,1s
+#Tenant,ID,FilterIDs,ActivationInterval,Weights,MinCost,MaxCost,MaxCostStrategy,RateID,RateFilterIDs,RateActivationStart,RateWeights,RateBlocker,RateIntervalStart,RateFixedFee,RateRecurrentFee,RateUnit,RateIncrement,Currency,VolumePeriod
+cgrates.org,RP1,*string:~*req.Subject:1001,,;0,0.1,0.6,*free,RT_WEEK,,"* * * * 1-5",;0,false,0s,0,0.12,1m,1m,,
+cgrates.org,RP1,,,,,,,RT_WEEK,,,,,1m,1.234,0.06,1m,1s,,
+cgrates.org,RP1,,,,,,,RT_WEEKEND,,"* * * * 0,6",;10,false,0s,0.089,0.06,1m,1s,,
+cgrates.org,RP1,,,,,,,RT_CHRISTMAS,,* * 24 12 *,;30,false,0s,0.0564,0.06,1m,1s,,
 `
 	ActionProfileCSVContent = `
 #Tenant,ID,FilterIDs,ActivationInterval,Weight,Schedule,TargetType,TargetIDs,ActionID,ActionFilterIDs,ActionBlocker,ActionTTL,ActionType,ActionOpts,ActionPath,ActionValue
@@ -548,6 +550,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheRateFilterIndexes:            {},
 		utils.CacheTimings:                      {},
 		utils.CacheDiameterMessages:             {},
+		utils.CacheRadiusPackets:                {},
 		utils.CacheClosedSessions:               {},
 		utils.CacheLoadIDs:                      {},
 		utils.CacheRPCConnections:               {},
@@ -562,6 +565,16 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheActionProfilesFilterIndexes:  {},
 		utils.CacheAccountProfiles:              {},
 		utils.CacheAccountProfilesFilterIndexes: {},
+		utils.CacheExchangeRateProfiles:         {},
+		utils.CacheRateVolumeCounters:           {},
+		utils.CacheRateDecks:                    {},
+		utils.CacheTaxProfiles:                  {},
+		utils.CacheTaxProfilesFilterIndexes:     {},
+		utils.CacheLookupTables:                 {},
+		utils.CacheProfileHits:                  {},
+		utils.CacheRateProfileVersions:          {},
+		utils.CacheLoadVersions:                 {},
+		utils.CacheActiveLoadVersions:           {},
 		utils.CacheReplicationHosts:             {},
 
 		utils.CacheAccounts:              {},
@@ -583,6 +596,7 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheTBLTPFilters:          {},
 		utils.CacheSessionCostsTBL:       {},
 		utils.CacheCDRsTBL:               {},
+		utils.CacheInvoicesTBL:           {},
 		utils.CacheTBLTPRoutes:           {},
 		utils.CacheTBLTPAttributes:       {},
 		utils.CacheTBLTPChargers:         {},
@@ -619,6 +633,10 @@ func GetDefaultEmptyArgCachePrefix() map[string][]string {
 		utils.DispatcherHostPrefix:          nil,
 		utils.RateProfilePrefix:             nil,
 		utils.ActionProfilePrefix:           nil,
+		utils.ExchangeRateProfilePrefix:     nil,
+		utils.RateDeckPrefix:                nil,
+		utils.TaxProfilePrefix:              nil,
+		utils.LookupTablePrefix:             nil,
 		utils.TimingsPrefix:                 nil,
 		utils.AttributeFilterIndexes:        nil,
 		utils.ResourceFilterIndexes:         nil,
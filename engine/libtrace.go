@@ -0,0 +1,91 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FilterTrace is the result of checking one of the filters of a profile
+type FilterTrace struct {
+	FilterID   string
+	Pass       bool
+	Inactive   bool   // the filter was ignored since it is not active
+	FailedRule string // the first rule which did not pass, as Type:Element:Values
+}
+
+// ProfileTrace describes how a candidate profile was handled while matching an event
+type ProfileTrace struct {
+	ID       string
+	Weight   float64
+	Filters  []*FilterTrace
+	Skipped  string // the reason the profile was dropped <*context|*ai|*filters|*last_matched|*weight>
+	Selected bool
+}
+
+// filterTraces returns where the filters are traced, nil if the tracing is disabled
+func (pt *ProfileTrace) filterTraces() *[]*FilterTrace {
+	if pt == nil {
+		return nil
+	}
+	return &pt.Filters
+}
+
+// skip records the reason the profile was dropped, safe to use on nil
+func (pt *ProfileTrace) skip(reason string) {
+	if pt == nil {
+		return
+	}
+	pt.Skipped = reason
+}
+
+// ProfileTraces collects the traces of the candidate profiles
+// the subsystems will not trace the matching if nil
+type ProfileTraces []*ProfileTrace
+
+// newTrace adds a trace for the profile, returns nil if the tracing is disabled
+func (pts *ProfileTraces) newTrace(id string, weight float64) (pt *ProfileTrace) {
+	if pts == nil {
+		return
+	}
+	pt = &ProfileTrace{ID: id, Weight: weight}
+	*pts = append(*pts, pt)
+	return
+}
+
+// selectIDs marks as selected the profiles with the given IDs
+func (pts *ProfileTraces) selectIDs(ids ...string) {
+	if pts == nil {
+		return
+	}
+	for _, pt := range *pts {
+		if utils.IsSliceMember(ids, pt.ID) {
+			pt.Selected = true
+		} else if pt.Skipped == utils.EmptyString { // passed but lost against a profile with higher weight
+			pt.Skipped = utils.MetaWeight
+		}
+	}
+}
+
+// filterRuleString returns the rule in the same format it is defined inline
+func filterRuleString(rule *FilterRule) string {
+	return utils.ConcatenatedKey(rule.Type, rule.Element, strings.Join(rule.Values, utils.InfieldSep))
+}
@@ -0,0 +1,294 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestProfileTracesNil(t *testing.T) {
+	var prfTrcs *ProfileTraces
+	pt := prfTrcs.newTrace("PRF1", 10)
+	if pt != nil {
+		t.Errorf("Expected nil trace, received %s", utils.ToJSON(pt))
+	}
+	pt.skip(utils.MetaFilters)
+	if fTrcs := pt.filterTraces(); fTrcs != nil {
+		t.Errorf("Expected nil filter traces, received %s", utils.ToJSON(fTrcs))
+	}
+	prfTrcs.selectIDs("PRF1")
+}
+
+func TestProfileTracesSelectIDs(t *testing.T) {
+	prfTrcs := new(ProfileTraces)
+	prfTrcs.newTrace("PRF1", 10)
+	prfTrcs.newTrace("PRF2", 20)
+	prfTrcs.newTrace("PRF3", 30).skip(utils.MetaFilters)
+	prfTrcs.selectIDs("PRF2")
+	exp := ProfileTraces{
+		{ID: "PRF1", Weight: 10, Skipped: utils.MetaWeight},
+		{ID: "PRF2", Weight: 20, Selected: true},
+		{ID: "PRF3", Weight: 30, Skipped: utils.MetaFilters},
+	}
+	if !reflect.DeepEqual(exp, *prfTrcs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(prfTrcs))
+	}
+}
+
+func TestFilterSPassWithTrace(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmTrc := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	fS := NewFilterS(cfg, nil, dmTrc)
+	ev := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+		},
+	}
+	var fTrcs []*FilterTrace
+	if pass, err := fS.passWithTrace("trace.org", []string{"*string:~*req.Account:1001",
+		"*prefix:~*req.Destination:+49"}, ev, &fTrcs); err != nil {
+		t.Fatal(err)
+	} else if pass {
+		t.Error("Expected the filters to not pass")
+	}
+	exp := []*FilterTrace{
+		{FilterID: "*string:~*req.Account:1001", Pass: true},
+		{FilterID: "*prefix:~*req.Destination:+49", FailedRule: "*prefix:~*req.Destination:+49"},
+	}
+	if !reflect.DeepEqual(exp, fTrcs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(fTrcs))
+	}
+	// without traces it behaves as Pass
+	if pass, err := fS.passWithTrace("trace.org", []string{"*string:~*req.Account:1001"},
+		ev, nil); err != nil {
+		t.Fatal(err)
+	} else if !pass {
+		t.Error("Expected the filters to pass")
+	}
+	if _, err := fS.passWithTrace("trace.org", []string{"FLTR_MISSING"},
+		ev, &fTrcs); err == nil || err.Error() != utils.ErrPrefixNotFound("FLTR_MISSING").Error() {
+		t.Errorf("Expected %v, received %v", utils.ErrPrefixNotFound("FLTR_MISSING"), err)
+	}
+}
+
+func TestAttributeSV1TraceEvent(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AttributeSCfg().ProcessRuns = 3
+	dmTrc := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	for _, attrPrf := range []*AttributeProfile{
+		{
+			Tenant:    "trace.org",
+			ID:        "ATTR_TRC_CATEGORY",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Attributes: []*Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Category,
+				Type:  utils.MetaConstant,
+				Value: config.NewRSRParsersMustCompile("premium", utils.InfieldSep),
+			}},
+			Weight: 20,
+		},
+		{
+			Tenant:    "trace.org",
+			ID:        "ATTR_TRC_SUBJECT",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"*string:~*req.Category:premium"},
+			Attributes: []*Attribute{
+				{
+					Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+					Type:  utils.MetaConstant,
+					Value: config.NewRSRParsersMustCompile("PREMIUM_1001", utils.InfieldSep),
+				},
+				{
+					FilterIDs: []string{"*string:~*req.Account:1002"},
+					Path:      utils.MetaReq + utils.NestingSep + utils.Destination,
+					Type:      utils.MetaConstant,
+					Value:     config.NewRSRParsersMustCompile("1003", utils.InfieldSep),
+				},
+			},
+			Blocker: true,
+			Weight:  10,
+		},
+		{
+			Tenant:   "trace.org",
+			ID:       "ATTR_TRC_SESSIONS",
+			Contexts: []string{utils.MetaSessionS},
+			Weight:   30,
+		},
+	} {
+		if err := dmTrc.SetAttributeProfile(attrPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	attrS := NewAttributeService(dmTrc, NewFilterS(cfg, nil, dmTrc), cfg)
+	args := &AttrArgsProcessEvent{
+		AttributeIDs: []string{"ATTR_TRC_SESSIONS", "ATTR_TRC_CATEGORY", "ATTR_TRC_SUBJECT"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "trace.org",
+			ID:     "TraceEvent",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Destination:  "1002",
+			},
+		},
+	}
+	var reply AttrSTraceReply
+	if err := attrS.V1TraceEvent(args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := AttrSTraceReply{
+		Runs: []*AttrSRunTrace{
+			{
+				Run: 1,
+				Profiles: ProfileTraces{
+					{ID: "ATTR_TRC_SESSIONS", Weight: 30, Skipped: utils.MetaContext},
+					{ID: "ATTR_TRC_CATEGORY", Weight: 20, Selected: true,
+						Filters: []*FilterTrace{{FilterID: "*string:~*req.Account:1001", Pass: true}}},
+					{ID: "ATTR_TRC_SUBJECT", Weight: 10, Skipped: utils.MetaFilters,
+						Filters: []*FilterTrace{{FilterID: "*string:~*req.Category:premium",
+							FailedRule: "*string:~*req.Category:premium"}}},
+				},
+				MatchedProfile: "ATTR_TRC_CATEGORY",
+				Substitutions: []*AttrSubstitutionTrace{{
+					Path:    utils.MetaReq + utils.NestingSep + utils.Category,
+					Type:    utils.MetaConstant,
+					Applied: true,
+					After:   "premium",
+				}},
+			},
+			{
+				Run: 2,
+				Profiles: ProfileTraces{
+					{ID: "ATTR_TRC_SESSIONS", Weight: 30, Skipped: utils.MetaContext},
+					{ID: "ATTR_TRC_CATEGORY", Weight: 20, Skipped: utils.MetaLastMatched,
+						Filters: []*FilterTrace{{FilterID: "*string:~*req.Account:1001", Pass: true}}},
+					{ID: "ATTR_TRC_SUBJECT", Weight: 10, Selected: true,
+						Filters: []*FilterTrace{{FilterID: "*string:~*req.Category:premium", Pass: true}}},
+				},
+				MatchedProfile: "ATTR_TRC_SUBJECT",
+				Substitutions: []*AttrSubstitutionTrace{
+					{
+						Path:    utils.MetaReq + utils.NestingSep + utils.Subject,
+						Type:    utils.MetaConstant,
+						Applied: true,
+						After:   "PREMIUM_1001",
+					},
+					{
+						Path: utils.MetaReq + utils.NestingSep + utils.Destination,
+						Type: utils.MetaConstant,
+						Filters: []*FilterTrace{{FilterID: "*string:~*req.Account:1002",
+							FailedRule: "*string:~*req.Account:1002"}},
+						Before: "1002",
+					},
+				},
+				Blocker: true,
+			},
+		},
+		CGREvent: &utils.CGREvent{
+			Tenant: "trace.org",
+			ID:     "TraceEvent",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Destination:  "1002",
+				utils.Category:     "premium",
+				utils.Subject:      "PREMIUM_1001",
+			},
+			Opts: map[string]interface{}{},
+		},
+	}
+	if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expected %s,\nreceived %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	// the errors are part of the trace
+	args.AttributeIDs = []string{"ATTR_TRC_SESSIONS"}
+	if err := attrS.V1TraceEvent(args, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Error != utils.ErrNotFound.Error() {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, reply.Error)
+	}
+	if len(reply.Runs) != 1 || reply.Runs[0].MatchedProfile != utils.EmptyString {
+		t.Errorf("Unexpected runs: %s", utils.ToJSON(reply.Runs))
+	}
+	if err := attrS.V1TraceEvent(&AttrArgsProcessEvent{}, &reply); err == nil {
+		t.Error("Expected error for missing event")
+	}
+}
+
+func TestChargerSMatchingProfilesWithTrace(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmTrc := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	for _, cP := range []*ChargerProfile{
+		{
+			Tenant:    "trace.org",
+			ID:        "CHRG_TRC_DEFAULT",
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			RunID:     utils.MetaDefault,
+			Weight:    20,
+		},
+		{
+			Tenant:    "trace.org",
+			ID:        "CHRG_TRC_SUPPLIER",
+			FilterIDs: []string{"*string:~*req.Account:1001", "*prefix:~*req.Destination:+49"},
+			RunID:     "supplier",
+			Weight:    10,
+		},
+	} {
+		if err := dmTrc.SetChargerProfile(cP, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cS := NewChargerService(dmTrc, NewFilterS(cfg, nil, dmTrc), cfg, nil)
+	prfTrcs := new(ProfileTraces)
+	cPs, err := cS.matchingChargerProfilesForEvent("trace.org", &utils.CGREvent{
+		Tenant: "trace.org",
+		ID:     "TraceCharger",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+		},
+	}, prfTrcs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cPs) != 1 || cPs[0].ID != "CHRG_TRC_DEFAULT" {
+		t.Errorf("Unexpected chargers: %s", utils.ToJSON(cPs))
+	}
+	trcs := make(map[string]*ProfileTrace)
+	for _, pt := range *prfTrcs {
+		trcs[pt.ID] = pt
+	}
+	exp := map[string]*ProfileTrace{
+		"CHRG_TRC_DEFAULT": {ID: "CHRG_TRC_DEFAULT", Weight: 20, Selected: true,
+			Filters: []*FilterTrace{{FilterID: "*string:~*req.Account:1001", Pass: true}}},
+		"CHRG_TRC_SUPPLIER": {ID: "CHRG_TRC_SUPPLIER", Weight: 10, Skipped: utils.MetaFilters,
+			Filters: []*FilterTrace{
+				{FilterID: "*string:~*req.Account:1001", Pass: true},
+				{FilterID: "*prefix:~*req.Destination:+49", FailedRule: "*prefix:~*req.Destination:+49"},
+			}},
+	}
+	if !reflect.DeepEqual(exp, trcs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(trcs))
+	}
+}
@@ -1345,6 +1345,20 @@ func TestLoadChargerProfiles(t *testing.T) {
 			RunID:        "*rated",
 			AttributeIDs: []string{"ATTR_1001_SIMPLEAUTH"},
 			Weight:       20,
+			Aggregations: []*utils.TPChargerAggregation{
+				{
+					Path:         "Margin",
+					Type:         utils.MetaDifference,
+					RunIDs:       []string{"*rated", "SupplierCharges"},
+					ThresholdIDs: []string{"THD_NEGATIVE_MARGIN"},
+				},
+				{
+					Path:      "TotalCosts",
+					FilterIDs: []string{"*string:~*req.Category:call"},
+					Type:      utils.MetaSum,
+					RunIDs:    []string{"*rated", "SupplierCharges"},
+				},
+			},
 		},
 	}
 	cppKey := utils.TenantID{Tenant: "cgrates.org", ID: "Charger1"}
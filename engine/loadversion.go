@@ -0,0 +1,56 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LoadVersionItem is one profile changed by an atomic load, the profiles are kept JSON encoded
+type LoadVersionItem struct {
+	LoaderType string
+	TenantID   string
+	Previous   []byte   // the profile before the load, nil if it was not stored
+	Profile    []byte   // the profile written by the load, nil on remove
+	Partial    bool     // *partial RateProfile, only the rates are set
+	RateIDs    []string // the rates removed on *partial remove
+}
+
+// LoadVersion is one atomic load of a loader, staged in DataDB before being switched in
+// and kept afterwards so it can be rolled back
+type LoadVersion struct {
+	LoaderID   string
+	Version    int
+	LoadOption string
+	LoadTime   time.Time
+	Items      []*LoadVersionItem
+}
+
+// LoadVersionID returns the concatenated key between the loader ID and the version
+func (lv *LoadVersion) LoadVersionID() string {
+	return LoadVersionID(lv.LoaderID, lv.Version)
+}
+
+// LoadVersionID returns the key of the given version of the loader
+func LoadVersionID(ldrID string, version int) string {
+	return utils.ConcatenatedKey(ldrID, strconv.Itoa(version))
+}
@@ -0,0 +1,70 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LookupTable maps keys (ie: numbers, IMSIs) to values
+// referenced from the other subsystems with ~*lookup.<ID>.<key>
+type LookupTable struct {
+	Tenant  string
+	ID      string
+	Mode    string // <*string|*prefix>, *string if empty
+	Default string // returned when no key matches, the lookup fails if empty
+	Entries map[string]string
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (lt *LookupTable) TenantID() string {
+	return utils.ConcatenatedKey(lt.Tenant, lt.ID)
+}
+
+// Compile validates the LookupTable
+func (lt *LookupTable) Compile() (err error) {
+	switch lt.Mode {
+	case utils.EmptyString, utils.MetaString, utils.MetaPrefix:
+	default:
+		return fmt.Errorf("unsupported lookup mode: <%s> for LookupTable: <%s>", lt.Mode, lt.TenantID())
+	}
+	return
+}
+
+// Lookup returns the value for the key
+// on *prefix mode the entry with the longest key which prefixes the searched one is used
+func (lt *LookupTable) Lookup(key string) (val string, err error) {
+	var has bool
+	if val, has = lt.Entries[key]; has {
+		return
+	}
+	if lt.Mode == utils.MetaPrefix {
+		for i := len(key) - 1; i > 0; i-- {
+			if val, has = lt.Entries[key[:i]]; has {
+				return
+			}
+		}
+	}
+	if lt.Default == utils.EmptyString {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	return lt.Default, nil
+}
@@ -0,0 +1,160 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLookupTableLookup(t *testing.T) {
+	lt := &LookupTable{
+		Tenant: "cgrates.org",
+		ID:     "PORTED_NUMBERS",
+		Entries: map[string]string{
+			"4915":   "NET_A",
+			"491511": "NET_B",
+		},
+	}
+	if err := lt.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if val, err := lt.Lookup("4915"); err != nil {
+		t.Error(err)
+	} else if val != "NET_A" {
+		t.Errorf("Expected NET_A, received %s", val)
+	}
+	// *string mode needs the exact key
+	if _, err := lt.Lookup("49151123"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	lt.Mode = utils.MetaPrefix
+	for key, exp := range map[string]string{
+		"49151123": "NET_B",
+		"49152123": "NET_A",
+		"491511":   "NET_B",
+	} {
+		if val, err := lt.Lookup(key); err != nil {
+			t.Errorf("<%s>: %v", key, err)
+		} else if val != exp {
+			t.Errorf("<%s> expected %s, received %s", key, exp, val)
+		}
+	}
+	if _, err := lt.Lookup("331234"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	lt.Default = "HOME_NET"
+	if val, err := lt.Lookup("331234"); err != nil {
+		t.Error(err)
+	} else if val != "HOME_NET" {
+		t.Errorf("Expected HOME_NET, received %s", val)
+	}
+	lt.Mode = "*invalid"
+	if err := lt.Compile(); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
+
+func TestDataManagerLookupTable(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	lt := &LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT_DM",
+		Mode:    utils.MetaPrefix,
+		Entries: map[string]string{"4915": "NET_A"},
+	}
+	if err := dm.SetLookupTable(lt); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetLookupTable("cgrates.org", "LKT_DM", false, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if rcv.Entries["4915"] != "NET_A" {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(lt), utils.ToJSON(rcv))
+	}
+	if err := dm.RemoveLookupTable("cgrates.org", "LKT_DM", utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetLookupTable("cgrates.org", "LKT_DM", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := dm.RemoveLookupTable("cgrates.org", "LKT_DM", utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	lt.Mode = "*invalid"
+	if err := dm.SetLookupTable(lt); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
+
+func TestAttributeProcessEventLookupTable(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AttributeSCfg().ProcessRuns = 1
+	dmLkt := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	defer SetDataStorage(dm)
+	SetDataStorage(dmLkt)
+	if err := dmLkt.SetLookupTable(&LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "LKT_PORTED_NUMBERS",
+		Mode:    utils.MetaPrefix,
+		Default: "HOME_NET",
+		Entries: map[string]string{
+			"4915":   "NET_A",
+			"491511": "NET_B",
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmLkt.SetAttributeProfile(&AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_LOOKUP",
+		Contexts: []string{utils.MetaAny},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + "Network",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*lookup.LKT_PORTED_NUMBERS.<~*req.Destination>", utils.InfieldSep),
+		}},
+		Weight: 10,
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	attrS := NewAttributeService(dmLkt, &FilterS{dm: dmLkt, cfg: cfg}, cfg)
+	for dst, exp := range map[string]string{
+		"49151123": "NET_B",
+		"49152123": "NET_A",
+		"331234":   "HOME_NET",
+	} {
+		var reply AttrSProcessEventReply
+		if err := attrS.V1ProcessEvent(&AttrArgsProcessEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "LookupEvent",
+				Event: map[string]interface{}{
+					utils.Destination: dst,
+				},
+			},
+		}, &reply); err != nil {
+			t.Fatalf("<%s>: %v", dst, err)
+		}
+		if rcv := reply.CGREvent.Event["Network"]; rcv != exp {
+			t.Errorf("<%s> expected %s, received %v", dst, exp, rcv)
+		}
+	}
+}
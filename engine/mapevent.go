@@ -99,6 +99,18 @@ func (me MapEvent) GetStringIgnoreErrors(fldName string) (out string) {
 	return
 }
 
+// LogFields returns the fields identifying the event within the structured logs
+func (me MapEvent) LogFields(tnt string) (flds utils.LogFields) {
+	flds = utils.LogFields{utils.LogFieldTenant: tnt}
+	if cgrID := me.GetStringIgnoreErrors(utils.CGRID); cgrID != utils.EmptyString {
+		flds[utils.LogFieldCGRID] = cgrID
+	}
+	if originID := me.GetStringIgnoreErrors(utils.OriginID); originID != utils.EmptyString {
+		flds[utils.LogFieldOriginID] = originID
+	}
+	return
+}
+
 // GetDuration returns a field as Duration
 func (me MapEvent) GetDuration(fldName string) (d time.Duration, err error) {
 	fldIface, has := me[fldName]
@@ -155,6 +155,25 @@ func TestMapEventGetStringIgnoreErrors(t *testing.T) {
 	}
 }
 
+func TestMapEventLogFields(t *testing.T) {
+	exp := utils.LogFields{utils.LogFieldTenant: "cgrates.org"}
+	if rply := mapEv.LogFields("cgrates.org"); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expecting %+v, received: %+v", exp, rply)
+	}
+	me := MapEvent{
+		utils.CGRID:    "cgrid1",
+		utils.OriginID: "origin1",
+	}
+	exp = utils.LogFields{
+		utils.LogFieldTenant:   "cgrates.org",
+		utils.LogFieldCGRID:    "cgrid1",
+		utils.LogFieldOriginID: "origin1",
+	}
+	if rply := me.LogFields("cgrates.org"); !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expecting %+v, received: %+v", exp, rply)
+	}
+}
+
 func TestMapEventGetDuration(t *testing.T) {
 	if rply, err := mapEv.GetDuration("test"); err != utils.ErrNotFound {
 		t.Errorf("Expected: %+v, received: %+v", utils.ErrNotFound, err)
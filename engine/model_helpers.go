@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +42,10 @@ func csvLoad(s interface{}, values []string) (interface{}, error) {
 		index := field.Tag.Get("index")
 		if index != utils.EmptyString {
 			idx, err := strconv.Atoi(index)
+			if err == nil && len(values) <= idx &&
+				field.Tag.Get("optional") == "true" { // column missing from older files
+				continue
+			}
 			if err != nil || len(values) <= idx {
 				return nil, fmt.Errorf("invalid %v.%v index %v", st.Name(), field.Name, index)
 			}
@@ -92,7 +97,7 @@ func csvLoad(s interface{}, values []string) (interface{}, error) {
 	return elem.Interface(), nil
 }
 
-//CsvDump receive and interface and convert it to a slice of string
+// CsvDump receive and interface and convert it to a slice of string
 func CsvDump(s interface{}) ([]string, error) {
 	fieldIndexMap := make(map[string]int)
 	st := reflect.ValueOf(s)
@@ -132,18 +137,22 @@ func CsvDump(s interface{}) ([]string, error) {
 	return result, nil
 }
 
-func getColumnCount(s interface{}) int {
+// getColumnCount returns the number of columns of the model and the number of
+// the trailing ones that can be missing from the files older than them
+func getColumnCount(s interface{}) (count, optional int) {
 	st := reflect.TypeOf(s)
 	numFields := st.NumField()
-	count := 0
 	for i := 0; i < numFields; i++ {
 		field := st.Field(i)
 		index := field.Tag.Get("index")
 		if index != utils.EmptyString {
+			if field.Tag.Get("optional") == "true" {
+				optional++
+			}
 			count++
 		}
 	}
-	return count
+	return
 }
 
 type DestinationMdls []DestinationMdl
@@ -1837,9 +1846,9 @@ type RouteMdls []*RouteMdl
 func (tps RouteMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
-		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteRateProfileIDs, utils.RouteResourceIDs,
+		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.RouteRateProfileIDs,
 	}
 }
 
@@ -1885,6 +1894,10 @@ func (tps RouteMdls) AsTPRouteProfile() (result []*utils.TPRouteProfile) {
 				ratingPlanSplit := strings.Split(tp.RouteRatingplanIDs, utils.InfieldSep)
 				sup.RatingPlanIDs = append(sup.RatingPlanIDs, ratingPlanSplit...)
 			}
+			if tp.RouteRateProfileIDs != utils.EmptyString {
+				rateProfileSplit := strings.Split(tp.RouteRateProfileIDs, utils.InfieldSep)
+				sup.RateProfileIDs = append(sup.RateProfileIDs, rateProfileSplit...)
+			}
 			if tp.RouteResourceIDs != utils.EmptyString {
 				resSplit := strings.Split(tp.RouteResourceIDs, utils.InfieldSep)
 				sup.ResourceIDs = append(sup.ResourceIDs, resSplit...)
@@ -1990,6 +2003,12 @@ func APItoModelTPRoutes(st *utils.TPRouteProfile) (mdls RouteMdls) {
 			}
 			mdl.RouteRatingplanIDs += val
 		}
+		for i, val := range supl.RateProfileIDs {
+			if i != 0 {
+				mdl.RouteRateProfileIDs += utils.InfieldSep
+			}
+			mdl.RouteRateProfileIDs += val
+		}
 		for i, val := range supl.FilterIDs {
 			if i != 0 {
 				mdl.RouteFilterIDs += utils.InfieldSep
@@ -2043,6 +2062,7 @@ func APItoRouteProfile(tpRp *utils.TPRouteProfile, timezone string) (rp *RoutePr
 			Weight:          route.Weight,
 			Blocker:         route.Blocker,
 			RatingPlanIDs:   route.RatingPlanIDs,
+			RateProfileIDs:  route.RateProfileIDs,
 			AccountIDs:      route.AccountIDs,
 			FilterIDs:       route.FilterIDs,
 			ResourceIDs:     route.ResourceIDs,
@@ -2071,6 +2091,7 @@ func RouteProfileToAPI(rp *RouteProfile) (tpRp *utils.TPRouteProfile) {
 			FilterIDs:       route.FilterIDs,
 			AccountIDs:      route.AccountIDs,
 			RatingPlanIDs:   route.RatingPlanIDs,
+			RateProfileIDs:  route.RateProfileIDs,
 			ResourceIDs:     route.ResourceIDs,
 			StatIDs:         route.StatIDs,
 			Weight:          route.Weight,
@@ -2298,13 +2319,15 @@ type ChargerMdls []*ChargerMdl
 // CSVHeader return the header for csv fields as a slice of string
 func (tps ChargerMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
-		utils.RunID, utils.AttributeIDs, utils.Weight}
+		utils.RunID, utils.AttributeIDs, utils.Weight, utils.AggregationPath, utils.AggregationFilterIDs,
+		utils.AggregationType, utils.AggregationRunIDs, utils.AggregationThresholdIDs}
 }
 
 func (tps ChargerMdls) AsTPChargers() (result []*utils.TPChargerProfile) {
 	mst := make(map[string]*utils.TPChargerProfile)
 	filterMap := make(map[string]utils.StringSet)
 	attributeMap := make(map[string][]string)
+	aggrMap := make(map[string]map[string]*utils.TPChargerAggregation) // map[tenantID]map[path]*TPChargerAggregation
 	for _, tp := range tps {
 		tntID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		tpCPP, found := mst[tntID]
@@ -2362,6 +2385,29 @@ func (tps ChargerMdls) AsTPChargers() (result []*utils.TPChargerProfile) {
 				inlineAttribute = utils.EmptyString
 			}
 		}
+		if tp.AggregationPath != utils.EmptyString {
+			if _, has := aggrMap[tntID]; !has {
+				aggrMap[tntID] = make(map[string]*utils.TPChargerAggregation)
+			}
+			aggr, has := aggrMap[tntID][tp.AggregationPath]
+			if !has {
+				aggr = &utils.TPChargerAggregation{Path: tp.AggregationPath}
+				aggrMap[tntID][tp.AggregationPath] = aggr
+				tpCPP.Aggregations = append(tpCPP.Aggregations, aggr)
+			}
+			if tp.AggregationType != utils.EmptyString {
+				aggr.Type = tp.AggregationType
+			}
+			if tp.AggregationFilterIDs != utils.EmptyString {
+				aggr.FilterIDs = append(aggr.FilterIDs, strings.Split(tp.AggregationFilterIDs, utils.InfieldSep)...)
+			}
+			if tp.AggregationRunIDs != utils.EmptyString { // keep the order since *difference substracts from the first run
+				aggr.RunIDs = append(aggr.RunIDs, strings.Split(tp.AggregationRunIDs, utils.InfieldSep)...)
+			}
+			if tp.AggregationThresholdIDs != utils.EmptyString {
+				aggr.ThresholdIDs = append(aggr.ThresholdIDs, strings.Split(tp.AggregationThresholdIDs, utils.InfieldSep)...)
+			}
+		}
 		mst[tntID] = tpCPP
 	}
 	result = make([]*utils.TPChargerProfile, len(mst))
@@ -2455,7 +2501,20 @@ func APItoModelTPCharger(tpCPP *utils.TPChargerProfile) (mdls ChargerMdls) {
 				mdls = append(mdls, mdl)
 			}
 		}
-
+		for i, aggr := range tpCPP.Aggregations {
+			if i == len(mdls) {
+				mdls = append(mdls, &ChargerMdl{
+					Tenant: tpCPP.Tenant,
+					Tpid:   tpCPP.TPid,
+					ID:     tpCPP.ID,
+				})
+			}
+			mdls[i].AggregationPath = aggr.Path
+			mdls[i].AggregationFilterIDs = strings.Join(aggr.FilterIDs, utils.InfieldSep)
+			mdls[i].AggregationType = aggr.Type
+			mdls[i].AggregationRunIDs = strings.Join(aggr.RunIDs, utils.InfieldSep)
+			mdls[i].AggregationThresholdIDs = strings.Join(aggr.ThresholdIDs, utils.InfieldSep)
+		}
 	}
 	return
 }
@@ -2475,6 +2534,21 @@ func APItoChargerProfile(tpCPP *utils.TPChargerProfile, timezone string) (cpp *C
 	for i, attribute := range tpCPP.AttributeIDs {
 		cpp.AttributeIDs[i] = attribute
 	}
+	if len(tpCPP.Aggregations) != 0 {
+		cpp.Aggregations = make([]*ChargerAggregation, len(tpCPP.Aggregations))
+		for i, aggr := range tpCPP.Aggregations {
+			cpp.Aggregations[i] = &ChargerAggregation{
+				Path:         aggr.Path,
+				FilterIDs:    aggr.FilterIDs,
+				Type:         aggr.Type,
+				RunIDs:       aggr.RunIDs,
+				ThresholdIDs: aggr.ThresholdIDs,
+			}
+			if err = cpp.Aggregations[i].Validate(); err != nil {
+				return nil, fmt.Errorf("invalid aggregation for path <%s>: %s", aggr.Path, err)
+			}
+		}
+	}
 	if tpCPP.ActivationInterval != nil {
 		if cpp.ActivationInterval, err = tpCPP.ActivationInterval.AsActivationInterval(timezone); err != nil {
 			return nil, err
@@ -2499,6 +2573,18 @@ func ChargerProfileToAPI(chargerPrf *ChargerProfile) (tpCharger *utils.TPCharger
 	for i, fli := range chargerPrf.AttributeIDs {
 		tpCharger.AttributeIDs[i] = fli
 	}
+	if len(chargerPrf.Aggregations) != 0 {
+		tpCharger.Aggregations = make([]*utils.TPChargerAggregation, len(chargerPrf.Aggregations))
+		for i, aggr := range chargerPrf.Aggregations {
+			tpCharger.Aggregations[i] = &utils.TPChargerAggregation{
+				Path:         aggr.Path,
+				FilterIDs:    aggr.FilterIDs,
+				Type:         aggr.Type,
+				RunIDs:       aggr.RunIDs,
+				ThresholdIDs: aggr.ThresholdIDs,
+			}
+		}
+	}
 	if chargerPrf.ActivationInterval != nil {
 		if !chargerPrf.ActivationInterval.ActivationTime.IsZero() {
 			tpCharger.ActivationInterval.ActivationTime = chargerPrf.ActivationInterval.ActivationTime.Format(time.RFC3339)
@@ -2900,6 +2986,7 @@ func (tps RateProfileMdls) CSVHeader() (result []string) {
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
 		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.Currency, utils.VolumePeriod,
 	}
 }
 
@@ -2972,6 +3059,12 @@ func (tps RateProfileMdls) AsTPRateProfile() (result []*utils.TPRateProfile) {
 		if tp.MaxCostStrategy != utils.EmptyString {
 			rPrf.MaxCostStrategy = tp.MaxCostStrategy
 		}
+		if tp.Currency != utils.EmptyString {
+			rPrf.Currency = tp.Currency
+		}
+		if tp.VolumePeriod != utils.EmptyString {
+			rPrf.VolumePeriod = tp.VolumePeriod
+		}
 		if tp.ActivationInterval != utils.EmptyString {
 			rPrf.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(tp.ActivationInterval, utils.InfieldSep)
@@ -3033,6 +3126,8 @@ func APItoModelTPRateProfile(tPrf *utils.TPRateProfile) (mdls RateProfileMdls) {
 				mdl.MinCost = tPrf.MinCost
 				mdl.MaxCost = tPrf.MaxCost
 				mdl.MaxCostStrategy = tPrf.MaxCostStrategy
+				mdl.Currency = tPrf.Currency
+				mdl.VolumePeriod = tPrf.VolumePeriod
 			}
 			mdl.RateID = rate.ID
 			if j == 0 {
@@ -3066,6 +3161,8 @@ func APItoRateProfile(tpRp *utils.TPRateProfile, timezone string) (rp *RateProfi
 		ID:              tpRp.ID,
 		FilterIDs:       make([]string, len(tpRp.FilterIDs)),
 		MaxCostStrategy: tpRp.MaxCostStrategy,
+		Currency:        tpRp.Currency,
+		VolumePeriod:    tpRp.VolumePeriod,
 		Rates:           make(map[string]*Rate),
 		MinCost:         utils.NewDecimalFromFloat64(tpRp.MinCost),
 		MaxCost:         utils.NewDecimalFromFloat64(tpRp.MaxCost),
@@ -3126,6 +3223,8 @@ func RateProfileToAPI(rp *RateProfile) (tpRp *utils.TPRateProfile) {
 		ActivationInterval: new(utils.TPActivationInterval),
 		Weights:            rp.Weights.String(";", "&"),
 		MaxCostStrategy:    rp.MaxCostStrategy,
+		Currency:           rp.Currency,
+		VolumePeriod:       rp.VolumePeriod,
 		Rates:              make(map[string]*utils.TPRate),
 	}
 	if rp.MinCost != nil {
@@ -3815,3 +3914,516 @@ func AccountProfileToAPI(ap *utils.AccountProfile) (tpAp *utils.TPAccountProfile
 	}
 	return
 }
+
+type ExchangeRateProfileMdls []*ExchangeRateProfileMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (erm ExchangeRateProfileMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.FromCurrency,
+		utils.ToCurrency, utils.ActivationTime, utils.ExchangeRateString,
+	}
+}
+
+func (tps ExchangeRateProfileMdls) AsTPExchangeRateProfile() (result []*utils.TPExchangeRateProfile) {
+	erPrfMap := make(map[string]*utils.TPExchangeRateProfile)
+	tntIDs := make([]string, 0, len(tps)) // keep the order of the profiles as loaded
+	for _, tp := range tps {
+		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
+		erPrf, found := erPrfMap[tenID]
+		if !found {
+			erPrf = &utils.TPExchangeRateProfile{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			erPrfMap[tenID] = erPrf
+			tntIDs = append(tntIDs, tenID)
+		}
+		if tp.FromCurrency != utils.EmptyString {
+			erPrf.Rates = append(erPrf.Rates, &utils.TPExchangeRate{
+				FromCurrency:   tp.FromCurrency,
+				ToCurrency:     tp.ToCurrency,
+				ActivationTime: tp.ActivationTime,
+				Rate:           tp.Rate,
+			})
+		}
+	}
+	result = make([]*utils.TPExchangeRateProfile, len(tntIDs))
+	for i, tntID := range tntIDs {
+		result[i] = erPrfMap[tntID]
+	}
+	return
+}
+
+func APItoModelTPExchangeRateProfile(tPrf *utils.TPExchangeRateProfile) (mdls ExchangeRateProfileMdls) {
+	for _, rt := range tPrf.Rates {
+		mdls = append(mdls, &ExchangeRateProfileMdl{
+			Tpid:           tPrf.TPid,
+			Tenant:         tPrf.Tenant,
+			ID:             tPrf.ID,
+			FromCurrency:   rt.FromCurrency,
+			ToCurrency:     rt.ToCurrency,
+			ActivationTime: rt.ActivationTime,
+			Rate:           rt.Rate,
+		})
+	}
+	return
+}
+
+func APItoExchangeRateProfile(tpErp *utils.TPExchangeRateProfile, timezone string) (erp *utils.ExchangeRateProfile, err error) {
+	erp = &utils.ExchangeRateProfile{
+		Tenant: tpErp.Tenant,
+		ID:     tpErp.ID,
+		Rates:  make([]*utils.ExchangeRate, len(tpErp.Rates)),
+	}
+	for i, rt := range tpErp.Rates {
+		if rt.ToCurrency == utils.EmptyString {
+			return nil, fmt.Errorf("empty %s for exchange rate from <%s>", utils.ToCurrency, rt.FromCurrency)
+		}
+		erp.Rates[i] = &utils.ExchangeRate{
+			FromCurrency: rt.FromCurrency,
+			ToCurrency:   rt.ToCurrency,
+			Rate:         utils.NewDecimalFromFloat64(rt.Rate),
+		}
+		if rt.ActivationTime != utils.EmptyString {
+			if erp.Rates[i].ActivationTime, err = utils.ParseTimeDetectLayout(rt.ActivationTime, timezone); err != nil {
+				return nil, err
+			}
+		}
+	}
+	erp.Sort()
+	return
+}
+
+func ExchangeRateProfileToAPI(erp *utils.ExchangeRateProfile) (tpErp *utils.TPExchangeRateProfile) {
+	tpErp = &utils.TPExchangeRateProfile{
+		Tenant: erp.Tenant,
+		ID:     erp.ID,
+		Rates:  make([]*utils.TPExchangeRate, len(erp.Rates)),
+	}
+	for i, rt := range erp.Rates {
+		tpErp.Rates[i] = &utils.TPExchangeRate{
+			FromCurrency: rt.FromCurrency,
+			ToCurrency:   rt.ToCurrency,
+		}
+		if !rt.ActivationTime.IsZero() {
+			tpErp.Rates[i].ActivationTime = rt.ActivationTime.Format(time.RFC3339)
+		}
+		if rt.Rate != nil {
+			//there should not be an invalid value of converting from Decimal into float64
+			tpErp.Rates[i].Rate, _ = rt.Rate.Float64()
+		}
+	}
+	return
+}
+
+type RateDeckMdls []*RateDeckMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (rdm RateDeckMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.IncreaseNotice, utils.DecreaseNotice,
+		utils.Prefix, utils.Description, utils.RateString, utils.EffectiveDate, utils.ExpiryDate,
+	}
+}
+
+func (tps RateDeckMdls) AsTPRateDeck() (result []*utils.TPRateDeck) {
+	rdMap := make(map[string]*utils.TPRateDeck)
+	tntIDs := make([]string, 0, len(tps)) // keep the order of the decks as loaded
+	for _, tp := range tps {
+		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
+		rd, found := rdMap[tenID]
+		if !found {
+			rd = &utils.TPRateDeck{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			rdMap[tenID] = rd
+			tntIDs = append(tntIDs, tenID)
+		}
+		if tp.IncreaseNotice != utils.EmptyString {
+			rd.IncreaseNotice = tp.IncreaseNotice
+		}
+		if tp.DecreaseNotice != utils.EmptyString {
+			rd.DecreaseNotice = tp.DecreaseNotice
+		}
+		if tp.Prefix != utils.EmptyString {
+			rd.Rates = append(rd.Rates, &utils.TPRateDeckRate{
+				Prefix:        tp.Prefix,
+				Description:   tp.Description,
+				Rate:          tp.Rate,
+				EffectiveDate: tp.EffectiveDate,
+				ExpiryDate:    tp.ExpiryDate,
+			})
+		}
+	}
+	result = make([]*utils.TPRateDeck, len(tntIDs))
+	for i, tntID := range tntIDs {
+		result[i] = rdMap[tntID]
+	}
+	return
+}
+
+func APItoModelTPRateDeck(tPrf *utils.TPRateDeck) (mdls RateDeckMdls) {
+	for i, rt := range tPrf.Rates {
+		mdl := &RateDeckMdl{
+			Tpid:          tPrf.TPid,
+			Tenant:        tPrf.Tenant,
+			ID:            tPrf.ID,
+			Prefix:        rt.Prefix,
+			Description:   rt.Description,
+			Rate:          rt.Rate,
+			EffectiveDate: rt.EffectiveDate,
+			ExpiryDate:    rt.ExpiryDate,
+		}
+		if i == 0 {
+			mdl.IncreaseNotice = tPrf.IncreaseNotice
+			mdl.DecreaseNotice = tPrf.DecreaseNotice
+		}
+		mdls = append(mdls, mdl)
+	}
+	return
+}
+
+func APItoRateDeck(tpRd *utils.TPRateDeck, timezone string) (rd *RateDeck, err error) {
+	rd = &RateDeck{
+		Tenant: tpRd.Tenant,
+		ID:     tpRd.ID,
+		Rates:  make([]*RateDeckRate, len(tpRd.Rates)),
+	}
+	if tpRd.IncreaseNotice != utils.EmptyString {
+		if rd.IncreaseNotice, err = utils.ParseDurationWithNanosecs(tpRd.IncreaseNotice); err != nil {
+			return nil, err
+		}
+	}
+	if tpRd.DecreaseNotice != utils.EmptyString {
+		if rd.DecreaseNotice, err = utils.ParseDurationWithNanosecs(tpRd.DecreaseNotice); err != nil {
+			return nil, err
+		}
+	}
+	for i, rt := range tpRd.Rates {
+		rd.Rates[i] = &RateDeckRate{
+			Prefix:      rt.Prefix,
+			Description: rt.Description,
+			Rate:        utils.NewDecimalFromFloat64(rt.Rate),
+		}
+		if rt.EffectiveDate != utils.EmptyString {
+			if rd.Rates[i].EffectiveDate, err = utils.ParseTimeDetectLayout(rt.EffectiveDate, timezone); err != nil {
+				return nil, err
+			}
+		}
+		if rt.ExpiryDate != utils.EmptyString {
+			if rd.Rates[i].ExpiryDate, err = utils.ParseTimeDetectLayout(rt.ExpiryDate, timezone); err != nil {
+				return nil, err
+			}
+		}
+	}
+	rd.Sort()
+	return
+}
+
+func RateDeckToAPI(rd *RateDeck) (tpRd *utils.TPRateDeck) {
+	tpRd = &utils.TPRateDeck{
+		Tenant: rd.Tenant,
+		ID:     rd.ID,
+		Rates:  make([]*utils.TPRateDeckRate, len(rd.Rates)),
+	}
+	if rd.IncreaseNotice != 0 {
+		tpRd.IncreaseNotice = rd.IncreaseNotice.String()
+	}
+	if rd.DecreaseNotice != 0 {
+		tpRd.DecreaseNotice = rd.DecreaseNotice.String()
+	}
+	for i, rt := range rd.Rates {
+		tpRd.Rates[i] = &utils.TPRateDeckRate{
+			Prefix:      rt.Prefix,
+			Description: rt.Description,
+		}
+		if !rt.EffectiveDate.IsZero() {
+			tpRd.Rates[i].EffectiveDate = rt.EffectiveDate.Format(time.RFC3339)
+		}
+		if !rt.ExpiryDate.IsZero() {
+			tpRd.Rates[i].ExpiryDate = rt.ExpiryDate.Format(time.RFC3339)
+		}
+		if rt.Rate != nil {
+			//there should not be an invalid value of converting from Decimal into float64
+			tpRd.Rates[i].Rate, _ = rt.Rate.Float64()
+		}
+	}
+	return
+}
+
+type TaxProfileMdls []*TaxProfileMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (tps TaxProfileMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
+		utils.Weights, utils.Jurisdiction, utils.TaxType, utils.RateString, utils.Compound,
+		utils.ExemptFilterIDs,
+	}
+}
+
+func (tps TaxProfileMdls) AsTPTaxProfile() (result []*utils.TPTaxProfile) {
+	tpMap := make(map[string]*utils.TPTaxProfile)
+	filterMap := make(map[string]utils.StringSet)
+	exemptMap := make(map[string]utils.StringSet)
+	tntIDs := make([]string, 0, len(tps)) // keep the order of the profiles as loaded
+	for _, tp := range tps {
+		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
+		tpTx, found := tpMap[tenID]
+		if !found {
+			tpTx = &utils.TPTaxProfile{
+				TPid:   tp.Tpid,
+				Tenant: tp.Tenant,
+				ID:     tp.ID,
+			}
+			tpMap[tenID] = tpTx
+			filterMap[tenID] = make(utils.StringSet)
+			exemptMap[tenID] = make(utils.StringSet)
+			tntIDs = append(tntIDs, tenID)
+		}
+		if tp.FilterIDs != utils.EmptyString {
+			for _, fltrID := range strings.Split(tp.FilterIDs, utils.InfieldSep) {
+				if !filterMap[tenID].Has(fltrID) {
+					filterMap[tenID].Add(fltrID)
+					tpTx.FilterIDs = append(tpTx.FilterIDs, fltrID)
+				}
+			}
+		}
+		if tp.ActivationInterval != utils.EmptyString {
+			tpTx.ActivationInterval = new(utils.TPActivationInterval)
+			aiSplt := strings.Split(tp.ActivationInterval, utils.InfieldSep)
+			if len(aiSplt) == 2 {
+				tpTx.ActivationInterval.ActivationTime = aiSplt[0]
+				tpTx.ActivationInterval.ExpiryTime = aiSplt[1]
+			} else if len(aiSplt) == 1 {
+				tpTx.ActivationInterval.ActivationTime = aiSplt[0]
+			}
+		}
+		if tp.Weights != utils.EmptyString {
+			tpTx.Weights = tp.Weights
+		}
+		if tp.Jurisdiction != utils.EmptyString {
+			tpTx.Jurisdiction = tp.Jurisdiction
+		}
+		if tp.TaxType != utils.EmptyString {
+			tpTx.TaxType = tp.TaxType
+		}
+		if tp.Rate != 0 {
+			tpTx.Rate = tp.Rate
+		}
+		if tp.Compound {
+			tpTx.Compound = tp.Compound
+		}
+		if tp.ExemptFilterIDs != utils.EmptyString {
+			for _, fltrID := range strings.Split(tp.ExemptFilterIDs, utils.InfieldSep) {
+				if !exemptMap[tenID].Has(fltrID) {
+					exemptMap[tenID].Add(fltrID)
+					tpTx.ExemptFilterIDs = append(tpTx.ExemptFilterIDs, fltrID)
+				}
+			}
+		}
+	}
+	result = make([]*utils.TPTaxProfile, len(tntIDs))
+	for i, tntID := range tntIDs {
+		result[i] = tpMap[tntID]
+	}
+	return
+}
+
+func APItoModelTPTaxProfile(tPrf *utils.TPTaxProfile) (mdls TaxProfileMdls) {
+	mdl := &TaxProfileMdl{
+		Tpid:            tPrf.TPid,
+		Tenant:          tPrf.Tenant,
+		ID:              tPrf.ID,
+		FilterIDs:       strings.Join(tPrf.FilterIDs, utils.InfieldSep),
+		Weights:         tPrf.Weights,
+		Jurisdiction:    tPrf.Jurisdiction,
+		TaxType:         tPrf.TaxType,
+		Rate:            tPrf.Rate,
+		Compound:        tPrf.Compound,
+		ExemptFilterIDs: strings.Join(tPrf.ExemptFilterIDs, utils.InfieldSep),
+	}
+	if tPrf.ActivationInterval != nil {
+		if tPrf.ActivationInterval.ActivationTime != utils.EmptyString {
+			mdl.ActivationInterval = tPrf.ActivationInterval.ActivationTime
+		}
+		if tPrf.ActivationInterval.ExpiryTime != utils.EmptyString {
+			mdl.ActivationInterval += utils.InfieldSep + tPrf.ActivationInterval.ExpiryTime
+		}
+	}
+	return TaxProfileMdls{mdl}
+}
+
+func APItoTaxProfile(tpTx *utils.TPTaxProfile, timezone string) (tp *TaxProfile, err error) {
+	tp = &TaxProfile{
+		Tenant:          tpTx.Tenant,
+		ID:              tpTx.ID,
+		FilterIDs:       make([]string, len(tpTx.FilterIDs)),
+		Jurisdiction:    tpTx.Jurisdiction,
+		TaxType:         tpTx.TaxType,
+		Rate:            utils.NewDecimalFromFloat64(tpTx.Rate),
+		Compound:        tpTx.Compound,
+		ExemptFilterIDs: make([]string, len(tpTx.ExemptFilterIDs)),
+	}
+	for i, fltrID := range tpTx.FilterIDs {
+		tp.FilterIDs[i] = fltrID
+	}
+	for i, fltrID := range tpTx.ExemptFilterIDs {
+		tp.ExemptFilterIDs[i] = fltrID
+	}
+	if tpTx.Weights != utils.EmptyString {
+		if tp.Weights, err = utils.NewDynamicWeightsFromString(tpTx.Weights, ";", "&"); err != nil {
+			return nil, err
+		}
+	}
+	if tpTx.ActivationInterval != nil {
+		if tp.ActivationInterval, err = tpTx.ActivationInterval.AsActivationInterval(timezone); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func TaxProfileToAPI(tp *TaxProfile) (tpTx *utils.TPTaxProfile) {
+	tpTx = &utils.TPTaxProfile{
+		Tenant:          tp.Tenant,
+		ID:              tp.ID,
+		FilterIDs:       make([]string, len(tp.FilterIDs)),
+		Weights:         tp.Weights.String(";", "&"),
+		Jurisdiction:    tp.Jurisdiction,
+		TaxType:         tp.TaxType,
+		Compound:        tp.Compound,
+		ExemptFilterIDs: make([]string, len(tp.ExemptFilterIDs)),
+	}
+	for i, fltrID := range tp.FilterIDs {
+		tpTx.FilterIDs[i] = fltrID
+	}
+	for i, fltrID := range tp.ExemptFilterIDs {
+		tpTx.ExemptFilterIDs[i] = fltrID
+	}
+	if tp.ActivationInterval != nil {
+		tpTx.ActivationInterval = new(utils.TPActivationInterval)
+		if !tp.ActivationInterval.ActivationTime.IsZero() {
+			tpTx.ActivationInterval.ActivationTime = tp.ActivationInterval.ActivationTime.Format(time.RFC3339)
+		}
+		if !tp.ActivationInterval.ExpiryTime.IsZero() {
+			tpTx.ActivationInterval.ExpiryTime = tp.ActivationInterval.ExpiryTime.Format(time.RFC3339)
+		}
+	}
+	if tp.Rate != nil {
+		//there should not be an invalid value of converting from Decimal into float64
+		tpTx.Rate, _ = tp.Rate.Float64()
+	}
+	return
+}
+
+type LookupTableMdls []*LookupTableMdl
+
+// CSVHeader return the header for csv fields as a slice of string
+func (ltm LookupTableMdls) CSVHeader() (result []string) {
+	return []string{"#" + utils.Tenant, utils.ID, utils.Mode, utils.Default,
+		utils.Key, utils.Value,
+	}
+}
+
+func (ltm LookupTableMdls) AsTPLookupTable() (result []*utils.TPLookupTable) {
+	ltMap := make(map[string]*utils.TPLookupTable)
+	tntIDs := make([]string, 0, len(ltm)) // keep the order of the tables as loaded
+	for _, mdl := range ltm {
+		tenID := (&utils.TenantID{Tenant: mdl.Tenant, ID: mdl.ID}).TenantID()
+		lt, found := ltMap[tenID]
+		if !found {
+			lt = &utils.TPLookupTable{
+				TPid:   mdl.Tpid,
+				Tenant: mdl.Tenant,
+				ID:     mdl.ID,
+			}
+			ltMap[tenID] = lt
+			tntIDs = append(tntIDs, tenID)
+		}
+		if mdl.Mode != utils.EmptyString {
+			lt.Mode = mdl.Mode
+		}
+		if mdl.Default != utils.EmptyString {
+			lt.Default = mdl.Default
+		}
+		if mdl.Key != utils.EmptyString {
+			lt.Entries = append(lt.Entries, &utils.TPLookupTableEntry{
+				Key:   mdl.Key,
+				Value: mdl.Value,
+			})
+		}
+	}
+	result = make([]*utils.TPLookupTable, len(tntIDs))
+	for i, tntID := range tntIDs {
+		result[i] = ltMap[tntID]
+	}
+	return
+}
+
+func APItoModelTPLookupTable(tPrf *utils.TPLookupTable) (mdls LookupTableMdls) {
+	if len(tPrf.Entries) == 0 {
+		return LookupTableMdls{{
+			Tpid:    tPrf.TPid,
+			Tenant:  tPrf.Tenant,
+			ID:      tPrf.ID,
+			Mode:    tPrf.Mode,
+			Default: tPrf.Default,
+		}}
+	}
+	for i, ent := range tPrf.Entries {
+		mdl := &LookupTableMdl{
+			Tpid:   tPrf.TPid,
+			Tenant: tPrf.Tenant,
+			ID:     tPrf.ID,
+			Key:    ent.Key,
+			Value:  ent.Value,
+		}
+		if i == 0 {
+			mdl.Mode = tPrf.Mode
+			mdl.Default = tPrf.Default
+		}
+		mdls = append(mdls, mdl)
+	}
+	return
+}
+
+func APItoLookupTable(tpLt *utils.TPLookupTable) (lt *LookupTable, err error) {
+	lt = &LookupTable{
+		Tenant:  tpLt.Tenant,
+		ID:      tpLt.ID,
+		Mode:    tpLt.Mode,
+		Default: tpLt.Default,
+		Entries: make(map[string]string, len(tpLt.Entries)),
+	}
+	for _, ent := range tpLt.Entries {
+		lt.Entries[ent.Key] = ent.Value
+	}
+	if err = lt.Compile(); err != nil {
+		return nil, err
+	}
+	return
+}
+
+func LookupTableToAPI(lt *LookupTable) (tpLt *utils.TPLookupTable) {
+	tpLt = &utils.TPLookupTable{
+		Tenant:  lt.Tenant,
+		ID:      lt.ID,
+		Mode:    lt.Mode,
+		Default: lt.Default,
+		Entries: make([]*utils.TPLookupTableEntry, 0, len(lt.Entries)),
+	}
+	keys := make([]string, 0, len(lt.Entries))
+	for key := range lt.Entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // keep the output deterministic
+	for _, key := range keys {
+		tpLt.Entries = append(tpLt.Entries, &utils.TPLookupTableEntry{
+			Key:   key,
+			Value: lt.Entries[key],
+		})
+	}
+	return
+}
@@ -2831,9 +2831,9 @@ func TestCsvHeader(t *testing.T) {
 	eOut := []string{
 		"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.Sorting, utils.SortingParameters, utils.RouteID, utils.RouteFilterIDs,
-		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteRateProfileIDs, utils.RouteResourceIDs,
+		utils.RouteAccountIDs, utils.RouteRatingplanIDs, utils.RouteResourceIDs,
 		utils.RouteStatIDs, utils.RouteWeight, utils.RouteBlocker,
-		utils.RouteParameters, utils.Weight,
+		utils.RouteParameters, utils.Weight, utils.RouteRateProfileIDs,
 	}
 	if rcv := tps.CSVHeader(); !reflect.DeepEqual(eOut, rcv) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eOut), utils.ToJSON(rcv))
@@ -3435,7 +3435,7 @@ func TestAPItoModelTPCharger5(t *testing.T) {
 	}
 }
 
-//both len(AttributeIDs) and len(FilterIDs) are 0
+// both len(AttributeIDs) and len(FilterIDs) are 0
 func TestAPItoModelTPCharger6(t *testing.T) {
 	tpCharger := &utils.TPChargerProfile{
 		TPid:   "TP1",
@@ -3464,6 +3464,212 @@ func TestAPItoModelTPCharger6(t *testing.T) {
 	}
 }
 
+func TestAPItoModelTPChargerAggregations(t *testing.T) {
+	tpCharger := &utils.TPChargerProfile{
+		TPid:         "TP1",
+		Tenant:       "cgrates.org",
+		ID:           "Charger1",
+		RunID:        "*default",
+		AttributeIDs: []string{"ATTR1"},
+		Weight:       20,
+		Aggregations: []*utils.TPChargerAggregation{
+			{
+				Path:         "Margin",
+				Type:         utils.MetaDifference,
+				RunIDs:       []string{"*default", "supplier1", "supplier2"},
+				ThresholdIDs: []string{"THD_NEGATIVE_MARGIN"},
+			},
+			{
+				Path:      "TotalCosts",
+				FilterIDs: []string{"*string:~*req.Category:call", "*prefix:~*req.Destination:49"},
+				Type:      utils.MetaSum,
+				RunIDs:    []string{"supplier1", "supplier2"},
+			},
+		},
+	}
+	expected := ChargerMdls{
+		&ChargerMdl{
+			Tpid:                    "TP1",
+			Tenant:                  "cgrates.org",
+			ID:                      "Charger1",
+			RunID:                   "*default",
+			AttributeIDs:            "ATTR1",
+			Weight:                  20,
+			AggregationPath:         "Margin",
+			AggregationType:         utils.MetaDifference,
+			AggregationRunIDs:       "*default;supplier1;supplier2",
+			AggregationThresholdIDs: "THD_NEGATIVE_MARGIN",
+		},
+		&ChargerMdl{
+			Tpid:                 "TP1",
+			Tenant:               "cgrates.org",
+			ID:                   "Charger1",
+			AggregationPath:      "TotalCosts",
+			AggregationFilterIDs: "*string:~*req.Category:call;*prefix:~*req.Destination:49",
+			AggregationType:      utils.MetaSum,
+			AggregationRunIDs:    "supplier1;supplier2",
+		},
+	}
+	rcv := APItoModelTPCharger(tpCharger)
+	if !reflect.DeepEqual(expected, rcv) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(expected), utils.ToJSON(rcv))
+	}
+	rcvTP := rcv.AsTPChargers()
+	if len(rcvTP) != 1 {
+		t.Fatalf("Expecting one profile, received: %+v", utils.ToJSON(rcvTP))
+	}
+	rcvTP[0].FilterIDs = nil
+	if !reflect.DeepEqual(tpCharger, rcvTP[0]) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(tpCharger), utils.ToJSON(rcvTP[0]))
+	}
+}
+
+func TestModelAsTPChargersAggregations(t *testing.T) {
+	models := ChargerMdls{
+		&ChargerMdl{
+			Tpid:                    "TP1",
+			Tenant:                  "cgrates.org",
+			ID:                      "Charger1",
+			RunID:                   "*default",
+			Weight:                  20,
+			AggregationPath:         "Margin",
+			AggregationType:         utils.MetaDifference,
+			AggregationRunIDs:       "*default",
+			AggregationThresholdIDs: "THD_NEGATIVE_MARGIN",
+		},
+		&ChargerMdl{
+			Tpid:              "TP1",
+			Tenant:            "cgrates.org",
+			ID:                "Charger1",
+			AggregationPath:   "Margin",
+			AggregationRunIDs: "supplier2;supplier1",
+		},
+	}
+	expected := []*ChargerAggregation{{
+		Path:         "Margin",
+		Type:         utils.MetaDifference,
+		RunIDs:       []string{"*default", "supplier2", "supplier1"},
+		ThresholdIDs: []string{"THD_NEGATIVE_MARGIN"},
+	}}
+	tpChargers := models.AsTPChargers()
+	if len(tpChargers) != 1 {
+		t.Fatalf("Expecting one profile, received: %+v", utils.ToJSON(tpChargers))
+	}
+	cpp, err := APItoChargerProfile(tpChargers[0], "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, cpp.Aggregations) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(expected), utils.ToJSON(cpp.Aggregations))
+	}
+	if rcv := ChargerProfileToAPI(cpp); !reflect.DeepEqual(tpChargers[0].Aggregations, rcv.Aggregations) {
+		t.Errorf("Expecting : %+v, received: %+v", utils.ToJSON(tpChargers[0].Aggregations), utils.ToJSON(rcv.Aggregations))
+	}
+}
+
+func TestModelHelperCsvLoadChargerWithoutAggregations(t *testing.T) {
+	if cnt, opt := getColumnCount(ChargerMdl{}); cnt != 12 || opt != 5 {
+		t.Errorf("Expecting 12 columns with 5 optional, received: %d with %d", cnt, opt)
+	}
+	// the rows of the files older than the aggregations
+	l, err := csvLoad(ChargerMdl{}, []string{"cgrates.org", "Charger1", "*string:~*req.Account:1001", "", "*default", "*none", "20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ChargerMdl{
+		Tenant:       "cgrates.org",
+		ID:           "Charger1",
+		FilterIDs:    "*string:~*req.Account:1001",
+		RunID:        "*default",
+		AttributeIDs: "*none",
+		Weight:       20,
+	}
+	if !reflect.DeepEqual(expected, l) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, l)
+	}
+	if _, err = csvLoad(ChargerMdl{}, []string{"cgrates.org", "Charger1", "", "", "*default", "*none"}); err == nil ||
+		err.Error() != "invalid ChargerMdl.Weight index 6" {
+		t.Errorf("Expecting invalid ChargerMdl.Weight index 6, received: %v", err)
+	}
+}
+
+func TestModelHelperRateProfileOptionalColumns(t *testing.T) {
+	if cnt, opt := getColumnCount(RateProfileMdl{}); cnt != 20 || opt != 2 {
+		t.Errorf("Expecting 20 columns with 2 optional, received: %d with %d", cnt, opt)
+	}
+	// the rows of the files older than the currency and volume period columns
+	l, err := csvLoad(RateProfileMdl{}, []string{"cgrates.org", "RP1", "*string:~*req.Subject:1001", "", ";0",
+		"0.1", "0.6", "*free", "RT_WEEK", "", "* * * * 1-5", ";0", "false", "0s", "0", "0.12", "1m", "1m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := RateProfileMdl{
+		Tenant:              "cgrates.org",
+		ID:                  "RP1",
+		FilterIDs:           "*string:~*req.Subject:1001",
+		Weights:             ";0",
+		MinCost:             0.1,
+		MaxCost:             0.6,
+		MaxCostStrategy:     "*free",
+		RateID:              "RT_WEEK",
+		RateActivationTimes: "* * * * 1-5",
+		RateWeights:         ";0",
+		RateIntervalStart:   "0s",
+		RateRecurrentFee:    0.12,
+		RateUnit:            "1m",
+		RateIncrement:       "1m",
+	}
+	if !reflect.DeepEqual(expected, l) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, l)
+	}
+}
+
+func TestModelHelperRouteRateProfileIDs(t *testing.T) {
+	// the rows of the files older than the rate profile ids column
+	l, err := csvLoad(RouteMdl{}, []string{"cgrates.org", "ROUTE1", "", "", "*score", "*cost:1",
+		"route1", "", "", "RP_1", "", "", "10", "false", "", "20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mdl := l.(RouteMdl)
+	if mdl.RouteRatingplanIDs != "RP_1" || mdl.RouteRateProfileIDs != utils.EmptyString {
+		t.Errorf("Unexpected model: %+v", mdl)
+	}
+	if l, err = csvLoad(RouteMdl{}, []string{"cgrates.org", "ROUTE1", "", "", "*score", "*cost:1",
+		"route1", "", "", "RP_1", "", "", "10", "false", "", "20", "RT_1;RT_2"}); err != nil {
+		t.Fatal(err)
+	}
+	mdl = l.(RouteMdl)
+	rPrf := RouteMdls{&mdl}.AsTPRouteProfile()
+	if len(rPrf) != 1 || len(rPrf[0].Routes) != 1 {
+		t.Fatalf("Unexpected profile: %s", utils.ToJSON(rPrf))
+	}
+	if exp := []string{"RT_1", "RT_2"}; !reflect.DeepEqual(exp, rPrf[0].Routes[0].RateProfileIDs) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rPrf[0].Routes[0].RateProfileIDs)
+	}
+	if mdls := APItoModelTPRoutes(rPrf[0]); len(mdls) != 1 ||
+		mdls[0].RouteRateProfileIDs != "RT_1;RT_2" {
+		t.Errorf("Unexpected models: %s", utils.ToJSON(mdls))
+	}
+}
+
+func TestAPItoChargerProfileInvalidAggregation(t *testing.T) {
+	tpCPP := &utils.TPChargerProfile{
+		Tenant: "cgrates.org",
+		ID:     "Charger1",
+		RunID:  utils.MetaDefault,
+		Aggregations: []*utils.TPChargerAggregation{{
+			Path:   "Margin",
+			Type:   "*diff",
+			RunIDs: []string{utils.MetaDefault, "supplier"},
+		}},
+	}
+	expErr := "invalid aggregation for path <Margin>: unsupported aggregation type: <*diff>"
+	if _, err := APItoChargerProfile(tpCPP, "UTC"); err == nil || err.Error() != expErr {
+		t.Errorf("Expecting %s, received: %v", expErr, err)
+	}
+}
+
 func TestModelAsTPChargers(t *testing.T) {
 	models := ChargerMdls{
 		&ChargerMdl{
@@ -5226,7 +5432,8 @@ func TestRateProfileMdlsCSVHeader(t *testing.T) {
 		utils.ActivationIntervalString, utils.Weight, utils.ConnectFee, utils.MinCost,
 		utils.MaxCost, utils.MaxCostStrategy, utils.RateID,
 		utils.RateFilterIDs, utils.RateActivationStart, utils.RateWeight, utils.RateBlocker,
-		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement}
+		utils.RateIntervalStart, utils.RateFixedFee, utils.RateRecurrentFee, utils.RateUnit, utils.RateIncrement,
+		utils.Currency, utils.VolumePeriod}
 	if !reflect.DeepEqual(result, expected) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", expected, result)
 	}
@@ -5987,7 +6194,8 @@ func TestChargerMdlsCSVHeader(t *testing.T) {
 		},
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
-		utils.RunID, utils.AttributeIDs, utils.Weight}
+		utils.RunID, utils.AttributeIDs, utils.Weight, utils.AggregationPath, utils.AggregationFilterIDs,
+		utils.AggregationType, utils.AggregationRunIDs, utils.AggregationThresholdIDs}
 
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
@@ -7481,3 +7689,428 @@ func TestModelHelpersAccountProfileToAPI(t *testing.T) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
 	}
 }
+
+func TestExchangeRateProfileMdlsAsTPExchangeRateProfile(t *testing.T) {
+	mdls := ExchangeRateProfileMdls{
+		{
+			Tpid:         "TP1",
+			Tenant:       "cgrates.org",
+			ID:           "ERP1",
+			FromCurrency: "EUR",
+			ToCurrency:   "USD",
+			Rate:         1.2,
+		},
+		{
+			Tpid:           "TP1",
+			Tenant:         "cgrates.org",
+			ID:             "ERP1",
+			FromCurrency:   "EUR",
+			ToCurrency:     "GBP",
+			ActivationTime: "2021-01-01T00:00:00Z",
+			Rate:           0.9,
+		},
+	}
+	exp := []*utils.TPExchangeRateProfile{
+		{
+			TPid:   "TP1",
+			Tenant: "cgrates.org",
+			ID:     "ERP1",
+			Rates: []*utils.TPExchangeRate{
+				{
+					FromCurrency: "EUR",
+					ToCurrency:   "USD",
+					Rate:         1.2,
+				},
+				{
+					FromCurrency:   "EUR",
+					ToCurrency:     "GBP",
+					ActivationTime: "2021-01-01T00:00:00Z",
+					Rate:           0.9,
+				},
+			},
+		},
+	}
+	if rcv := mdls.AsTPExchangeRateProfile(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := APItoModelTPExchangeRateProfile(exp[0]); !reflect.DeepEqual(mdls, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(mdls), utils.ToJSON(rcv))
+	}
+	expHdr := []string{"#" + utils.Tenant, utils.ID, utils.FromCurrency,
+		utils.ToCurrency, utils.ActivationTime, utils.ExchangeRateString}
+	if rcv := mdls.CSVHeader(); !reflect.DeepEqual(expHdr, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", expHdr, rcv)
+	}
+}
+
+func TestAPItoExchangeRateProfile(t *testing.T) {
+	tpErp := &utils.TPExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP1",
+		Rates: []*utils.TPExchangeRate{
+			{
+				FromCurrency:   "EUR",
+				ToCurrency:     "USD",
+				ActivationTime: "2021-01-01T00:00:00Z",
+				Rate:           1.2,
+			},
+			{
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         1.1,
+			},
+		},
+	}
+	erp, err := APItoExchangeRateProfile(tpErp, utils.EmptyString)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !erp.Rates[0].ActivationTime.IsZero() ||
+		!erp.Rates[1].ActivationTime.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("rates not sorted: %s", utils.ToJSON(erp.Rates))
+	}
+	expTP := &utils.TPExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP1",
+		Rates:  []*utils.TPExchangeRate{tpErp.Rates[1], tpErp.Rates[0]},
+	}
+	if rcv := ExchangeRateProfileToAPI(erp); !reflect.DeepEqual(expTP, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(expTP), utils.ToJSON(rcv))
+	}
+
+	tpErp.Rates[0].ToCurrency = utils.EmptyString
+	expErr := "empty ToCurrency for exchange rate from <EUR>"
+	if _, err := APItoExchangeRateProfile(tpErp, utils.EmptyString); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestRateDeckMdlsAsTPRateDeck(t *testing.T) {
+	mdls := RateDeckMdls{
+		{
+			Tpid:           "TP1",
+			Tenant:         "cgrates.org",
+			ID:             "CARRIER1",
+			IncreaseNotice: "168h",
+			DecreaseNotice: "24h",
+			Prefix:         "49",
+			Description:    "Germany",
+			Rate:           0.05,
+			EffectiveDate:  "2021-01-01T00:00:00Z",
+		},
+		{
+			Tpid:          "TP1",
+			Tenant:        "cgrates.org",
+			ID:            "CARRIER1",
+			Prefix:        "4930",
+			Description:   "Germany Berlin",
+			Rate:          0.02,
+			EffectiveDate: "2021-01-01T00:00:00Z",
+			ExpiryDate:    "2022-01-01T00:00:00Z",
+		},
+	}
+	exp := []*utils.TPRateDeck{
+		{
+			TPid:           "TP1",
+			Tenant:         "cgrates.org",
+			ID:             "CARRIER1",
+			IncreaseNotice: "168h",
+			DecreaseNotice: "24h",
+			Rates: []*utils.TPRateDeckRate{
+				{
+					Prefix:        "49",
+					Description:   "Germany",
+					Rate:          0.05,
+					EffectiveDate: "2021-01-01T00:00:00Z",
+				},
+				{
+					Prefix:        "4930",
+					Description:   "Germany Berlin",
+					Rate:          0.02,
+					EffectiveDate: "2021-01-01T00:00:00Z",
+					ExpiryDate:    "2022-01-01T00:00:00Z",
+				},
+			},
+		},
+	}
+	if rcv := mdls.AsTPRateDeck(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := APItoModelTPRateDeck(exp[0]); !reflect.DeepEqual(mdls, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(mdls), utils.ToJSON(rcv))
+	}
+	expHdr := []string{"#" + utils.Tenant, utils.ID, utils.IncreaseNotice, utils.DecreaseNotice,
+		utils.Prefix, utils.Description, utils.RateString, utils.EffectiveDate, utils.ExpiryDate}
+	if rcv := mdls.CSVHeader(); !reflect.DeepEqual(expHdr, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", expHdr, rcv)
+	}
+}
+
+func TestAPItoRateDeck(t *testing.T) {
+	tpRd := &utils.TPRateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: "168h0m0s",
+		Rates: []*utils.TPRateDeckRate{
+			{
+				Prefix:        "4930",
+				Description:   "Germany Berlin",
+				Rate:          0.02,
+				EffectiveDate: "2021-01-01T00:00:00Z",
+				ExpiryDate:    "2022-01-01T00:00:00Z",
+			},
+			{
+				Prefix:        "49",
+				Description:   "Germany",
+				Rate:          0.05,
+				EffectiveDate: "2021-01-01T00:00:00Z",
+			},
+		},
+	}
+	exp := &RateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: 168 * time.Hour,
+		Rates: []*RateDeckRate{
+			{
+				Prefix:        "49",
+				Description:   "Germany",
+				Rate:          utils.NewDecimalFromFloat64(0.05),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			{
+				Prefix:        "4930",
+				Description:   "Germany Berlin",
+				Rate:          utils.NewDecimalFromFloat64(0.02),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpiryDate:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	rd, err := APItoRateDeck(tpRd, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, rd) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rd))
+	}
+	// the API version keeps the order of the deck
+	tpRd.Rates[0], tpRd.Rates[1] = tpRd.Rates[1], tpRd.Rates[0]
+	if rcv := RateDeckToAPI(rd); !reflect.DeepEqual(tpRd, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(tpRd), utils.ToJSON(rcv))
+	}
+	tpRd.DecreaseNotice = "invalid"
+	if _, err := APItoRateDeck(tpRd, "UTC"); err == nil {
+		t.Error("expected error for invalid notice")
+	}
+}
+
+func TestTaxProfileMdlsAsTPTaxProfile(t *testing.T) {
+	mdls := TaxProfileMdls{
+		{
+			Tpid:               "TP1",
+			Tenant:             "cgrates.org",
+			ID:                 "TAX_DE_VAT",
+			FilterIDs:          "*string:~*req.Country:DE",
+			ActivationInterval: "2021-01-01T00:00:00Z;2022-01-01T00:00:00Z",
+			Weights:            ";20",
+			Jurisdiction:       "DE",
+			TaxType:            "VAT",
+			Rate:               0.19,
+			ExemptFilterIDs:    "*string:~*req.TaxExempt:true",
+		},
+		{
+			Tpid:      "TP1",
+			Tenant:    "cgrates.org",
+			ID:        "TAX_DE_VAT",
+			FilterIDs: "*prefix:~*req.Destination:49;*string:~*req.Country:DE",
+			Compound:  true,
+		},
+	}
+	exp := []*utils.TPTaxProfile{
+		{
+			TPid:      "TP1",
+			Tenant:    "cgrates.org",
+			ID:        "TAX_DE_VAT",
+			FilterIDs: []string{"*string:~*req.Country:DE", "*prefix:~*req.Destination:49"},
+			ActivationInterval: &utils.TPActivationInterval{
+				ActivationTime: "2021-01-01T00:00:00Z",
+				ExpiryTime:     "2022-01-01T00:00:00Z",
+			},
+			Weights:         ";20",
+			Jurisdiction:    "DE",
+			TaxType:         "VAT",
+			Rate:            0.19,
+			Compound:        true,
+			ExemptFilterIDs: []string{"*string:~*req.TaxExempt:true"},
+		},
+	}
+	if rcv := mdls.AsTPTaxProfile(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	expMdls := TaxProfileMdls{
+		{
+			Tpid:               "TP1",
+			Tenant:             "cgrates.org",
+			ID:                 "TAX_DE_VAT",
+			FilterIDs:          "*string:~*req.Country:DE;*prefix:~*req.Destination:49",
+			ActivationInterval: "2021-01-01T00:00:00Z;2022-01-01T00:00:00Z",
+			Weights:            ";20",
+			Jurisdiction:       "DE",
+			TaxType:            "VAT",
+			Rate:               0.19,
+			Compound:           true,
+			ExemptFilterIDs:    "*string:~*req.TaxExempt:true",
+		},
+	}
+	if rcv := APItoModelTPTaxProfile(exp[0]); !reflect.DeepEqual(expMdls, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(expMdls), utils.ToJSON(rcv))
+	}
+	expHdr := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
+		utils.Weights, utils.Jurisdiction, utils.TaxType, utils.RateString, utils.Compound,
+		utils.ExemptFilterIDs}
+	if rcv := mdls.CSVHeader(); !reflect.DeepEqual(expHdr, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", expHdr, rcv)
+	}
+}
+
+func TestAPItoTaxProfile(t *testing.T) {
+	tpTx := &utils.TPTaxProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TAX_DE_VAT",
+		FilterIDs: []string{"*string:~*req.Country:DE"},
+		ActivationInterval: &utils.TPActivationInterval{
+			ActivationTime: "2021-01-01T00:00:00Z",
+		},
+		Weights:         ";20",
+		Jurisdiction:    "DE",
+		TaxType:         "VAT",
+		Rate:            0.19,
+		ExemptFilterIDs: []string{"*string:~*req.TaxExempt:true"},
+	}
+	exp := &TaxProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TAX_DE_VAT",
+		FilterIDs: []string{"*string:~*req.Country:DE"},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Weights: utils.DynamicWeights{
+			{
+				Weight: 20,
+			},
+		},
+		Jurisdiction:    "DE",
+		TaxType:         "VAT",
+		Rate:            utils.NewDecimalFromFloat64(0.19),
+		ExemptFilterIDs: []string{"*string:~*req.TaxExempt:true"},
+	}
+	if rcv, err := APItoTaxProfile(tpTx, "UTC"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(utils.ToJSON(exp), utils.ToJSON(rcv)) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := TaxProfileToAPI(exp); !reflect.DeepEqual(utils.ToJSON(&utils.TPTaxProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TAX_DE_VAT",
+		FilterIDs: []string{"*string:~*req.Country:DE"},
+		ActivationInterval: &utils.TPActivationInterval{
+			ActivationTime: "2021-01-01T00:00:00Z",
+		},
+		Weights:         ";20",
+		Jurisdiction:    "DE",
+		TaxType:         "VAT",
+		Rate:            0.19,
+		ExemptFilterIDs: []string{"*string:~*req.TaxExempt:true"},
+	}), utils.ToJSON(rcv)) {
+		t.Errorf("received: %+v", utils.ToJSON(rcv))
+	}
+	tpTx.Weights = "invalid"
+	if _, err := APItoTaxProfile(tpTx, "UTC"); err == nil {
+		t.Error("expecting error for invalid weights")
+	}
+}
+
+func TestLookupTableMdlsAsTPLookupTable(t *testing.T) {
+	mdls := LookupTableMdls{
+		{
+			Tpid:    "TP1",
+			Tenant:  "cgrates.org",
+			ID:      "PORTED_NUMBERS",
+			Mode:    utils.MetaPrefix,
+			Default: "HOME_NET",
+			Key:     "4915",
+			Value:   "NET_A",
+		},
+		{
+			Tpid:   "TP1",
+			Tenant: "cgrates.org",
+			ID:     "PORTED_NUMBERS",
+			Key:    "491511",
+			Value:  "NET_B",
+		},
+	}
+	exp := []*utils.TPLookupTable{
+		{
+			TPid:    "TP1",
+			Tenant:  "cgrates.org",
+			ID:      "PORTED_NUMBERS",
+			Mode:    utils.MetaPrefix,
+			Default: "HOME_NET",
+			Entries: []*utils.TPLookupTableEntry{
+				{Key: "4915", Value: "NET_A"},
+				{Key: "491511", Value: "NET_B"},
+			},
+		},
+	}
+	if rcv := mdls.AsTPLookupTable(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv := APItoModelTPLookupTable(exp[0]); !reflect.DeepEqual(mdls, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(mdls), utils.ToJSON(rcv))
+	}
+	expHdr := []string{"#" + utils.Tenant, utils.ID, utils.Mode, utils.Default,
+		utils.Key, utils.Value}
+	if rcv := mdls.CSVHeader(); !reflect.DeepEqual(expHdr, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", expHdr, rcv)
+	}
+}
+
+func TestAPItoLookupTable(t *testing.T) {
+	tpLt := &utils.TPLookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "PORTED_NUMBERS",
+		Mode:    utils.MetaPrefix,
+		Default: "HOME_NET",
+		Entries: []*utils.TPLookupTableEntry{
+			{Key: "491511", Value: "NET_B"},
+			{Key: "4915", Value: "NET_A"},
+		},
+	}
+	exp := &LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "PORTED_NUMBERS",
+		Mode:    utils.MetaPrefix,
+		Default: "HOME_NET",
+		Entries: map[string]string{
+			"4915":   "NET_A",
+			"491511": "NET_B",
+		},
+	}
+	lt, err := APItoLookupTable(tpLt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exp, lt) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(exp), utils.ToJSON(lt))
+	}
+	// the API version is sorted by key
+	tpLt.Entries[0], tpLt.Entries[1] = tpLt.Entries[1], tpLt.Entries[0]
+	if rcv := LookupTableToAPI(lt); !reflect.DeepEqual(tpLt, rcv) {
+		t.Errorf("Expecting: %+v,\nreceived: %+v", utils.ToJSON(tpLt), utils.ToJSON(rcv))
+	}
+	tpLt.Mode = "*invalid"
+	if _, err := APItoLookupTable(tpLt); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+}
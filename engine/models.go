@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -398,6 +399,51 @@ func (t SessionCostsSQL) TableName() string {
 	return utils.SessionCostsTBL
 }
 
+type InvoiceSQL struct {
+	ID          int64
+	Tenant      string
+	InvoiceID   string
+	Account     string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Lines       string
+	Taxes       string
+	Subtotal    float64
+	TaxTotal    float64
+	Total       float64
+	CreatedAt   time.Time
+}
+
+func (t InvoiceSQL) TableName() string {
+	return utils.InvoicesTBL
+}
+
+// AsInvoice converts the row into an Invoice
+func (t *InvoiceSQL) AsInvoice() (inv *Invoice, err error) {
+	inv = &Invoice{
+		Tenant:      t.Tenant,
+		ID:          t.InvoiceID,
+		Account:     t.Account,
+		PeriodStart: t.PeriodStart,
+		PeriodEnd:   t.PeriodEnd,
+		Subtotal:    t.Subtotal,
+		TaxTotal:    t.TaxTotal,
+		Total:       t.Total,
+		CreatedAt:   t.CreatedAt,
+	}
+	if t.Lines != utils.EmptyString {
+		if err = json.Unmarshal([]byte(t.Lines), &inv.Lines); err != nil {
+			return nil, err
+		}
+	}
+	if t.Taxes != utils.EmptyString {
+		if err = json.Unmarshal([]byte(t.Taxes), &inv.Taxes); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
 type TBLVersion struct {
 	ID      uint
 	Item    string
@@ -409,25 +455,26 @@ func (t TBLVersion) TableName() string {
 }
 
 type RouteMdl struct {
-	PK                 uint `gorm:"primary_key"`
-	Tpid               string
-	Tenant             string  `index:"0" re:""`
-	ID                 string  `index:"1" re:""`
-	FilterIDs          string  `index:"2" re:""`
-	ActivationInterval string  `index:"3" re:""`
-	Sorting            string  `index:"4" re:""`
-	SortingParameters  string  `index:"5" re:""`
-	RouteID            string  `index:"6" re:""`
-	RouteFilterIDs     string  `index:"7" re:""`
-	RouteAccountIDs    string  `index:"8" re:""`
-	RouteRatingplanIDs string  `index:"9" re:""`
-	RouteResourceIDs   string  `index:"10" re:""`
-	RouteStatIDs       string  `index:"11" re:""`
-	RouteWeight        float64 `index:"12" re:"\d+\.?\d*"`
-	RouteBlocker       bool    `index:"13" re:""`
-	RouteParameters    string  `index:"14" re:""`
-	Weight             float64 `index:"15" re:"\d+\.?\d*"`
-	CreatedAt          time.Time
+	PK                  uint `gorm:"primary_key"`
+	Tpid                string
+	Tenant              string  `index:"0" re:""`
+	ID                  string  `index:"1" re:""`
+	FilterIDs           string  `index:"2" re:""`
+	ActivationInterval  string  `index:"3" re:""`
+	Sorting             string  `index:"4" re:""`
+	SortingParameters   string  `index:"5" re:""`
+	RouteID             string  `index:"6" re:""`
+	RouteFilterIDs      string  `index:"7" re:""`
+	RouteAccountIDs     string  `index:"8" re:""`
+	RouteRatingplanIDs  string  `index:"9" re:""`
+	RouteResourceIDs    string  `index:"10" re:""`
+	RouteStatIDs        string  `index:"11" re:""`
+	RouteWeight         float64 `index:"12" re:"\d+\.?\d*"`
+	RouteBlocker        bool    `index:"13" re:""`
+	RouteParameters     string  `index:"14" re:""`
+	Weight              float64 `index:"15" re:"\d+\.?\d*"`
+	RouteRateProfileIDs string  `index:"16" re:"" optional:"true"`
+	CreatedAt           time.Time
 }
 
 func (RouteMdl) TableName() string {
@@ -456,16 +503,21 @@ func (AttributeMdl) TableName() string {
 }
 
 type ChargerMdl struct {
-	PK                 uint `gorm:"primary_key"`
-	Tpid               string
-	Tenant             string  `index:"0" re:""`
-	ID                 string  `index:"1" re:""`
-	FilterIDs          string  `index:"2" re:""`
-	ActivationInterval string  `index:"3" re:""`
-	RunID              string  `index:"4" re:""`
-	AttributeIDs       string  `index:"5" re:""`
-	Weight             float64 `index:"6" re:"\d+\.?\d*"`
-	CreatedAt          time.Time
+	PK                      uint `gorm:"primary_key"`
+	Tpid                    string
+	Tenant                  string  `index:"0" re:""`
+	ID                      string  `index:"1" re:""`
+	FilterIDs               string  `index:"2" re:""`
+	ActivationInterval      string  `index:"3" re:""`
+	RunID                   string  `index:"4" re:""`
+	AttributeIDs            string  `index:"5" re:""`
+	Weight                  float64 `index:"6" re:"\d+\.?\d*"`
+	AggregationPath         string  `index:"7" re:"" optional:"true"`
+	AggregationFilterIDs    string  `index:"8" re:"" optional:"true"`
+	AggregationType         string  `index:"9" re:"" optional:"true"`
+	AggregationRunIDs       string  `index:"10" re:"" optional:"true"`
+	AggregationThresholdIDs string  `index:"11" re:"" optional:"true"`
+	CreatedAt               time.Time
 }
 
 func (ChargerMdl) TableName() string {
@@ -531,6 +583,8 @@ type RateProfileMdl struct {
 	RateRecurrentFee    float64 `index:"15" re:"\d+\.?\d*"`
 	RateUnit            string  `index:"16" re:""`
 	RateIncrement       string  `index:"17" re:""`
+	Currency            string  `index:"18" re:"" optional:"true"`
+	VolumePeriod        string  `index:"19" re:"" optional:"true"`
 
 	CreatedAt time.Time
 }
@@ -592,3 +646,58 @@ type AccountProfileMdl struct {
 func (AccountProfileMdl) TableName() string {
 	return utils.TBLTPAccountProfiles
 }
+
+type ExchangeRateProfileMdl struct {
+	PK             uint `gorm:"primary_key"`
+	Tpid           string
+	Tenant         string  `index:"0" re:""`
+	ID             string  `index:"1" re:""`
+	FromCurrency   string  `index:"2" re:""`
+	ToCurrency     string  `index:"3" re:""`
+	ActivationTime string  `index:"4" re:""`
+	Rate           float64 `index:"5" re:"\d+\.?\d*"`
+	CreatedAt      time.Time
+}
+
+type RateDeckMdl struct {
+	PK             uint `gorm:"primary_key"`
+	Tpid           string
+	Tenant         string  `index:"0" re:""`
+	ID             string  `index:"1" re:""`
+	IncreaseNotice string  `index:"2" re:""`
+	DecreaseNotice string  `index:"3" re:""`
+	Prefix         string  `index:"4" re:""`
+	Description    string  `index:"5" re:""`
+	Rate           float64 `index:"6" re:""`
+	EffectiveDate  string  `index:"7" re:""`
+	ExpiryDate     string  `index:"8" re:""`
+	CreatedAt      time.Time
+}
+
+type TaxProfileMdl struct {
+	PK                 uint `gorm:"primary_key"`
+	Tpid               string
+	Tenant             string  `index:"0" re:""`
+	ID                 string  `index:"1" re:""`
+	FilterIDs          string  `index:"2" re:""`
+	ActivationInterval string  `index:"3" re:""`
+	Weights            string  `index:"4" re:""`
+	Jurisdiction       string  `index:"5" re:""`
+	TaxType            string  `index:"6" re:""`
+	Rate               float64 `index:"7" re:""`
+	Compound           bool    `index:"8" re:""`
+	ExemptFilterIDs    string  `index:"9" re:""`
+	CreatedAt          time.Time
+}
+
+type LookupTableMdl struct {
+	PK        uint `gorm:"primary_key"`
+	Tpid      string
+	Tenant    string `index:"0" re:""`
+	ID        string `index:"1" re:""`
+	Mode      string `index:"2" re:""`
+	Default   string `index:"3" re:""`
+	Key       string `index:"4" re:""`
+	Value     string `index:"5" re:""`
+	CreatedAt time.Time
+}
@@ -0,0 +1,187 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ProfileHits keeps the number of events matched by one profile
+type ProfileHits struct {
+	Tenant  string
+	ID      string    // ProfileType:ProfileID
+	Hits    int64     // number of events matched
+	LastHit time.Time // time of the last match
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (ph *ProfileHits) TenantID() string {
+	return utils.ConcatenatedKey(ph.Tenant, ph.ID)
+}
+
+// ProfileHitsID builds the ID of the hit counter for a profile,
+// prfType is the cache partition of the profile, ie: *attribute_profiles
+func ProfileHitsID(prfType, prfID string) string {
+	return utils.ConcatenatedKey(prfType, prfID)
+}
+
+// ProfileHitsTypes are the profile types counted by HitCounters
+var ProfileHitsTypes = utils.NewStringSet([]string{
+	utils.CacheAttributeProfiles, utils.CacheChargerProfiles, utils.CacheRouteProfiles,
+	utils.CacheThresholdProfiles, utils.CacheStatQueueProfiles, utils.CacheResourceProfiles,
+	utils.CacheRateProfiles, utils.CacheDispatcherProfiles, utils.CacheFilters,
+})
+
+// NewHitCounters returns the HitCounters storing the hits in dm at storeInterval
+func NewHitCounters(dm *DataManager, storeInterval time.Duration) *HitCounters {
+	return &HitCounters{
+		dm:            dm,
+		storeInterval: storeInterval,
+		hits:          make(map[string]*ProfileHits),
+		stopBackup:    make(chan struct{}),
+		loopStoped:    make(chan struct{}),
+	}
+}
+
+// HitCounters counts the profile matches in memory and stores them periodically in DataDB
+type HitCounters struct {
+	dm            *DataManager
+	storeInterval time.Duration           // 0 disables the counting, <0 stores on each hit
+	hits          map[string]*ProfileHits // hits not yet stored, indexed on TenantID
+	hMux          sync.Mutex              // protects hits
+	stopBackup    chan struct{}
+	loopStoped    chan struct{}
+}
+
+// Hit counts one match for the profile and for the filters it matched with
+// called only once the profile is selected so the filter passes stay off the lock
+func (hc *HitCounters) Hit(prfType, tnt, prfID string, fltrIDs ...string) {
+	if hc == nil || hc.storeInterval == 0 {
+		return
+	}
+	now := time.Now()
+	hc.hMux.Lock()
+	hc.mergeHits(&ProfileHits{Tenant: tnt, ID: ProfileHitsID(prfType, prfID), Hits: 1, LastHit: now})
+	for _, fltrID := range fltrIDs {
+		if !strings.HasPrefix(fltrID, utils.Meta) { // inline filters are not counted
+			hc.mergeHits(&ProfileHits{Tenant: tnt, ID: ProfileHitsID(utils.CacheFilters, fltrID), Hits: 1, LastHit: now})
+		}
+	}
+	hc.hMux.Unlock()
+	if hc.storeInterval < 0 {
+		hc.StoreHits()
+	}
+}
+
+// StoreHits adds the pending hits to the ones in DataDB
+func (hc *HitCounters) StoreHits() {
+	if hc == nil {
+		return
+	}
+	hc.hMux.Lock()
+	pending := hc.hits
+	hc.hits = make(map[string]*ProfileHits)
+	hc.hMux.Unlock()
+	var failed []*ProfileHits
+	for _, ph := range pending {
+		if err := hc.storeProfileHits(ph); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed storing hits with ID: %s, error: %s",
+					utils.DataDB, ph.TenantID(), err.Error()))
+			failed = append(failed, ph) // schedule them for the next backup
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	hc.hMux.Lock()
+	for _, ph := range failed {
+		hc.mergeHits(ph)
+	}
+	hc.hMux.Unlock()
+}
+
+// mergeHits adds ph to the pending hits, needs to be called under lock
+func (hc *HitCounters) mergeHits(ph *ProfileHits) {
+	pending, has := hc.hits[ph.TenantID()]
+	if !has {
+		hc.hits[ph.TenantID()] = ph
+		return
+	}
+	pending.Hits += ph.Hits
+	if ph.LastHit.After(pending.LastHit) {
+		pending.LastHit = ph.LastHit
+	}
+}
+
+// storeProfileHits adds ph to the hits stored in DataDB
+// the read-modify-write is guarded on the hits key so concurrent stores do not lose increments
+func (hc *HitCounters) storeProfileHits(ph *ProfileHits) (err error) {
+	_, err = guardian.Guardian.Guard(func() (interface{}, error) {
+		stored, err := hc.dm.GetProfileHits(ph.Tenant, ph.ID)
+		if err != nil {
+			if err != utils.ErrNotFound {
+				return nil, err
+			}
+			stored = &ProfileHits{Tenant: ph.Tenant, ID: ph.ID}
+		}
+		stored.Hits += ph.Hits
+		if ph.LastHit.After(stored.LastHit) {
+			stored.LastHit = ph.LastHit
+		}
+		return nil, hc.dm.SetProfileHits(stored)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.ProfileHitsPrefix+ph.TenantID())
+	return
+}
+
+// runBackup will regularly store the hits in DataDB
+func (hc *HitCounters) runBackup() {
+	if hc.storeInterval <= 0 {
+		hc.loopStoped <- struct{}{}
+		return
+	}
+	for {
+		select {
+		case <-hc.stopBackup:
+			hc.loopStoped <- struct{}{}
+			return
+		case <-time.After(hc.storeInterval):
+			hc.StoreHits()
+		}
+	}
+}
+
+// StartLoop starts the gorutine with the backup loop
+func (hc *HitCounters) StartLoop() {
+	go hc.runBackup()
+}
+
+// Shutdown stops the backup loop and stores the remaining hits
+func (hc *HitCounters) Shutdown() {
+	close(hc.stopBackup)
+	<-hc.loopStoped // wait until the loop is done
+	hc.StoreHits()
+}
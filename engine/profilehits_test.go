@@ -0,0 +1,206 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestHitCountersStoreHits(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmHits := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	hc := NewHitCounters(dmHits, time.Minute)
+	hc.Hit(utils.CacheAttributeProfiles, "hits.org", "ATTR_1")
+	hc.Hit(utils.CacheAttributeProfiles, "hits.org", "ATTR_1")
+	hID := ProfileHitsID(utils.CacheAttributeProfiles, "ATTR_1")
+	if _, err := dmHits.GetProfileHits("hits.org", hID); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+	hc.StoreHits()
+	ph, err := dmHits.GetProfileHits("hits.org", hID)
+	if err != nil {
+		t.Fatal(err)
+	} else if ph.Hits != 2 || ph.LastHit.IsZero() {
+		t.Errorf("Unexpected hits: %s", utils.ToJSON(ph))
+	}
+	lastHit := ph.LastHit
+	hc.Hit(utils.CacheAttributeProfiles, "hits.org", "ATTR_1")
+	hc.StoreHits()
+	if ph, err = dmHits.GetProfileHits("hits.org", hID); err != nil {
+		t.Fatal(err)
+	} else if ph.Hits != 3 || ph.LastHit.Before(lastHit) {
+		t.Errorf("Unexpected hits: %s", utils.ToJSON(ph))
+	}
+}
+
+func TestHitCountersStoreInterval(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmHits := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	var hcNil *HitCounters
+	hcNil.Hit(utils.CacheChargerProfiles, "hits.org", "CHRG_1")
+	hcNil.StoreHits()
+
+	hc := NewHitCounters(dmHits, 0) // counting disabled
+	hc.Hit(utils.CacheChargerProfiles, "hits.org", "CHRG_1")
+	hc.StoreHits()
+	if _, err := dmHits.GetProfileHits("hits.org",
+		ProfileHitsID(utils.CacheChargerProfiles, "CHRG_1")); err != utils.ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", utils.ErrNotFound, err)
+	}
+
+	hc = NewHitCounters(dmHits, -1) // stored on each hit
+	hc.Hit(utils.CacheChargerProfiles, "hits.org", "CHRG_1")
+	if ph, err := dmHits.GetProfileHits("hits.org",
+		ProfileHitsID(utils.CacheChargerProfiles, "CHRG_1")); err != nil {
+		t.Error(err)
+	} else if ph.Hits != 1 {
+		t.Errorf("Unexpected hits: %s", utils.ToJSON(ph))
+	}
+
+	hc = NewHitCounters(dmHits, time.Hour)
+	hc.StartLoop()
+	hc.Hit(utils.CacheRouteProfiles, "hits.org", "ROUTE_1")
+	hc.Shutdown() // stores the pending hits
+	if ph, err := dmHits.GetProfileHits("hits.org",
+		ProfileHitsID(utils.CacheRouteProfiles, "ROUTE_1")); err != nil {
+		t.Error(err)
+	} else if ph.Hits != 1 {
+		t.Errorf("Unexpected hits: %s", utils.ToJSON(ph))
+	}
+}
+
+func TestHitCountersAttributeMatch(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmHits := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	hc := NewHitCounters(dmHits, time.Minute)
+	SetHitCounters(hc)
+	defer SetHitCounters(nil)
+	if err := dmHits.SetFilter(&Filter{
+		Tenant: "hits.org",
+		ID:     "FLTR_HITS_1001",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1001"},
+		}},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, attrPrf := range []*AttributeProfile{
+		{
+			Tenant:    "hits.org",
+			ID:        "ATTR_HITS_MATCHED",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"FLTR_HITS_1001", "*string:~*req.Destination:1002"},
+			Weight:    20,
+		},
+		{
+			Tenant:    "hits.org",
+			ID:        "ATTR_HITS_LOWER",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"FLTR_HITS_1001"},
+			Weight:    10,
+		},
+	} {
+		if err := dmHits.SetAttributeProfile(attrPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	attrS := NewAttributeService(dmHits, NewFilterS(cfg, nil, dmHits), cfg)
+	evNm := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+		},
+	}
+	if aPrf, err := attrS.attributeProfileForEvent("hits.org", nil,
+		[]string{"ATTR_HITS_MATCHED", "ATTR_HITS_LOWER"}, nil, evNm, utils.EmptyString, nil); err != nil {
+		t.Fatal(err)
+	} else if aPrf.ID != "ATTR_HITS_MATCHED" {
+		t.Errorf("Unexpected profile: %s", aPrf.ID)
+	}
+	hc.StoreHits()
+	for hID, expHits := range map[string]int64{
+		ProfileHitsID(utils.CacheAttributeProfiles, "ATTR_HITS_MATCHED"): 1,
+		ProfileHitsID(utils.CacheFilters, "FLTR_HITS_1001"):              1, // only the filters of the selected profile are counted
+	} {
+		if ph, err := dmHits.GetProfileHits("hits.org", hID); err != nil {
+			t.Errorf("<%s> %v", hID, err)
+		} else if ph.Hits != expHits {
+			t.Errorf("<%s> expected %d hits, received %d", hID, expHits, ph.Hits)
+		}
+	}
+	for _, hID := range []string{
+		ProfileHitsID(utils.CacheAttributeProfiles, "ATTR_HITS_LOWER"),
+		ProfileHitsID(utils.CacheFilters, "*string:~*req.Destination:1002"),
+	} {
+		if _, err := dmHits.GetProfileHits("hits.org", hID); err != utils.ErrNotFound {
+			t.Errorf("<%s> expected %+v, received %+v", hID, utils.ErrNotFound, err)
+		}
+	}
+}
+
+// slowHitsDB delays the reads of the hits so the concurrent stores overlap
+type slowHitsDB struct {
+	*InternalDB
+}
+
+func (sDB *slowHitsDB) GetProfileHitsDrv(tenant, id string) (ph *ProfileHits, err error) {
+	ph, err = sDB.InternalDB.GetProfileHitsDrv(tenant, id)
+	time.Sleep(time.Millisecond)
+	return
+}
+
+func TestHitCountersConcurrentStore(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dmHits := NewDataManager(&slowHitsDB{NewInternalDB(nil, nil, true)}, cfg.CacheCfg(), nil)
+	hc := NewHitCounters(dmHits, -1) // store on each hit
+	hID := ProfileHitsID(utils.CacheChargerProfiles, "CHRG_CONCURRENT")
+	if err := dmHits.RemoveProfileHits("hits.org", hID); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				hc.Hit(utils.CacheChargerProfiles, "hits.org", "CHRG_CONCURRENT")
+			}
+		}()
+		go func() { // as done by the store loop and APIs
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				hc.StoreHits()
+			}
+		}()
+	}
+	wg.Wait()
+	hc.StoreHits()
+	if ph, err := dmHits.GetProfileHits("hits.org", hID); err != nil {
+		t.Fatal(err)
+	} else if ph.Hits != 200 {
+		t.Errorf("Expected 200 hits, received %d", ph.Hits)
+	}
+}
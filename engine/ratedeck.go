@@ -0,0 +1,185 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// RateDeck holds the rates of a carrier keyed by destination prefix
+type RateDeck struct {
+	Tenant         string
+	ID             string
+	IncreaseNotice time.Duration // minimum notice before a rate increase becomes effective
+	DecreaseNotice time.Duration // minimum notice before a rate decrease becomes effective
+	Rates          []*RateDeckRate
+
+	trie *rateDeckTrie // compiled version of Rates used for longest prefix match
+}
+
+// RateDeckRate is one row of the RateDeck
+type RateDeckRate struct {
+	Prefix        string
+	Description   string
+	Rate          *utils.Decimal
+	EffectiveDate time.Time
+	ExpiryDate    time.Time // zero for rates which do not expire
+}
+
+// rateDeckTrie indexes the rates on the digits of their prefix
+type rateDeckTrie struct {
+	children map[byte]*rateDeckTrie
+	rates    []*RateDeckRate // sorted on EffectiveDate
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rd *RateDeck) TenantID() string {
+	return utils.ConcatenatedKey(rd.Tenant, rd.ID)
+}
+
+// ActiveAt returns true if the rate applies at the given time
+func (rdr *RateDeckRate) ActiveAt(tm time.Time) bool {
+	return !rdr.EffectiveDate.After(tm) &&
+		(rdr.ExpiryDate.IsZero() || tm.Before(rdr.ExpiryDate))
+}
+
+// sameRate returns true if the other row holds the same rate and expiry
+func (rdr *RateDeckRate) sameRate(oRdr *RateDeckRate) bool {
+	return oRdr != nil &&
+		oRdr.Rate.Compare(rdr.Rate) == 0 &&
+		oRdr.ExpiryDate.Equal(rdr.ExpiryDate)
+}
+
+// Sort orders the rates on Prefix and EffectiveDate
+func (rd *RateDeck) Sort() {
+	sort.SliceStable(rd.Rates, func(i, j int) bool {
+		if rd.Rates[i].Prefix != rd.Rates[j].Prefix {
+			return rd.Rates[i].Prefix < rd.Rates[j].Prefix
+		}
+		return rd.Rates[i].EffectiveDate.Before(rd.Rates[j].EffectiveDate)
+	})
+}
+
+// Compile validates the rates and builds the prefix trie used on lookups
+func (rd *RateDeck) Compile() (err error) {
+	rd.Sort()
+	trie := new(rateDeckTrie)
+	for _, rdr := range rd.Rates {
+		if rdr.Prefix == utils.EmptyString {
+			return fmt.Errorf("empty %s for rate deck <%s>", utils.Prefix, rd.TenantID())
+		}
+		if rdr.Rate == nil {
+			return fmt.Errorf("missing rate for prefix <%s> on rate deck <%s>", rdr.Prefix, rd.TenantID())
+		}
+		if !rdr.ExpiryDate.IsZero() && !rdr.ExpiryDate.After(rdr.EffectiveDate) {
+			return fmt.Errorf("expiry date before effective date for prefix <%s> on rate deck <%s>",
+				rdr.Prefix, rd.TenantID())
+		}
+		node := trie
+		for i := 0; i < len(rdr.Prefix); i++ {
+			if node.children == nil {
+				node.children = make(map[byte]*rateDeckTrie)
+			}
+			nxt, has := node.children[rdr.Prefix[i]]
+			if !has {
+				nxt = new(rateDeckTrie)
+				node.children[rdr.Prefix[i]] = nxt
+			}
+			node = nxt
+		}
+		node.rates = append(node.rates, rdr) // already sorted on EffectiveDate
+	}
+	rd.trie = trie
+	return
+}
+
+// RateForPrefix returns the rate of the longest prefix matching the destination which is active at tm
+func (rd *RateDeck) RateForPrefix(dst string, tm time.Time) (rdr *RateDeckRate, err error) {
+	if rd.trie == nil {
+		if err = rd.Compile(); err != nil {
+			return
+		}
+	}
+	node := rd.trie
+	for i := 0; i < len(dst); i++ {
+		if node = node.children[dst[i]]; node == nil {
+			break
+		}
+		for j := len(node.rates) - 1; j >= 0; j-- { // the latest effective rate wins
+			if node.rates[j].ActiveAt(tm) {
+				rdr = node.rates[j]
+				break
+			}
+		}
+	}
+	if rdr == nil {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// ApplyNotices defers the rates changed since the old RateDeck to the end of their notice period,
+// computed from the time of the import
+func (rd *RateDeck) ApplyNotices(oldRd *RateDeck, tm time.Time) {
+	if oldRd == nil ||
+		(rd.IncreaseNotice == 0 && rd.DecreaseNotice == 0) {
+		return
+	}
+	oldRates := make(map[string][]*RateDeckRate)
+	for _, rdr := range oldRd.Rates {
+		oldRates[rdr.Prefix] = append(oldRates[rdr.Prefix], rdr)
+	}
+	for _, rdr := range rd.Rates {
+		var crntRate *RateDeckRate // rate replaced on the requested EffectiveDate
+		var nextRate *RateDeckRate // first rate noticed after the requested EffectiveDate
+		for _, oldRdr := range oldRates[rdr.Prefix] {
+			if oldRdr.ActiveAt(rdr.EffectiveDate) {
+				if crntRate == nil ||
+					!oldRdr.EffectiveDate.Before(crntRate.EffectiveDate) { // the latest effective rate wins
+					crntRate = oldRdr
+				}
+			} else if oldRdr.EffectiveDate.After(rdr.EffectiveDate) &&
+				(nextRate == nil || oldRdr.EffectiveDate.Before(nextRate.EffectiveDate)) {
+				nextRate = oldRdr
+			}
+		}
+		switch {
+		case rdr.sameRate(crntRate): // already imported, keep the noticed date
+			rdr.EffectiveDate = crntRate.EffectiveDate
+		case rdr.sameRate(nextRate): // imported again before the end of the notice
+			rdr.EffectiveDate = nextRate.EffectiveDate
+		case crntRate != nil: // new destinations do not need notice
+			var notice time.Duration
+			switch crntRate.Rate.Compare(rdr.Rate) {
+			case -1:
+				notice = rd.IncreaseNotice
+			case 1:
+				notice = rd.DecreaseNotice
+			}
+			if effective := tm.Add(notice); rdr.EffectiveDate.Before(effective) {
+				rdr.EffectiveDate = effective
+			}
+		}
+	}
+	rd.Sort()
+}
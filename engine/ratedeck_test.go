@@ -0,0 +1,160 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRateDeckRateForPrefix(t *testing.T) {
+	jan := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	rd := &RateDeck{
+		Tenant: "cgrates.org",
+		ID:     "CARRIER1",
+		Rates: []*RateDeckRate{
+			{Prefix: "4930", Description: "Germany Berlin", Rate: utils.NewDecimal(2, 2), EffectiveDate: jan},
+			{Prefix: "49", Description: "Germany", Rate: utils.NewDecimal(5, 2), EffectiveDate: jan},
+			{Prefix: "4930", Description: "Germany Berlin", Rate: utils.NewDecimal(3, 2), EffectiveDate: feb},
+			{Prefix: "4917", Description: "Germany Mobile", Rate: utils.NewDecimal(10, 2), EffectiveDate: jan,
+				ExpiryDate: feb},
+		},
+	}
+	if err := rd.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		dst     string
+		tm      time.Time
+		expRate *utils.Decimal
+	}{
+		{"493012345", jan.Add(time.Hour), utils.NewDecimal(2, 2)},
+		{"493012345", feb.Add(time.Hour), utils.NewDecimal(3, 2)},
+		{"4989123", jan.Add(time.Hour), utils.NewDecimal(5, 2)},
+		{"4917123", jan.Add(time.Hour), utils.NewDecimal(10, 2)},
+		{"4917123", feb, utils.NewDecimal(5, 2)}, // expired, falls back to the shorter prefix
+	} {
+		if rdr, err := rd.RateForPrefix(tc.dst, tc.tm); err != nil {
+			t.Errorf("<%s> at %v: %v", tc.dst, tc.tm, err)
+		} else if rdr.Rate.Compare(tc.expRate) != 0 {
+			t.Errorf("<%s> at %v expected rate %s, received %s", tc.dst, tc.tm, tc.expRate, rdr.Rate)
+		}
+	}
+	if _, err := rd.RateForPrefix("331234", feb); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := rd.RateForPrefix("4930", jan.Add(-time.Hour)); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestRateDeckCompileErrors(t *testing.T) {
+	jan := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	rd := &RateDeck{
+		Tenant: "cgrates.org",
+		ID:     "CARRIER1",
+		Rates:  []*RateDeckRate{{Prefix: "49", EffectiveDate: jan}},
+	}
+	expErr := "missing rate for prefix <49> on rate deck <cgrates.org:CARRIER1>"
+	if err := rd.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %q, received %v", expErr, err)
+	}
+	rd.Rates[0].Rate = utils.NewDecimal(1, 1)
+	rd.Rates[0].ExpiryDate = jan
+	expErr = "expiry date before effective date for prefix <49> on rate deck <cgrates.org:CARRIER1>"
+	if err := rd.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %q, received %v", expErr, err)
+	}
+	rd.Rates[0].Prefix = utils.EmptyString
+	expErr = "empty Prefix for rate deck <cgrates.org:CARRIER1>"
+	if err := rd.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %q, received %v", expErr, err)
+	}
+}
+
+func TestRateDeckApplyNotices(t *testing.T) {
+	jan := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	oldRd := &RateDeck{
+		Tenant: "cgrates.org",
+		ID:     "CARRIER1",
+		Rates: []*RateDeckRate{
+			{Prefix: "49", Rate: utils.NewDecimal(5, 2), EffectiveDate: jan},
+			{Prefix: "33", Rate: utils.NewDecimal(5, 2), EffectiveDate: jan},
+			{Prefix: "44", Rate: utils.NewDecimal(5, 2), EffectiveDate: jan},
+		},
+	}
+	rd := &RateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: 7 * 24 * time.Hour,
+		DecreaseNotice: 24 * time.Hour,
+		Rates: []*RateDeckRate{
+			{Prefix: "49", Rate: utils.NewDecimal(6, 2), EffectiveDate: now},                          // increase
+			{Prefix: "33", Rate: utils.NewDecimal(4, 2), EffectiveDate: now},                          // decrease
+			{Prefix: "44", Rate: utils.NewDecimal(5, 2), EffectiveDate: now},                          // unchanged
+			{Prefix: "39", Rate: utils.NewDecimal(9, 2), EffectiveDate: now},                          // new destination
+			{Prefix: "49", Rate: utils.NewDecimal(7, 2), EffectiveDate: now.Add(30 * 24 * time.Hour)}, // noticed enough
+		},
+	}
+	rd.ApplyNotices(oldRd, now)
+	exp := map[string][]time.Time{
+		"33": {now.Add(24 * time.Hour)},
+		"39": {now},
+		"44": {jan},
+		"49": {now.Add(7 * 24 * time.Hour), now.Add(30 * 24 * time.Hour)},
+	}
+	rcv := make(map[string][]time.Time)
+	for _, rdr := range rd.Rates {
+		rcv[rdr.Prefix] = append(rcv[rdr.Prefix], rdr.EffectiveDate)
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+	// importing again the same deck keeps the noticed dates
+	rd2 := &RateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: 7 * 24 * time.Hour,
+		Rates: []*RateDeckRate{
+			{Prefix: "49", Rate: utils.NewDecimal(6, 2), EffectiveDate: now},
+		},
+	}
+	rd2.ApplyNotices(rd, now.Add(48*time.Hour))
+	if !rd2.Rates[0].EffectiveDate.Equal(now.Add(7 * 24 * time.Hour)) {
+		t.Errorf("unexpected effective date: %v", rd2.Rates[0].EffectiveDate)
+	}
+	// going back to an older rate still needs the notice
+	rd3 := &RateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: 7 * 24 * time.Hour,
+		Rates: []*RateDeckRate{
+			{Prefix: "33", Rate: utils.NewDecimal(5, 2), EffectiveDate: now.Add(48 * time.Hour)},
+		},
+	}
+	rd3.ApplyNotices(&RateDeck{Rates: append(oldRd.Rates, rd.Rates...)}, now.Add(48*time.Hour))
+	if !rd3.Rates[0].EffectiveDate.Equal(now.Add(9 * 24 * time.Hour)) {
+		t.Errorf("unexpected effective date: %v", rd3.Rates[0].EffectiveDate)
+	}
+}
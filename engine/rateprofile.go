@@ -39,14 +39,58 @@ type RateProfile struct {
 	MinCost            *utils.Decimal
 	MaxCost            *utils.Decimal
 	MaxCostStrategy    string
+	Currency           string // currency of the costs, ie: EUR
+	VolumePeriod       string // period of the volume counters, empty to apply the interval rates per event: <""|*daily|*weekly|*monthly|*yearly>
 	Rates              map[string]*Rate
+
+	snapshot bool // version from the history, its rates are not indexed
 }
 
 func (rp *RateProfile) TenantID() string {
 	return utils.ConcatenatedKey(rp.Tenant, rp.ID)
 }
 
+// IndexedRates returns false for the versions from history since only the rates of the stored profile are indexed
+func (rp *RateProfile) IndexedRates() bool {
+	return !rp.snapshot
+}
+
+// Clone returns a copy of the RateProfile without its history
+func (rp *RateProfile) Clone() (cln *RateProfile) {
+	cln = &RateProfile{
+		Tenant:             rp.Tenant,
+		ID:                 rp.ID,
+		ActivationInterval: rp.ActivationInterval.Clone(),
+		Weights:            rp.Weights.Clone(),
+		MaxCostStrategy:    rp.MaxCostStrategy,
+		Currency:           rp.Currency,
+		VolumePeriod:       rp.VolumePeriod,
+	}
+	if rp.FilterIDs != nil {
+		cln.FilterIDs = make([]string, len(rp.FilterIDs))
+		copy(cln.FilterIDs, rp.FilterIDs)
+	}
+	if rp.MinCost != nil {
+		cln.MinCost = rp.MinCost.Clone()
+	}
+	if rp.MaxCost != nil {
+		cln.MaxCost = rp.MaxCost.Clone()
+	}
+	if rp.Rates != nil {
+		cln.Rates = make(map[string]*Rate, len(rp.Rates))
+		for key, rt := range rp.Rates {
+			cln.Rates[key] = rt.Clone()
+		}
+	}
+	return
+}
+
 func (rp *RateProfile) Compile() (err error) {
+	if rp.VolumePeriod != utils.EmptyString {
+		if _, err = VolumePeriodStart(rp.VolumePeriod, time.Now()); err != nil {
+			return
+		}
+	}
 	for _, rtP := range rp.Rates {
 		rtP.uID = utils.ConcatenatedKey(rp.Tenant, rp.ID, rtP.ID)
 		if err = rtP.Compile(); err != nil {
@@ -74,6 +118,29 @@ func (rt *Rate) UID() string {
 	return rt.uID
 }
 
+// Clone returns a copy of the Rate
+func (rt *Rate) Clone() (cln *Rate) {
+	cln = &Rate{
+		ID:              rt.ID,
+		ActivationTimes: rt.ActivationTimes,
+		Weights:         rt.Weights.Clone(),
+		Blocker:         rt.Blocker,
+		sched:           rt.sched,
+		uID:             rt.uID,
+	}
+	if rt.FilterIDs != nil {
+		cln.FilterIDs = make([]string, len(rt.FilterIDs))
+		copy(cln.FilterIDs, rt.FilterIDs)
+	}
+	if rt.IntervalRates != nil {
+		cln.IntervalRates = make([]*IntervalRate, len(rt.IntervalRates))
+		for i, iRt := range rt.IntervalRates {
+			cln.IntervalRates[i] = iRt.Clone()
+		}
+	}
+	return
+}
+
 type IntervalRate struct {
 	IntervalStart time.Duration // Starting point when the Rate kicks in
 	FixedFee      *utils.Decimal
@@ -82,6 +149,24 @@ type IntervalRate struct {
 	Increment     *utils.Decimal // RateIncrement
 }
 
+// Clone returns a copy of the IntervalRate
+func (iR *IntervalRate) Clone() (cln *IntervalRate) {
+	cln = &IntervalRate{IntervalStart: iR.IntervalStart}
+	if iR.FixedFee != nil {
+		cln.FixedFee = iR.FixedFee.Clone()
+	}
+	if iR.RecurrentFee != nil {
+		cln.RecurrentFee = iR.RecurrentFee.Clone()
+	}
+	if iR.Unit != nil {
+		cln.Unit = iR.Unit.Clone()
+	}
+	if iR.Increment != nil {
+		cln.Increment = iR.Increment.Clone()
+	}
+	return
+}
+
 func (rt *Rate) Compile() (err error) {
 	aTime := rt.ActivationTimes
 	if aTime == utils.EmptyString {
@@ -149,6 +234,7 @@ type RateProfileCost struct {
 	MinCost         float64
 	MaxCost         float64
 	MaxCostStrategy string
+	Currency        string
 	RateSIntervals  []*RateSInterval
 	Altered         []string
 }
@@ -170,6 +256,22 @@ func (rPc *RateProfileCost) CorrectCost(rndDec *int, rndMtd string) {
 	}
 }
 
+// EventRateProfileCost is the outcome of rating one event out of a batch
+type EventRateProfileCost struct {
+	EventID         string
+	RateProfileCost *RateProfileCost
+	Error           string // populated instead of RateProfileCost when rating fails
+}
+
+// RateProfileComparison is the outcome of rating a batch of events with one RateProfile
+type RateProfileComparison struct {
+	ID     string  // RateProfileID
+	Cost   float64 // sum of the costs for the events rated successfully
+	Delta  float64 // Cost difference to the first compared RateProfile
+	Errors int     // number of events which could not be rated
+	Events []*EventRateProfileCost
+}
+
 // Sort will sort the IntervalRates from each Rate based on IntervalStart
 func (rpp *RateProfile) Sort() {
 	for _, rate := range rpp.Rates {
@@ -259,6 +361,8 @@ func (ext *APIRateProfile) AsRateProfile() (rp *RateProfile, err error) {
 		FilterIDs:          ext.FilterIDs,
 		ActivationInterval: ext.ActivationInterval,
 		MaxCostStrategy:    ext.MaxCostStrategy,
+		Currency:           ext.Currency,
+		VolumePeriod:       ext.VolumePeriod,
 	}
 	if ext.Weights != utils.EmptyString {
 		if rp.Weights, err = utils.NewDynamicWeightsFromString(ext.Weights, ";", "&"); err != nil {
@@ -294,6 +398,8 @@ type APIRateProfile struct {
 	MinCost            *float64
 	MaxCost            *float64
 	MaxCostStrategy    string
+	Currency           string
+	VolumePeriod       string
 	Rates              map[string]*APIRate
 }
 
@@ -0,0 +1,153 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// RateProfileVersion is a snapshot of a RateProfile used for rating starting with EffectiveTime
+type RateProfileVersion struct {
+	EffectiveTime time.Time
+	RateProfile   *RateProfile
+}
+
+// RateProfileVersions keeps the history of a RateProfile, sorted on EffectiveTime
+type RateProfileVersions struct {
+	Tenant   string
+	ID       string
+	Versions []*RateProfileVersion
+	Stored   time.Time // EffectiveTime of the version matching the stored RateProfile
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rpvs *RateProfileVersions) TenantID() string {
+	return utils.ConcatenatedKey(rpvs.Tenant, rpvs.ID)
+}
+
+// VersionAt returns the version effective at the given time
+// the first version is returned for the times before the history started
+func (rpvs *RateProfileVersions) VersionAt(tm time.Time) (rpv *RateProfileVersion) {
+	for _, v := range rpvs.Versions {
+		if rpv != nil && v.EffectiveTime.After(tm) {
+			break
+		}
+		rpv = v
+	}
+	return
+}
+
+// ProfileAt returns the RateProfile effective at the given time
+// rp is returned if there is no older version to choose from or if the version in effect is rp itself,
+// so the rates of the stored profile are selected out of their indexes
+func (rpvs *RateProfileVersions) ProfileAt(rp *RateProfile, tm time.Time) *RateProfile {
+	if len(rpvs.Versions) < 2 {
+		return rp
+	}
+	if vRp := rpvs.VersionAt(tm).RateProfile; vRp.snapshot {
+		return vRp
+	}
+	return rp
+}
+
+// FilterIDs returns the FilterIDs of rp together with the ones of its versions,
+// nil if any of them has no filters so the profile is indexed for all the events
+func (rpvs *RateProfileVersions) FilterIDs(rp *RateProfile) []string {
+	if len(rp.FilterIDs) == 0 {
+		return nil
+	}
+	fltrIDs := utils.NewStringSet(rp.FilterIDs)
+	if rpvs != nil {
+		for _, rpv := range rpvs.Versions {
+			if len(rpv.RateProfile.FilterIDs) == 0 {
+				return nil
+			}
+			fltrIDs.AddSlice(rpv.RateProfile.FilterIDs)
+		}
+	}
+	return fltrIDs.AsOrderedSlice()
+}
+
+// AddVersion records the RateProfile as a new version, returning false if the profile did not change
+// the version becomes effective at the ActivationTime of the profile, or now if missing
+// the versions already effective are immutable so the new one cannot become effective in the past,
+// with the exception of the first version; a version not yet effective is replaced by the one having the same EffectiveTime
+func (rpvs *RateProfileVersions) AddVersion(rp *RateProfile, now time.Time) (added bool) {
+	effTime := now
+	if rp.ActivationInterval != nil && !rp.ActivationInterval.ActivationTime.IsZero() {
+		effTime = rp.ActivationInterval.ActivationTime
+	}
+	if len(rpvs.Versions) != 0 {
+		if effTime.Before(now) {
+			effTime = now
+		}
+		if prev := rpvs.VersionAt(effTime); !prev.EffectiveTime.After(effTime) &&
+			utils.ToJSON(prev.RateProfile) == utils.ToJSON(rp) {
+			rpvs.Stored = prev.EffectiveTime
+			return
+		}
+	}
+	rpvs.Stored = effTime
+	rpv := &RateProfileVersion{EffectiveTime: effTime, RateProfile: rp}
+	idx := sort.Search(len(rpvs.Versions), func(i int) bool {
+		return !rpvs.Versions[i].EffectiveTime.Before(effTime)
+	})
+	if idx < len(rpvs.Versions) && rpvs.Versions[idx].EffectiveTime.Equal(effTime) {
+		rpvs.Versions[idx] = rpv
+		return true
+	}
+	rpvs.Versions = append(rpvs.Versions, nil)
+	copy(rpvs.Versions[idx+1:], rpvs.Versions[idx:])
+	rpvs.Versions[idx] = rpv
+	return true
+}
+
+// Trim removes the oldest versions already effective at the given time, keeping at most limit of them
+// the version in effect and the scheduled ones are always kept, a negative limit keeps the whole history
+func (rpvs *RateProfileVersions) Trim(limit int, now time.Time) (trimmed bool) {
+	if limit < 0 {
+		return
+	}
+	if limit == 0 {
+		limit = 1
+	}
+	effective := sort.Search(len(rpvs.Versions), func(i int) bool {
+		return rpvs.Versions[i].EffectiveTime.After(now)
+	})
+	if effective <= limit {
+		return
+	}
+	rpvs.Versions = rpvs.Versions[effective-limit:]
+	return true
+}
+
+// Compile compiles the RateProfiles of each version, marking the ones differing from the stored profile as taken from history
+// ProfileAt replaces the version matching the stored profile with the stored one so its rates are selected out of the indexes
+func (rpvs *RateProfileVersions) Compile() (err error) {
+	for _, rpv := range rpvs.Versions {
+		rpv.RateProfile.snapshot = !rpv.EffectiveTime.Equal(rpvs.Stored)
+		if err = rpv.RateProfile.Compile(); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,229 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testRateProfileVersion(fee int64, aTime time.Time) *RateProfile {
+	rp := &RateProfile{
+		Tenant: "cgrates.org",
+		ID:     "RP1",
+		Rates: map[string]*Rate{
+			"RT_ALWAYS": {
+				ID: "RT_ALWAYS",
+				IntervalRates: []*IntervalRate{
+					{RecurrentFee: utils.NewDecimal(fee, 2)},
+				},
+			},
+		},
+	}
+	if !aTime.IsZero() {
+		rp.ActivationInterval = &utils.ActivationInterval{ActivationTime: aTime}
+	}
+	return rp
+}
+
+func TestRateProfileVersionsAddVersion(t *testing.T) {
+	jan := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)
+	feb := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	rpvs := &RateProfileVersions{Tenant: "cgrates.org", ID: "RP1"}
+	// the first version keeps its ActivationTime even if in the past
+	if !rpvs.AddVersion(testRateProfileVersion(1, jan), now) {
+		t.Fatal("expecting version to be added")
+	}
+	// the versions already effective cannot be replaced
+	if !rpvs.AddVersion(testRateProfileVersion(2, jan), now) {
+		t.Fatal("expecting version to be added")
+	}
+	if !rpvs.Versions[0].EffectiveTime.Equal(jan) ||
+		!rpvs.Versions[1].EffectiveTime.Equal(now) {
+		t.Errorf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+	// unchanged profile
+	if rpvs.AddVersion(testRateProfileVersion(2, jan), now.Add(time.Hour)) {
+		t.Errorf("not expecting the unchanged profile to be added: %s", utils.ToJSON(rpvs))
+	}
+	// the future versions can be replaced before they become effective
+	if !rpvs.AddVersion(testRateProfileVersion(3, feb), now) ||
+		!rpvs.AddVersion(testRateProfileVersion(4, feb), now) {
+		t.Fatal("expecting version to be added")
+	}
+	if len(rpvs.Versions) != 3 {
+		t.Fatalf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+	for tm, fee := range map[time.Time]int64{
+		jan.Add(-time.Hour): 1,
+		jan:                 1,
+		now:                 2,
+		feb.Add(-time.Hour): 2,
+		feb:                 4,
+	} {
+		if rcv := rpvs.VersionAt(tm).RateProfile; rcv.Rates["RT_ALWAYS"].IntervalRates[0].
+			RecurrentFee.Compare(utils.NewDecimal(fee, 2)) != 0 {
+			t.Errorf("at %v expecting fee %d, received: %s", tm, fee, utils.ToJSON(rcv))
+		}
+	}
+}
+
+func TestRateProfileVersionsTrim(t *testing.T) {
+	now := time.Date(2021, 1, 15, 10, 0, 0, 0, time.UTC)
+	rpvs := &RateProfileVersions{Tenant: "cgrates.org", ID: "RP1"}
+	for i := 0; i < 4; i++ { // three versions in the past and one scheduled
+		rpvs.Versions = append(rpvs.Versions, &RateProfileVersion{
+			EffectiveTime: now.AddDate(0, 0, i-2),
+			RateProfile:   testRateProfileVersion(int64(i), time.Time{}),
+		})
+	}
+	if rpvs.Trim(-1, now) || len(rpvs.Versions) != 4 {
+		t.Errorf("not expecting the versions to be trimmed: %s", utils.ToJSON(rpvs))
+	}
+	if rpvs.Trim(3, now) || len(rpvs.Versions) != 4 {
+		t.Errorf("not expecting the versions to be trimmed: %s", utils.ToJSON(rpvs))
+	}
+	if !rpvs.Trim(2, now) || len(rpvs.Versions) != 3 ||
+		!rpvs.Versions[0].EffectiveTime.Equal(now.AddDate(0, 0, -1)) {
+		t.Errorf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+	// the version in effect and the scheduled one are kept
+	if !rpvs.Trim(0, now) || len(rpvs.Versions) != 2 ||
+		!rpvs.Versions[0].EffectiveTime.Equal(now) {
+		t.Errorf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+}
+
+func TestRateProfileClone(t *testing.T) {
+	rp := &RateProfile{
+		Tenant:             "cgrates.org",
+		ID:                 "RP1",
+		FilterIDs:          []string{"*string:~*req.Subject:1001"},
+		ActivationInterval: &utils.ActivationInterval{ActivationTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		Weights:            utils.DynamicWeights{{Weight: 10}},
+		MinCost:            utils.NewDecimal(1, 1),
+		MaxCost:            utils.NewDecimal(6, 1),
+		MaxCostStrategy:    utils.MetaMaxCostFree,
+		Currency:           "EUR",
+		Rates: map[string]*Rate{
+			"RT_WEEK": {
+				ID:              "RT_WEEK",
+				FilterIDs:       []string{"*gte:~*req.Usage:1m"},
+				ActivationTimes: "* * * * 1-5",
+				Weights:         utils.DynamicWeights{{Weight: 0}},
+				IntervalRates: []*IntervalRate{
+					{
+						IntervalStart: time.Minute,
+						FixedFee:      utils.NewDecimal(1, 2),
+						RecurrentFee:  utils.NewDecimal(12, 2),
+						Unit:          utils.NewDecimal(int64(time.Minute), 0),
+						Increment:     utils.NewDecimal(int64(time.Second), 0),
+					},
+				},
+			},
+		},
+	}
+	if err := rp.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	cln := rp.Clone()
+	if !reflect.DeepEqual(rp, cln) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(rp), utils.ToJSON(cln))
+	}
+	cln.Rates["RT_WEEK"].IntervalRates[0].RecurrentFee = utils.NewDecimal(1, 0)
+	cln.FilterIDs[0] = "*string:~*req.Subject:1002"
+	if rp.Rates["RT_WEEK"].IntervalRates[0].RecurrentFee.Compare(utils.NewDecimal(12, 2)) != 0 ||
+		rp.FilterIDs[0] != "*string:~*req.Subject:1001" {
+		t.Errorf("clone altered the original profile: %s", utils.ToJSON(rp))
+	}
+}
+
+func TestDataManagerRateProfileVersions(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	rp := testRateProfileVersion(2, time.Time{})
+	rp.ID = "RP_VERSIONS"
+	if err := dm.SetRateProfile(rp, false); err != nil {
+		t.Fatal(err)
+	}
+	nextDay := time.Now().Add(24 * time.Hour)
+	rp = testRateProfileVersion(3, nextDay)
+	rp.ID = "RP_VERSIONS"
+	if err := dm.SetRateProfile(rp, false); err != nil {
+		t.Fatal(err)
+	}
+	// setting the same profile again does not create a new version
+	rp = testRateProfileVersion(3, nextDay)
+	rp.ID = "RP_VERSIONS"
+	if err := dm.SetRateProfile(rp, false); err != nil {
+		t.Fatal(err)
+	}
+	rpvs, err := dm.GetRateProfileVersions("cgrates.org", "RP_VERSIONS", true, true, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(rpvs.Versions) != 2 {
+		t.Fatalf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+	if rcv := rpvs.ProfileAt(rp, time.Now()); rcv.ActivationInterval != nil || rcv.IndexedRates() {
+		t.Errorf("expecting the first version, received: %s", utils.ToJSON(rcv))
+	}
+	if rcv := rpvs.ProfileAt(rp, nextDay); rcv.ActivationInterval == nil ||
+		!rcv.ActivationInterval.ActivationTime.Equal(nextDay) {
+		t.Errorf("expecting the scheduled version, received: %s", utils.ToJSON(rcv))
+	}
+	// the version matching the stored profile is replaced by it so its rates are selected out of the indexes
+	stored, err := dm.GetRateProfile("cgrates.org", "RP_VERSIONS", true, true, utils.NonTransactional)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := rpvs.ProfileAt(stored, nextDay); rcv != stored || !rcv.IndexedRates() {
+		t.Errorf("expecting the stored profile, received: %s", utils.ToJSON(rcv))
+	}
+	if err := dm.RemoveRateProfile("cgrates.org", "RP_VERSIONS", utils.NonTransactional, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetRateProfileVersions("cgrates.org", "RP_VERSIONS",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestRateProfileVersionsFilterIDs(t *testing.T) {
+	rp := testRateProfileVersion(1, time.Time{})
+	rp.FilterIDs = []string{"FLTR_2", "FLTR_1"}
+	var rpvs *RateProfileVersions
+	if rcv := rpvs.FilterIDs(rp); !reflect.DeepEqual([]string{"FLTR_1", "FLTR_2"}, rcv) {
+		t.Errorf("Expected %+v, received %+v", []string{"FLTR_1", "FLTR_2"}, rcv)
+	}
+	old := testRateProfileVersion(2, time.Time{})
+	old.FilterIDs = []string{"FLTR_1", "FLTR_OLD"}
+	rpvs = &RateProfileVersions{Versions: []*RateProfileVersion{{RateProfile: old}, {RateProfile: rp}}}
+	if exp, rcv := []string{"FLTR_1", "FLTR_2", "FLTR_OLD"}, rpvs.FilterIDs(rp); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	// a version without filters matches all the events
+	old.FilterIDs = nil
+	if rcv := rpvs.FilterIDs(rp); rcv != nil {
+		t.Errorf("Expected nil, received %+v", rcv)
+	}
+}
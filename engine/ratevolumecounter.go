@@ -0,0 +1,70 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// RateVolumeCounter keeps the usage rated for one account within the volume period of a RateProfile
+type RateVolumeCounter struct {
+	Tenant      string
+	ID          string        // RateProfileID:AccountID
+	PeriodStart time.Time     // start of the period the usage was counted in
+	Usage       time.Duration // usage rated since PeriodStart
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (rvc *RateVolumeCounter) TenantID() string {
+	return utils.ConcatenatedKey(rvc.Tenant, rvc.ID)
+}
+
+// UsageAt returns the usage counted within the period starting at pStart
+func (rvc *RateVolumeCounter) UsageAt(pStart time.Time) time.Duration {
+	if !rvc.PeriodStart.Equal(pStart) { // counter from another period
+		return 0
+	}
+	return rvc.Usage
+}
+
+// RateVolumeCounterID builds the ID of the counter for an account on a RateProfile
+func RateVolumeCounterID(rpID, acntID string) string {
+	return utils.ConcatenatedKey(rpID, acntID)
+}
+
+// VolumePeriodStart returns the start of the volume period containing tm
+func VolumePeriodStart(period string, tm time.Time) (pStart time.Time, err error) {
+	y, m, d := tm.Date()
+	switch period {
+	case utils.MetaDaily:
+		pStart = time.Date(y, m, d, 0, 0, 0, 0, tm.Location())
+	case utils.MetaWeekly: // weeks start on Monday
+		pStart = time.Date(y, m, d-(int(tm.Weekday())+6)%7, 0, 0, 0, 0, tm.Location())
+	case utils.MetaMonthly:
+		pStart = time.Date(y, m, 1, 0, 0, 0, 0, tm.Location())
+	case utils.MetaYearly:
+		pStart = time.Date(y, 1, 1, 0, 0, 0, 0, tm.Location())
+	default:
+		err = fmt.Errorf("unsupported volume period: <%s>", period)
+	}
+	return
+}
@@ -0,0 +1,83 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestVolumePeriodStart(t *testing.T) {
+	tm := time.Date(2021, 3, 18, 15, 4, 5, 0, time.UTC) // Thursday
+	for period, exp := range map[string]time.Time{
+		utils.MetaDaily:   time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC),
+		utils.MetaWeekly:  time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+		utils.MetaMonthly: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		utils.MetaYearly:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if rcv, err := VolumePeriodStart(period, tm); err != nil {
+			t.Error(err)
+		} else if !rcv.Equal(exp) {
+			t.Errorf("<%s> expected %v, received %v", period, exp, rcv)
+		}
+	}
+	// Sunday belongs to the week started on Monday
+	if rcv, err := VolumePeriodStart(utils.MetaWeekly,
+		time.Date(2021, 3, 21, 10, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if exp := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC); !rcv.Equal(exp) {
+		t.Errorf("expected %v, received %v", exp, rcv)
+	}
+	expErr := "unsupported volume period: <*hourly>"
+	if _, err := VolumePeriodStart(utils.MetaHourly, tm); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestRateVolumeCounterUsageAt(t *testing.T) {
+	rvc := &RateVolumeCounter{
+		Tenant:      "cgrates.org",
+		ID:          RateVolumeCounterID("RP1", "1001"),
+		PeriodStart: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		Usage:       time.Hour,
+	}
+	if rvc.TenantID() != "cgrates.org:RP1:1001" {
+		t.Errorf("unexpected tenantID: %s", rvc.TenantID())
+	}
+	if usage := rvc.UsageAt(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)); usage != time.Hour {
+		t.Errorf("Expected %v, received %v", time.Hour, usage)
+	}
+	if usage := rvc.UsageAt(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)); usage != 0 {
+		t.Errorf("Expected 0, received %v", usage)
+	}
+}
+
+func TestRateProfileCompileVolumePeriod(t *testing.T) {
+	rp := &RateProfile{
+		Tenant:       "cgrates.org",
+		ID:           "RP1",
+		VolumePeriod: "*invalid",
+	}
+	expErr := "unsupported volume period: <*invalid>"
+	if err := rp.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
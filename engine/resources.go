@@ -559,6 +559,9 @@ func (rS *ResourceService) matchingResourcesForEvent(tnt string, ev *utils.CGREv
 			break
 		}
 	}
+	for _, r := range rs {
+		hitCounters.Hit(utils.CacheResourceProfiles, tnt, r.ID, r.rPrf.FilterIDs...)
+	}
 	err = Cache.Set(utils.CacheEventResources, evUUID, rs.resIDsMp(), nil, true, "")
 	return
 }
@@ -0,0 +1,74 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewMarginSorter(rS *RouteService) *MarginSorter {
+	return &MarginSorter{rS: rS,
+		sorting: utils.MetaMargin}
+}
+
+// MarginSorter sorts routes based on the difference between
+// the price charged to the customer and the cost of the route
+type MarginSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (ms *MarginSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting:      ms.sorting,
+		SortedRoutes: make([]*SortedRoute, 0)}
+	// the customer price is the same for all routes, SortingParameters can restrict the RateProfiles used for it
+	var custCost float64
+	if custCost, err = ms.rS.customerCostForEvent(ev, extraOpts.sortingParameters); err != nil {
+		return nil, err
+	}
+	for _, route := range routes {
+		if len(route.RatingPlanIDs) == 0 && len(route.AccountIDs) == 0 {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> supplier: <%s> - empty RatingPlanIDs or AccountIDs",
+					utils.RouteS, route.ID))
+			return nil, utils.NewErrMandatoryIeMissing("RatingPlanIDs or AccountIDs")
+		}
+		srtSpl, pass, err := ms.rS.populateSortingData(ev, route, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		margin := custCost - srtSpl.SortingData[utils.Cost].(float64)
+		if margin < 0 { // never route at a loss
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> ignoring route with ID: %s, negative margin: %v",
+					utils.RouteS, route.ID, margin))
+			continue
+		}
+		srtSpl.SortingData[utils.Margin] = margin
+		sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, srtSpl)
+	}
+	sortedRoutes.SortMargin()
+	return
+}
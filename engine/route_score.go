@@ -0,0 +1,173 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func NewScoreSorter(rS *RouteService) *ScoreSorter {
+	return &ScoreSorter{rS: rS,
+		sorting: utils.MetaScore}
+}
+
+// ScoreSorter sorts routes based on a score composed out of
+// weighted stat metrics and the cost from RateS, each normalised across the routes
+type ScoreSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+func (ss *ScoreSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	var coefs map[string]float64
+	if coefs, err = scoreCoefficients(extraOpts.sortingParameters); err != nil {
+		return
+	}
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting:      ss.sorting,
+		SortedRoutes: make([]*SortedRoute, 0)}
+	_, hasCost := coefs[utils.MetaCost]
+	for _, route := range routes {
+		srtRoute := route
+		if hasCost { // the cost is computed by RateS so there is no need to query RALs for it
+			srtRoute = new(Route)
+			*srtRoute = *route
+			srtRoute.AccountIDs = nil
+			srtRoute.RatingPlanIDs = nil
+		}
+		srtSpl, pass, err := ss.rS.populateSortingData(ev, srtRoute, extraOpts)
+		if err != nil {
+			return nil, err
+		} else if !pass || srtSpl == nil {
+			continue
+		}
+		if hasCost {
+			var cost float64
+			if cost, err = ss.rS.customerCostForEvent(ev, route.RateProfileIDs); err != nil {
+				if extraOpts.ignoreErrors {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> ignoring route with ID: %s, err: %s",
+							utils.RouteS, route.ID, err.Error()))
+					continue
+				}
+				return nil, err
+			}
+			if extraOpts.maxCost != 0 && cost > extraOpts.maxCost {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> ignoring route with ID: %s, err: %s",
+						utils.RouteS, route.ID, utils.ErrMaxCostExceeded.Error()))
+				continue
+			}
+			srtSpl.SortingData[utils.Cost] = cost
+		}
+		sortedRoutes.SortedRoutes = append(sortedRoutes.SortedRoutes, srtSpl)
+	}
+	sortedRoutes.computeScores(coefs)
+	sortedRoutes.SortScore()
+	return
+}
+
+// scoreCoefficients parses the SortingParameters of the *score strategy
+// each parameter is in the form metricID:coefficient, e.g. *asr:2
+func scoreCoefficients(params []string) (coefs map[string]float64, err error) {
+	if len(params) == 0 {
+		return nil, utils.NewErrMandatoryIeMissing(utils.SortingParameters)
+	}
+	coefs = make(map[string]float64)
+	for _, param := range params {
+		splt := strings.Split(param, utils.ConcatenatedKeySep)
+		if len(splt) != 2 {
+			return nil, fmt.Errorf("invalid %s parameter: <%s>", utils.MetaScore, param)
+		}
+		var coef float64
+		if coef, err = strconv.ParseFloat(splt[1], 64); err != nil {
+			return
+		}
+		if coef < 0 {
+			return nil, fmt.Errorf("negative coefficient for %s parameter: <%s>", utils.MetaScore, param)
+		}
+		coefs[splt[0]] = coef
+	}
+	return
+}
+
+// scoreMetricValue returns the value used for the metric out of SortingData
+// negative values are considered missing since StatS returns -1 for metrics without data
+func scoreMetricValue(sd map[string]interface{}, metric string) (val float64, has bool) {
+	key := metric
+	if metric == utils.MetaCost {
+		key = utils.Cost
+	}
+	var iface interface{}
+	if iface, has = sd[key]; !has {
+		return
+	}
+	var err error
+	if val, err = utils.IfaceAsFloat64(iface); err != nil || val < 0 {
+		return 0, false
+	}
+	return
+}
+
+// computeScores populates the Score in SortingData of each route
+// the values of each metric are normalised between 0 and 1 across the routes,
+// the lower the *pdd and *cost the better, the higher any other metric the better;
+// a route missing the metric gets 0 for it
+func (sRoutes *SortedRoutes) computeScores(coefs map[string]float64) {
+	var coefSum float64
+	scores := make([]float64, len(sRoutes.SortedRoutes))
+	for metric, coef := range coefs {
+		coefSum += coef
+		minVal, maxVal := math.Inf(1), math.Inf(-1)
+		for _, sRoute := range sRoutes.SortedRoutes {
+			if val, has := scoreMetricValue(sRoute.SortingData, metric); has {
+				minVal = math.Min(minVal, val)
+				maxVal = math.Max(maxVal, val)
+			}
+		}
+		for i, sRoute := range sRoutes.SortedRoutes {
+			val, has := scoreMetricValue(sRoute.SortingData, metric)
+			if !has {
+				continue
+			}
+			norm := 1.0 // all the routes have the same value
+			if maxVal != minVal {
+				switch metric {
+				case utils.MetaPDD, utils.MetaCost:
+					norm = (maxVal - val) / (maxVal - minVal)
+				default:
+					norm = (val - minVal) / (maxVal - minVal)
+				}
+			}
+			scores[i] += coef * norm
+		}
+	}
+	for i, sRoute := range sRoutes.SortedRoutes {
+		if coefSum != 0 {
+			scores[i] = scores[i] / coefSum
+		}
+		sRoute.SortingData[utils.Score] = scores[i]
+	}
+}
@@ -35,6 +35,7 @@ type Route struct {
 	FilterIDs       []string
 	AccountIDs      []string
 	RatingPlanIDs   []string // used when computing price
+	RateProfileIDs  []string // used when computing the cost with RateS
 	ResourceIDs     []string // queried in some strategies
 	StatIDs         []string // queried in some strategies
 	Weight          float64
@@ -66,6 +67,10 @@ type RouteProfileWithOpts struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
+	if rp.Sorting == utils.MetaScore { // validate the coefficients early
+		_, err := scoreCoefficients(rp.SortingParameters)
+		return err
+	}
 	if rp.Sorting == utils.MetaLoad {
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
@@ -142,7 +147,9 @@ func (rpS *RouteService) Shutdown() {
 }
 
 // matchingRouteProfilesForEvent returns ordered list of matching resources which are active by the time of the call
-func (rpS *RouteService) matchingRouteProfilesForEvent(tnt string, ev *utils.CGREvent, singleResult bool) (matchingRPrf []*RouteProfile, err error) {
+// the candidate profiles are traced into prfTrcs if not nil
+func (rpS *RouteService) matchingRouteProfilesForEvent(tnt string, ev *utils.CGREvent, singleResult bool,
+	prfTrcs *ProfileTraces) (matchingRPrf []*RouteProfile, err error) {
 	evNm := utils.MapStorage{
 		utils.MetaReq:  ev.Event,
 		utils.MetaOpts: ev.Opts,
@@ -171,14 +178,17 @@ func (rpS *RouteService) matchingRouteProfilesForEvent(tnt string, ev *utils.CGR
 			}
 			return nil, err
 		}
+		pt := prfTrcs.newTrace(rPrf.ID, rPrf.Weight)
 		if rPrf.ActivationInterval != nil && ev.Time != nil &&
 			!rPrf.ActivationInterval.IsActiveAtTime(*ev.Time) { // not active
+			pt.skip(utils.MetaActivationInterval)
 			continue
 		}
-		if pass, err := rpS.filterS.Pass(tnt, rPrf.FilterIDs,
-			evNm); err != nil {
+		if pass, err := rpS.filterS.passWithTrace(tnt, rPrf.FilterIDs,
+			evNm, pt.filterTraces()); err != nil {
 			return nil, err
 		} else if !pass {
+			pt.skip(utils.MetaFilters)
 			continue
 		}
 		if singleResult {
@@ -199,6 +209,17 @@ func (rpS *RouteService) matchingRouteProfilesForEvent(tnt string, ev *utils.CGR
 		}
 		sort.Slice(matchingRPrf, func(i, j int) bool { return matchingRPrf[i].Weight > matchingRPrf[j].Weight })
 	}
+	if prfTrcs != nil {
+		ids := make([]string, len(matchingRPrf))
+		for i, rPrf := range matchingRPrf {
+			ids[i] = rPrf.ID
+		}
+		prfTrcs.selectIDs(ids...)
+	} else {
+		for _, rPrf := range matchingRPrf {
+			hitCounters.Hit(utils.CacheRouteProfiles, tnt, rPrf.ID, rPrf.FilterIDs...)
+		}
+	}
 	return
 }
 
@@ -293,6 +314,24 @@ func (rpS *RouteService) costForEvent(ev *utils.CGREvent,
 	return
 }
 
+// customerCostForEvent queries RateS for the cost of the event using the given RateProfileIDs
+func (rpS *RouteService) customerCostForEvent(ev *utils.CGREvent, rpIDs []string) (cost float64, err error) {
+	if len(rpS.cgrcfg.RouteSCfg().RateSConns) == 0 {
+		return 0, utils.NewErrNotConnected(utils.RateS)
+	}
+	cgrEv := ev.Clone()
+	delete(cgrEv.Opts, utils.OptsRatesVolumeUpdate) // querying routes should not alter the volume counters
+	var rpCost RateProfileCost
+	if err = rpS.connMgr.Call(rpS.cgrcfg.RouteSCfg().RateSConns, nil, utils.RateSv1CostForEvent,
+		&utils.ArgsCostForEvent{
+			RateProfileIDs: rpIDs,
+			CGREvent:       cgrEv,
+		}, &rpCost); err != nil {
+		return
+	}
+	return rpCost.Cost, nil
+}
+
 // statMetrics will query a list of statIDs and return composed metric values
 // first metric found is always returned
 func (rpS *RouteService) statMetrics(statIDs []string, tenant string) (stsMetric map[string]float64, err error) {
@@ -458,13 +497,17 @@ func (rpS *RouteService) populateSortingData(ev *utils.CGREvent, route *Route,
 			//check if the route have the metric from sortingParameters
 			//in case that the metric don't exist
 			//we use 10000000 for *pdd and -1 for others
-			for _, metric := range extraOpts.sortingParameters {
-				if _, hasMetric := metricSupp[metric]; !hasMetric {
-					switch metric {
-					default:
-						sortedSpl.SortingData[metric] = -1.0
-					case utils.MetaPDD:
-						sortedSpl.SortingData[metric] = 10000000.0
+			//*score and *margin strategies have their own format for sortingParameters
+			if extraOpts.sortingStragety != utils.MetaScore &&
+				extraOpts.sortingStragety != utils.MetaMargin {
+				for _, metric := range extraOpts.sortingParameters {
+					if _, hasMetric := metricSupp[metric]; !hasMetric {
+						switch metric {
+						default:
+							sortedSpl.SortingData[metric] = -1.0
+						case utils.MetaPDD:
+							sortedSpl.SortingData[metric] = 10000000.0
+						}
 					}
 				}
 			}
@@ -512,7 +555,7 @@ func (rpS *RouteService) sortedRoutesForEvent(tnt string, args *ArgsGetRoutes) (
 		args.CGREvent.Event[utils.Usage] = time.Minute // make sure we have default set for Usage
 	}
 	var rPrfs []*RouteProfile
-	if rPrfs, err = rpS.matchingRouteProfilesForEvent(tnt, args.CGREvent, true); err != nil {
+	if rPrfs, err = rpS.matchingRouteProfilesForEvent(tnt, args.CGREvent, true, nil); err != nil {
 		return
 	}
 	rPrfl := rPrfs[0]
@@ -692,7 +735,7 @@ func (rpS *RouteService) V1GetRouteProfilesForEvent(args *utils.CGREvent, reply
 	if tnt == utils.EmptyString {
 		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
 	}
-	sPs, err := rpS.matchingRouteProfilesForEvent(tnt, args, false)
+	sPs, err := rpS.matchingRouteProfilesForEvent(tnt, args, false, nil)
 	if err != nil {
 		if err != utils.ErrNotFound {
 			err = utils.NewErrServerError(err)
@@ -23,6 +23,8 @@ import (
 	"time"
 
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/ltcache"
+	"github.com/cgrates/rpcclient"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -544,7 +546,7 @@ func TestRoutesmatchingRouteProfilesForEvent(t *testing.T) {
 			t.Errorf("Expecting: %+v, received: %+v", spp, tempSpp)
 		}
 	}
-	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true)
+	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -552,7 +554,7 @@ func TestRoutesmatchingRouteProfilesForEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[0], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -560,7 +562,7 @@ func TestRoutesmatchingRouteProfilesForEvent(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[1], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1912,7 +1914,7 @@ func TestRoutesAsOptsGetRoutesMaxCost(t *testing.T) {
 	}
 
 	routeService.cgrcfg.RouteSCfg().IndexedSelects = false
-	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true)
+	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1920,7 +1922,7 @@ func TestRoutesAsOptsGetRoutesMaxCost(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[0], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1928,7 +1930,7 @@ func TestRoutesAsOptsGetRoutesMaxCost(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[1], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2166,7 +2168,7 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 	}
 
 	routeService.cgrcfg.RouteSCfg().IndexedSelects = false
-	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true)
+	sprf, err := routeService.matchingRouteProfilesForEvent(argsGetRoutes[0].Tenant, argsGetRoutes[0].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2174,7 +2176,7 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[0], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[1].Tenant, argsGetRoutes[1].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2182,7 +2184,7 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[1], sprf[0])
 	}
 
-	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true)
+	sprf, err = routeService.matchingRouteProfilesForEvent(argsGetRoutes[2].Tenant, argsGetRoutes[2].CGREvent, true, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2190,3 +2192,187 @@ func TestRoutesMatchWithIndexFalse(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", sppTest[2], sprf[0])
 	}
 }
+
+// routesRPCMock answers with the cost of the first RatingPlanID out of rpCosts and, for RateS,
+// with the cost of the first RateProfileID out of rateCosts or custCost if not found
+type routesRPCMock struct {
+	rpCosts   map[string]float64
+	rateCosts map[string]float64
+	custCost  float64
+}
+
+func (m *routesRPCMock) Call(method string, args interface{}, rply interface{}) error {
+	switch method {
+	case utils.ResponderGetCostOnRatingPlans:
+		rpID := args.(*utils.GetCostOnRatingPlansArgs).RatingPlanIDs[0]
+		*rply.(*map[string]interface{}) = map[string]interface{}{
+			utils.Cost:         m.rpCosts[rpID],
+			utils.RatingPlanID: rpID,
+		}
+	case utils.RateSv1CostForEvent:
+		if rpIDs := args.(*utils.ArgsCostForEvent).RateProfileIDs; len(rpIDs) != 0 {
+			if cost, has := m.rateCosts[rpIDs[0]]; has {
+				*rply.(*RateProfileCost) = RateProfileCost{ID: rpIDs[0], Cost: cost}
+				return nil
+			}
+		}
+		*rply.(*RateProfileCost) = RateProfileCost{ID: "RP_CUSTOMER", Cost: m.custCost}
+	default:
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	return nil
+}
+
+func newRouteServiceWithMock(mock *routesRPCMock) *RouteService {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder)}
+	cfg.RouteSCfg().RateSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS)}
+	ralsChan := make(chan rpcclient.ClientConnector, 1)
+	ralsChan <- mock
+	ratesChan := make(chan rpcclient.ClientConnector, 1)
+	ratesChan <- mock
+	cM := &ConnManager{
+		cfg: cfg,
+		rpcInternal: map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder): ralsChan,
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS):     ratesChan,
+		},
+		connCache: ltcache.NewCache(-1, 0, true, nil),
+	}
+	Cache.Clear([]string{utils.CacheRPCConnections}) // do not reuse the mocks of other tests
+	return NewRouteService(nil, nil, cfg, cM)
+}
+
+func TestRouteServiceSortMargin(t *testing.T) {
+	rS := newRouteServiceWithMock(&routesRPCMock{
+		rpCosts: map[string]float64{
+			"RP_ROUTE1": 0.3,
+			"RP_ROUTE2": 0.1,
+			"RP_ROUTE3": 0.7,
+		},
+		custCost: 0.5,
+	})
+	routes := map[string]*Route{
+		"route1": {ID: "route1", RatingPlanIDs: []string{"RP_ROUTE1"}, Weight: 10},
+		"route2": {ID: "route2", RatingPlanIDs: []string{"RP_ROUTE2"}, Weight: 20},
+		"route3": {ID: "route3", RatingPlanIDs: []string{"RP_ROUTE3"}, Weight: 30},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "MarginEvent",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.SetupTime:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			utils.Usage:        time.Minute,
+		},
+	}
+	sortedRoutes, err := rS.sorter.SortRoutes("ROUTE_MARGIN", utils.MetaMargin,
+		routes, ev, &optsGetRoutes{sortingStragety: utils.MetaMargin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// route3 is loss-making so it is excluded
+	if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route2", "route1"}, rcv) {
+		t.Errorf("Expected %+v, received %+v", []string{"route2", "route1"}, rcv)
+	}
+	if margin := sortedRoutes.SortedRoutes[0].SortingData[utils.Margin]; margin != 0.4 {
+		t.Errorf("Expected margin 0.4, received %+v", margin)
+	}
+
+	rS.cgrcfg.RouteSCfg().RateSConns = []string{}
+	if _, err = rS.sorter.SortRoutes("ROUTE_MARGIN", utils.MetaMargin,
+		routes, ev, &optsGetRoutes{sortingStragety: utils.MetaMargin}); err == nil ||
+		err.Error() != utils.NewErrNotConnected(utils.RateS).Error() {
+		t.Errorf("Expected %+v, received %+v", utils.NewErrNotConnected(utils.RateS), err)
+	}
+}
+
+func TestRouteServiceSortScore(t *testing.T) {
+	// RALs and RateS disagree on the cheapest route, the cost from RateS is used
+	rS := newRouteServiceWithMock(&routesRPCMock{
+		rpCosts: map[string]float64{
+			"RP_ROUTE1": 0.1,
+			"RP_ROUTE2": 0.3,
+		},
+		rateCosts: map[string]float64{
+			"RT_ROUTE1": 0.3,
+			"RT_ROUTE2": 0.1,
+		},
+	})
+	routes := map[string]*Route{
+		"route1": {ID: "route1", RatingPlanIDs: []string{"RP_ROUTE1"},
+			RateProfileIDs: []string{"RT_ROUTE1"}, Weight: 10},
+		"route2": {ID: "route2", RatingPlanIDs: []string{"RP_ROUTE2"},
+			RateProfileIDs: []string{"RT_ROUTE2"}, Weight: 20},
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ScoreEvent",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "1002",
+			utils.SetupTime:    time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			utils.Usage:        time.Minute,
+		},
+	}
+	sortedRoutes, err := rS.sorter.SortRoutes("ROUTE_SCORE", utils.MetaScore,
+		routes, ev, &optsGetRoutes{
+			sortingStragety:   utils.MetaScore,
+			sortingParameters: []string{"*cost:1"},
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route2", "route1"}, rcv) {
+		t.Errorf("Expected %+v, received %+v", []string{"route2", "route1"}, rcv)
+	}
+	if cost := sortedRoutes.SortedRoutes[0].SortingData[utils.Cost]; cost != 0.1 {
+		t.Errorf("Expected cost 0.1, received %+v", cost)
+	}
+	if sortedRoutes, err = rS.sorter.SortRoutes("ROUTE_SCORE", utils.MetaScore,
+		routes, ev, &optsGetRoutes{
+			sortingStragety:   utils.MetaScore,
+			sortingParameters: []string{"*cost:1"},
+			maxCost:           0.2,
+		}); err != nil {
+		t.Fatal(err)
+	} else if rcv := sortedRoutes.RouteIDs(); !reflect.DeepEqual([]string{"route2"}, rcv) {
+		t.Errorf("Expected %+v, received %+v", []string{"route2"}, rcv)
+	}
+	rS.cgrcfg.RouteSCfg().RateSConns = []string{}
+	if _, err = rS.sorter.SortRoutes("ROUTE_SCORE", utils.MetaScore,
+		routes, ev, &optsGetRoutes{
+			sortingStragety:   utils.MetaScore,
+			sortingParameters: []string{"*cost:1"},
+		}); err == nil || err.Error() != utils.NewErrNotConnected(utils.RateS).Error() {
+		t.Errorf("Expected %+v, received %+v", utils.NewErrNotConnected(utils.RateS), err)
+	}
+	if _, err = rS.sorter.SortRoutes("ROUTE_SCORE", utils.MetaScore,
+		routes, ev, &optsGetRoutes{sortingStragety: utils.MetaScore}); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.SortingParameters).Error() {
+		t.Errorf("Expected %+v, received %+v", utils.NewErrMandatoryIeMissing(utils.SortingParameters), err)
+	}
+}
+
+func TestRouteProfileCompileScore(t *testing.T) {
+	rp := &RouteProfile{
+		Tenant:            "cgrates.org",
+		ID:                "ROUTE_SCORE",
+		Sorting:           utils.MetaScore,
+		SortingParameters: []string{"*asr:2", "*pdd"},
+	}
+	expErr := "invalid *score parameter: <*pdd>"
+	if err := rp.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	rp.SortingParameters = []string{"*asr:2", "*pdd:-1"}
+	expErr = "negative coefficient for *score parameter: <*pdd:-1>"
+	if err := rp.Compile(); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+	rp.SortingParameters = []string{"*asr:2", "*pdd:1"}
+	if err := rp.Compile(); err != nil {
+		t.Error(err)
+	}
+}
@@ -202,6 +202,9 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 			break
 		}
 	}
+	for _, s := range sqs {
+		hitCounters.Hit(utils.CacheStatQueueProfiles, tnt, s.ID, s.sqPrfl.FilterIDs...)
+	}
 	return
 }
 
@@ -398,10 +398,14 @@ func appendName(paths []string, fileName string) (out []string) {
 }
 
 func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process func(interface{})) error {
-	collumnCount := getColumnCount(listType)
+	collumnCount, optCollumns := getColumnCount(listType)
+	nrFields := collumnCount
+	if optCollumns != 0 {
+		nrFields = -1 // the trailing optional columns can be missing, checked bellow
+	}
 	for _, fileName := range fns {
 		csvReader := csvs.generator()
-		err := csvReader.Open(fileName, csvs.sep, collumnCount)
+		err := csvReader.Open(fileName, csvs.sep, nrFields)
 		if err != nil {
 			// maybe a log to view if failed to open file
 			continue // try read the rest
@@ -413,6 +417,11 @@ func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process
 					log.Printf("bad line in %s, %s\n", fileName, err.Error())
 					return err
 				}
+				if len(record) < collumnCount-optCollumns || len(record) > collumnCount {
+					err = fmt.Errorf("wrong number of fields: %d", len(record))
+					log.Printf("bad line in %s, %s\n", fileName, err.Error())
+					return err
+				}
 				if item, err := csvLoad(listType, record); err != nil {
 					log.Printf("error loading %s: %v", "", err)
 					return err
@@ -928,8 +937,12 @@ func (c *csvGoogle) Read() (record []string, err error) {
 	if err != nil {
 		return
 	}
-	record = make([]string, c.nrFields)
-	for i := 0; i < c.nrFields; i++ {
+	nrFields := c.nrFields
+	if nrFields < 0 { // optional columns
+		nrFields = len(row)
+	}
+	record = make([]string, nrFields)
+	for i := 0; i < nrFields; i++ {
 		if i < len(row) {
 			record[i] = utils.IfaceAsString(row[i])
 			if i == 0 && strings.HasPrefix(record[i], "#") {
@@ -138,6 +138,35 @@ type DataDB interface {
 	GetAccountProfileDrv(string, string) (*utils.AccountProfile, error)
 	SetAccountProfileDrv(profile *utils.AccountProfile) error
 	RemoveAccountProfileDrv(string, string) error
+	GetExchangeRateProfileDrv(string, string) (*utils.ExchangeRateProfile, error)
+	SetExchangeRateProfileDrv(*utils.ExchangeRateProfile) error
+	RemoveExchangeRateProfileDrv(string, string) error
+	GetRateVolumeCounterDrv(string, string) (*RateVolumeCounter, error)
+	SetRateVolumeCounterDrv(*RateVolumeCounter) error
+	RemoveRateVolumeCounterDrv(string, string) error
+	GetRateDeckDrv(string, string) (*RateDeck, error)
+	SetRateDeckDrv(*RateDeck) error
+	RemoveRateDeckDrv(string, string) error
+	GetTaxProfileDrv(string, string) (*TaxProfile, error)
+	SetTaxProfileDrv(*TaxProfile) error
+	RemoveTaxProfileDrv(string, string) error
+	GetLookupTableDrv(string, string) (*LookupTable, error)
+	SetLookupTableDrv(*LookupTable) error
+	RemoveLookupTableDrv(string, string) error
+	GetProfileHitsDrv(string, string) (*ProfileHits, error)
+	SetProfileHitsDrv(*ProfileHits) error
+	RemoveProfileHitsDrv(string, string) error
+	GetRateProfileVersionsDrv(string, string) (*RateProfileVersions, error)
+	SetRateProfileVersionsDrv(*RateProfileVersions) error
+	RemoveRateProfileVersionsDrv(string, string) error
+	GetLoadVersionDrv(string, int) (*LoadVersion, error)
+	SetLoadVersionDrv(*LoadVersion) error
+	RemoveLoadVersionDrv(string, int) error
+	GetActiveLoadVersionDrv(string) (int, error)
+	SetActiveLoadVersionDrv(string, int) error
+	GetConfigSectionsDrv(nodeID string, sectionIDs []string) (map[string][]byte, error)
+	SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) error
+	RemoveConfigSectionsDrv(nodeID string, sectionIDs []string) error
 }
 
 type StorDB interface {
@@ -154,6 +183,9 @@ type CdrStorage interface {
 	RemoveSMCost(*SMCost) error
 	RemoveSMCosts(qryFltr *utils.SMCostFilter) error
 	GetCDRs(*utils.CDRsFilter, bool) ([]*CDR, int64, error)
+	SetInvoice(*Invoice) error
+	GetInvoices(*utils.InvoicesFilter) ([]*Invoice, error)
+	RemoveInvoices(*utils.InvoicesFilter) error
 }
 
 type LoadStorage interface {
@@ -39,6 +39,9 @@ type InternalDB struct {
 	indexedFieldsMutex  sync.RWMutex   // used for reload
 	cnter               *utils.Counter // used for OrderID for cdr
 	ms                  Marshaler
+	cfgSections         map[string]map[string][]byte // config sections indexed on node ID
+	isDataDB            bool
+	persist             *iDBPersistence // nil if the DB is kept only in memory
 }
 
 // NewInternalDB constructs an InternalDB
@@ -49,6 +52,7 @@ func NewInternalDB(stringIndexedFields, prefixIndexedFields []string, isDataDB b
 		prefixIndexedFields: prefixIndexedFields,
 		cnter:               utils.NewCounter(time.Now().UnixNano(), 0),
 		ms:                  ms,
+		isDataDB:            isDataDB,
 	}
 	return
 }
@@ -67,13 +71,25 @@ func (iDB *InternalDB) SetPrefixIndexedFields(prefixIndexedFields []string) {
 	iDB.indexedFieldsMutex.Unlock()
 }
 
-// Close only to implement Storage interface
-func (iDB *InternalDB) Close() {}
+// Close writes the last snapshot if persistence is enabled
+func (iDB *InternalDB) Close() {
+	if iDB.persist != nil {
+		iDB.closePersistence()
+	}
+}
 
 // Flush clears the cache
-func (iDB *InternalDB) Flush(string) error {
+func (iDB *InternalDB) Flush(string) (err error) {
+	p := iDB.persist
+	if p == nil {
+		Cache.Clear(nil)
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
 	Cache.Clear(nil)
-	return nil
+	p.grpIDs = make(map[string]map[string][]string)
+	return iDB.snapshot() // empty snapshot, discarding the log as well
 }
 
 // SelectDatabase only to implement Storage interface
@@ -103,7 +119,7 @@ func (iDB *InternalDB) RemoveKeysForPrefix(prefix string) (err error) {
 		return
 	}
 	for _, key := range keys {
-		Cache.RemoveWithoutReplicate(utils.CacheReverseDestinations, key,
+		iDB.cacheRemove(utils.CacheReverseDestinations, key,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	}
 	return
@@ -132,7 +148,7 @@ func (iDB *InternalDB) SetVersions(vrs Versions, overwrite bool) (err error) {
 	}
 	x, ok := Cache.Get(utils.CacheVersions, utils.VersionName)
 	if !ok || x == nil {
-		Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, vrs, nil,
+		iDB.cacheSet(utils.CacheVersions, utils.VersionName, vrs, nil,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 		return
 	}
@@ -140,7 +156,7 @@ func (iDB *InternalDB) SetVersions(vrs Versions, overwrite bool) (err error) {
 	for key, val := range vrs {
 		provVrs[key] = val
 	}
-	Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, provVrs, nil,
+	iDB.cacheSet(utils.CacheVersions, utils.VersionName, provVrs, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -156,11 +172,11 @@ func (iDB *InternalDB) RemoveVersions(vrs Versions) (err error) {
 		for key := range vrs {
 			delete(internalVersions, key)
 		}
-		Cache.SetWithoutReplicate(utils.CacheVersions, utils.VersionName, internalVersions, nil,
+		iDB.cacheSet(utils.CacheVersions, utils.VersionName, internalVersions, nil,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 		return
 	}
-	Cache.RemoveWithoutReplicate(utils.CacheVersions, utils.VersionName,
+	iDB.cacheRemove(utils.CacheVersions, utils.VersionName,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -189,7 +205,9 @@ func (iDB *InternalDB) HasDataDrv(category, subject, tenant string) (bool, error
 	case utils.ResourcesPrefix, utils.ResourceProfilesPrefix, utils.StatQueuePrefix,
 		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
 		utils.FilterPrefix, utils.RouteProfilePrefix, utils.AttributeProfilePrefix,
-		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix:
+		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix,
+		utils.ExchangeRateProfilePrefix, utils.RateDeckPrefix, utils.TaxProfilePrefix,
+		utils.LookupTablePrefix:
 		return Cache.HasItem(utils.CachePrefixToInstance[category], utils.ConcatenatedKey(tenant, subject)), nil
 	}
 	return false, errors.New("Unsupported HasData category")
@@ -204,13 +222,13 @@ func (iDB *InternalDB) GetRatingPlanDrv(id string) (rp *RatingPlan, err error) {
 }
 
 func (iDB *InternalDB) SetRatingPlanDrv(rp *RatingPlan) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheRatingPlans, rp.Id, rp, nil,
+	iDB.cacheSet(utils.CacheRatingPlans, rp.Id, rp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRatingPlanDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRatingPlans, id,
+	iDB.cacheRemove(utils.CacheRatingPlans, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -224,13 +242,13 @@ func (iDB *InternalDB) GetRatingProfileDrv(id string) (rp *RatingProfile, err er
 }
 
 func (iDB *InternalDB) SetRatingProfileDrv(rp *RatingProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheRatingProfiles, rp.Id, rp, nil,
+	iDB.cacheSet(utils.CacheRatingProfiles, rp.Id, rp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRatingProfileDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRatingProfiles, id,
+	iDB.cacheRemove(utils.CacheRatingProfiles, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -243,13 +261,13 @@ func (iDB *InternalDB) GetDestinationDrv(key, _ string) (dest *Destination, err
 }
 
 func (iDB *InternalDB) SetDestinationDrv(dest *Destination, transactionID string) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDestinations, dest.Id, dest, nil,
+	iDB.cacheSet(utils.CacheDestinations, dest.Id, dest, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDestinationDrv(destID string, transactionID string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDestinations, destID,
+	iDB.cacheRemove(utils.CacheDestinations, destID,
 		cacheCommit(transactionID), transactionID)
 	return
 }
@@ -266,10 +284,10 @@ func (iDB *InternalDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID st
 	mpRevDst := utils.NewStringSet(revDst)
 	mpRevDst.Remove(dstID)
 	if mpRevDst.Size() != 0 {
-		Cache.SetWithoutReplicate(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil,
+		iDB.cacheSet(utils.CacheReverseDestinations, prfx, mpRevDst.AsSlice(), nil,
 			cacheCommit(transactionID), transactionID)
 	} else {
-		Cache.RemoveWithoutReplicate(utils.CacheReverseDestinations, prfx,
+		iDB.cacheRemove(utils.CacheReverseDestinations, prfx,
 			cacheCommit(transactionID), transactionID)
 	}
 	return
@@ -286,7 +304,7 @@ func (iDB *InternalDB) SetReverseDestinationDrv(destID string, prefixes []string
 		mpRevDst := utils.NewStringSet(revDst)
 		mpRevDst.Add(destID)
 		// for ReverseDestination we will use Groups
-		Cache.SetWithoutReplicate(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil,
+		iDB.cacheSet(utils.CacheReverseDestinations, p, mpRevDst.AsSlice(), nil,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	}
 	return
@@ -309,13 +327,13 @@ func (iDB *InternalDB) GetActionsDrv(id string) (acts Actions, err error) {
 }
 
 func (iDB *InternalDB) SetActionsDrv(id string, acts Actions) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActions, id, acts, nil,
+	iDB.cacheSet(utils.CacheActions, id, acts, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionsDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActions, id,
+	iDB.cacheRemove(utils.CacheActions, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -328,13 +346,13 @@ func (iDB *InternalDB) GetSharedGroupDrv(id string) (sh *SharedGroup, err error)
 }
 
 func (iDB *InternalDB) SetSharedGroupDrv(sh *SharedGroup) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheSharedGroups, sh.Id, sh, nil,
+	iDB.cacheSet(utils.CacheSharedGroups, sh.Id, sh, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveSharedGroupDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheSharedGroups, id,
+	iDB.cacheRemove(utils.CacheSharedGroups, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -347,13 +365,13 @@ func (iDB *InternalDB) GetActionTriggersDrv(id string) (at ActionTriggers, err e
 }
 
 func (iDB *InternalDB) SetActionTriggersDrv(id string, at ActionTriggers) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActionTriggers, id, at, nil,
+	iDB.cacheSet(utils.CacheActionTriggers, id, at, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionTriggersDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionTriggers, id,
+	iDB.cacheRemove(utils.CacheActionTriggers, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -370,7 +388,7 @@ func (iDB *InternalDB) SetActionPlanDrv(key string, ats *ActionPlan,
 	overwrite bool, transactionID string) (err error) {
 	cCommit := cacheCommit(transactionID)
 	if len(ats.ActionTimings) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheActionPlans, key,
+		iDB.cacheRemove(utils.CacheActionPlans, key,
 			cCommit, transactionID)
 		return
 	}
@@ -386,13 +404,13 @@ func (iDB *InternalDB) SetActionPlanDrv(key string, ats *ActionPlan,
 			}
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheActionPlans, key, ats, nil,
+	iDB.cacheSet(utils.CacheActionPlans, key, ats, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionPlanDrv(key string, transactionID string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionPlans, key, cacheCommit(transactionID), transactionID)
+	iDB.cacheRemove(utils.CacheActionPlans, key, cacheCommit(transactionID), transactionID)
 	return
 }
 
@@ -436,14 +454,14 @@ func (iDB *InternalDB) SetAccountActionPlansDrv(acntID string, apIDs []string, o
 			}
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheAccountActionPlans, acntID, apIDs, nil,
+	iDB.cacheSet(utils.CacheAccountActionPlans, acntID, apIDs, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemAccountActionPlansDrv(acntID string, apIDs []string) (err error) {
 	if len(apIDs) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheAccountActionPlans, acntID,
+		iDB.cacheRemove(utils.CacheAccountActionPlans, acntID,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 		return
 	}
@@ -460,11 +478,11 @@ func (iDB *InternalDB) RemAccountActionPlansDrv(acntID string, apIDs []string) (
 		i++
 	}
 	if len(oldaPlIDs) == 0 {
-		Cache.RemoveWithoutReplicate(utils.CacheAccountActionPlans, acntID,
+		iDB.cacheRemove(utils.CacheAccountActionPlans, acntID,
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheAccountActionPlans, acntID, oldaPlIDs, nil,
+	iDB.cacheSet(utils.CacheAccountActionPlans, acntID, oldaPlIDs, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -510,13 +528,13 @@ func (iDB *InternalDB) SetAccountDrv(acc *Account) (err error) {
 		}
 	}
 	acc.UpdateTime = time.Now()
-	Cache.SetWithoutReplicate(utils.CacheAccounts, acc.ID, acc, nil,
+	iDB.cacheSet(utils.CacheAccounts, acc.ID, acc, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAccountDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAccounts, id,
+	iDB.cacheRemove(utils.CacheAccounts, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -529,13 +547,13 @@ func (iDB *InternalDB) GetResourceProfileDrv(tenant, id string) (rp *ResourcePro
 }
 
 func (iDB *InternalDB) SetResourceProfileDrv(rp *ResourceProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheResourceProfiles, rp.TenantID(), rp, nil,
+	iDB.cacheSet(utils.CacheResourceProfiles, rp.TenantID(), rp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveResourceProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheResourceProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -548,13 +566,13 @@ func (iDB *InternalDB) GetResourceDrv(tenant, id string) (r *Resource, err error
 }
 
 func (iDB *InternalDB) SetResourceDrv(r *Resource) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheResources, r.TenantID(), r, nil,
+	iDB.cacheSet(utils.CacheResources, r.TenantID(), r, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveResourceDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheResources, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -568,13 +586,13 @@ func (iDB *InternalDB) GetTimingDrv(id string) (tmg *utils.TPTiming, err error)
 }
 
 func (iDB *InternalDB) SetTimingDrv(timing *utils.TPTiming) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheTimings, timing.ID, timing, nil,
+	iDB.cacheSet(utils.CacheTimings, timing.ID, timing, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveTimingDrv(id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheTimings, id,
+	iDB.cacheRemove(utils.CacheTimings, id,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -596,13 +614,13 @@ func (iDB *InternalDB) GetStatQueueProfileDrv(tenant string, id string) (sq *Sta
 
 }
 func (iDB *InternalDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
+	iDB.cacheSet(utils.CacheStatQueueProfiles, sq.TenantID(), sq, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemStatQueueProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheStatQueueProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -621,12 +639,12 @@ func (iDB *InternalDB) SetStatQueueDrv(ssq *StoredStatQueue, sq *StatQueue) (err
 			return
 		}
 	}
-	Cache.SetWithoutReplicate(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil,
+	iDB.cacheSet(utils.CacheStatQueues, utils.ConcatenatedKey(sq.Tenant, sq.ID), sq, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 func (iDB *InternalDB) RemStatQueueDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheStatQueues, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -640,13 +658,13 @@ func (iDB *InternalDB) GetThresholdProfileDrv(tenant, id string) (tp *ThresholdP
 }
 
 func (iDB *InternalDB) SetThresholdProfileDrv(tp *ThresholdProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil,
+	iDB.cacheSet(utils.CacheThresholdProfiles, tp.TenantID(), tp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemThresholdProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheThresholdProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -660,13 +678,13 @@ func (iDB *InternalDB) GetThresholdDrv(tenant, id string) (th *Threshold, err er
 }
 
 func (iDB *InternalDB) SetThresholdDrv(th *Threshold) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheThresholds, th.TenantID(), th, nil,
+	iDB.cacheSet(utils.CacheThresholds, th.TenantID(), th, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveThresholdDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheThresholds, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -684,13 +702,13 @@ func (iDB *InternalDB) SetFilterDrv(fltr *Filter) (err error) {
 	if err = fltr.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheFilters, fltr.TenantID(), fltr, nil,
+	iDB.cacheSet(utils.CacheFilters, fltr.TenantID(), fltr, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveFilterDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheFilters, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheFilters, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -707,13 +725,13 @@ func (iDB *InternalDB) SetRouteProfileDrv(spp *RouteProfile) (err error) {
 	if err = spp.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheRouteProfiles, spp.TenantID(), spp, nil,
+	iDB.cacheSet(utils.CacheRouteProfiles, spp.TenantID(), spp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRouteProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheRouteProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -730,13 +748,13 @@ func (iDB *InternalDB) SetAttributeProfileDrv(attr *AttributeProfile) (err error
 	if err = attr.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil,
+	iDB.cacheSet(utils.CacheAttributeProfiles, attr.TenantID(), attr, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAttributeProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheAttributeProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -750,13 +768,13 @@ func (iDB *InternalDB) GetChargerProfileDrv(tenant, id string) (ch *ChargerProfi
 }
 
 func (iDB *InternalDB) SetChargerProfileDrv(chr *ChargerProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheChargerProfiles, chr.TenantID(), chr, nil,
+	iDB.cacheSet(utils.CacheChargerProfiles, chr.TenantID(), chr, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveChargerProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheChargerProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -770,13 +788,13 @@ func (iDB *InternalDB) GetDispatcherProfileDrv(tenant, id string) (dpp *Dispatch
 }
 
 func (iDB *InternalDB) SetDispatcherProfileDrv(dpp *DispatcherProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil,
+	iDB.cacheSet(utils.CacheDispatcherProfiles, dpp.TenantID(), dpp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheDispatcherProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -794,7 +812,7 @@ func (iDB *InternalDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[strin
 }
 
 func (iDB *InternalDB) SetLoadIDsDrv(loadIDs map[string]int64) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil,
+	iDB.cacheSet(utils.CacheLoadIDs, utils.LoadIDs, loadIDs, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -808,13 +826,13 @@ func (iDB *InternalDB) GetDispatcherHostDrv(tenant, id string) (dpp *DispatcherH
 }
 
 func (iDB *InternalDB) SetDispatcherHostDrv(dpp *DispatcherHost) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil,
+	iDB.cacheSet(utils.CacheDispatcherHosts, dpp.TenantID(), dpp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheDispatcherHosts, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -831,13 +849,13 @@ func (iDB *InternalDB) SetRateProfileDrv(rpp *RateProfile) (err error) {
 	if err = rpp.Compile(); err != nil {
 		return
 	}
-	Cache.SetWithoutReplicate(utils.CacheRateProfiles, rpp.TenantID(), rpp, nil,
+	iDB.cacheSet(utils.CacheRateProfiles, rpp.TenantID(), rpp, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveRateProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -851,13 +869,13 @@ func (iDB *InternalDB) GetActionProfileDrv(tenant, id string) (ap *ActionProfile
 }
 
 func (iDB *InternalDB) SetActionProfileDrv(ap *ActionProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheActionProfiles, ap.TenantID(), ap, nil,
+	iDB.cacheSet(utils.CacheActionProfiles, ap.TenantID(), ap, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveActionProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheActionProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheActionProfiles, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
@@ -903,10 +921,10 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			if !ok || x == nil {
 				continue
 			}
-			Cache.RemoveWithoutReplicate(idxItmType, dbKey,
+			iDB.cacheRemove(idxItmType, dbKey,
 				cacheCommit(utils.NonTransactional), utils.NonTransactional)
 			key := strings.TrimSuffix(strings.TrimPrefix(dbKey, "tmp_"), utils.ConcatenatedKeySep+transactionID)
-			Cache.SetWithoutReplicate(idxItmType, key, x, []string{tntCtx},
+			iDB.cacheSet(idxItmType, key, x, []string{tntCtx},
 				cacheCommit(utils.NonTransactional), utils.NonTransactional)
 		}
 		return
@@ -917,11 +935,11 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 			dbKey = "tmp_" + utils.ConcatenatedKey(dbKey, transactionID)
 		}
 		if len(indx) == 0 {
-			Cache.SetWithoutReplicate(idxItmType, dbKey, nil, []string{tntCtx},
+			iDB.cacheSet(idxItmType, dbKey, nil, []string{tntCtx},
 				cacheCommit(utils.NonTransactional), utils.NonTransactional)
 			continue
 		}
-		Cache.SetWithoutReplicate(idxItmType, dbKey, indx, []string{tntCtx},
+		iDB.cacheSet(idxItmType, dbKey, indx, []string{tntCtx},
 			cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	}
 	return
@@ -929,10 +947,10 @@ func (iDB *InternalDB) SetIndexesDrv(idxItmType, tntCtx string,
 
 func (iDB *InternalDB) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err error) {
 	if idxKey == utils.EmptyString {
-		Cache.tCache.RemoveGroup(idxItmType, tntCtx, true, utils.EmptyString)
+		iDB.cacheRemoveGroup(idxItmType, tntCtx, true, utils.EmptyString)
 		return
 	}
-	Cache.RemoveWithoutReplicate(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	iDB.cacheRemove(idxItmType, utils.ConcatenatedKey(tntCtx, idxKey), cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
@@ -945,13 +963,240 @@ func (iDB *InternalDB) GetAccountProfileDrv(tenant, id string) (ap *utils.Accoun
 }
 
 func (iDB *InternalDB) SetAccountProfileDrv(ap *utils.AccountProfile) (err error) {
-	Cache.SetWithoutReplicate(utils.CacheAccountProfiles, ap.TenantID(), ap, nil,
+	iDB.cacheSet(utils.CacheAccountProfiles, ap.TenantID(), ap, nil,
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
 
 func (iDB *InternalDB) RemoveAccountProfileDrv(tenant, id string) (err error) {
-	Cache.RemoveWithoutReplicate(utils.CacheAccountProfiles, utils.ConcatenatedKey(tenant, id),
+	iDB.cacheRemove(utils.CacheAccountProfiles, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetExchangeRateProfileDrv(tenant, id string) (erp *utils.ExchangeRateProfile, err error) {
+	x, ok := Cache.Get(utils.CacheExchangeRateProfiles, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*utils.ExchangeRateProfile).Clone(), nil
+}
+
+func (iDB *InternalDB) SetExchangeRateProfileDrv(erp *utils.ExchangeRateProfile) (err error) {
+	iDB.cacheSet(utils.CacheExchangeRateProfiles, erp.TenantID(), erp, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheExchangeRateProfiles, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	x, ok := Cache.Get(utils.CacheRateVolumeCounters, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	cln := *x.(*RateVolumeCounter)
+	return &cln, nil
+}
+
+func (iDB *InternalDB) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	iDB.cacheSet(utils.CacheRateVolumeCounters, rvc.TenantID(), rvc, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheRateVolumeCounters, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRateDeckDrv(tenant, id string) (rd *RateDeck, err error) {
+	x, ok := Cache.Get(utils.CacheRateDecks, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*RateDeck), nil
+}
+
+func (iDB *InternalDB) SetRateDeckDrv(rd *RateDeck) (err error) {
+	if err = rd.Compile(); err != nil {
+		return
+	}
+	iDB.cacheSet(utils.CacheRateDecks, rd.TenantID(), rd, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRateDeckDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheRateDecks, utils.ConcatenatedKey(tenant, id),
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return
 }
+
+func (iDB *InternalDB) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	x, ok := Cache.Get(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*TaxProfile), nil
+}
+
+func (iDB *InternalDB) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	iDB.cacheSet(utils.CacheTaxProfiles, tp.TenantID(), tp, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	x, ok := Cache.Get(utils.CacheLookupTables, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*LookupTable), nil
+}
+
+func (iDB *InternalDB) SetLookupTableDrv(lt *LookupTable) (err error) {
+	iDB.cacheSet(utils.CacheLookupTables, lt.TenantID(), lt, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveLookupTableDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheLookupTables, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetProfileHitsDrv(tenant, id string) (ph *ProfileHits, err error) {
+	x, ok := Cache.Get(utils.CacheProfileHits, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	cln := *x.(*ProfileHits)
+	return &cln, nil
+}
+
+func (iDB *InternalDB) SetProfileHitsDrv(ph *ProfileHits) (err error) {
+	iDB.cacheSet(utils.CacheProfileHits, ph.TenantID(), ph, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveProfileHitsDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheProfileHits, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetRateProfileVersionsDrv(tenant, id string) (rpvs *RateProfileVersions, err error) {
+	x, ok := Cache.Get(utils.CacheRateProfileVersions, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	rpvs = x.(*RateProfileVersions)
+	// return a copy of the list so the stored history is not altered when adding versions
+	return &RateProfileVersions{
+		Tenant:   rpvs.Tenant,
+		ID:       rpvs.ID,
+		Versions: append([]*RateProfileVersion{}, rpvs.Versions...),
+	}, nil
+}
+
+func (iDB *InternalDB) SetRateProfileVersionsDrv(rpvs *RateProfileVersions) (err error) {
+	if err = rpvs.Compile(); err != nil {
+		return
+	}
+	iDB.cacheSet(utils.CacheRateProfileVersions, rpvs.TenantID(), rpvs, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveRateProfileVersionsDrv(tenant, id string) (err error) {
+	iDB.cacheRemove(utils.CacheRateProfileVersions, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetLoadVersionDrv(ldrID string, version int) (lv *LoadVersion, err error) {
+	x, ok := Cache.Get(utils.CacheLoadVersions, LoadVersionID(ldrID, version))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*LoadVersion), nil
+}
+
+func (iDB *InternalDB) SetLoadVersionDrv(lv *LoadVersion) (err error) {
+	iDB.cacheSet(utils.CacheLoadVersions, lv.LoadVersionID(), lv, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveLoadVersionDrv(ldrID string, version int) (err error) {
+	iDB.cacheRemove(utils.CacheLoadVersions, LoadVersionID(ldrID, version),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetActiveLoadVersionDrv(ldrID string) (version int, err error) {
+	x, ok := Cache.Get(utils.CacheActiveLoadVersions, ldrID)
+	if !ok || x == nil {
+		return 0, utils.ErrNotFound
+	}
+	return x.(int), nil
+}
+
+func (iDB *InternalDB) SetActiveLoadVersionDrv(ldrID string, version int) (err error) {
+	iDB.cacheSet(utils.CacheActiveLoadVersions, ldrID, version, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// GetConfigSectionsDrv returns the config sections stored for the node, the missing ones are ignored
+func (iDB *InternalDB) GetConfigSectionsDrv(nodeID string, sectionIDs []string) (sectionsData map[string][]byte, err error) {
+	iDB.mu.RLock()
+	defer iDB.mu.RUnlock()
+	sectionsData = make(map[string][]byte)
+	for _, sectionID := range sectionIDs {
+		if data, has := iDB.cfgSections[nodeID][sectionID]; has {
+			sectionsData[sectionID] = data
+		}
+	}
+	return
+}
+
+// SetConfigSectionsDrv stores the config sections for the node
+func (iDB *InternalDB) SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) (err error) {
+	iDB.mu.Lock()
+	defer iDB.mu.Unlock()
+	if iDB.cfgSections == nil {
+		iDB.cfgSections = make(map[string]map[string][]byte)
+	}
+	if _, has := iDB.cfgSections[nodeID]; !has {
+		iDB.cfgSections[nodeID] = make(map[string][]byte)
+	}
+	for sectionID, data := range sectionsData {
+		iDB.cfgSections[nodeID][sectionID] = data
+	}
+	return
+}
+
+// RemoveConfigSectionsDrv removes the config sections stored for the node
+func (iDB *InternalDB) RemoveConfigSectionsDrv(nodeID string, sectionIDs []string) (err error) {
+	iDB.mu.Lock()
+	defer iDB.mu.Unlock()
+	for _, sectionID := range sectionIDs {
+		delete(iDB.cfgSections[nodeID], sectionID)
+	}
+	return
+}
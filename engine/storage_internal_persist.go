@@ -0,0 +1,571 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// operations written in the append-only log
+const (
+	iDBOpSet         = "*set"
+	iDBOpRemove      = "*remove"
+	iDBOpRemoveGroup = "*remove_group"
+
+	iDBSnapshotExt = ".snapshot"
+	iDBLogExt      = ".aof"
+)
+
+var (
+	// persistentIDBs are the internal databases with persistence enabled
+	persistentIDBs    = make(map[*InternalDB]struct{})
+	persistentIDBsMux sync.RWMutex
+
+	errIDBCorruptedRecord = errors.New("corrupted record")
+
+	// iDBItemTypes are the types stored by InternalDB within each cache partition
+	iDBItemTypes = map[string]reflect.Type{
+		utils.CacheVersions:             reflect.TypeOf(Versions{}),
+		utils.CacheDestinations:         reflect.TypeOf(new(Destination)),
+		utils.CacheReverseDestinations:  reflect.TypeOf([]string{}),
+		utils.CacheRatingPlans:          reflect.TypeOf(new(RatingPlan)),
+		utils.CacheRatingProfiles:       reflect.TypeOf(new(RatingProfile)),
+		utils.CacheActions:              reflect.TypeOf(Actions{}),
+		utils.CacheActionPlans:          reflect.TypeOf(new(ActionPlan)),
+		utils.CacheAccountActionPlans:   reflect.TypeOf([]string{}),
+		utils.CacheActionTriggers:       reflect.TypeOf(ActionTriggers{}),
+		utils.CacheSharedGroups:         reflect.TypeOf(new(SharedGroup)),
+		utils.CacheTimings:              reflect.TypeOf(new(utils.TPTiming)),
+		utils.CacheAccounts:             reflect.TypeOf(new(Account)),
+		utils.CacheResourceProfiles:     reflect.TypeOf(new(ResourceProfile)),
+		utils.CacheResources:            reflect.TypeOf(new(Resource)),
+		utils.CacheStatQueueProfiles:    reflect.TypeOf(new(StatQueueProfile)),
+		utils.CacheStatQueues:           reflect.TypeOf(new(StoredStatQueue)), // converted to StatQueue
+		utils.CacheThresholdProfiles:    reflect.TypeOf(new(ThresholdProfile)),
+		utils.CacheThresholds:           reflect.TypeOf(new(Threshold)),
+		utils.CacheFilters:              reflect.TypeOf(new(Filter)),
+		utils.CacheRouteProfiles:        reflect.TypeOf(new(RouteProfile)),
+		utils.CacheAttributeProfiles:    reflect.TypeOf(new(AttributeProfile)),
+		utils.CacheChargerProfiles:      reflect.TypeOf(new(ChargerProfile)),
+		utils.CacheDispatcherProfiles:   reflect.TypeOf(new(DispatcherProfile)),
+		utils.CacheDispatcherHosts:      reflect.TypeOf(new(DispatcherHost)),
+		utils.CacheRateProfiles:         reflect.TypeOf(new(RateProfile)),
+		utils.CacheActionProfiles:       reflect.TypeOf(new(ActionProfile)),
+		utils.CacheAccountProfiles:      reflect.TypeOf(new(utils.AccountProfile)),
+		utils.CacheExchangeRateProfiles: reflect.TypeOf(new(utils.ExchangeRateProfile)),
+		utils.CacheRateVolumeCounters:   reflect.TypeOf(new(RateVolumeCounter)),
+		utils.CacheProfileHits:          reflect.TypeOf(new(ProfileHits)),
+		utils.CacheRateDecks:            reflect.TypeOf(new(RateDeck)),
+		utils.CacheTaxProfiles:          reflect.TypeOf(new(TaxProfile)),
+		utils.CacheLookupTables:         reflect.TypeOf(new(LookupTable)),
+		utils.CacheRateProfileVersions:  reflect.TypeOf(new(RateProfileVersions)),
+		utils.CacheLoadVersions:         reflect.TypeOf(new(LoadVersion)),
+		utils.CacheActiveLoadVersions:   reflect.TypeOf(0),
+		utils.CacheLoadIDs:              reflect.TypeOf(map[string]int64{}),
+
+		utils.CacheTBLTPTimings:          reflect.TypeOf(new(utils.ApierTPTiming)),
+		utils.CacheTBLTPDestinations:     reflect.TypeOf(new(utils.TPDestination)),
+		utils.CacheTBLTPRates:            reflect.TypeOf(new(utils.TPRateRALs)),
+		utils.CacheTBLTPDestinationRates: reflect.TypeOf(new(utils.TPDestinationRate)),
+		utils.CacheTBLTPRatingPlans:      reflect.TypeOf(new(utils.TPRatingPlan)),
+		utils.CacheTBLTPRatingProfiles:   reflect.TypeOf(new(utils.TPRatingProfile)),
+		utils.CacheTBLTPSharedGroups:     reflect.TypeOf(new(utils.TPSharedGroups)),
+		utils.CacheTBLTPActions:          reflect.TypeOf(new(utils.TPActions)),
+		utils.CacheTBLTPActionPlans:      reflect.TypeOf(new(utils.TPActionPlan)),
+		utils.CacheTBLTPActionTriggers:   reflect.TypeOf(new(utils.TPActionTriggers)),
+		utils.CacheTBLTPAccountActions:   reflect.TypeOf(new(utils.TPAccountActions)),
+		utils.CacheTBLTPResources:        reflect.TypeOf(new(utils.TPResourceProfile)),
+		utils.CacheTBLTPStats:            reflect.TypeOf(new(utils.TPStatProfile)),
+		utils.CacheTBLTPThresholds:       reflect.TypeOf(new(utils.TPThresholdProfile)),
+		utils.CacheTBLTPFilters:          reflect.TypeOf(new(utils.TPFilterProfile)),
+		utils.CacheTBLTPRoutes:           reflect.TypeOf(new(utils.TPRouteProfile)),
+		utils.CacheTBLTPAttributes:       reflect.TypeOf(new(utils.TPAttributeProfile)),
+		utils.CacheTBLTPChargers:         reflect.TypeOf(new(utils.TPChargerProfile)),
+		utils.CacheTBLTPDispatchers:      reflect.TypeOf(new(utils.TPDispatcherProfile)),
+		utils.CacheTBLTPDispatcherHosts:  reflect.TypeOf(new(utils.TPDispatcherHost)),
+		utils.CacheTBLTPRateProfiles:     reflect.TypeOf(new(utils.TPRateProfile)),
+		utils.CacheTBLTPActionProfiles:   reflect.TypeOf(new(utils.TPActionProfile)),
+		utils.CacheTBLTPAccountProfiles:  reflect.TypeOf(new(utils.TPAccountProfile)),
+		utils.CacheCDRsTBL:               reflect.TypeOf(new(CDR)),
+		utils.CacheSessionCostsTBL:       reflect.TypeOf(new(SMCost)),
+		utils.CacheInvoicesTBL:           reflect.TypeOf(new(Invoice)),
+	}
+)
+
+// iDBRecord is one entry of the snapshot or of the append-only log
+type iDBRecord struct {
+	Op       string
+	ChID     string
+	ItmID    string
+	GroupIDs []string
+	Value    []byte // marshaled item, empty for nil
+}
+
+// iDBPersistence keeps the InternalDB on disk as a snapshot followed by an append-only log
+// with the writes done after the snapshot was taken
+type iDBPersistence struct {
+	sync.Mutex   // serializes the writes so the log follows the cache
+	path         string
+	name         string // data_db or stor_db, used as file name
+	fsync        string // <*always|*none|$interval>
+	aof          *os.File
+	grpIDs       map[string]map[string][]string // groups of the items, not exported by the cache
+	stopInterval chan struct{}
+	closed       bool
+}
+
+func (p *iDBPersistence) snapshotPath() string {
+	return filepath.Join(p.path, p.name+iDBSnapshotExt)
+}
+
+func (p *iDBPersistence) logPath() string {
+	return filepath.Join(p.path, p.name+iDBLogExt)
+}
+
+// setGroupIDs tracks the groups of the item so they can be written in the snapshot
+func (p *iDBPersistence) setGroupIDs(chID, itmID string, grpIDs []string) {
+	if len(grpIDs) == 0 {
+		delete(p.grpIDs[chID], itmID)
+		return
+	}
+	if _, has := p.grpIDs[chID]; !has {
+		p.grpIDs[chID] = make(map[string][]string)
+	}
+	p.grpIDs[chID][itmID] = grpIDs
+}
+
+// enablePersistence configures persistence out of data_db/stor_db opts, no path disables it
+func (iDB *InternalDB) enablePersistence(opts map[string]interface{}) (err error) {
+	path := utils.IfaceAsString(opts[utils.InternalDBPathCfg])
+	if path == utils.EmptyString {
+		return
+	}
+	var snapshotIntvl time.Duration
+	if snapshotIntvl, err = utils.IfaceAsDuration(opts[utils.InternalDBSnapshotIntervalCfg]); err != nil {
+		return
+	}
+	fsync := utils.FirstNonEmpty(utils.IfaceAsString(opts[utils.InternalDBFsyncCfg]), utils.MetaNone)
+	var fsyncIntvl time.Duration
+	if fsync != utils.MetaAlways && fsync != utils.MetaNone {
+		if fsyncIntvl, err = utils.ParseDurationWithNanosecs(fsync); err != nil {
+			return fmt.Errorf("invalid %s: <%s>", utils.InternalDBFsyncCfg, fsync)
+		}
+	}
+	if err = os.MkdirAll(path, 0700); err != nil { // the dumps hold account and CDR data
+		return
+	}
+	p := &iDBPersistence{
+		path:         path,
+		name:         utils.StorDB,
+		fsync:        fsync,
+		grpIDs:       make(map[string]map[string][]string),
+		stopInterval: make(chan struct{}),
+	}
+	if iDB.isDataDB {
+		p.name = utils.DataDB
+	}
+	// a crash can leave a partial record at the end of the log, cut it so we can append
+	var validLen int64
+	if validLen, err = iDB.readRecords(p.name, p.logPath(), nil); err != nil {
+		return
+	}
+	if err = os.Truncate(p.logPath(), validLen); err != nil && !os.IsNotExist(err) {
+		return
+	}
+	if p.aof, err = os.OpenFile(p.logPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600); err != nil {
+		return
+	}
+	iDB.persist = p
+	if snapshotIntvl > 0 {
+		go iDB.snapshotLoop(snapshotIntvl, p.stopInterval)
+	}
+	if fsyncIntvl > 0 {
+		go p.fsyncLoop(fsyncIntvl)
+	}
+	persistentIDBsMux.Lock()
+	persistentIDBs[iDB] = struct{}{}
+	persistentIDBsMux.Unlock()
+	return
+}
+
+// partitions returns the cache partitions owned by this DB
+func (iDB *InternalDB) partitions() (chIDs []string) {
+	chIDs = []string{utils.CacheVersions}
+	if !iDB.isDataDB {
+		for _, chID := range utils.CacheStorDBPartitions {
+			chIDs = append(chIDs, chID)
+		}
+		return
+	}
+	for chID := range utils.CacheInstanceToPrefix {
+		if chID != utils.MetaAPIBan {
+			chIDs = append(chIDs, chID)
+		}
+	}
+	return
+}
+
+// encodeItem marshals the item as stored in one of the partitions
+func (iDB *InternalDB) encodeItem(chID string, itm interface{}) (b []byte, err error) {
+	switch val := itm.(type) {
+	case nil:
+		return
+	case utils.StringSet: // indexes
+		return iDB.ms.Marshal(val.AsSlice())
+	case *StatQueue:
+		var ssq *StoredStatQueue
+		if ssq, err = NewStoredStatQueue(val, iDB.ms); err != nil {
+			return
+		}
+		return iDB.ms.Marshal(ssq)
+	}
+	return iDB.ms.Marshal(itm)
+}
+
+// decodeItem is the reverse of encodeItem
+func (iDB *InternalDB) decodeItem(chID string, b []byte) (itm interface{}, err error) {
+	if len(b) == 0 {
+		return
+	}
+	if _, isIdx := utils.CacheIndexesToPrefix[chID]; isIdx {
+		var idx []string
+		if err = iDB.ms.Unmarshal(b, &idx); err != nil {
+			return
+		}
+		return utils.NewStringSet(idx), nil
+	}
+	typ, has := iDBItemTypes[chID]
+	if !has {
+		return nil, fmt.Errorf("unsupported partition <%s>", chID)
+	}
+	val := reflect.New(typ)
+	if err = iDB.ms.Unmarshal(b, val.Interface()); err != nil {
+		return
+	}
+	itm = val.Elem().Interface()
+	if ssq, isSSQ := itm.(*StoredStatQueue); isSSQ {
+		return ssq.AsStatQueue(iDB.ms)
+	}
+	return
+}
+
+// writeRecord appends the record with its length in front
+func (iDB *InternalDB) writeRecord(w io.Writer, rec *iDBRecord) (err error) {
+	var b []byte
+	if b, err = iDB.ms.Marshal(rec); err != nil {
+		return
+	}
+	buf := make([]byte, 4, 4+len(b))
+	binary.BigEndian.PutUint32(buf, uint32(len(b)))
+	_, err = w.Write(append(buf, b...))
+	return
+}
+
+// readRecords passes the records within file to f, returning the length of the file holding complete records
+func (iDB *InternalDB) readRecords(dbName, path string, f func(*iDBRecord) error) (validLen int64, err error) {
+	fl, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer fl.Close()
+	rdr := bufio.NewReader(fl)
+	hdr := make([]byte, 4)
+	for {
+		if _, err = io.ReadFull(rdr, hdr); err != nil {
+			break
+		}
+		b := make([]byte, binary.BigEndian.Uint32(hdr))
+		if _, err = io.ReadFull(rdr, b); err != nil {
+			break
+		}
+		rec := new(iDBRecord)
+		if err = iDB.ms.Unmarshal(b, rec); err != nil {
+			err = errIDBCorruptedRecord
+			break
+		}
+		if f != nil {
+			if err = f(rec); err != nil {
+				return
+			}
+		}
+		validLen += int64(len(hdr) + len(b))
+	}
+	if err == io.EOF {
+		return validLen, nil
+	}
+	utils.Logger.Warning(fmt.Sprintf("<%s> ignoring the content of <%s> after offset %d, err: %s",
+		dbName, path, validLen, err.Error()))
+	return validLen, nil
+}
+
+// appendRecord writes the change to the log, called with persistence locked
+func (iDB *InternalDB) appendRecord(rec *iDBRecord) {
+	p := iDB.persist
+	if p.closed {
+		return
+	}
+	if err := iDB.writeRecord(p.aof, rec); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> cannot write %s of <%s> from partition <%s> to <%s>, err: %s",
+			p.name, rec.Op, rec.ItmID, rec.ChID, p.logPath(), err.Error()))
+		return
+	}
+	if p.fsync == utils.MetaAlways {
+		p.aof.Sync()
+	}
+}
+
+// cacheSet stores the item within Cache, logging it if persistence is enabled
+func (iDB *InternalDB) cacheSet(chID, itmID string, value interface{},
+	groupIDs []string, commit bool, transID string) {
+	p := iDB.persist
+	if p == nil {
+		Cache.SetWithoutReplicate(chID, itmID, value, groupIDs, commit, transID)
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	Cache.SetWithoutReplicate(chID, itmID, value, groupIDs, commit, transID)
+	p.setGroupIDs(chID, itmID, groupIDs)
+	b, err := iDB.encodeItem(chID, value)
+	if err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> cannot marshal <%s> from partition <%s>, err: %s",
+			p.name, itmID, chID, err.Error()))
+		return
+	}
+	iDB.appendRecord(&iDBRecord{Op: iDBOpSet, ChID: chID, ItmID: itmID, GroupIDs: groupIDs, Value: b})
+}
+
+// cacheRemove removes the item out of Cache, logging it if persistence is enabled
+func (iDB *InternalDB) cacheRemove(chID, itmID string, commit bool, transID string) {
+	p := iDB.persist
+	if p == nil {
+		Cache.RemoveWithoutReplicate(chID, itmID, commit, transID)
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	Cache.RemoveWithoutReplicate(chID, itmID, commit, transID)
+	p.setGroupIDs(chID, itmID, nil)
+	iDB.appendRecord(&iDBRecord{Op: iDBOpRemove, ChID: chID, ItmID: itmID})
+}
+
+// cacheRemoveGroup removes the items of a group out of Cache, logging it if persistence is enabled
+func (iDB *InternalDB) cacheRemoveGroup(chID, grpID string, commit bool, transID string) {
+	p := iDB.persist
+	if p == nil {
+		Cache.tCache.RemoveGroup(chID, grpID, commit, transID)
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	for _, itmID := range Cache.tCache.GetGroupItemIDs(chID, grpID) {
+		p.setGroupIDs(chID, itmID, nil)
+	}
+	Cache.tCache.RemoveGroup(chID, grpID, commit, transID)
+	iDB.appendRecord(&iDBRecord{Op: iDBOpRemoveGroup, ChID: chID, ItmID: grpID})
+}
+
+// Snapshot writes the content of the DB on disk and truncates the append-only log
+func (iDB *InternalDB) Snapshot() (err error) {
+	p := iDB.persist
+	if p == nil {
+		return utils.ErrNotImplemented
+	}
+	p.Lock()
+	defer p.Unlock()
+	if p.closed {
+		return
+	}
+	return iDB.snapshot()
+}
+
+// snapshot is called with persistence locked
+func (iDB *InternalDB) snapshot() (err error) {
+	p := iDB.persist
+	tmpPath := p.snapshotPath() + ".tmp"
+	var fl *os.File
+	if fl, err = os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600); err != nil {
+		return
+	}
+	wrtr := bufio.NewWriter(fl)
+	for _, chID := range iDB.partitions() {
+		for _, itmID := range Cache.GetItemIDs(chID, utils.EmptyString) {
+			x, ok := Cache.Get(chID, itmID)
+			if !ok || x == nil { // nil is also cached for the items not found
+				continue
+			}
+			var b []byte
+			if b, err = iDB.encodeItem(chID, x); err != nil {
+				fl.Close()
+				return
+			}
+			if err = iDB.writeRecord(wrtr, &iDBRecord{Op: iDBOpSet, ChID: chID, ItmID: itmID,
+				GroupIDs: p.grpIDs[chID][itmID], Value: b}); err != nil {
+				fl.Close()
+				return
+			}
+		}
+	}
+	if err = wrtr.Flush(); err != nil {
+		fl.Close()
+		return
+	}
+	if err = fl.Sync(); err != nil {
+		fl.Close()
+		return
+	}
+	if err = fl.Close(); err != nil {
+		return
+	}
+	if err = os.Rename(tmpPath, p.snapshotPath()); err != nil {
+		return
+	}
+	// the snapshot holds now everything written in the log
+	if err = p.aof.Truncate(0); err != nil {
+		return
+	}
+	return p.aof.Sync()
+}
+
+// Restore loads the snapshot and replays the append-only log within Cache
+func (iDB *InternalDB) Restore() (err error) {
+	p := iDB.persist
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	apply := func(rec *iDBRecord) (err error) {
+		switch rec.Op {
+		case iDBOpSet:
+			var itm interface{}
+			if itm, err = iDB.decodeItem(rec.ChID, rec.Value); err != nil {
+				return fmt.Errorf("cannot restore <%s> from partition <%s>, err: %s",
+					rec.ItmID, rec.ChID, err.Error())
+			}
+			Cache.SetWithoutReplicate(rec.ChID, rec.ItmID, itm, rec.GroupIDs, true, utils.NonTransactional)
+			p.setGroupIDs(rec.ChID, rec.ItmID, rec.GroupIDs)
+		case iDBOpRemove:
+			Cache.RemoveWithoutReplicate(rec.ChID, rec.ItmID, true, utils.NonTransactional)
+			p.setGroupIDs(rec.ChID, rec.ItmID, nil)
+		case iDBOpRemoveGroup:
+			for _, itmID := range Cache.tCache.GetGroupItemIDs(rec.ChID, rec.ItmID) {
+				p.setGroupIDs(rec.ChID, itmID, nil)
+			}
+			Cache.tCache.RemoveGroup(rec.ChID, rec.ItmID, true, utils.NonTransactional)
+		}
+		return
+	}
+	if _, err = iDB.readRecords(p.name, p.snapshotPath(), apply); err != nil {
+		return
+	}
+	_, err = iDB.readRecords(p.name, p.logPath(), apply)
+	return
+}
+
+// closePersistence takes the last snapshot and closes the files
+func (iDB *InternalDB) closePersistence() {
+	p := iDB.persist
+	persistentIDBsMux.Lock()
+	delete(persistentIDBs, iDB)
+	persistentIDBsMux.Unlock()
+	close(p.stopInterval)
+	p.Lock()
+	defer p.Unlock()
+	if err := iDB.snapshot(); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> cannot write snapshot on <%s>, err: %s",
+			p.name, p.snapshotPath(), err.Error()))
+	}
+	p.aof.Close()
+	p.closed = true
+}
+
+func (iDB *InternalDB) snapshotLoop(intvl time.Duration, stopChan chan struct{}) {
+	tm := time.NewTicker(intvl)
+	defer tm.Stop()
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-tm.C:
+			if err := iDB.Snapshot(); err != nil {
+				utils.Logger.Err(fmt.Sprintf("<%s> cannot write snapshot on <%s>, err: %s",
+					iDB.persist.name, iDB.persist.snapshotPath(), err.Error()))
+			}
+		}
+	}
+}
+
+func (p *iDBPersistence) fsyncLoop(intvl time.Duration) {
+	tm := time.NewTicker(intvl)
+	defer tm.Stop()
+	for {
+		select {
+		case <-p.stopInterval:
+			return
+		case <-tm.C:
+			p.Lock()
+			if !p.closed {
+				p.aof.Sync()
+			}
+			p.Unlock()
+		}
+	}
+}
+
+// SnapshotInternalDBs writes to disk the internal databases having persistence enabled
+func SnapshotInternalDBs() (err error) {
+	persistentIDBsMux.RLock()
+	defer persistentIDBsMux.RUnlock()
+	if len(persistentIDBs) == 0 {
+		return utils.ErrNotImplemented
+	}
+	for iDB := range persistentIDBs {
+		if err = iDB.Snapshot(); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RestoreInternalDBs loads the persisted internal databases within Cache, called once the Cache is created
+func RestoreInternalDBs() (err error) {
+	persistentIDBsMux.RLock()
+	defer persistentIDBsMux.RUnlock()
+	for iDB := range persistentIDBs {
+		if err = iDB.Restore(); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,272 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testIDBPersistOpts(path string) map[string]interface{} {
+	return map[string]interface{}{
+		utils.InternalDBPathCfg:             path,
+		utils.InternalDBSnapshotIntervalCfg: "0",
+		utils.InternalDBFsyncCfg:            utils.MetaAlways,
+	}
+}
+
+// testIDBCache uses a new cache for the internal DB, restoring the old one at the end
+func testIDBCache(t *testing.T) {
+	oldCache := Cache
+	Cache = NewCacheS(config.CgrConfig(), nil, nil)
+	t.Cleanup(func() { Cache = oldCache })
+}
+
+// testIDBCrash drops the DB without the final snapshot
+func testIDBCrash(iDB *InternalDB) {
+	persistentIDBsMux.Lock()
+	delete(persistentIDBs, iDB)
+	persistentIDBsMux.Unlock()
+	close(iDB.persist.stopInterval)
+	iDB.persist.aof.Close()
+	Cache = NewCacheS(config.CgrConfig(), nil, nil)
+}
+
+func TestIDBPersistRestoreLog(t *testing.T) {
+	testIDBCache(t)
+	path := t.TempDir()
+	iDB := NewInternalDB(nil, nil, true)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	acnt := &Account{
+		ID: "cgrates.org:1001",
+		BalanceMap: map[string]Balances{
+			utils.MetaMonetary: {{ID: "MONETARY", Value: 10}},
+		},
+	}
+	if err := iDB.SetAccountDrv(acnt); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.RemoveAccountDrv("cgrates.org:1002"); err != nil {
+		t.Fatal(err)
+	}
+	sq := &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ1",
+		SQItems:   []SQItem{{EventID: "ev1"}},
+		SQMetrics: map[string]StatMetric{utils.MetaTCD: &StatTCD{Sum: time.Minute, Count: 1, Events: map[string]*DurationWithCompress{"ev1": {Duration: time.Minute, CompressFactor: 1}}}},
+	}
+	if err := iDB.SetStatQueueDrv(nil, sq); err != nil {
+		t.Fatal(err)
+	}
+	idxs := map[string]utils.StringSet{"*string:*req.Account:1001": utils.NewStringSet([]string{"ATTR1"})}
+	if err := iDB.SetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions", idxs, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	testIDBCrash(iDB)
+
+	iDB = NewInternalDB(nil, nil, true)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if err := RestoreInternalDBs(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := iDB.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(acnt.BalanceMap[utils.MetaMonetary][0].Value, rcv.BalanceMap[utils.MetaMonetary][0].Value) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(acnt), utils.ToJSON(rcv))
+	}
+	if _, err := iDB.GetAccountDrv("cgrates.org:1002"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if rcv, err := iDB.GetStatQueueDrv("cgrates.org", "SQ1"); err != nil {
+		t.Fatal(err)
+	} else if val := rcv.SQMetrics[utils.MetaTCD].GetValue(config.CgrConfig().GeneralCfg().RoundingDecimals); val != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, val)
+	}
+	if rcv, err := iDB.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:*sessions", utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(idxs, rcv) { // restored within the group so all of the indexes are returned
+		t.Errorf("Expected %+v, received %+v", idxs, rcv)
+	}
+}
+
+func TestIDBPersistSnapshot(t *testing.T) {
+	testIDBCache(t)
+	path := t.TempDir()
+	iDB := NewInternalDB(nil, nil, false)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	cdr := &CDR{
+		CGRID:       "CGRID1",
+		RunID:       utils.MetaDefault,
+		OriginID:    "orig1",
+		Tenant:      "cgrates.org",
+		Account:     "1001",
+		Usage:       time.Minute,
+		Cost:        0.6,
+		AnswerTime:  time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC),
+		ExtraFields: map[string]string{},
+	}
+	if err := iDB.SetCDR(cdr, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := SnapshotInternalDBs(); err != nil {
+		t.Fatal(err)
+	}
+	if fi, err := os.Stat(filepath.Join(path, utils.StorDB+iDBLogExt)); err != nil {
+		t.Fatal(err)
+	} else if fi.Size() != 0 {
+		t.Errorf("Expected the log truncated, has %d bytes", fi.Size())
+	} else if fi.Mode().Perm() != 0600 {
+		t.Errorf("Expected the log readable only by the owner, received %v", fi.Mode().Perm())
+	}
+	if fi, err := os.Stat(iDB.persist.snapshotPath()); err != nil {
+		t.Fatal(err)
+	} else if fi.Mode().Perm() != 0600 {
+		t.Errorf("Expected the snapshot readable only by the owner, received %v", fi.Mode().Perm())
+	}
+	tpTiming := &utils.ApierTPTiming{TPid: "TP1", ID: "ALWAYS", Years: utils.MetaAny}
+	if err := iDB.SetTPTimings([]*utils.ApierTPTiming{tpTiming}); err != nil {
+		t.Fatal(err)
+	}
+	testIDBCrash(iDB)
+
+	iDB = NewInternalDB(nil, nil, false)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if cdrs, _, err := iDB.GetCDRs(&utils.CDRsFilter{Accounts: []string{"1001"}}, false); err != nil {
+		t.Fatal(err)
+	} else if len(cdrs) != 1 || cdrs[0].Cost != cdr.Cost || !cdrs[0].AnswerTime.Equal(cdr.AnswerTime) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(cdr), utils.ToJSON(cdrs))
+	}
+	if rcv, err := iDB.GetTPTimings("TP1", "ALWAYS"); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual([]*utils.ApierTPTiming{tpTiming}, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(tpTiming), utils.ToJSON(rcv))
+	}
+	iDB.Close() // writes the last snapshot
+	if _, has := persistentIDBs[iDB]; has {
+		t.Error("DB not unregistered on close")
+	}
+	if err := iDB.Snapshot(); err != nil {
+		t.Errorf("Expected no error after close, received %v", err)
+	}
+	Cache = NewCacheS(config.CgrConfig(), nil, nil)
+	iDB = NewInternalDB(nil, nil, false)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if err := iDB.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := iDB.GetTPTimings("TP1", "ALWAYS"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIDBPersistPartialRecord(t *testing.T) {
+	testIDBCache(t)
+	path := t.TempDir()
+	iDB := NewInternalDB(nil, nil, true)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	if err := iDB.SetLoadIDsDrv(map[string]int64{utils.CacheAccounts: 1}); err != nil {
+		t.Fatal(err)
+	}
+	testIDBCrash(iDB)
+	logPath := filepath.Join(path, utils.DataDB+iDBLogExt)
+	fi, err := os.Stat(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the write interrupted by crash
+	fl, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = fl.Write([]byte{0, 0, 1, 0, 'x'}); err != nil {
+		t.Fatal(err)
+	}
+	fl.Close()
+
+	iDB = NewInternalDB(nil, nil, true)
+	if err := iDB.enablePersistence(testIDBPersistOpts(path)); err != nil {
+		t.Fatal(err)
+	}
+	defer iDB.Close()
+	if newFi, err := os.Stat(logPath); err != nil {
+		t.Fatal(err)
+	} else if newFi.Size() != fi.Size() {
+		t.Errorf("Expected log truncated to %d, has %d", fi.Size(), newFi.Size())
+	}
+	if err := iDB.Restore(); err != nil {
+		t.Fatal(err)
+	}
+	exp := map[string]int64{utils.CacheAccounts: 1}
+	if rcv, err := iDB.GetItemLoadIDsDrv(utils.EmptyString); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+}
+
+func TestIDBPersistOpts(t *testing.T) {
+	iDB := NewInternalDB(nil, nil, true)
+	if err := iDB.enablePersistence(map[string]interface{}{utils.InternalDBPathCfg: utils.EmptyString}); err != nil {
+		t.Error(err)
+	} else if iDB.persist != nil {
+		t.Error("persistence enabled without path")
+	}
+	if err := iDB.Snapshot(); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	opts := testIDBPersistOpts(t.TempDir())
+	opts[utils.InternalDBFsyncCfg] = "*sometimes"
+	expErr := "invalid internal_db_fsync: <*sometimes>"
+	if err := iDB.enablePersistence(opts); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+	cfg := config.NewDefaultCGRConfig()
+	if db, err := NewDataDBConn(utils.INTERNAL, utils.EmptyString, utils.EmptyString, utils.EmptyString,
+		utils.EmptyString, utils.EmptyString, utils.MsgPack, cfg.DataDbCfg().Opts); err != nil {
+		t.Error(err)
+	} else if db.(*InternalDB).persist != nil {
+		t.Error("persistence enabled by default")
+	}
+}
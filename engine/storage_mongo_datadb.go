@@ -80,6 +80,16 @@ const (
 	ColApp  = "action_profiles"
 	ColLID  = "load_ids"
 	ColAnp  = "account_profiles"
+	ColErp  = "exchange_rate_profiles"
+	ColRvc  = "rate_volume_counters"
+	ColRdk  = "rate_decks"
+	ColTxp  = "tax_profiles"
+	ColLkt  = "lookup_tables"
+	ColPhs  = "profile_hits"
+	ColRpv  = "rate_profile_versions"
+	ColLdv  = "load_versions"
+	ColAlv  = "active_load_versions"
+	ColCfg  = "config_sections"
 )
 
 var (
@@ -316,7 +326,7 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "key"); err != nil {
 			return
 		}
-	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp:
+	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColApp, ColAnp, ColErp, ColRvc, ColRdk, ColTxp, ColRpv, ColLkt, ColPhs:
 		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
 			return
 		}
@@ -324,6 +334,18 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColCfg:
+		if err = ms.enusureIndex(col, true, "node_id", "section"); err != nil {
+			return
+		}
+	case ColLdv:
+		if err = ms.enusureIndex(col, true, "loaderid", "version"); err != nil {
+			return
+		}
+	case ColAlv:
+		if err = ms.enusureIndex(col, true, "loaderid"); err != nil {
+			return
+		}
 		//StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
@@ -363,6 +385,13 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 			OriginIDLow); err != nil {
 			return
 		}
+	case utils.InvoicesTBL:
+		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, "tenant", "account"); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -381,7 +410,7 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
 			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp, ColApp,
-			ColRpf, ColShg, ColAcc, ColAnp} {
+			ColRpf, ColShg, ColAcc, ColAnp, ColErp, ColRvc, ColRdk, ColTxp, ColRpv, ColLkt, ColPhs, ColLdv, ColAlv, ColCfg} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -393,7 +422,7 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL, utils.InvoicesTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -644,6 +673,14 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField2(sctx, ColApp, utils.ActionProfilePrefix, subject, tntID)
 		case utils.AccountProfilePrefix:
 			result, err = ms.getField2(sctx, ColAnp, utils.AccountProfilePrefix, subject, tntID)
+		case utils.ExchangeRateProfilePrefix:
+			result, err = ms.getField2(sctx, ColErp, utils.ExchangeRateProfilePrefix, subject, tntID)
+		case utils.RateDeckPrefix:
+			result, err = ms.getField2(sctx, ColRdk, utils.RateDeckPrefix, subject, tntID)
+		case utils.TaxProfilePrefix:
+			result, err = ms.getField2(sctx, ColTxp, utils.TaxProfilePrefix, subject, tntID)
+		case utils.LookupTablePrefix:
+			result, err = ms.getField2(sctx, ColLkt, utils.LookupTablePrefix, subject, tntID)
 		case utils.DispatcherHostPrefix:
 			result, err = ms.getField2(sctx, ColDph, utils.DispatcherHostPrefix, subject, tntID)
 		case utils.AttributeFilterIndexes:
@@ -666,6 +703,8 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField3(sctx, ColIndx, utils.ActionProfilesFilterIndexPrfx, "key")
 		case utils.AccountProfileFilterIndexPrfx:
 			result, err = ms.getField3(sctx, ColIndx, utils.AccountProfileFilterIndexPrfx, "key")
+		case utils.TaxProfilesFilterIndexPrfx:
+			result, err = ms.getField3(sctx, ColIndx, utils.TaxProfilesFilterIndexPrfx, "key")
 		case utils.RateProfilesFilterIndexPrfx:
 			result, err = ms.getField3(sctx, ColIndx, utils.RateProfilesFilterIndexPrfx, "key")
 		case utils.RateFilterIndexPrfx:
@@ -726,6 +765,14 @@ func (ms *MongoStorage) HasDataDrv(category, subject, tenant string) (has bool,
 			count, err = ms.getCol(ColApp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		case utils.AccountProfilePrefix:
 			count, err = ms.getCol(ColAnp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.ExchangeRateProfilePrefix:
+			count, err = ms.getCol(ColErp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.RateDeckPrefix:
+			count, err = ms.getCol(ColRdk).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.TaxProfilePrefix:
+			count, err = ms.getCol(ColTxp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.LookupTablePrefix:
+			count, err = ms.getCol(ColLkt).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		default:
 			err = fmt.Errorf("unsupported category in HasData: %s", category)
 		}
@@ -2246,3 +2293,368 @@ func (ms *MongoStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 		return err
 	})
 }
+
+func (ms *MongoStorage) GetExchangeRateProfileDrv(tenant, id string) (erp *utils.ExchangeRateProfile, err error) {
+	erp = new(utils.ExchangeRateProfile)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColErp).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(erp); err != nil {
+			erp = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetExchangeRateProfileDrv(erp *utils.ExchangeRateProfile) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColErp).UpdateOne(sctx, bson.M{"tenant": erp.Tenant, "id": erp.ID},
+			bson.M{"$set": erp},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColErp).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	rvc = new(RateVolumeCounter)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRvc).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(rvc); err != nil {
+			rvc = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRvc).UpdateOne(sctx, bson.M{"tenant": rvc.Tenant, "id": rvc.ID},
+			bson.M{"$set": rvc},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRvc).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRateDeckDrv(tenant, id string) (rd *RateDeck, err error) {
+	rd = new(RateDeck)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRdk).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(rd); err != nil {
+			rd = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRateDeckDrv(rd *RateDeck) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRdk).UpdateOne(sctx, bson.M{"tenant": rd.Tenant, "id": rd.ID},
+			bson.M{"$set": rd},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRateDeckDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRdk).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	tp = new(TaxProfile)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColTxp).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(tp); err != nil {
+			tp = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColTxp).UpdateOne(sctx, bson.M{"tenant": tp.Tenant, "id": tp.ID},
+			bson.M{"$set": tp},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColTxp).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	lt = new(LookupTable)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColLkt).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(lt); err != nil {
+			lt = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetLookupTableDrv(lt *LookupTable) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColLkt).UpdateOne(sctx, bson.M{"tenant": lt.Tenant, "id": lt.ID},
+			bson.M{"$set": lt},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColLkt).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetProfileHitsDrv(tenant, id string) (ph *ProfileHits, err error) {
+	ph = new(ProfileHits)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColPhs).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(ph); err != nil {
+			ph = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetProfileHitsDrv(ph *ProfileHits) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColPhs).UpdateOne(sctx, bson.M{"tenant": ph.Tenant, "id": ph.ID},
+			bson.M{"$set": ph},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveProfileHitsDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColPhs).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetRateProfileVersionsDrv(tenant, id string) (rpvs *RateProfileVersions, err error) {
+	rpvs = new(RateProfileVersions)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColRpv).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(rpvs); err != nil {
+			rpvs = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetRateProfileVersionsDrv(rpvs *RateProfileVersions) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColRpv).UpdateOne(sctx, bson.M{"tenant": rpvs.Tenant, "id": rpvs.ID},
+			bson.M{"$set": rpvs},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveRateProfileVersionsDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColRpv).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetLoadVersionDrv(ldrID string, version int) (lv *LoadVersion, err error) {
+	lv = new(LoadVersion)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColLdv).FindOne(sctx, bson.M{"loaderid": ldrID, "version": version})
+		if err := cur.Decode(lv); err != nil {
+			lv = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetLoadVersionDrv(lv *LoadVersion) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColLdv).UpdateOne(sctx, bson.M{"loaderid": lv.LoaderID, "version": lv.Version},
+			bson.M{"$set": lv},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveLoadVersionDrv(ldrID string, version int) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColLdv).DeleteOne(sctx, bson.M{"loaderid": ldrID, "version": version})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetActiveLoadVersionDrv(ldrID string) (version int, err error) {
+	var alv struct{ Version int }
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColAlv).FindOne(sctx, bson.M{"loaderid": ldrID})
+		if err := cur.Decode(&alv); err != nil {
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return alv.Version, err
+}
+
+func (ms *MongoStorage) SetActiveLoadVersionDrv(ldrID string, version int) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColAlv).UpdateOne(sctx, bson.M{"loaderid": ldrID},
+			bson.M{"$set": bson.M{"version": version}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+// GetConfigSectionsDrv returns the config sections stored for the node, the missing ones are ignored
+func (ms *MongoStorage) GetConfigSectionsDrv(nodeID string, sectionIDs []string) (sectionsData map[string][]byte, err error) {
+	sectionsData = make(map[string][]byte)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColCfg).Find(sctx, bson.M{
+			"node_id": nodeID,
+			"section": bson.M{"$in": sectionIDs},
+		})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var elem struct {
+				Section string
+				Value   string
+			}
+			if err := cur.Decode(&elem); err != nil {
+				return err
+			}
+			sectionsData[elem.Section] = []byte(elem.Value)
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
+// SetConfigSectionsDrv stores the config sections for the node
+func (ms *MongoStorage) SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for sectionID, data := range sectionsData {
+			if _, err = ms.getCol(ColCfg).UpdateOne(sctx, bson.M{"node_id": nodeID, "section": sectionID},
+				bson.M{"$set": bson.M{"node_id": nodeID, "section": sectionID, "value": string(data)}},
+				options.Update().SetUpsert(true),
+			); err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+// RemoveConfigSectionsDrv removes the config sections stored for the node
+func (ms *MongoStorage) RemoveConfigSectionsDrv(nodeID string, sectionIDs []string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCfg).DeleteMany(sctx, bson.M{
+			"node_id": nodeID,
+			"section": bson.M{"$in": sectionIDs},
+		})
+		return
+	})
+}
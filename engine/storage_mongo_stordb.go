@@ -1745,3 +1745,69 @@ func (ms *MongoStorage) RemoveVersions(vrs Versions) (err error) {
 func (ms *MongoStorage) GetStorageType() string {
 	return utils.Mongo
 }
+
+// invoicesQuery builds the mongo query out of the InvoicesFilter
+func (ms *MongoStorage) invoicesQuery(fltr *utils.InvoicesFilter) (filter bson.M) {
+	filter = bson.M{}
+	if fltr == nil {
+		return
+	}
+	if fltr.Tenant != utils.EmptyString {
+		filter["tenant"] = fltr.Tenant
+	}
+	if len(fltr.IDs) != 0 {
+		filter["id"] = bson.M{"$in": fltr.IDs}
+	}
+	if len(fltr.Accounts) != 0 {
+		filter["account"] = bson.M{"$in": fltr.Accounts}
+	}
+	if fltr.PeriodStart != nil {
+		filter["periodstart"] = bson.M{"$gte": *fltr.PeriodStart}
+	}
+	if fltr.PeriodEnd != nil {
+		filter["periodend"] = bson.M{"$lte": *fltr.PeriodEnd}
+	}
+	return
+}
+
+func (ms *MongoStorage) SetInvoice(inv *Invoice) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.InvoicesTBL).UpdateOne(sctx, bson.M{"tenant": inv.Tenant, "id": inv.ID},
+			bson.M{"$set": inv},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetInvoices(fltr *utils.InvoicesFilter) (invs []*Invoice, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.InvoicesTBL).Find(sctx, ms.invoicesQuery(fltr))
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var inv Invoice
+			if err := cur.Decode(&inv); err != nil {
+				return err
+			}
+			invs = append(invs, &inv)
+		}
+		if len(invs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	SortInvoices(invs)
+	return
+}
+
+func (ms *MongoStorage) RemoveInvoices(fltr *utils.InvoicesFilter) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.InvoicesTBL).DeleteMany(sctx, ms.invoicesQuery(fltr))
+		return err
+	})
+}
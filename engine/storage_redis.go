@@ -270,7 +270,8 @@ func (rs *RedisStorage) HasDataDrv(category, subject, tenant string) (exists boo
 		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
 		utils.FilterPrefix, utils.RouteProfilePrefix, utils.AttributeProfilePrefix,
 		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix,
-		utils.RateProfilePrefix:
+		utils.RateProfilePrefix, utils.ExchangeRateProfilePrefix, utils.RateDeckPrefix,
+		utils.TaxProfilePrefix, utils.LookupTablePrefix:
 		err := rs.Cmd(&i, redis_EXISTS, category+utils.ConcatenatedKey(tenant, subject))
 		return i == 1, err
 	}
@@ -1388,3 +1389,245 @@ func (rs *RedisStorage) SetAccountProfileDrv(ap *utils.AccountProfile) (err erro
 func (rs *RedisStorage) RemoveAccountProfileDrv(tenant, id string) (err error) {
 	return rs.Cmd(nil, redis_DEL, utils.AccountProfilePrefix+utils.ConcatenatedKey(tenant, id))
 }
+
+func (rs *RedisStorage) GetExchangeRateProfileDrv(tenant, id string) (erp *utils.ExchangeRateProfile, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.ExchangeRateProfilePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &erp)
+	return
+}
+
+func (rs *RedisStorage) SetExchangeRateProfileDrv(erp *utils.ExchangeRateProfile) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(erp); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.ExchangeRateProfilePrefix+utils.ConcatenatedKey(erp.Tenant, erp.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveExchangeRateProfileDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.ExchangeRateProfilePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetRateVolumeCounterDrv(tenant, id string) (rvc *RateVolumeCounter, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &rvc)
+	return
+}
+
+func (rs *RedisStorage) SetRateVolumeCounterDrv(rvc *RateVolumeCounter) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(rvc); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(rvc.Tenant, rvc.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveRateVolumeCounterDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetRateDeckDrv(tenant, id string) (rd *RateDeck, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RateDeckPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &rd)
+	return
+}
+
+func (rs *RedisStorage) SetRateDeckDrv(rd *RateDeck) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(rd); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RateDeckPrefix+utils.ConcatenatedKey(rd.Tenant, rd.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveRateDeckDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RateDeckPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetTaxProfileDrv(tenant, id string) (tp *TaxProfile, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.TaxProfilePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &tp)
+	return
+}
+
+func (rs *RedisStorage) SetTaxProfileDrv(tp *TaxProfile) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(tp); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.TaxProfilePrefix+utils.ConcatenatedKey(tp.Tenant, tp.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.TaxProfilePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetLookupTableDrv(tenant, id string) (lt *LookupTable, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &lt)
+	return
+}
+
+func (rs *RedisStorage) SetLookupTableDrv(lt *LookupTable) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(lt); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.LookupTablePrefix+utils.ConcatenatedKey(lt.Tenant, lt.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveLookupTableDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.LookupTablePrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetProfileHitsDrv(tenant, id string) (ph *ProfileHits, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.ProfileHitsPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &ph)
+	return
+}
+
+func (rs *RedisStorage) SetProfileHitsDrv(ph *ProfileHits) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(ph); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.ProfileHitsPrefix+ph.TenantID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveProfileHitsDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.ProfileHitsPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetRateProfileVersionsDrv(tenant, id string) (rpvs *RateProfileVersions, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.RateProfileVersionsPrefix+utils.ConcatenatedKey(tenant, id)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &rpvs)
+	return
+}
+
+func (rs *RedisStorage) SetRateProfileVersionsDrv(rpvs *RateProfileVersions) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(rpvs); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.RateProfileVersionsPrefix+utils.ConcatenatedKey(rpvs.Tenant, rpvs.ID), string(result))
+}
+
+func (rs *RedisStorage) RemoveRateProfileVersionsDrv(tenant, id string) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.RateProfileVersionsPrefix+utils.ConcatenatedKey(tenant, id))
+}
+
+func (rs *RedisStorage) GetLoadVersionDrv(ldrID string, version int) (lv *LoadVersion, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.LoadVersionPrefix+LoadVersionID(ldrID, version)); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	err = rs.ms.Unmarshal(values, &lv)
+	return
+}
+
+func (rs *RedisStorage) SetLoadVersionDrv(lv *LoadVersion) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(lv); err != nil {
+		return
+	}
+	return rs.Cmd(nil, redis_SET, utils.LoadVersionPrefix+lv.LoadVersionID(), string(result))
+}
+
+func (rs *RedisStorage) RemoveLoadVersionDrv(ldrID string, version int) (err error) {
+	return rs.Cmd(nil, redis_DEL, utils.LoadVersionPrefix+LoadVersionID(ldrID, version))
+}
+
+func (rs *RedisStorage) GetActiveLoadVersionDrv(ldrID string) (version int, err error) {
+	var values []byte
+	if err = rs.Cmd(&values, redis_GET, utils.ActiveLoadVersionPrefix+ldrID); err != nil {
+		return
+	} else if len(values) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	return strconv.Atoi(string(values))
+}
+
+func (rs *RedisStorage) SetActiveLoadVersionDrv(ldrID string, version int) (err error) {
+	return rs.Cmd(nil, redis_SET, utils.ActiveLoadVersionPrefix+ldrID, strconv.Itoa(version))
+}
+
+// GetConfigSectionsDrv returns the config sections stored for the node, the missing ones are ignored
+func (rs *RedisStorage) GetConfigSectionsDrv(nodeID string, sectionIDs []string) (sectionsData map[string][]byte, err error) {
+	var mp map[string]string
+	if err = rs.Cmd(&mp, redis_HGETALL, utils.ConfigPrefix+nodeID); err != nil {
+		return
+	}
+	sectionsData = make(map[string][]byte)
+	for _, sectionID := range sectionIDs {
+		if val, has := mp[sectionID]; has {
+			sectionsData[sectionID] = []byte(val)
+		}
+	}
+	return
+}
+
+// SetConfigSectionsDrv stores the config sections for the node
+func (rs *RedisStorage) SetConfigSectionsDrv(nodeID string, sectionsData map[string][]byte) (err error) {
+	if len(sectionsData) == 0 {
+		return
+	}
+	mp := make(map[string]string)
+	for sectionID, data := range sectionsData {
+		mp[sectionID] = string(data)
+	}
+	return rs.FlatCmd(nil, redis_HMSET, utils.ConfigPrefix+nodeID, mp)
+}
+
+// RemoveConfigSectionsDrv removes the config sections stored for the node
+func (rs *RedisStorage) RemoveConfigSectionsDrv(nodeID string, sectionIDs []string) (err error) {
+	if len(sectionIDs) == 0 {
+		return
+	}
+	return rs.Cmd(nil, redis_HDEL, append([]string{utils.ConfigPrefix + nodeID}, sectionIDs...)...)
+}
@@ -105,7 +105,7 @@ func (sqls *SQLStorage) IsDBEmpty() (resp bool, err error) {
 		utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 		utils.TBLTPFilters, utils.SessionCostsTBL, utils.CDRsTBL, utils.TBLTPActionPlans,
 		utils.TBLVersions, utils.TBLTPRoutes, utils.TBLTPAttributes, utils.TBLTPChargers,
-		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts, utils.InvoicesTBL,
 	}
 	for _, tbl := range tbls {
 		if sqls.db.Migrator().HasTable(tbl) {
@@ -890,6 +890,82 @@ func (sqls *SQLStorage) RemoveSMCosts(qryFltr *utils.SMCostFilter) error {
 	return nil
 }
 
+// invoicesQuery applies the InvoicesFilter on the invoices table
+func (sqls *SQLStorage) invoicesQuery(fltr *utils.InvoicesFilter) *gorm.DB {
+	q := sqls.db.Table(utils.InvoicesTBL)
+	if fltr == nil {
+		return q
+	}
+	if fltr.Tenant != utils.EmptyString {
+		q = q.Where("tenant = ?", fltr.Tenant)
+	}
+	if len(fltr.IDs) != 0 {
+		q = q.Where("invoice_id in (?)", fltr.IDs)
+	}
+	if len(fltr.Accounts) != 0 {
+		q = q.Where("account in (?)", fltr.Accounts)
+	}
+	if fltr.PeriodStart != nil {
+		q = q.Where("period_start >= ?", fltr.PeriodStart)
+	}
+	if fltr.PeriodEnd != nil {
+		q = q.Where("period_end <= ?", fltr.PeriodEnd)
+	}
+	return q
+}
+
+// SetInvoice stores the invoice, overwriting the one with the same ID
+func (sqls *SQLStorage) SetInvoice(inv *Invoice) error {
+	tx := sqls.db.Begin()
+	if err := tx.Where("tenant = ? AND invoice_id = ?", inv.Tenant, inv.ID).Delete(InvoiceSQL{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	mdl := &InvoiceSQL{
+		Tenant:      inv.Tenant,
+		InvoiceID:   inv.ID,
+		Account:     inv.Account,
+		PeriodStart: inv.PeriodStart,
+		PeriodEnd:   inv.PeriodEnd,
+		Lines:       utils.ToJSON(inv.Lines),
+		Taxes:       utils.ToJSON(inv.Taxes),
+		Subtotal:    inv.Subtotal,
+		TaxTotal:    inv.TaxTotal,
+		Total:       inv.Total,
+		CreatedAt:   inv.CreatedAt,
+	}
+	if err := tx.Create(mdl).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// GetInvoices returns the invoices matching the filter
+func (sqls *SQLStorage) GetInvoices(fltr *utils.InvoicesFilter) (invs []*Invoice, err error) {
+	results := make([]*InvoiceSQL, 0)
+	if err = sqls.invoicesQuery(fltr).Find(&results).Error; err != nil {
+		return
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	invs = make([]*Invoice, len(results))
+	for i, result := range results {
+		if invs[i], err = result.AsInvoice(); err != nil {
+			return nil, err
+		}
+	}
+	SortInvoices(invs)
+	return
+}
+
+// RemoveInvoices removes the invoices matching the filter
+func (sqls *SQLStorage) RemoveInvoices(fltr *utils.InvoicesFilter) error {
+	return sqls.invoicesQuery(fltr).Delete(InvoiceSQL{}).Error
+}
+
 // GetSMCosts is used to retrieve one or multiple SMCosts based on filter
 func (sqls *SQLStorage) GetSMCosts(cgrid, runid, originHost, originIDPrefix string) ([]*SMCost, error) {
 	var smCosts []*SMCost
@@ -18,6 +18,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -362,3 +363,33 @@ func BenchmarkMarshallerBincStoreRestore(b *testing.B) {
 		ms.Unmarshal(result, ub1)
 	}
 }
+
+func TestStorageInternalConfigSections(t *testing.T) {
+	iDB := NewInternalDB(nil, nil, true)
+	if rcv, err := iDB.GetConfigSectionsDrv("node1", []string{"general"}); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 0 {
+		t.Errorf("Expected no sections, received %s", utils.ToJSON(rcv))
+	}
+	sectionsData := map[string][]byte{
+		"general": []byte(`{"node_id":"node1"}`),
+		"cores":   []byte(`{"caps":10}`),
+	}
+	if err := iDB.SetConfigSectionsDrv("node1", sectionsData); err != nil {
+		t.Error(err)
+	}
+	if rcv, err := iDB.GetConfigSectionsDrv("node1", []string{"general", "cores", "rals"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rcv, sectionsData) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(sectionsData), utils.ToJSON(rcv))
+	}
+	if err := iDB.RemoveConfigSectionsDrv("node1", []string{"cores"}); err != nil {
+		t.Error(err)
+	}
+	exp := map[string][]byte{"general": []byte(`{"node_id":"node1"}`)}
+	if rcv, err := iDB.GetConfigSectionsDrv("node1", []string{"general", "cores"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rcv, exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
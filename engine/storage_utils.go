@@ -71,7 +71,11 @@ func NewDataDBConn(dbType, host, port, name, user,
 		}
 		d, err = NewMongoStorage(host, port, name, user, pass, marshaler, utils.DataDB, nil, ttl)
 	case utils.INTERNAL:
-		d = NewInternalDB(nil, nil, true)
+		iDB := NewInternalDB(nil, nil, true)
+		if err = iDB.enablePersistence(opts); err != nil {
+			return
+		}
+		d = iDB
 	default:
 		err = fmt.Errorf("unsupported db_type <%s>", dbType)
 	}
@@ -116,7 +120,11 @@ func NewStorDBConn(dbType, host, port, name, user, pass, marshaler string,
 		db, err = NewMySQLStorage(host, port, name, user, pass, int(maxConn), int(maxIdleConn),
 			int(connMaxLifetime), utils.IfaceAsString(opts[utils.MysqlLocation]))
 	case utils.INTERNAL:
-		db = NewInternalDB(stringIndexedFields, prefixIndexedFields, false)
+		iDB := NewInternalDB(stringIndexedFields, prefixIndexedFields, false)
+		if err = iDB.enablePersistence(opts); err != nil {
+			return
+		}
+		db = iDB
 	default:
 		err = fmt.Errorf("unknown db '%s' valid options are [%s, %s, %s, %s]",
 			dbType, utils.MySQL, utils.Mongo, utils.Postgres, utils.INTERNAL)
@@ -0,0 +1,117 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// matchingTaxProfilesForEvent returns the TaxProfiles matching the event, sorted on weight
+func matchingTaxProfilesForEvent(cfg *config.CGRConfig, dm *DataManager, fltrS *FilterS,
+	cgrEv *utils.CGREvent) (tps TaxProfilesWithWeight, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaReq:  cgrEv.Event,
+		utils.MetaOpts: cgrEv.Opts,
+	}
+	var tpIDs utils.StringSet
+	if tpIDs, err = MatchingItemIDsForEvent(
+		evNm,
+		cfg.TaxSCfg().StringIndexedFields,
+		cfg.TaxSCfg().PrefixIndexedFields,
+		cfg.TaxSCfg().SuffixIndexedFields,
+		dm,
+		utils.CacheTaxProfilesFilterIndexes,
+		cgrEv.Tenant,
+		cfg.TaxSCfg().IndexedSelects,
+		cfg.TaxSCfg().NestedFields,
+	); err != nil {
+		return
+	}
+	for tpID := range tpIDs {
+		var tp *TaxProfile
+		if tp, err = dm.GetTaxProfile(cgrEv.Tenant, tpID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				continue
+			}
+			return
+		}
+		if tp.ActivationInterval != nil && cgrEv.Time != nil &&
+			!tp.ActivationInterval.IsActiveAtTime(*cgrEv.Time) { // not active
+			continue
+		}
+		var pass bool
+		if pass, err = fltrS.Pass(cgrEv.Tenant, tp.FilterIDs, evNm); err != nil {
+			return
+		} else if !pass {
+			continue
+		}
+		if len(tp.ExemptFilterIDs) != 0 {
+			if pass, err = fltrS.Pass(cgrEv.Tenant, tp.ExemptFilterIDs, evNm); err != nil {
+				return
+			} else if pass { // exempt from this tax
+				continue
+			}
+		}
+		var weight float64
+		if weight, err = WeightFromDynamics(tp.Weights,
+			fltrS, cgrEv.Tenant, evNm); err != nil {
+			return
+		}
+		tps = append(tps, &TaxProfileWithWeight{tp, weight})
+	}
+	if len(tps) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	tps.Sort()
+	return
+}
+
+// TaxesForEvent computes the taxes applied on the amount charged for the event
+// the compound taxes are applied on the amount including the taxes computed before them
+func TaxesForEvent(cfg *config.CGRConfig, dm *DataManager, fltrS *FilterS,
+	cgrEv *utils.CGREvent, amount *utils.Decimal) (tcs []*utils.TaxCharge, err error) {
+	var tps TaxProfilesWithWeight
+	if tps, err = matchingTaxProfilesForEvent(cfg, dm, fltrS, cgrEv); err != nil {
+		return
+	}
+	taxed := amount.Big // amount including the taxes applied so far
+	for _, tp := range tps {
+		if tp.Rate == nil {
+			continue
+		}
+		base := amount.Big
+		if tp.Compound {
+			base = taxed
+		}
+		tc := &utils.TaxCharge{
+			TaxProfileID: tp.ID,
+			Jurisdiction: tp.Jurisdiction,
+			TaxType:      tp.TaxType,
+			Rate:         tp.Rate,
+			Base:         &utils.Decimal{Big: base},
+			Amount:       &utils.Decimal{Big: utils.MultiplyBig(base, tp.Rate.Big)},
+		}
+		taxed = utils.SumBig(taxed, tc.Amount.Big)
+		tcs = append(tcs, tc)
+	}
+	return
+}
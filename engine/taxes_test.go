@@ -0,0 +1,150 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTaxesForEvent(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true), cfg.CacheCfg(), nil)
+	fltrS := NewFilterS(cfg, nil, dm)
+	for _, tp := range []*TaxProfile{
+		{
+			Tenant:    "cgrates.org",
+			ID:        "TAX_CA_GST",
+			FilterIDs: []string{"*string:~*req.Country:CA"},
+			Weights: utils.DynamicWeights{
+				{
+					Weight: 20,
+				},
+			},
+			Jurisdiction: "CA",
+			TaxType:      "GST",
+			Rate:         utils.NewDecimal(5, 2),
+		},
+		{
+			Tenant:    "cgrates.org",
+			ID:        "TAX_CA_QC_QST",
+			FilterIDs: []string{"*string:~*req.Country:CA", "*string:~*req.Region:QC"},
+			Weights: utils.DynamicWeights{
+				{
+					Weight: 10,
+				},
+			},
+			Jurisdiction:    "CA-QC",
+			TaxType:         "QST",
+			Rate:            utils.NewDecimal(1, 1),
+			Compound:        true,
+			ExemptFilterIDs: []string{"*string:~*req.Category:exempt"},
+		},
+		{
+			Tenant:    "cgrates.org",
+			ID:        "TAX_CA_EXPIRED",
+			FilterIDs: []string{"*string:~*req.Country:CA"},
+			ActivationInterval: &utils.ActivationInterval{
+				ExpiryTime: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			Jurisdiction: "CA",
+			TaxType:      "OLD",
+			Rate:         utils.NewDecimal(5, 1),
+		},
+	} {
+		if err := dm.SetTaxProfile(tp, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestTaxesForEvent",
+		Time:   utils.TimePointer(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)),
+		Event: map[string]interface{}{
+			"Country": "CA",
+			"Region":  "QC",
+		},
+	}
+	exp := []*utils.TaxCharge{
+		{
+			TaxProfileID: "TAX_CA_GST",
+			Jurisdiction: "CA",
+			TaxType:      "GST",
+			Rate:         utils.NewDecimal(5, 2),
+			Base:         utils.NewDecimal(100, 0),
+			Amount:       utils.NewDecimal(5, 0),
+		},
+		{ // compound, applied on the amount including the GST
+			TaxProfileID: "TAX_CA_QC_QST",
+			Jurisdiction: "CA-QC",
+			TaxType:      "QST",
+			Rate:         utils.NewDecimal(1, 1),
+			Base:         utils.NewDecimal(105, 0),
+			Amount:       utils.NewDecimal(105, 1),
+		},
+	}
+	if rcv, err := TaxesForEvent(cfg, dm, fltrS, cgrEv, utils.NewDecimal(100, 0)); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != len(exp) {
+		t.Fatalf("expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	} else {
+		for i := range exp {
+			if exp[i].TaxProfileID != rcv[i].TaxProfileID ||
+				exp[i].Base.Compare(rcv[i].Base) != 0 ||
+				exp[i].Amount.Compare(rcv[i].Amount) != 0 {
+				t.Errorf("expecting: %s, received: %s", utils.ToJSON(exp[i]), utils.ToJSON(rcv[i]))
+			}
+		}
+		if total := utils.TaxesTotal(rcv); total.Compare(utils.NewDecimal(155, 1)) != 0 {
+			t.Errorf("expecting total 15.5, received: %s", total)
+		}
+	}
+
+	cgrEv.Event[utils.Category] = "exempt"
+	if rcv, err := TaxesForEvent(cfg, dm, fltrS, cgrEv, utils.NewDecimal(100, 0)); err != nil {
+		t.Fatal(err)
+	} else if len(rcv) != 1 || rcv[0].TaxProfileID != "TAX_CA_GST" {
+		t.Errorf("expecting only the GST, received: %s", utils.ToJSON(rcv))
+	}
+
+	cgrEv.Event["Country"] = "US"
+	if _, err := TaxesForEvent(cfg, dm, fltrS, cgrEv, utils.NewDecimal(100, 0)); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestTaxProfilesWithWeightSort(t *testing.T) {
+	tps := TaxProfilesWithWeight{
+		{&TaxProfile{ID: "TP1"}, 10},
+		{&TaxProfile{ID: "TP2"}, 30},
+		{&TaxProfile{ID: "TP3"}, 20},
+	}
+	tps.Sort()
+	var rcv []string
+	for _, tp := range tps {
+		rcv = append(rcv, tp.ID)
+	}
+	if exp := []string{"TP2", "TP3", "TP1"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %v, received: %v", exp, rcv)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Real-time Online/Offline Charging System (OerS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TaxProfile defines one tax applied on top of the rated amount
+type TaxProfile struct {
+	Tenant             string
+	ID                 string
+	FilterIDs          []string
+	ActivationInterval *utils.ActivationInterval // Activation interval
+	Weights            utils.DynamicWeights      // the taxes are applied in the descending order of the weights
+	Jurisdiction       string
+	TaxType            string
+	Rate               *utils.Decimal // fraction of the taxable amount, ie: 0.19 for 19%
+	Compound           bool           // the taxable amount includes the taxes applied before
+	ExemptFilterIDs    []string       // the events matching these filters are exempt from the tax
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (tp *TaxProfile) TenantID() string {
+	return utils.ConcatenatedKey(tp.Tenant, tp.ID)
+}
+
+// TaxProfileWithWeight attaches the computed weight to the TaxProfile
+type TaxProfileWithWeight struct {
+	*TaxProfile
+	Weight float64
+}
+
+// TaxProfilesWithWeight is a sortable list of TaxProfileWithWeight
+type TaxProfilesWithWeight []*TaxProfileWithWeight
+
+// Sort is part of sort interface, sort based on Weight
+func (tps TaxProfilesWithWeight) Sort() {
+	sort.SliceStable(tps, func(i, j int) bool { return tps[i].Weight > tps[j].Weight })
+}
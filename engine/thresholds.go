@@ -290,6 +290,9 @@ func (tS *ThresholdService) matchingThresholdsForEvent(tnt string, args *Thresho
 			break
 		}
 	}
+	for _, t := range ts {
+		hitCounters.Hit(utils.CacheThresholdProfiles, tnt, t.ID, t.tPrfl.FilterIDs...)
+	}
 	return
 }
 
@@ -1351,75 +1351,46 @@ func (tpr *TpReader) LoadDispatcherHosts() error {
 	return tpr.LoadDispatcherHostsFiltered("")
 }
 
-func (tpr *TpReader) LoadAll() (err error) {
-	if err = tpr.LoadDestinations(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadTimings(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadRates(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadDestinationRates(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadRatingPlans(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadRatingProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadSharedGroups(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadActions(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadActionPlans(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadActionTriggers(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadAccountActions(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadFilters(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadResourceProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadStats(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadThresholds(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadRouteProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadAttributeProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadChargerProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadDispatcherProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadDispatcherHosts(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadRateProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
-	}
-	if err = tpr.LoadActionProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
+// tpLoadFunc loads the data of one type
+type tpLoadFunc struct {
+	ldrType string
+	load    func() error
+}
+
+// loadFuncs returns the load functions in the order the data needs to be loaded
+func (tpr *TpReader) loadFuncs() []*tpLoadFunc {
+	return []*tpLoadFunc{
+		{utils.MetaDestinations, tpr.LoadDestinations},
+		{utils.MetaTimings, tpr.LoadTimings},
+		{utils.MetaTpRates, tpr.LoadRates},
+		{utils.MetaTpDestinationRates, tpr.LoadDestinationRates},
+		{utils.MetaRatingPlans, tpr.LoadRatingPlans},
+		{utils.MetaRatingProfiles, tpr.LoadRatingProfiles},
+		{utils.MetaSharedGroups, tpr.LoadSharedGroups},
+		{utils.MetaActions, tpr.LoadActions},
+		{utils.MetaActionPlans, tpr.LoadActionPlans},
+		{utils.MetaActionTriggers, tpr.LoadActionTriggers},
+		{utils.MetaTpAccountActions, tpr.LoadAccountActions},
+		{utils.MetaFilters, tpr.LoadFilters},
+		{utils.MetaResources, tpr.LoadResourceProfiles},
+		{utils.MetaStats, tpr.LoadStats},
+		{utils.MetaThresholds, tpr.LoadThresholds},
+		{utils.MetaRoutes, tpr.LoadRouteProfiles},
+		{utils.MetaAttributes, tpr.LoadAttributeProfiles},
+		{utils.MetaChargers, tpr.LoadChargerProfiles},
+		{utils.MetaDispatchers, tpr.LoadDispatcherProfiles},
+		{utils.MetaDispatcherHosts, tpr.LoadDispatcherHosts},
+		{utils.MetaRateProfiles, tpr.LoadRateProfiles},
+		{utils.MetaActionProfiles, tpr.LoadActionProfiles},
+		{utils.MetaAccountProfiles, tpr.LoadAccountProfiles},
 	}
-	if err = tpr.LoadAccountProfiles(); err != nil && err.Error() != utils.NotFoundCaps {
-		return
+}
+
+func (tpr *TpReader) LoadAll() (err error) {
+	for _, lf := range tpr.loadFuncs() {
+		if err = lf.load(); err != nil && err.Error() != utils.NotFoundCaps {
+			return
+		}
 	}
 	return nil
 }
@@ -2848,3 +2819,106 @@ func (tpr *TpReader) setDestination(dest *Destination, disableReverse bool, tran
 	}
 	return tpr.dm.UpdateReverseDestination(oldDest, dest, transID)
 }
+
+// DryRun loads the data and validates it against DataDB without writing into it
+// the errors of each data type are recorded in the report instead of stopping the load
+func (tpr *TpReader) DryRun(remove bool) (rpt *DryRunReport, err error) {
+	rpt = NewDryRunReport()
+	for _, lf := range tpr.loadFuncs() {
+		if errLoad := lf.load(); errLoad != nil && errLoad.Error() != utils.NotFoundCaps {
+			rpt.AddError(lf.ldrType, utils.EmptyString, 0, errLoad)
+		}
+	}
+	for actID := range tpr.actions {
+		rpt.AddLoaded(utils.MetaActions, actID)
+	}
+	type dryRunItem struct {
+		ldrType string
+		tntID   utils.TenantID
+		getPrf  func() (interface{ TenantID() string }, error)
+	}
+	var items []*dryRunItem
+	for tntID, tpPrf := range tpr.filters {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaFilters, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoFilter(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.resProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaResources, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoResource(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.sqProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaStats, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoStats(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.thProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaThresholds, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoThresholdProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.routeProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaRoutes, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoRouteProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.attributeProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaAttributes, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoAttributeProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.chargerProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaChargers, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoChargerProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.dispatcherProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaDispatchers, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoDispatcherProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.dispatcherHosts {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaDispatcherHosts, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoDispatcherHost(tpPrf), nil }})
+	}
+	for tntID, tpPrf := range tpr.rateProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaRateProfiles, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoRateProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.actionProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaActionProfiles, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoActionProfile(tpPrf, tpr.timezone) }})
+	}
+	for tntID, tpPrf := range tpr.accountProfiles {
+		tpPrf := tpPrf
+		items = append(items, &dryRunItem{utils.MetaAccountProfiles, tntID,
+			func() (interface{ TenantID() string }, error) { return APItoAccountProfile(tpPrf, tpr.timezone) }})
+	}
+	for _, item := range items {
+		if remove {
+			if err = rpt.AddRemoved(tpr.dm, item.ldrType, item.tntID.TenantID()); err != nil {
+				return
+			}
+			continue
+		}
+		prf, errPrf := item.getPrf()
+		if errPrf != nil {
+			rpt.AddError(item.ldrType, item.tntID.TenantID(), 0, errPrf)
+			continue
+		}
+		if err = rpt.AddProfile(tpr.dm, item.ldrType, prf); err != nil {
+			return
+		}
+	}
+	if !remove {
+		if err = rpt.CheckReferences(tpr.dm); err != nil {
+			return
+		}
+	}
+	rpt.Sort()
+	return
+}
@@ -38,8 +38,11 @@ var (
 		utils.Routes:         "cgr-migrator -exec=*routes",
 	}
 	storDBVers = map[string]string{
-		utils.CostDetails:   "cgr-migrator -exec=*cost_details",
-		utils.SessionSCosts: "cgr-migrator -exec=*sessions_costs",
+		utils.CostDetails:    "cgr-migrator -exec=*cost_details",
+		utils.SessionSCosts:  "cgr-migrator -exec=*sessions_costs",
+		utils.TpChargers:     "cgr-migrator -exec=*tp_chargers",
+		utils.TpRateProfiles: "cgr-migrator -exec=*tp_rate_profiles",
+		utils.TpRoutes:       "cgr-migrator -exec=*tp_routes",
 	}
 	allVers map[string]string // init will fill this with a merge of data+stor
 )
@@ -182,7 +185,7 @@ func CurrentStorDBVersions() Versions {
 		utils.TpActionPlans:      1,
 		utils.TpActions:          1,
 		utils.TpThresholds:       1,
-		utils.TpRoutes:           1,
+		utils.TpRoutes:           2,
 		utils.TpStats:            1,
 		utils.TpSharedGroups:     1,
 		utils.TpRatingProfiles:   1,
@@ -193,9 +196,9 @@ func CurrentStorDBVersions() Versions {
 		utils.TpDestinations:     1,
 		utils.TpRatingPlan:       1,
 		utils.TpRatingProfile:    1,
-		utils.TpChargers:         1,
+		utils.TpChargers:         2,
 		utils.TpDispatchers:      1,
-		utils.TpRateProfiles:     1,
+		utils.TpRateProfiles:     2,
 		utils.TpActionProfiles:   1,
 	}
 }
@@ -99,12 +99,12 @@ func TestCurrentDBVersions(t *testing.T) {
 		utils.CostDetails: 2, utils.SessionSCosts: 3, utils.CDRs: 2,
 		utils.TpRatingPlans: 1, utils.TpFilters: 1, utils.TpDestinationRates: 1,
 		utils.TpActionTriggers: 1, utils.TpAccountActionsV: 1, utils.TpActionPlans: 1,
-		utils.TpActions: 1, utils.TpThresholds: 1, utils.TpRoutes: 1,
+		utils.TpActions: 1, utils.TpThresholds: 1, utils.TpRoutes: 2,
 		utils.TpStats: 1, utils.TpSharedGroups: 1, utils.TpRatingProfiles: 1,
 		utils.TpResources: 1, utils.TpRates: 1, utils.TpTiming: 1,
 		utils.TpResource: 1, utils.TpDestinations: 1, utils.TpRatingPlan: 1,
-		utils.TpRatingProfile: 1, utils.TpChargers: 1, utils.TpDispatchers: 1,
-		utils.TpRateProfiles: 1, utils.TpActionProfiles: 1,
+		utils.TpRatingProfile: 1, utils.TpChargers: 2, utils.TpDispatchers: 1,
+		utils.TpRateProfiles: 2, utils.TpActionProfiles: 1,
 	}
 	if vrs := CurrentDBVersions(utils.Mongo, true); !reflect.DeepEqual(expVersDataDB, vrs) {
 		t.Errorf("Expectred %+v, received %+v", expVersDataDB, vrs)
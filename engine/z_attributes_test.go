@@ -244,7 +244,7 @@ func TestAttributeProfileForEvent(t *testing.T) {
 			utils.MetaVars: utils.MapStorage{
 				utils.ProcessRuns: utils.NewNMData(0),
 			},
-		}, utils.EmptyString)
+		}, utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -258,7 +258,7 @@ func TestAttributeProfileForEvent(t *testing.T) {
 			utils.MetaVars: utils.MapStorage{
 				utils.ProcessRuns: utils.NewNMData(0),
 			},
-		}, utils.EmptyString)
+		}, utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -273,7 +273,7 @@ func TestAttributeProfileForEvent(t *testing.T) {
 			utils.MetaVars: utils.MapStorage{
 				utils.ProcessRuns: utils.NewNMData(0),
 			},
-		}, utils.EmptyString)
+		}, utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -296,7 +296,7 @@ func TestAttributeProcessEvent(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	atrp, err := attrService.processEvent(attrEvs[0].Tenant, attrEvs[0], eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	atrp, err := attrService.processEvent(attrEvs[0].Tenant, attrEvs[0], eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -315,7 +315,7 @@ func TestAttributeProcessEventWithNotFound(t *testing.T) {
 		},
 	}
 	if _, err := attrService.processEvent(attrEvs[0].Tenant, attrEvs[3], eNM,
-		newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString); err == nil || err != utils.ErrNotFound {
+		newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil); err == nil || err != utils.ErrNotFound {
 		t.Errorf("Error: %+v", err)
 	}
 }
@@ -335,7 +335,7 @@ func TestAttributeProcessEventWithIDs(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	if atrp, err := attrService.processEvent(attrEvs[0].Tenant, attrEvs[3], eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString); err != nil {
+	if atrp, err := attrService.processEvent(attrEvs[0].Tenant, attrEvs[3], eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil); err != nil {
 	} else if !reflect.DeepEqual(eRply, atrp) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(atrp))
 	}
@@ -1931,7 +1931,7 @@ func TestProcessAttributeConstant(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -1991,7 +1991,7 @@ func TestProcessAttributeVariable(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2058,7 +2058,7 @@ func TestProcessAttributeComposed(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2120,7 +2120,7 @@ func TestProcessAttributeUsageDifference(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2182,7 +2182,7 @@ func TestProcessAttributeSum(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2244,7 +2244,7 @@ func TestProcessAttributeDiff(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2306,7 +2306,7 @@ func TestProcessAttributeMultiply(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2368,7 +2368,7 @@ func TestProcessAttributeDivide(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2430,7 +2430,7 @@ func TestProcessAttributeValueExponent(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2492,7 +2492,7 @@ func TestProcessAttributeUnixTimeStamp(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2553,7 +2553,7 @@ func TestProcessAttributePrefix(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
@@ -2614,7 +2614,7 @@ func TestProcessAttributeSuffix(t *testing.T) {
 			utils.ProcessRuns: utils.NewNMData(0),
 		},
 	}
-	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString)
+	rcv, err := attrService.processEvent(ev.Tenant, ev, eNM, newDynamicDP(nil, nil, nil, "cgrates.org", eNM), utils.EmptyString, nil)
 	if err != nil {
 		t.Errorf("Error: %+v", err)
 	}
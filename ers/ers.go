@@ -88,9 +88,10 @@ func (erS *ERService) ListenAndServe(stopChan, cfgRldChan chan struct{}) (err er
 			return
 		case erEv := <-erS.rdrEvents:
 			if err := erS.processEvent(erEv.cgrEvent, erEv.rdrCfg); err != nil {
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> reading event: <%s> got error: <%s>",
-						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()))
+						utils.ERs, utils.ToIJSON(erEv.cgrEvent), err.Error()),
+					engine.MapEvent(erEv.cgrEvent.Event).LogFields(erEv.cgrEvent.Tenant))
 			}
 		case <-cfgRldChan: // handle reload
 			cfgIDs := make(map[string]int)
@@ -155,9 +156,10 @@ func (erS *ERService) processEvent(cgrEv *utils.CGREvent,
 	rdrCfg *config.EventReaderCfg) (err error) {
 	// log the event created if requested by flags
 	if rdrCfg.Flags.Has(utils.MetaLog) {
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> LOG, reader: <%s>, message: %s",
-				utils.ERs, rdrCfg.ID, utils.ToIJSON(cgrEv)))
+				utils.ERs, rdrCfg.ID, utils.ToIJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	}
 	// find out reqType
 	var reqType string
@@ -176,8 +178,10 @@ func (erS *ERService) processEvent(cgrEv *utils.CGREvent,
 		reqType == utils.MetaMessage ||
 		reqType == utils.MetaEvent {
 		if cgrArgs, err = utils.GetRoutePaginatorFromOpts(cgrEv.Opts); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s> args extraction for reader <%s> failed because <%s>",
-				utils.ERs, rdrCfg.ID, err.Error()))
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
+				fmt.Sprintf("<%s> args extraction for reader <%s> failed because <%s>",
+					utils.ERs, rdrCfg.ID, err.Error()),
+				engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 			err = nil // reset the error and continue the processing
 		}
 	}
@@ -187,9 +191,10 @@ func (erS *ERService) processEvent(cgrEv *utils.CGREvent,
 		return fmt.Errorf("unsupported reqType: <%s>", reqType)
 	case utils.MetaNone: // do nothing on CGRateS side
 	case utils.MetaDryRun:
-		utils.Logger.Info(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_INFO,
 			fmt.Sprintf("<%s> DRYRUN, reader: <%s>, CGREvent: <%s>",
-				utils.ERs, rdrCfg.ID, utils.ToJSON(cgrEv)))
+				utils.ERs, rdrCfg.ID, utils.ToJSON(cgrEv)),
+			engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 	case utils.MetaAuthorize:
 		authArgs := sessions.NewV1AuthorizeArgs(
 			rdrCfg.Flags.Has(utils.MetaAttributes),
@@ -47,6 +47,7 @@ require (
 	github.com/nyaruka/phonenumbers v1.0.60
 	github.com/peterh/liner v1.2.1
 	github.com/pierrec/lz4 v2.6.0+incompatible // indirect
+	github.com/pkg/sftp v1.11.0
 	github.com/rivo/uniseg v0.2.0 // indirect
 	github.com/segmentio/kafka-go v0.4.8
 	github.com/streadway/amqp v1.0.0
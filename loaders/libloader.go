@@ -88,6 +88,24 @@ func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 	return
 }
 
+// optionalColumns holds for the loader types with trailing optional columns
+// the number of columns of the files older than them and the current one
+var optionalColumns = map[string][2]int{
+	utils.MetaRoutes:       {16, 17},
+	utils.MetaChargers:     {7, 12},
+	utils.MetaRateProfiles: {18, 20},
+}
+
+// padOptionalColumns adds the empty trailing optional columns
+// to the records read from the files older than them
+func padOptionalColumns(loaderType string, record []string) []string {
+	cols, has := optionalColumns[loaderType]
+	if !has || len(record) < cols[0] || len(record) >= cols[1] {
+		return record
+	}
+	return append(record, make([]string, cols[1]-len(record))...)
+}
+
 // newCsvProvider constructs a DataProvider
 func newCsvProvider(record []string, fileName string) (dP utils.DataProvider) {
 	return &csvProvider{
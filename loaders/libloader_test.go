@@ -272,6 +272,50 @@ func TestDataUpdateFromCSVMultiFiles(t *testing.T) {
 	}
 }
 
+func TestDataUpdateFromCSVMissingColumns(t *testing.T) {
+	chrgFlds := []*config.FCTemplate{
+		{Tag: "TenantID",
+			Path:      "Tenant",
+			Type:      utils.MetaComposed,
+			Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "ProfileID",
+			Path:      "ID",
+			Type:      utils.MetaComposed,
+			Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+			Mandatory: true},
+		{Tag: "AggregationPath",
+			Path:  "AggregationPath",
+			Type:  utils.MetaVariable,
+			Value: config.NewRSRParsersMustCompile("~*req.7", utils.InfieldSep)},
+	}
+	// records from older files are missing the optional columns
+	record := padOptionalColumns(utils.MetaChargers,
+		[]string{"cgrates.org", "Charger1", "", "", "*default", "*none", "20"})
+	if len(record) != 12 {
+		t.Errorf("Expecting 12 columns, received: %d", len(record))
+	}
+	lData := make(LoaderData)
+	if err := lData.UpdateFromCSV("Chargers.csv", record, chrgFlds,
+		config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep), nil); err != nil {
+		t.Error(err)
+	}
+	eLData := LoaderData{"Tenant": "cgrates.org",
+		"ID":              "Charger1",
+		"AggregationPath": "",
+	}
+	if !reflect.DeepEqual(eLData, lData) {
+		t.Errorf("expecting: %+v, received: %+v", eLData, lData)
+	}
+	// only the trailing optional columns can be missing
+	record = padOptionalColumns(utils.MetaChargers, []string{"cgrates.org", "Charger1"})
+	lData = make(LoaderData)
+	if err := lData.UpdateFromCSV("Chargers.csv", record, chrgFlds,
+		config.NewRSRParsersMustCompile("cgrates.org", utils.InfieldSep), nil); err == nil {
+		t.Error("Expecting error for the short record")
+	}
+}
+
 func TestRemoteHostLoaderData(t *testing.T) {
 	record := []string{"ignored", "ignored", "Subject", "*any", "1001"}
 	fNmae := "File1.csv"
@@ -22,10 +22,10 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -49,7 +49,10 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		ldrID:         cfg.ID,
 		tpInDir:       cfg.TpInDir,
 		tpOutDir:      cfg.TpOutDir,
+		opts:          cfg.Opts,
 		lockFilename:  cfg.LockFileName,
+		atomic:        cfg.Atomic,
+		versionsLimit: cfg.VersionsLimit,
 		fieldSep:      cfg.FieldSeparator,
 		runDelay:      cfg.RunDelay,
 		dataTpls:      make(map[string][]*config.FCTemplate),
@@ -92,6 +95,10 @@ type Loader struct {
 	ldrID         string
 	tpInDir       string
 	tpOutDir      string
+	opts          map[string]interface{}
+	srcMux        sync.Mutex
+	src           loaderSource // where the files are read from, created out of tpInDir
+	dst           loaderSource // where the files are moved after processing, created out of tpOutDir
 	lockFilename  string
 	fieldSep      string
 	runDelay      time.Duration
@@ -105,6 +112,12 @@ type Loader struct {
 	filterS       *engine.FilterS
 	connMgr       *engine.ConnManager
 	cacheConns    []string
+	dryRunRpt     *engine.DryRunReport // collects the results instead of writing into DataDB
+	dryRunStop    bool                 // stop the dry run on the first error
+	atomic        bool
+	versionsLimit int
+	stage         *engine.LoadVersion // collects the items of an atomic load before switching them in
+	versionsMux   sync.Mutex          // serializes the atomic loads and the rollbacks
 }
 
 func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
@@ -118,6 +131,9 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (
 		return
 	}
 	defer ldr.unlockFolder()
+	if ldr.atomic && !ldr.dryRun {
+		return ldr.processFolderAtomic(caching, loadOption)
+	}
 	for ldrType := range ldr.rdrs {
 		if err = ldr.processFiles(ldrType, caching, loadOption); err != nil {
 			if stopOnError {
@@ -131,27 +147,119 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (
 	return ldr.moveFiles()
 }
 
+// DryRunFolder validates the content of the folder for the loadOption (*store or *remove), returning the report of the load
+// with stopOnError the validation stops at the first error found
+func (ldr *Loader) DryRunFolder(loadOption string, stopOnError bool) (rpt *engine.DryRunReport, err error) {
+	// processed by a copy of the loader so the folder lock and the running loads are not affected
+	dryLdr := ldr.dryRunLoader()
+	dryLdr.dryRunStop = stopOnError
+	for ldrType := range dryLdr.rdrs {
+		if err = dryLdr.processFiles(ldrType, utils.MetaNone, loadOption); err != nil {
+			dryLdr.dryRunRpt.AddError(ldrType, utils.EmptyString, 0, err)
+		}
+		if dryLdr.dryRunStopped() {
+			break
+		}
+	}
+	if !dryLdr.dryRunStopped() {
+		if err = dryLdr.dryRunRpt.CheckReferences(ldr.dm); err != nil {
+			return
+		}
+	}
+	rpt = dryLdr.dryRunRpt
+	rpt.Sort()
+	return
+}
+
+// dryRunLoader returns a copy of the loader collecting the results into a new dry run report
+// the copy reads the files with its own state and sources
+func (ldr *Loader) dryRunLoader() (dryLdr *Loader) {
+	dryLdr = &Loader{
+		enabled:       ldr.enabled,
+		tenant:        ldr.tenant,
+		ldrID:         ldr.ldrID,
+		tpInDir:       ldr.tpInDir,
+		opts:          ldr.opts,
+		lockFilename:  ldr.lockFilename,
+		fieldSep:      ldr.fieldSep,
+		dataTpls:      ldr.dataTpls,
+		flagsTpls:     ldr.flagsTpls,
+		rdrs:          make(map[string]map[string]*openedCSVFile),
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            ldr.dm,
+		timezone:      ldr.timezone,
+		filterS:       ldr.filterS,
+		connMgr:       ldr.connMgr,
+		cacheConns:    ldr.cacheConns,
+		dryRunRpt:     engine.NewDryRunReport(),
+	}
+	for ldrType, fNames := range ldr.rdrs {
+		dryLdr.rdrs[ldrType] = make(map[string]*openedCSVFile)
+		for fName := range fNames {
+			dryLdr.rdrs[ldrType][fName] = nil
+		}
+	}
+	return
+}
+
+// dryRunStopped returns true if the dry run needs to stop on the errors already found
+func (ldr *Loader) dryRunStopped() bool {
+	return ldr.dryRunRpt != nil && ldr.dryRunStop && ldr.dryRunRpt.HasErrors()
+}
+
+// addDryRunError records the error in the dry run report
+// returns false if the loader is not in dry run so the error needs to be handled by the caller
+func (ldr *Loader) addDryRunError(loaderType, tntID string, line int, err error) bool {
+	if ldr.dryRunRpt == nil {
+		return false
+	}
+	ldr.dryRunRpt.AddError(loaderType, tntID, line, err)
+	return true
+}
+
+// getSources returns the sources for tpInDir and tpOutDir, creating them on first use
+// dst is nil if the files are not moved after processing
+func (ldr *Loader) getSources() (src, dst loaderSource, err error) {
+	ldr.srcMux.Lock()
+	defer ldr.srcMux.Unlock()
+	if ldr.src == nil {
+		if src, err = newLoaderSource(ldr.tpInDir, ldr.opts); err != nil {
+			return
+		}
+		ldr.src = src
+	}
+	if ldr.dst == nil && ldr.tpOutDir != utils.EmptyString {
+		if dst, err = newLoaderSource(ldr.tpOutDir, ldr.opts); err != nil {
+			return
+		}
+		ldr.dst = dst
+	}
+	return ldr.src, ldr.dst, nil
+}
+
 // lockFolder will attempt to lock the folder by creating the lock file
 func (ldr *Loader) lockFolder() (err error) {
-	_, err = os.OpenFile(path.Join(ldr.tpInDir, ldr.lockFilename),
-		os.O_RDONLY|os.O_CREATE, 0644)
-	return
+	var src loaderSource
+	if src, _, err = ldr.getSources(); err != nil {
+		return
+	}
+	return src.Lock(ldr.lockFilename)
 }
 
 func (ldr *Loader) unlockFolder() (err error) {
-	return os.Remove(path.Join(ldr.tpInDir,
-		ldr.lockFilename))
+	var src loaderSource
+	if src, _, err = ldr.getSources(); err != nil {
+		return
+	}
+	return src.Unlock(ldr.lockFilename)
 }
 
 func (ldr *Loader) isFolderLocked() (locked bool, err error) {
-	if _, err = os.Stat(path.Join(ldr.tpInDir,
-		ldr.lockFilename)); err == nil {
-		return true, nil
-	}
-	if os.IsNotExist(err) {
-		return false, nil
+	var src loaderSource
+	if src, _, err = ldr.getSources(); err != nil {
+		return
 	}
-	return
+	return src.IsLocked(ldr.lockFilename)
 }
 
 // unreferenceFile will cleanup an used file by closing and removing from referece map
@@ -162,18 +270,25 @@ func (ldr *Loader) unreferenceFile(loaderType, fileName string) (err error) {
 }
 
 func (ldr *Loader) moveFiles() (err error) {
-	if ldr.tpOutDir == utils.EmptyString {
+	src, dst, err := ldr.getSources()
+	if err != nil {
+		return
+	}
+	if dst == nil {
+		if httpSrc, isHTTP := src.(*httpSource); isHTTP { // mark them as processed so they are not loaded again
+			fNames, _ := httpSrc.ListFiles()
+			for _, fName := range fNames {
+				httpSrc.RemoveFile(fName)
+			}
+		}
 		return
 	}
-	filesInDir, _ := ioutil.ReadDir(ldr.tpInDir)
-	for _, file := range filesInDir {
-		fName := file.Name()
+	fNames, _ := src.ListFiles()
+	for _, fName := range fNames {
 		if fName == ldr.lockFilename {
 			continue
 		}
-		oldPath := path.Join(ldr.tpInDir, fName)
-		newPath := path.Join(ldr.tpOutDir, fName)
-		if err = os.Rename(oldPath, newPath); err != nil {
+		if err = moveFile(src, dst, fName); err != nil {
 			return
 		}
 	}
@@ -181,16 +296,34 @@ func (ldr *Loader) moveFiles() (err error) {
 }
 
 func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err error) {
+	src, _, err := ldr.getSources()
+	if err != nil {
+		return
+	}
+	var unchanged []string // not changed since they were processed
 	for fName := range ldr.rdrs[loaderType] {
-		var rdr *os.File
-		if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
-			return err
+		var rdr io.ReadCloser
+		if rdr, err = src.OpenFile(fName); err == errUnchangedFile {
+			unchanged = append(unchanged, fName)
+			continue
+		} else if err == utils.ErrNotFound {
+			return utils.ErrPrefixNotFound(fName)
+		} else if err != nil {
+			return
 		}
-		csvReader := csv.NewReader(rdr)
-		csvReader.Comma = rune(ldr.fieldSep[0])
-		csvReader.Comment = '#'
-		ldr.rdrs[loaderType][fName] = &openedCSVFile{
-			fileName: fName, rdr: rdr, csvRdr: csvReader}
+		ldr.openCSVFile(loaderType, fName, rdr)
+		defer ldr.unreferenceFile(loaderType, fName)
+	}
+	if len(unchanged) == len(ldr.rdrs[loaderType]) { // nothing to load for this type
+		return nil
+	}
+	// the rows are read from all the files of the type at once so the unchanged ones are read again
+	for _, fName := range unchanged {
+		var rdr io.ReadCloser
+		if rdr, err = src.(unchangedFileOpener).OpenUnchangedFile(fName); err != nil {
+			return
+		}
+		ldr.openCSVFile(loaderType, fName, rdr)
 		defer ldr.unreferenceFile(loaderType, fName)
 	}
 	// based on load option will store or remove the content
@@ -207,11 +340,21 @@ func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err err
 	return
 }
 
+// openCSVFile references the file as a reader of the loaderType
+func (ldr *Loader) openCSVFile(loaderType, fName string, rdr io.ReadCloser) {
+	csvReader := csv.NewReader(rdr)
+	csvReader.Comma = rune(ldr.fieldSep[0])
+	csvReader.Comment = '#'
+	ldr.rdrs[loaderType][fName] = &openedCSVFile{
+		fileName: fName, rdr: rdr, csvRdr: csvReader}
+}
+
 //processContent will process the contect and will store it into database
 func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 	// start processing lines
 	keepLooping := true // controls looping
 	lineNr := 0
+	var bufLine int // the line where the buffered profile starts
 	for keepLooping {
 		lineNr++
 		var hasErrors bool
@@ -227,16 +370,32 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> reading line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.addDryRunError(loaderType, utils.EmptyString, lineNr, err)
+				if ldr.dryRunStopped() {
+					return nil
+				}
+				if ldr.stage != nil { // the atomic loads are aborted instead of ignoring the line
+					ldr.bufLoaderData = make(map[string][]LoaderData)
+					return
+				}
 			}
 			if hasErrors { // if any of the readers will give errors, we ignore the line
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromCSV(fName, padOptionalColumns(loaderType, record),
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.addDryRunError(loaderType, utils.EmptyString, lineNr, err)
+				if ldr.dryRunStopped() {
+					return nil
+				}
+				if ldr.stage != nil {
+					ldr.bufLoaderData = make(map[string][]LoaderData)
+					return err
+				}
 				hasErrors = true
 				continue
 			}
@@ -247,17 +406,27 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 			continue
 		}
 		tntID := lData.TenantID()
-		if _, has := ldr.bufLoaderData[tntID]; !has &&
-			len(ldr.bufLoaderData) == 1 { // process previous records before going futher
-			var prevTntID string
-			for prevTntID = range ldr.bufLoaderData {
-				break // have stolen the existing key in buffer
-			}
-			if err = ldr.storeLoadedData(loaderType,
-				map[string][]LoaderData{prevTntID: ldr.bufLoaderData[prevTntID]}, caching); err != nil {
-				return
+		if _, has := ldr.bufLoaderData[tntID]; !has {
+			if len(ldr.bufLoaderData) == 1 { // process previous records before going futher
+				var prevTntID string
+				for prevTntID = range ldr.bufLoaderData {
+					break // have stolen the existing key in buffer
+				}
+				if ldr.dryRunRpt != nil {
+					ldr.dryRunRpt.SetLine(loaderType, prevTntID, bufLine)
+				}
+				if err = ldr.storeLoadedData(loaderType,
+					map[string][]LoaderData{prevTntID: ldr.bufLoaderData[prevTntID]}, caching); err != nil &&
+					!ldr.addDryRunError(loaderType, prevTntID, 0, err) {
+					return
+				}
+				err = nil
+				delete(ldr.bufLoaderData, prevTntID)
+				if ldr.dryRunStopped() {
+					return
+				}
 			}
-			delete(ldr.bufLoaderData, prevTntID)
+			bufLine = lineNr
 		}
 		ldr.bufLoaderData[tntID] = append(ldr.bufLoaderData[tntID], lData)
 	}
@@ -266,10 +435,15 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 	for tntID = range ldr.bufLoaderData {
 		break // get the first tenantID
 	}
+	if ldr.dryRunRpt != nil {
+		ldr.dryRunRpt.SetLine(loaderType, tntID, bufLine)
+	}
 	if err = ldr.storeLoadedData(loaderType,
-		map[string][]LoaderData{tntID: ldr.bufLoaderData[tntID]}, caching); err != nil {
+		map[string][]LoaderData{tntID: ldr.bufLoaderData[tntID]}, caching); err != nil &&
+		!ldr.addDryRunError(loaderType, tntID, 0, err) {
 		return
 	}
+	err = nil
 	delete(ldr.bufLoaderData, tntID)
 	return
 }
@@ -295,6 +469,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, apf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: AttributeProfile: %s",
@@ -325,6 +505,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, res); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ResourceProfile: %s",
@@ -368,6 +554,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, fltrPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: Filter: %s",
@@ -397,6 +589,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, stsPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: StatsQueueProfile: %s",
@@ -443,6 +641,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, thPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ThresholdProfile: %s",
@@ -477,6 +681,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, spPrf); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RouteProfile: %s",
@@ -507,6 +717,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, cpp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ChargerProfile: %s",
@@ -536,6 +752,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, dsp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherProfile: %s",
@@ -561,6 +783,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 			}
 			for _, tpDsp := range dispModels.AsTPDispatcherHosts() {
 				dsp := engine.APItoDispatcherHost(tpDsp)
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, dsp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: DispatcherHost: %s",
@@ -590,6 +818,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, rpl); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: RateProfile: %s",
@@ -626,6 +860,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, acp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: ActionProfile: %s",
@@ -659,6 +899,12 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				if err != nil {
 					return err
 				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, acp); err != nil {
+						return err
+					}
+					continue
+				}
 				if ldr.dryRun {
 					utils.Logger.Info(
 						fmt.Sprintf("<%s-%s> DRY_RUN: AccountProfiles: %s",
@@ -672,47 +918,146 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 			}
 		}
-	}
-
-	if len(ldr.cacheConns) != 0 {
-		var reply string
-		switch caching {
-		case utils.MetaNone:
-			return
-		case utils.MetaReload:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1ReloadCache, utils.AttrReloadCacheWithOpts{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
+	case utils.MetaExchangeRateProfiles:
+		for _, lDataSet := range lds {
+			erpMdls := make(engine.ExchangeRateProfileMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				erpMdls[i] = new(engine.ExchangeRateProfileMdl)
+				if err = utils.UpdateStructWithIfaceMap(erpMdls[i], ld); err != nil {
+					return
+				}
 			}
-		case utils.MetaLoad:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1LoadCache, utils.AttrReloadCacheWithOpts{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
+			for _, tpErp := range erpMdls.AsTPExchangeRateProfile() {
+				erp, err := engine.APItoExchangeRateProfile(tpErp, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, erp); err != nil {
+						return err
+					}
+					continue
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRateProfiles: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(erp)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, erp.TenantID())
+				if err := ldr.dm.SetExchangeRateProfile(erp); err != nil {
+					return err
+				}
+				cacheArgs[utils.ExchangeRateProfileIDs] = ids
 			}
-		case utils.MetaRemove:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1RemoveItems, utils.AttrReloadCacheWithOpts{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
+		}
+	case utils.MetaRateDecks:
+		for _, lDataSet := range lds {
+			rdMdls := make(engine.RateDeckMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				rdMdls[i] = new(engine.RateDeckMdl)
+				if err = utils.UpdateStructWithIfaceMap(rdMdls[i], ld); err != nil {
+					return
+				}
 			}
-		case utils.MetaClear:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1Clear, new(utils.AttrCacheIDsWithOpts), &reply); err != nil {
-				return
+			for _, tpRd := range rdMdls.AsTPRateDeck() {
+				rd, err := engine.APItoRateDeck(tpRd, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, rd); err != nil {
+						return err
+					}
+					continue
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: RateDecks: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(rd)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, rd.TenantID())
+				if err := ldr.dm.SetRateDeck(rd); err != nil {
+					return err
+				}
+				cacheArgs[utils.RateDeckIDs] = ids
 			}
 		}
-		if len(cacheIDs) != 0 {
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil, utils.CacheSv1Clear, &utils.AttrCacheIDsWithOpts{
-				CacheIDs: cacheIDs,
-			}, &reply); err != nil {
-				return
+	case utils.MetaTaxProfiles:
+		cacheIDs = []string{utils.CacheTaxProfilesFilterIndexes}
+		for _, lDataSet := range lds {
+			txpMdls := make(engine.TaxProfileMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				txpMdls[i] = new(engine.TaxProfileMdl)
+				if err = utils.UpdateStructWithIfaceMap(txpMdls[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpTxp := range txpMdls.AsTPTaxProfile() {
+				txp, err := engine.APItoTaxProfile(tpTxp, ldr.timezone)
+				if err != nil {
+					return err
+				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, txp); err != nil {
+						return err
+					}
+					continue
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: TaxProfiles: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(txp)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, txp.TenantID())
+				if err := ldr.dm.SetTaxProfile(txp, true); err != nil {
+					return err
+				}
+				cacheArgs[utils.TaxProfileIDs] = ids
+			}
+		}
+	case utils.MetaLookupTables:
+		for _, lDataSet := range lds {
+			ltMdls := make(engine.LookupTableMdls, len(lDataSet))
+			for i, ld := range lDataSet {
+				ltMdls[i] = new(engine.LookupTableMdl)
+				if err = utils.UpdateStructWithIfaceMap(ltMdls[i], ld); err != nil {
+					return
+				}
+			}
+			for _, tpLt := range ltMdls.AsTPLookupTable() {
+				lt, err := engine.APItoLookupTable(tpLt)
+				if err != nil {
+					return err
+				}
+				if ldr.isStaging() {
+					if err := ldr.stageProfile(loaderType, lt); err != nil {
+						return err
+					}
+					continue
+				}
+				if ldr.dryRun {
+					utils.Logger.Info(
+						fmt.Sprintf("<%s-%s> DRY_RUN: LookupTables: %s",
+							utils.LoaderS, ldr.ldrID, utils.ToJSON(lt)))
+					continue
+				}
+				// get IDs so we can reload in cache
+				ids = append(ids, lt.TenantID())
+				if err := ldr.dm.SetLookupTable(lt); err != nil {
+					return err
+				}
+				cacheArgs[utils.LookupTableIDs] = ids
 			}
 		}
-
 	}
-	return
+
+	return ldr.updateCache(caching, cacheArgs, cacheIDs)
 }
 
 //removeContent will process the content and will remove it from database
@@ -720,6 +1065,7 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 	// start processing lines
 	keepLooping := true // controls looping
 	lineNr := 0
+	var bufLine int // the line where the buffered profile starts
 	for keepLooping {
 		lineNr++
 		var hasErrors bool
@@ -735,16 +1081,32 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> reading line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.addDryRunError(loaderType, utils.EmptyString, lineNr, err)
+				if ldr.dryRunStopped() {
+					return nil
+				}
+				if ldr.stage != nil { // the atomic loads are aborted instead of ignoring the line
+					ldr.bufLoaderData = make(map[string][]LoaderData)
+					return
+				}
 			}
 			if hasErrors { // if any of the readers will give errors, we ignore the line
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromCSV(fName, padOptionalColumns(loaderType, record),
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
 						utils.LoaderS, ldr.ldrID, lineNr, err.Error()))
+				ldr.addDryRunError(loaderType, utils.EmptyString, lineNr, err)
+				if ldr.dryRunStopped() {
+					return nil
+				}
+				if ldr.stage != nil {
+					ldr.bufLoaderData = make(map[string][]LoaderData)
+					return err
+				}
 				hasErrors = true
 				continue
 			}
@@ -755,17 +1117,27 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 			continue
 		}
 		tntID := lData.TenantID()
-		if _, has := ldr.bufLoaderData[tntID]; !has &&
-			len(ldr.bufLoaderData) == 1 { // process previous records before going futher
-			var prevTntID string
-			for prevTntID = range ldr.bufLoaderData {
-				break // have stolen the existing key in buffer
-			}
-			if err = ldr.removeLoadedData(loaderType,
-				map[string][]LoaderData{prevTntID: ldr.bufLoaderData[prevTntID]}, caching); err != nil {
-				return
+		if _, has := ldr.bufLoaderData[tntID]; !has {
+			if len(ldr.bufLoaderData) == 1 { // process previous records before going futher
+				var prevTntID string
+				for prevTntID = range ldr.bufLoaderData {
+					break // have stolen the existing key in buffer
+				}
+				if ldr.dryRunRpt != nil {
+					ldr.dryRunRpt.SetLine(loaderType, prevTntID, bufLine)
+				}
+				if err = ldr.removeLoadedData(loaderType,
+					map[string][]LoaderData{prevTntID: ldr.bufLoaderData[prevTntID]}, caching); err != nil &&
+					!ldr.addDryRunError(loaderType, prevTntID, 0, err) {
+					return
+				}
+				err = nil
+				delete(ldr.bufLoaderData, prevTntID)
+				if ldr.dryRunStopped() {
+					return
+				}
 			}
-			delete(ldr.bufLoaderData, prevTntID)
+			bufLine = lineNr
 		}
 		ldr.bufLoaderData[tntID] = append(ldr.bufLoaderData[tntID], lData)
 	}
@@ -774,17 +1146,30 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 	for tntID = range ldr.bufLoaderData {
 		break // get the first tenantID
 	}
+	if ldr.dryRunRpt != nil {
+		ldr.dryRunRpt.SetLine(loaderType, tntID, bufLine)
+	}
 	if err = ldr.removeLoadedData(loaderType,
-		map[string][]LoaderData{tntID: ldr.bufLoaderData[tntID]}, caching); err != nil {
+		map[string][]LoaderData{tntID: ldr.bufLoaderData[tntID]}, caching); err != nil &&
+		!ldr.addDryRunError(loaderType, tntID, 0, err) {
 		return
 	}
+	err = nil
 	delete(ldr.bufLoaderData, tntID)
 	return
 }
 
-//removeLoadedData will remove the data from database
-//since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
+// removeLoadedData will remove the data from database
+// since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
 func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderData, caching string) (err error) {
+	if ldr.isStaging() {
+		for tntID, ldData := range lds {
+			if err = ldr.stageRemove(loaderType, tntID, ldData); err != nil {
+				return
+			}
+		}
+		return
+	}
 	var ids []string
 	cacheArgs := make(map[string][]string)
 	var cacheIDs []string // verify if we need to clear indexe
@@ -1028,8 +1413,82 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				}
 			}
 		}
+	case utils.MetaExchangeRateProfiles:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: ExchangeRateProfileIDs: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveExchangeRateProfile(tntIDStruct.Tenant,
+					tntIDStruct.ID, utils.NonTransactional); err != nil {
+					return err
+				}
+				cacheArgs[utils.ExchangeRateProfileIDs] = ids
+			}
+		}
+	case utils.MetaRateDecks:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: RateDeckIDs: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveRateDeck(tntIDStruct.Tenant,
+					tntIDStruct.ID, utils.NonTransactional); err != nil {
+					return err
+				}
+				cacheArgs[utils.RateDeckIDs] = ids
+			}
+		}
+	case utils.MetaTaxProfiles:
+		cacheIDs = []string{utils.CacheTaxProfiles, utils.CacheTaxProfilesFilterIndexes}
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: TaxProfileIDs: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveTaxProfile(tntIDStruct.Tenant,
+					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+					return err
+				}
+				cacheArgs[utils.TaxProfileIDs] = ids
+			}
+		}
+	case utils.MetaLookupTables:
+		for tntID := range lds {
+			if ldr.dryRun {
+				utils.Logger.Info(
+					fmt.Sprintf("<%s-%s> DRY_RUN: LookupTableIDs: %s",
+						utils.LoaderS, ldr.ldrID, tntID))
+			} else {
+				tntIDStruct := utils.NewTenantID(tntID)
+				// get IDs so we can reload in cache
+				ids = append(ids, tntID)
+				if err := ldr.dm.RemoveLookupTable(tntIDStruct.Tenant,
+					tntIDStruct.ID, utils.NonTransactional); err != nil {
+					return err
+				}
+				cacheArgs[utils.LookupTableIDs] = ids
+			}
+		}
 	}
 
+	return ldr.updateCache(caching, cacheArgs, cacheIDs)
+}
+
+// updateCache updates the caches after the data was written into DataDB
+func (ldr *Loader) updateCache(caching string, cacheArgs map[string][]string, cacheIDs []string) (err error) {
 	if len(ldr.cacheConns) != 0 {
 		var reply string
 		switch caching {
@@ -1076,6 +1535,11 @@ func (ldr *Loader) serve(stopChan chan struct{}) (err error) {
 	case time.Duration(0): // 0 disables the automatic read, maybe done per API
 		return
 	case time.Duration(-1):
+		if src, _, err := ldr.getSources(); err != nil {
+			return err
+		} else if _, isLocal := src.(localSource); !isLocal {
+			return fmt.Errorf("cannot watch for changes the path: <%s>", ldr.tpInDir)
+		}
 		return utils.WatchDir(ldr.tpInDir, ldr.processFile,
 			utils.LoaderS+"-"+ldr.ldrID, stopChan)
 	default:
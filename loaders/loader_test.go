@@ -20,7 +20,10 @@ package loaders
 
 import (
 	"encoding/csv"
+	"encoding/json"
 	"io/ioutil"
+	"os"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
@@ -1052,6 +1055,425 @@ cgrates.org,ACTPRF_ID1
 	}
 }
 
+func TestLoaderProcessExchangeRateProfiles(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessExchangeRateProfiles",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaExchangeRateProfiles: {
+			{Tag: "TenantID",
+				Path:      "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ProfileID",
+				Path:      "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "FromCurrency",
+				Path:  "FromCurrency",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Tag: "ToCurrency",
+				Path:  "ToCurrency",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Tag: "ActivationTime",
+				Path:  "ActivationTime",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			{Tag: "Rate",
+				Path:  "Rate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+		},
+	}
+	erpCsv := `
+#Tenant[0],ID[1],FromCurrency[2],ToCurrency[3],ActivationTime[4],Rate[5]
+cgrates.org,ECB,USD,EUR,2021-02-01T00:00:00Z,0.83
+cgrates.org,ECB,USD,EUR,2021-01-01T00:00:00Z,0.82
+cgrates.org,ECB,GBP,EUR,,1.12
+`
+	rdr := ioutil.NopCloser(strings.NewReader(erpCsv))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaExchangeRateProfiles: {
+			utils.ExchangeRateProfilesCsv: &openedCSVFile{
+				fileName: utils.ExchangeRateProfilesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaExchangeRateProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eErp := &utils.ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ECB",
+		Rates: []*utils.ExchangeRate{
+			{FromCurrency: "GBP", ToCurrency: "EUR", Rate: utils.NewDecimalFromFloat64(1.12)},
+			{FromCurrency: "USD", ToCurrency: "EUR", Rate: utils.NewDecimalFromFloat64(0.82),
+				ActivationTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{FromCurrency: "USD", ToCurrency: "EUR", Rate: utils.NewDecimalFromFloat64(0.83),
+				ActivationTime: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	if rcv, err := ldr.dm.GetExchangeRateProfile("cgrates.org", "ECB",
+		false, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(utils.ToJSON(eErp), utils.ToJSON(rcv)) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eErp), utils.ToJSON(rcv))
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(erpCsv))
+	csvRdr = csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaExchangeRateProfiles: {
+			utils.ExchangeRateProfilesCsv: &openedCSVFile{
+				fileName: utils.ExchangeRateProfilesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.removeContent(utils.MetaExchangeRateProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetExchangeRateProfile("cgrates.org", "ECB",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderProcessRateDecks(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessRateDecks",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaRateDecks: {
+			{Tag: "TenantID",
+				Path:      "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ProfileID",
+				Path:      "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "IncreaseNotice",
+				Path:  "IncreaseNotice",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Tag: "DecreaseNotice",
+				Path:  "DecreaseNotice",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Tag: "Prefix",
+				Path:  "Prefix",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			{Tag: "Description",
+				Path:  "Description",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+			{Tag: "Rate",
+				Path:  "Rate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.6", utils.InfieldSep)},
+			{Tag: "EffectiveDate",
+				Path:  "EffectiveDate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.7", utils.InfieldSep)},
+			{Tag: "ExpiryDate",
+				Path:  "ExpiryDate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.8", utils.InfieldSep)},
+		},
+	}
+	rdCsv := `
+#Tenant[0],ID[1],IncreaseNotice[2],DecreaseNotice[3],Prefix[4],Description[5],Rate[6],EffectiveDate[7],ExpiryDate[8]
+cgrates.org,CARRIER1,168h,,4930,Germany Berlin,0.02,2021-01-01T00:00:00Z,
+cgrates.org,CARRIER1,,,49,Germany,0.05,2021-01-01T00:00:00Z,2022-01-01T00:00:00Z
+`
+	rdr := ioutil.NopCloser(strings.NewReader(rdCsv))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaRateDecks: {
+			utils.RateDecksCsv: &openedCSVFile{
+				fileName: utils.RateDecksCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaRateDecks, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eRd := &engine.RateDeck{
+		Tenant:         "cgrates.org",
+		ID:             "CARRIER1",
+		IncreaseNotice: 168 * time.Hour,
+		Rates: []*engine.RateDeckRate{
+			{Prefix: "49", Description: "Germany", Rate: utils.NewDecimalFromFloat64(0.05),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+				ExpiryDate:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Prefix: "4930", Description: "Germany Berlin", Rate: utils.NewDecimalFromFloat64(0.02),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	if rcv, err := ldr.dm.GetRateDeck("cgrates.org", "CARRIER1",
+		false, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(utils.ToJSON(eRd), utils.ToJSON(rcv)) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eRd), utils.ToJSON(rcv))
+	} else if rdRt, err := rcv.RateForPrefix("49301234", time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if rdRt.Prefix != "4930" {
+		t.Errorf("unexpected rate: %s", utils.ToJSON(rdRt))
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(rdCsv))
+	csvRdr = csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaRateDecks: {
+			utils.RateDecksCsv: &openedCSVFile{
+				fileName: utils.RateDecksCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.removeContent(utils.MetaRateDecks, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetRateDeck("cgrates.org", "CARRIER1",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderProcessTaxProfiles(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessTaxProfiles",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaTaxProfiles: {
+			{Tag: "Tenant",
+				Path:      "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ID",
+				Path:      "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "FilterIDs",
+				Path:  "FilterIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Tag: "ActivationInterval",
+				Path:  "ActivationInterval",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Tag: "Weights",
+				Path:  "Weights",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			{Tag: "Jurisdiction",
+				Path:  "Jurisdiction",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+			{Tag: "TaxType",
+				Path:  "TaxType",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.6", utils.InfieldSep)},
+			{Tag: "Rate",
+				Path:  "Rate",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.7", utils.InfieldSep)},
+			{Tag: "Compound",
+				Path:  "Compound",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.8", utils.InfieldSep)},
+			{Tag: "ExemptFilterIDs",
+				Path:  "ExemptFilterIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.9", utils.InfieldSep)},
+		},
+	}
+	txCsv := `
+#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],Weights[4],Jurisdiction[5],TaxType[6],Rate[7],Compound[8],ExemptFilterIDs[9]
+cgrates.org,TAX_DE_VAT,*string:~*req.Country:DE,2021-01-01T00:00:00Z,;20,DE,VAT,0.19,false,*string:~*req.TaxExempt:true
+cgrates.org,TAX_DE_VAT,*prefix:~*req.Destination:49,,,,,,,
+`
+	rdr := ioutil.NopCloser(strings.NewReader(txCsv))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaTaxProfiles: {
+			utils.TaxProfilesCsv: &openedCSVFile{
+				fileName: utils.TaxProfilesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaTaxProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eTxp := &engine.TaxProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TAX_DE_VAT",
+		FilterIDs: []string{"*string:~*req.Country:DE", "*prefix:~*req.Destination:49"},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Weights: utils.DynamicWeights{
+			{
+				Weight: 20,
+			},
+		},
+		Jurisdiction:    "DE",
+		TaxType:         "VAT",
+		Rate:            utils.NewDecimalFromFloat64(0.19),
+		ExemptFilterIDs: []string{"*string:~*req.TaxExempt:true"},
+	}
+	if rcv, err := ldr.dm.GetTaxProfile("cgrates.org", "TAX_DE_VAT",
+		false, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(utils.ToJSON(eTxp), utils.ToJSON(rcv)) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eTxp), utils.ToJSON(rcv))
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(txCsv))
+	csvRdr = csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaTaxProfiles: {
+			utils.TaxProfilesCsv: &openedCSVFile{
+				fileName: utils.TaxProfilesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.removeContent(utils.MetaTaxProfiles, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetTaxProfile("cgrates.org", "TAX_DE_VAT",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderProcessLookupTables(t *testing.T) {
+	data := engine.NewInternalDB(nil, nil, true)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessLookupTables",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+	}
+	ldr.dataTpls = map[string][]*config.FCTemplate{
+		utils.MetaLookupTables: {
+			{Tag: "Tenant",
+				Path:      "Tenant",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "ID",
+				Path:      "ID",
+				Type:      utils.MetaComposed,
+				Value:     config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep),
+				Mandatory: true},
+			{Tag: "Mode",
+				Path:  "Mode",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+			{Tag: "Default",
+				Path:  "Default",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+			{Tag: "Key",
+				Path:  "Key",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			{Tag: "Value",
+				Path:  "Value",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+		},
+	}
+	ltCsv := `
+#Tenant[0],ID[1],Mode[2],Default[3],Key[4],Value[5]
+cgrates.org,PORTED_NUMBERS,*prefix,HOME_NET,4915,NET_A
+cgrates.org,PORTED_NUMBERS,,,491511,NET_B
+`
+	rdr := ioutil.NopCloser(strings.NewReader(ltCsv))
+	csvRdr := csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaLookupTables: {
+			utils.LookupTablesCsv: &openedCSVFile{
+				fileName: utils.LookupTablesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.processContent(utils.MetaLookupTables, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eLt := &engine.LookupTable{
+		Tenant:  "cgrates.org",
+		ID:      "PORTED_NUMBERS",
+		Mode:    utils.MetaPrefix,
+		Default: "HOME_NET",
+		Entries: map[string]string{
+			"4915":   "NET_A",
+			"491511": "NET_B",
+		},
+	}
+	if rcv, err := ldr.dm.GetLookupTable("cgrates.org", "PORTED_NUMBERS",
+		false, false, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(eLt, rcv) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eLt), utils.ToJSON(rcv))
+	}
+
+	rdr = ioutil.NopCloser(strings.NewReader(ltCsv))
+	csvRdr = csv.NewReader(rdr)
+	csvRdr.Comment = '#'
+	ldr.rdrs = map[string]map[string]*openedCSVFile{
+		utils.MetaLookupTables: {
+			utils.LookupTablesCsv: &openedCSVFile{
+				fileName: utils.LookupTablesCsv, rdr: rdr,
+				csvRdr: csvRdr,
+			},
+		},
+	}
+	if err := ldr.removeContent(utils.MetaLookupTables, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ldr.dm.GetLookupTable("cgrates.org", "PORTED_NUMBERS",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
 func TestLoaderProcessChargers(t *testing.T) {
 	data := engine.NewInternalDB(nil, nil, true)
 	ldr := &Loader{
@@ -1092,6 +1514,26 @@ func TestLoaderProcessChargers(t *testing.T) {
 				Path:  "Weight",
 				Type:  utils.MetaComposed,
 				Value: config.NewRSRParsersMustCompile("~*req.6", utils.InfieldSep)},
+			{Tag: "AggregationPath",
+				Path:  "AggregationPath",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.7", utils.InfieldSep)},
+			{Tag: "AggregationFilterIDs",
+				Path:  "AggregationFilterIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.8", utils.InfieldSep)},
+			{Tag: "AggregationType",
+				Path:  "AggregationType",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.9", utils.InfieldSep)},
+			{Tag: "AggregationRunIDs",
+				Path:  "AggregationRunIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.10", utils.InfieldSep)},
+			{Tag: "AggregationThresholdIDs",
+				Path:  "AggregationThresholdIDs",
+				Type:  utils.MetaComposed,
+				Value: config.NewRSRParsersMustCompile("~*req.11", utils.InfieldSep)},
 		},
 	}
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ChargersCSVContent))
@@ -1118,6 +1560,20 @@ func TestLoaderProcessChargers(t *testing.T) {
 		RunID:        "*rated",
 		AttributeIDs: []string{"ATTR_1001_SIMPLEAUTH"},
 		Weight:       20,
+		Aggregations: []*engine.ChargerAggregation{
+			{
+				Path:         "Margin",
+				Type:         utils.MetaDifference,
+				RunIDs:       []string{"*rated", "SupplierCharges"},
+				ThresholdIDs: []string{"THD_NEGATIVE_MARGIN"},
+			},
+			{
+				Path:      "TotalCosts",
+				FilterIDs: []string{"*string:~*req.Category:call"},
+				Type:      utils.MetaSum,
+				RunIDs:    []string{"*rated", "SupplierCharges"},
+			},
+		},
 	}
 
 	if rcv, err := ldr.dm.GetChargerProfile("cgrates.org", "Charger1",
@@ -2403,20 +2859,24 @@ func TestNewLoaderWithMultiFiles(t *testing.T) {
 		}
 	}
 	expected := utils.StringSet{
-		utils.AttributesCsv:         {},
-		utils.ChargersCsv:           {},
-		utils.DispatcherHostsCsv:    {},
-		utils.DispatcherProfilesCsv: {},
-		"File1.csv":                 {},
-		"File2.csv":                 {},
-		utils.FiltersCsv:            {},
-		utils.RateProfilesCsv:       {},
-		utils.ResourcesCsv:          {},
-		utils.RoutesCsv:             {},
-		utils.StatsCsv:              {},
-		utils.ThresholdsCsv:         {},
-		utils.ActionProfilesCsv:     {},
-		utils.AccountProfilesCsv:    {},
+		utils.AttributesCsv:           {},
+		utils.ChargersCsv:             {},
+		utils.DispatcherHostsCsv:      {},
+		utils.DispatcherProfilesCsv:   {},
+		"File1.csv":                   {},
+		"File2.csv":                   {},
+		utils.FiltersCsv:              {},
+		utils.RateProfilesCsv:         {},
+		utils.ResourcesCsv:            {},
+		utils.RoutesCsv:               {},
+		utils.StatsCsv:                {},
+		utils.ThresholdsCsv:           {},
+		utils.ActionProfilesCsv:       {},
+		utils.AccountProfilesCsv:      {},
+		utils.ExchangeRateProfilesCsv: {},
+		utils.RateDecksCsv:            {},
+		utils.TaxProfilesCsv:          {},
+		utils.LookupTablesCsv:         {},
 	}
 	if !reflect.DeepEqual(expected, openRdrs) {
 		t.Errorf("Expected %s,received %s", utils.ToJSON(expected), utils.ToJSON(openRdrs))
@@ -5151,3 +5611,356 @@ func TestLoaderServiceEnabled(t *testing.T) {
 		t.Errorf("Expected false, received %+v", rcv)
 	}
 }
+
+func TestLoaderServiceV1DryRun(t *testing.T) {
+	tpInDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderServiceV1DryRun")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tpInDir)
+	chargersCSV := `#Tenant[0],ID[1],FilterIDs[2],ActivationInterval[3],RunID[4],AttributeIDs[5],Weight[6]
+cgrates.org,CHRG_1,*string:~*req.Account:1001,,*default,ATTR_1,20
+cgrates.org,CHRG_2,FLTR_MISSING,,*default,ATTR_MISSING,10
+cgrates.org,CHRG_3,*wrong:~*req.Account:1001,,*default,*none,10
+cgrates.org,CHRG_EXISTING,,,*default,*none,30
+`
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.ChargersCsv), []byte(chargersCSV), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	if err := dm.SetAttributeProfile(&engine.AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_1",
+		Contexts: []string{utils.MetaAny},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetChargerProfile(&engine.ChargerProfile{
+		Tenant:       "cgrates.org",
+		ID:           "CHRG_EXISTING",
+		RunID:        utils.MetaDefault,
+		AttributeIDs: []string{utils.MetaNone},
+		Weight:       10,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	ldr := &Loader{
+		ldrID:         utils.MetaDefault,
+		tpInDir:       tpInDir,
+		lockFilename:  ".lck",
+		fieldSep:      utils.FieldsSep,
+		dm:            dm,
+		timezone:      "UTC",
+		bufLoaderData: make(map[string][]LoaderData),
+		dataTpls: map[string][]*config.FCTemplate{
+			utils.MetaChargers: {
+				{Path: "Tenant", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep)},
+				{Path: "ID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep)},
+				{Path: "FilterIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+				{Path: "ActivationInterval", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+				{Path: "RunID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+				{Path: "AttributeIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+				{Path: "Weight", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.6", utils.InfieldSep)},
+			},
+		},
+		rdrs: map[string]map[string]*openedCSVFile{
+			utils.MetaChargers: {utils.ChargersCsv: nil},
+		},
+	}
+	ldrS := &LoaderService{ldrs: map[string]*Loader{utils.MetaDefault: ldr}}
+
+	if err := ldr.lockFolder(); err != nil { // held by another load
+		t.Fatal(err)
+	}
+	var rpt engine.DryRunReport
+	if err := ldrS.V1DryRun(&ArgsProcessFolder{}, &rpt); err != nil {
+		t.Fatal(err)
+	}
+	if locked, err := ldr.isFolderLocked(); err != nil {
+		t.Fatal(err)
+	} else if !locked {
+		t.Error("Expected the lock of the running load to be kept")
+	}
+	expCreated := map[string][]string{
+		utils.MetaChargers: {"cgrates.org:CHRG_1", "cgrates.org:CHRG_2", "cgrates.org:CHRG_3"},
+	}
+	if !reflect.DeepEqual(rpt.Created, expCreated) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expCreated), utils.ToJSON(rpt.Created))
+	}
+	expUpdated := map[string][]string{utils.MetaChargers: {"cgrates.org:CHRG_EXISTING"}}
+	if !reflect.DeepEqual(rpt.Updated, expUpdated) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expUpdated), utils.ToJSON(rpt.Updated))
+	}
+	expErrs := []*engine.DryRunError{
+		{LoaderType: utils.MetaChargers, ID: "cgrates.org:CHRG_2", Line: 2,
+			Error: "broken reference to *filters: <cgrates.org:FLTR_MISSING>"},
+		{LoaderType: utils.MetaChargers, ID: "cgrates.org:CHRG_2", Line: 2,
+			Error: "broken reference to *attributes: <cgrates.org:ATTR_MISSING>"},
+		{LoaderType: utils.MetaChargers, ID: "cgrates.org:CHRG_3", Line: 3,
+			Error: "Unsupported filter Type: *wrong"},
+	}
+	if !reflect.DeepEqual(rpt.Errors, expErrs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expErrs), utils.ToJSON(rpt.Errors))
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_1", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_EXISTING", false, false,
+		utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if chrg.Weight != 10 {
+		t.Errorf("Expected the profile to not be updated, received %s", utils.ToJSON(chrg))
+	}
+	if _, err := os.Stat(path.Join(tpInDir, utils.ChargersCsv)); err != nil {
+		t.Errorf("Expected the file to not be moved, received %v", err)
+	}
+	if ldr.rdrs[utils.MetaChargers][utils.ChargersCsv] != nil || len(ldr.bufLoaderData) != 0 {
+		t.Error("Expected the state of the loader to not be changed by the dry run")
+	}
+	rpt = engine.DryRunReport{}
+	if err := ldrS.V1DryRun(&ArgsProcessFolder{StopOnError: true}, &rpt); err != nil {
+		t.Fatal(err)
+	}
+	expErrs = []*engine.DryRunError{
+		{LoaderType: utils.MetaChargers, ID: "cgrates.org:CHRG_3", Line: 3,
+			Error: "Unsupported filter Type: *wrong"},
+	}
+	if !reflect.DeepEqual(rpt.Errors, expErrs) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expErrs), utils.ToJSON(rpt.Errors))
+	}
+	if _, has := rpt.Updated[utils.MetaChargers]; has {
+		t.Errorf("Expected the validation to stop at CHRG_3, received %s", utils.ToJSON(rpt.Updated))
+	}
+	// the *dry_run load option of the normal loads
+	var rplyRpt string
+	if err := ldrS.V1Load(&ArgsProcessFolder{LoadOption: utils.MetaDryRunLoad,
+		StopOnError: true}, &rplyRpt); err != nil {
+		t.Fatal(err)
+	} else if exp := utils.ToJSON(rpt); rplyRpt != exp {
+		t.Errorf("Expected %s, received %s", exp, rplyRpt)
+	}
+	if err := ldrS.V1Remove(&ArgsProcessFolder{LoadOption: utils.MetaDryRunLoad}, &rplyRpt); err != nil {
+		t.Fatal(err)
+	}
+	rpt = engine.DryRunReport{}
+	if err := json.Unmarshal([]byte(rplyRpt), &rpt); err != nil {
+		t.Fatal(err)
+	}
+	expRemoved := map[string][]string{utils.MetaChargers: {"cgrates.org:CHRG_EXISTING"}}
+	if !reflect.DeepEqual(rpt.Removed, expRemoved) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(expRemoved), utils.ToJSON(rpt.Removed))
+	}
+	if len(rpt.Created) != 0 || len(rpt.Errors) != 3 {
+		t.Errorf("Expected the missing profiles reported as errors, received %s", utils.ToJSON(rpt))
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_EXISTING", false, false,
+		utils.NonTransactional); err != nil {
+		t.Errorf("Expected the profile to not be removed, received %v", err)
+	}
+	if err := ldrS.V1Load(&ArgsProcessFolder{LoadOption: "*wrong"}, &rplyRpt); err == nil ||
+		err.Error() != "UNSUPPORTED_LOAD_OPTION: *wrong" {
+		t.Errorf("Expected UNSUPPORTED_LOAD_OPTION, received %v", err)
+	}
+}
+
+func TestLoaderAtomicLoadRollback(t *testing.T) {
+	tpInDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderAtomicLoadRollback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tpInDir)
+	writeChargers := func(csv string) {
+		if err := ioutil.WriteFile(path.Join(tpInDir, utils.ChargersCsv), []byte(csv), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	if err := dm.SetChargerProfile(&engine.ChargerProfile{
+		Tenant:       "cgrates.org",
+		ID:           "CHRG_EXISTING",
+		RunID:        utils.MetaDefault,
+		AttributeIDs: []string{utils.MetaNone},
+		Weight:       10,
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+	ldr := &Loader{
+		ldrID:         utils.MetaDefault,
+		tpInDir:       tpInDir,
+		lockFilename:  ".lck",
+		fieldSep:      utils.FieldsSep,
+		dm:            dm,
+		timezone:      "UTC",
+		atomic:        true,
+		versionsLimit: 3,
+		bufLoaderData: make(map[string][]LoaderData),
+		dataTpls: map[string][]*config.FCTemplate{
+			utils.MetaChargers: {
+				{Path: "Tenant", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep)},
+				{Path: "ID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep)},
+				{Path: "FilterIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+				{Path: "RunID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+				{Path: "AttributeIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+				{Path: "Weight", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.5", utils.InfieldSep)},
+			},
+		},
+		rdrs: map[string]map[string]*openedCSVFile{
+			utils.MetaChargers: {utils.ChargersCsv: nil},
+		},
+	}
+	ldrS := &LoaderService{ldrs: map[string]*Loader{utils.MetaDefault: ldr}}
+	var reply string
+	if err := ldrS.V1Rollback(&ArgsRollback{}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	writeChargers(`cgrates.org,CHRG_1,,*default,*none,20
+cgrates.org,CHRG_EXISTING,,*default,*none,30
+`)
+	if err := ldrS.V1Load(&ArgsProcessFolder{Caching: utils.StringPointer(utils.MetaNone)}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_EXISTING", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if chrg.Weight != 30 {
+		t.Errorf("Expected %v, received %v", 30, chrg.Weight)
+	}
+	if _, err := dm.GetItemLoadIDs(utils.CacheChargerProfiles, false); err != nil {
+		t.Errorf("Expected the load ID to be set, received %v", err)
+	}
+
+	// the second profile fails on write so the first one is restored
+	writeChargers(`cgrates.org,CHRG_2,,*default,*none,20
+cgrates.org,CHRG_3,FLTR_MISSING,*default,*none,20
+`)
+	if err := ldrS.V1Load(&ArgsProcessFolder{Caching: utils.StringPointer(utils.MetaNone)}, &reply); err == nil {
+		t.Fatal("Expected the load to fail")
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_2", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	// the malformed lines abort the load
+	writeChargers(`cgrates.org,CHRG_2,,*default,*none,20
+cgrates.org,CHRG_3
+`)
+	if err := ldrS.V1Load(&ArgsProcessFolder{Caching: utils.StringPointer(utils.MetaNone)}, &reply); err == nil {
+		t.Fatal("Expected the load to fail")
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_2", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+
+	writeChargers(`cgrates.org,CHRG_2,,*default,*none,20
+`)
+	if err := ldrS.V1Load(&ArgsProcessFolder{Caching: utils.StringPointer(utils.MetaNone)}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	// the failed load was removed from DataDB so its version number is reused
+	if active, err := dm.GetActiveLoadVersion(utils.MetaDefault); err != nil {
+		t.Fatal(err)
+	} else if active != 2 {
+		t.Errorf("Expected the active version %v, received %v", 2, active)
+	}
+	var vers []*LoadVersion
+	if err := ldrS.V1GetLoadVersions(&ArgsGetLoadVersions{}, &vers); err != nil {
+		t.Fatal(err)
+	} else if len(vers) != 2 {
+		t.Fatalf("Expected 2 versions, received %s", utils.ToJSON(vers))
+	} else if exp := map[string][]string{
+		utils.MetaChargers: {"cgrates.org:CHRG_1", "cgrates.org:CHRG_EXISTING"},
+	}; !reflect.DeepEqual(vers[0].Items, exp) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(vers[0].Items))
+	}
+
+	if err := ldrS.V1Rollback(&ArgsRollback{Version: vers[0].Version,
+		Caching: utils.StringPointer(utils.MetaNone)}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	for _, id := range []string{"CHRG_1", "CHRG_2"} {
+		if _, err := dm.GetChargerProfile("cgrates.org", id, false, false,
+			utils.NonTransactional); err != utils.ErrNotFound {
+			t.Errorf("Expected %v for %s, received %v", utils.ErrNotFound, id, err)
+		}
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_EXISTING", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if chrg.Weight != 10 {
+		t.Errorf("Expected %v, received %v", 10, chrg.Weight)
+	}
+	if err := ldrS.V1GetLoadVersions(&ArgsGetLoadVersions{}, &vers); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestLoaderAtomicLoadRecover(t *testing.T) {
+	tpInDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderAtomicLoadRecover")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tpInDir)
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	chrg := &engine.ChargerProfile{
+		Tenant:       "cgrates.org",
+		ID:           "CHRG_STAGED",
+		RunID:        utils.MetaDefault,
+		AttributeIDs: []string{utils.MetaNone},
+	}
+	if err := dm.SetChargerProfile(chrg, false); err != nil {
+		t.Fatal(err)
+	}
+	// a load interrupted after writing the profile but before switching in its version
+	if err := dm.SetLoadVersion(&engine.LoadVersion{
+		LoaderID: utils.MetaDefault,
+		Version:  1,
+		Items: []*engine.LoadVersionItem{{
+			LoaderType: utils.MetaChargers,
+			TenantID:   chrg.TenantID(),
+			Profile:    []byte(utils.ToJSON(chrg)),
+		}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// the readers resolve the profile through the active version until the load is switched in
+	config.CgrConfig().LoaderCfg()[0].Atomic = true
+	defer func() { config.CgrConfig().LoaderCfg()[0].Atomic = false }()
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_STAGED", true, true,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_STAGED", true, false,
+		utils.NonTransactional); err != nil {
+		t.Errorf("Expected the profile written for update, received %v", err)
+	}
+	ldrS := &LoaderService{ldrs: map[string]*Loader{
+		utils.MetaDefault: {
+			ldrID:        utils.MetaDefault,
+			tpInDir:      tpInDir,
+			lockFilename: ".lck",
+			dm:           dm,
+			atomic:       true,
+		},
+	}}
+	var vers []*LoadVersion
+	if err := ldrS.V1GetLoadVersions(&ArgsGetLoadVersions{}, &vers); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	var reply string
+	if err := ldrS.V1Rollback(&ArgsRollback{Caching: utils.StringPointer(utils.MetaNone)},
+		&reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := dm.GetChargerProfile("cgrates.org", "CHRG_STAGED", false, false,
+		utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if _, err := dm.GetLoadVersion(utils.MetaDefault, 1); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
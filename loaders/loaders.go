@@ -66,6 +66,17 @@ type ArgsProcessFolder struct {
 	ForceLock   bool
 	Caching     *string
 	StopOnError bool
+	LoadOption  string // *dry_run to only validate the content, replying with the JSON encoded report
+}
+
+// dryRunFolder validates the load of the folder, replying with the JSON encoded report
+func dryRunFolder(ldr *Loader, loadOption string, stopOnError bool, rply *string) (err error) {
+	rpt, err := ldr.DryRunFolder(loadOption, stopOnError)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*rply = utils.ToJSON(rpt)
+	return
 }
 
 func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
@@ -79,6 +90,13 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 	if !has {
 		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
 	}
+	switch args.LoadOption {
+	case utils.EmptyString:
+	case utils.MetaDryRunLoad: // does not need the folder lock since the files are not moved
+		return dryRunFolder(ldr, utils.MetaStore, args.StopOnError, rply)
+	default:
+		return fmt.Errorf("UNSUPPORTED_LOAD_OPTION: %s", args.LoadOption)
+	}
 	if locked, err := ldr.isFolderLocked(); err != nil {
 		return utils.NewErrServerError(err)
 	} else if locked {
@@ -101,6 +119,27 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 	return
 }
 
+// V1DryRun validates the content of the loader folder without writing into DataDB, returning the report as structure
+// the folder lock is not needed since the files are not moved
+func (ldrS *LoaderService) V1DryRun(args *ArgsProcessFolder,
+	rply *engine.DryRunReport) (err error) {
+	ldrS.RLock()
+	defer ldrS.RUnlock()
+	if args.LoaderID == "" {
+		args.LoaderID = utils.MetaDefault
+	}
+	ldr, has := ldrS.ldrs[args.LoaderID]
+	if !has {
+		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
+	}
+	rpt, err := ldr.DryRunFolder(utils.MetaStore, args.StopOnError)
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*rply = *rpt
+	return
+}
+
 func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 	rply *string) (err error) {
 	ldrS.RLock()
@@ -112,6 +151,13 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 	if !has {
 		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
 	}
+	switch args.LoadOption {
+	case utils.EmptyString:
+	case utils.MetaDryRunLoad: // does not need the folder lock since the files are not moved
+		return dryRunFolder(ldr, utils.MetaRemove, args.StopOnError, rply)
+	default:
+		return fmt.Errorf("UNSUPPORTED_LOAD_OPTION: %s", args.LoadOption)
+	}
 	if locked, err := ldr.isFolderLocked(); err != nil {
 		return utils.NewErrServerError(err)
 	} else if locked {
@@ -134,6 +180,82 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 	return
 }
 
+// ArgsRollback the arguments for V1Rollback
+type ArgsRollback struct {
+	LoaderID  string
+	ForceLock bool
+	Version   int // the load version to be rolled back together with the ones after it, the last one if 0
+	Caching   *string
+}
+
+// V1Rollback restores the profiles as they were before the given atomic load
+func (ldrS *LoaderService) V1Rollback(args *ArgsRollback,
+	rply *string) (err error) {
+	ldrS.RLock()
+	defer ldrS.RUnlock()
+	if args.LoaderID == "" {
+		args.LoaderID = utils.MetaDefault
+	}
+	ldr, has := ldrS.ldrs[args.LoaderID]
+	if !has {
+		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
+	}
+	if locked, err := ldr.isFolderLocked(); err != nil {
+		return utils.NewErrServerError(err)
+	} else if locked {
+		if !args.ForceLock {
+			return errors.New("ANOTHER_LOADER_RUNNING")
+		}
+		if err := ldr.unlockFolder(); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	caching := config.CgrConfig().GeneralCfg().DefaultCaching
+	if args.Caching != nil {
+		caching = *args.Caching
+	}
+	if err = ldr.lockFolder(); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	defer ldr.unlockFolder()
+	if err = ldr.Rollback(args.Version, caching); err != nil {
+		if err == utils.ErrNotFound {
+			return
+		}
+		return utils.NewErrServerError(err)
+	}
+	*rply = utils.OK
+	return
+}
+
+// ArgsGetLoadVersions the arguments for V1GetLoadVersions
+type ArgsGetLoadVersions struct {
+	LoaderID string
+}
+
+// V1GetLoadVersions returns the atomic loads that can be rolled back
+func (ldrS *LoaderService) V1GetLoadVersions(args *ArgsGetLoadVersions,
+	rply *[]*LoadVersion) (err error) {
+	ldrS.RLock()
+	defer ldrS.RUnlock()
+	if args.LoaderID == "" {
+		args.LoaderID = utils.MetaDefault
+	}
+	ldr, has := ldrS.ldrs[args.LoaderID]
+	if !has {
+		return fmt.Errorf("UNKNOWN_LOADER: %s", args.LoaderID)
+	}
+	vers, err := ldr.getLoadVersions()
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	if len(vers) == 0 {
+		return utils.ErrNotFound
+	}
+	*rply = vers
+	return
+}
+
 // Reload recreates the loaders map thread safe
 func (ldrS *LoaderService) Reload(dm *engine.DataManager, ldrsCfg []*config.LoaderSCfg,
 	timezone string, filterS *engine.FilterS, connMgr *engine.ConnManager) {
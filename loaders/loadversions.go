@@ -0,0 +1,542 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// LoadVersion is the summary of one atomic load kept in DataDB so it can be rolled back
+type LoadVersion struct {
+	Version    int
+	LoadOption string
+	LoadTime   time.Time
+	Items      map[string][]string // map[loaderType][]tenantID of the changed profiles
+}
+
+// newLoadVersion returns the summary of the load version stored in DataDB
+func newLoadVersion(lv *engine.LoadVersion) (ver *LoadVersion) {
+	ver = &LoadVersion{
+		Version:    lv.Version,
+		LoadOption: lv.LoadOption,
+		LoadTime:   lv.LoadTime,
+		Items:      make(map[string][]string),
+	}
+	for _, itm := range lv.Items {
+		ver.Items[itm.LoaderType] = append(ver.Items[itm.LoaderType], itm.TenantID)
+	}
+	return
+}
+
+// ldrTypeCacheArgs are the cache partitions reloaded for each loader type
+var ldrTypeCacheArgs = map[string][]string{
+	utils.MetaAttributes:           {utils.AttributeProfileIDs},
+	utils.MetaResources:            {utils.ResourceProfileIDs, utils.ResourceIDs},
+	utils.MetaFilters:              {utils.FilterIDs},
+	utils.MetaStats:                {utils.StatsQueueProfileIDs, utils.StatsQueueIDs},
+	utils.MetaThresholds:           {utils.ThresholdProfileIDs, utils.ThresholdIDs},
+	utils.MetaRoutes:               {utils.RouteProfileIDs},
+	utils.MetaChargers:             {utils.ChargerProfileIDs},
+	utils.MetaDispatchers:          {utils.DispatcherProfileIDs},
+	utils.MetaDispatcherHosts:      {utils.DispatcherHostIDs},
+	utils.MetaRateProfiles:         {utils.RateProfileIDs},
+	utils.MetaActionProfiles:       {utils.ActionProfileIDs},
+	utils.MetaExchangeRateProfiles: {utils.ExchangeRateProfileIDs},
+	utils.MetaRateDecks:            {utils.RateDeckIDs},
+	utils.MetaTaxProfiles:          {utils.TaxProfileIDs},
+	utils.MetaLookupTables:         {utils.LookupTableIDs},
+}
+
+// ldrTypeCacheIDs are the index partitions cleared for each loader type
+var ldrTypeCacheIDs = map[string][]string{
+	utils.MetaAttributes:      {utils.CacheAttributeFilterIndexes},
+	utils.MetaResources:       {utils.CacheResourceFilterIndexes},
+	utils.MetaStats:           {utils.CacheStatFilterIndexes},
+	utils.MetaThresholds:      {utils.CacheThresholdFilterIndexes},
+	utils.MetaRoutes:          {utils.CacheRouteFilterIndexes},
+	utils.MetaChargers:        {utils.CacheChargerFilterIndexes},
+	utils.MetaDispatchers:     {utils.CacheDispatcherFilterIndexes},
+	utils.MetaRateProfiles:    {utils.CacheRateProfilesFilterIndexes, utils.CacheRateFilterIndexes},
+	utils.MetaActionProfiles:  {utils.CacheActionProfiles, utils.CacheActionProfilesFilterIndexes},
+	utils.MetaAccountProfiles: {utils.CacheAccountProfiles, utils.CacheAccountProfilesFilterIndexes},
+	utils.MetaTaxProfiles:     {utils.CacheTaxProfiles, utils.CacheTaxProfilesFilterIndexes},
+}
+
+// ldrTypeLoadIDs are the load IDs updated for each loader type
+var ldrTypeLoadIDs = map[string][]string{
+	utils.MetaAttributes:           {utils.CacheAttributeProfiles},
+	utils.MetaResources:            {utils.CacheResourceProfiles, utils.CacheResources},
+	utils.MetaFilters:              {utils.CacheFilters},
+	utils.MetaStats:                {utils.CacheStatQueueProfiles, utils.CacheStatQueues},
+	utils.MetaThresholds:           {utils.CacheThresholdProfiles, utils.CacheThresholds},
+	utils.MetaRoutes:               {utils.CacheRouteProfiles},
+	utils.MetaChargers:             {utils.CacheChargerProfiles},
+	utils.MetaDispatchers:          {utils.CacheDispatcherProfiles},
+	utils.MetaDispatcherHosts:      {utils.CacheDispatcherHosts},
+	utils.MetaRateProfiles:         {utils.CacheRateProfiles},
+	utils.MetaActionProfiles:       {utils.CacheActionProfiles},
+	utils.MetaAccountProfiles:      {utils.CacheAccountProfiles},
+	utils.MetaExchangeRateProfiles: {utils.CacheExchangeRateProfiles},
+	utils.MetaRateDecks:            {utils.CacheRateDecks},
+	utils.MetaTaxProfiles:          {utils.CacheTaxProfiles},
+	utils.MetaLookupTables:         {utils.CacheLookupTables},
+}
+
+// isStaging returns true if the profiles are collected instead of being written into DataDB
+func (ldr *Loader) isStaging() bool {
+	return ldr.dryRunRpt != nil || ldr.stage != nil
+}
+
+// getPrevProfile returns the JSON encoded profile from DataDB or nil if it is not stored
+func (ldr *Loader) getPrevProfile(loaderType, tntID string) (prev []byte, err error) {
+	var prf interface{}
+	if prf, err = engine.GetLoaderProfile(ldr.dm, loaderType, utils.NewTenantID(tntID)); err != nil {
+		if err == utils.ErrNotFound {
+			return nil, nil
+		}
+		return
+	}
+	return json.Marshal(prf)
+}
+
+// stageProfile adds the profile to the dry run report or to the atomic load
+func (ldr *Loader) stageProfile(loaderType string, prf interface{ TenantID() string }) (err error) {
+	if ldr.dryRunRpt != nil {
+		return ldr.dryRunRpt.AddProfile(ldr.dm, loaderType, prf)
+	}
+	itm := &engine.LoadVersionItem{
+		LoaderType: loaderType,
+		TenantID:   prf.TenantID(),
+		Partial:    ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial),
+	}
+	if itm.Profile, err = json.Marshal(prf); err != nil {
+		return
+	}
+	if itm.Previous, err = ldr.getPrevProfile(loaderType, itm.TenantID); err != nil {
+		return
+	}
+	ldr.stage.Items = append(ldr.stage.Items, itm)
+	return
+}
+
+// stageRemove adds the removed profile to the dry run report or to the atomic load
+func (ldr *Loader) stageRemove(loaderType, tntID string, lds []LoaderData) (err error) {
+	if ldr.dryRunRpt != nil {
+		return ldr.dryRunRpt.AddRemoved(ldr.dm, loaderType, tntID)
+	}
+	itm := &engine.LoadVersionItem{
+		LoaderType: loaderType,
+		TenantID:   tntID,
+	}
+	if loaderType == utils.MetaRateProfiles &&
+		ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial) {
+		itm.Partial = true
+		if itm.RateIDs, err = lds[0].GetRateIDs(); err != nil {
+			return
+		}
+	}
+	if itm.Previous, err = ldr.getPrevProfile(loaderType, tntID); err != nil {
+		return
+	}
+	if itm.Previous == nil {
+		return utils.ErrNotFound
+	}
+	ldr.stage.Items = append(ldr.stage.Items, itm)
+	return
+}
+
+// processFolderAtomic stages all the profiles from the folder as a new load version in DataDB
+// and switches to it with a single write of the active version once all of them were written
+// until then the readers caching the profiles get the ones stored before the load
+func (ldr *Loader) processFolderAtomic(caching, loadOption string) (err error) {
+	ldr.versionsMux.Lock()
+	defer ldr.versionsMux.Unlock()
+	var active int
+	if active, err = ldr.recoverLoadVersions(caching); err != nil {
+		return
+	}
+	ldr.stage = &engine.LoadVersion{
+		LoaderID:   ldr.ldrID,
+		Version:    active + 1,
+		LoadOption: loadOption,
+	}
+	defer func() { ldr.stage = nil }()
+	for ldrType := range ldr.rdrs {
+		if err = ldr.processFiles(ldrType, utils.MetaNone, loadOption); err != nil {
+			return
+		}
+	}
+	ver := ldr.stage
+	ver.LoadTime = time.Now()
+	if err = ldr.dm.SetLoadVersion(ver); err != nil {
+		return
+	}
+	if err = ldr.switchItems(ver.Items, false); err == nil {
+		err = ldr.dm.SetActiveLoadVersion(ldr.ldrID, ver.Version)
+	}
+	if err != nil {
+		if errUndo := ldr.undoLoadVersion(ver); errUndo != nil { // recovered by the next load or rollback otherwise
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> failed to remove the load version: %d, err: %s",
+				utils.LoaderS, ldr.ldrID, ver.Version, errUndo.Error()))
+		}
+		return
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s-%s> switched in load version: %d with %d items",
+		utils.LoaderS, ldr.ldrID, ver.Version, len(ver.Items)))
+	ldr.removeOldLoadVersions(ver.Version)
+	if err = ldr.moveFiles(); err != nil {
+		return
+	}
+	return ldr.updateItemsCache(ver.Items, caching) // the version is kept even if the caches fail so it can be rolled back
+}
+
+// recoverLoadVersions rolls back the load versions stored above the active one,
+// left by a failed load or by a rollback, returning the active version
+func (ldr *Loader) recoverLoadVersions(caching string) (active int, err error) {
+	if active, err = ldr.dm.GetActiveLoadVersion(ldr.ldrID); err != nil {
+		return
+	}
+	var vers []*engine.LoadVersion
+	for version := active + 1; ; version++ {
+		var ver *engine.LoadVersion
+		if ver, err = ldr.dm.GetLoadVersion(ldr.ldrID, version); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+			break
+		}
+		vers = append(vers, ver)
+	}
+	var items []*engine.LoadVersionItem // the caches are updated once for all the versions rolled back
+	for i := len(vers) - 1; i >= 0; i-- {
+		if err = ldr.undoLoadVersion(vers[i]); err != nil {
+			break
+		}
+		items = append(items, vers[i].Items...)
+		utils.Logger.Info(fmt.Sprintf("<%s-%s> rolled back load version: %d",
+			utils.LoaderS, ldr.ldrID, vers[i].Version))
+	}
+	if len(items) != 0 {
+		if errCache := ldr.updateItemsCache(items, caching); err == nil {
+			err = errCache
+		}
+	}
+	return
+}
+
+// undoLoadVersion restores the previous profiles of the load version and removes it from DataDB
+func (ldr *Loader) undoLoadVersion(ver *engine.LoadVersion) (err error) {
+	if err = ldr.switchItems(ver.Items, true); err != nil {
+		return
+	}
+	if err = ldr.dm.RemoveLoadVersion(ver.LoaderID, ver.Version); err == utils.ErrNotFound {
+		err = nil
+	}
+	return
+}
+
+// removeOldLoadVersions removes from DataDB the versions over the versions limit
+// the active version is always kept so it can be rolled back
+func (ldr *Loader) removeOldLoadVersions(active int) {
+	keep := ldr.versionsLimit
+	if keep < 1 {
+		keep = 1
+	}
+	for version := active - keep; version > 0; version-- {
+		if _, err := ldr.dm.GetLoadVersion(ldr.ldrID, version); err != nil {
+			return
+		}
+		if err := ldr.dm.RemoveLoadVersion(ldr.ldrID, version); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> failed to remove the load version: %d, err: %s",
+				utils.LoaderS, ldr.ldrID, version, err.Error()))
+			return
+		}
+	}
+}
+
+// switchItems writes the items into DataDB, the new profiles or the previous ones on undo
+// on error the items already written are restored so DataDB is left as before
+func (ldr *Loader) switchItems(items []*engine.LoadVersionItem, undo bool) (err error) {
+	for i := range items {
+		itm := items[i]
+		if undo {
+			itm = items[len(items)-1-i] // restore in reverse so the oldest previous profile wins
+		}
+		if err = ldr.writeLoadItem(itm, undo); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> failed to write %s: <%s>, err: %s, restoring the previous items",
+				utils.LoaderS, ldr.ldrID, itm.LoaderType, itm.TenantID, err.Error()))
+			for j := i - 1; j >= 0; j-- {
+				itm = items[j]
+				if undo {
+					itm = items[len(items)-1-j]
+				}
+				if errRst := ldr.writeLoadItem(itm, !undo); errRst != nil {
+					utils.Logger.Err(fmt.Sprintf("<%s-%s> failed to restore %s: <%s>, err: %s",
+						utils.LoaderS, ldr.ldrID, itm.LoaderType, itm.TenantID, errRst.Error()))
+				}
+			}
+			return
+		}
+	}
+	return
+}
+
+// updateItemsCache sets the load IDs and updates the caches once for all the items
+func (ldr *Loader) updateItemsCache(items []*engine.LoadVersionItem, caching string) (err error) {
+	cacheArgs := make(map[string][]string)
+	cacheIDs := make(utils.StringSet)
+	loadIDs := make(map[string]int64)
+	now := time.Now().UnixNano()
+	for _, itm := range items {
+		for _, cacheArg := range ldrTypeCacheArgs[itm.LoaderType] {
+			cacheArgs[cacheArg] = append(cacheArgs[cacheArg], itm.TenantID)
+		}
+		cacheIDs.AddSlice(ldrTypeCacheIDs[itm.LoaderType])
+		for _, loadID := range ldrTypeLoadIDs[itm.LoaderType] {
+			loadIDs[loadID] = now
+		}
+	}
+	if len(loadIDs) != 0 {
+		if err = ldr.dm.SetLoadIDs(loadIDs); err != nil {
+			return
+		}
+	}
+	return ldr.updateCache(caching, cacheArgs, cacheIDs.AsSlice())
+}
+
+// writeLoadItem writes the item into DataDB, the previous profile if undo is true
+// the profiles already missing are ignored on undo since the load might not have reached them
+func (ldr *Loader) writeLoadItem(itm *engine.LoadVersionItem, undo bool) (err error) {
+	prf, partial, rateIDs := itm.Profile, itm.Partial, itm.RateIDs
+	if undo {
+		prf, partial, rateIDs = itm.Previous, false, nil
+	}
+	if prf == nil {
+		if err = ldr.removeLoadProfile(itm.LoaderType, itm.TenantID, rateIDs); undo && err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	var lPrf interface{}
+	if lPrf, err = engine.NewLoaderProfile(itm.LoaderType); err != nil {
+		return
+	}
+	if err = json.Unmarshal(prf, lPrf); err != nil {
+		return
+	}
+	return ldr.setLoadProfile(itm.LoaderType, lPrf, partial)
+}
+
+// setLoadProfile stores the profile the same way storeLoadedData does
+func (ldr *Loader) setLoadProfile(loaderType string, prf interface{}, partial bool) (err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		return ldr.dm.SetAttributeProfile(prf.(*engine.AttributeProfile), true)
+	case utils.MetaResources:
+		res := prf.(*engine.ResourceProfile)
+		if err = ldr.dm.SetResourceProfile(res, true); err != nil {
+			return
+		}
+		var ttl *time.Duration
+		if res.UsageTTL > 0 {
+			ttl = &res.UsageTTL
+		}
+		return ldr.dm.SetResource(
+			&engine.Resource{
+				Tenant: res.Tenant,
+				ID:     res.ID,
+				Usages: make(map[string]*engine.ResourceUsage),
+			}, ttl, res.Limit, !res.Stored)
+	case utils.MetaFilters:
+		return ldr.dm.SetFilter(prf.(*engine.Filter), true)
+	case utils.MetaStats:
+		stsPrf := prf.(*engine.StatQueueProfile)
+		if err = ldr.dm.SetStatQueueProfile(stsPrf, true); err != nil {
+			return
+		}
+		var sq *engine.StatQueue
+		if sq, err = engine.NewStatQueue(stsPrf.Tenant, stsPrf.ID, stsPrf.Metrics,
+			stsPrf.MinItems); err != nil {
+			return utils.APIErrorHandler(err)
+		}
+		var ttl *time.Duration
+		if stsPrf.TTL > 0 {
+			ttl = &stsPrf.TTL
+		}
+		return ldr.dm.SetStatQueue(sq, stsPrf.Metrics,
+			stsPrf.MinItems, ttl, stsPrf.QueueLength,
+			!stsPrf.Stored)
+	case utils.MetaThresholds:
+		thPrf := prf.(*engine.ThresholdProfile)
+		if err = ldr.dm.SetThresholdProfile(thPrf, true); err != nil {
+			return
+		}
+		return ldr.dm.SetThreshold(&engine.Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID}, thPrf.MinSleep, false)
+	case utils.MetaRoutes:
+		return ldr.dm.SetRouteProfile(prf.(*engine.RouteProfile), true)
+	case utils.MetaChargers:
+		return ldr.dm.SetChargerProfile(prf.(*engine.ChargerProfile), true)
+	case utils.MetaDispatchers:
+		return ldr.dm.SetDispatcherProfile(prf.(*engine.DispatcherProfile), true)
+	case utils.MetaDispatcherHosts:
+		return ldr.dm.SetDispatcherHost(prf.(*engine.DispatcherHost))
+	case utils.MetaRateProfiles:
+		if partial {
+			return ldr.dm.SetRateProfileRates(prf.(*engine.RateProfile), true)
+		}
+		return ldr.dm.SetRateProfile(prf.(*engine.RateProfile), true)
+	case utils.MetaActionProfiles:
+		return ldr.dm.SetActionProfile(prf.(*engine.ActionProfile), true)
+	case utils.MetaAccountProfiles:
+		return ldr.dm.SetAccountProfile(prf.(*utils.AccountProfile), true)
+	case utils.MetaExchangeRateProfiles:
+		return ldr.dm.SetExchangeRateProfile(prf.(*utils.ExchangeRateProfile))
+	case utils.MetaRateDecks:
+		return ldr.dm.SetRateDeck(prf.(*engine.RateDeck))
+	case utils.MetaTaxProfiles:
+		return ldr.dm.SetTaxProfile(prf.(*engine.TaxProfile), true)
+	case utils.MetaLookupTables:
+		return ldr.dm.SetLookupTable(prf.(*engine.LookupTable))
+	}
+	return fmt.Errorf("unsupported loader type: <%s>", loaderType)
+}
+
+// removeLoadProfile removes the profile the same way removeLoadedData does
+// only the given rates are removed if rateIDs is not nil
+func (ldr *Loader) removeLoadProfile(loaderType, tntID string, rateIDs []string) (err error) {
+	tntIDStruct := utils.NewTenantID(tntID)
+	switch loaderType {
+	case utils.MetaAttributes:
+		return ldr.dm.RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
+			utils.NonTransactional, true)
+	case utils.MetaResources:
+		if err = ldr.dm.RemoveResourceProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveResource(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaFilters:
+		return ldr.dm.RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
+			utils.NonTransactional, true)
+	case utils.MetaStats:
+		if err = ldr.dm.RemoveStatQueueProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveStatQueue(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaThresholds:
+		if err = ldr.dm.RemoveThresholdProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+			return
+		}
+		return ldr.dm.RemoveThreshold(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaRoutes:
+		return ldr.dm.RemoveRouteProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaChargers:
+		return ldr.dm.RemoveChargerProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaDispatchers:
+		return ldr.dm.RemoveDispatcherProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaDispatcherHosts:
+		return ldr.dm.RemoveDispatcherHost(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaRateProfiles:
+		if rateIDs != nil {
+			return ldr.dm.RemoveRateProfileRates(tntIDStruct.Tenant,
+				tntIDStruct.ID, rateIDs, true)
+		}
+		return ldr.dm.RemoveRateProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaActionProfiles:
+		return ldr.dm.RemoveActionProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaAccountProfiles:
+		return ldr.dm.RemoveAccountProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaExchangeRateProfiles:
+		return ldr.dm.RemoveExchangeRateProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaRateDecks:
+		return ldr.dm.RemoveRateDeck(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional)
+	case utils.MetaTaxProfiles:
+		return ldr.dm.RemoveTaxProfile(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional, true)
+	case utils.MetaLookupTables:
+		return ldr.dm.RemoveLookupTable(tntIDStruct.Tenant,
+			tntIDStruct.ID, utils.NonTransactional)
+	}
+	return fmt.Errorf("unsupported loader type: <%s>", loaderType)
+}
+
+// getLoadVersions returns the versions kept in DataDB for rollback
+func (ldr *Loader) getLoadVersions() (vers []*LoadVersion, err error) {
+	ldr.versionsMux.Lock()
+	defer ldr.versionsMux.Unlock()
+	var active int
+	if active, err = ldr.dm.GetActiveLoadVersion(ldr.ldrID); err != nil {
+		return
+	}
+	for version := active; version > 0; version-- {
+		var ver *engine.LoadVersion
+		if ver, err = ldr.dm.GetLoadVersion(ldr.ldrID, version); err != nil {
+			if err == utils.ErrNotFound {
+				err = nil
+				break
+			}
+			return
+		}
+		vers = append([]*LoadVersion{newLoadVersion(ver)}, vers...)
+	}
+	return
+}
+
+// Rollback restores DataDB as it was before the given load version
+// the loads done after it are rolled back as well, the last load is rolled back if version is 0
+// the active version is switched back first so an interrupted rollback is completed by the next one
+func (ldr *Loader) Rollback(version int, caching string) (err error) {
+	ldr.versionsMux.Lock()
+	defer ldr.versionsMux.Unlock()
+	var active int
+	if active, err = ldr.recoverLoadVersions(caching); err != nil {
+		return
+	}
+	if version == 0 {
+		version = active
+	}
+	if version <= 0 || version > active {
+		return utils.ErrNotFound
+	}
+	if _, err = ldr.dm.GetLoadVersion(ldr.ldrID, version); err != nil { // the version was not kept
+		return
+	}
+	if err = ldr.dm.SetActiveLoadVersion(ldr.ldrID, version-1); err != nil {
+		return
+	}
+	_, err = ldr.recoverLoadVersions(caching)
+	return
+}
@@ -0,0 +1,452 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// startTestSFTPServer starts a SFTP server on a random port accepting the given user and password
+// knownHosts is the path of a known hosts file containing the key of the server
+func startTestSFTPServer(t *testing.T, user, passwd string) (addr, knownHosts string, stop func()) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srvCfg := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == user && string(pass) == passwd {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %q", c.User())
+		},
+	}
+	srvCfg.AddHostKey(signer)
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				_, chans, reqs, err := ssh.NewServerConn(conn, srvCfg)
+				if err != nil {
+					return
+				}
+				go ssh.DiscardRequests(reqs)
+				for newCh := range chans {
+					if newCh.ChannelType() != "session" {
+						newCh.Reject(ssh.UnknownChannelType, "unknown channel type")
+						continue
+					}
+					ch, chReqs, err := newCh.Accept()
+					if err != nil {
+						return
+					}
+					go func(in <-chan *ssh.Request) {
+						for req := range in {
+							req.Reply(req.Type == "subsystem" &&
+								string(req.Payload[4:]) == utils.SFTPScheme, nil)
+						}
+					}(chReqs)
+					srv, err := sftp.NewServer(ch)
+					if err != nil {
+						return
+					}
+					go func() {
+						srv.Serve()
+						srv.Close()
+					}()
+				}
+			}(conn)
+		}
+	}()
+	addr = l.Addr().String()
+	knownHosts = path.Join(t.TempDir(), "known_hosts")
+	if err = ioutil.WriteFile(knownHosts,
+		[]byte(knownhosts.Line([]string{addr}, signer.PublicKey())+"\n"), 0644); err != nil {
+		l.Close()
+		t.Fatal(err)
+	}
+	return addr, knownHosts, func() { l.Close() }
+}
+
+func TestLoaderSourceLocal(t *testing.T) {
+	dir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderSourceLocal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src, err := newLoaderSource(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, isLocal := src.(localSource); !isLocal {
+		t.Fatalf("Expected localSource, received %T", src)
+	}
+	testLoaderSource(t, src)
+}
+
+func TestLoaderSourceSFTP(t *testing.T) {
+	dir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderSourceSFTP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	addr, knownHosts, stop := startTestSFTPServer(t, "cgrates", "CGRateS.org")
+	defer stop()
+	expErr := utils.NewErrMandatoryIeMissing(utils.SFTPKnownHostsFile).Error()
+	if _, err := newLoaderSource("sftp://cgrates:CGRateS.org@"+addr+dir, nil); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received %v", expErr, err)
+	}
+	if src, err := newLoaderSource("sftp://cgrates:CGRateS.org@"+addr+dir,
+		map[string]interface{}{utils.SFTPInsecureHostKey: true}); err != nil {
+		t.Fatal(err)
+	} else if _, err := src.ListFiles(); err != nil {
+		t.Error(err)
+	}
+	otherKnownHosts := path.Join(dir, "known_hosts")
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSigner, err := ssh.NewSignerFromKey(otherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(otherKnownHosts,
+		[]byte(knownhosts.Line([]string{addr}, otherSigner.PublicKey())+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if src, err := newLoaderSource("sftp://cgrates:CGRateS.org@"+addr+dir,
+		map[string]interface{}{utils.SFTPKnownHostsFile: otherKnownHosts}); err != nil {
+		t.Fatal(err)
+	} else if _, err := src.ListFiles(); err == nil {
+		t.Error("Expected host key mismatch error")
+	}
+	os.Remove(otherKnownHosts)
+	opts := map[string]interface{}{utils.SFTPKnownHostsFile: knownHosts}
+	if src, err := newLoaderSource("sftp://cgrates:wrong@"+addr+dir, opts); err != nil {
+		t.Fatal(err)
+	} else if _, err := src.ListFiles(); err == nil {
+		t.Error("Expected authentication error")
+	}
+	src, err := newLoaderSource("sftp://cgrates:CGRateS.org@"+addr+dir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, isSFTP := src.(*sftpSource); !isSFTP {
+		t.Fatalf("Expected sftpSource, received %T", src)
+	}
+	testLoaderSource(t, src)
+}
+
+// testLoaderSource checks the locking and the file operations of an empty source
+func testLoaderSource(t *testing.T, src loaderSource) {
+	if locked, err := src.IsLocked(".lck"); err != nil {
+		t.Fatal(err)
+	} else if locked {
+		t.Error("Expected the source to not be locked")
+	}
+	if err := src.Lock(".lck"); err != nil {
+		t.Fatal(err)
+	}
+	if locked, err := src.IsLocked(".lck"); err != nil {
+		t.Fatal(err)
+	} else if !locked {
+		t.Error("Expected the source to be locked")
+	}
+	if err := src.WriteFile(utils.AttributesCsv, strings.NewReader("cgrates.org,ATTR_1")); err != nil {
+		t.Fatal(err)
+	}
+	fNames, err := src.ListFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(fNames)
+	if exp := []string{".lck", utils.AttributesCsv}; !reflect.DeepEqual(exp, fNames) {
+		t.Errorf("Expected %+v, received %+v", exp, fNames)
+	}
+	rdr, err := src.OpenFile(utils.AttributesCsv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := ioutil.ReadAll(rdr)
+	rdr.Close()
+	if err != nil {
+		t.Fatal(err)
+	} else if string(content) != "cgrates.org,ATTR_1" {
+		t.Errorf("Expected %q, received %q", "cgrates.org,ATTR_1", string(content))
+	}
+	if _, err := src.OpenFile(utils.ChargersCsv); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := src.RemoveFile(utils.AttributesCsv); err != nil {
+		t.Fatal(err)
+	}
+	if err := src.Unlock(".lck"); err != nil {
+		t.Fatal(err)
+	}
+	if fNames, err := src.ListFiles(); err != nil {
+		t.Fatal(err)
+	} else if len(fNames) != 0 {
+		t.Errorf("Expected no files, received %+v", fNames)
+	}
+}
+
+func TestLoaderSourceHTTP(t *testing.T) {
+	content := "cgrates.org,ATTR_1"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tariffs/"+utils.AttributesCsv {
+			http.NotFound(w, r)
+			return
+		}
+		etag := fmt.Sprintf("%q", content)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+	src, err := newLoaderSource(srv.URL+"/tariffs/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.OpenFile(utils.ChargersCsv); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := src.WriteFile(utils.AttributesCsv, bytes.NewReader(nil)); err != utils.ErrNotImplemented {
+		t.Errorf("Expected %v, received %v", utils.ErrNotImplemented, err)
+	}
+	rdr, err := src.OpenFile(utils.AttributesCsv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rdr.Close()
+	if fNames, err := src.ListFiles(); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(fNames, []string{utils.AttributesCsv}) {
+		t.Errorf("Expected %+v, received %+v", []string{utils.AttributesCsv}, fNames)
+	}
+	if err := src.RemoveFile(utils.AttributesCsv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := src.OpenFile(utils.AttributesCsv); err != errUnchangedFile { // not changed since processed
+		t.Errorf("Expected %v, received %v", errUnchangedFile, err)
+	}
+	if rdr, err := src.(unchangedFileOpener).OpenUnchangedFile(utils.AttributesCsv); err != nil {
+		t.Error(err)
+	} else {
+		rdr.Close()
+	}
+	content = "cgrates.org,ATTR_2"
+	if rdr, err := src.OpenFile(utils.AttributesCsv); err != nil {
+		t.Error(err)
+	} else {
+		rdr.Close()
+	}
+	if err := src.Lock(".lck"); err != nil {
+		t.Fatal(err)
+	}
+	if locked, err := src.IsLocked(".lck"); err != nil {
+		t.Fatal(err)
+	} else if !locked {
+		t.Error("Expected the source to be locked")
+	}
+}
+
+func TestLoaderProcessFolderHTTPUnchangedFiles(t *testing.T) {
+	contents := map[string]string{
+		utils.ChargersCsv:   "cgrates.org,CHRG_HTTP,*default,*none,20\n",
+		utils.AttributesCsv: "cgrates.org,CHRG_HTTP,10\n",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, has := contents[strings.TrimPrefix(r.URL.Path, "/tariffs/")]
+		if !has {
+			http.NotFound(w, r)
+			return
+		}
+		etag := fmt.Sprintf("%q", content)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	ldr := &Loader{
+		ldrID:         utils.MetaDefault,
+		tpInDir:       srv.URL + "/tariffs/",
+		lockFilename:  ".lck",
+		fieldSep:      utils.FieldsSep,
+		dm:            dm,
+		timezone:      "UTC",
+		bufLoaderData: make(map[string][]LoaderData),
+		dataTpls: map[string][]*config.FCTemplate{
+			utils.MetaChargers: {
+				{Path: "Tenant", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*file(Chargers.csv).0", utils.InfieldSep)},
+				{Path: "ID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*file(Chargers.csv).1", utils.InfieldSep)},
+				{Path: "RunID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*file(Chargers.csv).2", utils.InfieldSep)},
+				{Path: "AttributeIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*file(Chargers.csv).3", utils.InfieldSep)},
+				{Path: "Weight", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*file(Chargers.csv).4", utils.InfieldSep)},
+			},
+		},
+		rdrs: map[string]map[string]*openedCSVFile{
+			utils.MetaChargers: {utils.ChargersCsv: nil},
+		},
+	}
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, true); err != nil {
+		t.Fatal(err)
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_HTTP", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if chrg.Weight != 20 {
+		t.Errorf("Expected %v, received %v", 20, chrg.Weight)
+	}
+	// the chargers file did not change since processed
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, true); err != nil {
+		t.Fatal(err)
+	}
+	// the missing files are not skipped
+	ldr.rdrs[utils.MetaChargers][utils.RoutesCsv] = nil
+	expErr := utils.ErrPrefixNotFound(utils.RoutesCsv).Error()
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, true); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received %v", expErr, err)
+	}
+	delete(ldr.rdrs[utils.MetaChargers], utils.RoutesCsv)
+	// the unchanged chargers file is read again together with the changed one
+	ldr.rdrs[utils.MetaChargers][utils.AttributesCsv] = nil
+	ldr.dataTpls[utils.MetaChargers][4].Value = config.NewRSRParsersMustCompile("~*file(Attributes.csv).2", utils.InfieldSep)
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, true); err != nil {
+		t.Fatal(err)
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_HTTP", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if chrg.Weight != 10 || chrg.RunID != utils.MetaDefault {
+		t.Errorf("Expected weight 10 and run %s, received %s", utils.MetaDefault, utils.ToJSON(chrg))
+	}
+}
+
+func TestLoaderProcessFolderSFTP(t *testing.T) {
+	inDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderProcessFolderSFTPIn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(inDir)
+	outDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderProcessFolderSFTPOut")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(outDir)
+	if err := ioutil.WriteFile(path.Join(inDir, utils.ChargersCsv),
+		[]byte("cgrates.org,CHRG_1,*default,*none,20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	addr, knownHosts, stop := startTestSFTPServer(t, "cgrates", "CGRateS.org")
+	defer stop()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true), config.CgrConfig().CacheCfg(), nil)
+	ldr := &Loader{
+		ldrID:         utils.MetaDefault,
+		tpInDir:       "sftp://cgrates:CGRateS.org@" + addr + inDir,
+		tpOutDir:      outDir,
+		opts:          map[string]interface{}{utils.SFTPKnownHostsFile: knownHosts},
+		lockFilename:  ".lck",
+		fieldSep:      utils.FieldsSep,
+		dm:            dm,
+		timezone:      "UTC",
+		bufLoaderData: make(map[string][]LoaderData),
+		dataTpls: map[string][]*config.FCTemplate{
+			utils.MetaChargers: {
+				{Path: "Tenant", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.0", utils.InfieldSep)},
+				{Path: "ID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.1", utils.InfieldSep)},
+				{Path: "RunID", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.2", utils.InfieldSep)},
+				{Path: "AttributeIDs", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.3", utils.InfieldSep)},
+				{Path: "Weight", Type: utils.MetaComposed, Value: config.NewRSRParsersMustCompile("~*req.4", utils.InfieldSep)},
+			},
+		},
+		rdrs: map[string]map[string]*openedCSVFile{
+			utils.MetaChargers: {utils.ChargersCsv: nil},
+		},
+	}
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, true); err != nil {
+		t.Fatal(err)
+	}
+	if chrg, err := dm.GetChargerProfile("cgrates.org", "CHRG_1", false, false,
+		utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	} else if chrg.Weight != 20 {
+		t.Errorf("Expected %v, received %v", 20, chrg.Weight)
+	}
+	if _, err := os.Stat(path.Join(outDir, utils.ChargersCsv)); err != nil {
+		t.Errorf("Expected the file to be moved, received %v", err)
+	}
+	if files, err := ioutil.ReadDir(inDir); err != nil {
+		t.Fatal(err)
+	} else if len(files) != 0 {
+		t.Errorf("Expected the source folder to be empty, received %d files", len(files))
+	}
+}
+
+func TestLoaderSourceS3NotFound(t *testing.T) {
+	if !isS3NotFound(awserr.New(s3.ErrCodeNoSuchKey, "The specified key does not exist.", nil)) {
+		t.Error("Expected NoSuchKey to be a missing object")
+	}
+	if !isS3NotFound(awserr.NewRequestFailure(awserr.New("NotFound", "Not Found", nil), http.StatusNotFound, "reqID")) {
+		t.Error("Expected status 404 to be a missing object")
+	}
+	if isS3NotFound(awserr.New(s3.ErrCodeNoSuchBucket, "The specified bucket does not exist", nil)) {
+		t.Error("Expected NoSuchBucket not to be a missing object")
+	}
+}
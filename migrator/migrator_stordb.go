@@ -31,6 +31,9 @@ type MigratorStorDB interface {
 	getV2SMCost() (v2Cost *v2SessionsCost, err error)
 	setV2SMCost(v2Cost *v2SessionsCost) (err error)
 	remV2SMCost(v2Cost *v2SessionsCost) (err error)
+	alterV1TPChargers() (err error)
+	alterV1TPRateProfiles() (err error)
+	alterV1TPRoutes() (err error)
 	StorDB() engine.StorDB
 	close()
 }
@@ -83,3 +83,21 @@ func (iDBMig *internalStorDBMigrator) setV2SMCost(v2Cost *v2SessionsCost) (err e
 func (iDBMig *internalStorDBMigrator) remV2SMCost(v2Cost *v2SessionsCost) (err error) {
 	return utils.ErrNotImplemented
 }
+
+//TPChargers methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPChargers() (err error) {
+	return // the profiles without aggregations are read as they are
+}
+
+//TPRateProfiles methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPRateProfiles() (err error) {
+	return // the profiles without currency and volume period are read as they are
+}
+
+//TPRoutes methods
+//alter
+func (iDBMig *internalStorDBMigrator) alterV1TPRoutes() (err error) {
+	return // the routes without rate profiles are read as they are
+}
@@ -128,3 +128,24 @@ func (v1ms *mongoStorDBMigrator) remV2SMCost(v2Cost *v2SessionsCost) (err error)
 	_, err = v1ms.mgoDB.DB().Collection(utils.SessionCostsTBL).DeleteMany(v1ms.mgoDB.GetContext(), bson.D{})
 	return
 }
+
+//TPChargers methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPChargers() (err error) {
+	_, err = v1ms.mgoDB.DB().Collection(utils.TBLTPChargers).UpdateMany(v1ms.mgoDB.GetContext(),
+		bson.M{"aggregations": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"aggregations": bson.A{}}})
+	return
+}
+
+//TPRateProfiles methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPRateProfiles() (err error) {
+	return // the missing currency and volume period are decoded as empty
+}
+
+//TPRoutes methods
+//alter
+func (v1ms *mongoStorDBMigrator) alterV1TPRoutes() (err error) {
+	return // the missing route rate profile ids are decoded as empty
+}
@@ -184,3 +184,58 @@ func (mgSQL *migratorSQL) remV2SMCost(v2Cost *v2SessionsCost) (err error) {
 	return nil
 
 }
+
+// alterV1TPChargers adds the aggregation columns to tp_chargers, same as alter_tp_chargers_aggregations.sql
+func (mgSQL *migratorSQL) alterV1TPChargers() (err error) {
+	qrys := []string{"ALTER TABLE tp_chargers " +
+		"ADD COLUMN `aggregation_path` varchar(64) NOT NULL DEFAULT '' AFTER `weight`, " +
+		"ADD COLUMN `aggregation_filter_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_path`, " +
+		"ADD COLUMN `aggregation_type` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_filter_ids`, " +
+		"ADD COLUMN `aggregation_run_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_type`, " +
+		"ADD COLUMN `aggregation_threshold_ids` varchar(64) NOT NULL DEFAULT '' AFTER `aggregation_run_ids`, " +
+		"DROP INDEX `unique_tp_chargers`, " +
+		"ADD UNIQUE KEY `unique_tp_chargers` (`tpid`,`tenant`,`id`,`filter_ids`,`run_id`,`attribute_ids`,`aggregation_path`);"}
+	if mgSQL.StorDB().GetStorageType() == utils.Postgres {
+		qrys = []string{`ALTER TABLE tp_chargers
+	  ADD COLUMN "aggregation_path" varchar(64) NOT NULL DEFAULT '',
+	  ADD COLUMN "aggregation_filter_ids" varchar(64) NOT NULL DEFAULT '',
+	  ADD COLUMN "aggregation_type" varchar(64) NOT NULL DEFAULT '',
+	  ADD COLUMN "aggregation_run_ids" varchar(64) NOT NULL DEFAULT '',
+	  ADD COLUMN "aggregation_threshold_ids" varchar(64) NOT NULL DEFAULT '';`,
+			"DROP INDEX IF EXISTS tp_chargers_unique;",
+			`CREATE INDEX tp_chargers_unique ON tp_chargers ("tpid", "tenant", "id",
+	  "filter_ids", "run_id", "attribute_ids", "aggregation_path");`,
+		}
+	}
+	for _, qry := range qrys {
+		if _, err = mgSQL.sqlStorage.Db.Exec(qry); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// alterV1TPRateProfiles adds the currency and volume period columns to tp_rate_profiles, same as alter_tp_rate_profiles_currency.sql
+func (mgSQL *migratorSQL) alterV1TPRateProfiles() (err error) {
+	qry := "ALTER TABLE tp_rate_profiles " +
+		"ADD COLUMN `currency` varchar(16) NOT NULL DEFAULT '' AFTER `rate_increment`, " +
+		"ADD COLUMN `volume_period` varchar(16) NOT NULL DEFAULT '' AFTER `currency`;"
+	if mgSQL.StorDB().GetStorageType() == utils.Postgres {
+		qry = `ALTER TABLE tp_rate_profiles
+	  ADD COLUMN "currency" VARCHAR(16) NOT NULL DEFAULT '',
+	  ADD COLUMN "volume_period" VARCHAR(16) NOT NULL DEFAULT '';`
+	}
+	_, err = mgSQL.sqlStorage.Db.Exec(qry)
+	return
+}
+
+// alterV1TPRoutes adds the route rate profile ids column to tp_routes, same as alter_tp_routes_rate_profile_ids.sql
+// the MySQL table was already created with it
+func (mgSQL *migratorSQL) alterV1TPRoutes() (err error) {
+	if mgSQL.StorDB().GetStorageType() != utils.Postgres {
+		return
+	}
+	_, err = mgSQL.sqlStorage.Db.Exec(`ALTER TABLE tp_routes
+	  ADD COLUMN IF NOT EXISTS "route_rate_profile_ids" VARCHAR(64) NOT NULL DEFAULT '';`)
+	return
+}
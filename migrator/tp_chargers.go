@@ -65,6 +65,16 @@ func (m *Migrator) migrateTPChargers() (err error) {
 		return
 	}
 	switch vrs[utils.TpChargers] {
+	case 1: // version 2 added the aggregation columns
+		if !m.dryRun {
+			if err = m.storDBIn.alterV1TPChargers(); err != nil {
+				return
+			}
+			if err = m.setVersions(utils.TpChargers); err != nil {
+				return
+			}
+		}
+		fallthrough
 	case current[utils.TpChargers]:
 		if m.sameStorDB {
 			break
@@ -65,6 +65,16 @@ func (m *Migrator) migrateTPRateProfiles() (err error) {
 		return err
 	}
 	switch vrs[utils.TpRateProfiles] {
+	case 1: // version 2 added the currency and volume period columns
+		if !m.dryRun {
+			if err = m.storDBIn.alterV1TPRateProfiles(); err != nil {
+				return
+			}
+			if err = m.setVersions(utils.TpRateProfiles); err != nil {
+				return
+			}
+		}
+		fallthrough
 	case current[utils.TpRateProfiles]:
 		if m.sameStorDB {
 			break
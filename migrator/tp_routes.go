@@ -66,6 +66,16 @@ func (m *Migrator) migrateTPRoutes() (err error) {
 		return
 	}
 	switch vrs[utils.TpRoutes] {
+	case 1: // version 2 added the route rate profile ids column
+		if !m.dryRun {
+			if err = m.storDBIn.alterV1TPRoutes(); err != nil {
+				return
+			}
+			if err = m.setVersions(utils.TpRoutes); err != nil {
+				return
+			}
+		}
+		fallthrough
 	case current[utils.TpRoutes]:
 		if m.sameStorDB {
 			break
@@ -20,10 +20,13 @@ package rates
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/guardian"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -91,6 +94,10 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 		}
 		rPfIDs = rPfIDMp.AsSlice()
 	}
+	var sTime time.Time // the version is selected at the time the rating starts
+	if sTime, err = args.StartTime(rS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
 	var rpWw *rpWithWeight
 	for _, rPfID := range rPfIDs {
 		var rPf *engine.RateProfile
@@ -102,6 +109,9 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 			}
 			return
 		}
+		if rPf, err = rS.rateProfileVersion(rPf, sTime); err != nil {
+			return
+		}
 		if rPf.ActivationInterval != nil && args.CGREvent.Time != nil &&
 			!rPf.ActivationInterval.IsActiveAtTime(*args.CGREvent.Time) { // not active
 			continue
@@ -124,10 +134,23 @@ func (rS *RateS) matchingRateProfileForEvent(tnt string, rPfIDs []string, args *
 	if rpWw == nil {
 		return nil, utils.ErrNotFound
 	}
-
+	engine.RecordProfileHit(utils.CacheRateProfiles, tnt, rpWw.ID, rpWw.FilterIDs...)
 	return rpWw.RateProfile, nil
 }
 
+// rateProfileVersion returns the version of the RateProfile effective at the given time
+func (rS *RateS) rateProfileVersion(rtPfl *engine.RateProfile, vTime time.Time) (*engine.RateProfile, error) {
+	rpvs, err := rS.dm.GetRateProfileVersions(rtPfl.Tenant, rtPfl.ID,
+		true, true, utils.NonTransactional)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return rtPfl, nil
+		}
+		return nil, err
+	}
+	return rpvs.ProfileAt(rtPfl, vTime), nil
+}
+
 // rateProfileCostForEvent computes the rateProfileCost for an event based on a preselected rate profile
 func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent, verbosity int) (rpCost *engine.RateProfileCost, err error) {
 	evNm := utils.MapStorage{
@@ -135,7 +158,12 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 		utils.MetaOpts: args.Opts,
 	}
 	var rtIDs utils.StringSet
-	if rtIDs, err = engine.MatchingItemIDsForEvent(
+	if !rtPfl.IndexedRates() { // rates of older versions are checked one by one
+		rtIDs = make(utils.StringSet)
+		for rtID := range rtPfl.Rates {
+			rtIDs.Add(rtID)
+		}
+	} else if rtIDs, err = engine.MatchingItemIDsForEvent(
 		evNm,
 		rS.cfg.RateSCfg().RateStringIndexedFields,
 		rS.cfg.RateSCfg().RatePrefixIndexedFields,
@@ -179,8 +207,18 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 	if ordRts, err = orderRatesOnIntervals(aRates, wghts, sTime, usage, true, verbosity); err != nil {
 		return
 	}
+	var volUsage time.Duration // usage rated before within the volume period, selects the interval rates
+	if rtPfl.VolumePeriod != utils.EmptyString {
+		if volUsage, err = rS.volumeUsage(rtPfl, args, sTime); err != nil {
+			return
+		}
+		for _, ordRt := range ordRts {
+			ordRt.Duration += volUsage
+		}
+	}
 	rpCost = &engine.RateProfileCost{
-		ID: rtPfl.ID,
+		ID:       rtPfl.ID,
+		Currency: rtPfl.Currency,
 	}
 	var ok bool
 	if rtPfl.MinCost != nil {
@@ -194,13 +232,79 @@ func (rS *RateS) rateProfileCostForEvent(rtPfl *engine.RateProfile, args *utils.
 		}
 	}
 
-	if rpCost.RateSIntervals, err = computeRateSIntervals(ordRts, 0, usage); err != nil {
+	if rpCost.RateSIntervals, err = computeRateSIntervals(ordRts, volUsage, usage); err != nil {
 		return nil, err
 	}
 	// in case we have error it is returned in the function from above
 	// this came to light in coverage tests
 	rpCost.Cost, _ = engine.CostForIntervals(rpCost.RateSIntervals).Float64()
 
+	if rtPfl.VolumePeriod != utils.EmptyString {
+		var upd bool
+		if upd, err = args.VolumeUpdate(); err != nil {
+			return nil, err
+		}
+		if upd {
+			if err = rS.updateVolumeCounter(rtPfl, args, sTime, usage); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return
+}
+
+// volumeUsage returns the usage rated before within the volume period of the account
+func (rS *RateS) volumeUsage(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent,
+	sTime time.Time) (volUsage time.Duration, err error) {
+	var has bool
+	if volUsage, has, err = args.VolumeUsage(); err != nil || has { // counted by the caller
+		return
+	}
+	acntID := args.VolumeAccount()
+	if acntID == utils.EmptyString { // no counter context, tiers apply per event
+		return
+	}
+	var pStart time.Time
+	if pStart, err = engine.VolumePeriodStart(rtPfl.VolumePeriod, sTime); err != nil {
+		return
+	}
+	var rvc *engine.RateVolumeCounter
+	if rvc, err = rS.dm.GetRateVolumeCounter(args.CGREvent.Tenant,
+		engine.RateVolumeCounterID(rtPfl.ID, acntID)); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	return rvc.UsageAt(pStart), nil
+}
+
+// updateVolumeCounter adds the usage of the event to the volume counter of the account
+func (rS *RateS) updateVolumeCounter(rtPfl *engine.RateProfile, args *utils.ArgsCostForEvent,
+	sTime time.Time, usage time.Duration) (err error) {
+	acntID := args.VolumeAccount()
+	if acntID == utils.EmptyString {
+		return fmt.Errorf("missing <%s> to update the volume counter", utils.OptsRatesVolumeAccount)
+	}
+	var pStart time.Time
+	if pStart, err = engine.VolumePeriodStart(rtPfl.VolumePeriod, sTime); err != nil {
+		return
+	}
+	tnt := args.CGREvent.Tenant
+	rvcID := engine.RateVolumeCounterID(rtPfl.ID, acntID)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		var rvc *engine.RateVolumeCounter
+		if rvc, err = rS.dm.GetRateVolumeCounter(tnt, rvcID); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			rvc = &engine.RateVolumeCounter{Tenant: tnt, ID: rvcID}
+		}
+		rvc.Usage = rvc.UsageAt(pStart) + usage
+		rvc.PeriodStart = pStart
+		err = rS.dm.SetRateVolumeCounter(rvc)
+		return
+	}, rS.cfg.GeneralCfg().LockingTimeout, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tnt, rvcID))
 	return
 }
 
@@ -227,3 +331,229 @@ func (rS *RateS) V1CostForEvent(args *utils.ArgsCostForEvent, rpCost *engine.Rat
 	*rpCost = *rcvCost
 	return
 }
+
+// eventCostForBatch rates the event on index i of the batch, reporting the error within the reply
+// rtPfl forces the RateProfile used, otherwise the one matching the event is selected
+func (rS *RateS) eventCostForBatch(args *utils.ArgsCostForEvents, i int,
+	rtPfl *engine.RateProfile) (evCost *engine.EventRateProfileCost) {
+	cfeArgs, err := args.AsArgsCostForEvent(i)
+	if err != nil {
+		return &engine.EventRateProfileCost{Error: err.Error()}
+	}
+	evCost = &engine.EventRateProfileCost{EventID: cfeArgs.ID}
+	if rtPfl == nil {
+		if rtPfl, err = rS.matchingRateProfileForEvent(cfeArgs.Tenant, cfeArgs.RateProfileIDs, cfeArgs); err != nil {
+			evCost.Error = err.Error()
+			return
+		}
+	} else { // comparing profiles should not alter the volume counters
+		delete(cfeArgs.Opts, utils.OptsRatesVolumeUpdate)
+		var sTime time.Time
+		if sTime, err = cfeArgs.StartTime(rS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+			evCost.Error = err.Error()
+			return
+		}
+		if rtPfl, err = rS.rateProfileVersion(rtPfl, sTime); err != nil {
+			evCost.Error = err.Error()
+			return
+		}
+	}
+	if evCost.RateProfileCost, err = rS.rateProfileCostForEvent(rtPfl, cfeArgs,
+		rS.cfg.RateSCfg().Verbosity); err != nil {
+		evCost.Error = err.Error()
+	}
+	return
+}
+
+// costForEvents rates the events of the batch in parallel, keeping their order within the reply
+// the events counted on volume are rated one after the other, in the order of the batch, so their tiers do not depend on scheduling
+func (rS *RateS) costForEvents(args *utils.ArgsCostForEvents,
+	rtPfl *engine.RateProfile) (evCosts []*engine.EventRateProfileCost) {
+	evCosts = make([]*engine.EventRateProfileCost, len(args.Events))
+	guard := make(chan struct{}, runtime.NumCPU()) // limits the events rated at once
+	var wg sync.WaitGroup
+	var volIdxs []int
+	for i := range args.Events {
+		if cfeArgs, err := args.AsArgsCostForEvent(i); err == nil &&
+			cfeArgs.VolumeAccount() != utils.EmptyString {
+			volIdxs = append(volIdxs, i)
+			continue
+		}
+		wg.Add(1)
+		guard <- struct{}{}
+		go func(i int) {
+			evCosts[i] = rS.eventCostForBatch(args, i, rtPfl)
+			<-guard
+			wg.Done()
+		}(i)
+	}
+	for _, i := range volIdxs {
+		evCosts[i] = rS.eventCostForBatch(args, i, rtPfl)
+	}
+	wg.Wait()
+	return
+}
+
+// V1CostForEvents will be called to calculate the costs for a batch of events
+// the events which cannot be rated carry their error within the reply
+func (rS *RateS) V1CostForEvents(args *utils.ArgsCostForEvents, evCosts *[]*engine.EventRateProfileCost) (err error) {
+	if len(args.Events) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.Events)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	*evCosts = rS.costForEvents(args, nil)
+	return
+}
+
+// V1CompareRateProfiles rates the same batch of events with each of the RateProfiles
+// the Delta of each comparison is computed against the first RateProfile
+func (rS *RateS) V1CompareRateProfiles(args *utils.ArgsCostForEvents, rpCmps *[]*engine.RateProfileComparison) (err error) {
+	if len(args.RateProfileIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.RateProfileIDs)
+	}
+	if len(args.Events) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.Events)
+	}
+	args.Tenant = utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	rndDec := rS.cfg.GeneralCfg().RoundingDecimals
+	cmps := make([]*engine.RateProfileComparison, len(args.RateProfileIDs))
+	for i, rpID := range args.RateProfileIDs {
+		var rtPfl *engine.RateProfile
+		if rtPfl, err = rS.dm.GetRateProfile(args.Tenant, rpID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				return utils.ErrPrefixNotFound(rpID)
+			}
+			return utils.NewErrServerError(err)
+		}
+		cmp := &engine.RateProfileComparison{
+			ID:     rpID,
+			Events: rS.costForEvents(args, rtPfl),
+		}
+		for _, evCost := range cmp.Events {
+			if evCost.RateProfileCost == nil {
+				cmp.Errors++
+				continue
+			}
+			cmp.Cost += evCost.RateProfileCost.Cost
+		}
+		cmp.Cost = utils.Round(cmp.Cost, rndDec, utils.MetaRoundingMiddle)
+		if i != 0 {
+			cmp.Delta = utils.Round(cmp.Cost-cmps[0].Cost, rndDec, utils.MetaRoundingMiddle)
+		}
+		cmps[i] = cmp
+	}
+	*rpCmps = cmps
+	return
+}
+
+// V1GetRateProfileVersions returns the history of a RateProfile, sorted on EffectiveTime
+func (rS *RateS) V1GetRateProfileVersions(args *utils.TenantIDWithOpts, rpvs *[]*engine.RateProfileVersion) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	var rcv *engine.RateProfileVersions
+	if rcv, err = rS.dm.GetRateProfileVersions(tnt, args.ID,
+		true, true, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*rpvs = rcv.Versions
+	return
+}
+
+// V1UpdateVolumeCounter adds the usage of the event to the volume counter of the account on the matching RateProfile
+// used after charging so the usage counted is the one actually debited
+func (rS *RateS) V1UpdateVolumeCounter(args *utils.ArgsCostForEvent, reply *string) (err error) {
+	if args.VolumeAccount() == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.OptsRatesVolumeAccount)
+	}
+	args.CGREvent.Tenant = utils.FirstNonEmpty(args.CGREvent.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	var rtPfl *engine.RateProfile
+	if rtPfl, err = rS.matchingRateProfileForEvent(args.CGREvent.Tenant, args.RateProfileIDs, args); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	if rtPfl.VolumePeriod != utils.EmptyString {
+		var sTime time.Time
+		if sTime, err = args.StartTime(rS.cfg.GeneralCfg().DefaultTimezone); err != nil {
+			return
+		}
+		var usage time.Duration
+		if usage, err = args.Usage(); err != nil {
+			return
+		}
+		if err = rS.updateVolumeCounter(rtPfl, args, sTime, usage); err != nil {
+			return utils.NewErrServerError(err)
+		}
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetVolumeCounter returns the volume counter of the account on the RateProfile
+func (rS *RateS) V1GetVolumeCounter(args *utils.ArgsRateVolumeCounter, rvc *engine.RateVolumeCounter) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.RateProfileID, utils.AccountID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	var rcv *engine.RateVolumeCounter
+	if rcv, err = rS.dm.GetRateVolumeCounter(tnt,
+		engine.RateVolumeCounterID(args.RateProfileID, args.AccountID)); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*rvc = *rcv
+	return
+}
+
+// V1ResetVolumeCounter removes the volume counter of the account on the RateProfile
+func (rS *RateS) V1ResetVolumeCounter(args *utils.ArgsRateVolumeCounter, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.RateProfileID, utils.AccountID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	rvcID := engine.RateVolumeCounterID(args.RateProfileID, args.AccountID)
+	guardian.Guardian.Guard(func() (gRes interface{}, gErr error) {
+		err = rS.dm.RemoveRateVolumeCounter(tnt, rvcID)
+		return
+	}, rS.cfg.GeneralCfg().LockingTimeout, utils.RateVolumeCounterPrefix+utils.ConcatenatedKey(tnt, rvcID))
+	if err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetRateForPrefix returns the rate of the longest prefix within the RateDeck matching the destination
+func (rS *RateS) V1GetRateForPrefix(args *utils.ArgsGetRateForPrefix, rdr *engine.RateDeckRate) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.RateDeckID, utils.Destination}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := utils.FirstNonEmpty(args.Tenant, rS.cfg.GeneralCfg().DefaultTenant)
+	tm := time.Now()
+	if args.Time != nil {
+		tm = *args.Time
+	}
+	var rd *engine.RateDeck
+	if rd, err = rS.dm.GetRateDeck(tnt, args.RateDeckID,
+		true, true, utils.NonTransactional); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	var rcvRdr *engine.RateDeckRate
+	if rcvRdr, err = rd.RateForPrefix(args.Destination, tm); err != nil {
+		return
+	}
+	*rdr = *rcvRdr
+	return
+}
@@ -970,3 +970,486 @@ func TestRateProfileCostForEventMaximumIterations(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRateProfileCostForEventVolumeTiers(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:       "cgrates.org",
+		ID:           "RP_VOLUME",
+		VolumePeriod: utils.MetaMonthly,
+		Rates: map[string]*engine.Rate{
+			"RT_VOLUME": {
+				ID:              "RT_VOLUME",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 2),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+					{
+						IntervalStart: 1000 * time.Minute,
+						RecurrentFee:  utils.NewDecimal(15, 3),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	costForEvent := func(opts map[string]interface{}) float64 {
+		rpCost, err := rateS.rateProfileCostForEvent(rPrf, &utils.ArgsCostForEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "VOLUME_EVENT",
+				Event:  map[string]interface{}{},
+				Opts:   opts,
+			}}, rateS.cfg.RateSCfg().Verbosity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rpCost.Cost
+	}
+
+	// first event stays within the first tier and is counted
+	if cost := costForEvent(map[string]interface{}{
+		utils.OptsRatesStartTime:     "2021-01-10T10:00:00Z",
+		utils.OptsRatesUsage:         "990m",
+		utils.OptsRatesVolumeAccount: "1001",
+		utils.OptsRatesVolumeUpdate:  true,
+	}); cost != 19.8 {
+		t.Errorf("Expected 19.8, received %v", cost)
+	}
+	// second event crosses into the next tier
+	if cost := costForEvent(map[string]interface{}{
+		utils.OptsRatesStartTime:     "2021-01-20T10:00:00Z",
+		utils.OptsRatesUsage:         "20m",
+		utils.OptsRatesVolumeAccount: "1001",
+		utils.OptsRatesVolumeUpdate:  true,
+	}); cost != 0.35 {
+		t.Errorf("Expected 0.35, received %v", cost)
+	}
+	if rvc, err := dm.GetRateVolumeCounter("cgrates.org",
+		engine.RateVolumeCounterID("RP_VOLUME", "1001")); err != nil {
+		t.Error(err)
+	} else if rvc.Usage != 1010*time.Minute ||
+		!rvc.PeriodStart.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected counter: %s", utils.ToJSON(rvc))
+	}
+	// other accounts start from the first tier
+	if cost := costForEvent(map[string]interface{}{
+		utils.OptsRatesStartTime:     "2021-01-20T10:00:00Z",
+		utils.OptsRatesUsage:         "10m",
+		utils.OptsRatesVolumeAccount: "1002",
+	}); cost != 0.2 {
+		t.Errorf("Expected 0.2, received %v", cost)
+	}
+	// the counter restarts with a new period
+	if cost := costForEvent(map[string]interface{}{
+		utils.OptsRatesStartTime:     "2021-02-01T10:00:00Z",
+		utils.OptsRatesUsage:         "10m",
+		utils.OptsRatesVolumeAccount: "1001",
+	}); cost != 0.2 {
+		t.Errorf("Expected 0.2, received %v", cost)
+	}
+	// usage counted by the caller
+	if cost := costForEvent(map[string]interface{}{
+		utils.OptsRatesUsage:       "10m",
+		utils.OptsRatesVolumeUsage: "1000m",
+	}); cost != 0.15 {
+		t.Errorf("Expected 0.15, received %v", cost)
+	}
+
+	expErr := "missing <*ratesVolumeAccount> to update the volume counter"
+	if _, err := rateS.rateProfileCostForEvent(rPrf, &utils.ArgsCostForEvent{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "VOLUME_EVENT",
+			Event:  map[string]interface{}{},
+			Opts: map[string]interface{}{
+				utils.OptsRatesVolumeUpdate: true,
+			},
+		}}, rateS.cfg.RateSCfg().Verbosity); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
+}
+
+func TestRateSV1VolumeCounters(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	rPrf := &engine.RateProfile{
+		Tenant:       "cgrates.org",
+		ID:           "RP_VOLUME_BATCH",
+		VolumePeriod: utils.MetaMonthly,
+		Rates: map[string]*engine.Rate{
+			"RT_VOLUME": {
+				ID:              "RT_VOLUME",
+				ActivationTimes: "* * * * *",
+				IntervalRates: []*engine.IntervalRate{
+					{
+						IntervalStart: 0,
+						RecurrentFee:  utils.NewDecimal(2, 2),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+					{
+						IntervalStart: 1000 * time.Minute,
+						RecurrentFee:  utils.NewDecimal(15, 3),
+						Unit:          minDecimal,
+						Increment:     minDecimal,
+					},
+				},
+			},
+		},
+	}
+	if err := rPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetRateProfile(rPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsCostForEvents{
+		Tenant:         "cgrates.org",
+		RateProfileIDs: []string{"RP_VOLUME_BATCH"},
+		Opts: map[string]interface{}{
+			utils.OptsRatesStartTime:     "2021-01-10T10:00:00Z",
+			utils.OptsRatesUsage:         "500m",
+			utils.OptsRatesVolumeAccount: "1001",
+			utils.OptsRatesVolumeUpdate:  true,
+		},
+	}
+	for _, evID := range []string{"EV_1", "EV_2", "EV_3"} {
+		args.Events = append(args.Events, &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     evID,
+			Event:  map[string]interface{}{},
+		})
+	}
+	var evCosts []*engine.EventRateProfileCost
+	if err := rateS.V1CostForEvents(args, &evCosts); err != nil {
+		t.Fatal(err)
+	}
+	for i, expCost := range []float64{10, 10, 7.5} { // tiers follow the order of the batch
+		if evCosts[i].Error != utils.EmptyString {
+			t.Fatal(evCosts[i].Error)
+		}
+		if evCosts[i].RateProfileCost.Cost != expCost {
+			t.Errorf("Expected %v for %s, received %v", expCost, evCosts[i].EventID, evCosts[i].RateProfileCost.Cost)
+		}
+	}
+
+	var rvc engine.RateVolumeCounter
+	if err := rateS.V1GetVolumeCounter(&utils.ArgsRateVolumeCounter{RateProfileID: "RP_VOLUME_BATCH"},
+		&rvc); err == nil || err.Error() != "MANDATORY_IE_MISSING: [AccountID]" {
+		t.Errorf("Expected MANDATORY_IE_MISSING: [AccountID], received %v", err)
+	}
+	rvcArgs := &utils.ArgsRateVolumeCounter{RateProfileID: "RP_VOLUME_BATCH", AccountID: "1001"}
+	if err := rateS.V1GetVolumeCounter(rvcArgs, &rvc); err != nil {
+		t.Fatal(err)
+	} else if rvc.Usage != 1500*time.Minute {
+		t.Errorf("Expected %v, received %v", 1500*time.Minute, rvc.Usage)
+	}
+	var reply string
+	if err := rateS.V1UpdateVolumeCounter(&utils.ArgsCostForEvent{
+		RateProfileIDs: []string{"RP_VOLUME_BATCH"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV_4",
+			Event:  map[string]interface{}{},
+			Opts: map[string]interface{}{
+				utils.OptsRatesStartTime: "2021-01-20T10:00:00Z",
+				utils.OptsRatesUsage:     "10m",
+			},
+		}}, &reply); err == nil || err.Error() != "MANDATORY_IE_MISSING: [*ratesVolumeAccount]" {
+		t.Errorf("Expected MANDATORY_IE_MISSING: [*ratesVolumeAccount], received %v", err)
+	}
+	if err := rateS.V1UpdateVolumeCounter(&utils.ArgsCostForEvent{
+		RateProfileIDs: []string{"RP_VOLUME_BATCH"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV_4",
+			Event:  map[string]interface{}{},
+			Opts: map[string]interface{}{
+				utils.OptsRatesStartTime:     "2021-01-20T10:00:00Z",
+				utils.OptsRatesUsage:         "10m",
+				utils.OptsRatesVolumeAccount: "1001",
+			},
+		}}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := rateS.V1GetVolumeCounter(rvcArgs, &rvc); err != nil {
+		t.Fatal(err)
+	} else if rvc.Usage != 1510*time.Minute {
+		t.Errorf("Expected %v, received %v", 1510*time.Minute, rvc.Usage)
+	}
+	if err := rateS.V1ResetVolumeCounter(rvcArgs, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := rateS.V1GetVolumeCounter(rvcArgs, &rvc); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestRateSV1CostForEventsAndCompare(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	for id, fee := range map[string]*utils.Decimal{
+		"RP_A": utils.NewDecimal(2, 2),
+		"RP_B": utils.NewDecimal(15, 3),
+	} {
+		rPrf := &engine.RateProfile{
+			Tenant: "cgrates.org",
+			ID:     id,
+			Rates: map[string]*engine.Rate{
+				"RT_1": {
+					ID:              "RT_1",
+					ActivationTimes: "* * * * *",
+					IntervalRates: []*engine.IntervalRate{
+						{
+							IntervalStart: 0,
+							RecurrentFee:  fee,
+							Unit:          minDecimal,
+							Increment:     minDecimal,
+						},
+					},
+				},
+			},
+		}
+		if err := rPrf.Compile(); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.SetRateProfile(rPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := &utils.ArgsCostForEvents{
+		RateProfileIDs: []string{"RP_A"},
+		Events: []*utils.CGREvent{
+			{
+				ID:    "EV_1",
+				Event: map[string]interface{}{utils.Usage: "10m"},
+			},
+			{
+				ID:    "EV_2",
+				Event: map[string]interface{}{utils.Usage: "20m"},
+			},
+			{
+				ID:    "EV_3",
+				Event: map[string]interface{}{},
+				Opts:  map[string]interface{}{utils.OptsRatesUsage: "invalid"},
+			},
+		},
+		Opts: map[string]interface{}{
+			utils.OptsRatesStartTime: "2021-01-10T10:00:00Z",
+		},
+	}
+
+	var evCosts []*engine.EventRateProfileCost
+	if err := rateS.V1CostForEvents(args, &evCosts); err != nil {
+		t.Fatal(err)
+	}
+	if len(evCosts) != 3 {
+		t.Fatalf("Expected 3 costs, received: %s", utils.ToJSON(evCosts))
+	}
+	for i, exp := range []float64{0.2, 0.4} {
+		if evCosts[i].Error != utils.EmptyString || evCosts[i].EventID != args.Events[i].ID ||
+			evCosts[i].RateProfileCost.ID != "RP_A" || evCosts[i].RateProfileCost.Cost != exp {
+			t.Errorf("unexpected cost on index %d: %s", i, utils.ToJSON(evCosts[i]))
+		}
+	}
+	if evCosts[2].EventID != "EV_3" || evCosts[2].RateProfileCost != nil ||
+		evCosts[2].Error == utils.EmptyString {
+		t.Errorf("expected error for the last event, received: %s", utils.ToJSON(evCosts[2]))
+	}
+
+	args.RateProfileIDs = []string{"RP_A", "RP_B"}
+	var rpCmps []*engine.RateProfileComparison
+	if err := rateS.V1CompareRateProfiles(args, &rpCmps); err != nil {
+		t.Fatal(err)
+	}
+	if len(rpCmps) != 2 {
+		t.Fatalf("Expected 2 comparisons, received: %s", utils.ToJSON(rpCmps))
+	}
+	if rpCmps[0].ID != "RP_A" || rpCmps[0].Cost != 0.6 ||
+		rpCmps[0].Delta != 0 || rpCmps[0].Errors != 1 || len(rpCmps[0].Events) != 3 {
+		t.Errorf("unexpected comparison: %s", utils.ToJSON(rpCmps[0]))
+	}
+	if rpCmps[1].ID != "RP_B" || rpCmps[1].Cost != 0.45 ||
+		rpCmps[1].Delta != -0.15 || rpCmps[1].Errors != 1 {
+		t.Errorf("unexpected comparison: %s", utils.ToJSON(rpCmps[1]))
+	}
+
+	args.RateProfileIDs = []string{"RP_A", "RP_MISSING"}
+	if err := rateS.V1CompareRateProfiles(args, &rpCmps); err == nil ||
+		err.Error() != utils.ErrPrefixNotFound("RP_MISSING").Error() {
+		t.Errorf("Expected NOT_FOUND:RP_MISSING, received %v", err)
+	}
+	if err := rateS.V1CompareRateProfiles(&utils.ArgsCostForEvents{Events: args.Events},
+		&rpCmps); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.RateProfileIDs).Error() {
+		t.Errorf("Expected MANDATORY_IE_MISSING, received %v", err)
+	}
+	if err := rateS.V1CostForEvents(&utils.ArgsCostForEvents{}, &evCosts); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.Events).Error() {
+		t.Errorf("Expected MANDATORY_IE_MISSING, received %v", err)
+	}
+}
+
+func TestRateSV1GetRateForPrefix(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	rd := &engine.RateDeck{
+		Tenant: "cgrates.org",
+		ID:     "CARRIER1",
+		Rates: []*engine.RateDeckRate{
+			{Prefix: "49", Description: "Germany", Rate: utils.NewDecimal(5, 2),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+			{Prefix: "4930", Description: "Germany Berlin", Rate: utils.NewDecimal(2, 2),
+				EffectiveDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	if err := dm.SetRateDeck(rd); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.ArgsGetRateForPrefix{
+		RateDeckID:  "CARRIER1",
+		Destination: "4930123456",
+		Time:        utils.TimePointer(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	var rdr engine.RateDeckRate
+	if err := rateS.V1GetRateForPrefix(args, &rdr); err != nil {
+		t.Fatal(err)
+	} else if rdr.Prefix != "4930" || rdr.Rate.Compare(utils.NewDecimal(2, 2)) != 0 {
+		t.Errorf("unexpected rate: %s", utils.ToJSON(rdr))
+	}
+	args.Destination = "33123456"
+	if err := rateS.V1GetRateForPrefix(args, &rdr); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	args.RateDeckID = "CARRIER2"
+	if err := rateS.V1GetRateForPrefix(args, &rdr); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := rateS.V1GetRateForPrefix(&utils.ArgsGetRateForPrefix{RateDeckID: "CARRIER1"},
+		&rdr); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.Destination).Error() {
+		t.Errorf("Expected MANDATORY_IE_MISSING, received %v", err)
+	}
+}
+
+func TestRateSV1RateProfileVersions(t *testing.T) {
+	defaultCfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true)
+	dm := engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	filters := engine.NewFilterS(defaultCfg, nil, dm)
+	rateS := NewRateS(defaultCfg, filters, dm)
+	minDecimal, err := utils.NewDecimalFromUsage("1m")
+	if err != nil {
+		t.Error(err)
+	}
+	tNow := time.Now()
+	effTime := tNow.Add(time.Hour)
+	for _, fee := range []*utils.Decimal{utils.NewDecimal(2, 2), utils.NewDecimal(3, 2)} {
+		rPrf := &engine.RateProfile{
+			Tenant: "cgrates.org",
+			ID:     "RP_VERSIONED",
+			Rates: map[string]*engine.Rate{
+				"RT_1": {
+					ID:              "RT_1",
+					ActivationTimes: "* * * * *",
+					IntervalRates: []*engine.IntervalRate{
+						{
+							IntervalStart: 0,
+							RecurrentFee:  fee,
+							Unit:          minDecimal,
+							Increment:     minDecimal,
+						},
+					},
+				},
+			},
+		}
+		if fee.Compare(utils.NewDecimal(3, 2)) == 0 { // the new tariff is scheduled in one hour
+			rPrf.ActivationInterval = &utils.ActivationInterval{ActivationTime: effTime}
+		}
+		if err := dm.SetRateProfile(rPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for sTime, expCost := range map[time.Time]float64{
+		tNow.Add(10 * time.Minute): 0.2,
+		effTime.Add(time.Minute):   0.3,
+	} {
+		var rpCost engine.RateProfileCost
+		if err := rateS.V1CostForEvent(&utils.ArgsCostForEvent{
+			RateProfileIDs: []string{"RP_VERSIONED"},
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "EV_1",
+				Event:  map[string]interface{}{utils.Usage: "10m"},
+				Opts:   map[string]interface{}{utils.OptsRatesStartTime: sTime},
+			},
+		}, &rpCost); err != nil {
+			t.Fatal(err)
+		} else if rpCost.Cost != expCost {
+			t.Errorf("at %v expecting cost %v, received: %s", sTime, expCost, utils.ToJSON(rpCost))
+		}
+	}
+	// without a start time the version effective now is used
+	var rpCost engine.RateProfileCost
+	if err := rateS.V1CostForEvent(&utils.ArgsCostForEvent{
+		RateProfileIDs: []string{"RP_VERSIONED"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "EV_2",
+			Event: map[string]interface{}{
+				utils.SetupTime: effTime.Add(time.Minute),
+				utils.Usage:     "10m",
+			},
+		},
+	}, &rpCost); err != nil {
+		t.Fatal(err)
+	} else if rpCost.Cost != 0.2 {
+		t.Errorf("expecting cost 0.2, received: %s", utils.ToJSON(rpCost))
+	}
+	var rpvs []*engine.RateProfileVersion
+	if err := rateS.V1GetRateProfileVersions(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: "RP_VERSIONED"}}, &rpvs); err != nil {
+		t.Fatal(err)
+	} else if len(rpvs) != 2 || !rpvs[1].EffectiveTime.Equal(effTime) {
+		t.Errorf("unexpected versions: %s", utils.ToJSON(rpvs))
+	}
+	if err := rateS.V1GetRateProfileVersions(&utils.TenantIDWithOpts{
+		TenantID: &utils.TenantID{ID: "RP_MISSING"}}, &rpvs); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -46,9 +46,11 @@ type DataDBService struct {
 	oldDBCfg *config.DataDbCfg
 	connMgr  *engine.ConnManager
 
-	dm     *engine.DataManager
-	dbchan chan *engine.DataManager
-	srvDep map[string]*sync.WaitGroup
+	dm       *engine.DataManager
+	hits     *engine.HitCounters
+	dbchan   chan *engine.DataManager
+	srvDep   map[string]*sync.WaitGroup
+	stopSync chan struct{}
 }
 
 // Start should handle the sercive start
@@ -74,10 +76,25 @@ func (db *DataDBService) Start() (err error) {
 	}
 	db.dm = engine.NewDataManager(d, db.cfg.CacheCfg(), db.connMgr)
 	engine.SetDataStorage(db.dm)
+	db.hits = engine.NewHitCounters(db.dm, db.cfg.GeneralCfg().HitsStoreInterval)
+	engine.SetHitCounters(db.hits)
+	db.hits.StartLoop()
 	if err = engine.CheckVersions(db.dm.DataDB()); err != nil {
 		fmt.Println(err)
 		return
 	}
+	if db.cfg.ConfigSCfg().LoadFromDataDB {
+		if db.cfg.DataDbCfg().Type != utils.INTERNAL { // already loaded on engine start
+			db.cfg.SetConfigDB(db.dm.DataDB())
+		} else if err = db.cfg.LoadFromDB(db.dm.DataDB()); err != nil {
+			utils.Logger.Crit(fmt.Sprintf("Could not load the config from dataDb: %s exiting!", err))
+			return
+		}
+		if syncIntvl := db.cfg.ConfigSCfg().DataDBSyncInterval; syncIntvl > 0 {
+			db.stopSync = make(chan struct{})
+			go db.cfg.SyncFromDB(syncIntvl, db.stopSync)
+		}
+	}
 	db.dbchan <- db.dm
 	return
 }
@@ -112,6 +129,12 @@ func (db *DataDBService) Reload() (err error) {
 func (db *DataDBService) Shutdown() (err error) {
 	db.srvDep[utils.DataDB].Wait()
 	db.Lock()
+	if db.stopSync != nil {
+		close(db.stopSync)
+		db.stopSync = nil
+	}
+	engine.SetHitCounters(nil)
+	db.hits.Shutdown()
 	db.dm.DataDB().Close()
 	db.dm = nil
 	db.Unlock()
@@ -141,7 +164,8 @@ func (db *DataDBService) mandatoryDB() bool {
 		db.cfg.AttributeSCfg().Enabled || db.cfg.ResourceSCfg().Enabled || db.cfg.StatSCfg().Enabled ||
 		db.cfg.ThresholdSCfg().Enabled || db.cfg.RouteSCfg().Enabled || db.cfg.DispatcherSCfg().Enabled ||
 		db.cfg.LoaderCfg().Enabled() || db.cfg.ApierCfg().Enabled || db.cfg.RateSCfg().Enabled ||
-		db.cfg.AccountSCfg().Enabled || db.cfg.ActionSCfg().Enabled || db.cfg.AnalyzerSCfg().Enabled
+		db.cfg.AccountSCfg().Enabled || db.cfg.ActionSCfg().Enabled || db.cfg.AnalyzerSCfg().Enabled ||
+		db.cfg.ConfigSCfg().LoadFromDataDB
 }
 
 // GetDM returns the DataManager
@@ -23,7 +23,9 @@ import (
 	"sync"
 
 	"github.com/cgrates/cgrates/agents"
+	v1 "github.com/cgrates/cgrates/apier/v1"
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/servmanager"
 	"github.com/cgrates/cgrates/utils"
@@ -31,11 +33,12 @@ import (
 
 // NewDiameterAgent returns the Diameter Agent
 func NewDiameterAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
-	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
+	server *cores.Server, shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
 	srvDep map[string]*sync.WaitGroup) servmanager.Service {
 	return &DiameterAgent{
 		cfg:         cfg,
 		filterSChan: filterSChan,
+		server:      server,
 		shdChan:     shdChan,
 		connMgr:     connMgr,
 		srvDep:      srvDep,
@@ -47,10 +50,12 @@ type DiameterAgent struct {
 	sync.RWMutex
 	cfg         *config.CGRConfig
 	filterSChan chan *engine.FilterS
+	server      *cores.Server
 	shdChan     *utils.SyncedChan
 	stopChan    chan struct{}
 
 	da      *agents.DiameterAgent
+	rpc     *v1.DiameterAgentV1
 	connMgr *engine.ConnManager
 
 	lnet  string
@@ -80,6 +85,14 @@ func (da *DiameterAgent) start(filterS *engine.FilterS) (err error) {
 			utils.DiameterAgent, err))
 		return
 	}
+	if da.rpc == nil {
+		da.rpc = v1.NewDiameterAgentV1(da.da)
+		if !da.cfg.DispatcherSCfg().Enabled {
+			da.server.RpcRegister(da.rpc)
+		}
+	} else {
+		da.rpc.SetDiameterAgent(da.da)
+	}
 	da.lnet = da.cfg.DiameterAgentCfg().ListenNet
 	da.laddr = da.cfg.DiameterAgentCfg().Listen
 	da.stopChan = make(chan struct{})
@@ -112,6 +125,9 @@ func (da *DiameterAgent) Shutdown() (err error) {
 	da.Lock()
 	close(da.stopChan)
 	da.da = nil
+	if da.rpc != nil {
+		da.rpc.SetDiameterAgent(nil)
+	}
 	da.Unlock()
 	return // no shutdown for the momment
 }
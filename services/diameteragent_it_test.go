@@ -1,3 +1,4 @@
+//go:build integration
 // +build integration
 
 /*
@@ -53,7 +54,7 @@ func TestDiameterAgentReload1(t *testing.T) {
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan rpcclient.ClientConnector, 1), srvDep)
 	sS := NewSessionService(cfg, db, server, make(chan rpcclient.ClientConnector, 1),
 		shdChan, nil, nil, anz, srvDep)
-	srv := NewDiameterAgent(cfg, filterSChan, shdChan, nil, srvDep)
+	srv := NewDiameterAgent(cfg, filterSChan, server, shdChan, nil, srvDep)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(srv, sS,
 		NewLoaderService(cfg, db, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil, anz, srvDep), db)
@@ -112,8 +113,9 @@ func TestDiameterAgentReload2(t *testing.T) {
 	chS := engine.NewCacheS(cfg, nil, nil)
 	cacheSChan := make(chan rpcclient.ClientConnector, 1)
 	cacheSChan <- chS
+	server := cores.NewServer(nil)
 	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
-	srv := NewDiameterAgent(cfg, filterSChan, shdChan, nil, srvDep)
+	srv := NewDiameterAgent(cfg, filterSChan, server, shdChan, nil, srvDep)
 	if srv.IsRunning() {
 		t.Errorf("Expected service to be down")
 	}
@@ -137,8 +139,9 @@ func TestDiameterAgentReload3(t *testing.T) {
 	chS := engine.NewCacheS(cfg, nil, nil)
 	cacheSChan := make(chan rpcclient.ClientConnector, 1)
 	cacheSChan <- chS
+	server := cores.NewServer(nil)
 	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
-	srv := NewDiameterAgent(cfg, filterSChan, shdChan, nil, srvDep)
+	srv := NewDiameterAgent(cfg, filterSChan, server, shdChan, nil, srvDep)
 
 	cfg.DiameterAgentCfg().ListenNet = "bad"
 	cfg.DiameterAgentCfg().DictionariesPath = ""
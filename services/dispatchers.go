@@ -154,6 +154,9 @@ func (dspS *DispatcherService) Start() (err error) {
 	dspS.server.RpcRegisterName(utils.AccountSv1,
 		v1.NewDispatcherAccountSv1(dspS.dspS))
 
+	dspS.server.RpcRegisterName(utils.DiameterAgentV1,
+		v1.NewDispatcherDiameterAgentV1(dspS.dspS))
+
 	dspS.connChan <- dspS.anz.GetInternalCodec(dspS.dspS, utils.DispatcherS)
 
 	return
@@ -111,6 +111,14 @@ func (s *Session) cgrID() (cgrID string) {
 	return
 }
 
+// logFields returns the fields identifying the session within the structured logs
+// not thread safe
+func (s *Session) logFields() (flds utils.LogFields) {
+	flds = s.EventStart.LogFields(s.Tenant)
+	flds[utils.LogFieldCGRID] = s.CGRID
+	return
+}
+
 // Clone is a thread safe method to clone the sessions information
 func (s *Session) Clone() (cln *Session) {
 	s.RLock()
@@ -63,6 +63,24 @@ func TestSessionCgrID(t *testing.T) {
 
 }
 
+func TestSessionLogFields(t *testing.T) {
+	s := &Session{
+		CGRID:  "testID",
+		Tenant: "cgrates.org",
+		EventStart: engine.MapEvent{
+			utils.OriginID: "origin1",
+		},
+	}
+	eOut := utils.LogFields{
+		utils.LogFieldTenant:   "cgrates.org",
+		utils.LogFieldCGRID:    "testID",
+		utils.LogFieldOriginID: "origin1",
+	}
+	if rcv := s.logFields(); !reflect.DeepEqual(eOut, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eOut), utils.ToJSON(rcv))
+	}
+}
+
 func TestSessionClone(t *testing.T) {
 	//empty check
 	session := new(Session)
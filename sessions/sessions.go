@@ -344,19 +344,21 @@ func (sS *SessionS) forceSTerminate(s *Session, extraUsage time.Duration, tUsage
 	if extraUsage != 0 {
 		for i := range s.SRuns {
 			if _, err = sS.debitSession(s, i, extraUsage, lastUsed); err != nil {
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf(
 						"<%s> failed debitting cgrID %s, sRunIdx: %d, err: %s",
-						utils.SessionS, s.cgrID(), i, err.Error()))
+						utils.SessionS, s.cgrID(), i, err.Error()),
+					s.logFields())
 			}
 		}
 	}
 	// we apply the correction before
 	if err = sS.endSession(s, tUsage, lastUsed, nil, false); err != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf(
 				"<%s> failed force terminating session with ID <%s>, err: <%s>",
-				utils.SessionS, s.cgrID(), err.Error()))
+				utils.SessionS, s.cgrID(), err.Error()),
+			s.logFields())
 	}
 	// post the CDRs
 	if len(sS.cgrCfg.SessionSCfg().CDRsConns) != 0 {
@@ -374,10 +376,11 @@ func (sS *SessionS) forceSTerminate(s *Session, extraUsage time.Duration, tUsage
 			argsProc.SetCloneable(true)
 			if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().CDRsConns, nil,
 				utils.CDRsV1ProcessEvent, argsProc, &reply); err != nil {
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf(
 						"<%s> could not post CDR for event %s, err: %s",
-						utils.SessionS, utils.ToJSON(cgrEv), err.Error()))
+						utils.SessionS, utils.ToJSON(cgrEv), err.Error()),
+					s.logFields())
 			}
 		}
 	}
@@ -398,9 +401,10 @@ func (sS *SessionS) forceSTerminate(s *Session, extraUsage time.Duration, tUsage
 		if err := sS.connMgr.Call(sS.cgrCfg.SessionSCfg().ResSConns, nil,
 			utils.ResourceSv1ReleaseResources,
 			argsRU, &reply); err != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s could not release resource with resourceID: %s",
-					utils.SessionS, err.Error(), s.ResourceID))
+					utils.SessionS, err.Error(), s.ResourceID),
+				s.logFields())
 		}
 	}
 	sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
@@ -414,9 +418,10 @@ func (sS *SessionS) forceSTerminate(s *Session, extraUsage time.Duration, tUsage
 					Reason:     ErrForcedDisconnect.Error()},
 				&rply); err != nil {
 				if err != utils.ErrNotImplemented {
-					utils.Logger.Warning(
+					utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 						fmt.Sprintf("<%s> err: %s remotely disconnect session with id: %s",
-							utils.SessionS, err.Error(), s.CGRID))
+							utils.SessionS, err.Error(), s.CGRID),
+						s.logFields())
 				}
 			}
 		}()
@@ -550,9 +555,10 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 		}
 		var maxDebit time.Duration
 		if maxDebit, err = sS.debitSession(s, sRunIdx, dbtIvl, nil); err != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> could not complete debit operation on session: <%s>, error: <%s>",
-					utils.SessionS, s.cgrID(), err.Error()))
+					utils.SessionS, s.cgrID(), err.Error()),
+				s.logFields())
 			dscReason := utils.ErrServerError.Error()
 			if err.Error() == utils.ErrUnauthorizedDestination.Error() {
 				dscReason = err.Error()
@@ -563,12 +569,14 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 					s.Unlock()
 					return
 				}
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> could not disconnect session: %s, error: %s",
-						utils.SessionS, s.cgrID(), err.Error()))
+						utils.SessionS, s.cgrID(), err.Error()),
+					s.logFields())
 			}
 			if err = sS.forceSTerminate(s, 0, nil, nil); err != nil {
-				utils.Logger.Warning(fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>", utils.SessionS, s.cgrID(), err))
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING, fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>", utils.SessionS, s.cgrID(), err),
+					s.logFields())
 			}
 			s.Unlock()
 			return
@@ -577,8 +585,9 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 		s.SRuns[sRunIdx].NextAutoDebit = utils.TimePointer(time.Now().Add(dbtIvl))
 		if maxDebit < dbtIvl && sS.cgrCfg.SessionSCfg().MinDurLowBalance != time.Duration(0) { // warn client for low balance
 			if sS.cgrCfg.SessionSCfg().MinDurLowBalance >= dbtIvl {
-				utils.Logger.Warning(fmt.Sprintf("<%s> can not run warning for the session: <%s> since the remaining time:<%s> is higher than the debit interval:<%s>.",
-					utils.SessionS, s.cgrID(), sS.cgrCfg.SessionSCfg().MinDurLowBalance, dbtIvl))
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING, fmt.Sprintf("<%s> can not run warning for the session: <%s> since the remaining time:<%s> is higher than the debit interval:<%s>.",
+					utils.SessionS, s.cgrID(), sS.cgrCfg.SessionSCfg().MinDurLowBalance, dbtIvl),
+					s.logFields())
 			} else if maxDebit <= sS.cgrCfg.SessionSCfg().MinDurLowBalance {
 				go sS.warnSession(s.ClientConnID, s.EventStart.Clone())
 			}
@@ -598,12 +607,14 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 						return
 					}
 				}
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> could not disconnect session: <%s>, error: <%s>",
-						utils.SessionS, s.cgrID(), err.Error()))
+						utils.SessionS, s.cgrID(), err.Error()),
+					s.logFields())
 				if err = sS.forceSTerminate(s, 0, nil, nil); err != nil {
-					utils.Logger.Warning(fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>",
-						utils.SessionS, s.cgrID(), err))
+					utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING, fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>",
+						utils.SessionS, s.cgrID(), err),
+						s.logFields())
 				}
 			}
 			return
@@ -698,14 +709,16 @@ func (sS *SessionS) storeSCost(s *Session, sRunIdx int) (err error) {
 	// use the v1 because it doesn't do rounding refund
 	if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().CDRsConns, nil, utils.CDRsV1StoreSessionCost,
 		argSmCost, &reply); err != nil && err == utils.ErrExists {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> refunding session: <%s> error: <%s>",
-				utils.SessionS, s.CGRID, err.Error()))
+				utils.SessionS, s.CGRID, err.Error()),
+			s.logFields())
 		if err = sS.refundSession(s, sRunIdx, sr.CD.GetDuration()); err != nil { // refund entire duration
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf(
 					"<%s> failed refunding session: <%s>, srIdx: <%d>, error: <%s>",
-					utils.SessionS, s.CGRID, sRunIdx, err.Error()))
+					utils.SessionS, s.CGRID, sRunIdx, err.Error()),
+				s.logFields())
 		}
 	}
 	return
@@ -802,9 +815,10 @@ func (sS *SessionS) replicateSessions(cgrID string, psv bool, connIDs []string)
 		if err := sS.connMgr.Call(connIDs, nil,
 			utils.SessionSv1SetPassiveSession,
 			sCln, &rply); err != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> cannot replicate session with id <%s>, err: %s",
-					utils.SessionS, sCln.CGRID, err.Error()))
+					utils.SessionS, sCln.CGRID, err.Error()),
+				sCln.logFields())
 		}
 	}
 	return
@@ -1391,9 +1405,10 @@ func (sS *SessionS) terminateSyncSessions(toBeRemoved []string) {
 		}
 		ss[0].Lock()
 		if err := sS.forceSTerminate(ss[0], 0, nil, nil); err != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> failed force-terminating session: <%s>, err: <%s>",
-					utils.SessionS, cgrID, err.Error()))
+					utils.SessionS, cgrID, err.Error()),
+				ss[0].logFields())
 		}
 		ss[0].Unlock()
 	}
@@ -1578,16 +1593,18 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 					}
 				} else if notCharged < 0 { // charged too much, try refund
 					if err = sS.refundSession(s, sRunIdx, -notCharged); err != nil {
-						utils.Logger.Warning(
+						utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 							fmt.Sprintf(
 								"<%s> failed refunding session: <%s>, srIdx: <%d>, error: <%s>",
-								utils.SessionS, s.CGRID, sRunIdx, err.Error()))
+								utils.SessionS, s.CGRID, sRunIdx, err.Error()),
+							s.logFields())
 					}
 				}
 				if err := sS.roundCost(s, sRunIdx); err != nil { // will round the cost and refund the extra increment
-					utils.Logger.Warning(
+					utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 						fmt.Sprintf("<%s> failed rounding  session cost for <%s>, srIdx: <%d>, error: <%s>",
-							utils.SessionS, s.CGRID, sRunIdx, err.Error()))
+							utils.SessionS, s.CGRID, sRunIdx, err.Error()),
+						s.logFields())
 				}
 			}
 			// compute the event cost before saving the SessionCost
@@ -1595,9 +1612,10 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 			sr.EventCost.Compute()
 			if sS.cgrCfg.SessionSCfg().StoreSCosts {
 				if err := sS.storeSCost(s, sRunIdx); err != nil {
-					utils.Logger.Warning(
+					utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 						fmt.Sprintf("<%s> failed storing session cost for <%s>, srIdx: <%d>, error: <%s>",
-							utils.SessionS, s.CGRID, sRunIdx, err.Error()))
+							utils.SessionS, s.CGRID, sRunIdx, err.Error()),
+						s.logFields())
 				}
 			}
 
@@ -1633,9 +1651,10 @@ func (sS *SessionS) chargeEvent(cgrEv *utils.CGREvent, forceDuration bool) (maxU
 	if sRunsUsage, err = sS.updateSession(s, nil, nil, true); err != nil {
 		if errEnd := sS.terminateSession(s,
 			utils.DurationPointer(time.Duration(0)), nil, nil, true); errEnd != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error when force-ending charged event: <%s>, err: <%s>",
-					utils.SessionS, cgrID, errEnd.Error()))
+					utils.SessionS, cgrID, errEnd.Error()),
+				s.logFields())
 		}
 		err = utils.NewErrRALs(err)
 		return
@@ -1654,9 +1673,10 @@ func (sS *SessionS) chargeEvent(cgrEv *utils.CGREvent, forceDuration bool) (maxU
 	}
 	//in case of postpaid and rated maxUsage = usage from event
 	if errEnd := sS.terminateSession(s, utils.DurationPointer(usage), nil, nil, true); errEnd != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> error when ending charged event: <%s>, err: <%s>",
-				utils.SessionS, cgrID, errEnd.Error()))
+				utils.SessionS, cgrID, errEnd.Error()),
+			s.logFields())
 	}
 	return // returns here the maxUsage from update
 }
@@ -2010,9 +2030,10 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(clnt rpcclient.ClientConnector,
 	if args.ProcessThresholds {
 		tIDs, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
 		if err != nil && err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with ThresholdS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		authReply.ThresholdIDs = &tIDs
@@ -2021,9 +2042,10 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(clnt rpcclient.ClientConnector,
 		sIDs, err := sS.processStats(args.CGREvent, args.StatIDs, false)
 		if err != nil &&
 			err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with StatS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		authReply.StatQueueIDs = &sIDs
@@ -2301,9 +2323,10 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 	if args.ProcessThresholds {
 		tIDs, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
 		if err != nil && err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with ThresholdS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		rply.ThresholdIDs = &tIDs
@@ -2312,9 +2335,10 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 		sIDs, err := sS.processStats(args.CGREvent, args.StatIDs, false)
 		if err != nil &&
 			err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with StatS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		rply.StatQueueIDs = &sIDs
@@ -2674,9 +2698,10 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 		_, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
 		if err != nil &&
 			err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with ThresholdS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 	}
@@ -2684,9 +2709,10 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 		_, err := sS.processStats(args.CGREvent, args.StatIDs, false)
 		if err != nil &&
 			err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with StatS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 	}
@@ -2966,9 +2992,10 @@ func (sS *SessionS) BiRPCv1ProcessMessage(clnt rpcclient.ClientConnector,
 	if args.ProcessThresholds {
 		tIDs, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
 		if err != nil && err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with ThresholdS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		rply.ThresholdIDs = &tIDs
@@ -2977,9 +3004,10 @@ func (sS *SessionS) BiRPCv1ProcessMessage(clnt rpcclient.ClientConnector,
 		sIDs, err := sS.processStats(args.CGREvent, args.StatIDs, false)
 		if err != nil &&
 			err.Error() != utils.ErrNotFound.Error() {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error: %s processing event %+v with StatS.",
-					utils.SessionS, err.Error(), args.CGREvent))
+					utils.SessionS, err.Error(), args.CGREvent),
+				engine.MapEvent(args.CGREvent.Event).LogFields(args.CGREvent.Tenant))
 			withErrors = true
 		}
 		rply.StatQueueIDs = &sIDs
@@ -3189,9 +3217,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 				if blockError {
 					return utils.NewErrThresholdS(err)
 				}
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> error: %s processing event %+v for RunID <%s>  with ThresholdS.",
-						utils.SessionS, err.Error(), cgrEv, runID))
+						utils.SessionS, err.Error(), cgrEv, runID),
+					engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 				withErrors = true
 			}
 			rply.ThresholdIDs[runID] = tIDs
@@ -3209,9 +3238,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 				if blockError {
 					return utils.NewErrStatS(err)
 				}
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> error: %s processing event %+v for RunID <%s> with StatS.",
-						utils.SessionS, err.Error(), cgrEv, runID))
+						utils.SessionS, err.Error(), cgrEv, runID),
+					engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 				withErrors = true
 			}
 			rply.StatQueueIDs[runID] = sIDs
@@ -3303,9 +3333,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 						if blockError {
 							return utils.NewErrResourceS(err)
 						}
-						utils.Logger.Warning(
+						utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 							fmt.Sprintf("<%s> error: <%s> processing event %+v for RunID <%s>  with ResourceS.",
-								utils.SessionS, err.Error(), cgrEv, runID))
+								utils.SessionS, err.Error(), cgrEv, runID),
+							engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 						withErrors = true
 					}
 				case resOpt.Has(utils.MetaAllocate):
@@ -3314,9 +3345,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 						if blockError {
 							return utils.NewErrResourceS(err)
 						}
-						utils.Logger.Warning(
+						utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 							fmt.Sprintf("<%s> error: <%s> processing event %+v for RunID <%s>  with ResourceS.",
-								utils.SessionS, err.Error(), cgrEv, runID))
+								utils.SessionS, err.Error(), cgrEv, runID),
+							engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 						withErrors = true
 					}
 				case resOpt.Has(utils.MetaRelease):
@@ -3325,9 +3357,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 						if blockError {
 							return utils.NewErrResourceS(err)
 						}
-						utils.Logger.Warning(
+						utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 							fmt.Sprintf("<%s> error: <%s> processing event %+v for RunID <%s>  with ResourceS.",
-								utils.SessionS, err.Error(), cgrEv, runID))
+								utils.SessionS, err.Error(), cgrEv, runID),
+							engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 						withErrors = true
 					}
 				}
@@ -3487,9 +3520,10 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 				if blockError {
 					return utils.NewErrCDRS(err)
 				}
-				utils.Logger.Warning(
+				utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 					fmt.Sprintf("<%s> error: <%s> processing event %+v with CDRs.",
-						utils.SessionS, err.Error(), cgrEv))
+						utils.SessionS, err.Error(), cgrEv),
+					engine.MapEvent(cgrEv.Event).LogFields(cgrEv.Tenant))
 				withErrors = true
 			}
 		}
@@ -3624,10 +3658,11 @@ func (sS *SessionS) BiRPCv1ForceDisconnect(clnt rpcclient.ClientConnector,
 		}
 		ss[0].Lock()
 		if errTerm := sS.forceSTerminate(ss[0], 0, nil, nil); errTerm != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf(
 					"<%s> failed force-terminating session with id: <%s>, err: <%s>",
-					utils.SessionS, ss[0].cgrID(), errTerm.Error()))
+					utils.SessionS, ss[0].cgrID(), errTerm.Error()),
+				ss[0].logFields())
 			err = utils.ErrPartiallyExecuted
 		}
 		ss[0].Unlock()
@@ -3708,9 +3743,10 @@ func (sS *SessionS) processCDR(cgrEv *utils.CGREvent, flags []string, rply *stri
 		ev.GetStringIgnoreErrors(utils.OriginID),
 		ev.GetStringIgnoreErrors(utils.OriginHost))
 	if s != nil {
-		utils.Logger.Warning(
+		utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 			fmt.Sprintf("<%s> ProcessCDR called for active session with CGRID: <%s>",
-				utils.SessionS, cgrID))
+				utils.SessionS, cgrID),
+			ev.LogFields(cgrEv.Tenant))
 		s.Lock() // events update session panic
 		defer s.Unlock()
 	} else if sIface, has := engine.Cache.Get(utils.CacheClosedSessions, cgrID); has {
@@ -3742,9 +3778,10 @@ func (sS *SessionS) processCDR(cgrEv *utils.CGREvent, flags []string, rply *stri
 		}
 		if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().CDRsConns, nil, utils.CDRsV1ProcessEvent,
 			argsProc, rply); err != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf("<%s> error <%s> posting CDR with CGRID: <%s>",
-					utils.SessionS, err.Error(), cgrID))
+					utils.SessionS, err.Error(), cgrID),
+				s.logFields())
 			withErrors = true
 		}
 	}
@@ -3993,10 +4030,11 @@ func (sS *SessionS) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector,
 			continue
 		}
 		if errTerm := sS.sendRar(ss[0]); errTerm != nil {
-			utils.Logger.Warning(
+			utils.Logger.LogWithFields(utils.LOGLEVEL_WARNING,
 				fmt.Sprintf(
 					"<%s> failed sending RAR for session with id: <%s>, err: <%s>",
-					utils.SessionS, ss[0].cgrID(), errTerm.Error()))
+					utils.SessionS, ss[0].cgrID(), errTerm.Error()),
+				ss[0].logFields())
 			err = utils.ErrPartiallyExecuted
 		}
 	}
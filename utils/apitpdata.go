@@ -948,6 +948,7 @@ type ArgsComputeFilterIndexIDs struct {
 	RateProfileIDs    []string
 	AccountProfileIDs []string
 	ActionProfileIDs  []string
+	TaxProfileIDs     []string
 }
 
 type ArgsComputeFilterIndexes struct {
@@ -963,6 +964,7 @@ type ArgsComputeFilterIndexes struct {
 	RateS       bool
 	AccountS    bool
 	ActionS     bool
+	TaxS        bool
 }
 
 // AsActivationTime converts TPActivationInterval into ActivationInterval
@@ -993,7 +995,7 @@ type AttrDisconnectSession struct {
 	Reason     string
 }
 
-//MetricWithFilters is used in TPStatProfile
+// MetricWithFilters is used in TPStatProfile
 type MetricWithFilters struct {
 	FilterIDs []string
 	MetricID  string
@@ -1054,6 +1056,7 @@ type TPRoute struct {
 	FilterIDs       []string
 	AccountIDs      []string
 	RatingPlanIDs   []string // used when computing price
+	RateProfileIDs  []string // used when computing the cost with RateS
 	ResourceIDs     []string // queried in some strategies
 	StatIDs         []string // queried in some strategies
 	Weight          float64
@@ -1105,6 +1108,16 @@ type TPChargerProfile struct {
 	RunID              string
 	AttributeIDs       []string
 	Weight             float64
+	Aggregations       []*TPChargerAggregation
+}
+
+// TPChargerAggregation is used in TPChargerProfile
+type TPChargerAggregation struct {
+	Path         string
+	FilterIDs    []string
+	Type         string
+	RunIDs       []string
+	ThresholdIDs []string
 }
 
 type TPTntID struct {
@@ -1282,6 +1295,20 @@ func AppendToSMCostFilter(smcFilter *SMCostFilter, fieldType, fieldName string,
 	return smcFilter, err
 }
 
+// InvoicesFilter selects the invoices stored in StorDB
+type InvoicesFilter struct {
+	Tenant      string
+	IDs         []string
+	Accounts    []string
+	PeriodStart *time.Time // invoices with the period starting at or after this time
+	PeriodEnd   *time.Time // invoices with the period ending at or before this time
+}
+
+type InvoicesFilterWithOpts struct {
+	*InvoicesFilter
+	Opts map[string]interface{}
+}
+
 type RPCCDRsFilterWithOpts struct {
 	*RPCCDRsFilter
 	Opts   map[string]interface{}
@@ -1470,6 +1497,8 @@ type TPRateProfile struct {
 	MinCost            float64
 	MaxCost            float64
 	MaxCostStrategy    string
+	Currency           string
+	VolumePeriod       string
 	Rates              map[string]*TPRate
 }
 
@@ -1527,6 +1556,62 @@ func (args *ArgsCostForEvent) Usage() (usage time.Duration, err error) {
 	return
 }
 
+// VolumeAccount returns the account owning the volume counter
+func (args *ArgsCostForEvent) VolumeAccount() string {
+	return IfaceAsString(args.Opts[OptsRatesVolumeAccount])
+}
+
+// VolumeUsage returns the usage already rated within the volume period, if given by the caller
+func (args *ArgsCostForEvent) VolumeUsage() (usage time.Duration, has bool, err error) {
+	var uIface interface{}
+	if uIface, has = args.Opts[OptsRatesVolumeUsage]; !has {
+		return
+	}
+	usage, err = IfaceAsDuration(uIface)
+	return
+}
+
+// VolumeUpdate returns true if the usage of the event should be added to the volume counter
+func (args *ArgsCostForEvent) VolumeUpdate() (upd bool, err error) {
+	if uIface, has := args.Opts[OptsRatesVolumeUpdate]; has {
+		return IfaceAsBool(uIface)
+	}
+	return
+}
+
+// ArgsCostForEvents is used to rate a batch of events within one call
+type ArgsCostForEvents struct {
+	Tenant         string
+	RateProfileIDs []string
+	Events         []*CGREvent
+	Opts           map[string]interface{} // defaults for the options missing from the events
+}
+
+// AsArgsCostForEvent returns the arguments used to rate the event on index i of the batch
+func (args *ArgsCostForEvents) AsArgsCostForEvent(i int) (cfeArgs *ArgsCostForEvent, err error) {
+	ev := args.Events[i]
+	if ev == nil {
+		return nil, NewErrMandatoryIeMissing(Event)
+	}
+	opts := make(map[string]interface{}, len(args.Opts)+len(ev.Opts))
+	for k, v := range args.Opts {
+		opts[k] = v
+	}
+	for k, v := range ev.Opts {
+		opts[k] = v
+	}
+	return &ArgsCostForEvent{
+		RateProfileIDs: args.RateProfileIDs,
+		CGREvent: &CGREvent{
+			Tenant: FirstNonEmpty(ev.Tenant, args.Tenant),
+			ID:     ev.ID,
+			Time:   ev.Time,
+			Event:  ev.Event,
+			Opts:   opts,
+		},
+	}, nil
+}
+
 type TPActionProfile struct {
 	TPid               string
 	Tenant             string
@@ -1661,6 +1746,108 @@ func (unitFactor *TPBalanceUnitFactor) AsString() (s string) {
 	return
 }
 
+// TPExchangeRateProfile is the TP version of the ExchangeRateProfile
+type TPExchangeRateProfile struct {
+	TPid   string
+	Tenant string
+	ID     string
+	Rates  []*TPExchangeRate
+}
+
+type TPExchangeRate struct {
+	FromCurrency   string
+	ToCurrency     string
+	ActivationTime string
+	Rate           float64
+}
+
+// ArgsGetRateForPrefix is used by RateSv1.GetRateForPrefix
+type ArgsGetRateForPrefix struct {
+	Tenant      string
+	RateDeckID  string
+	Destination string
+	Time        *time.Time // defaults to the current time
+	Opts        map[string]interface{}
+}
+
+// ArgsRateVolumeCounter identifies the volume counter of an account on a RateProfile
+type ArgsRateVolumeCounter struct {
+	Tenant        string
+	RateProfileID string
+	AccountID     string
+	Opts          map[string]interface{}
+}
+
+// UpdatePolicyCountersArgs selects the Sy subscriptions of the DiameterAgent to be re-evaluated
+type UpdatePolicyCountersArgs struct {
+	Tenant     string
+	SessionIDs []string // empty for all subscriptions
+	Opts       map[string]interface{}
+}
+
+// ArgsGetProfileHits is used by APIerSv1.GetProfileHits
+type ArgsGetProfileHits struct {
+	Tenant string
+	Type   string // profile type as cache partition, ie: *attribute_profiles
+	ID     string
+}
+
+// ArgsGetStaleProfiles is used by APIerSv1.GetStaleProfiles
+type ArgsGetStaleProfiles struct {
+	Tenant string
+	Type   string    // profile type as cache partition, ie: *attribute_profiles
+	Since  time.Time // profiles not matched after this time are considered stale
+	Paginator
+}
+
+// TPRateDeck is the TP version of the RateDeck
+type TPRateDeck struct {
+	TPid           string
+	Tenant         string
+	ID             string
+	IncreaseNotice string
+	DecreaseNotice string
+	Rates          []*TPRateDeckRate
+}
+
+type TPRateDeckRate struct {
+	Prefix        string
+	Description   string
+	Rate          float64
+	EffectiveDate string
+	ExpiryDate    string
+}
+
+// TPTaxProfile is the TP version of the TaxProfile
+type TPTaxProfile struct {
+	TPid               string
+	Tenant             string
+	ID                 string
+	FilterIDs          []string
+	ActivationInterval *TPActivationInterval
+	Weights            string
+	Jurisdiction       string
+	TaxType            string
+	Rate               float64
+	Compound           bool
+	ExemptFilterIDs    []string
+}
+
+// TPLookupTable is the TP version of the LookupTable
+type TPLookupTable struct {
+	TPid    string
+	Tenant  string
+	ID      string
+	Mode    string
+	Default string
+	Entries []*TPLookupTableEntry
+}
+
+type TPLookupTableEntry struct {
+	Key   string
+	Value string
+}
+
 // ArgActionSv1ScheduleActions is used in ActionSv1 methods
 type ArgActionSv1ScheduleActions struct {
 	*CGREvent
@@ -43,7 +43,7 @@ var (
 	}
 
 	extraDBPartition = NewStringSet([]string{CacheDispatchers,
-		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses, CacheClosedSessions,
+		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRadiusPackets, CacheRPCResponses, CacheClosedSessions,
 		CacheCDRIDs, CacheRPCConnections, CacheUCH, CacheSTIR, CacheEventCharges, MetaAPIBan,
 		CacheCapsEvents, CacheVersions, CacheReplicationHosts})
 
@@ -56,14 +56,18 @@ var (
 		CacheAttributeFilterIndexes, CacheChargerFilterIndexes, CacheDispatcherFilterIndexes, CacheLoadIDs,
 		CacheRatingProfilesTmp, CacheRateProfiles, CacheRateProfilesFilterIndexes, CacheRateFilterIndexes,
 		CacheActionProfilesFilterIndexes, CacheAccountProfilesFilterIndexes, CacheReverseFilterIndexes,
-		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts})
+		CacheActionPlans, CacheAccountActionPlans, CacheAccountProfiles, CacheAccounts, CacheExchangeRateProfiles,
+		CacheRateVolumeCounters, CacheRateDecks, CacheRateProfileVersions, CacheTaxProfiles,
+		CacheTaxProfilesFilterIndexes, CacheLookupTables, CacheProfileHits, CacheLoadVersions,
+		CacheActiveLoadVersions})
 
 	storDBPartition = NewStringSet([]string{CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
 		CacheTBLTPActionPlans, CacheTBLTPActionTriggers, CacheTBLTPAccountActions, CacheTBLTPResources,
 		CacheTBLTPStats, CacheTBLTPThresholds, CacheTBLTPFilters, CacheSessionCostsTBL, CacheCDRsTBL,
 		CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
-		CacheTBLTPDispatcherHosts, CacheTBLTPRateProfiles, CacheTBLTPActionProfiles, CacheTBLTPAccountProfiles})
+		CacheTBLTPDispatcherHosts, CacheTBLTPRateProfiles, CacheTBLTPActionProfiles, CacheTBLTPAccountProfiles,
+		CacheInvoicesTBL})
 
 	// CachePartitions enables creation of cache partitions
 	CachePartitions = Join(extraDBPartition, dataDBPartition, storDBPartition)
@@ -94,6 +98,10 @@ var (
 		CacheRateProfiles:                 RateProfilePrefix,
 		CacheActionProfiles:               ActionProfilePrefix,
 		CacheAccountProfiles:              AccountProfilePrefix,
+		CacheExchangeRateProfiles:         ExchangeRateProfilePrefix,
+		CacheRateDecks:                    RateDeckPrefix,
+		CacheTaxProfiles:                  TaxProfilePrefix,
+		CacheLookupTables:                 LookupTablePrefix,
 		CacheResourceFilterIndexes:        ResourceFilterIndexes,
 		CacheStatFilterIndexes:            StatFilterIndexes,
 		CacheThresholdFilterIndexes:       ThresholdFilterIndexes,
@@ -104,6 +112,7 @@ var (
 		CacheRateProfilesFilterIndexes:    RateProfilesFilterIndexPrfx,
 		CacheActionProfilesFilterIndexes:  ActionProfilesFilterIndexPrfx,
 		CacheAccountProfilesFilterIndexes: AccountProfileFilterIndexPrfx,
+		CacheTaxProfilesFilterIndexes:     TaxProfilesFilterIndexPrfx,
 
 		CacheLoadIDs:              LoadIDPrefix,
 		CacheAccounts:             AccountPrefix,
@@ -124,6 +133,7 @@ var (
 		CacheRateFilterIndexes:            RatePrefix,
 		CacheActionProfilesFilterIndexes:  ActionProfilePrefix,
 		CacheAccountProfilesFilterIndexes: AccountProfilePrefix,
+		CacheTaxProfilesFilterIndexes:     TaxProfilePrefix,
 		CacheReverseFilterIndexes:         FilterPrefix,
 	}
 
@@ -137,6 +147,7 @@ var (
 		CacheDispatcherProfiles: CacheDispatcherFilterIndexes,
 		CacheRateProfiles:       CacheRateProfilesFilterIndexes,
 		CacheActionProfiles:     CacheActionProfilesFilterIndexes,
+		CacheTaxProfiles:        CacheTaxProfilesFilterIndexes,
 		CacheFilters:            CacheReverseFilterIndexes,
 		// CacheRates:              CacheRateFilterIndexes,
 	}
@@ -173,33 +184,38 @@ var (
 		TBLTPRateProfiles:     CacheTBLTPRateProfiles,
 		TBLTPActionProfiles:   CacheTBLTPActionProfiles,
 		TBLTPAccountProfiles:  CacheTBLTPAccountProfiles,
+		InvoicesTBL:           CacheInvoicesTBL,
 	}
 	// ProtectedSFlds are the fields that sessions should not alter
 	ProtectedSFlds   = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage})
 	ArgCacheToPrefix = map[string]string{
-		DestinationIDs:        DestinationPrefix,
-		ReverseDestinationIDs: ReverseDestinationPrefix,
-		RatingPlanIDs:         RatingPlanPrefix,
-		RatingProfileIDs:      RatingProfilePrefix,
-		ActionIDs:             ActionPrefix,
-		ActionPlanIDs:         ActionPlanPrefix,
-		AccountActionPlanIDs:  AccountActionPlansPrefix,
-		ActionTriggerIDs:      ActionTriggerPrefix,
-		SharedGroupIDs:        SharedGroupPrefix,
-		ResourceProfileIDs:    ResourceProfilesPrefix,
-		ResourceIDs:           ResourcesPrefix,
-		StatsQueueIDs:         StatQueuePrefix,
-		StatsQueueProfileIDs:  StatQueueProfilePrefix,
-		ThresholdIDs:          ThresholdPrefix,
-		ThresholdProfileIDs:   ThresholdProfilePrefix,
-		FilterIDs:             FilterPrefix,
-		RouteProfileIDs:       RouteProfilePrefix,
-		AttributeProfileIDs:   AttributeProfilePrefix,
-		ChargerProfileIDs:     ChargerProfilePrefix,
-		DispatcherProfileIDs:  DispatcherProfilePrefix,
-		DispatcherHostIDs:     DispatcherHostPrefix,
-		RateProfileIDs:        RateProfilePrefix,
-		ActionProfileIDs:      ActionProfilePrefix,
+		DestinationIDs:         DestinationPrefix,
+		ReverseDestinationIDs:  ReverseDestinationPrefix,
+		RatingPlanIDs:          RatingPlanPrefix,
+		RatingProfileIDs:       RatingProfilePrefix,
+		ActionIDs:              ActionPrefix,
+		ActionPlanIDs:          ActionPlanPrefix,
+		AccountActionPlanIDs:   AccountActionPlansPrefix,
+		ActionTriggerIDs:       ActionTriggerPrefix,
+		SharedGroupIDs:         SharedGroupPrefix,
+		ResourceProfileIDs:     ResourceProfilesPrefix,
+		ResourceIDs:            ResourcesPrefix,
+		StatsQueueIDs:          StatQueuePrefix,
+		StatsQueueProfileIDs:   StatQueueProfilePrefix,
+		ThresholdIDs:           ThresholdPrefix,
+		ThresholdProfileIDs:    ThresholdProfilePrefix,
+		FilterIDs:              FilterPrefix,
+		RouteProfileIDs:        RouteProfilePrefix,
+		AttributeProfileIDs:    AttributeProfilePrefix,
+		ChargerProfileIDs:      ChargerProfilePrefix,
+		DispatcherProfileIDs:   DispatcherProfilePrefix,
+		DispatcherHostIDs:      DispatcherHostPrefix,
+		RateProfileIDs:         RateProfilePrefix,
+		ActionProfileIDs:       ActionProfilePrefix,
+		ExchangeRateProfileIDs: ExchangeRateProfilePrefix,
+		RateDeckIDs:            RateDeckPrefix,
+		TaxProfileIDs:          TaxProfilePrefix,
+		LookupTableIDs:         LookupTablePrefix,
 
 		TimingIDs:                     TimingsPrefix,
 		AttributeFilterIndexIDs:       AttributeFilterIndexes,
@@ -213,33 +229,38 @@ var (
 		RateFilterIndexIDs:            RateFilterIndexPrfx,
 		ActionProfilesFilterIndexIDs:  ActionProfilesFilterIndexPrfx,
 		AccountProfilesFilterIndexIDs: AccountProfileFilterIndexPrfx,
+		TaxProfilesFilterIndexIDs:     TaxProfilesFilterIndexPrfx,
 		FilterIndexIDs:                FilterIndexPrfx,
 	}
 	CacheInstanceToArg map[string]string
 	ArgCacheToInstance = map[string]string{
-		DestinationIDs:        CacheDestinations,
-		ReverseDestinationIDs: CacheReverseDestinations,
-		RatingPlanIDs:         CacheRatingPlans,
-		RatingProfileIDs:      CacheRatingProfiles,
-		ActionIDs:             CacheActions,
-		ActionPlanIDs:         CacheActionPlans,
-		AccountActionPlanIDs:  CacheAccountActionPlans,
-		ActionTriggerIDs:      CacheActionTriggers,
-		SharedGroupIDs:        CacheSharedGroups,
-		ResourceProfileIDs:    CacheResourceProfiles,
-		ResourceIDs:           CacheResources,
-		StatsQueueIDs:         CacheStatQueues,
-		StatsQueueProfileIDs:  CacheStatQueueProfiles,
-		ThresholdIDs:          CacheThresholds,
-		ThresholdProfileIDs:   CacheThresholdProfiles,
-		FilterIDs:             CacheFilters,
-		RouteProfileIDs:       CacheRouteProfiles,
-		AttributeProfileIDs:   CacheAttributeProfiles,
-		ChargerProfileIDs:     CacheChargerProfiles,
-		DispatcherProfileIDs:  CacheDispatcherProfiles,
-		DispatcherHostIDs:     CacheDispatcherHosts,
-		RateProfileIDs:        CacheRateProfiles,
-		ActionProfileIDs:      CacheActionProfiles,
+		DestinationIDs:         CacheDestinations,
+		ReverseDestinationIDs:  CacheReverseDestinations,
+		RatingPlanIDs:          CacheRatingPlans,
+		RatingProfileIDs:       CacheRatingProfiles,
+		ActionIDs:              CacheActions,
+		ActionPlanIDs:          CacheActionPlans,
+		AccountActionPlanIDs:   CacheAccountActionPlans,
+		ActionTriggerIDs:       CacheActionTriggers,
+		SharedGroupIDs:         CacheSharedGroups,
+		ResourceProfileIDs:     CacheResourceProfiles,
+		ResourceIDs:            CacheResources,
+		StatsQueueIDs:          CacheStatQueues,
+		StatsQueueProfileIDs:   CacheStatQueueProfiles,
+		ThresholdIDs:           CacheThresholds,
+		ThresholdProfileIDs:    CacheThresholdProfiles,
+		FilterIDs:              CacheFilters,
+		RouteProfileIDs:        CacheRouteProfiles,
+		AttributeProfileIDs:    CacheAttributeProfiles,
+		ChargerProfileIDs:      CacheChargerProfiles,
+		DispatcherProfileIDs:   CacheDispatcherProfiles,
+		DispatcherHostIDs:      CacheDispatcherHosts,
+		RateProfileIDs:         CacheRateProfiles,
+		ActionProfileIDs:       CacheActionProfiles,
+		ExchangeRateProfileIDs: CacheExchangeRateProfiles,
+		RateDeckIDs:            CacheRateDecks,
+		TaxProfileIDs:          CacheTaxProfiles,
+		LookupTableIDs:         CacheLookupTables,
 
 		TimingIDs:                     CacheTimings,
 		AttributeFilterIndexIDs:       CacheAttributeFilterIndexes,
@@ -254,6 +275,7 @@ var (
 		FilterIndexIDs:                CacheReverseFilterIndexes,
 		ActionProfilesFilterIndexIDs:  CacheActionProfilesFilterIndexes,
 		AccountProfilesFilterIndexIDs: CacheAccountProfilesFilterIndexes,
+		TaxProfilesFilterIndexIDs:     CacheTaxProfilesFilterIndexes,
 	}
 	ConcurrentReqsLimit    int
 	ConcurrentReqsStrategy string
@@ -280,6 +302,7 @@ const (
 	MetaPseudoPrepaid        = "*pseudoprepaid"
 	MetaRated                = "*rated"
 	MetaNone                 = "*none"
+	MetaAlways               = "*always"
 	MetaNow                  = "*now"
 	MetaRoundingUp           = "*up"
 	MetaRoundingMiddle       = "*middle"
@@ -335,10 +358,14 @@ const (
 
 	CustomValue             = "CustomValue"
 	Value                   = "Value"
+	Key                     = "Key"
+	Mode                    = "Mode"
+	Default                 = "Default"
 	LastUsed                = "LastUsed"
 	PDD                     = "PDD"
 	Route                   = "Route"
 	RunID                   = "RunID"
+	RunIDs                  = "RunIDs"
 	AttributeIDs            = "AttributeIDs"
 	MetaReqRunID            = "*req.RunID"
 	Cost                    = "Cost"
@@ -359,6 +386,7 @@ const (
 	OK                      = "OK"
 	MetaFileXML             = "*file_xml"
 	MetaFileJSON            = "*file_json"
+	MetaFileHTML            = "*file_html"
 	MaskChar                = "*"
 	ConcatenatedKeySep      = ":"
 	UnitTest                = "UNIT_TEST"
@@ -404,10 +432,20 @@ const (
 	RateProfilePrefix         = "rtp_"
 	ActionProfilePrefix       = "acp_"
 	AccountProfilePrefix      = "anp_"
+	ExchangeRateProfilePrefix = "erp_"
+	RateVolumeCounterPrefix   = "rvc_"
+	RateDeckPrefix            = "rdk_"
+	RateProfileVersionsPrefix = "rpv_"
+	TaxProfilePrefix          = "txp_"
+	LookupTablePrefix         = "lkt_"
+	ProfileHitsPrefix         = "phs_"
+	LoadVersionPrefix         = "ldv_"
+	ActiveLoadVersionPrefix   = "alv_"
 	DispatcherHostPrefix      = "dph_"
 	ThresholdProfilePrefix    = "thp_"
 	StatQueuePrefix           = "stq_"
 	LoadIDPrefix              = "lid_"
+	ConfigPrefix              = "cfg_"
 	LoadInstKey               = "load_history"
 	CreateCDRsTablesSQL       = "create_cdrs_tables.sql"
 	CreateTariffPlanTablesSQL = "create_tariffplan_tables.sql"
@@ -499,6 +537,7 @@ const (
 	MetaReplicator           = "*replicator"
 	MetaRerate               = "*rerate"
 	MetaRefund               = "*refund"
+	MetaTaxes                = "*taxes"
 	MetaStats                = "*stats"
 	MetaResponder            = "*responder"
 	MetaCore                 = "*core"
@@ -517,6 +556,7 @@ const (
 	MetaGuardian             = "*guardians"
 	MetaEEs                  = "*ees"
 	MetaRateS                = "*rates"
+	MetaDiameterAgent        = "*diameter_agent"
 	MetaContinue             = "*continue"
 	MetaUp                   = "*up"
 	Migrator                 = "migrator"
@@ -528,6 +568,7 @@ const (
 	GOBSuffix                = ".gob"
 	XMLSuffix                = ".xml"
 	CSVSuffix                = ".csv"
+	HTMLSuffix               = ".html"
 	FWVSuffix                = ".fwv"
 	ContentJSON              = "json"
 	ContentForm              = "form"
@@ -564,6 +605,10 @@ const (
 	RateProfiles             = "RateProfiles"
 	ActionProfiles           = "ActionProfiles"
 	AccountProfilesString    = "AccountProfiles"
+	ExchangeRateProfiles     = "ExchangeRateProfiles"
+	RateDecks                = "RateDecks"
+	TaxProfiles              = "TaxProfiles"
+	LookupTables             = "LookupTables"
 	MetaEveryMinute          = "*every_minute"
 	MetaHourly               = "*hourly"
 	ID                       = "ID"
@@ -642,11 +687,15 @@ const (
 	MetaStorDB               = "*stordb"
 	MetaDataDB               = "*datadb"
 	MetaWeight               = "*weight"
+	MetaContext              = "*context"
+	MetaLastMatched          = "*last_matched"
 	MetaLC                   = "*lc"
 	MetaHC                   = "*hc"
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaMargin               = "*margin"
+	MetaScore                = "*score"
 	Weight                   = "Weight"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
@@ -668,6 +717,7 @@ const (
 	BalanceField             = "Balance"
 	BalanceSummaries         = "BalanceSummaries"
 	Type                     = "Type"
+	Since                    = "Since"
 	Element                  = "Element"
 	Values                   = "Values"
 	YearsFieldName           = "Years"
@@ -693,6 +743,34 @@ const (
 	RateFixedFee             = "RateFixedFee"
 	RateRecurrentFee         = "RateRecurrentFee"
 	RateBlocker              = "RateBlocker"
+	Currency                 = "Currency"
+	VolumePeriod             = "VolumePeriod"
+	FromCurrency             = "FromCurrency"
+	ToCurrency               = "ToCurrency"
+	ActivationTime           = "ActivationTime"
+	ExchangeRateString       = "ExchangeRate"
+	IncreaseNotice           = "IncreaseNotice"
+	DecreaseNotice           = "DecreaseNotice"
+	Prefix                   = "Prefix"
+	Description              = "Description"
+	RateString               = "Rate"
+	EffectiveDate            = "EffectiveDate"
+	ExpiryDate               = "ExpiryDate"
+	Jurisdiction             = "Jurisdiction"
+	TaxType                  = "TaxType"
+	Compound                 = "Compound"
+	ExemptFilterIDs          = "ExemptFilterIDs"
+	TaxAmount                = "TaxAmount"
+	Taxes                    = "Taxes"
+	InvoiceID                = "InvoiceID"
+	PeriodStart              = "PeriodStart"
+	PeriodEnd                = "PeriodEnd"
+	Subtotal                 = "Subtotal"
+	Total                    = "Total"
+	Lines                    = "Lines"
+	Records                  = "Records"
+	MetaInvoice              = "*invoice"
+	MetaRecurrent            = "*recurrent"
 	TimingID                 = "TimingID"
 	RatesID                  = "RatesID"
 	RatingFiltersID          = "RatingFiltersID"
@@ -718,6 +796,11 @@ const (
 	RouteBlocker             = "RouteBlocker"
 	RouteResourceIDs         = "RouteResourceIDs"
 	RouteFilterIDs           = "RouteFilterIDs"
+	AggregationPath          = "AggregationPath"
+	AggregationFilterIDs     = "AggregationFilterIDs"
+	AggregationType          = "AggregationType"
+	AggregationRunIDs        = "AggregationRunIDs"
+	AggregationThresholdIDs  = "AggregationThresholdIDs"
 	AttributeFilterIDs       = "AttributeFilterIDs"
 	QueueLength              = "QueueLength"
 	TTL                      = "TTL"
@@ -741,6 +824,7 @@ const (
 	MetaRoutesMaxCost        = "*routes_maxcost"
 	MetaMaxCost              = "*maxcost"
 	MetaRoutesIgnoreErrors   = "*routes_ignore_errors"
+	MetaRedirect             = "*redirect"
 	Freeswitch               = "freeswitch"
 	Kamailio                 = "kamailio"
 	Opensips                 = "opensips"
@@ -770,6 +854,7 @@ const (
 	MetaMessage              = "*message"
 	MetaDryRun               = "*dryrun"
 	Event                    = "Event"
+	Events                   = "Events"
 	EmptyString              = ""
 	DynamicDataPrefix        = "~"
 	AttrValueSep             = "="
@@ -804,6 +889,7 @@ const (
 	ResourceUsage         = "ResourceUsage"
 	MetaDuration          = "*duration"
 	MetaLibPhoneNumber    = "*libphonenumber"
+	MetaLookup            = "*lookup"
 	MetaTimeString        = "*time_string"
 	MetaIP2Hex            = "*ip2hex"
 	MetaString2Hex        = "*string2hex"
@@ -816,6 +902,7 @@ const (
 	MetaRemove            = "*remove"
 	MetaRemoveAll         = "*removeall"
 	MetaStore             = "*store"
+	MetaDryRunLoad        = "*dry_run"
 	MetaClear             = "*clear"
 	MetaExport            = "*export"
 	MetaExportID          = "*export_id"
@@ -834,6 +921,12 @@ const (
 	Preference            = "Preference"
 	Flags                 = "Flags"
 	Service               = "Service"
+	Priority              = "Priority"
+	Port                  = "Port"
+	Target                = "Target"
+	Txt                   = "Txt"
+	Ptr                   = "Ptr"
+	ClientSubnet          = "ClientSubnet"
 	ApierV                = "ApierV"
 	MetaApier             = "*apier"
 	MetaAnalyzer          = "*analyzer"
@@ -851,6 +944,8 @@ const (
 	EEs                   = "EEs"
 	Ratio                 = "Ratio"
 	Load                  = "Load"
+	Margin                = "Margin"
+	Score                 = "Score"
 	Slash                 = "/"
 	UUID                  = "UUID"
 	ActionsID             = "ActionsID"
@@ -883,6 +978,7 @@ const (
 	MetaLoaders           = "*loaders"
 	TmpSuffix             = ".tmp"
 	MetaDiamreq           = "*diamreq"
+	MetaRadDAReq          = "*radDAReq"
 	MetaCost              = "*cost"
 	MetaGroup             = "*group"
 	InternalRPCSet        = "InternalRPCSet"
@@ -952,6 +1048,7 @@ const (
 	MetaAbstract          = "*abstract"
 	MetaBalanceLimit      = "*balanceLimit"
 	MetaBalanceUnlimited  = "*balanceUnlimited"
+	MetaBalanceCurrency   = "*balanceCurrency"
 	MetaTemplateID        = "*templateID"
 	MetaCdrLog            = "*cdrLog"
 	MetaCDR               = "*cdr"
@@ -977,30 +1074,34 @@ const (
 
 // Meta Items
 const (
-	MetaAccounts            = "*accounts"
-	MetaAccountActionPlans  = "*account_action_plans"
-	MetaReverseDestinations = "*reverse_destinations"
-	MetaActionPlans         = "*action_plans"
-	MetaActionTriggers      = "*action_triggers"
-	MetaActions             = "*actions"
-	MetaResourceProfile     = "*resource_profiles"
-	MetaStatQueueProfiles   = "*statqueue_profiles"
-	MetaStatQueues          = "*statqueues"
-	MetaThresholdProfiles   = "*threshold_profiles"
-	MetaRouteProfiles       = "*route_profiles"
-	MetaAttributeProfiles   = "*attribute_profiles"
-	MetaIndexes             = "*indexes"
-	MetaDispatcherProfiles  = "*dispatcher_profiles"
-	MetaRateProfiles        = "*rate_profiles"
-	MetaRateProfileRates    = "*rate_profile_rates"
-	MetaChargerProfiles     = "*charger_profiles"
-	MetaSharedGroups        = "*shared_groups"
-	MetaThresholds          = "*thresholds"
-	MetaRoutes              = "*routes"
-	MetaAttributes          = "*attributes"
-	MetaActionProfiles      = "*action_profiles"
-	MetaAccountProfiles     = "*account_profiles"
-	MetaLoadIDs             = "*load_ids"
+	MetaAccounts             = "*accounts"
+	MetaAccountActionPlans   = "*account_action_plans"
+	MetaReverseDestinations  = "*reverse_destinations"
+	MetaActionPlans          = "*action_plans"
+	MetaActionTriggers       = "*action_triggers"
+	MetaActions              = "*actions"
+	MetaResourceProfile      = "*resource_profiles"
+	MetaStatQueueProfiles    = "*statqueue_profiles"
+	MetaStatQueues           = "*statqueues"
+	MetaThresholdProfiles    = "*threshold_profiles"
+	MetaRouteProfiles        = "*route_profiles"
+	MetaAttributeProfiles    = "*attribute_profiles"
+	MetaIndexes              = "*indexes"
+	MetaDispatcherProfiles   = "*dispatcher_profiles"
+	MetaRateProfiles         = "*rate_profiles"
+	MetaRateProfileRates     = "*rate_profile_rates"
+	MetaExchangeRateProfiles = "*exchange_rate_profiles"
+	MetaRateDecks            = "*rate_decks"
+	MetaTaxProfiles          = "*tax_profiles"
+	MetaLookupTables         = "*lookup_tables"
+	MetaChargerProfiles      = "*charger_profiles"
+	MetaSharedGroups         = "*shared_groups"
+	MetaThresholds           = "*thresholds"
+	MetaRoutes               = "*routes"
+	MetaAttributes           = "*attributes"
+	MetaActionProfiles       = "*action_profiles"
+	MetaAccountProfiles      = "*account_profiles"
+	MetaLoadIDs              = "*load_ids"
 )
 
 // MetaMetrics
@@ -1016,6 +1117,8 @@ const (
 	MetaAverage  = "*average"
 	MetaDistinct = "*distinct"
 	MetaRAR      = "*rar"
+	MetaDMR      = "*dmr"
+	MetaCoA      = "*coa"
 )
 
 // Services
@@ -1096,6 +1199,7 @@ const (
 	MetaResetThreshold          = "*reset_threshold"
 	MetaResetStatQueue          = "*reset_stat_queue"
 	MetaRemoteSetAccount        = "*remote_set_account"
+	MetaDiameterPolicyCounters  = "*diameter_policy_counters"
 	ActionID                    = "ActionID"
 	ActionType                  = "ActionType"
 	ActionValue                 = "ActionValue"
@@ -1192,7 +1296,7 @@ const (
 	ArgDispatcherField = "ArgDispatcher"
 )
 
-//Filter types
+// Filter types
 const (
 	MetaNot                = "*not"
 	MetaString             = "*string"
@@ -1563,6 +1667,9 @@ const (
 	ConfigSv1SetConfig         = "ConfigSv1.SetConfig"
 	ConfigSv1GetConfigAsJSON   = "ConfigSv1.GetConfigAsJSON"
 	ConfigSv1SetConfigFromJSON = "ConfigSv1.SetConfigFromJSON"
+	ConfigSv1GetConfigHistory  = "ConfigSv1.GetConfigHistory"
+	ConfigSv1RollbackConfig    = "ConfigSv1.RollbackConfig"
+	ConfigSv1SetDBConfig       = "ConfigSv1.SetDBConfig"
 )
 
 const (
@@ -1572,9 +1679,16 @@ const (
 )
 
 const (
-	RateSv1             = "RateSv1"
-	RateSv1CostForEvent = "RateSv1.CostForEvent"
-	RateSv1Ping         = "RateSv1.Ping"
+	RateSv1                       = "RateSv1"
+	RateSv1CostForEvent           = "RateSv1.CostForEvent"
+	RateSv1CostForEvents          = "RateSv1.CostForEvents"
+	RateSv1CompareRateProfiles    = "RateSv1.CompareRateProfiles"
+	RateSv1GetRateForPrefix       = "RateSv1.GetRateForPrefix"
+	RateSv1GetRateProfileVersions = "RateSv1.GetRateProfileVersions"
+	RateSv1UpdateVolumeCounter    = "RateSv1.UpdateVolumeCounter"
+	RateSv1GetVolumeCounter       = "RateSv1.GetVolumeCounter"
+	RateSv1ResetVolumeCounter     = "RateSv1.ResetVolumeCounter"
+	RateSv1Ping                   = "RateSv1.Ping"
 )
 
 const (
@@ -1590,11 +1704,14 @@ const (
 )
 
 const (
-	CoreS         = "CoreS"
-	CoreSv1       = "CoreSv1"
-	CoreSv1Status = "CoreSv1.Status"
-	CoreSv1Ping   = "CoreSv1.Ping"
-	CoreSv1Sleep  = "CoreSv1.Sleep"
+	CoreS               = "CoreS"
+	CoreSv1             = "CoreSv1"
+	CoreSv1Status       = "CoreSv1.Status"
+	CoreSv1Ping         = "CoreSv1.Ping"
+	CoreSv1Sleep        = "CoreSv1.Sleep"
+	CoreSv1Snapshot     = "CoreSv1.Snapshot"
+	CoreSv1SetLogLevel  = "CoreSv1.SetLogLevel"
+	CoreSv1GetLogLevels = "CoreSv1.GetLogLevels"
 )
 
 // RouteS APIs
@@ -1617,6 +1734,7 @@ const (
 	APIerSv2SetAttributeProfile      = "APIerSv2.SetAttributeProfile"
 	AttributeSv1GetAttributeForEvent = "AttributeSv1.GetAttributeForEvent"
 	AttributeSv1ProcessEvent         = "AttributeSv1.ProcessEvent"
+	AttributeSv1TraceEvent           = "AttributeSv1.TraceEvent"
 	AttributeSv1Ping                 = "AttributeSv1.Ping"
 )
 
@@ -1757,6 +1875,45 @@ const (
 	APIerSv1RemoveRateProfileRates = "APIerSv1.RemoveRateProfileRates"
 )
 
+// ExchangeRateProfile APIs
+const (
+	APIerSv1SetExchangeRateProfile    = "APIerSv1.SetExchangeRateProfile"
+	APIerSv1GetExchangeRateProfile    = "APIerSv1.GetExchangeRateProfile"
+	APIerSv1GetExchangeRateProfileIDs = "APIerSv1.GetExchangeRateProfileIDs"
+	APIerSv1RemoveExchangeRateProfile = "APIerSv1.RemoveExchangeRateProfile"
+)
+
+// RateDeck APIs
+const (
+	APIerSv1SetRateDeck    = "APIerSv1.SetRateDeck"
+	APIerSv1GetRateDeck    = "APIerSv1.GetRateDeck"
+	APIerSv1GetRateDeckIDs = "APIerSv1.GetRateDeckIDs"
+	APIerSv1RemoveRateDeck = "APIerSv1.RemoveRateDeck"
+)
+
+// TaxProfile APIs
+const (
+	APIerSv1SetTaxProfile    = "APIerSv1.SetTaxProfile"
+	APIerSv1GetTaxProfile    = "APIerSv1.GetTaxProfile"
+	APIerSv1GetTaxProfileIDs = "APIerSv1.GetTaxProfileIDs"
+	APIerSv1RemoveTaxProfile = "APIerSv1.RemoveTaxProfile"
+	APIerSv1GetTaxesForEvent = "APIerSv1.GetTaxesForEvent"
+)
+
+// LookupTable APIs
+const (
+	APIerSv1SetLookupTable    = "APIerSv1.SetLookupTable"
+	APIerSv1GetLookupTable    = "APIerSv1.GetLookupTable"
+	APIerSv1GetLookupTableIDs = "APIerSv1.GetLookupTableIDs"
+	APIerSv1RemoveLookupTable = "APIerSv1.RemoveLookupTable"
+)
+
+// ProfileHits APIs
+const (
+	APIerSv1GetProfileHits   = "APIerSv1.GetProfileHits"
+	APIerSv1GetStaleProfiles = "APIerSv1.GetStaleProfiles"
+)
+
 // AnalyzerS APIs
 const (
 	AnalyzerSv1            = "AnalyzerSv1"
@@ -1766,10 +1923,22 @@ const (
 
 // LoaderS APIs
 const (
-	LoaderSv1       = "LoaderSv1"
-	LoaderSv1Load   = "LoaderSv1.Load"
-	LoaderSv1Remove = "LoaderSv1.Remove"
-	LoaderSv1Ping   = "LoaderSv1.Ping"
+	LoaderSv1                = "LoaderSv1"
+	LoaderSv1Load            = "LoaderSv1.Load"
+	LoaderSv1Remove          = "LoaderSv1.Remove"
+	LoaderSv1DryRun          = "LoaderSv1.DryRun"
+	LoaderSv1Rollback        = "LoaderSv1.Rollback"
+	LoaderSv1GetLoadVersions = "LoaderSv1.GetLoadVersions"
+	LoaderSv1Ping            = "LoaderSv1.Ping"
+)
+
+// DiameterAgent APIs
+const (
+	DiameterAgentV1         = "DiameterAgentV1"
+	DiameterAgentV1GetPeers = "DiameterAgentV1.GetPeers"
+	DiameterAgentV1Ping     = "DiameterAgentV1.Ping"
+
+	DiameterAgentV1UpdatePolicyCounters = "DiameterAgentV1.UpdatePolicyCounters"
 )
 
 // CacheS APIs
@@ -1811,6 +1980,9 @@ const (
 	CDRsV1ProcessExternalCDR = "CDRsV1.ProcessExternalCDR"
 	CDRsV1StoreSessionCost   = "CDRsV1.StoreSessionCost"
 	CDRsV1ProcessEvent       = "CDRsV1.ProcessEvent"
+	CDRsV1BillingRun         = "CDRsV1.BillingRun"
+	CDRsV1GetInvoices        = "CDRsV1.GetInvoices"
+	CDRsV1RemoveInvoices     = "CDRsV1.RemoveInvoices"
 	CDRsV1Ping               = "CDRsV1.Ping"
 	CDRsV2                   = "CDRsV2"
 	CDRsV2StoreSessionCost   = "CDRsV2.StoreSessionCost"
@@ -1842,7 +2014,7 @@ const (
 	APIerSv1RemoveActionProfile      = "APIerSv1.RemoveActionProfile"
 )
 
-//cgr_ variables
+// cgr_ variables
 const (
 	CGRAccount         = "cgr_account"
 	CGRRoute           = "cgr_route"
@@ -1859,31 +2031,35 @@ const (
 	CGROpts            = "cgr_opts"
 )
 
-//CSV file name
-const (
-	TimingsCsv            = "Timings.csv"
-	DestinationsCsv       = "Destinations.csv"
-	RatesCsv              = "Rates.csv"
-	DestinationRatesCsv   = "DestinationRates.csv"
-	RatingPlansCsv        = "RatingPlans.csv"
-	RatingProfilesCsv     = "RatingProfiles.csv"
-	SharedGroupsCsv       = "SharedGroups.csv"
-	ActionsCsv            = "Actions.csv"
-	ActionPlansCsv        = "ActionPlans.csv"
-	ActionTriggersCsv     = "ActionTriggers.csv"
-	AccountActionsCsv     = "AccountActions.csv"
-	ResourcesCsv          = "Resources.csv"
-	StatsCsv              = "Stats.csv"
-	ThresholdsCsv         = "Thresholds.csv"
-	FiltersCsv            = "Filters.csv"
-	RoutesCsv             = "Routes.csv"
-	AttributesCsv         = "Attributes.csv"
-	ChargersCsv           = "Chargers.csv"
-	DispatcherProfilesCsv = "DispatcherProfiles.csv"
-	DispatcherHostsCsv    = "DispatcherHosts.csv"
-	RateProfilesCsv       = "RateProfiles.csv"
-	ActionProfilesCsv     = "ActionProfiles.csv"
-	AccountProfilesCsv    = "AccountProfiles.csv"
+// CSV file name
+const (
+	TimingsCsv              = "Timings.csv"
+	DestinationsCsv         = "Destinations.csv"
+	RatesCsv                = "Rates.csv"
+	DestinationRatesCsv     = "DestinationRates.csv"
+	RatingPlansCsv          = "RatingPlans.csv"
+	RatingProfilesCsv       = "RatingProfiles.csv"
+	SharedGroupsCsv         = "SharedGroups.csv"
+	ActionsCsv              = "Actions.csv"
+	ActionPlansCsv          = "ActionPlans.csv"
+	ActionTriggersCsv       = "ActionTriggers.csv"
+	AccountActionsCsv       = "AccountActions.csv"
+	ResourcesCsv            = "Resources.csv"
+	StatsCsv                = "Stats.csv"
+	ThresholdsCsv           = "Thresholds.csv"
+	FiltersCsv              = "Filters.csv"
+	RoutesCsv               = "Routes.csv"
+	AttributesCsv           = "Attributes.csv"
+	ChargersCsv             = "Chargers.csv"
+	DispatcherProfilesCsv   = "DispatcherProfiles.csv"
+	DispatcherHostsCsv      = "DispatcherHosts.csv"
+	RateProfilesCsv         = "RateProfiles.csv"
+	ActionProfilesCsv       = "ActionProfiles.csv"
+	AccountProfilesCsv      = "AccountProfiles.csv"
+	ExchangeRateProfilesCsv = "ExchangeRateProfiles.csv"
+	RateDecksCsv            = "RateDecks.csv"
+	TaxProfilesCsv          = "TaxProfiles.csv"
+	LookupTablesCsv         = "LookupTables.csv"
 )
 
 // Table Name
@@ -1915,6 +2091,7 @@ const (
 	TBLTPRateProfiles     = "tp_rate_profiles"
 	TBLTPActionProfiles   = "tp_action_profiles"
 	TBLTPAccountProfiles  = "tp_account_profiles"
+	InvoicesTBL           = "invoices"
 )
 
 // Cache Name
@@ -1948,6 +2125,15 @@ const (
 	CacheRateProfiles                 = "*rate_profiles"
 	CacheActionProfiles               = "*action_profiles"
 	CacheAccountProfiles              = "*account_profiles"
+	CacheExchangeRateProfiles         = "*exchange_rate_profiles"
+	CacheRateVolumeCounters           = "*rate_volume_counters"
+	CacheRateDecks                    = "*rate_decks"
+	CacheRateProfileVersions          = "*rate_profile_versions"
+	CacheTaxProfiles                  = "*tax_profiles"
+	CacheLookupTables                 = "*lookup_tables"
+	CacheProfileHits                  = "*profile_hits"
+	CacheLoadVersions                 = "*load_versions"
+	CacheActiveLoadVersions           = "*active_load_versions"
 	CacheResourceFilterIndexes        = "*resource_filter_indexes"
 	CacheStatFilterIndexes            = "*stat_filter_indexes"
 	CacheThresholdFilterIndexes       = "*threshold_filter_indexes"
@@ -1956,11 +2142,13 @@ const (
 	CacheChargerFilterIndexes         = "*charger_filter_indexes"
 	CacheDispatcherFilterIndexes      = "*dispatcher_filter_indexes"
 	CacheDiameterMessages             = "*diameter_messages"
+	CacheRadiusPackets                = "*radius_packets"
 	CacheRPCResponses                 = "*rpc_responses"
 	CacheClosedSessions               = "*closed_sessions"
 	CacheRateProfilesFilterIndexes    = "*rate_profile_filter_indexes"
 	CacheActionProfilesFilterIndexes  = "*action_profile_filter_indexes"
 	CacheAccountProfilesFilterIndexes = "*account_profile_filter_indexes"
+	CacheTaxProfilesFilterIndexes     = "*tax_profile_filter_indexes"
 	CacheRateFilterIndexes            = "*rate_filter_indexes"
 	MetaPrecaching                    = "*precaching"
 	MetaReady                         = "*ready"
@@ -2003,6 +2191,7 @@ const (
 	CacheTBLTPRateProfiles     = "*tp_rate_profiles"
 	CacheTBLTPActionProfiles   = "*tp_action_profiles"
 	CacheTBLTPAccountProfiles  = "*tp_account_profiles"
+	CacheInvoicesTBL           = "*invoices"
 )
 
 // Prefix for indexing
@@ -2019,6 +2208,7 @@ const (
 	RateFilterIndexPrfx           = "rri_"
 	ActionProfilesFilterIndexPrfx = "aci_"
 	AccountProfileFilterIndexPrfx = "ani_"
+	TaxProfilesFilterIndexPrfx    = "txi_"
 	FilterIndexPrfx               = "fii_"
 )
 
@@ -2053,30 +2243,35 @@ var (
 
 // GeneralCfg
 const (
-	NodeIDCfg           = "node_id"
-	LoggerCfg           = "logger"
-	LogLevelCfg         = "log_level"
-	RoundingDecimalsCfg = "rounding_decimals"
-	DBDataEncodingCfg   = "dbdata_encoding"
-	TpExportPathCfg     = "tpexport_dir"
-	PosterAttemptsCfg   = "poster_attempts"
-	FailedPostsDirCfg   = "failed_posts_dir"
-	FailedPostsTTLCfg   = "failed_posts_ttl"
-	DefaultReqTypeCfg   = "default_request_type"
-	DefaultCategoryCfg  = "default_category"
-	DefaultTenantCfg    = "default_tenant"
-	DefaultTimezoneCfg  = "default_timezone"
-	DefaultCachingCfg   = "default_caching"
-	ConnectAttemptsCfg  = "connect_attempts"
-	ReconnectsCfg       = "reconnects"
-	ConnectTimeoutCfg   = "connect_timeout"
-	ReplyTimeoutCfg     = "reply_timeout"
-	LockingTimeoutCfg   = "locking_timeout"
-	DigestSeparatorCfg  = "digest_separator"
-	DigestEqualCfg      = "digest_equal"
-	RSRSepCfg           = "rsr_separator"
-	MaxParallelConnsCfg = "max_parallel_conns"
-	EEsConnsCfg         = "ees_conns"
+	NodeIDCfg            = "node_id"
+	LoggerCfg            = "logger"
+	LogLevelCfg          = "log_level"
+	LogLevelsCfg         = "log_levels"
+	LogFileCfg           = "log_file"
+	TracesExporterCfg    = "traces_exporter"
+	TracesEndpointCfg    = "traces_endpoint"
+	RoundingDecimalsCfg  = "rounding_decimals"
+	DBDataEncodingCfg    = "dbdata_encoding"
+	TpExportPathCfg      = "tpexport_dir"
+	PosterAttemptsCfg    = "poster_attempts"
+	FailedPostsDirCfg    = "failed_posts_dir"
+	FailedPostsTTLCfg    = "failed_posts_ttl"
+	DefaultReqTypeCfg    = "default_request_type"
+	DefaultCategoryCfg   = "default_category"
+	DefaultTenantCfg     = "default_tenant"
+	DefaultTimezoneCfg   = "default_timezone"
+	DefaultCachingCfg    = "default_caching"
+	ConnectAttemptsCfg   = "connect_attempts"
+	ReconnectsCfg        = "reconnects"
+	ConnectTimeoutCfg    = "connect_timeout"
+	ReplyTimeoutCfg      = "reply_timeout"
+	LockingTimeoutCfg    = "locking_timeout"
+	HitsStoreIntervalCfg = "hits_store_interval"
+	DigestSeparatorCfg   = "digest_separator"
+	DigestEqualCfg       = "digest_equal"
+	RSRSepCfg            = "rsr_separator"
+	MaxParallelConnsCfg  = "max_parallel_conns"
+	EEsConnsCfg          = "ees_conns"
 )
 
 // StorDbCfg
@@ -2098,23 +2293,26 @@ const (
 
 // DataDbCfg
 const (
-	DataDbTypeCfg              = "db_type"
-	DataDbHostCfg              = "db_host"
-	DataDbPortCfg              = "db_port"
-	DataDbNameCfg              = "db_name"
-	DataDbUserCfg              = "db_user"
-	DataDbPassCfg              = "db_password"
-	RedisSentinelNameCfg       = "redis_sentinel"
-	RedisClusterCfg            = "redis_cluster"
-	RedisClusterSyncCfg        = "redis_cluster_sync"
-	RedisClusterOnDownDelayCfg = "redis_cluster_ondown_delay"
-	RedisTLS                   = "redis_tls"
-	RedisClientCertificate     = "redis_client_certificate"
-	RedisClientKey             = "redis_client_key"
-	RedisCACertificate         = "redis_ca_certificate"
-	ReplicationFilteredCfg     = "replication_filtered"
-	ReplicationCache           = "replication_cache"
-	RemoteConnIDCfg            = "remote_conn_id"
+	DataDbTypeCfg                 = "db_type"
+	DataDbHostCfg                 = "db_host"
+	DataDbPortCfg                 = "db_port"
+	DataDbNameCfg                 = "db_name"
+	DataDbUserCfg                 = "db_user"
+	DataDbPassCfg                 = "db_password"
+	RedisSentinelNameCfg          = "redis_sentinel"
+	RedisClusterCfg               = "redis_cluster"
+	RedisClusterSyncCfg           = "redis_cluster_sync"
+	RedisClusterOnDownDelayCfg    = "redis_cluster_ondown_delay"
+	RedisTLS                      = "redis_tls"
+	RedisClientCertificate        = "redis_client_certificate"
+	RedisClientKey                = "redis_client_key"
+	RedisCACertificate            = "redis_ca_certificate"
+	ReplicationFilteredCfg        = "replication_filtered"
+	ReplicationCache              = "replication_cache"
+	RemoteConnIDCfg               = "remote_conn_id"
+	InternalDBPathCfg             = "internal_db_path"
+	InternalDBSnapshotIntervalCfg = "internal_db_snapshot_interval"
+	InternalDBFsyncCfg            = "internal_db_fsync"
 )
 
 // ItemOpt
@@ -2198,8 +2396,9 @@ const (
 
 // SchedulerCfg
 const (
-	CDRsConnsCfg = "cdrs_conns"
-	FiltersCfg   = "filters"
+	CDRsConnsCfg          = "cdrs_conns"
+	FiltersCfg            = "filters"
+	DiameterAgentConnsCfg = "diameter_agent_conns"
 )
 
 // CdrsCfg
@@ -2282,20 +2481,35 @@ const (
 	AsteriskConnsCfg = "asterisk_conns"
 
 	// DiameterAgentCfg
-	ListenNetCfg          = "listen_net"
-	ConcurrentRequestsCfg = "concurrent_requests"
-	ListenCfg             = "listen"
-	DictionariesPathCfg   = "dictionaries_path"
-	OriginHostCfg         = "origin_host"
-	OriginRealmCfg        = "origin_realm"
-	VendorIDCfg           = "vendor_id"
-	ProductNameCfg        = "product_name"
-	SyncedConnReqsCfg     = "synced_conn_requests"
-	ASRTemplateCfg        = "asr_template"
-	RARTemplateCfg        = "rar_template"
-	ForcedDisconnectCfg   = "forced_disconnect"
-	TemplatesCfg          = "templates"
-	RequestProcessorsCfg  = "request_processors"
+	ListenNetCfg              = "listen_net"
+	ConcurrentRequestsCfg     = "concurrent_requests"
+	ListenCfg                 = "listen"
+	DictionariesPathCfg       = "dictionaries_path"
+	OriginHostCfg             = "origin_host"
+	OriginRealmCfg            = "origin_realm"
+	VendorIDCfg               = "vendor_id"
+	ProductNameCfg            = "product_name"
+	SyncedConnReqsCfg         = "synced_conn_requests"
+	ASRTemplateCfg            = "asr_template"
+	RARTemplateCfg            = "rar_template"
+	ForcedDisconnectCfg       = "forced_disconnect"
+	TemplatesCfg              = "templates"
+	RequestProcessorsCfg      = "request_processors"
+	WatchdogIntervalCfg       = "watchdog_interval"
+	ReconnectIntervalCfg      = "reconnect_interval"
+	MaxReconnectIntervalCfg   = "max_reconnect_interval"
+	PeersCfg                  = "peers"
+	PolicyCountersIntervalCfg = "policy_counters_interval"
+	PolicyCountersCfg         = "policy_counters"
+
+	// DiameterPeerCfg
+	NetworkCfg            = "network"
+	AuthApplicationIDsCfg = "auth_application_ids"
+
+	// DiameterPolicyCounterCfg
+	DefaultStatusCfg = "default_status"
+	StatusesCfg      = "statuses"
+	StatusCfg        = "status"
 
 	// RequestProcessor
 	RequestFieldsCfg = "request_fields"
@@ -2306,6 +2520,9 @@ const (
 	ListenAcctCfg         = "listen_acct"
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
+	ClientDAAddressesCfg  = "client_da_addresses"
+	DMRTemplateCfg        = "dmr_template"
+	CoATemplateCfg        = "coa_template"
 
 	// AttributeSCfg
 	IndexedSelectsCfg = "indexed_selects"
@@ -2331,11 +2548,13 @@ const (
 	AttributeIDsCfg      = "attribute_ids"
 
 	//LoaderSCfg
-	DryRunCfg       = "dry_run"
-	LockFileNameCfg = "lock_filename"
-	TpInDirCfg      = "tp_in_dir"
-	TpOutDirCfg     = "tp_out_dir"
-	DataCfg         = "data"
+	DryRunCfg        = "dry_run"
+	LockFileNameCfg  = "lock_filename"
+	AtomicCfg        = "atomic"
+	VersionsLimitCfg = "versions_limit"
+	TpInDirCfg       = "tp_in_dir"
+	TpOutDirCfg      = "tp_out_dir"
+	DataCfg          = "data"
 
 	DefaultRatioCfg           = "default_ratio"
 	ReadersCfg                = "readers"
@@ -2356,6 +2575,7 @@ const (
 	RatePrefixIndexedFieldsCfg = "rate_prefix_indexed_fields"
 	RateSuffixIndexedFieldsCfg = "rate_suffix_indexed_fields"
 	Verbosity                  = "verbosity"
+	ProfileVersionsLimitCfg    = "profile_versions_limit"
 
 	// AnalyzerSCfg
 	CleanupIntervalCfg = "cleanup_interval"
@@ -2369,8 +2589,9 @@ const (
 	ShutdownTimeoutCfg   = "shutdown_timeout"
 
 	// AccountSCfg
-	MaxIterations = "max_iterations"
-	MaxUsage      = "max_usage"
+	MaxIterations             = "max_iterations"
+	MaxUsage                  = "max_usage"
+	ExchangeRateProfileIDsCfg = "exchange_rate_profile_ids"
 )
 
 // FC Template
@@ -2397,6 +2618,10 @@ const (
 // SureTax
 const (
 	RootDirCfg              = "root_dir"
+	HistoryDirCfg           = "history_dir"
+	HistoryLimitCfg         = "history_limit"
+	LoadFromDataDBCfg       = "load_from_datadb"
+	DataDBSyncIntervalCfg   = "datadb_sync_interval"
 	URLCfg                  = "url"
 	ClientNumberCfg         = "client_number"
 	ValidationKeyCfg        = "validation_key"
@@ -2525,13 +2750,14 @@ var (
 )
 
 // CGROptionsSet the possible cgr options
-var CGROptionsSet = NewStringSet([]string{OptsRatesStartTime, OptsRatesUsage, OptsSessionsTTL,
+var CGROptionsSet = NewStringSet([]string{OptsRatesStartTime, OptsRatesUsage,
+	OptsRatesVolumeAccount, OptsRatesVolumeUsage, OptsRatesVolumeUpdate, OptsSessionsTTL,
 	OptsSessionsTTLMaxDelay, OptsSessionsTTLLastUsed, OptsSessionsTTLLastUsage, OptsSessionsTTLUsage,
 	OptsDebitInterval, OptsStirATest, OptsStirPayloadMaxDuration, OptsStirIdentity,
 	OptsStirOriginatorTn, OptsStirOriginatorURI, OptsStirDestinationTn, OptsStirDestinationURI,
 	OptsStirPublicKeyPath, OptsStirPrivateKeyPath, OptsAPIKey, OptsRouteID, OptsContext,
 	OptsAttributesProcessRuns, OptsRoutesLimit, OptsRoutesOffset, OptsChargeable,
-	RemoteHostOpt, CacheOpt})
+	RemoteHostOpt, CacheOpt, OptsTraceParent, OptsAccountsTaxes})
 
 // EventExporter metrics
 const (
@@ -2557,6 +2783,9 @@ const (
 	OptsRoutesOffset         = "*routes_offset"
 	OptsRatesStartTime       = "*ratesStartTime"
 	OptsRatesUsage           = "*ratesUsage"
+	OptsRatesVolumeAccount   = "*ratesVolumeAccount" // account owning the volume counter
+	OptsRatesVolumeUsage     = "*ratesVolumeUsage"   // usage already rated within the volume period, overwrites the counter
+	OptsRatesVolumeUpdate    = "*ratesVolumeUpdate"  // add the usage of the event to the volume counter
 	OptsSessionsTTL          = "*sessionsTTL"
 	OptsSessionsTTLMaxDelay  = "*sessionsTTLMaxDelay"
 	OptsSessionsTTLLastUsed  = "*sessionsTTLLastUsed"
@@ -2564,6 +2793,7 @@ const (
 	OptsSessionsTTLUsage     = "*sessionsTTLUsage"
 	OptsDebitInterval        = "*sessionsDebitInterval"
 	OptsChargeable           = "*sessionsChargeable"
+	OptsAccountsTaxes        = "*accountsTaxes" // apply the taxes on the concretes charged
 	// STIR
 	OptsStirATest              = "*stirATest"
 	OptsStirPayloadMaxDuration = "*stirPayloadMaxDuration"
@@ -2577,6 +2807,8 @@ const (
 	// DispatcherS
 	OptsAPIKey  = "*apiKey"
 	OptsRouteID = "*routeID"
+	// ConfigS
+	OptsConfigAuthor = "*configAuthor"
 	// EEs
 	OptsEEsVerbose = "*eesVerbose"
 	// EEs Elasticsearch options
@@ -2649,7 +2881,13 @@ const (
 	DispatcherHostIDs             = "DispatcherHostIDs"
 	DispatcherRoutesIDs           = "DispatcherRoutesIDs"
 	RateProfileIDs                = "RateProfileIDs"
+	RateProfileID                 = "RateProfileID"
 	ActionProfileIDs              = "ActionProfileIDs"
+	ExchangeRateProfileIDs        = "ExchangeRateProfileIDs"
+	RateDeckIDs                   = "RateDeckIDs"
+	RateDeckID                    = "RateDeckID"
+	TaxProfileIDs                 = "TaxProfileIDs"
+	LookupTableIDs                = "LookupTableIDs"
 	TimingIDs                     = "TimingIDs"
 	AttributeFilterIndexIDs       = "AttributeFilterIndexIDs"
 	ResourceFilterIndexIDs        = "ResourceFilterIndexIDs"
@@ -2662,9 +2900,21 @@ const (
 	RateFilterIndexIDs            = "RateFilterIndexIDs"
 	ActionProfilesFilterIndexIDs  = "ActionProfilesFilterIndexIDs"
 	AccountProfilesFilterIndexIDs = "AccountProfilesFilterIndexIDs"
+	TaxProfilesFilterIndexIDs     = "TaxProfilesFilterIndexIDs"
 	FilterIndexIDs                = "FilterIndexIDs"
 )
 
+// LoaderS source schemes and options
+const (
+	S3Scheme            = "s3"
+	SFTPScheme          = "sftp"
+	HTTPScheme          = "http"
+	HTTPSScheme         = "https"
+	SFTPKeyFile         = "sftpKeyFile"
+	SFTPKnownHostsFile  = "sftpKnownHostsFile"
+	SFTPInsecureHostKey = "sftpInsecureHostKey"
+)
+
 // Poster and Event reader constants
 const (
 	SQSPoster     = "SQSPoster"
@@ -2673,6 +2923,7 @@ const (
 	AWSKey        = "awsKey"
 	AWSSecret     = "awsSecret"
 	AWSToken      = "awsToken"
+	S3Endpoint    = "s3Endpoint"
 	AWSFolderPath = "folderPath"
 	KafkaTopic    = "topic"
 	KafkaGroupID  = "groupID"
@@ -2694,8 +2945,10 @@ const (
 	KafkaDefaultGroupID = "cgrates"
 	KafkaDefaultMaxWait = time.Millisecond
 
-	SQLDBName         = "dbName"
-	SQLTableName      = "tableName"
+	SQLDBName    = "dbName"
+	SQLTableName = "tableName"
+
+	HTMLTemplate      = "htmlTemplate"
 	SQLSSLMode        = "sslmode"
 	SQLDefaultSSLMode = "disable"
 	SQLDefaultDBName  = "cgrates"
@@ -2719,7 +2972,7 @@ const (
 	Opts             = "Opts"
 )
 
-//CMD constants
+// CMD constants
 const (
 	//Common
 	VerboseCgr      = "verbose"
@@ -2799,6 +3052,37 @@ const (
 	ActionSv1ExecuteActions  = "ActionSv1.ExecuteActions"
 )
 
+// Log fields
+const (
+	LogFieldTime      = "time"
+	LogFieldLevel     = "level"
+	LogFieldNodeID    = "node_id"
+	LogFieldSubsystem = "subsystem"
+	LogFieldMessage   = "message"
+	LogFieldTenant    = "tenant"
+	LogFieldCGRID     = "cgrid"
+	LogFieldOriginID  = "origin_id"
+	LogFieldRPCMethod = "rpc_method"
+	LogFieldDuration  = "duration"
+	LogFieldError     = "error"
+)
+
+// Tracing
+const (
+	OptsTraceParent      = "*traceparent"
+	MetaHTTP             = "*http"
+	TraceRPCSystem       = "rpc.system"
+	TraceRPCService      = "rpc.service"
+	TraceRPCMethod       = "rpc.method"
+	TraceConnIDs         = "cgr.conn_ids"
+	TraceSubsystem       = "cgr.subsystem"
+	TraceTenant          = "cgr.tenant"
+	TraceDispatcherHost  = "cgr.dispatcher_host"
+	TraceDispatcherRoute = "cgr.dispatcher_route"
+	TraceServiceName     = "service.name"
+	TraceServiceInstance = "service.instance.id"
+)
+
 // Time duration suffix
 const (
 	NsSuffix = "ns"
@@ -977,6 +977,14 @@ type DurationArgs struct {
 	Tenant   string
 }
 
+// ArgsSetLogLevel changes the log level at runtime
+type ArgsSetLogLevel struct {
+	Subsystem string // empty for the system wide log level
+	Level     int    // negative level removes the subsystem overwrite
+	Opts      map[string]interface{}
+	Tenant    string
+}
+
 // AESEncrypt will encrypt the provided txt using the encKey and AES algorithm
 func AESEncrypt(txt, encKey string) (encrypted string, err error) {
 	key, _ := hex.DecodeString(encKey)
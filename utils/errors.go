@@ -64,6 +64,7 @@ var (
 	ErrNotEnoughParameters           = errors.New("NotEnoughParameters")
 	ErrNotConnected                  = errors.New("NOT_CONNECTED")
 	RalsErrorPrfx                    = "RALS_ERROR"
+	RoutesErrorPrfx                  = "ROUTES_ERROR"
 	DispatcherErrorPrefix            = "DISPATCHER_ERROR"
 	RateSErrPrfx                     = "RATES_ERROR"
 	ErrUnsupportedFormat             = errors.New("UNSUPPORTED_FORMAT")
@@ -178,7 +179,7 @@ func NewErrResourceS(err error) error {
 }
 
 func NewErrRouteS(err error) error {
-	return fmt.Errorf("ROUTES_ERROR:%s", err)
+	return fmt.Errorf("%s:%s", RoutesErrorPrfx, err)
 }
 
 func NewErrAttributeS(err error) error {
@@ -38,22 +38,113 @@ type EventCharges struct {
 
 	Accounting *ChargingAccountS
 	Rating     *ChargingRateS
+
+	ExchangeRates []*ChargedExchangeRate // conversions done when paying in another currency
+	Taxes         []*TaxCharge           // taxes applied on top of the concretes charged
+}
+
+// ChargedExchangeRate records the exchange rate applied when debiting a balance in another currency than the cost
+type ChargedExchangeRate struct {
+	BalanceID    string
+	FromCurrency string   // currency of the cost
+	ToCurrency   string   // currency of the balance
+	Rate         *Decimal // one unit of FromCurrency in ToCurrency
+	Cost         *Decimal // cost debited, in FromCurrency
+	Debited      *Decimal // units debited from the balance, in ToCurrency
+}
+
+// AsExtChargedExchangeRate converts ChargedExchangeRate to ExtChargedExchangeRate
+func (cer *ChargedExchangeRate) AsExtChargedExchangeRate() (eCer *ExtChargedExchangeRate, err error) {
+	eCer = &ExtChargedExchangeRate{
+		BalanceID:    cer.BalanceID,
+		FromCurrency: cer.FromCurrency,
+		ToCurrency:   cer.ToCurrency,
+	}
+	for _, dec := range []struct {
+		name string
+		val  *Decimal
+		flt  **float64
+	}{
+		{"Rate", cer.Rate, &eCer.Rate},
+		{"Cost", cer.Cost, &eCer.Cost},
+		{"Debited", cer.Debited, &eCer.Debited},
+	} {
+		if dec.val == nil {
+			continue
+		}
+		flt, ok := dec.val.Big.Float64()
+		if !ok {
+			return nil, errors.New("cannot convert decimal " + dec.name + " to float64")
+		}
+		*dec.flt = &flt
+	}
+	return
+}
+
+// TaxCharge records one tax applied on top of a charged amount
+type TaxCharge struct {
+	TaxProfileID string
+	Jurisdiction string
+	TaxType      string
+	Rate         *Decimal // fraction of the Base, ie: 0.19 for 19%
+	Base         *Decimal // amount the tax was applied on
+	Amount       *Decimal
+}
+
+// AsExtTaxCharge converts TaxCharge to ExtTaxCharge
+func (tc *TaxCharge) AsExtTaxCharge() (eTc *ExtTaxCharge, err error) {
+	eTc = &ExtTaxCharge{
+		TaxProfileID: tc.TaxProfileID,
+		Jurisdiction: tc.Jurisdiction,
+		TaxType:      tc.TaxType,
+	}
+	for _, dec := range []struct {
+		name string
+		val  *Decimal
+		flt  **float64
+	}{
+		{"Rate", tc.Rate, &eTc.Rate},
+		{"Base", tc.Base, &eTc.Base},
+		{"Amount", tc.Amount, &eTc.Amount},
+	} {
+		if dec.val == nil {
+			continue
+		}
+		flt, ok := dec.val.Big.Float64()
+		if !ok {
+			return nil, errors.New("cannot convert decimal " + dec.name + " to float64")
+		}
+		*dec.flt = &flt
+	}
+	return
+}
+
+// TaxesTotal returns the sum of the tax amounts
+func TaxesTotal(tcs []*TaxCharge) (total *Decimal) {
+	total = NewDecimal(0, 0)
+	for _, tc := range tcs {
+		if tc.Amount != nil {
+			total = &Decimal{SumBig(total.Big, tc.Amount.Big)}
+		}
+	}
+	return
 }
 
 // Merge will merge the event charges into existing
 func (ec *EventCharges) Merge(eCs ...*EventCharges) {
 	for _, nEc := range eCs {
-		if ec.Abstracts != nil {
-			ec.Abstracts = &Decimal{SumBig(ec.Abstracts.Big, nEc.Abstracts.Big)}
-		} else { // initial
+		if ec.Abstracts == nil { // initial
 			ec.Abstracts = nEc.Abstracts
+		} else if nEc.Abstracts != nil {
+			ec.Abstracts = &Decimal{SumBig(ec.Abstracts.Big, nEc.Abstracts.Big)}
 		}
-		if ec.Concretes != nil {
-			ec.Concretes = &Decimal{SumBig(ec.Concretes.Big, nEc.Concretes.Big)}
-		} else { // initial
+		if ec.Concretes == nil { // initial
 			ec.Concretes = nEc.Concretes
+		} else if nEc.Concretes != nil {
+			ec.Concretes = &Decimal{SumBig(ec.Concretes.Big, nEc.Concretes.Big)}
 		}
-
+		ec.ExchangeRates = append(ec.ExchangeRates, nEc.ExchangeRates...)
+		ec.Taxes = append(ec.Taxes, nEc.Taxes...)
 	}
 }
 
@@ -74,12 +165,50 @@ func (ec *EventCharges) AsExtEventCharges() (eEc *ExtEventCharges, err error) {
 			eEc.Concretes = &flt
 		}
 	}
+	if ec.ExchangeRates != nil {
+		eEc.ExchangeRates = make([]*ExtChargedExchangeRate, len(ec.ExchangeRates))
+		for i, cer := range ec.ExchangeRates {
+			if eEc.ExchangeRates[i], err = cer.AsExtChargedExchangeRate(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if ec.Taxes != nil {
+		eEc.Taxes = make([]*ExtTaxCharge, len(ec.Taxes))
+		for i, tc := range ec.Taxes {
+			if eEc.Taxes[i], err = tc.AsExtTaxCharge(); err != nil {
+				return nil, err
+			}
+		}
+	}
 	// add here code for the rest of the fields
 	return
 }
 
 // ExtEventCharges is a generic EventCharges used in APIs
 type ExtEventCharges struct {
-	Abstracts *float64
-	Concretes *float64
+	Abstracts     *float64
+	Concretes     *float64
+	ExchangeRates []*ExtChargedExchangeRate
+	Taxes         []*ExtTaxCharge
+}
+
+// ExtChargedExchangeRate is a generic ChargedExchangeRate used in APIs
+type ExtChargedExchangeRate struct {
+	BalanceID    string
+	FromCurrency string
+	ToCurrency   string
+	Rate         *float64
+	Cost         *float64
+	Debited      *float64
+}
+
+// ExtTaxCharge is a generic TaxCharge used in APIs
+type ExtTaxCharge struct {
+	TaxProfileID string
+	Jurisdiction string
+	TaxType      string
+	Rate         *float64
+	Base         *float64
+	Amount       *float64
 }
@@ -164,3 +164,83 @@ func TestECAsExtEventChargesFailConcretes(t *testing.T) {
 		t.Errorf("\nReceived: %v,\nExpected: %v", err, expected)
 	}
 }
+
+func TestECAsExtEventChargesExchangeRates(t *testing.T) {
+	ec := &EventCharges{
+		Concretes: NewDecimal(10, 0),
+		ExchangeRates: []*ChargedExchangeRate{
+			{
+				BalanceID:    "CB_USD",
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         NewDecimal(2, 0),
+				Cost:         NewDecimal(5, 0),
+				Debited:      NewDecimal(10, 0),
+			},
+		},
+	}
+	expected := &ExtEventCharges{
+		Concretes: Float64Pointer(10),
+		ExchangeRates: []*ExtChargedExchangeRate{
+			{
+				BalanceID:    "CB_USD",
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         Float64Pointer(2),
+				Cost:         Float64Pointer(5),
+				Debited:      Float64Pointer(10),
+			},
+		},
+	}
+	if received, err := ec.AsExtEventCharges(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(received, expected) {
+		t.Errorf("\nReceived: <%v>,\nExpected: <%v>", ToJSON(received), ToJSON(expected))
+	}
+
+	merged := NewEventCharges()
+	merged.Merge(ec, ec)
+	if len(merged.ExchangeRates) != 2 {
+		t.Errorf("Expected 2 exchange rates, received %s", ToJSON(merged.ExchangeRates))
+	}
+}
+
+func TestECAsExtEventChargesTaxes(t *testing.T) {
+	ec := &EventCharges{
+		Concretes: NewDecimal(10, 0),
+		Taxes: []*TaxCharge{
+			{
+				TaxProfileID: "TAX_VAT",
+				Jurisdiction: "DE",
+				TaxType:      "VAT",
+				Rate:         NewDecimal(19, 2),
+				Base:         NewDecimal(10, 0),
+				Amount:       NewDecimal(19, 1),
+			},
+		},
+	}
+	expected := &ExtEventCharges{
+		Concretes: Float64Pointer(10),
+		Taxes: []*ExtTaxCharge{
+			{
+				TaxProfileID: "TAX_VAT",
+				Jurisdiction: "DE",
+				TaxType:      "VAT",
+				Rate:         Float64Pointer(0.19),
+				Base:         Float64Pointer(10),
+				Amount:       Float64Pointer(1.9),
+			},
+		},
+	}
+	if received, err := ec.AsExtEventCharges(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(received, expected) {
+		t.Errorf("\nReceived: <%v>,\nExpected: <%v>", ToJSON(received), ToJSON(expected))
+	}
+
+	merged := NewEventCharges()
+	merged.Merge(ec, ec)
+	if total := TaxesTotal(merged.Taxes); total.Compare(NewDecimal(38, 1)) != 0 {
+		t.Errorf("Expected total 3.8, received %s", total)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+// ExchangeRateProfile is a table of exchange rates between currencies
+type ExchangeRateProfile struct {
+	Tenant string
+	ID     string
+	Rates  []*ExchangeRate
+}
+
+// ExchangeRate is the rate used to convert one unit of FromCurrency into ToCurrency, starting with ActivationTime
+type ExchangeRate struct {
+	FromCurrency   string
+	ToCurrency     string
+	ActivationTime time.Time
+	Rate           *Decimal
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (erp *ExchangeRateProfile) TenantID() string {
+	return ConcatenatedKey(erp.Tenant, erp.ID)
+}
+
+// Sort orders the rates based on their activation time
+func (erp *ExchangeRateProfile) Sort() {
+	sort.SliceStable(erp.Rates, func(i, j int) bool {
+		return erp.Rates[i].ActivationTime.Before(erp.Rates[j].ActivationTime)
+	})
+}
+
+// Clone returns a copy of the profile
+func (erp *ExchangeRateProfile) Clone() (cln *ExchangeRateProfile) {
+	cln = &ExchangeRateProfile{
+		Tenant: erp.Tenant,
+		ID:     erp.ID,
+	}
+	if erp.Rates != nil {
+		cln.Rates = make([]*ExchangeRate, len(erp.Rates))
+		for i, rt := range erp.Rates {
+			cln.Rates[i] = rt.Clone()
+		}
+	}
+	return
+}
+
+// Clone returns a copy of the rate
+func (er *ExchangeRate) Clone() (cln *ExchangeRate) {
+	cln = &ExchangeRate{
+		FromCurrency:   er.FromCurrency,
+		ToCurrency:     er.ToCurrency,
+		ActivationTime: er.ActivationTime,
+	}
+	if er.Rate != nil {
+		cln.Rate = &Decimal{new(decimal.Big).Copy(er.Rate.Big)}
+	}
+	return
+}
+
+// ExchangeRateAt returns the rate converting from one currency into the other, active at the given time
+// the inverse of the reverse rate is used if the direct one is not defined
+func (erp *ExchangeRateProfile) ExchangeRateAt(from, to string, at time.Time) (rate *Decimal, err error) {
+	var direct, reverse *ExchangeRate
+	for _, er := range erp.Rates { // sorted on activation time so the last match is the active one
+		if er.ActivationTime.After(at) {
+			break
+		}
+		switch {
+		case er.FromCurrency == from && er.ToCurrency == to:
+			direct = er
+		case er.FromCurrency == to && er.ToCurrency == from:
+			reverse = er
+		}
+	}
+	switch {
+	case direct != nil:
+		return direct.Rate, nil
+	case reverse != nil && reverse.Rate.Big.Sign() != 0:
+		return &Decimal{DivideBig(decimal.New(1, 0), reverse.Rate.Big)}, nil
+	}
+	return nil, ErrNotFound
+}
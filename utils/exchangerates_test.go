@@ -0,0 +1,123 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ericlagergren/decimal"
+)
+
+func TestExchangeRateProfileSort(t *testing.T) {
+	erp := &ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP1",
+		Rates: []*ExchangeRate{
+			{
+				FromCurrency:   "EUR",
+				ToCurrency:     "USD",
+				ActivationTime: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Rate:           NewDecimal(12, 1),
+			},
+			{
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         NewDecimal(11, 1),
+			},
+		},
+	}
+	erp.Sort()
+	if !erp.Rates[0].ActivationTime.IsZero() {
+		t.Errorf("unexpected order: %s", ToJSON(erp.Rates))
+	}
+	if rcv := erp.TenantID(); rcv != "cgrates.org:ERP1" {
+		t.Errorf("Expected %q, received %q", "cgrates.org:ERP1", rcv)
+	}
+}
+
+func TestExchangeRateProfileExchangeRateAt(t *testing.T) {
+	erp := &ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP1",
+		Rates: []*ExchangeRate{
+			{
+				FromCurrency: "EUR",
+				ToCurrency:   "USD",
+				Rate:         NewDecimal(11, 1),
+			},
+			{
+				FromCurrency: "GBP",
+				ToCurrency:   "EUR",
+				Rate:         NewDecimal(2, 0),
+			},
+			{
+				FromCurrency:   "EUR",
+				ToCurrency:     "USD",
+				ActivationTime: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Rate:           NewDecimal(12, 1),
+			},
+		},
+	}
+	if rate, err := erp.ExchangeRateAt("EUR", "USD",
+		time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if rate.Cmp(decimal.New(11, 1)) != 0 {
+		t.Errorf("Expected 1.1, received %s", rate)
+	}
+	if rate, err := erp.ExchangeRateAt("EUR", "USD",
+		time.Date(2021, 2, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Error(err)
+	} else if rate.Cmp(decimal.New(12, 1)) != 0 {
+		t.Errorf("Expected 1.2, received %s", rate)
+	}
+	// inverse of the reverse rate
+	if rate, err := erp.ExchangeRateAt("EUR", "GBP", time.Now()); err != nil {
+		t.Error(err)
+	} else if rate.Cmp(decimal.New(5, 1)) != 0 {
+		t.Errorf("Expected 0.5, received %s", rate)
+	}
+	if _, err := erp.ExchangeRateAt("EUR", "RON", time.Now()); err != ErrNotFound {
+		t.Errorf("Expected %+v, received %+v", ErrNotFound, err)
+	}
+}
+
+func TestExchangeRateProfileClone(t *testing.T) {
+	erp := &ExchangeRateProfile{
+		Tenant: "cgrates.org",
+		ID:     "ERP1",
+		Rates: []*ExchangeRate{
+			{
+				FromCurrency:   "EUR",
+				ToCurrency:     "USD",
+				ActivationTime: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+				Rate:           NewDecimal(12, 1),
+			},
+		},
+	}
+	cln := erp.Clone()
+	if !reflect.DeepEqual(erp, cln) {
+		t.Errorf("Expected %s, received %s", ToJSON(erp), ToJSON(cln))
+	}
+	cln.Rates[0].ToCurrency = "GBP"
+	if erp.Rates[0].ToCurrency != "USD" {
+		t.Error("Expected clone to not modify the cloned")
+	}
+}
@@ -37,6 +37,8 @@ type RateProfile struct {
 	MinCost            *Decimal
 	MaxCost            *Decimal
 	MaxCostStrategy    string
+	Currency           string // currency of the costs, ie: EUR
+	VolumePeriod       string // period of the volume counters, empty to apply the interval rates per event: <""|*daily|*weekly|*monthly|*yearly>
 	Rates              map[string]*Rate
 }
 
@@ -147,6 +149,7 @@ type RateProfileCost struct {
 	MinCost         float64
 	MaxCost         float64
 	MaxCostStrategy string
+	Currency        string
 	RateSIntervals  []*RateSInterval
 	Altered         []string
 }
@@ -257,6 +260,8 @@ func (ext *APIRateProfile) AsRateProfile() (rp *RateProfile, err error) {
 		FilterIDs:          ext.FilterIDs,
 		ActivationInterval: ext.ActivationInterval,
 		MaxCostStrategy:    ext.MaxCostStrategy,
+		Currency:           ext.Currency,
+		VolumePeriod:       ext.VolumePeriod,
 	}
 	if ext.Weights != EmptyString {
 		if rp.Weights, err = NewDynamicWeightsFromString(ext.Weights, ";", "&"); err != nil {
@@ -292,6 +297,8 @@ type APIRateProfile struct {
 	MinCost            *float64
 	MaxCost            *float64
 	MaxCostStrategy    string
+	Currency           string
+	VolumePeriod       string
 	Rates              map[string]*APIRate
 }
 
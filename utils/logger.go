@@ -22,8 +22,13 @@ import (
 	"fmt"
 	"log"
 	"log/syslog"
+	"os"
 	"reflect"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 var Logger LoggerInterface
@@ -46,6 +51,8 @@ func Newlogger(loggertype, id string) (lgr LoggerInterface, err error) {
 		l, err = syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, fmt.Sprintf("CGRateS <%s> ", id))
 		lgr.SetSyslog(l) // if we received an error, l is nil
 		return
+	case MetaJSON:
+		return NewJSONLogger(os.Stdout, id), nil
 	default:
 		return nil, fmt.Errorf("unsuported logger: <%s>", loggertype)
 	}
@@ -54,6 +61,8 @@ func Newlogger(loggertype, id string) (lgr LoggerInterface, err error) {
 type LoggerInterface interface {
 	SetSyslog(log *syslog.Writer)
 	SetLogLevel(level int)
+	SetSubsystemLogLevel(subsys string, level int)
+	GetLogLevels() *LogLevels
 	GetSyslog() *syslog.Writer
 	Close() error
 	Emerg(m string) error
@@ -64,9 +73,27 @@ type LoggerInterface interface {
 	Notice(m string) error
 	Info(m string) error
 	Debug(m string) error
+	LogWithFields(level int, m string, fields LogFields) error
 	Write(p []byte) (n int, err error)
 }
 
+// LogFields are the structured fields attached to a log message
+type LogFields map[string]interface{}
+
+// LogLevels is the system wide log level together with the subsystem overwrites
+type LogLevels struct {
+	LogLevel   int
+	Subsystems map[string]int
+}
+
+// SubsystemLogLevel returns the log level applied to the subsystem
+func (lls *LogLevels) SubsystemLogLevel(subsys string) int {
+	if lvl, has := lls.Subsystems[subsys]; has {
+		return lvl
+	}
+	return lls.LogLevel
+}
+
 // log severities following rfc3164
 const (
 	LOGLEVEL_EMERGENCY = iota
@@ -79,11 +106,97 @@ const (
 	LOGLEVEL_DEBUG
 )
 
+var logLevelNames = []string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR",
+	"WARNING", "NOTICE", "INFO", "DEBUG"}
+
+// LogSubsystem returns the subsystem from a message prefixed with <subsystem>
+func LogSubsystem(m string) (subsys, msg string) {
+	if !strings.HasPrefix(m, "<") {
+		return EmptyString, m
+	}
+	idx := strings.IndexByte(m, '>')
+	if idx == -1 {
+		return EmptyString, m
+	}
+	return m[1:idx], strings.TrimSpace(m[idx+1:])
+}
+
+// subsystemLogLevels overwrites the log level for the messages of some subsystems
+type subsystemLogLevels struct {
+	sync.RWMutex
+	lvls map[string]int
+}
+
+// set adds the overwrite for the subsystem, negative level removes it
+func (sl *subsystemLogLevels) set(subsys string, level int) {
+	sl.Lock()
+	defer sl.Unlock()
+	if level < 0 {
+		delete(sl.lvls, subsys)
+		return
+	}
+	if sl.lvls == nil {
+		sl.lvls = make(map[string]int)
+	}
+	sl.lvls[subsys] = level
+}
+
+func (sl *subsystemLogLevels) logLevels(dflt int) (lls *LogLevels) {
+	sl.RLock()
+	lls = &LogLevels{
+		LogLevel:   dflt,
+		Subsystems: make(map[string]int, len(sl.lvls)),
+	}
+	for subsys, lvl := range sl.lvls {
+		lls.Subsystems[subsys] = lvl
+	}
+	sl.RUnlock()
+	return
+}
+
+// logLevel returns the level applied to the message
+func (sl *subsystemLogLevels) logLevel(m string, dflt int) int {
+	sl.RLock()
+	defer sl.RUnlock()
+	if len(sl.lvls) == 0 {
+		return dflt
+	}
+	if subsys, _ := LogSubsystem(m); subsys != EmptyString {
+		if lvl, has := sl.lvls[subsys]; has {
+			return lvl
+		}
+	}
+	return dflt
+}
+
+// logWithLevel calls the logger method for the level
+func logWithLevel(lgr LoggerInterface, level int, m string) error {
+	switch level {
+	case LOGLEVEL_EMERGENCY:
+		return lgr.Emerg(m)
+	case LOGLEVEL_ALERT:
+		return lgr.Alert(m)
+	case LOGLEVEL_CRITICAL:
+		return lgr.Crit(m)
+	case LOGLEVEL_ERROR:
+		return lgr.Err(m)
+	case LOGLEVEL_WARNING:
+		return lgr.Warning(m)
+	case LOGLEVEL_NOTICE:
+		return lgr.Notice(m)
+	case LOGLEVEL_INFO:
+		return lgr.Info(m)
+	default:
+		return lgr.Debug(m)
+	}
+}
+
 // Logs to standard output
 type StdLogger struct {
-	logLevel int
-	nodeID   string
-	syslog   *syslog.Writer
+	logLevel   int32 // accessed atomically since it can be changed at runtime
+	nodeID     string
+	syslog     *syslog.Writer
+	subsystems subsystemLogLevels
 }
 
 func (sl *StdLogger) Close() (err error) {
@@ -110,12 +223,42 @@ func (sl *StdLogger) GetSyslog() *syslog.Writer {
 
 // SetLogLevel changes the log level
 func (sl *StdLogger) SetLogLevel(level int) {
-	sl.logLevel = level
+	atomic.StoreInt32(&sl.logLevel, int32(level))
+}
+
+// level returns the log level, ignoring the subsystem overwrites
+func (sl *StdLogger) level() int {
+	return int(atomic.LoadInt32(&sl.logLevel))
+}
+
+// SetSubsystemLogLevel overwrites the log level for the subsystem, negative level removes the overwrite
+func (sl *StdLogger) SetSubsystemLogLevel(subsys string, level int) {
+	sl.subsystems.set(subsys, level)
+}
+
+// GetLogLevels returns the log levels in use
+func (sl *StdLogger) GetLogLevels() *LogLevels {
+	return sl.subsystems.logLevels(sl.level())
+}
+
+// LogWithFields logs the message followed by the fields as key=value
+func (sl *StdLogger) LogWithFields(level int, m string, fields LogFields) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(m)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf(" %s=%v", k, fields[k]))
+	}
+	return logWithLevel(sl, level, sb.String())
 }
 
 // Alert logs to syslog with alert level
 func (sl *StdLogger) Alert(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_ALERT {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_ALERT {
 		return
 	}
 	if sl.syslog != nil {
@@ -128,7 +271,7 @@ func (sl *StdLogger) Alert(m string) (err error) {
 
 // Crit logs to syslog with critical level
 func (sl *StdLogger) Crit(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_CRITICAL {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_CRITICAL {
 		return
 	}
 	if sl.syslog != nil {
@@ -141,7 +284,7 @@ func (sl *StdLogger) Crit(m string) (err error) {
 
 // Debug logs to syslog with debug level
 func (sl *StdLogger) Debug(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_DEBUG {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_DEBUG {
 		return
 	}
 	if sl.syslog != nil {
@@ -154,7 +297,7 @@ func (sl *StdLogger) Debug(m string) (err error) {
 
 // Emerg logs to syslog with emergency level
 func (sl *StdLogger) Emerg(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_EMERGENCY {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_EMERGENCY {
 		return
 	}
 	if sl.syslog != nil {
@@ -167,7 +310,7 @@ func (sl *StdLogger) Emerg(m string) (err error) {
 
 // Err logs to syslog with error level
 func (sl *StdLogger) Err(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_ERROR {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_ERROR {
 		return
 	}
 	if sl.syslog != nil {
@@ -180,7 +323,7 @@ func (sl *StdLogger) Err(m string) (err error) {
 
 // Info logs to syslog with info level
 func (sl *StdLogger) Info(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_INFO {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_INFO {
 		return
 	}
 	if sl.syslog != nil {
@@ -193,7 +336,7 @@ func (sl *StdLogger) Info(m string) (err error) {
 
 // Notice logs to syslog with notice level
 func (sl *StdLogger) Notice(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_NOTICE {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_NOTICE {
 		return
 	}
 	if sl.syslog != nil {
@@ -206,7 +349,7 @@ func (sl *StdLogger) Notice(m string) (err error) {
 
 // Warning logs to syslog with warning level
 func (sl *StdLogger) Warning(m string) (err error) {
-	if sl.logLevel < LOGLEVEL_WARNING {
+	if sl.subsystems.logLevel(m, sl.level()) < LOGLEVEL_WARNING {
 		return
 	}
 
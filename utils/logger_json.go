@@ -0,0 +1,158 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"io"
+	"log/syslog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewJSONLogger returns a logger writing one JSON record per line into w
+func NewJSONLogger(w io.Writer, nodeID string) *JSONLogger {
+	return &JSONLogger{
+		w:      w,
+		nodeID: nodeID,
+	}
+}
+
+// NewJSONFileLogger returns a JSON logger appending to the file at path
+func NewJSONFileLogger(path, nodeID string) (jl *JSONLogger, err error) {
+	var fl *os.File
+	if fl, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+	return NewJSONLogger(fl, nodeID), nil
+}
+
+// JSONLogger logs structured records so they can be parsed by the log collectors
+type JSONLogger struct {
+	sync.Mutex // protects the writer
+	w          io.Writer
+	logLevel   int32 // accessed atomically since it can be changed at runtime
+	nodeID     string
+	subsystems subsystemLogLevels
+}
+
+// log writes the record if the level is allowed for the subsystem of the message
+func (jl *JSONLogger) log(level int, m string, fields LogFields) (err error) {
+	if jl.subsystems.logLevel(m, jl.level()) < level {
+		return
+	}
+	rec := make(map[string]interface{}, len(fields)+5)
+	for k, v := range fields {
+		rec[k] = v
+	}
+	rec[LogFieldTime] = time.Now().Format(time.RFC3339Nano)
+	rec[LogFieldLevel] = logLevelNames[level]
+	rec[LogFieldNodeID] = jl.nodeID
+	subsys, msg := LogSubsystem(m)
+	if subsys != EmptyString {
+		rec[LogFieldSubsystem] = subsys
+	}
+	rec[LogFieldMessage] = msg
+	var b []byte
+	if b, err = json.Marshal(rec); err != nil {
+		return
+	}
+	b = append(b, '\n')
+	jl.Lock()
+	_, err = jl.w.Write(b)
+	jl.Unlock()
+	return
+}
+
+// Close closes the underlying writer if possible
+func (jl *JSONLogger) Close() (err error) {
+	if cl, canClose := jl.w.(io.Closer); canClose && jl.w != os.Stdout {
+		err = cl.Close()
+	}
+	return
+}
+
+// Write logs p as an info message
+func (jl *JSONLogger) Write(p []byte) (n int, err error) {
+	if err = jl.log(LOGLEVEL_INFO, strings.TrimSpace(string(p)), nil); err != nil {
+		return
+	}
+	return len(p), nil
+}
+
+// SetSyslog is not used by the JSON logger
+func (jl *JSONLogger) SetSyslog(*syslog.Writer) {}
+
+// GetSyslog is not used by the JSON logger
+func (jl *JSONLogger) GetSyslog() *syslog.Writer { return nil }
+
+// SetLogLevel changes the log level
+func (jl *JSONLogger) SetLogLevel(level int) {
+	atomic.StoreInt32(&jl.logLevel, int32(level))
+}
+
+// level returns the log level, ignoring the subsystem overwrites
+func (jl *JSONLogger) level() int {
+	return int(atomic.LoadInt32(&jl.logLevel))
+}
+
+// SetSubsystemLogLevel overwrites the log level for the subsystem, negative level removes the overwrite
+func (jl *JSONLogger) SetSubsystemLogLevel(subsys string, level int) {
+	jl.subsystems.set(subsys, level)
+}
+
+// GetLogLevels returns the log levels in use
+func (jl *JSONLogger) GetLogLevels() *LogLevels {
+	return jl.subsystems.logLevels(jl.level())
+}
+
+// LogWithFields logs the message together with the structured fields
+func (jl *JSONLogger) LogWithFields(level int, m string, fields LogFields) error {
+	if level < LOGLEVEL_EMERGENCY || level > LOGLEVEL_DEBUG {
+		level = LOGLEVEL_DEBUG
+	}
+	return jl.log(level, m, fields)
+}
+
+// Emerg logs with emergency level
+func (jl *JSONLogger) Emerg(m string) error { return jl.log(LOGLEVEL_EMERGENCY, m, nil) }
+
+// Alert logs with alert level
+func (jl *JSONLogger) Alert(m string) error { return jl.log(LOGLEVEL_ALERT, m, nil) }
+
+// Crit logs with critical level
+func (jl *JSONLogger) Crit(m string) error { return jl.log(LOGLEVEL_CRITICAL, m, nil) }
+
+// Err logs with error level
+func (jl *JSONLogger) Err(m string) error { return jl.log(LOGLEVEL_ERROR, m, nil) }
+
+// Warning logs with warning level
+func (jl *JSONLogger) Warning(m string) error { return jl.log(LOGLEVEL_WARNING, m, nil) }
+
+// Notice logs with notice level
+func (jl *JSONLogger) Notice(m string) error { return jl.log(LOGLEVEL_NOTICE, m, nil) }
+
+// Info logs with info level
+func (jl *JSONLogger) Info(m string) error { return jl.log(LOGLEVEL_INFO, m, nil) }
+
+// Debug logs with debug level
+func (jl *JSONLogger) Debug(m string) error { return jl.log(LOGLEVEL_DEBUG, m, nil) }
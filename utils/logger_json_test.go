@@ -0,0 +1,191 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testJSONLogRecords(t *testing.T, b []byte) (recs []map[string]interface{}) {
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		if line == EmptyString {
+			continue
+		}
+		rec := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("invalid record %q: %v", line, err)
+		}
+		delete(rec, LogFieldTime)
+		recs = append(recs, rec)
+	}
+	return
+}
+
+func TestJSONLogger(t *testing.T) {
+	output := new(bytes.Buffer)
+	jl := NewJSONLogger(output, "node1")
+	jl.SetLogLevel(LOGLEVEL_WARNING)
+	jl.Info("<SessionS> not logged")
+	jl.Err("<SessionS> error: <NOT_FOUND>")
+	jl.Warning("no subsystem")
+	exp := []map[string]interface{}{
+		{
+			LogFieldLevel:     "ERROR",
+			LogFieldNodeID:    "node1",
+			LogFieldSubsystem: SessionS,
+			LogFieldMessage:   "error: <NOT_FOUND>",
+		},
+		{
+			LogFieldLevel:   "WARNING",
+			LogFieldNodeID:  "node1",
+			LogFieldMessage: "no subsystem",
+		},
+	}
+	if rcv := testJSONLogRecords(t, output.Bytes()); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+}
+
+func TestJSONLoggerFields(t *testing.T) {
+	output := new(bytes.Buffer)
+	jl := NewJSONLogger(output, "node1")
+	jl.SetLogLevel(LOGLEVEL_INFO)
+	jl.SetSubsystemLogLevel(CoreS, LOGLEVEL_DEBUG)
+	if err := jl.LogWithFields(LOGLEVEL_DEBUG, "<CoreS> served API call", LogFields{
+		LogFieldRPCMethod: CoreSv1Ping,
+		LogFieldTenant:    "cgrates.org",
+		LogFieldDuration:  "1ms",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	jl.LogWithFields(LOGLEVEL_DEBUG, "<RALs> not logged", nil)
+	exp := []map[string]interface{}{{
+		LogFieldLevel:     "DEBUG",
+		LogFieldNodeID:    "node1",
+		LogFieldSubsystem: CoreS,
+		LogFieldMessage:   "served API call",
+		LogFieldRPCMethod: CoreSv1Ping,
+		LogFieldTenant:    "cgrates.org",
+		LogFieldDuration:  "1ms",
+	}}
+	if rcv := testJSONLogRecords(t, output.Bytes()); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+	expLvls := &LogLevels{LogLevel: LOGLEVEL_INFO, Subsystems: map[string]int{CoreS: LOGLEVEL_DEBUG}}
+	if rcv := jl.GetLogLevels(); !reflect.DeepEqual(expLvls, rcv) {
+		t.Errorf("Expected %+v, received %+v", expLvls, rcv)
+	} else if lvl := rcv.SubsystemLogLevel(RALService); lvl != LOGLEVEL_INFO {
+		t.Errorf("Expected %d, received %d", LOGLEVEL_INFO, lvl)
+	}
+}
+
+func TestJSONLoggerWrite(t *testing.T) {
+	output := new(bytes.Buffer)
+	jl := NewJSONLogger(output, "node1")
+	jl.SetLogLevel(LOGLEVEL_INFO)
+	if n, err := jl.Write([]byte("http: TLS handshake error\n")); err != nil {
+		t.Error(err)
+	} else if n != 26 {
+		t.Errorf("Expected 26, received %d", n)
+	}
+	exp := []map[string]interface{}{{
+		LogFieldLevel:   "INFO",
+		LogFieldNodeID:  "node1",
+		LogFieldMessage: "http: TLS handshake error",
+	}}
+	if rcv := testJSONLogRecords(t, output.Bytes()); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+	if jl.GetSyslog() != nil {
+		t.Error("Expected no syslog")
+	}
+}
+
+func TestJSONFileLogger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cgrates.log")
+	jl, err := NewJSONFileLogger(path, "node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jl.SetLogLevel(LOGLEVEL_INFO)
+	jl.Info("<CDRs> started")
+	if err = jl.Close(); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []map[string]interface{}{{
+		LogFieldLevel:     "INFO",
+		LogFieldNodeID:    "node1",
+		LogFieldSubsystem: CDRs,
+		LogFieldMessage:   "started",
+	}}
+	if rcv := testJSONLogRecords(t, b); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", ToJSON(exp), ToJSON(rcv))
+	}
+	if _, err = NewJSONFileLogger(filepath.Join(path, "invalid"), "node1"); err == nil {
+		t.Error("Expected error for invalid path")
+	}
+}
+
+func TestNewLoggerJSON(t *testing.T) {
+	if lgr, err := Newlogger(MetaJSON, "node1"); err != nil {
+		t.Error(err)
+	} else if jl, canCast := lgr.(*JSONLogger); !canCast {
+		t.Errorf("Expected *JSONLogger, received %T", lgr)
+	} else if jl.w != os.Stdout {
+		t.Error("Expected logging to stdout")
+	} else if err = jl.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJSONLoggerSetLogLevelConcurrent(t *testing.T) {
+	jl := NewJSONLogger(ioutil.Discard, "node1")
+	jl.SetLogLevel(LOGLEVEL_WARNING)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jl.SetLogLevel(LOGLEVEL_INFO + i%2)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			jl.Info("<SessionS> logged depending on the level")
+		}
+	}()
+	wg.Wait()
+	jl.SetLogLevel(LOGLEVEL_DEBUG)
+	if lvl := jl.GetLogLevels().LogLevel; lvl != LOGLEVEL_DEBUG {
+		t.Errorf("Expected %v, received %v", LOGLEVEL_DEBUG, lvl)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"log"
 	syslog "log/syslog"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -324,3 +325,48 @@ func TestNewLoggerInvalidLoggerType(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", expected, err)
 	}
 }
+
+func TestStdLoggerSubsystemLogLevel(t *testing.T) {
+	output := new(bytes.Buffer)
+	log.SetOutput(output)
+	defer log.SetOutput(os.Stderr)
+
+	newLogger := &StdLogger{nodeID: "id_subsys"}
+	newLogger.SetLogLevel(LOGLEVEL_INFO)
+	newLogger.SetSubsystemLogLevel(SessionS, LOGLEVEL_DEBUG)
+	newLogger.Debug("<RALs> not logged")
+	newLogger.Debug("<SessionS> logged")
+	if rcv := output.String(); strings.Contains(rcv, "not logged") ||
+		!strings.Contains(rcv, "CGRateS <id_subsys> [DEBUG] <SessionS> logged") {
+		t.Errorf("Received: %q", rcv)
+	}
+	exp := &LogLevels{LogLevel: LOGLEVEL_INFO, Subsystems: map[string]int{SessionS: LOGLEVEL_DEBUG}}
+	if rcv := newLogger.GetLogLevels(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %+v, received %+v", exp, rcv)
+	}
+	newLogger.SetSubsystemLogLevel(SessionS, -1)
+	output.Reset()
+	newLogger.Debug("<SessionS> logged")
+	if rcv := output.String(); rcv != EmptyString {
+		t.Errorf("Received: %q", rcv)
+	}
+	newLogger.LogWithFields(LOGLEVEL_WARNING, "<CDRs> duplicate", LogFields{
+		LogFieldTenant: "cgrates.org",
+		LogFieldCGRID:  "cgrid1",
+	})
+	if exp, rcv := "[WARNING] <CDRs> duplicate cgrid=cgrid1 tenant=cgrates.org", output.String(); !strings.Contains(rcv, exp) {
+		t.Errorf("Expected %q, received %q", exp, rcv)
+	}
+}
+
+func TestLogSubsystem(t *testing.T) {
+	if subsys, msg := LogSubsystem("<SessionS> error: <NOT_FOUND>"); subsys != SessionS || msg != "error: <NOT_FOUND>" {
+		t.Errorf("Received %q, %q", subsys, msg)
+	}
+	if subsys, msg := LogSubsystem("no subsystem"); subsys != EmptyString || msg != "no subsystem" {
+		t.Errorf("Received %q, %q", subsys, msg)
+	}
+	if subsys, msg := LogSubsystem("<unterminated"); subsys != EmptyString || msg != "<unterminated" {
+		t.Errorf("Received %q, %q", subsys, msg)
+	}
+}
@@ -0,0 +1,347 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// span kinds as defined by OpenTelemetry
+const (
+	SpanKindInternal = 1
+	SpanKindServer   = 2
+	SpanKindClient   = 3
+)
+
+const (
+	traceParentSep     = "-"
+	traceParentVersion = "00"
+	traceFlagSampled   = "01"
+	tracesBatchSize    = 512
+	tracesQueueSize    = 4096
+)
+
+var (
+	tracer    *Tracer
+	tracerMux sync.RWMutex
+
+	optsType = reflect.TypeOf(map[string]interface{}{})
+)
+
+// SetTracer sets the tracer used by the engine, nil disables the tracing
+func SetTracer(t *Tracer) {
+	tracerMux.Lock()
+	tracer = t
+	tracerMux.Unlock()
+}
+
+// getTracer returns the tracer in use
+func getTracer() (t *Tracer) {
+	tracerMux.RLock()
+	t = tracer
+	tracerMux.RUnlock()
+	return
+}
+
+// TracingEnabled returns true if the spans are exported
+func TracingEnabled() bool {
+	return getTracer() != nil
+}
+
+// SpanExporter sends the finished spans to their destination
+type SpanExporter interface {
+	ExportSpans(nodeID string, spans []*Span) error
+	Close() error
+}
+
+// NewTracer starts a tracer exporting the spans in batches, at each interval
+func NewTracer(exp SpanExporter, nodeID string, interval time.Duration) (t *Tracer) {
+	t = &Tracer{
+		exp:    exp,
+		nodeID: nodeID,
+		spans:  make(chan *Span, tracesQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go t.loop(interval)
+	return
+}
+
+// Tracer collects the finished spans and exports them
+type Tracer struct {
+	exp    SpanExporter
+	nodeID string
+	spans  chan *Span
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func (t *Tracer) loop(interval time.Duration) {
+	defer close(t.done)
+	tkr := time.NewTicker(interval)
+	defer tkr.Stop()
+	batch := make([]*Span, 0, tracesBatchSize)
+	export := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.exp.ExportSpans(t.nodeID, batch); err != nil {
+			Logger.Warning(fmt.Sprintf("<%s> failed exporting %d spans, error: %s",
+				CoreS, len(batch), err.Error()))
+		}
+		batch = make([]*Span, 0, tracesBatchSize)
+	}
+	for {
+		select {
+		case s := <-t.spans:
+			if batch = append(batch, s); len(batch) == tracesBatchSize {
+				export()
+			}
+		case <-tkr.C:
+			export()
+		case <-t.stop:
+			for {
+				select {
+				case s := <-t.spans:
+					batch = append(batch, s)
+				default:
+					export()
+					return
+				}
+			}
+		}
+	}
+}
+
+// export queues the span, dropping it if the exporter cannot keep up
+func (t *Tracer) export(s *Span) {
+	select {
+	case t.spans <- s:
+	default:
+	}
+}
+
+// Close exports the queued spans and closes the exporter
+func (t *Tracer) Close() error {
+	close(t.stop)
+	<-t.done
+	return t.exp.Close()
+}
+
+// StartSpan starts a span as child of the traceparent
+// a new trace is started for invalid or empty traceparent
+// returns nil if the tracing is disabled
+func StartSpan(traceParent, name string, kind int) (s *Span) {
+	t := getTracer()
+	if t == nil {
+		return
+	}
+	s = &Span{
+		Name:      name,
+		Kind:      kind,
+		StartTime: time.Now(),
+		tracer:    t,
+	}
+	var has bool
+	if s.TraceID, s.ParentSpanID, has = ParseTraceParent(traceParent); !has {
+		s.TraceID = newTraceID(16)
+	}
+	s.SpanID = newTraceID(8)
+	return
+}
+
+// Span is a timed operation within a trace
+type Span struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+	Name         string
+	Kind         int
+	StartTime    time.Time
+	EndTime      time.Time
+	Attributes   map[string]interface{}
+	Error        string
+
+	tracer *Tracer
+}
+
+// TraceParent returns the span context in the W3C traceparent format
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return EmptyString
+	}
+	return traceParentVersion + traceParentSep + s.TraceID + traceParentSep +
+		s.SpanID + traceParentSep + traceFlagSampled
+}
+
+// SetAttribute adds an attribute to the span
+func (s *Span) SetAttribute(key string, val interface{}) {
+	if s == nil {
+		return
+	}
+	if s.Attributes == nil {
+		s.Attributes = make(map[string]interface{})
+	}
+	s.Attributes[key] = val
+}
+
+// End finishes the span and queues it for export
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.EndTime = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.tracer.export(s)
+}
+
+// ParseTraceParent returns the trace and parent span IDs out of a W3C traceparent
+func ParseTraceParent(traceParent string) (traceID, spanID string, valid bool) {
+	flds := strings.Split(traceParent, traceParentSep)
+	if len(flds) != 4 ||
+		len(flds[0]) != 2 || flds[0] == "ff" ||
+		!isTraceID(flds[1], 32) ||
+		!isTraceID(flds[2], 16) ||
+		len(flds[3]) != 2 {
+		return
+	}
+	return flds[1], flds[2], true
+}
+
+// isTraceID checks for a lowercase hex ID of the given length, not all zeroes
+func isTraceID(id string, size int) bool {
+	if len(id) != size ||
+		strings.ToLower(id) != id ||
+		strings.Trim(id, "0") == EmptyString {
+		return false
+	}
+	_, err := hex.DecodeString(id)
+	return err == nil
+}
+
+func newTraceID(size int) string {
+	b := make([]byte, size)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// StartRPCSpan starts a span for the API call, child of the traceparent in the options of args
+// the returned args are a copy of the original having the new span as traceparent
+func StartRPCSpan(args interface{}, method string, kind int) (s *Span, newArgs interface{}) {
+	newArgs = args
+	if !TracingEnabled() {
+		return
+	}
+	s = StartSpan(IfaceAsString(argsOpt(args, OptsTraceParent)), method, kind)
+	s.SetAttribute(TraceRPCSystem, CGRateSLwr)
+	if splt := strings.SplitN(method, NestingSep, 2); len(splt) == 2 {
+		s.SetAttribute(TraceRPCService, splt[0])
+		s.SetAttribute(TraceRPCMethod, splt[1])
+	}
+	newArgs = argsWithOpt(args, OptsTraceParent, s.TraceParent())
+	return
+}
+
+// optsField walks the struct pointed by v to the Opts field
+// with clone the structures on the path are copied so the original stays unchanged
+func optsField(v reflect.Value, clone bool) (root, opts reflect.Value, has bool) {
+	if v.Kind() != reflect.Ptr || v.IsNil() ||
+		v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	sf, has := v.Elem().Type().FieldByName(Opts)
+	if !has || sf.Type != optsType {
+		return root, opts, false
+	}
+	if clone {
+		cln := reflect.New(v.Elem().Type())
+		cln.Elem().Set(v.Elem())
+		v = cln
+	}
+	root = v
+	cur := v.Elem()
+	for _, idx := range sf.Index[:len(sf.Index)-1] {
+		fld := cur.Field(idx)
+		if fld.Kind() != reflect.Ptr {
+			cur = fld
+			continue
+		}
+		if fld.IsNil() {
+			return root, opts, false
+		}
+		if clone {
+			if !fld.CanSet() {
+				return root, opts, false
+			}
+			cln := reflect.New(fld.Type().Elem())
+			cln.Elem().Set(fld.Elem())
+			fld.Set(cln)
+		}
+		cur = fld.Elem()
+	}
+	opts = cur.Field(sf.Index[len(sf.Index)-1])
+	return root, opts, opts.CanSet()
+}
+
+// argsOpt returns the option out of the Opts field of args
+func argsOpt(args interface{}, key string) (val interface{}) {
+	_, opts, has := optsField(reflect.ValueOf(args), false)
+	if !has || opts.IsNil() {
+		return
+	}
+	return opts.Interface().(map[string]interface{})[key]
+}
+
+// argsWithOpt returns a copy of args with the option set
+// args are returned unchanged if they have no Opts field
+func argsWithOpt(args interface{}, key string, val interface{}) interface{} {
+	root, opts, has := optsField(reflect.ValueOf(args), true)
+	if !has {
+		return args
+	}
+	oldOpts, _ := opts.Interface().(map[string]interface{})
+	newOpts := make(map[string]interface{}, len(oldOpts)+1)
+	for k, v := range oldOpts {
+		newOpts[k] = v
+	}
+	newOpts[key] = val
+	opts.Set(reflect.ValueOf(newOpts))
+	return root.Interface()
+}
+
+// SetArgsOpt sets the option in the Opts field of args, creating the map if needed
+func SetArgsOpt(args interface{}, key string, val interface{}) bool {
+	_, opts, has := optsField(reflect.ValueOf(args), false)
+	if !has {
+		return false
+	}
+	if opts.IsNil() {
+		opts.Set(reflect.MakeMap(optsType))
+	}
+	opts.Interface().(map[string]interface{})[key] = val
+	return true
+}
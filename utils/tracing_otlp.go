@@ -0,0 +1,235 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const otlpStatusError = 2
+
+// OTLP/JSON encoding of the ExportTraceServiceRequest
+type otlpTraceRequest struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []*otlpKeyValue `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// otlpValue encodes the attribute value as OTLP AnyValue
+func otlpValue(val interface{}) map[string]interface{} {
+	switch v := val.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": v}
+	case bool:
+		return map[string]interface{}{"boolValue": v}
+	case int:
+		return map[string]interface{}{"intValue": strconv.Itoa(v)}
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		return map[string]interface{}{"doubleValue": v}
+	case []string:
+		vals := make([]map[string]interface{}, len(v))
+		for i, s := range v {
+			vals[i] = otlpValue(s)
+		}
+		return map[string]interface{}{"arrayValue": map[string]interface{}{"values": vals}}
+	default:
+		return map[string]interface{}{"stringValue": IfaceAsString(v)}
+	}
+}
+
+func otlpAttributes(attrs map[string]interface{}) (kvs []*otlpKeyValue) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs = make([]*otlpKeyValue, len(keys))
+	for i, k := range keys {
+		kvs[i] = &otlpKeyValue{Key: k, Value: otlpValue(attrs[k])}
+	}
+	return
+}
+
+func otlpTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// newOTLPTraceRequest builds the export request for the spans of one node
+func newOTLPTraceRequest(nodeID string, spans []*Span) *otlpTraceRequest {
+	oSpans := make([]*otlpSpan, len(spans))
+	for i, s := range spans {
+		oSpans[i] = &otlpSpan{
+			TraceID:           s.TraceID,
+			SpanID:            s.SpanID,
+			ParentSpanID:      s.ParentSpanID,
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: otlpTime(s.StartTime),
+			EndTimeUnixNano:   otlpTime(s.EndTime),
+			Attributes:        otlpAttributes(s.Attributes),
+		}
+		if s.Error != EmptyString {
+			oSpans[i].Status = &otlpStatus{Code: otlpStatusError, Message: s.Error}
+		}
+	}
+	return &otlpTraceRequest{
+		ResourceSpans: []*otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: otlpAttributes(map[string]interface{}{
+					TraceServiceName:     CGRateSLwr,
+					TraceServiceInstance: nodeID,
+				}),
+			},
+			ScopeSpans: []*otlpScopeSpans{{
+				Scope: otlpScope{Name: CGRateSLwr, Version: Version},
+				Spans: oSpans,
+			}},
+		}},
+	}
+}
+
+// NewOTLPFileExporter appends the spans to the file at path, one OTLP/JSON request per line
+func NewOTLPFileExporter(path string) (exp *OTLPFileExporter, err error) {
+	var fl *os.File
+	if fl, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644); err != nil {
+		return
+	}
+	return &OTLPFileExporter{w: fl}, nil
+}
+
+// OTLPFileExporter writes the spans in the format read by the OpenTelemetry Collector file receiver
+type OTLPFileExporter struct {
+	sync.Mutex
+	w io.WriteCloser
+}
+
+// ExportSpans writes the spans as one line
+func (fe *OTLPFileExporter) ExportSpans(nodeID string, spans []*Span) (err error) {
+	var b []byte
+	if b, err = json.Marshal(newOTLPTraceRequest(nodeID, spans)); err != nil {
+		return
+	}
+	fe.Lock()
+	_, err = fe.w.Write(append(b, '\n'))
+	fe.Unlock()
+	return
+}
+
+// Close closes the file
+func (fe *OTLPFileExporter) Close() error {
+	return fe.w.Close()
+}
+
+// NewOTLPHTTPExporter posts the spans to an OTLP/HTTP collector, ie: http://127.0.0.1:4318/v1/traces
+func NewOTLPHTTPExporter(url string, timeout time.Duration) *OTLPHTTPExporter {
+	return &OTLPHTTPExporter{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// OTLPHTTPExporter sends the spans to a collector using OTLP/HTTP with JSON encoding
+type OTLPHTTPExporter struct {
+	url    string
+	client *http.Client
+}
+
+// ExportSpans posts the spans to the collector
+func (he *OTLPHTTPExporter) ExportSpans(nodeID string, spans []*Span) (err error) {
+	var b []byte
+	if b, err = json.Marshal(newOTLPTraceRequest(nodeID, spans)); err != nil {
+		return
+	}
+	var resp *http.Response
+	if resp, err = he.client.Post(he.url, "application/json", bytes.NewReader(b)); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code from collector: %d", resp.StatusCode)
+	}
+	return
+}
+
+// Close has nothing to release for the HTTP exporter
+func (he *OTLPHTTPExporter) Close() error { return nil }
+
+// NewSpanExporter returns the exporter based on its type
+func NewSpanExporter(expType, endpoint string, timeout time.Duration) (SpanExporter, error) {
+	switch expType {
+	case MetaFile:
+		return NewOTLPFileExporter(endpoint)
+	case MetaHTTP:
+		return NewOTLPHTTPExporter(endpoint, timeout), nil
+	default:
+		return nil, fmt.Errorf("unsupported traces exporter: <%s>", expType)
+	}
+}
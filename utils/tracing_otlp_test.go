@@ -0,0 +1,139 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testOTLPSpans() []*Span {
+	return []*Span{{
+		TraceID:      "0af7651916cd43dd8448eb211c80319c",
+		SpanID:       "b7ad6b7169203331",
+		ParentSpanID: "00f067aa0ba902b7",
+		Name:         AttributeSv1ProcessEvent,
+		Kind:         SpanKindClient,
+		StartTime:    time.Unix(0, 1600000000000000000),
+		EndTime:      time.Unix(0, 1600000000000001000),
+		Attributes: map[string]interface{}{
+			TraceRPCSystem: CGRateSLwr,
+			TraceConnIDs:   []string{"*internal"},
+			"cgr.runs":     2,
+			"cgr.cost":     0.5,
+			"cgr.cached":   true,
+		},
+		Error: "NOT_FOUND",
+	}}
+}
+
+var testOTLPRequest = `{"resourceSpans":[{"resource":{"attributes":[{"key":"service.instance.id","value":{"stringValue":"node1"}},{"key":"service.name","value":{"stringValue":"cgrates"}}]},"scopeSpans":[{"scope":{"name":"cgrates","version":"` + Version + `"},"spans":[{"traceId":"0af7651916cd43dd8448eb211c80319c","spanId":"b7ad6b7169203331","parentSpanId":"00f067aa0ba902b7","name":"AttributeSv1.ProcessEvent","kind":3,"startTimeUnixNano":"1600000000000000000","endTimeUnixNano":"1600000000000001000","attributes":[{"key":"cgr.cached","value":{"boolValue":true}},{"key":"cgr.conn_ids","value":{"arrayValue":{"values":[{"stringValue":"*internal"}]}}},{"key":"cgr.cost","value":{"doubleValue":0.5}},{"key":"cgr.runs","value":{"intValue":"2"}},{"key":"rpc.system","value":{"stringValue":"cgrates"}}],"status":{"code":2,"message":"NOT_FOUND"}}]}]}]}`
+
+func TestNewOTLPTraceRequest(t *testing.T) {
+	b, err := json.Marshal(newOTLPTraceRequest("node1", testOTLPSpans()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != testOTLPRequest {
+		t.Errorf("Expected %s\n, received %s", testOTLPRequest, string(b))
+	}
+}
+
+func TestOTLPFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	exp, err := NewSpanExporter(MetaFile, path, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = exp.ExportSpans("node1", testOTLPSpans()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = exp.Close(); err != nil {
+		t.Fatal(err)
+	}
+	rcv, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expLines := testOTLPRequest + "\n" + testOTLPRequest + "\n"; string(rcv) != expLines {
+		t.Errorf("Expected %s, received %s", expLines, string(rcv))
+	}
+	if _, err = NewOTLPFileExporter(filepath.Join(path, "traces.json")); err == nil {
+		t.Error("Expected error for invalid path")
+	}
+}
+
+func TestOTLPHTTPExporter(t *testing.T) {
+	var rcv []byte
+	var contentType string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		rcv, _ = ioutil.ReadAll(r.Body)
+		if r.URL.Path != "/v1/traces" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	exp, err := NewSpanExporter(MetaHTTP, srv.URL+"/v1/traces", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = exp.ExportSpans("node1", testOTLPSpans()); err != nil {
+		t.Fatal(err)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Expected application/json, received %q", contentType)
+	}
+	if !bytes.Equal(rcv, []byte(testOTLPRequest)) {
+		t.Errorf("Expected %s, received %s", testOTLPRequest, string(rcv))
+	}
+	if err = exp.Close(); err != nil {
+		t.Error(err)
+	}
+	exp = NewOTLPHTTPExporter(srv.URL, time.Second)
+	expErr := "unexpected status code from collector: 404"
+	if err = exp.ExportSpans("node1", testOTLPSpans()); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+}
+
+func TestNewSpanExporterUnsupported(t *testing.T) {
+	expErr := "unsupported traces exporter: <*grpc>"
+	if _, err := NewSpanExporter("*grpc", EmptyString, time.Second); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %s, received %v", expErr, err)
+	}
+}
+
+func TestOTLPValue(t *testing.T) {
+	if rcv := otlpValue(int64(3)); !reflect.DeepEqual(map[string]interface{}{"intValue": "3"}, rcv) {
+		t.Errorf("Received %+v", rcv)
+	}
+	if rcv := otlpValue(time.Second); !reflect.DeepEqual(map[string]interface{}{"stringValue": "1s"}, rcv) {
+		t.Errorf("Received %+v", rcv)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSpanExporter struct {
+	sync.Mutex
+	nodeID string
+	spans  []*Span
+	closed bool
+}
+
+func (te *testSpanExporter) ExportSpans(nodeID string, spans []*Span) error {
+	te.Lock()
+	te.nodeID = nodeID
+	te.spans = append(te.spans, spans...)
+	te.Unlock()
+	return nil
+}
+
+func (te *testSpanExporter) Close() error {
+	te.closed = true
+	return nil
+}
+
+// testTracer enables the tracing for the duration of the test
+func testTracer(t *testing.T) (*Tracer, *testSpanExporter) {
+	exp := new(testSpanExporter)
+	tr := NewTracer(exp, "node1", time.Hour)
+	SetTracer(tr)
+	t.Cleanup(func() { SetTracer(nil) })
+	return tr, exp
+}
+
+func TestParseTraceParent(t *testing.T) {
+	if trID, spID, valid := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"); !valid {
+		t.Error("Expected valid traceparent")
+	} else if trID != "0af7651916cd43dd8448eb211c80319c" {
+		t.Errorf("Expected trace ID 0af7651916cd43dd8448eb211c80319c, received %q", trID)
+	} else if spID != "b7ad6b7169203331" {
+		t.Errorf("Expected span ID b7ad6b7169203331, received %q", spID)
+	}
+	for _, tp := range []string{
+		EmptyString,
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331",
+		"ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+		"00-00000000000000000000000000000000-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-0000000000000000-01",
+		"00-0af7651916cd43dd8448eb211c8031zz-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd-b7ad6b7169203331-01",
+	} {
+		if _, _, valid := ParseTraceParent(tp); valid {
+			t.Errorf("Expected invalid traceparent: %q", tp)
+		}
+	}
+}
+
+func TestStartSpanDisabled(t *testing.T) {
+	SetTracer(nil)
+	if TracingEnabled() {
+		t.Error("Expected tracing disabled")
+	}
+	if s := StartSpan(EmptyString, CoreSv1Ping, SpanKindServer); s != nil {
+		t.Errorf("Expected nil span, received %+v", s)
+	}
+	args := &CGREvent{Tenant: "cgrates.org"}
+	if s, newArgs := StartRPCSpan(args, CoreSv1Ping, SpanKindClient); s != nil {
+		t.Errorf("Expected nil span, received %+v", s)
+	} else if newArgs != args {
+		t.Errorf("Expected the same args, received %+v", newArgs)
+	}
+	// nil spans are safe to use
+	var s *Span
+	s.SetAttribute(TraceTenant, "cgrates.org")
+	s.End(nil)
+	if tp := s.TraceParent(); tp != EmptyString {
+		t.Errorf("Expected empty traceparent, received %q", tp)
+	}
+}
+
+func TestStartSpan(t *testing.T) {
+	tr, exp := testTracer(t)
+	parent := StartSpan(EmptyString, "parent", SpanKindServer)
+	if _, _, valid := ParseTraceParent(parent.TraceParent()); !valid {
+		t.Errorf("Invalid traceparent: %q", parent.TraceParent())
+	}
+	if parent.ParentSpanID != EmptyString {
+		t.Errorf("Expected root span, received parent %q", parent.ParentSpanID)
+	}
+	child := StartSpan(parent.TraceParent(), "child", SpanKindClient)
+	if child.TraceID != parent.TraceID {
+		t.Errorf("Expected trace %q, received %q", parent.TraceID, child.TraceID)
+	}
+	if child.ParentSpanID != parent.SpanID {
+		t.Errorf("Expected parent %q, received %q", parent.SpanID, child.ParentSpanID)
+	}
+	child.SetAttribute(TraceTenant, "cgrates.org")
+	child.End(errors.New("NOT_FOUND"))
+	parent.End(nil)
+	if err := tr.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !exp.closed {
+		t.Error("Expected the exporter closed")
+	}
+	if exp.nodeID != "node1" {
+		t.Errorf("Expected node1, received %q", exp.nodeID)
+	}
+	if len(exp.spans) != 2 {
+		t.Fatalf("Expected 2 spans, received %s", ToJSON(exp.spans))
+	}
+	if exp.spans[0] != child || exp.spans[1] != parent {
+		t.Errorf("Unexpected spans: %s", ToJSON(exp.spans))
+	}
+	if child.Error != "NOT_FOUND" {
+		t.Errorf("Expected NOT_FOUND, received %q", child.Error)
+	}
+	if child.EndTime.Before(child.StartTime) {
+		t.Errorf("End time %v before start time %v", child.EndTime, child.StartTime)
+	}
+	if expAttrs := map[string]interface{}{TraceTenant: "cgrates.org"}; !reflect.DeepEqual(expAttrs, child.Attributes) {
+		t.Errorf("Expected %+v, received %+v", expAttrs, child.Attributes)
+	}
+}
+
+func TestStartRPCSpan(t *testing.T) {
+	testTracer(t)
+	parent := StartSpan(EmptyString, "parent", SpanKindServer)
+	type argsWithEvent struct {
+		IDs []string
+		*CGREvent
+	}
+	ev := &CGREvent{
+		Tenant: "cgrates.org",
+		Opts:   map[string]interface{}{OptsTraceParent: parent.TraceParent(), OptsRouteID: "route1"},
+	}
+	args := &argsWithEvent{IDs: []string{"ID1"}, CGREvent: ev}
+	s, newArgs := StartRPCSpan(args, AttributeSv1ProcessEvent, SpanKindClient)
+	if s.TraceID != parent.TraceID || s.ParentSpanID != parent.SpanID {
+		t.Errorf("Expected child of %+v, received %+v", parent, s)
+	}
+	expAttrs := map[string]interface{}{
+		TraceRPCSystem:  CGRateSLwr,
+		TraceRPCService: AttributeSv1,
+		TraceRPCMethod:  "ProcessEvent",
+	}
+	if !reflect.DeepEqual(expAttrs, s.Attributes) {
+		t.Errorf("Expected %+v, received %+v", expAttrs, s.Attributes)
+	}
+	rcv, canCast := newArgs.(*argsWithEvent)
+	if !canCast {
+		t.Fatalf("Expected *argsWithEvent, received %T", newArgs)
+	}
+	if rcv == args || rcv.CGREvent == ev {
+		t.Error("Expected the args copied")
+	}
+	expOpts := map[string]interface{}{OptsTraceParent: s.TraceParent(), OptsRouteID: "route1"}
+	if !reflect.DeepEqual(expOpts, rcv.Opts) {
+		t.Errorf("Expected %+v, received %+v", expOpts, rcv.Opts)
+	}
+	if ev.Opts[OptsTraceParent] != parent.TraceParent() {
+		t.Errorf("Original options modified: %+v", ev.Opts)
+	}
+	if !reflect.DeepEqual(args.IDs, rcv.IDs) || rcv.Tenant != ev.Tenant {
+		t.Errorf("Expected %s, received %s", ToJSON(args), ToJSON(rcv))
+	}
+	// args without options are passed unchanged
+	ids := []string{"ID1"}
+	if s, newArgs := StartRPCSpan(ids, CoreSv1Ping, SpanKindClient); s == nil {
+		t.Error("Expected a new trace")
+	} else if !reflect.DeepEqual(ids, newArgs) {
+		t.Errorf("Expected %+v, received %+v", ids, newArgs)
+	}
+	noEv := &argsWithEvent{} // nil embedded event
+	if _, newArgs := StartRPCSpan(noEv, CoreSv1Ping, SpanKindClient); newArgs != noEv {
+		t.Errorf("Expected the same args, received %+v", newArgs)
+	}
+}
+
+func TestSetArgsOpt(t *testing.T) {
+	args := &TenantWithOpts{Tenant: "cgrates.org"}
+	if !SetArgsOpt(args, OptsTraceParent, "tp1") {
+		t.Error("Expected the option set")
+	}
+	if exp := map[string]interface{}{OptsTraceParent: "tp1"}; !reflect.DeepEqual(exp, args.Opts) {
+		t.Errorf("Expected %+v, received %+v", exp, args.Opts)
+	}
+	if SetArgsOpt(TenantWithOpts{}, OptsTraceParent, "tp1") {
+		t.Error("Expected the option not set on value")
+	}
+	if SetArgsOpt(&TenantID{Tenant: "cgrates.org"}, OptsTraceParent, "tp1") {
+		t.Error("Expected the option not set without Opts")
+	}
+	if SetArgsOpt(nil, OptsTraceParent, "tp1") {
+		t.Error("Expected the option not set on nil")
+	}
+}